@@ -0,0 +1,235 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gofin/internal/cases/create_account"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+var (
+	accountProjectSlug string
+	accountName        string
+	accountCurrency    string
+	accountType        string
+	accountDescription string
+	accountCreditLimit float64
+	accountClosingDay  int
+	accountDueDay      int
+	accountShowAll     bool
+	accountRestore     bool
+)
+
+var accountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Manage project accounts",
+	Long:  `List, create and archive the accounts of a project.`,
+}
+
+var accountListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the accounts of a project",
+	Long:  `List the accounts of a project in display order. Archived accounts are shown with --all.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listAccounts(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+var accountCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new account in a project",
+	Long:  `Create a new account with a name, currency and type. The account is added at the end of the display order.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := createAccount(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+var accountArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Archive or restore an account",
+	Long:  `Hide an account from the transaction forms while keeping its history. Use --restore to bring it back.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := archiveAccount(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	accountCmd.PersistentFlags().StringVarP(&accountProjectSlug, "project", "p", "", "Project slug (required)")
+	accountCmd.MarkPersistentFlagRequired("project")
+
+	accountListCmd.Flags().BoolVarP(&accountShowAll, "all", "a", false, "Include archived accounts")
+
+	accountCreateCmd.Flags().StringVarP(&accountName, "name", "n", "", "Account name (required)")
+	accountCreateCmd.Flags().StringVarP(&accountCurrency, "currency", "c", money.PLN.String(), "Account currency")
+	accountCreateCmd.Flags().StringVarP(&accountType, "type", "t", models.AccountChecking.String(), "Account type: cash, checking, savings, credit_card, investment or loan")
+	accountCreateCmd.Flags().StringVarP(&accountDescription, "description", "d", "", "Account description")
+	accountCreateCmd.Flags().Float64Var(&accountCreditLimit, "limit", 0, "Credit limit of a credit card")
+	accountCreateCmd.Flags().IntVar(&accountClosingDay, "statement-day", 0, "Day of the month a credit card statement closes")
+	accountCreateCmd.Flags().IntVar(&accountDueDay, "due-day", 0, "Day of the month a credit card payment is due")
+	accountCreateCmd.MarkFlagRequired("name")
+
+	accountArchiveCmd.Flags().StringVarP(&accountName, "name", "n", "", "Account name (required)")
+	accountArchiveCmd.Flags().BoolVarP(&accountRestore, "restore", "r", false, "Restore an archived account")
+	accountArchiveCmd.MarkFlagRequired("name")
+
+	accountCmd.AddCommand(accountListCmd)
+	accountCmd.AddCommand(accountCreateCmd)
+	accountCmd.AddCommand(accountArchiveCmd)
+}
+
+func listAccounts(ctx context.Context) error {
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.ProjectRepository.GetBySlug(ctx, accountProjectSlug)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	accounts, err := container.AccountRepository.GetByProjectID(ctx, project.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	if !accountShowAll {
+		accounts = models.ActiveAccounts(accounts)
+	}
+
+	if len(accounts) == 0 {
+		fmt.Printf("No accounts in project %s\n", project.Slug)
+		return nil
+	}
+
+	for _, account := range accounts {
+		status := ""
+		if account.IsArchived() {
+			status = " (archived)"
+		}
+		fmt.Printf("%s  %-12s %s  %s%s\n", account.Currency, account.Type.Label(), account.ID, account.Name, status)
+		if account.Description != "" {
+			fmt.Printf("     %s\n", account.Description)
+		}
+	}
+
+	return nil
+}
+
+func createAccount(ctx context.Context) error {
+	currency, err := money.ParseCurrency(accountCurrency)
+	if err != nil {
+		return err
+	}
+
+	parsedType, err := models.ParseAccountType(strings.ToLower(accountType))
+	if err != nil {
+		return err
+	}
+
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.ProjectRepository.GetBySlug(ctx, accountProjectSlug)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	account, err := container.CreateAccountService.CreateAccount(ctx, create_account.CreateAccountData{
+		ProjectID:    project.ID,
+		Name:         accountName,
+		Currency:     currency,
+		Type:         parsedType,
+		Description:  accountDescription,
+		CreditLimit:  accountCreditLimit,
+		StatementDay: accountClosingDay,
+		DueDay:       accountDueDay,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Account created successfully!\n")
+	fmt.Printf("   Project: %s\n", project.Slug)
+	fmt.Printf("   Name: %s\n", account.Name)
+	fmt.Printf("   Type: %s\n", account.Type.Label())
+	fmt.Printf("   Currency: %s\n", account.Currency)
+	if account.CreditLimit > 0 {
+		fmt.Printf("   Credit limit: %.2f\n", account.CreditLimit)
+	}
+	if account.HasStatements() {
+		fmt.Printf("   Statement closes on day: %d\n", account.StatementDay)
+	}
+	if account.DueDay > 0 {
+		fmt.Printf("   Payment due on day: %d\n", account.DueDay)
+	}
+	fmt.Printf("   ID: %s\n", account.ID)
+
+	return nil
+}
+
+func archiveAccount(ctx context.Context) error {
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	account, err := findAccountByName(ctx, container, accountProjectSlug, accountName)
+	if err != nil {
+		return err
+	}
+
+	if accountRestore {
+		if _, err := container.UpdateAccountService.UnarchiveAccount(ctx, account.ProjectID, account.ID); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Account restored: %s\n", account.Name)
+		return nil
+	}
+
+	if _, err := container.UpdateAccountService.ArchiveAccount(ctx, account.ProjectID, account.ID); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Account archived: %s\n", account.Name)
+
+	return nil
+}
+
+func findAccountByName(ctx context.Context, container *container.Container, projectSlug, name string) (*models.Account, error) {
+	project, err := container.ProjectRepository.GetBySlug(ctx, projectSlug)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	accounts, err := container.AccountRepository.GetByProjectID(ctx, project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	for _, account := range accounts {
+		if strings.EqualFold(account.Name, strings.TrimSpace(name)) {
+			return account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("account '%s' not found in project %s", name, projectSlug)
+}
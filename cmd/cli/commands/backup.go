@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gofin/internal/cases/restore_project"
+	"gofin/pkg/config"
+)
+
+var (
+	backupProjectSlug string
+	backupOutput      string
+	restoreSlug       string
+	restoreName       string
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up a project to a JSON archive",
+	Long: `Write a project with its settings, accesses, accounts, categories, payees, transactions and splits
+to a versioned JSON archive carrying a checksum of its contents. Accesses keep only their PIN hash;
+TOTP secrets and recovery codes are left out, so accesses using a second factor enroll again after
+a restore. Loans, investments, shared expenses, reconciliations and attachments are not included.
+
+The archive is written to --output, or to <slug>-<date>.json in the current directory.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runBackup(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore FILE",
+	Short: "Restore a project from a backup archive",
+	Long: `Recreate a project from an archive written by gofin backup, into the configured database. Every
+record gets a new ID, so an archive can be restored into the database it came from under another
+--slug, or into a different database with --db-path.
+
+The archive's checksum and references are checked before anything is written, and the balances
+of the restored accounts are compared with the archive afterwards.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runRestore(cmd.Context(), args[0]); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	backupCmd.Flags().StringVarP(&backupProjectSlug, "project", "p", "", "Project slug (required)")
+	backupCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "File to write")
+	backupCmd.MarkFlagRequired("project")
+
+	restoreCmd.Flags().StringVarP(&restoreSlug, "slug", "s", "", "Slug of the restored project, the archived one by default")
+	restoreCmd.Flags().StringVarP(&restoreName, "name", "n", "", "Name of the restored project, the archived one by default")
+}
+
+func runBackup(ctx context.Context) error {
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.ProjectRepository.GetBySlug(ctx, backupProjectSlug)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	path := backupOutput
+	if path == "" {
+		path = fmt.Sprintf("%s-%s.json", project.Slug, time.Now().Format(config.DateFormat))
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create backup file: %w", err)
+	}
+
+	archive, err := container.BackupProjectService.Backup(ctx, file, project.ID)
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write backup file: %w", err)
+	}
+
+	fmt.Printf("✅ Backed up project %s to %s\n", project.Slug, path)
+	fmt.Printf("   %d accounts, %d transactions, %d accesses\n", archive.Counts.Accounts, archive.Counts.Transactions, archive.Counts.Accesses)
+	return nil
+}
+
+func runRestore(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open backup file: %w", err)
+	}
+	defer file.Close()
+
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	result, err := container.RestoreProjectService.Restore(ctx, file, restore_project.RestoreOptions{
+		Slug: restoreSlug,
+		Name: restoreName,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Restored project %s (%s)\n", result.Project.Name, result.Project.Slug)
+	fmt.Printf("   %d accounts, %d transactions, %d accesses\n", result.Counts.Accounts, result.Counts.Transactions, result.Counts.Accesses)
+	if result.TwoFactorReset > 0 {
+		fmt.Printf("   %d accesses have to set up two-factor authentication again\n", result.TwoFactorReset)
+	}
+	return nil
+}
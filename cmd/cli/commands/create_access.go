@@ -1,10 +1,10 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"gofin/internal/container"
 )
 
 var (
@@ -19,7 +19,7 @@ var createAccessCmd = &cobra.Command{
 	Long:  `Create a new access credential with auto-generated UID and PIN for a project.`,
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := createAccess(); err != nil {
+		if err := createAccess(cmd.Context()); err != nil {
 			exitWithError(err)
 		}
 	},
@@ -33,7 +33,7 @@ func init() {
 	createAccessCmd.MarkFlagRequired("name")
 }
 
-func createAccess() error {
+func createAccess(ctx context.Context) error {
 	if accessProjectSlug == "" {
 		return fmt.Errorf("project slug is required")
 	}
@@ -42,13 +42,13 @@ func createAccess() error {
 		return fmt.Errorf("name is required")
 	}
 
-	container, err := container.NewContainerWithDefaultConfig()
+	container, err := newContainer()
 	if err != nil {
 		return fmt.Errorf("failed to initialize container: %w", err)
 	}
 	defer container.DB.Close()
 
-	access, plainPIN, err := container.CreateAccessService.CreateAccess(accessProjectSlug, accessName, accessReadonly)
+	access, plainPIN, err := container.CreateAccessService.CreateAccess(ctx, accessProjectSlug, accessName, accessReadonly)
 	if err != nil {
 		return err
 	}
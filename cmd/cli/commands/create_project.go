@@ -1,10 +1,10 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"gofin/internal/container"
 )
 
 var (
@@ -18,7 +18,7 @@ var createProjectCmd = &cobra.Command{
 	Long:  `Create a new financial project with a unique slug and name.`,
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := createProject(); err != nil {
+		if err := createProject(cmd.Context()); err != nil {
 			exitWithError(err)
 		}
 	},
@@ -31,14 +31,14 @@ func init() {
 	createProjectCmd.MarkFlagRequired("name")
 }
 
-func createProject() error {
-	container, err := container.NewContainerWithDefaultConfig()
+func createProject(ctx context.Context) error {
+	container, err := newContainer()
 	if err != nil {
 		return fmt.Errorf("failed to initialize container: %w", err)
 	}
 	defer container.DB.Close()
 
-	project, err := container.CreateProjectService.CreateProject(projectName, projectSlug)
+	project, err := container.CreateProjectService.CreateProject(ctx, projectName, projectSlug)
 	if err != nil {
 		return err
 	}
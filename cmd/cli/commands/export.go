@@ -0,0 +1,191 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"gofin/internal/cases/get_reports"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	"gofin/pkg/export"
+)
+
+const (
+	exportTransactions = "transactions"
+	exportBalances     = "balances"
+	exportReport       = "report"
+	exportStdout       = "-"
+)
+
+var (
+	exportProjectSlug string
+	exportFormat      string
+	exportData        string
+	exportOutput      string
+	exportFrom        string
+	exportTo          string
+	exportAccounts    []string
+	exportSearch      string
+	exportType        string
+	exportCurrency    string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export transactions, balances or reports",
+	Long: `Export the transactions of a project, the balances of its accounts or its reports to CSV, XLSX
+or PDF. Transactions can be narrowed down with the same filters as the search page and are listed
+with signed amounts. Balances are taken at the end of --to, today by default, and reports cover
+--from to --to, the last twelve months by default.
+
+The file is written to --output, or to a name made of the project, the data and the format in the
+current directory. Use --output - to write to standard output.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runExport(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportProjectSlug, "project", "p", "", "Project slug (required)")
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "", "Export format: csv, xlsx or pdf (required)")
+	exportCmd.Flags().StringVarP(&exportData, "data", "d", exportTransactions, "What to export: transactions, balances or report")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "File to write, - for standard output")
+	exportCmd.Flags().StringVar(&exportFrom, "from", "", "First day to include (YYYY-MM-DD)")
+	exportCmd.Flags().StringVar(&exportTo, "to", "", "Last day to include (YYYY-MM-DD)")
+	exportCmd.Flags().StringSliceVarP(&exportAccounts, "account", "a", nil, "Only transactions of these account IDs")
+	exportCmd.Flags().StringVarP(&exportSearch, "search", "s", "", "Only transactions whose name or notes match")
+	exportCmd.Flags().StringVarP(&exportType, "type", "t", "", "Only debit or top-up transactions")
+	exportCmd.Flags().StringVarP(&exportCurrency, "currency", "c", "", "Currency of the report, the first account's by default")
+	exportCmd.MarkFlagRequired("project")
+	exportCmd.MarkFlagRequired("format")
+}
+
+func runExport(ctx context.Context) error {
+	format, err := export.ParseFormat(exportFormat)
+	if err != nil {
+		return err
+	}
+
+	from, err := parseExportDate("from", exportFrom)
+	if err != nil {
+		return err
+	}
+	to, err := parseExportDate("to", exportTo)
+	if err != nil {
+		return err
+	}
+
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.ProjectRepository.GetBySlug(ctx, exportProjectSlug)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	var render func(w io.Writer) error
+	switch exportData {
+	case exportTransactions:
+		query := models.TransactionQuery{
+			StartDate: from,
+			Search:    exportSearch,
+			SortBy:    models.SortByDate,
+		}
+		if to != nil {
+			end := to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			query.EndDate = &end
+		}
+		if exportType != "" {
+			query.Type, err = models.ParseTransactionType(exportType)
+			if err != nil {
+				return err
+			}
+		}
+		for _, account := range exportAccounts {
+			accountID, err := uuid.Parse(account)
+			if err != nil {
+				return fmt.Errorf("invalid account ID %q: %w", account, err)
+			}
+			query.AccountIDs = append(query.AccountIDs, accountID)
+		}
+		render = func(w io.Writer) error {
+			return container.ExportDataService.ExportTransactions(ctx, w, format, project.ID, query)
+		}
+	case exportBalances:
+		asOf := time.Now()
+		if to != nil {
+			asOf = *to
+		}
+		render = func(w io.Writer) error {
+			return container.ExportDataService.ExportBalances(ctx, w, format, project.ID, asOf)
+		}
+	case exportReport:
+		now := time.Now()
+		query := get_reports.ReportQuery{
+			ProjectID: project.ID,
+			From:      time.Date(now.Year(), now.Month()-11, 1, 0, 0, 0, 0, time.UTC),
+			To:        time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+			Currency:  exportCurrency,
+		}
+		if from != nil {
+			query.From = *from
+		}
+		if to != nil {
+			query.To = *to
+		}
+		render = func(w io.Writer) error {
+			return container.ExportDataService.ExportReport(ctx, w, format, query)
+		}
+	default:
+		return fmt.Errorf("invalid data %q, expected transactions, balances or report", exportData)
+	}
+
+	if exportOutput == exportStdout {
+		return render(os.Stdout)
+	}
+
+	path := exportOutput
+	if path == "" {
+		path = format.FileName(project.Slug + "-" + exportData)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	if err := render(file); err != nil {
+		file.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+
+	fmt.Printf("✅ Exported %s of project %s to %s\n", exportData, project.Slug, path)
+	return nil
+}
+
+func parseExportDate(flag, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(config.DateFormat, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s date %q, expected YYYY-MM-DD", flag, value)
+	}
+	return &date, nil
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"gofin/internal/cases/import_statement"
+	"gofin/pkg/config"
+)
+
+var (
+	importProjectSlug string
+	importAccount     string
+	importDryRun      bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Import an OFX, QFX or CAMT.053 bank statement",
+	Long: `Import the transactions of a bank statement downloaded as OFX, QFX or CAMT.053. Every statement
+in the file goes to --account, or else to the account whose name or description holds its account
+number, or to the only account in its currency.
+
+Transactions are recognized by the bank's ID, so importing the same or an overlapping statement
+again only adds what is new. Entries dated in a closed period are left out. Use --dry-run to list
+what would be imported without saving anything.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runImport(cmd.Context(), args[0]); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&importProjectSlug, "project", "p", "", "Project slug (required)")
+	importCmd.Flags().StringVarP(&importAccount, "account", "a", "", "ID of the account to import into")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Only list what would be imported")
+	importCmd.MarkFlagRequired("project")
+}
+
+func runImport(ctx context.Context, path string) error {
+	var accountID *uuid.UUID
+	if importAccount != "" {
+		parsed, err := uuid.Parse(importAccount)
+		if err != nil {
+			return fmt.Errorf("invalid account ID %q: %w", importAccount, err)
+		}
+		accountID = &parsed
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open statement file: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, import_statement.MaxStatementSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read statement file: %w", err)
+	}
+	if len(data) > import_statement.MaxStatementSize {
+		return fmt.Errorf("statement file is larger than %d MB", import_statement.MaxStatementSize>>20)
+	}
+
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.ProjectRepository.GetBySlug(ctx, importProjectSlug)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	preview, err := container.ImportStatementService.Preview(ctx, project.ID, data, accountID)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range preview.Statements {
+		fmt.Printf("%s (%s) into account %s\n", statement.AccountNumber, statement.Currency, statement.Account.Name)
+		for _, row := range statement.Rows {
+			status := "new"
+			if row.SkipReason != "" {
+				status = row.SkipReason
+			}
+			fmt.Printf("  %s  %12.2f  %-40s  %s\n", row.Data.TransactionDate.Format(config.DateFormat), row.Entry.Amount, row.Data.Name, status)
+		}
+	}
+
+	if importDryRun {
+		fmt.Printf("%d transactions would be imported\n", preview.NewRows())
+		return nil
+	}
+
+	transactions := preview.TransactionData()
+	if len(transactions) == 0 {
+		fmt.Println("Nothing new to import")
+		return nil
+	}
+
+	result, err := container.ImportStatementService.Import(ctx, project.ID, transactions)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Imported %d transactions into project %s\n", len(result.Created), project.Slug)
+	return nil
+}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+)
+
+var (
+	periodProjectSlug string
+	periodMonth       string
+	periodYear        int
+	periodUntil       string
+	periodAll         bool
+	periodActor       string
+	periodReason      string
+)
+
+var periodCmd = &cobra.Command{
+	Use:   "period",
+	Short: "Close and reopen accounting periods",
+	Long: `Close the books of a project up to the end of a month or year, so no transaction dated in a
+closed period can be created, changed or deleted. Reopening is reserved to administrators and is only
+available here.`,
+}
+
+var periodCloseCmd = &cobra.Command{
+	Use:   "close",
+	Short: "Close a month or a year",
+	Long:  `Close the books up to the end of --month (YYYY-MM) or --year. The period has to be over already.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := closePeriod(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+var periodReopenCmd = &cobra.Command{
+	Use:   "reopen",
+	Short: "Reopen closed periods",
+	Long: `Move the closing date back to --until (YYYY-MM-DD), or reopen every period with --all. The
+reason is kept in the audit trail together with who reopened.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := reopenPeriod(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+var periodLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the audit trail of closed periods",
+	Long:  `List every close and reopen of the project newest first.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showPeriodLog(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	periodCmd.PersistentFlags().StringVarP(&periodProjectSlug, "project", "p", "", "Project slug (required)")
+	periodCmd.MarkPersistentFlagRequired("project")
+
+	periodCloseCmd.Flags().StringVarP(&periodMonth, "month", "m", "", "Month to close (YYYY-MM)")
+	periodCloseCmd.Flags().IntVarP(&periodYear, "year", "y", 0, "Year to close")
+	periodCloseCmd.Flags().StringVar(&periodActor, "by", "", "Name of the person closing the period (required)")
+	periodCloseCmd.MarkFlagsOneRequired("month", "year")
+	periodCloseCmd.MarkFlagsMutuallyExclusive("month", "year")
+	periodCloseCmd.MarkFlagRequired("by")
+
+	periodReopenCmd.Flags().StringVarP(&periodUntil, "until", "u", "", "Last day to keep closed (YYYY-MM-DD)")
+	periodReopenCmd.Flags().BoolVarP(&periodAll, "all", "a", false, "Reopen every period")
+	periodReopenCmd.Flags().StringVar(&periodActor, "by", "", "Name of the administrator reopening the period (required)")
+	periodReopenCmd.Flags().StringVarP(&periodReason, "reason", "r", "", "Why the period is reopened (required)")
+	periodReopenCmd.MarkFlagsOneRequired("until", "all")
+	periodReopenCmd.MarkFlagsMutuallyExclusive("until", "all")
+	periodReopenCmd.MarkFlagRequired("by")
+	periodReopenCmd.MarkFlagRequired("reason")
+
+	periodCmd.AddCommand(periodCloseCmd)
+	periodCmd.AddCommand(periodReopenCmd)
+	periodCmd.AddCommand(periodLogCmd)
+}
+
+func closePeriod(ctx context.Context) error {
+	until := models.MonthEnd(periodYear, time.December)
+	if periodMonth != "" {
+		start, err := time.Parse("2006-01", periodMonth)
+		if err != nil {
+			return fmt.Errorf("invalid month %q, expected YYYY-MM", periodMonth)
+		}
+		until = models.MonthEnd(start.Year(), start.Month())
+	}
+
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.ProjectRepository.GetBySlug(ctx, periodProjectSlug)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	project, err = container.ClosePeriodService.ClosePeriod(ctx, project.ID, until, periodActor)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Period closed!\n")
+	fmt.Printf("   Project: %s\n", project.Slug)
+	fmt.Printf("   Books closed up to: %s\n", project.LockedUntil.Format(config.DateFormat))
+
+	return nil
+}
+
+func reopenPeriod(ctx context.Context) error {
+	var until *time.Time
+	if !periodAll {
+		date, err := time.Parse(config.DateFormat, periodUntil)
+		if err != nil {
+			return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", periodUntil)
+		}
+		until = &date
+	}
+
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.ProjectRepository.GetBySlug(ctx, periodProjectSlug)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	project, err = container.ClosePeriodService.ReopenPeriod(ctx, project.ID, until, periodActor, periodReason)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Period reopened!\n")
+	fmt.Printf("   Project: %s\n", project.Slug)
+	if project.LockedUntil != nil {
+		fmt.Printf("   Books closed up to: %s\n", project.LockedUntil.Format(config.DateFormat))
+	} else {
+		fmt.Printf("   Every period is open\n")
+	}
+
+	return nil
+}
+
+func showPeriodLog(ctx context.Context) error {
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.ProjectRepository.GetBySlug(ctx, periodProjectSlug)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	events, err := container.ClosePeriodService.GetAuditTrail(ctx, project.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		fmt.Printf("No period of project %s has been closed\n", project.Slug)
+		return nil
+	}
+
+	for _, event := range events {
+		lockedUntil := "open"
+		if event.LockedUntil != nil {
+			lockedUntil = event.LockedUntil.Format(config.DateFormat)
+		}
+		fmt.Printf("%s  %-6s  %-10s  %s", event.CreatedAt.Format("2006-01-02 15:04"), event.Action, lockedUntil, event.Actor)
+		if event.Reason != "" {
+			fmt.Printf("  (%s)", event.Reason)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
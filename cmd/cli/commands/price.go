@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var pricesProjectSlug string
+
+var priceCmd = &cobra.Command{
+	Use:   "price",
+	Short: "Manage security prices",
+	Long:  `Load the price history used to value the holdings of investment accounts.`,
+}
+
+var priceImportCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Import a CSV price history",
+	Long: `Import security prices from a CSV file with ticker, date (YYYY-MM-DD) and price columns,
+with or without a header row. A price for a day that already has one replaces it. Nothing is
+imported unless every row is valid and names a security of the project.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := importPrices(cmd.Context(), args[0]); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	priceCmd.PersistentFlags().StringVarP(&pricesProjectSlug, "project", "p", "", "Project slug (required)")
+	priceCmd.MarkPersistentFlagRequired("project")
+
+	priceCmd.AddCommand(priceImportCmd)
+}
+
+func importPrices(ctx context.Context, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open price file: %w", err)
+	}
+	defer file.Close()
+
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.ProjectRepository.GetBySlug(ctx, pricesProjectSlug)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	count, err := container.SecurityPricesService.ImportPrices(ctx, project.ID, file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d prices into project %s\n", count, project.Slug)
+	return nil
+}
@@ -1,16 +1,43 @@
 package commands
 
 import (
+	"flag"
 	"fmt"
+	"log/slog"
 	"os"
 
 	"github.com/spf13/cobra"
+	"gofin/internal/container"
+	"gofin/pkg/config"
+	"gofin/pkg/logging"
+)
+
+var (
+	appConfig      *config.Config
+	configFile     *string
+	configOverride config.Overrides
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "gofin",
 	Short: "A simple financial management CLI",
 	Long:  `Gofin is a CLI tool for managing financial projects and transactions.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(*configFile, configOverride)
+		if err != nil {
+			return fmt.Errorf("failed to load configuration: %w", err)
+		}
+
+		logLevel, _ := config.ParseLogLevel(cfg.Log.Level)
+		logger, err := logging.New(os.Stderr, logLevel, cfg.Log.Format)
+		if err != nil {
+			return err
+		}
+		slog.SetDefault(logger)
+
+		appConfig = cfg
+		return nil
+	},
 }
 
 func Execute() error {
@@ -18,11 +45,28 @@ func Execute() error {
 }
 
 func init() {
+	configFlags := flag.NewFlagSet("gofin", flag.ContinueOnError)
+	configFile, configOverride = config.RegisterFlags(configFlags)
+	rootCmd.PersistentFlags().AddGoFlagSet(configFlags)
+
 	rootCmd.AddCommand(createProjectCmd)
 	rootCmd.AddCommand(createAccessCmd)
+	rootCmd.AddCommand(setTwoFactorPolicyCmd)
+	rootCmd.AddCommand(accountCmd)
+	rootCmd.AddCommand(priceCmd)
+	rootCmd.AddCommand(periodCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.AddCommand(snapshotCmd)
 }
 
 func exitWithError(err error) {
 	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 	os.Exit(1)
 }
+
+func newContainer() (*container.Container, error) {
+	return container.NewContainerFromConfig(appConfig)
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	policyProjectSlug string
+	policyRequired    bool
+)
+
+var setTwoFactorPolicyCmd = &cobra.Command{
+	Use:   "set-2fa-policy",
+	Short: "Require two-factor authentication for read-write accesses",
+	Long:  `Enable or disable the project policy requiring TOTP two-factor authentication for read-write accesses.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := setTwoFactorPolicy(cmd.Context()); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	setTwoFactorPolicyCmd.Flags().StringVarP(&policyProjectSlug, "project", "p", "", "Project slug (required)")
+	setTwoFactorPolicyCmd.Flags().BoolVarP(&policyRequired, "required", "r", true, "Require two-factor authentication for read-write accesses")
+	setTwoFactorPolicyCmd.MarkFlagRequired("project")
+}
+
+func setTwoFactorPolicy(ctx context.Context) error {
+	container, err := newContainer()
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer container.DB.Close()
+
+	project, err := container.SetTwoFactorPolicyService.SetTwoFactorPolicy(ctx, policyProjectSlug, policyRequired)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Two-factor policy updated!\n")
+	fmt.Printf("   Project: %s\n", project.Slug)
+	fmt.Printf("   Required for read-write access: %t\n", project.RequireTwoFactor)
+
+	return nil
+}
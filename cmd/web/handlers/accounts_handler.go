@@ -0,0 +1,331 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/cases/create_account"
+	"gofin/internal/cases/record_transfer"
+	"gofin/internal/cases/update_account"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	"gofin/pkg/logging"
+	"gofin/pkg/money"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	addAccountError       = "Failed to create account: %v"
+	updateAccountError    = "Failed to save account: %v"
+	archiveAccountError   = "Failed to archive account: %v"
+	unarchiveAccountError = "Failed to restore account: %v"
+	moveAccountError      = "Failed to move account: %v"
+	recordTransferError   = "Failed to record transfer: %v"
+	invalidAccountIDError = "Invalid account ID"
+	invalidDirectionError = "Invalid direction"
+)
+
+// parseCreditCardTerms reads the optional credit card fields of an account form,
+// treating empty fields as not set.
+func parseCreditCardTerms(r *http.Request) (creditLimit float64, statementDay, dueDay int, err error) {
+	if value := strings.TrimSpace(r.PostFormValue(web.CreditLimitFormField)); value != "" {
+		if creditLimit, err = strconv.ParseFloat(value, 64); err != nil {
+			return 0, 0, 0, errors.New("invalid credit limit")
+		}
+	}
+
+	if value := strings.TrimSpace(r.PostFormValue(web.StatementDayFormField)); value != "" {
+		if statementDay, err = strconv.Atoi(value); err != nil {
+			return 0, 0, 0, errors.New("invalid statement closing day")
+		}
+	}
+
+	if value := strings.TrimSpace(r.PostFormValue(web.DueDayFormField)); value != "" {
+		if dueDay, err = strconv.Atoi(value); err != nil {
+			return 0, 0, 0, errors.New("invalid payment due day")
+		}
+	}
+
+	return creditLimit, statementDay, dueDay, nil
+}
+
+type AccountsHandler struct {
+	accountsComponent *components.AccountsComponent
+}
+
+func NewAccountsHandler(accountsComponent *components.AccountsComponent) *AccountsHandler {
+	return &AccountsHandler{
+		accountsComponent: accountsComponent,
+	}
+}
+
+func (h *AccountsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	h.accountsComponent.RenderAccounts(w, r, project, access, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+// AddAccountHandler creates an account from the accounts page form, unlike
+// CreateAccountHandler which serves the JSON endpoint of the transaction form.
+type AddAccountHandler struct {
+	container         *container.Container
+	accountsComponent *components.AccountsComponent
+}
+
+func NewAddAccountHandler(container *container.Container, accountsComponent *components.AccountsComponent) *AddAccountHandler {
+	return &AddAccountHandler{
+		container:         container,
+		accountsComponent: accountsComponent,
+	}
+}
+
+func (h *AddAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	currency, err := money.ParseCurrency(r.PostFormValue(web.AccountCurrencyFormField))
+	var accountType models.AccountType
+	if err == nil {
+		accountType, err = models.ParseAccountType(r.PostFormValue(web.AccountTypeFormField))
+	}
+	var creditLimit float64
+	var statementDay, dueDay int
+	if err == nil {
+		creditLimit, statementDay, dueDay, err = parseCreditCardTerms(r)
+	}
+	if err == nil {
+		_, err = h.container.CreateAccountService.CreateAccount(r.Context(), create_account.CreateAccountData{
+			ProjectID:    project.ID,
+			Name:         r.PostFormValue("name"),
+			Currency:     currency,
+			Type:         accountType,
+			Description:  r.PostFormValue(web.AccountDescriptionFormField),
+			CreditLimit:  creditLimit,
+			StatementDay: statementDay,
+			DueDay:       dueDay,
+		})
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to create account", logging.Err(err))
+		h.accountsComponent.RenderAccounts(w, r, project, access, "", fmt.Sprintf(addAccountError, err))
+		return
+	}
+
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, project.Slug, web.RouteAccounts), web.SuccessKeyAccountCreated)
+}
+
+type UpdateAccountHandler struct {
+	container         *container.Container
+	accountsComponent *components.AccountsComponent
+}
+
+func NewUpdateAccountHandler(container *container.Container, accountsComponent *components.AccountsComponent) *UpdateAccountHandler {
+	return &UpdateAccountHandler{
+		container:         container,
+		accountsComponent: accountsComponent,
+	}
+}
+
+func (h *UpdateAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	accountType, err := models.ParseAccountType(r.PostFormValue(web.AccountTypeFormField))
+	var creditLimit float64
+	var statementDay, dueDay int
+	if err == nil {
+		creditLimit, statementDay, dueDay, err = parseCreditCardTerms(r)
+	}
+	if err == nil {
+		_, err = h.container.UpdateAccountService.UpdateAccount(r.Context(), project.ID, accountID, update_account.UpdateAccountData{
+			Name:         r.PostFormValue("name"),
+			Type:         accountType,
+			Description:  r.PostFormValue(web.AccountDescriptionFormField),
+			CreditLimit:  creditLimit,
+			StatementDay: statementDay,
+			DueDay:       dueDay,
+		})
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to update account", logging.Err(err))
+		h.accountsComponent.RenderAccounts(w, r, project, access, "", fmt.Sprintf(updateAccountError, err))
+		return
+	}
+
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, project.Slug, web.RouteAccounts), web.SuccessKeyAccountUpdated)
+}
+
+type ArchiveAccountHandler struct {
+	container         *container.Container
+	accountsComponent *components.AccountsComponent
+	archive           bool
+}
+
+// NewArchiveAccountHandler archives the account in the URL, or restores it when
+// archive is false.
+func NewArchiveAccountHandler(container *container.Container, accountsComponent *components.AccountsComponent, archive bool) *ArchiveAccountHandler {
+	return &ArchiveAccountHandler{
+		container:         container,
+		accountsComponent: accountsComponent,
+		archive:           archive,
+	}
+}
+
+func (h *ArchiveAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	errorFormat, successKey := archiveAccountError, web.SuccessKeyAccountArchived
+	if h.archive {
+		_, err = h.container.UpdateAccountService.ArchiveAccount(r.Context(), project.ID, accountID)
+	} else {
+		errorFormat, successKey = unarchiveAccountError, web.SuccessKeyAccountUnarchived
+		_, err = h.container.UpdateAccountService.UnarchiveAccount(r.Context(), project.ID, accountID)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to change account archive state", logging.Err(err))
+		h.accountsComponent.RenderAccounts(w, r, project, access, "", fmt.Sprintf(errorFormat, err))
+		return
+	}
+
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, project.Slug, web.RouteAccounts), successKey)
+}
+
+type MoveAccountHandler struct {
+	container         *container.Container
+	accountsComponent *components.AccountsComponent
+}
+
+func NewMoveAccountHandler(container *container.Container, accountsComponent *components.AccountsComponent) *MoveAccountHandler {
+	return &MoveAccountHandler{
+		container:         container,
+		accountsComponent: accountsComponent,
+	}
+}
+
+func (h *MoveAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	var offset int
+	switch strings.ToLower(r.PostFormValue(web.MoveDirectionFormField)) {
+	case web.MoveDirectionUp:
+		offset = -1
+	case web.MoveDirectionDown:
+		offset = 1
+	default:
+		http.Error(w, invalidDirectionError, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.container.UpdateAccountService.MoveAccount(r.Context(), project.ID, accountID, offset); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to move account", logging.Err(err))
+		h.accountsComponent.RenderAccounts(w, r, project, access, "", fmt.Sprintf(moveAccountError, err))
+		return
+	}
+
+	http.Redirect(w, r, webcontext.ProjectURL(r, project.Slug, web.RouteAccounts), http.StatusSeeOther)
+}
+
+type CreditCardStatementsHandler struct {
+	accountsComponent *components.AccountsComponent
+}
+
+func NewCreditCardStatementsHandler(accountsComponent *components.AccountsComponent) *CreditCardStatementsHandler {
+	return &CreditCardStatementsHandler{
+		accountsComponent: accountsComponent,
+	}
+}
+
+func (h *CreditCardStatementsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	h.accountsComponent.RenderStatements(w, r, project, accountID)
+}
+
+type RecordTransferHandler struct {
+	container         *container.Container
+	accountsComponent *components.AccountsComponent
+}
+
+func NewRecordTransferHandler(container *container.Container, accountsComponent *components.AccountsComponent) *RecordTransferHandler {
+	return &RecordTransferHandler{
+		container:         container,
+		accountsComponent: accountsComponent,
+	}
+}
+
+func (h *RecordTransferHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	data, err := parseTransferForm(r)
+	if err == nil {
+		_, err = h.container.RecordTransferService.RecordTransfer(r.Context(), project.ID, data)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to record transfer", logging.Err(err))
+		h.accountsComponent.RenderAccounts(w, r, project, access, "", fmt.Sprintf(recordTransferError, err))
+		return
+	}
+
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, project.Slug, web.RouteAccounts), web.SuccessKeyTransferRecorded)
+}
+
+func parseTransferForm(r *http.Request) (record_transfer.TransferData, error) {
+	data := record_transfer.TransferData{Name: strings.TrimSpace(r.PostFormValue("name"))}
+	var err error
+
+	if data.FromAccountID, err = uuid.Parse(r.PostFormValue(web.FromAccountFormField)); err != nil {
+		return data, errors.New("paying account is required")
+	}
+	if data.ToAccountID, err = uuid.Parse(r.PostFormValue(web.ToAccountFormField)); err != nil {
+		return data, errors.New("receiving account is required")
+	}
+	if data.Amount, err = strconv.ParseFloat(strings.TrimSpace(r.PostFormValue(web.AmountFormField)), 64); err != nil {
+		return data, errors.New("invalid amount")
+	}
+	if value := strings.TrimSpace(r.PostFormValue(web.PaymentDateFormField)); value != "" {
+		date, err := time.Parse(config.DateFormat, value)
+		if err != nil {
+			return data, errors.New("invalid date")
+		}
+		data.Date = &date
+	}
+
+	return data, nil
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/record_settlement"
+	"gofin/internal/container"
+	"gofin/pkg/logging"
+	"gofin/pkg/money"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	recordSettlementError = "Failed to record settlement: %v"
+	invalidSettlementForm = "Invalid settlement"
+)
+
+type BalancesHandler struct {
+	container         *container.Container
+	balancesComponent *components.BalancesComponent
+}
+
+func NewBalancesHandler(container *container.Container, balancesComponent *components.BalancesComponent) *BalancesHandler {
+	return &BalancesHandler{
+		container:         container,
+		balancesComponent: balancesComponent,
+	}
+}
+
+func (h *BalancesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	renderBalances(w, r, h.container, h.balancesComponent, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+func renderBalances(w http.ResponseWriter, r *http.Request, container *container.Container, balancesComponent *components.BalancesComponent, successKey, errorMsg string) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	balances, err := container.SharedBalancesService.GetBalances(r.Context(), project.ID)
+	if err != nil {
+		webcontext.ServerError(w, r, "Failed to get member balances", err)
+		return
+	}
+
+	balancesComponent.RenderBalances(w, r, project, access, balances, successKey, errorMsg)
+}
+
+type RecordSettlementHandler struct {
+	container         *container.Container
+	balancesComponent *components.BalancesComponent
+}
+
+func NewRecordSettlementHandler(container *container.Container, balancesComponent *components.BalancesComponent) *RecordSettlementHandler {
+	return &RecordSettlementHandler{
+		container:         container,
+		balancesComponent: balancesComponent,
+	}
+}
+
+func (h *RecordSettlementHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	data, err := parseSettlementForm(r)
+	if err == nil {
+		_, err = h.container.RecordSettlementService.RecordSettlement(r.Context(), project.ID, data)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to record settlement", logging.Err(err))
+		renderBalances(w, r, h.container, h.balancesComponent, "", fmt.Sprintf(recordSettlementError, err))
+		return
+	}
+
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, project.Slug, web.RouteBalances), web.SuccessKeySettlementRecorded)
+}
+
+func parseSettlementForm(r *http.Request) (record_settlement.SettlementData, error) {
+	var data record_settlement.SettlementData
+	var err error
+
+	if data.FromID, err = uuid.Parse(r.PostFormValue("from_id")); err != nil {
+		return data, errors.New(invalidSettlementForm)
+	}
+	if data.ToID, err = uuid.Parse(r.PostFormValue("to_id")); err != nil {
+		return data, errors.New(invalidSettlementForm)
+	}
+	if data.Currency, err = money.ParseCurrency(r.PostFormValue("currency")); err != nil {
+		return data, err
+	}
+	if data.Amount, err = strconv.ParseFloat(r.PostFormValue("amount"), 64); err != nil {
+		return data, errors.New("invalid amount")
+	}
+	if data.FromAccountID, err = uuid.Parse(r.PostFormValue("from_account_id")); err != nil {
+		return data, errors.New("paying account is required")
+	}
+	if toAccount := r.PostFormValue("to_account_id"); toAccount != "" {
+		toAccountID, err := uuid.Parse(toAccount)
+		if err != nil {
+			return data, errors.New(invalidSettlementForm)
+		}
+		data.ToAccountID = &toAccountID
+	}
+
+	return data, nil
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"gofin/internal/container"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const createCategoryError = "Failed to create category: %v"
+
+type CategoriesHandler struct {
+	categoriesComponent *components.CategoriesComponent
+}
+
+func NewCategoriesHandler(categoriesComponent *components.CategoriesComponent) *CategoriesHandler {
+	return &CategoriesHandler{
+		categoriesComponent: categoriesComponent,
+	}
+}
+
+func (h *CategoriesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	h.categoriesComponent.RenderCategories(w, r, project, access, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+type CreateCategoryHandler struct {
+	container           *container.Container
+	categoriesComponent *components.CategoriesComponent
+}
+
+func NewCreateCategoryHandler(container *container.Container, categoriesComponent *components.CategoriesComponent) *CreateCategoryHandler {
+	return &CreateCategoryHandler{
+		container:           container,
+		categoriesComponent: categoriesComponent,
+	}
+}
+
+func (h *CreateCategoryHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	_, err := h.container.CreateCategoryService.CreateCategory(r.Context(), project.ID, r.PostFormValue("name"))
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to create category", logging.Err(err))
+		h.categoriesComponent.RenderCategories(w, r, project, access, "", fmt.Sprintf(createCategoryError, err))
+		return
+	}
+
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, project.Slug, web.RouteCategories), web.SuccessKeyCategoryCreated)
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 
 	"gofin/internal/cases/create_account"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
 	"gofin/pkg/money"
 	webpkg "gofin/pkg/web"
 )
@@ -20,14 +22,17 @@ func NewCreateAccountHandler(createAccountService *create_account.CreateAccountS
 }
 
 type CreateAccountRequest struct {
-	Name     string `json:"name"`
-	Currency string `json:"currency"`
+	Name        string `json:"name"`
+	Currency    string `json:"currency"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 type CreateAccountResponse struct {
 	ID       string `json:"id"`
 	Name     string `json:"name"`
 	Currency string `json:"currency"`
+	Type     string `json:"type,omitempty"`
 	Error    string `json:"error,omitempty"`
 }
 
@@ -56,12 +61,29 @@ func (h *CreateAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	account, err := h.createAccountService.CreateAccount(create_account.CreateAccountData{
-		ProjectID: project.ID,
-		Name:      req.Name,
-		Currency:  currency,
+	accountType := models.AccountChecking
+	if req.Type != "" {
+		accountType, err = models.ParseAccountType(req.Type)
+		if err != nil {
+			response := CreateAccountResponse{
+				Error: "Invalid account type",
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+	}
+
+	account, err := h.createAccountService.CreateAccount(r.Context(), create_account.CreateAccountData{
+		ProjectID:   project.ID,
+		Name:        req.Name,
+		Currency:    currency,
+		Type:        accountType,
+		Description: req.Description,
 	})
 	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to create account", logging.Err(err))
 		response := CreateAccountResponse{
 			Error: err.Error(),
 		}
@@ -75,6 +97,7 @@ func (h *CreateAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		ID:       account.ID.String(),
 		Name:     account.Name,
 		Currency: account.Currency.String(),
+		Type:     account.Type.String(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"gofin/internal/container"
+	"gofin/internal/models"
 	webcontext "gofin/pkg/web"
 	"gofin/web/components"
 )
@@ -23,11 +24,11 @@ func NewCreateTransactionFormHandler(container *container.Container, transaction
 func (h *CreateTransactionFormHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	project, _ := webcontext.GetProject(r.Context())
 
-	accounts, err := h.container.AccountRepository.GetByProjectID(project.ID)
+	accounts, err := h.container.AccountRepository.GetByProjectID(r.Context(), project.ID)
 	if err != nil {
-		http.Error(w, "Failed to fetch accounts", http.StatusInternalServerError)
+		webcontext.ServerError(w, r, "Failed to fetch accounts", err)
 		return
 	}
 
-	h.transactionComponent.RenderCreateTransactionPage(w, r, project.Slug, accounts, "")
+	h.transactionComponent.RenderCreateTransactionPage(w, r, project.Slug, models.ActiveAccounts(accounts), "")
 }
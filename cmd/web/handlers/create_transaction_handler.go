@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"gofin/internal/container"
 	"gofin/internal/models"
 	"gofin/pkg/config"
+	"gofin/pkg/logging"
 	webpkg "gofin/pkg/web"
 	"gofin/web"
 	"gofin/web/components"
@@ -38,23 +40,30 @@ const (
 	invalidAccountError    = "Invalid account for group %d"
 	invalidTypeError       = "Invalid type for group %d"
 	invalidDateError       = "Invalid date for group %d"
+	invalidGroupCategory   = "Invalid category for group %d"
 	createTransactionError = "Failed to create transactions: %v"
 )
 
+// TransactionGroupData is one transaction of the create transaction form, the
+// groups[i].* fields. Index is i; imported rows also carry the bank's ID.
 type TransactionGroupData struct {
-	Name      string
-	Value     float64
-	Type      string
-	AccountID string
-	Date      time.Time
+	Index      int
+	Name       string
+	Value      float64
+	Type       string
+	AccountID  string
+	Date       time.Time
+	Notes      string
+	CategoryID *uuid.UUID
+	ExternalID string
 }
 
 func (h *CreateTransactionHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	project, _ := webpkg.GetProject(r.Context())
 
-	accounts, err := h.container.AccountRepository.GetByProjectID(project.ID)
+	accounts, err := h.container.AccountRepository.GetByProjectID(r.Context(), project.ID)
 	if err != nil {
-		http.Error(w, "Failed to fetch accounts", http.StatusInternalServerError)
+		webpkg.ServerError(w, r, "Failed to fetch accounts", err)
 		return
 	}
 
@@ -63,6 +72,39 @@ func (h *CreateTransactionHandler) Handle(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	groups, err := parseTransactionGroups(r)
+	if err != nil {
+		h.renderCreateTransactionForm(w, r, accounts, project.Slug, err.Error())
+		return
+	}
+
+	if len(groups) == 0 {
+		h.renderCreateTransactionForm(w, r, accounts, project.Slug, noTransactionsError)
+		return
+	}
+
+	var transactionData []models.TransactionData
+	for _, group := range groups {
+		data := group.transactionData()
+		data.ExternalID = ""
+		transactionData = append(transactionData, data)
+	}
+
+	created, err := h.createTransactionSvc.CreateGroupedTransactions(r.Context(), project.ID, transactionData)
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to create transactions", logging.Err(err))
+		h.renderCreateTransactionForm(w, r, accounts, project.Slug, fmt.Sprintf(createTransactionError, err))
+		return
+	}
+
+	h.container.Metrics.AddTransactionsCreated(project.Slug, len(created))
+
+	webpkg.RedirectToProjectHomeWithSuccess(w, r, project.Slug, web.SuccessKeyTransactionsCreated)
+}
+
+// parseTransactionGroups reads the groups[i].* fields of a parsed form in the
+// order of i, leaving out groups without a value.
+func parseTransactionGroups(r *http.Request) ([]TransactionGroupData, error) {
 	var groups []TransactionGroupData
 
 	for key, values := range r.Form {
@@ -88,32 +130,27 @@ func (h *CreateTransactionHandler) Handle(w http.ResponseWriter, r *http.Request
 
 		value, err := strconv.ParseFloat(valueStr, 64)
 		if err != nil {
-			h.renderCreateTransactionForm(w, r, accounts, project.Slug, fmt.Sprintf(invalidValueError, index+1))
-			return
+			return nil, fmt.Errorf(invalidValueError, index+1)
 		}
 
 		accountIDStr := r.FormValue(fmt.Sprintf("groups[%d].account_id", index))
 		if accountIDStr == "" {
-			h.renderCreateTransactionForm(w, r, accounts, project.Slug, fmt.Sprintf("Account is required for group %d", index+1))
-			return
+			return nil, fmt.Errorf("Account is required for group %d", index+1)
 		}
 
 		_, err = uuid.Parse(accountIDStr)
 		if err != nil {
-			h.renderCreateTransactionForm(w, r, accounts, project.Slug, fmt.Sprintf(invalidAccountError, index+1))
-			return
+			return nil, fmt.Errorf(invalidAccountError, index+1)
 		}
 
 		typeStr := r.FormValue(fmt.Sprintf("groups[%d].type", index))
 		if typeStr == "" {
-			h.renderCreateTransactionForm(w, r, accounts, project.Slug, fmt.Sprintf("Type is required for group %d", index+1))
-			return
+			return nil, fmt.Errorf("Type is required for group %d", index+1)
 		}
 
 		_, err = models.ParseTransactionType(typeStr)
 		if err != nil {
-			h.renderCreateTransactionForm(w, r, accounts, project.Slug, fmt.Sprintf(invalidTypeError, index+1))
-			return
+			return nil, fmt.Errorf(invalidTypeError, index+1)
 		}
 
 		date := time.Now()
@@ -121,48 +158,57 @@ func (h *CreateTransactionHandler) Handle(w http.ResponseWriter, r *http.Request
 		if dateStr != "" {
 			date, err = time.Parse(config.DateTimeFormat, dateStr)
 			if err != nil {
-				h.renderCreateTransactionForm(w, r, accounts, project.Slug, fmt.Sprintf(invalidDateError, index+1))
-				return
+				return nil, fmt.Errorf(invalidDateError, index+1)
+			}
+		}
+
+		var categoryID *uuid.UUID
+		if categoryStr := r.FormValue(fmt.Sprintf("groups[%d].category_id", index)); categoryStr != "" {
+			parsed, err := uuid.Parse(categoryStr)
+			if err != nil {
+				return nil, fmt.Errorf(invalidGroupCategory, index+1)
 			}
+			categoryID = &parsed
 		}
 
 		groups = append(groups, TransactionGroupData{
-			Name:      r.FormValue(fmt.Sprintf("groups[%d].name", index)),
-			Value:     value,
-			Type:      typeStr,
-			AccountID: accountIDStr,
-			Date:      date,
+			Index:      index,
+			Name:       r.FormValue(fmt.Sprintf("groups[%d].name", index)),
+			Value:      value,
+			Type:       typeStr,
+			AccountID:  accountIDStr,
+			Date:       date,
+			Notes:      strings.TrimSpace(r.FormValue(fmt.Sprintf("groups[%d].notes", index))),
+			CategoryID: categoryID,
+			ExternalID: strings.TrimSpace(r.FormValue(fmt.Sprintf("groups[%d].external_id", index))),
 		})
 	}
 
-	if len(groups) == 0 {
-		h.renderCreateTransactionForm(w, r, accounts, project.Slug, noTransactionsError)
-		return
-	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Index < groups[j].Index
+	})
 
-	var transactionData []models.TransactionData
-	for _, group := range groups {
-		accountID, _ := uuid.Parse(group.AccountID)
-		transactionType, _ := models.ParseTransactionType(group.Type)
-
-		transactionData = append(transactionData, models.TransactionData{
-			AccountID:       accountID,
-			Value:           group.Value,
-			Name:            group.Name,
-			Type:            transactionType,
-			TransactionDate: &group.Date,
-		})
-	}
+	return groups, nil
+}
 
-	_, err = h.createTransactionSvc.CreateGroupedTransactions(project.ID, transactionData)
-	if err != nil {
-		h.renderCreateTransactionForm(w, r, accounts, project.Slug, fmt.Sprintf(createTransactionError, err))
-		return
+// transactionData converts a group parseTransactionGroups validated.
+func (g TransactionGroupData) transactionData() models.TransactionData {
+	accountID, _ := uuid.Parse(g.AccountID)
+	transactionType, _ := models.ParseTransactionType(g.Type)
+	date := g.Date
+
+	return models.TransactionData{
+		AccountID:       accountID,
+		Value:           g.Value,
+		Name:            g.Name,
+		Type:            transactionType,
+		TransactionDate: &date,
+		Notes:           g.Notes,
+		CategoryID:      g.CategoryID,
+		ExternalID:      g.ExternalID,
 	}
-
-	webpkg.RedirectToProjectHomeWithSuccess(w, r, project.Slug, web.SuccessKeyTransactionsCreated)
 }
 
 func (h *CreateTransactionHandler) renderCreateTransactionForm(w http.ResponseWriter, r *http.Request, accounts []*models.Account, projectSlug, errorMsg string) {
-	h.transactionComponent.RenderCreateTransactionPage(w, r, projectSlug, accounts, errorMsg)
+	h.transactionComponent.RenderCreateTransactionPage(w, r, projectSlug, models.ActiveAccounts(accounts), errorMsg)
 }
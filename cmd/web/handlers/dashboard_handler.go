@@ -30,19 +30,31 @@ func (h *DashboardHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	successMsg := r.URL.Query().Get(web.SuccessQueryParam)
 	year, month := h.parseAndValidateFilterParams(r)
 
-	transactions, err := h.container.GetProjectTransactionsService.GetProjectTransactions(project.ID, year, month)
+	transactions, err := h.container.GetProjectTransactionsService.GetProjectTransactions(r.Context(), project.ID, year, month)
 	if err != nil {
-		http.Error(w, "Failed to get project transactions", http.StatusInternalServerError)
+		webcontext.ServerError(w, r, "Failed to get project transactions", err)
 		return
 	}
 
-	balanceData, err := h.container.GetProjectBalanceService.GetProjectBalancesFromTransactions(project.ID, transactions)
+	balanceData, err := h.container.GetProjectBalanceService.GetProjectBalancesFromTransactions(r.Context(), project.ID, transactions)
 	if err != nil {
-		http.Error(w, "Failed to get project balances", http.StatusInternalServerError)
+		webcontext.ServerError(w, r, "Failed to get project balances", err)
 		return
 	}
 
-	h.dashboardComponent.RenderDashboard(w, r, project, access, project.Slug, successMsg, year, month, transactions, balanceData)
+	categoryTotals, err := h.container.GetCategorySummaryService.GetCategoryTotalsFromTransactions(r.Context(), project.ID, transactions)
+	if err != nil {
+		webcontext.ServerError(w, r, "Failed to get category totals", err)
+		return
+	}
+
+	reminders, err := h.container.CreditCardStatementsService.GetPaymentReminders(r.Context(), project.ID, time.Now())
+	if err != nil {
+		webcontext.ServerError(w, r, "Failed to get payment reminders", err)
+		return
+	}
+
+	h.dashboardComponent.RenderDashboard(w, r, project, access, project.Slug, successMsg, year, month, transactions, balanceData, categoryTotals, reminders)
 }
 
 func (h *DashboardHandler) parseAndValidateFilterParams(r *http.Request) (int, int) {
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+)
+
+type DeleteAttachmentHandler struct {
+	container *container.Container
+}
+
+func NewDeleteAttachmentHandler(container *container.Container) *DeleteAttachmentHandler {
+	return &DeleteAttachmentHandler{
+		container: container,
+	}
+}
+
+func (h *DeleteAttachmentHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	attachmentID, err := uuid.Parse(chi.URLParam(r, web.AttachmentIDParam))
+	if err != nil {
+		http.Error(w, "Invalid attachment ID", http.StatusBadRequest)
+		return
+	}
+
+	attachment, err := h.container.AttachmentsService.Delete(r.Context(), project.ID, attachmentID)
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to delete attachment", logging.Err(err))
+		http.NotFound(w, r)
+		return
+	}
+
+	redirectToTransactionWithSuccess(w, r, project.Slug, attachment.TransactionID, web.SuccessKeyAttachmentDeleted)
+}
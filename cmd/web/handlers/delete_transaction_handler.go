@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/google/uuid"
 	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
 	webcontext "gofin/pkg/web"
 	"gofin/web"
 )
@@ -45,11 +48,18 @@ func (h *DeleteTransactionHandler) Handle(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = h.container.DeleteTransactionService.DeleteTransaction(transactionID)
+	err = h.container.DeleteTransactionService.DeleteTransaction(r.Context(), transactionID)
+	if errors.Is(err, models.ErrTransactionReconciled) || errors.Is(err, models.ErrPeriodClosed) {
+		logging.FromContext(r.Context()).Warn("refused to delete transaction", logging.Err(err))
+		http.Error(w, "Cannot delete the transaction: "+err.Error(), http.StatusConflict)
+		return
+	}
 	if err != nil {
-		http.Error(w, "Failed to delete transaction", http.StatusInternalServerError)
+		webcontext.ServerError(w, r, "Failed to delete transaction", err)
 		return
 	}
 
-	http.Redirect(w, r, "/"+project.Slug+web.RouteDashboard+"?success="+web.SuccessKeyTransactionDeleted, http.StatusSeeOther)
+	h.container.Metrics.IncTransactionsDeleted(project.Slug)
+
+	webcontext.RedirectToProjectHomeWithSuccess(w, r, project.Slug, web.SuccessKeyTransactionDeleted)
 }
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gofin/internal/container"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+type DisableTwoFactorHandler struct {
+	container          *container.Container
+	twoFactorComponent *components.TwoFactorComponent
+}
+
+func NewDisableTwoFactorHandler(container *container.Container, twoFactorComponent *components.TwoFactorComponent) *DisableTwoFactorHandler {
+	return &DisableTwoFactorHandler{
+		container:          container,
+		twoFactorComponent: twoFactorComponent,
+	}
+}
+
+func (h *DisableTwoFactorHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		h.twoFactorComponent.RenderTwoFactorPage(w, r, project, access, nil, nil, "Invalid form data")
+		return
+	}
+
+	if err := h.container.EnrollTwoFactorService.DisableTwoFactor(r.Context(), access.ID, r.FormValue("code")); err != nil {
+		h.twoFactorComponent.RenderTwoFactorPage(w, r, project, access, nil, nil, err.Error())
+		return
+	}
+
+	webcontext.RedirectToProjectHomeWithSuccess(w, r, project.Slug, web.SuccessKeyTwoFactorDisabled)
+}
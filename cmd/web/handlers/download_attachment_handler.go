@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+)
+
+// DownloadAttachmentHandler streams an attachment, or its thumbnail when built with
+// thumbnail set. Files are always sent as downloads with sniffing disabled so an
+// uploaded file can never run as a page of this site.
+type DownloadAttachmentHandler struct {
+	container *container.Container
+	thumbnail bool
+}
+
+func NewDownloadAttachmentHandler(container *container.Container) *DownloadAttachmentHandler {
+	return &DownloadAttachmentHandler{container: container}
+}
+
+func NewAttachmentThumbnailHandler(container *container.Container) *DownloadAttachmentHandler {
+	return &DownloadAttachmentHandler{container: container, thumbnail: true}
+}
+
+func (h *DownloadAttachmentHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	attachmentID, err := uuid.Parse(chi.URLParam(r, web.AttachmentIDParam))
+	if err != nil {
+		http.Error(w, "Invalid attachment ID", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+
+	if h.thumbnail {
+		content, err := h.container.AttachmentsService.OpenThumbnail(r.Context(), project.ID, attachmentID)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer content.Close()
+
+		w.Header().Set("Content-Type", "image/jpeg")
+		h.copy(w, r, content)
+		return
+	}
+
+	attachment, content, err := h.container.AttachmentsService.Open(r.Context(), project.ID, attachmentID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	h.copy(w, r, content)
+}
+
+func (h *DownloadAttachmentHandler) copy(w http.ResponseWriter, r *http.Request, content io.Reader) {
+	if _, err := io.Copy(w, content); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to send attachment", logging.Err(err))
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gofin/internal/container"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+type EnableTwoFactorHandler struct {
+	container          *container.Container
+	twoFactorComponent *components.TwoFactorComponent
+}
+
+func NewEnableTwoFactorHandler(container *container.Container, twoFactorComponent *components.TwoFactorComponent) *EnableTwoFactorHandler {
+	return &EnableTwoFactorHandler{
+		container:          container,
+		twoFactorComponent: twoFactorComponent,
+	}
+}
+
+func (h *EnableTwoFactorHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		h.renderWithError(w, r, "Invalid form data")
+		return
+	}
+
+	recoveryCodes, err := h.container.EnrollTwoFactorService.ConfirmEnrollment(r.Context(), access.ID, r.FormValue("code"))
+	if err != nil {
+		h.renderWithError(w, r, err.Error())
+		return
+	}
+
+	access, err = h.container.AccessRepository.GetByID(r.Context(), access.ID)
+	if err != nil {
+		webcontext.ServerError(w, r, web.AccessNotFoundError, err)
+		return
+	}
+
+	h.twoFactorComponent.RenderTwoFactorPage(w, r, project, access, nil, recoveryCodes, web.EmptyString)
+}
+
+func (h *EnableTwoFactorHandler) renderWithError(w http.ResponseWriter, r *http.Request, errorMsg string) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	enrollment, err := h.container.EnrollTwoFactorService.StartEnrollment(r.Context(), access.ID)
+	if err != nil {
+		webcontext.ServerError(w, r, "Failed to start two-factor enrollment", err)
+		return
+	}
+
+	h.twoFactorComponent.RenderTwoFactorPage(w, r, project, access, enrollment, nil, errorMsg)
+}
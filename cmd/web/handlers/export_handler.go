@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofin/internal/container"
+	"gofin/pkg/config"
+	"gofin/pkg/export"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+)
+
+const invalidAsOfError = "invalid balance date"
+
+// ExportTransactionsHandler downloads the transactions matching the search
+// filters in the query string, every page of them.
+type ExportTransactionsHandler struct {
+	container *container.Container
+}
+
+func NewExportTransactionsHandler(container *container.Container) *ExportTransactionsHandler {
+	return &ExportTransactionsHandler{
+		container: container,
+	}
+}
+
+func (h *ExportTransactionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	query, err := parseTransactionQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sendExport(w, r, project.Slug+"-transactions", func(ctx context.Context, out io.Writer, format export.Format) error {
+		return h.container.ExportDataService.ExportTransactions(ctx, out, format, project.ID, query)
+	})
+}
+
+// ExportBalancesHandler downloads the account balances at the end of the as_of
+// day, today by default.
+type ExportBalancesHandler struct {
+	container *container.Container
+}
+
+func NewExportBalancesHandler(container *container.Container) *ExportBalancesHandler {
+	return &ExportBalancesHandler{
+		container: container,
+	}
+}
+
+func (h *ExportBalancesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	asOf := time.Now()
+	if value := strings.TrimSpace(r.URL.Query().Get(web.ExportAsOfParam)); value != "" {
+		parsed, err := time.Parse(config.DateFormat, value)
+		if err != nil {
+			http.Error(w, invalidAsOfError, http.StatusBadRequest)
+			return
+		}
+		asOf = parsed
+	}
+
+	sendExport(w, r, project.Slug+"-balances-"+asOf.Format(config.DateFormat), func(ctx context.Context, out io.Writer, format export.Format) error {
+		return h.container.ExportDataService.ExportBalances(ctx, out, format, project.ID, asOf)
+	})
+}
+
+// ExportReportHandler downloads the reports page for the same range and
+// currency.
+type ExportReportHandler struct {
+	container *container.Container
+}
+
+func NewExportReportHandler(container *container.Container) *ExportReportHandler {
+	return &ExportReportHandler{
+		container: container,
+	}
+}
+
+func (h *ExportReportHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	query, err := parseReportQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query.ProjectID = project.ID
+
+	name := project.Slug + "-report-" + query.From.Format(config.DateFormat) + "-" + query.To.Format(config.DateFormat)
+	sendExport(w, r, name, func(ctx context.Context, out io.Writer, format export.Format) error {
+		return h.container.ExportDataService.ExportReport(ctx, out, format, query)
+	})
+}
+
+// sendExport renders the export in the requested format in full before sending
+// it, so a failure halfway still ends in a proper error response.
+func sendExport(w http.ResponseWriter, r *http.Request, name string, render func(ctx context.Context, out io.Writer, format export.Format) error) {
+	format, err := export.ParseFormat(r.URL.Query().Get(web.ExportFormatParam))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if err := render(r.Context(), &buffer, format); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to export", logging.Err(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Length", strconv.Itoa(buffer.Len()))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": format.FileName(name)}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if _, err := buffer.WriteTo(w); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to send export", logging.Err(err))
+	}
+}
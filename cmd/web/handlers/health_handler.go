@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"gofin/internal/infrastructure/database"
+)
+
+const readinessCheckTimeout = 2 * time.Second
+
+type HealthResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HealthHandler answers liveness probes: it only reports that the process serves requests.
+type HealthHandler struct{}
+
+func NewHealthHandler() *HealthHandler {
+	return &HealthHandler{}
+}
+
+func (h *HealthHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	writeHealthResponse(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// ReadinessHandler answers readiness probes: the database must respond and be fully migrated.
+type ReadinessHandler struct {
+	db database.Database
+}
+
+func NewReadinessHandler(db database.Database) *ReadinessHandler {
+	return &ReadinessHandler{
+		db: db,
+	}
+}
+
+func (h *ReadinessHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessCheckTimeout)
+	defer cancel()
+
+	if err := h.db.Ping(ctx); err != nil {
+		writeHealthResponse(w, http.StatusServiceUnavailable, HealthResponse{Status: "unavailable", Error: "database unreachable"})
+		return
+	}
+
+	if err := h.db.CheckSchema(ctx); err != nil {
+		writeHealthResponse(w, http.StatusServiceUnavailable, HealthResponse{Status: "unavailable", Error: err.Error()})
+		return
+	}
+
+	writeHealthResponse(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+func writeHealthResponse(w http.ResponseWriter, status int, response HealthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(response)
+}
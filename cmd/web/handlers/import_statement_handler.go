@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/import_statement"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	webpkg "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	missingStatementFileError = "Choose a statement file to import"
+	statementTooLargeError    = "Statement file is larger than %d MB"
+	previewStatementError     = "Failed to read statement: %v"
+	importStatementError      = "Failed to import statement: %v"
+	nothingToImportError      = "Nothing to import: every transaction was imported before or left unticked"
+)
+
+type ImportStatementFormHandler struct {
+	importComponent *components.ImportStatementComponent
+}
+
+func NewImportStatementFormHandler(importComponent *components.ImportStatementComponent) *ImportStatementFormHandler {
+	return &ImportStatementFormHandler{
+		importComponent: importComponent,
+	}
+}
+
+func (h *ImportStatementFormHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webpkg.GetProject(r.Context())
+	h.importComponent.RenderImportStatement(w, r, project, nil, "")
+}
+
+// PreviewStatementHandler parses an uploaded statement and shows what importing
+// it would add. Nothing is saved until the preview is confirmed.
+type PreviewStatementHandler struct {
+	container       *container.Container
+	importComponent *components.ImportStatementComponent
+}
+
+func NewPreviewStatementHandler(container *container.Container, importComponent *components.ImportStatementComponent) *PreviewStatementHandler {
+	return &PreviewStatementHandler{
+		container:       container,
+		importComponent: importComponent,
+	}
+}
+
+func (h *PreviewStatementHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webpkg.GetProject(r.Context())
+
+	var accountID *uuid.UUID
+	if value := r.FormValue(web.ImportAccountFormField); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+			return
+		}
+		accountID = &parsed
+	}
+
+	file, _, err := r.FormFile(web.StatementFileFormField)
+	if err != nil {
+		h.importComponent.RenderImportStatement(w, r, project, nil, missingStatementFileError)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, import_statement.MaxStatementSize+1))
+	if err == nil && len(data) > import_statement.MaxStatementSize {
+		err = fmt.Errorf(statementTooLargeError, import_statement.MaxStatementSize>>20)
+	}
+
+	var preview *import_statement.ImportPreview
+	if err == nil {
+		preview, err = h.container.ImportStatementService.Preview(r.Context(), project.ID, data, accountID)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to preview statement", logging.Err(err))
+		h.importComponent.RenderImportStatement(w, r, project, nil, fmt.Sprintf(previewStatementError, err))
+		return
+	}
+
+	h.importComponent.RenderImportStatement(w, r, project, preview, "")
+}
+
+// ConfirmImportHandler imports the ticked rows of a preview. They come in the
+// groups[i].* fields of the create transaction form, so they are read the same
+// way as manually entered transactions.
+type ConfirmImportHandler struct {
+	container       *container.Container
+	importComponent *components.ImportStatementComponent
+}
+
+func NewConfirmImportHandler(container *container.Container, importComponent *components.ImportStatementComponent) *ConfirmImportHandler {
+	return &ConfirmImportHandler{
+		container:       container,
+		importComponent: importComponent,
+	}
+}
+
+func (h *ConfirmImportHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webpkg.GetProject(r.Context())
+
+	if err := r.ParseForm(); err != nil {
+		h.importComponent.RenderImportStatement(w, r, project, nil, formParseError)
+		return
+	}
+
+	groups, err := parseTransactionGroups(r)
+	if err != nil {
+		h.importComponent.RenderImportStatement(w, r, project, nil, err.Error())
+		return
+	}
+
+	var transactionData []models.TransactionData
+	for _, group := range groups {
+		if r.FormValue(fmt.Sprintf(web.ImportIncludeFormField, group.Index)) == "" {
+			continue
+		}
+		transactionData = append(transactionData, group.transactionData())
+	}
+
+	var result *import_statement.ImportResult
+	if len(transactionData) > 0 {
+		result, err = h.container.ImportStatementService.Import(r.Context(), project.ID, transactionData)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to import statement", logging.Err(err))
+		h.importComponent.RenderImportStatement(w, r, project, nil, fmt.Sprintf(importStatementError, err))
+		return
+	}
+
+	if result == nil || len(result.Created) == 0 {
+		h.importComponent.RenderImportStatement(w, r, project, nil, nothingToImportError)
+		return
+	}
+
+	h.container.Metrics.AddTransactionsCreated(project.Slug, len(result.Created))
+
+	webpkg.RedirectToProjectHomeWithSuccess(w, r, project.Slug, web.SuccessKeyStatementImported)
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/cases/record_investment_operation"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	recordOperationError  = "Failed to record operation: %v"
+	setPriceError         = "Failed to save price: %v"
+	importPricesError     = "Failed to import prices: %v"
+	invalidQuantityError  = "invalid quantity"
+	invalidPriceError     = "invalid price"
+	invalidFeesError      = "invalid fees"
+	missingPriceFileError = "no price file uploaded"
+)
+
+type InvestmentsHandler struct {
+	investmentsComponent *components.InvestmentsComponent
+}
+
+func NewInvestmentsHandler(investmentsComponent *components.InvestmentsComponent) *InvestmentsHandler {
+	return &InvestmentsHandler{
+		investmentsComponent: investmentsComponent,
+	}
+}
+
+func (h *InvestmentsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	h.investmentsComponent.RenderInvestments(w, r, project)
+}
+
+type InvestmentHandler struct {
+	investmentsComponent *components.InvestmentsComponent
+}
+
+func NewInvestmentHandler(investmentsComponent *components.InvestmentsComponent) *InvestmentHandler {
+	return &InvestmentHandler{
+		investmentsComponent: investmentsComponent,
+	}
+}
+
+func (h *InvestmentHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	h.investmentsComponent.RenderInvestment(w, r, project, access, accountID, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+type RecordInvestmentOperationHandler struct {
+	container            *container.Container
+	investmentsComponent *components.InvestmentsComponent
+}
+
+func NewRecordInvestmentOperationHandler(container *container.Container, investmentsComponent *components.InvestmentsComponent) *RecordInvestmentOperationHandler {
+	return &RecordInvestmentOperationHandler{
+		container:            container,
+		investmentsComponent: investmentsComponent,
+	}
+}
+
+func (h *RecordInvestmentOperationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	data := record_investment_operation.OperationData{
+		AccountID:    accountID,
+		Ticker:       r.PostFormValue(web.TickerFormField),
+		SecurityName: r.PostFormValue(web.SecurityNameFormField),
+	}
+
+	data.Type, err = models.ParseInvestmentOperationType(r.PostFormValue(web.OperationTypeFormField))
+	if err == nil && data.Type == models.OperationDividend {
+		data.Amount, err = parseLoanFloat(r.PostFormValue(web.AmountFormField), invalidLoanValueError)
+	} else if err == nil {
+		data.Quantity, err = parseLoanFloat(r.PostFormValue(web.QuantityFormField), invalidQuantityError)
+		if err == nil {
+			data.Price, err = parseLoanFloat(r.PostFormValue(web.PriceFormField), invalidPriceError)
+		}
+		if fees := strings.TrimSpace(r.PostFormValue(web.FeesFormField)); err == nil && fees != "" {
+			data.Fees, err = parseLoanFloat(fees, invalidFeesError)
+		}
+	}
+	if err == nil {
+		data.Date, err = parseLoanDate(r.PostFormValue(web.PaymentDateFormField))
+	}
+	if err == nil {
+		_, err = h.container.RecordInvestmentOperationService.RecordOperation(r.Context(), project.ID, data)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to record investment operation", logging.Err(err))
+		h.investmentsComponent.RenderInvestment(w, r, project, access, accountID, "", fmt.Sprintf(recordOperationError, err))
+		return
+	}
+
+	redirectToInvestmentWithSuccess(w, r, project.Slug, accountID, web.SuccessKeyOperationRecorded)
+}
+
+type SetSecurityPriceHandler struct {
+	container            *container.Container
+	investmentsComponent *components.InvestmentsComponent
+}
+
+func NewSetSecurityPriceHandler(container *container.Container, investmentsComponent *components.InvestmentsComponent) *SetSecurityPriceHandler {
+	return &SetSecurityPriceHandler{
+		container:            container,
+		investmentsComponent: investmentsComponent,
+	}
+}
+
+func (h *SetSecurityPriceHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	date, err := parseLoanDate(r.PostFormValue(web.PaymentDateFormField))
+	var price float64
+	if err == nil {
+		price, err = parseLoanFloat(r.PostFormValue(web.PriceFormField), invalidPriceError)
+	}
+	if err == nil {
+		err = h.container.SecurityPricesService.SetPrice(r.Context(), project.ID, r.PostFormValue(web.TickerFormField), date, price)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to set security price", logging.Err(err))
+		h.investmentsComponent.RenderInvestment(w, r, project, access, accountID, "", fmt.Sprintf(setPriceError, err))
+		return
+	}
+
+	redirectToInvestmentWithSuccess(w, r, project.Slug, accountID, web.SuccessKeyPriceSaved)
+}
+
+type ImportSecurityPricesHandler struct {
+	container            *container.Container
+	investmentsComponent *components.InvestmentsComponent
+}
+
+func NewImportSecurityPricesHandler(container *container.Container, investmentsComponent *components.InvestmentsComponent) *ImportSecurityPricesHandler {
+	return &ImportSecurityPricesHandler{
+		container:            container,
+		investmentsComponent: investmentsComponent,
+	}
+}
+
+func (h *ImportSecurityPricesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile(web.PriceFileFormField)
+	if err != nil {
+		err = errors.New(missingPriceFileError)
+	} else {
+		defer file.Close()
+		_, err = h.container.SecurityPricesService.ImportPrices(r.Context(), project.ID, file)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to import security prices", logging.Err(err))
+		h.investmentsComponent.RenderInvestment(w, r, project, access, accountID, "", fmt.Sprintf(importPricesError, err))
+		return
+	}
+
+	redirectToInvestmentWithSuccess(w, r, project.Slug, accountID, web.SuccessKeyPricesImported)
+}
+
+func redirectToInvestmentWithSuccess(w http.ResponseWriter, r *http.Request, projectSlug string, accountID uuid.UUID, successKey string) {
+	route := strings.Replace(web.RouteInvestment, "{"+web.AccountIDParam+"}", accountID.String(), 1)
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, projectSlug, route), successKey)
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/cases/create_loan"
+	"gofin/internal/cases/record_loan_payment"
+	"gofin/internal/container"
+	"gofin/pkg/config"
+	"gofin/pkg/logging"
+	"gofin/pkg/money"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	createLoanError        = "Failed to create loan: %v"
+	addLoanRateError       = "Failed to add rate change: %v"
+	removeLoanRateError    = "Failed to remove rate change: %v"
+	recordLoanPaymentError = "Failed to record payment: %v"
+	invalidPeriodIDError   = "Invalid rate period ID"
+	invalidLoanRateError   = "invalid interest rate"
+	invalidLoanDateError   = "invalid date"
+	invalidLoanValueError  = "invalid amount"
+)
+
+type LoansHandler struct {
+	loansComponent *components.LoansComponent
+}
+
+func NewLoansHandler(loansComponent *components.LoansComponent) *LoansHandler {
+	return &LoansHandler{
+		loansComponent: loansComponent,
+	}
+}
+
+func (h *LoansHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	h.loansComponent.RenderLoans(w, r, project, access, "")
+}
+
+type CreateLoanHandler struct {
+	container      *container.Container
+	loansComponent *components.LoansComponent
+}
+
+func NewCreateLoanHandler(container *container.Container, loansComponent *components.LoansComponent) *CreateLoanHandler {
+	return &CreateLoanHandler{
+		container:      container,
+		loansComponent: loansComponent,
+	}
+}
+
+func (h *CreateLoanHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	data := create_loan.CreateLoanData{Name: r.PostFormValue("name")}
+
+	var err error
+	data.Currency, err = money.ParseCurrency(r.PostFormValue(web.AccountCurrencyFormField))
+	if err == nil {
+		data.Principal, err = parseLoanFloat(r.PostFormValue(web.PrincipalFormField), "invalid principal")
+	}
+	if err == nil {
+		data.AnnualRate, err = parseLoanFloat(r.PostFormValue(web.AnnualRateFormField), invalidLoanRateError)
+	}
+	if err == nil {
+		data.TermMonths, err = parseLoanInt(r.PostFormValue(web.TermMonthsFormField), "invalid term")
+	}
+	if err == nil {
+		data.PaymentDay, err = parseLoanInt(r.PostFormValue(web.PaymentDayFormField), "invalid payment day")
+	}
+	if err == nil {
+		data.StartDate, err = parseLoanDate(r.PostFormValue(web.StartDateFormField))
+	}
+
+	var accountID uuid.UUID
+	if err == nil {
+		loan, createErr := h.container.CreateLoanService.CreateLoan(r.Context(), project.ID, data)
+		if err = createErr; err == nil {
+			accountID = loan.AccountID
+		}
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to create loan", logging.Err(err))
+		h.loansComponent.RenderLoans(w, r, project, access, fmt.Sprintf(createLoanError, err))
+		return
+	}
+
+	redirectToLoanWithSuccess(w, r, project.Slug, accountID, web.SuccessKeyLoanCreated)
+}
+
+type LoanHandler struct {
+	loansComponent *components.LoansComponent
+}
+
+func NewLoanHandler(loansComponent *components.LoansComponent) *LoanHandler {
+	return &LoanHandler{
+		loansComponent: loansComponent,
+	}
+}
+
+func (h *LoanHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	h.loansComponent.RenderLoan(w, r, project, access, accountID, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+type AddLoanRateHandler struct {
+	container      *container.Container
+	loansComponent *components.LoansComponent
+}
+
+func NewAddLoanRateHandler(container *container.Container, loansComponent *components.LoansComponent) *AddLoanRateHandler {
+	return &AddLoanRateHandler{
+		container:      container,
+		loansComponent: loansComponent,
+	}
+}
+
+func (h *AddLoanRateHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	startsOn, err := parseLoanDate(r.PostFormValue(web.StartsOnFormField))
+	var rate float64
+	if err == nil {
+		rate, err = parseLoanFloat(r.PostFormValue(web.AnnualRateFormField), invalidLoanRateError)
+	}
+	if err == nil {
+		_, err = h.container.UpdateLoanService.AddRatePeriod(r.Context(), project.ID, accountID, startsOn, rate)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to add loan rate period", logging.Err(err))
+		h.loansComponent.RenderLoan(w, r, project, access, accountID, "", fmt.Sprintf(addLoanRateError, err))
+		return
+	}
+
+	redirectToLoanWithSuccess(w, r, project.Slug, accountID, web.SuccessKeyLoanUpdated)
+}
+
+type DeleteLoanRateHandler struct {
+	container      *container.Container
+	loansComponent *components.LoansComponent
+}
+
+func NewDeleteLoanRateHandler(container *container.Container, loansComponent *components.LoansComponent) *DeleteLoanRateHandler {
+	return &DeleteLoanRateHandler{
+		container:      container,
+		loansComponent: loansComponent,
+	}
+}
+
+func (h *DeleteLoanRateHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	periodID, err := uuid.Parse(chi.URLParam(r, web.PeriodIDParam))
+	if err != nil {
+		http.Error(w, invalidPeriodIDError, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.container.UpdateLoanService.RemoveRatePeriod(r.Context(), project.ID, accountID, periodID); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to remove loan rate period", logging.Err(err))
+		h.loansComponent.RenderLoan(w, r, project, access, accountID, "", fmt.Sprintf(removeLoanRateError, err))
+		return
+	}
+
+	redirectToLoanWithSuccess(w, r, project.Slug, accountID, web.SuccessKeyLoanUpdated)
+}
+
+type RecordLoanPaymentHandler struct {
+	container      *container.Container
+	loansComponent *components.LoansComponent
+}
+
+func NewRecordLoanPaymentHandler(container *container.Container, loansComponent *components.LoansComponent) *RecordLoanPaymentHandler {
+	return &RecordLoanPaymentHandler{
+		container:      container,
+		loansComponent: loansComponent,
+	}
+}
+
+func (h *RecordLoanPaymentHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	data := record_loan_payment.LoanPaymentData{LoanAccountID: accountID}
+	data.FromAccountID, err = uuid.Parse(r.PostFormValue(web.FromAccountFormField))
+	if err != nil {
+		err = errors.New("invalid paying account")
+	}
+	if err == nil {
+		data.Amount, err = parseLoanFloat(r.PostFormValue(web.AmountFormField), invalidLoanValueError)
+	}
+	if err == nil {
+		data.Date, err = parseLoanDate(r.PostFormValue(web.PaymentDateFormField))
+	}
+	if err == nil {
+		_, err = h.container.RecordLoanPaymentService.RecordPayment(r.Context(), project.ID, data)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to record loan payment", logging.Err(err))
+		h.loansComponent.RenderLoan(w, r, project, access, accountID, "", fmt.Sprintf(recordLoanPaymentError, err))
+		return
+	}
+
+	redirectToLoanWithSuccess(w, r, project.Slug, accountID, web.SuccessKeyLoanPaymentRecorded)
+}
+
+func redirectToLoanWithSuccess(w http.ResponseWriter, r *http.Request, projectSlug string, accountID uuid.UUID, successKey string) {
+	route := strings.Replace(web.RouteLoan, "{"+web.AccountIDParam+"}", accountID.String(), 1)
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, projectSlug, route), successKey)
+}
+
+func parseLoanFloat(value, message string) (float64, error) {
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, errors.New(message)
+	}
+	return parsed, nil
+}
+
+func parseLoanInt(value, message string) (int, error) {
+	parsed, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, errors.New(message)
+	}
+	return parsed, nil
+}
+
+func parseLoanDate(value string) (time.Time, error) {
+	date, err := time.Parse(config.DateFormat, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, errors.New(invalidLoanDateError)
+	}
+	return date, nil
+}
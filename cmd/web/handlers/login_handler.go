@@ -2,11 +2,14 @@ package handlers
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
 
 	"github.com/google/uuid"
 	"gofin/internal/container"
+	"gofin/pkg/logging"
+	"gofin/pkg/metrics"
 	"gofin/pkg/password"
 	"gofin/pkg/session"
 	webpkg "gofin/pkg/web"
@@ -47,25 +50,44 @@ func (h *LoginHandler) handleLoginPost(w http.ResponseWriter, r *http.Request, p
 		return
 	}
 
-	access, err := h.container.AccessRepository.GetByUID(projectID, uid)
+	access, err := h.container.AccessRepository.GetByUID(r.Context(), projectID, uid)
 	if err != nil {
+		logging.FromContext(r.Context()).Warn("login failed: unknown access", slog.String("uid", uid), logging.Err(err))
+		h.container.Metrics.IncLogin(metrics.LoginFailure)
 		h.loginComponent.RenderLoginPage(w, r, projectSlug, "Invalid credentials")
 		return
 	}
 
 	valid, err := password.Verify(pin, access.PinHash)
 	if err != nil || !valid {
+		logging.FromContext(r.Context()).Warn("login failed: invalid pin", slog.String("access_id", access.ID.String()))
+		h.container.Metrics.IncLogin(metrics.LoginFailure)
 		h.loginComponent.RenderLoginPage(w, r, projectSlug, "Invalid credentials")
 		return
 	}
 
+	if access.TOTPEnabled {
+		twoFactorToken, err := h.sessionManager.GenerateTwoFactorToken(access.ID.String(), projectID.String())
+		if err != nil {
+			logging.FromContext(r.Context()).Error("failed to create two-factor token", logging.Err(err))
+			h.loginComponent.RenderLoginPage(w, r, projectSlug, "Failed to create session")
+			return
+		}
+
+		h.sessionManager.SetTwoFactorCookie(w, twoFactorToken)
+		http.Redirect(w, r, webpkg.ProjectURL(r, projectSlug, web.RouteLoginTwoFactor), http.StatusSeeOther)
+		return
+	}
+
 	sessionToken, err := h.sessionManager.GenerateSessionToken(access.ID.String(), projectID.String())
 	if err != nil {
+		logging.FromContext(r.Context()).Error("failed to create session token", logging.Err(err))
 		h.loginComponent.RenderLoginPage(w, r, projectSlug, "Failed to create session")
 		return
 	}
 
-	session.SetSessionCookie(w, sessionToken)
+	h.sessionManager.SetSessionCookie(w, sessionToken)
+	h.container.Metrics.IncLogin(metrics.LoginSuccess)
 
 	webpkg.RedirectToProjectHomeWithSuccess(w, r, projectSlug, web.SuccessKeyLoginSuccessful)
 }
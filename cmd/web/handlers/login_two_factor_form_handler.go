@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gofin/pkg/session"
+	webpkg "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+type LoginTwoFactorFormHandler struct {
+	loginComponent *components.LoginComponent
+	sessionManager *session.SessionManager
+}
+
+func NewLoginTwoFactorFormHandler(loginComponent *components.LoginComponent, sessionManager *session.SessionManager) *LoginTwoFactorFormHandler {
+	return &LoginTwoFactorFormHandler{
+		loginComponent: loginComponent,
+		sessionManager: sessionManager,
+	}
+}
+
+func (h *LoginTwoFactorFormHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webpkg.GetProject(r.Context())
+
+	cookie, err := r.Cookie(web.TwoFactorCookie)
+	if err != nil || cookie.Value == web.EmptyString {
+		webpkg.RedirectToProjectLogin(w, r, project.Slug)
+		return
+	}
+
+	token, valid := h.sessionManager.ValidateTwoFactorToken(cookie.Value)
+	if !valid || token.ProjectID != project.ID.String() {
+		h.sessionManager.ClearTwoFactorCookie(w)
+		webpkg.RedirectToProjectLogin(w, r, project.Slug)
+		return
+	}
+
+	h.loginComponent.RenderTwoFactorPage(w, r, project.Slug, web.EmptyString)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/verify_two_factor"
+	"gofin/internal/container"
+	"gofin/pkg/logging"
+	"gofin/pkg/metrics"
+	"gofin/pkg/session"
+	webpkg "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+type LoginTwoFactorHandler struct {
+	container      *container.Container
+	loginComponent *components.LoginComponent
+	sessionManager *session.SessionManager
+}
+
+func NewLoginTwoFactorHandler(container *container.Container, loginComponent *components.LoginComponent, sessionManager *session.SessionManager) *LoginTwoFactorHandler {
+	return &LoginTwoFactorHandler{
+		container:      container,
+		loginComponent: loginComponent,
+		sessionManager: sessionManager,
+	}
+}
+
+func (h *LoginTwoFactorHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webpkg.GetProject(r.Context())
+
+	cookie, err := r.Cookie(web.TwoFactorCookie)
+	if err != nil || cookie.Value == web.EmptyString {
+		webpkg.RedirectToProjectLogin(w, r, project.Slug)
+		return
+	}
+
+	token, valid := h.sessionManager.ValidateTwoFactorToken(cookie.Value)
+	if !valid || token.ProjectID != project.ID.String() {
+		h.sessionManager.ClearTwoFactorCookie(w)
+		webpkg.RedirectToProjectLogin(w, r, project.Slug)
+		return
+	}
+
+	accessID, err := uuid.Parse(token.AccessID)
+	if err != nil {
+		h.sessionManager.ClearTwoFactorCookie(w)
+		webpkg.RedirectToProjectLogin(w, r, project.Slug)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.loginComponent.RenderTwoFactorPage(w, r, project.Slug, "Invalid form data")
+		return
+	}
+
+	access, err := h.container.VerifyTwoFactorService.Verify(r.Context(), accessID, r.FormValue("code"))
+	if errors.Is(err, verify_two_factor.ErrTooManyAttempts) {
+		logging.FromContext(r.Context()).Warn("two-factor verification locked", slog.String("access_id", accessID.String()))
+		h.container.Metrics.IncLogin(metrics.LoginFailure)
+		h.sessionManager.ClearTwoFactorCookie(w)
+		h.loginComponent.RenderLoginPage(w, r, project.Slug, "Too many failed attempts, try again later")
+		return
+	}
+	if err != nil || access.ProjectID != project.ID {
+		logging.FromContext(r.Context()).Warn("two-factor verification failed", slog.String("access_id", accessID.String()), logging.Err(err))
+		h.container.Metrics.IncLogin(metrics.LoginFailure)
+		h.loginComponent.RenderTwoFactorPage(w, r, project.Slug, "Invalid authentication code")
+		return
+	}
+
+	sessionToken, err := h.sessionManager.GenerateSessionToken(access.ID.String(), project.ID.String())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("failed to create session token", logging.Err(err))
+		h.loginComponent.RenderTwoFactorPage(w, r, project.Slug, "Failed to create session")
+		return
+	}
+
+	h.sessionManager.ClearTwoFactorCookie(w)
+	h.sessionManager.SetSessionCookie(w, sessionToken)
+	h.container.Metrics.IncLogin(metrics.LoginSuccess)
+
+	webpkg.RedirectToProjectHomeWithSuccess(w, r, project.Slug, web.SuccessKeyLoginSuccessful)
+}
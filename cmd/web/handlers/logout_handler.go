@@ -6,23 +6,24 @@ import (
 	"gofin/internal/container"
 	"gofin/pkg/session"
 	webcontext "gofin/pkg/web"
-	webpkg "gofin/pkg/web"
 )
 
 type LogoutHandler struct {
-	container *container.Container
+	container      *container.Container
+	sessionManager *session.SessionManager
 }
 
-func NewLogoutHandler(container *container.Container) *LogoutHandler {
+func NewLogoutHandler(container *container.Container, sessionManager *session.SessionManager) *LogoutHandler {
 	return &LogoutHandler{
-		container: container,
+		container:      container,
+		sessionManager: sessionManager,
 	}
 }
 
 func (h *LogoutHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	project, _ := webcontext.GetProject(r.Context())
 
-	session.ClearSessionCookie(w)
+	h.sessionManager.ClearSessionCookie(w)
 
-	webpkg.RedirectToProjectLogin(w, r, project.Slug)
+	webcontext.RedirectToProjectLogin(w, r, project.Slug)
 }
@@ -5,7 +5,6 @@ import (
 
 	"gofin/internal/container"
 	webcontext "gofin/pkg/web"
-	webpkg "gofin/pkg/web"
 	"gofin/web"
 )
 
@@ -25,9 +24,9 @@ func (h *MainHandler) Handle(w http.ResponseWriter, req *http.Request) {
 	cookie, err := req.Cookie(web.SessionTokenCookie)
 
 	if err != nil || cookie.Value == web.EmptyString {
-		webpkg.RedirectToProjectLogin(w, req, project.Slug)
+		webcontext.RedirectToProjectLogin(w, req, project.Slug)
 		return
 	}
 
-	webpkg.RedirectToProjectDashboard(w, req, project.Slug)
+	webcontext.RedirectToProjectDashboard(w, req, project.Slug)
 }
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/cases/create_payee"
+	"gofin/internal/container"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	createPayeeError      = "Failed to create payee: %v"
+	addAliasError         = "Failed to add alias: %v"
+	removeAliasError      = "Failed to remove alias: %v"
+	updatePayeeError      = "Failed to save payee: %v"
+	mergePayeesError      = "Failed to merge payees: %v"
+	invalidPayeeError     = "Invalid payee"
+	invalidPayeeIDMessage = "Invalid payee ID"
+)
+
+type PayeesHandler struct {
+	payeesComponent *components.PayeesComponent
+}
+
+func NewPayeesHandler(payeesComponent *components.PayeesComponent) *PayeesHandler {
+	return &PayeesHandler{
+		payeesComponent: payeesComponent,
+	}
+}
+
+func (h *PayeesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	h.payeesComponent.RenderPayees(w, r, project, access, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+type CreatePayeeHandler struct {
+	container       *container.Container
+	payeesComponent *components.PayeesComponent
+}
+
+func NewCreatePayeeHandler(container *container.Container, payeesComponent *components.PayeesComponent) *CreatePayeeHandler {
+	return &CreatePayeeHandler{
+		container:       container,
+		payeesComponent: payeesComponent,
+	}
+}
+
+func (h *CreatePayeeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	categoryID, err := parseOptionalID(r.PostFormValue(web.CategoryFormField), invalidCategoryError)
+	var payeeID uuid.UUID
+	if err == nil {
+		var aliases []string
+		for _, alias := range strings.Split(r.PostFormValue(web.AliasesFormField), ",") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				aliases = append(aliases, alias)
+			}
+		}
+
+		payee, createErr := h.container.CreatePayeeService.CreatePayee(r.Context(), project.ID, create_payee.CreatePayeeData{
+			Name:              r.PostFormValue("name"),
+			Aliases:           aliases,
+			DefaultCategoryID: categoryID,
+		})
+		if err = createErr; err == nil {
+			payeeID = payee.ID
+		}
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to create payee", logging.Err(err))
+		h.payeesComponent.RenderPayees(w, r, project, access, "", fmt.Sprintf(createPayeeError, err))
+		return
+	}
+
+	redirectToPayeeWithSuccess(w, r, project.Slug, payeeID, web.SuccessKeyPayeeCreated)
+}
+
+type PayeeHandler struct {
+	payeesComponent *components.PayeesComponent
+}
+
+func NewPayeeHandler(payeesComponent *components.PayeesComponent) *PayeeHandler {
+	return &PayeeHandler{
+		payeesComponent: payeesComponent,
+	}
+}
+
+func (h *PayeeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	payeeID, err := uuid.Parse(chi.URLParam(r, web.PayeeIDParam))
+	if err != nil {
+		http.Error(w, invalidPayeeIDMessage, http.StatusBadRequest)
+		return
+	}
+
+	h.payeesComponent.RenderPayee(w, r, project, access, payeeID, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+type AddPayeeAliasHandler struct {
+	container       *container.Container
+	payeesComponent *components.PayeesComponent
+}
+
+func NewAddPayeeAliasHandler(container *container.Container, payeesComponent *components.PayeesComponent) *AddPayeeAliasHandler {
+	return &AddPayeeAliasHandler{
+		container:       container,
+		payeesComponent: payeesComponent,
+	}
+}
+
+func (h *AddPayeeAliasHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	payeeID, err := uuid.Parse(chi.URLParam(r, web.PayeeIDParam))
+	if err != nil {
+		http.Error(w, invalidPayeeIDMessage, http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.container.UpdatePayeeService.AddAlias(r.Context(), project.ID, payeeID, r.PostFormValue(web.AliasFormField)); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to add payee alias", logging.Err(err))
+		h.payeesComponent.RenderPayee(w, r, project, access, payeeID, "", fmt.Sprintf(addAliasError, err))
+		return
+	}
+
+	redirectToPayeeWithSuccess(w, r, project.Slug, payeeID, web.SuccessKeyPayeeUpdated)
+}
+
+type DeletePayeeAliasHandler struct {
+	container       *container.Container
+	payeesComponent *components.PayeesComponent
+}
+
+func NewDeletePayeeAliasHandler(container *container.Container, payeesComponent *components.PayeesComponent) *DeletePayeeAliasHandler {
+	return &DeletePayeeAliasHandler{
+		container:       container,
+		payeesComponent: payeesComponent,
+	}
+}
+
+func (h *DeletePayeeAliasHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	payeeID, err := uuid.Parse(chi.URLParam(r, web.PayeeIDParam))
+	if err != nil {
+		http.Error(w, invalidPayeeIDMessage, http.StatusBadRequest)
+		return
+	}
+
+	aliasID, err := uuid.Parse(chi.URLParam(r, web.AliasIDParam))
+	if err != nil {
+		http.Error(w, "Invalid alias ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.container.UpdatePayeeService.RemoveAlias(r.Context(), project.ID, payeeID, aliasID); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to remove payee alias", logging.Err(err))
+		h.payeesComponent.RenderPayee(w, r, project, access, payeeID, "", fmt.Sprintf(removeAliasError, err))
+		return
+	}
+
+	redirectToPayeeWithSuccess(w, r, project.Slug, payeeID, web.SuccessKeyPayeeUpdated)
+}
+
+type UpdatePayeeCategoryHandler struct {
+	container       *container.Container
+	payeesComponent *components.PayeesComponent
+}
+
+func NewUpdatePayeeCategoryHandler(container *container.Container, payeesComponent *components.PayeesComponent) *UpdatePayeeCategoryHandler {
+	return &UpdatePayeeCategoryHandler{
+		container:       container,
+		payeesComponent: payeesComponent,
+	}
+}
+
+func (h *UpdatePayeeCategoryHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	payeeID, err := uuid.Parse(chi.URLParam(r, web.PayeeIDParam))
+	if err != nil {
+		http.Error(w, invalidPayeeIDMessage, http.StatusBadRequest)
+		return
+	}
+
+	categoryID, err := parseOptionalID(r.PostFormValue(web.CategoryFormField), invalidCategoryError)
+	if err == nil {
+		err = h.container.UpdatePayeeService.SetDefaultCategory(r.Context(), project.ID, payeeID, categoryID)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to update payee category", logging.Err(err))
+		h.payeesComponent.RenderPayee(w, r, project, access, payeeID, "", fmt.Sprintf(updatePayeeError, err))
+		return
+	}
+
+	redirectToPayeeWithSuccess(w, r, project.Slug, payeeID, web.SuccessKeyPayeeUpdated)
+}
+
+type MergePayeeHandler struct {
+	container       *container.Container
+	payeesComponent *components.PayeesComponent
+}
+
+func NewMergePayeeHandler(container *container.Container, payeesComponent *components.PayeesComponent) *MergePayeeHandler {
+	return &MergePayeeHandler{
+		container:       container,
+		payeesComponent: payeesComponent,
+	}
+}
+
+func (h *MergePayeeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	payeeID, err := uuid.Parse(chi.URLParam(r, web.PayeeIDParam))
+	if err != nil {
+		http.Error(w, invalidPayeeIDMessage, http.StatusBadRequest)
+		return
+	}
+
+	targetID, err := uuid.Parse(r.PostFormValue(web.MergeTargetFormField))
+	if err != nil {
+		h.payeesComponent.RenderPayee(w, r, project, access, payeeID, "", fmt.Sprintf(mergePayeesError, invalidPayeeError))
+		return
+	}
+
+	if _, err := h.container.MergePayeesService.MergePayees(r.Context(), project.ID, payeeID, targetID); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to merge payees", logging.Err(err))
+		h.payeesComponent.RenderPayee(w, r, project, access, payeeID, "", fmt.Sprintf(mergePayeesError, err))
+		return
+	}
+
+	redirectToPayeeWithSuccess(w, r, project.Slug, targetID, web.SuccessKeyPayeesMerged)
+}
+
+type UpdateTransactionPayeeHandler struct {
+	container        *container.Container
+	detailsComponent *components.TransactionDetailsComponent
+}
+
+func NewUpdateTransactionPayeeHandler(container *container.Container, detailsComponent *components.TransactionDetailsComponent) *UpdateTransactionPayeeHandler {
+	return &UpdateTransactionPayeeHandler{
+		container:        container,
+		detailsComponent: detailsComponent,
+	}
+}
+
+func (h *UpdateTransactionPayeeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	transactionID, err := uuid.Parse(chi.URLParam(r, web.TransactionIDParam))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	payeeID, err := parseOptionalID(r.PostFormValue(web.PayeeFormField), invalidPayeeError)
+	if err == nil {
+		err = h.container.AssignTransactionPayeeService.AssignPayee(r.Context(), project.ID, transactionID, payeeID)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to update transaction payee", logging.Err(err))
+		renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, "", fmt.Sprintf(updatePayeeError, err))
+		return
+	}
+
+	redirectToTransactionWithSuccess(w, r, project.Slug, transactionID, web.SuccessKeyPayeeUpdated)
+}
+
+// parseOptionalID reads an ID from a select whose empty option means none.
+func parseOptionalID(value, invalidMessage string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		return nil, errors.New(invalidMessage)
+	}
+
+	return &parsed, nil
+}
+
+func redirectToPayeeWithSuccess(w http.ResponseWriter, r *http.Request, projectSlug string, payeeID uuid.UUID, successKey string) {
+	route := strings.Replace(web.RoutePayee, "{"+web.PayeeIDParam+"}", payeeID.String(), 1)
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, projectSlug, route), successKey)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	closePeriodError   = "Failed to close period: %v"
+	invalidMonthError  = "invalid month"
+	invalidYearError   = "invalid year"
+	closeMonthInFormat = "2006-01"
+)
+
+type PeriodsHandler struct {
+	periodsComponent *components.PeriodsComponent
+}
+
+func NewPeriodsHandler(periodsComponent *components.PeriodsComponent) *PeriodsHandler {
+	return &PeriodsHandler{
+		periodsComponent: periodsComponent,
+	}
+}
+
+func (h *PeriodsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	h.periodsComponent.RenderPeriods(w, r, project, access, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+// ClosePeriodHandler closes the books up to the end of the submitted month or
+// year, recording the access that did it in the audit trail.
+type ClosePeriodHandler struct {
+	container        *container.Container
+	periodsComponent *components.PeriodsComponent
+}
+
+func NewClosePeriodHandler(container *container.Container, periodsComponent *components.PeriodsComponent) *ClosePeriodHandler {
+	return &ClosePeriodHandler{
+		container:        container,
+		periodsComponent: periodsComponent,
+	}
+}
+
+func (h *ClosePeriodHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	until, err := parseClosingDate(r)
+	if err == nil {
+		_, err = h.container.ClosePeriodService.ClosePeriod(r.Context(), project.ID, until, access.Name)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to close period", logging.Err(err))
+		h.periodsComponent.RenderPeriods(w, r, project, access, "", fmt.Sprintf(closePeriodError, err))
+		return
+	}
+
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, project.Slug, web.RoutePeriods), web.SuccessKeyPeriodClosed)
+}
+
+func parseClosingDate(r *http.Request) (time.Time, error) {
+	if month := strings.TrimSpace(r.PostFormValue(web.CloseMonthFormField)); month != "" {
+		start, err := time.Parse(closeMonthInFormat, month)
+		if err != nil {
+			return time.Time{}, errors.New(invalidMonthError)
+		}
+		return models.MonthEnd(start.Year(), start.Month()), nil
+	}
+
+	year, err := strconv.Atoi(strings.TrimSpace(r.PostFormValue(web.CloseYearFormField)))
+	if err != nil {
+		return time.Time{}, errors.New(invalidYearError)
+	}
+	return models.MonthEnd(year, time.December), nil
+}
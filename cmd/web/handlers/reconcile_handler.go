@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	startReconcileError          = "Failed to start reconciliation: %v"
+	saveReconcileError           = "Failed to save reconciliation: %v"
+	cancelReconcileError         = "Failed to cancel reconciliation: %v"
+	invalidReconciliationIDError = "Invalid reconciliation ID"
+	invalidStatementBalanceError = "invalid statement balance"
+	invalidClearedIDError        = "invalid transaction ID in the cleared list"
+)
+
+type ReconcileHandler struct {
+	reconcileComponent *components.ReconcileComponent
+}
+
+func NewReconcileHandler(reconcileComponent *components.ReconcileComponent) *ReconcileHandler {
+	return &ReconcileHandler{
+		reconcileComponent: reconcileComponent,
+	}
+}
+
+func (h *ReconcileHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	h.reconcileComponent.RenderReconcile(w, r, project, access, accountID, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+type StartReconciliationHandler struct {
+	container          *container.Container
+	reconcileComponent *components.ReconcileComponent
+}
+
+func NewStartReconciliationHandler(container *container.Container, reconcileComponent *components.ReconcileComponent) *StartReconciliationHandler {
+	return &StartReconciliationHandler{
+		container:          container,
+		reconcileComponent: reconcileComponent,
+	}
+}
+
+func (h *StartReconciliationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	date, err := parseLoanDate(r.PostFormValue(web.StatementDateFormField))
+	var balance float64
+	if err == nil {
+		balance, err = parseLoanFloat(r.PostFormValue(web.StatementBalanceFormField), invalidStatementBalanceError)
+	}
+	if err == nil {
+		_, err = h.container.ReconcileAccountService.StartReconciliation(r.Context(), project.ID, accountID, date, balance)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to start reconciliation", logging.Err(err))
+		h.reconcileComponent.RenderReconcile(w, r, project, access, accountID, "", fmt.Sprintf(startReconcileError, err))
+		return
+	}
+
+	redirectToReconcileWithSuccess(w, r, project.Slug, accountID, web.SuccessKeyReconcileStarted)
+}
+
+// SaveReconciliationHandler stores the ticked transactions of an open
+// reconciliation and, when the finish button was used, closes it.
+type SaveReconciliationHandler struct {
+	container          *container.Container
+	reconcileComponent *components.ReconcileComponent
+}
+
+func NewSaveReconciliationHandler(container *container.Container, reconcileComponent *components.ReconcileComponent) *SaveReconciliationHandler {
+	return &SaveReconciliationHandler{
+		container:          container,
+		reconcileComponent: reconcileComponent,
+	}
+}
+
+func (h *SaveReconciliationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	reconciliationID, err := uuid.Parse(chi.URLParam(r, web.ReconcileIDParam))
+	if err != nil {
+		http.Error(w, invalidReconciliationIDError, http.StatusBadRequest)
+		return
+	}
+
+	clearedIDs, err := parseClearedIDs(r)
+	successKey := web.SuccessKeyReconcileSaved
+	if err == nil && r.PostFormValue(web.ReconcileActionFormField) == web.ReconcileActionFinish {
+		successKey = web.SuccessKeyReconcileFinished
+		err = h.container.ReconcileAccountService.Finish(r.Context(), project.ID, reconciliationID, clearedIDs)
+	} else if err == nil {
+		_, err = h.container.ReconcileAccountService.Save(r.Context(), project.ID, reconciliationID, clearedIDs)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to save reconciliation", logging.Err(err))
+		h.reconcileComponent.RenderReconcile(w, r, project, access, accountID, "", fmt.Sprintf(saveReconcileError, err))
+		return
+	}
+
+	redirectToReconcileWithSuccess(w, r, project.Slug, accountID, successKey)
+}
+
+type CancelReconciliationHandler struct {
+	container          *container.Container
+	reconcileComponent *components.ReconcileComponent
+}
+
+func NewCancelReconciliationHandler(container *container.Container, reconcileComponent *components.ReconcileComponent) *CancelReconciliationHandler {
+	return &CancelReconciliationHandler{
+		container:          container,
+		reconcileComponent: reconcileComponent,
+	}
+}
+
+func (h *CancelReconciliationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	accountID, err := uuid.Parse(chi.URLParam(r, web.AccountIDParam))
+	if err != nil {
+		http.Error(w, invalidAccountIDError, http.StatusBadRequest)
+		return
+	}
+
+	reconciliationID, err := uuid.Parse(chi.URLParam(r, web.ReconcileIDParam))
+	if err != nil {
+		http.Error(w, invalidReconciliationIDError, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.container.ReconcileAccountService.Cancel(r.Context(), project.ID, reconciliationID); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to cancel reconciliation", logging.Err(err))
+		h.reconcileComponent.RenderReconcile(w, r, project, access, accountID, "", fmt.Sprintf(cancelReconcileError, err))
+		return
+	}
+
+	redirectToReconcileWithSuccess(w, r, project.Slug, accountID, web.SuccessKeyReconcileCancelled)
+}
+
+func parseClearedIDs(r *http.Request) ([]uuid.UUID, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(r.PostForm[web.ClearedFormField]))
+	for _, value := range r.PostForm[web.ClearedFormField] {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, errors.New(invalidClearedIDError)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func redirectToReconcileWithSuccess(w http.ResponseWriter, r *http.Request, projectSlug string, accountID uuid.UUID, successKey string) {
+	route := strings.Replace(web.RouteReconcile, "{"+web.AccountIDParam+"}", accountID.String(), 1)
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, projectSlug, route), successKey)
+}
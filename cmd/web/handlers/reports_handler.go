@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gofin/internal/cases/get_reports"
+	"gofin/internal/container"
+	"gofin/pkg/config"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	reportError         = "Failed to build report: %v"
+	invalidReportFrom   = "invalid start date"
+	invalidReportTo     = "invalid end date"
+	defaultReportMonths = 12
+)
+
+// ReportsHandler renders the reports page for the range and currency in the
+// query string, the last twelve months by default.
+type ReportsHandler struct {
+	container        *container.Container
+	reportsComponent *components.ReportsComponent
+}
+
+func NewReportsHandler(container *container.Container, reportsComponent *components.ReportsComponent) *ReportsHandler {
+	return &ReportsHandler{
+		container:        container,
+		reportsComponent: reportsComponent,
+	}
+}
+
+func (h *ReportsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	query, err := parseReportQuery(r)
+	var report *get_reports.Report
+	if err == nil {
+		query.ProjectID = project.ID
+		report, err = h.container.GetReportsService.GetReport(r.Context(), query)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to build report", logging.Err(err))
+		h.reportsComponent.RenderReports(w, r, project, query, nil, fmt.Sprintf(reportError, err))
+		return
+	}
+
+	h.reportsComponent.RenderReports(w, r, project, query, report, "")
+}
+
+// ReportsDataHandler serves the same report as the reports page as JSON.
+type ReportsDataHandler struct {
+	container *container.Container
+}
+
+func NewReportsDataHandler(container *container.Container) *ReportsDataHandler {
+	return &ReportsDataHandler{
+		container: container,
+	}
+}
+
+type ReportsDataResponse struct {
+	*get_reports.Report
+	Error string `json:"error,omitempty"`
+}
+
+func (h *ReportsDataHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	query, err := parseReportQuery(r)
+	var report *get_reports.Report
+	if err == nil {
+		query.ProjectID = project.ID
+		report, err = h.container.GetReportsService.GetReport(r.Context(), query)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to build report", logging.Err(err))
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ReportsDataResponse{Error: err.Error()})
+		return
+	}
+
+	json.NewEncoder(w).Encode(ReportsDataResponse{Report: report})
+}
+
+// parseReportQuery reads the range and currency of a report, defaulting to the
+// current month and the eleven before it. The returned query keeps the defaults
+// even when parsing fails, so the page can still show the filter form.
+func parseReportQuery(r *http.Request) (get_reports.ReportQuery, error) {
+	now := time.Now()
+	query := get_reports.ReportQuery{
+		From:     time.Date(now.Year(), now.Month()-defaultReportMonths+1, 1, 0, 0, 0, 0, time.UTC),
+		To:       time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		Currency: strings.TrimSpace(r.URL.Query().Get(web.ReportCurrencyParam)),
+	}
+
+	if from := strings.TrimSpace(r.URL.Query().Get(web.ReportFromParam)); from != "" {
+		parsed, err := time.Parse(config.DateFormat, from)
+		if err != nil {
+			return query, errors.New(invalidReportFrom)
+		}
+		query.From = parsed
+	}
+
+	if to := strings.TrimSpace(r.URL.Query().Get(web.ReportToParam)); to != "" {
+		parsed, err := time.Parse(config.DateFormat, to)
+		if err != nil {
+			return query, errors.New(invalidReportTo)
+		}
+		query.To = parsed
+	}
+
+	return query, nil
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const searchTransactionsError = "Search failed: %v"
+
+type SearchTransactionsHandler struct {
+	container       *container.Container
+	searchComponent *components.TransactionSearchComponent
+}
+
+func NewSearchTransactionsHandler(container *container.Container, searchComponent *components.TransactionSearchComponent) *SearchTransactionsHandler {
+	return &SearchTransactionsHandler{
+		container:       container,
+		searchComponent: searchComponent,
+	}
+}
+
+func (h *SearchTransactionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	query, err := parseTransactionQuery(r.URL.Query())
+	if err != nil {
+		h.searchComponent.RenderSearch(w, r, project, nil, fmt.Sprintf(searchTransactionsError, err))
+		return
+	}
+
+	page, err := h.container.SearchTransactionsService.Search(r.Context(), project.ID, query)
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to search transactions", logging.Err(err))
+		h.searchComponent.RenderSearch(w, r, project, nil, fmt.Sprintf(searchTransactionsError, err))
+		return
+	}
+
+	h.searchComponent.RenderSearch(w, r, project, page, "")
+}
+
+// parseTransactionQuery reads the search filters shared by the search page and
+// the transaction export. The end date includes the whole day.
+func parseTransactionQuery(params url.Values) (models.TransactionQuery, error) {
+	query := models.TransactionQuery{
+		Search:        params.Get(web.SearchParamQuery),
+		Type:          models.TransactionType(params.Get(web.SearchParamType)),
+		SortBy:        models.TransactionSortField(params.Get(web.SearchParamSort)),
+		SortDirection: models.SortDirection(params.Get(web.SearchParamDirection)),
+		Cursor:        params.Get(web.SearchParamCursor),
+	}
+
+	if from := params.Get(web.SearchParamFrom); from != web.EmptyString {
+		start, err := time.Parse(config.DateFormat, from)
+		if err != nil {
+			return query, fmt.Errorf("invalid start date: %w", err)
+		}
+		query.StartDate = &start
+	}
+
+	if to := params.Get(web.SearchParamTo); to != web.EmptyString {
+		end, err := time.Parse(config.DateFormat, to)
+		if err != nil {
+			return query, fmt.Errorf("invalid end date: %w", err)
+		}
+		end = end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		query.EndDate = &end
+	}
+
+	minValue, err := parseAmount(params.Get(web.SearchParamMinValue))
+	if err != nil {
+		return query, fmt.Errorf("invalid min amount: %w", err)
+	}
+	query.MinValue = minValue
+
+	maxValue, err := parseAmount(params.Get(web.SearchParamMaxValue))
+	if err != nil {
+		return query, fmt.Errorf("invalid max amount: %w", err)
+	}
+	query.MaxValue = maxValue
+
+	for _, accountID := range params[web.SearchParamAccount] {
+		if accountID == web.EmptyString {
+			continue
+		}
+
+		parsed, err := uuid.Parse(accountID)
+		if err != nil {
+			return query, fmt.Errorf("invalid account: %w", err)
+		}
+		query.AccountIDs = append(query.AccountIDs, parsed)
+	}
+
+	return query, nil
+}
+
+func parseAmount(value string) (*float64, error) {
+	if value == web.EmptyString {
+		return nil, nil
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &amount, nil
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/cases/share_expense"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	shareExpenseError   = "Failed to save shared expense: %v"
+	unshareExpenseError = "Failed to stop sharing: %v"
+	invalidPayerError   = "Invalid payer"
+	invalidMemberError  = "Invalid member"
+	invalidShareError   = "Invalid value for participant %d"
+)
+
+type ShareTransactionHandler struct {
+	container        *container.Container
+	detailsComponent *components.TransactionDetailsComponent
+}
+
+func NewShareTransactionHandler(container *container.Container, detailsComponent *components.TransactionDetailsComponent) *ShareTransactionHandler {
+	return &ShareTransactionHandler{
+		container:        container,
+		detailsComponent: detailsComponent,
+	}
+}
+
+func (h *ShareTransactionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	transactionID, err := uuid.Parse(chi.URLParam(r, web.TransactionIDParam))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	data, err := parseShareForm(r)
+	if err == nil {
+		_, err = h.container.ShareExpenseService.ShareTransaction(r.Context(), project.ID, transactionID, data)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to share expense", logging.Err(err))
+		renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, "", fmt.Sprintf(shareExpenseError, err))
+		return
+	}
+
+	redirectToTransactionWithSuccess(w, r, project.Slug, transactionID, web.SuccessKeyExpenseShared)
+}
+
+// parseShareForm reads the payer, the split method and the ticked members. The value
+// next to each member is a percentage or an amount depending on the method.
+func parseShareForm(r *http.Request) (share_expense.ShareExpenseData, error) {
+	var data share_expense.ShareExpenseData
+
+	if err := r.ParseForm(); err != nil {
+		return data, errors.New(formParseError)
+	}
+
+	payerID, err := uuid.Parse(r.PostForm.Get(web.PayerFormField))
+	if err != nil {
+		return data, errors.New(invalidPayerError)
+	}
+	data.PayerID = payerID
+
+	data.Method, err = models.ParseShareMethod(r.PostForm.Get(web.ShareMethodFormField))
+	if err != nil {
+		return data, err
+	}
+
+	for i, memberStr := range r.PostForm[web.ShareMemberFormField] {
+		accessID, err := uuid.Parse(memberStr)
+		if err != nil {
+			return data, errors.New(invalidMemberError)
+		}
+
+		participant := models.ShareData{AccessID: accessID}
+		if data.Method != models.ShareEqual {
+			valueStr := strings.TrimSpace(r.PostForm.Get(web.ShareValueFormField + memberStr))
+			value, err := strconv.ParseFloat(valueStr, 64)
+			if err != nil {
+				return data, fmt.Errorf(invalidShareError, i+1)
+			}
+			if data.Method == models.SharePercentage {
+				participant.Percentage = value
+			} else {
+				participant.Amount = value
+			}
+		}
+
+		data.Participants = append(data.Participants, participant)
+	}
+
+	return data, nil
+}
+
+type UnshareTransactionHandler struct {
+	container        *container.Container
+	detailsComponent *components.TransactionDetailsComponent
+}
+
+func NewUnshareTransactionHandler(container *container.Container, detailsComponent *components.TransactionDetailsComponent) *UnshareTransactionHandler {
+	return &UnshareTransactionHandler{
+		container:        container,
+		detailsComponent: detailsComponent,
+	}
+}
+
+func (h *UnshareTransactionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	transactionID, err := uuid.Parse(chi.URLParam(r, web.TransactionIDParam))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.container.ShareExpenseService.UnshareTransaction(r.Context(), project.ID, transactionID); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to unshare expense", logging.Err(err))
+		renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, "", fmt.Sprintf(unshareExpenseError, err))
+		return
+	}
+
+	redirectToTransactionWithSuccess(w, r, project.Slug, transactionID, web.SuccessKeyExpenseUnshared)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+type TransactionDetailsHandler struct {
+	container        *container.Container
+	detailsComponent *components.TransactionDetailsComponent
+}
+
+func NewTransactionDetailsHandler(container *container.Container, detailsComponent *components.TransactionDetailsComponent) *TransactionDetailsHandler {
+	return &TransactionDetailsHandler{
+		container:        container,
+		detailsComponent: detailsComponent,
+	}
+}
+
+func (h *TransactionDetailsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := uuid.Parse(chi.URLParam(r, web.TransactionIDParam))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, r.URL.Query().Get(web.SuccessQueryParam), "")
+}
+
+// renderTransactionDetails shows the transaction page, answering 404 for transactions
+// outside the current project.
+func renderTransactionDetails(w http.ResponseWriter, r *http.Request, container *container.Container, detailsComponent *components.TransactionDetailsComponent, transactionID uuid.UUID, successKey, errorMsg string) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	attachments, err := container.AttachmentsService.List(r.Context(), project.ID, transactionID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	transaction, err := container.TransactionRepository.GetByID(r.Context(), transactionID)
+	if err != nil {
+		webcontext.ServerError(w, r, "Failed to get transaction", err)
+		return
+	}
+
+	detailsComponent.RenderDetails(w, r, project, access, transaction, attachments, successKey, errorMsg)
+}
+
+func redirectToTransactionWithSuccess(w http.ResponseWriter, r *http.Request, projectSlug string, transactionID uuid.UUID, successKey string) {
+	route := strings.Replace(web.RouteTransaction, "{"+web.TransactionIDParam+"}", transactionID.String(), 1)
+	webcontext.RedirectWithSuccess(w, r, webcontext.ProjectURL(r, projectSlug, route), successKey)
+}
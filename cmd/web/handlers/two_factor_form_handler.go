@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"gofin/internal/cases/enroll_two_factor"
+	"gofin/internal/container"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+type TwoFactorFormHandler struct {
+	container          *container.Container
+	twoFactorComponent *components.TwoFactorComponent
+}
+
+func NewTwoFactorFormHandler(container *container.Container, twoFactorComponent *components.TwoFactorComponent) *TwoFactorFormHandler {
+	return &TwoFactorFormHandler{
+		container:          container,
+		twoFactorComponent: twoFactorComponent,
+	}
+}
+
+func (h *TwoFactorFormHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+	access, _ := webcontext.GetAccess(r.Context())
+
+	var enrollment *enroll_two_factor.EnrollmentData
+	if !access.TOTPEnabled {
+		var err error
+		enrollment, err = h.container.EnrollTwoFactorService.StartEnrollment(r.Context(), access.ID)
+		if err != nil {
+			webcontext.ServerError(w, r, "Failed to start two-factor enrollment", err)
+			return
+		}
+	}
+
+	h.twoFactorComponent.RenderTwoFactorPage(w, r, project, access, enrollment, nil, web.EmptyString)
+}
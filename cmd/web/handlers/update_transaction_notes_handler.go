@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const updateNotesError = "Failed to save notes: %v"
+
+type UpdateTransactionNotesHandler struct {
+	container        *container.Container
+	detailsComponent *components.TransactionDetailsComponent
+}
+
+func NewUpdateTransactionNotesHandler(container *container.Container, detailsComponent *components.TransactionDetailsComponent) *UpdateTransactionNotesHandler {
+	return &UpdateTransactionNotesHandler{
+		container:        container,
+		detailsComponent: detailsComponent,
+	}
+}
+
+func (h *UpdateTransactionNotesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	transactionID, err := uuid.Parse(chi.URLParam(r, web.TransactionIDParam))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.container.UpdateTransactionNotesService.UpdateNotes(r.Context(), project.ID, transactionID, r.PostFormValue("notes"))
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to update transaction notes", logging.Err(err))
+		renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, "", fmt.Sprintf(updateNotesError, err))
+		return
+	}
+
+	redirectToTransactionWithSuccess(w, r, project.Slug, transactionID, web.SuccessKeyNotesUpdated)
+}
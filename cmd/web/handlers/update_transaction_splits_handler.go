@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	updateCategoriesError   = "Failed to save categories: %v"
+	invalidCategoryError    = "Invalid category"
+	invalidSplitAmountError = "Invalid amount in split %d"
+	invalidSplitError       = "Invalid category in split %d"
+)
+
+type UpdateTransactionSplitsHandler struct {
+	container        *container.Container
+	detailsComponent *components.TransactionDetailsComponent
+}
+
+func NewUpdateTransactionSplitsHandler(container *container.Container, detailsComponent *components.TransactionDetailsComponent) *UpdateTransactionSplitsHandler {
+	return &UpdateTransactionSplitsHandler{
+		container:        container,
+		detailsComponent: detailsComponent,
+	}
+}
+
+func (h *UpdateTransactionSplitsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	transactionID, err := uuid.Parse(chi.URLParam(r, web.TransactionIDParam))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	categoryID, splits, err := parseCategoryForm(r)
+	if err != nil {
+		renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, "", fmt.Sprintf(updateCategoriesError, err))
+		return
+	}
+
+	// Split lines take over from the single category, so a form that sends both
+	// (the select keeps its value) is read as a request to split.
+	if len(splits) > 0 {
+		categoryID = nil
+	}
+
+	err = h.container.UpdateTransactionCategoriesService.UpdateCategories(r.Context(), project.ID, transactionID, categoryID, splits)
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to update transaction categories", logging.Err(err))
+		renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, "", fmt.Sprintf(updateCategoriesError, err))
+		return
+	}
+
+	redirectToTransactionWithSuccess(w, r, project.Slug, transactionID, web.SuccessKeyCategoriesUpdated)
+}
+
+// parseCategoryForm reads the category select and the split rows. Rows left blank
+// are ignored; the rows are submitted as parallel lists in form order.
+func parseCategoryForm(r *http.Request) (*uuid.UUID, []models.SplitData, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, nil, errors.New(formParseError)
+	}
+
+	var categoryID *uuid.UUID
+	if value := r.PostForm.Get(web.CategoryFormField); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			return nil, nil, errors.New(invalidCategoryError)
+		}
+		categoryID = &parsed
+	}
+
+	categories := r.PostForm[web.SplitCategoryFormField]
+	amounts := r.PostForm[web.SplitAmountFormField]
+	memos := r.PostForm[web.SplitMemoFormField]
+
+	var splits []models.SplitData
+	for i, amountStr := range amounts {
+		amountStr = strings.TrimSpace(amountStr)
+		categoryStr := formListValue(categories, i)
+		memo := strings.TrimSpace(formListValue(memos, i))
+		if amountStr == "" && categoryStr == "" && memo == "" {
+			continue
+		}
+
+		amount, err := strconv.ParseFloat(amountStr, 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf(invalidSplitAmountError, len(splits)+1)
+		}
+
+		splitCategoryID, err := uuid.Parse(categoryStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf(invalidSplitError, len(splits)+1)
+		}
+
+		splits = append(splits, models.SplitData{
+			CategoryID: splitCategoryID,
+			Amount:     amount,
+			Memo:       memo,
+		})
+	}
+
+	return categoryID, splits, nil
+}
+
+func formListValue(values []string, index int) string {
+	if index < len(values) {
+		return values[index]
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const updateStatusError = "Failed to save status: %v"
+
+type UpdateTransactionStatusHandler struct {
+	container        *container.Container
+	detailsComponent *components.TransactionDetailsComponent
+}
+
+func NewUpdateTransactionStatusHandler(container *container.Container, detailsComponent *components.TransactionDetailsComponent) *UpdateTransactionStatusHandler {
+	return &UpdateTransactionStatusHandler{
+		container:        container,
+		detailsComponent: detailsComponent,
+	}
+}
+
+func (h *UpdateTransactionStatusHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	transactionID, err := uuid.Parse(chi.URLParam(r, web.TransactionIDParam))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	status, err := models.ParseTransactionStatus(r.PostFormValue(web.StatusFormField))
+	if err == nil {
+		err = h.container.UpdateTransactionStatusService.UpdateStatus(r.Context(), project.ID, transactionID, status)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to update transaction status", logging.Err(err))
+		renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, "", fmt.Sprintf(updateStatusError, err))
+		return
+	}
+
+	redirectToTransactionWithSuccess(w, r, project.Slug, transactionID, web.SuccessKeyStatusUpdated)
+}
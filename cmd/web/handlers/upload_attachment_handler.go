@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+	"gofin/web/components"
+)
+
+const (
+	missingAttachmentError = "Choose a file to upload"
+	uploadAttachmentError  = "Failed to upload attachment: %v"
+)
+
+type UploadAttachmentHandler struct {
+	container        *container.Container
+	detailsComponent *components.TransactionDetailsComponent
+}
+
+func NewUploadAttachmentHandler(container *container.Container, detailsComponent *components.TransactionDetailsComponent) *UploadAttachmentHandler {
+	return &UploadAttachmentHandler{
+		container:        container,
+		detailsComponent: detailsComponent,
+	}
+}
+
+func (h *UploadAttachmentHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	project, _ := webcontext.GetProject(r.Context())
+
+	transactionID, err := uuid.Parse(chi.URLParam(r, web.TransactionIDParam))
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile(web.AttachmentFormField)
+	if err != nil {
+		renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, "", missingAttachmentError)
+		return
+	}
+	defer file.Close()
+
+	_, err = h.container.AttachmentsService.Upload(r.Context(), project.ID, transactionID, header.Filename, file)
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to upload attachment", logging.Err(err))
+		renderTransactionDetails(w, r, h.container, h.detailsComponent, transactionID, "", fmt.Sprintf(uploadAttachmentError, err))
+		return
+	}
+
+	redirectToTransactionWithSuccess(w, r, project.Slug, transactionID, web.SuccessKeyAttachmentUploaded)
+}
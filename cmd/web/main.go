@@ -1,27 +1,80 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"gofin/internal/container"
+	"gofin/internal/infrastructure/snapshot"
+	"gofin/pkg/config"
+	"gofin/pkg/logging"
 )
 
-const ServerPort = ":8080"
-
 func main() {
-	container, err := container.NewContainerWithDefaultConfig()
+	flags := flag.NewFlagSet("gofin-web", flag.ExitOnError)
+	configFile, overrides := config.RegisterFlags(flags)
+	flags.Parse(os.Args[1:])
+
+	cfg, err := config.Load(*configFile, overrides)
 	if err != nil {
-		log.Fatalf("Failed to initialize container: %v", err)
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
-	defer container.DB.Close()
 
-	mux := http.NewServeMux()
+	if err := run(cfg); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	_, err = NewRouter(container, mux)
+func run(cfg *config.Config) error {
+	logLevel, _ := config.ParseLogLevel(cfg.Log.Level)
+	logger, err := logging.New(os.Stderr, logLevel, cfg.Log.Format)
 	if err != nil {
-		log.Fatalf("Failed to initialize router: %v", err)
+		return err
+	}
+	slog.SetDefault(logger)
+
+	if cfg.Server.DevMode {
+		slog.Info("dev mode: serving templates and static files from disk", slog.String("assets_dir", cfg.Server.AssetsDir))
+	}
+
+	container, err := container.NewContainerFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize container: %w", err)
+	}
+	defer func() {
+		if err := container.DB.Close(); err != nil {
+			slog.Error("failed to close database", logging.Err(err))
+		}
+	}()
+
+	mux := http.NewServeMux()
+
+	if _, err := NewRouter(container, mux); err != nil {
+		return fmt.Errorf("failed to initialize router: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if cfg.Snapshots.Interval > 0 {
+		snapshotsDone := make(chan struct{})
+		go func() {
+			defer close(snapshotsDone)
+			snapshot.Schedule(ctx, container.DB, cfg.Snapshots)
+		}()
+		// Let a snapshot in progress stop before the database is closed.
+		defer func() {
+			stop()
+			<-snapshotsDone
+		}()
 	}
 
-	Start(ServerPort, mux)
+	return Serve(ctx, NewServer(cfg.Server, mux), cfg.Server.ShutdownTimeout)
 }
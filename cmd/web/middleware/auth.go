@@ -2,13 +2,13 @@ package middleware
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 
 	"github.com/google/uuid"
 	"gofin/internal/container"
 	"gofin/pkg/session"
 	webcontext "gofin/pkg/web"
-	webpkg "gofin/pkg/web"
 	"gofin/web"
 )
 
@@ -29,25 +29,31 @@ func AuthRequired(container *container.Container, sessionManager *session.Sessio
 
 			token, valid := sessionManager.ValidateSessionToken(sessionToken)
 			if !valid {
-				clearInvalidCookie(w)
+				clearInvalidCookie(w, sessionManager)
 				redirectToLogin(w, r, container)
 				return
 			}
 
 			if token.ProjectID != project.ID.String() {
-				clearInvalidCookie(w)
+				clearInvalidCookie(w, sessionManager)
 				redirectToLogin(w, r, container)
 				return
 			}
 
-			access, err := container.AccessRepository.GetByID(uuid.MustParse(token.AccessID))
+			access, err := container.AccessRepository.GetByID(r.Context(), uuid.MustParse(token.AccessID))
 			if err != nil || access.ProjectID != project.ID {
-				clearInvalidCookie(w)
+				clearInvalidCookie(w, sessionManager)
 				redirectToLogin(w, r, container)
 				return
 			}
 
+			if access.RequiresTwoFactor(project) && !access.TOTPEnabled && !isTwoFactorEnrollmentPath(r, project.Slug) {
+				http.Redirect(w, r, webcontext.ProjectURL(r, project.Slug, web.RouteTwoFactor), http.StatusSeeOther)
+				return
+			}
+
 			ctx := webcontext.SetAccess(r.Context(), access)
+			ctx = withLogAttrs(ctx, slog.String("access_id", access.ID.String()))
 			next.ServeHTTP(w, r.WithContext(ctx))
 		}
 	}
@@ -66,6 +72,10 @@ func getSessionTokenFromCookie(r *http.Request) (string, error) {
 	return cookie.Value, nil
 }
 
+func isTwoFactorEnrollmentPath(r *http.Request, projectSlug string) bool {
+	return r.URL.Path == "/"+projectSlug+web.RouteTwoFactor
+}
+
 func redirectToLogin(w http.ResponseWriter, r *http.Request, container *container.Container) {
 	project, ok := webcontext.GetProject(r.Context())
 	if !ok {
@@ -73,9 +83,9 @@ func redirectToLogin(w http.ResponseWriter, r *http.Request, container *containe
 		return
 	}
 
-	webpkg.RedirectToProjectLogin(w, r, project.Slug)
+	webcontext.RedirectToProjectLogin(w, r, project.Slug)
 }
 
-func clearInvalidCookie(w http.ResponseWriter) {
-	session.ClearSessionCookie(w)
+func clearInvalidCookie(w http.ResponseWriter, sessionManager *session.SessionManager) {
+	sessionManager.ClearSessionCookie(w)
 }
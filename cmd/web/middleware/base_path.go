@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"net/http"
+
+	webcontext "gofin/pkg/web"
+)
+
+func BasePath(basePath string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := webcontext.SetBasePath(r.Context(), basePath)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// BodyLimit caps request bodies at limit bytes. Reads past the limit fail with
+// *http.MaxBytesError, which CSRFProtected turns into a 413.
+func BodyLimit(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"gofin/pkg/session"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+)
+
+func CSRFProtected(sessionManager *session.SessionManager) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seed := getCSRFSeedFromCookie(r)
+			if seed == web.EmptyString {
+				seed = session.GenerateCSRFSeed()
+				sessionManager.SetCSRFCookie(w, seed)
+			}
+
+			sessionToken, _ := getSessionTokenFromCookie(r)
+
+			if !isSafeMethod(r.Method) {
+				var tooLarge *http.MaxBytesError
+				if err := parseSubmittedForm(r); errors.As(err, &tooLarge) {
+					http.Error(w, web.RequestTooLargeError, http.StatusRequestEntityTooLarge)
+					return
+				}
+
+				if !sessionManager.ValidateCSRFToken(seed, sessionToken, getSubmittedCSRFToken(r)) {
+					http.Error(w, web.CSRFTokenInvalidError, http.StatusForbidden)
+					return
+				}
+			}
+
+			ctx := webcontext.SetCSRFToken(r.Context(), sessionManager.GenerateCSRFToken(seed, sessionToken))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func getCSRFSeedFromCookie(r *http.Request) string {
+	cookie, err := r.Cookie(web.CSRFTokenCookie)
+	if err != nil {
+		return web.EmptyString
+	}
+
+	return cookie.Value
+}
+
+// parseSubmittedForm parses the body up front so an oversized upload is reported as
+// such instead of as a missing CSRF token.
+func parseSubmittedForm(r *http.Request) error {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.ParseMultipartForm(web.MultipartMemory)
+	}
+
+	return r.ParseForm()
+}
+
+func getSubmittedCSRFToken(r *http.Request) string {
+	if token := r.Header.Get(web.CSRFHeader); token != web.EmptyString {
+		return token
+	}
+
+	return r.PostFormValue(web.CSRFFormField)
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"gofin/pkg/metrics"
+)
+
+const unmatchedRoute = "unmatched"
+
+// Metrics records the duration and status of every request, labelled with the chi
+// route pattern (e.g. /{projectSlug}/dashboard) rather than the raw path.
+func Metrics(recorder metrics.Recorder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorded := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(recorded, r)
+
+			route := unmatchedRoute
+			if routeContext := chi.RouteContext(r.Context()); routeContext != nil && routeContext.RoutePattern() != "" {
+				route = routeContext.RoutePattern()
+			}
+
+			recorder.ObserveHTTPRequest(r.Method, route, recorded.status, time.Since(start))
+		})
+	}
+}
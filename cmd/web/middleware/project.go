@@ -1,11 +1,14 @@
 package middleware
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
 	"strings"
 
 	"gofin/internal/container"
 	"gofin/internal/models"
+	"gofin/pkg/logging"
 	webcontext "gofin/pkg/web"
 )
 
@@ -26,7 +29,7 @@ func ProjectBased(container *container.Container) func(http.Handler) http.Handle
 				return
 			}
 
-			project, err := container.ProjectRepository.GetBySlug(projectSlug)
+			project, err := container.ProjectRepository.GetBySlug(r.Context(), projectSlug)
 			if err != nil {
 				http.NotFound(w, r)
 				return
@@ -40,5 +43,18 @@ func ProjectBased(container *container.Container) func(http.Handler) http.Handle
 func serveWithProject(w http.ResponseWriter, r *http.Request, next http.Handler, project *models.Project) {
 	ctx := r.Context()
 	ctx = webcontext.SetProject(ctx, project)
+	ctx = withLogAttrs(ctx, slog.String("project_slug", project.Slug))
 	next.ServeHTTP(w, r.WithContext(ctx))
 }
+
+// withLogAttrs attaches attrs to the request logger and to the access log record.
+func withLogAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	logging.AddRequestAttrs(ctx, attrs...)
+
+	args := make([]any, 0, len(attrs))
+	for _, attr := range attrs {
+		args = append(args, attr)
+	}
+
+	return logging.WithLogger(ctx, logging.FromContext(ctx).With(args...))
+}
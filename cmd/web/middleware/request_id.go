@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gofin/pkg/logging"
+	webcontext "gofin/pkg/web"
+	"gofin/web"
+)
+
+const maxRequestIDLength = 64
+
+// RequestID tags every request with an ID, reusing a sane X-Request-ID from a proxy,
+// and stores a logger carrying that ID in the request context.
+func RequestID(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(web.RequestIDHeader)
+			if !isValidRequestID(requestID) {
+				requestID = uuid.NewString()
+			}
+
+			w.Header().Set(web.RequestIDHeader, requestID)
+
+			ctx := webcontext.SetRequestID(r.Context(), requestID)
+			ctx = logging.WithLogger(ctx, logger.With(slog.String("request_id", requestID)))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func isValidRequestID(requestID string) bool {
+	if requestID == web.EmptyString || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range requestID {
+		isAlphanumeric := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+		if !isAlphanumeric && c != '-' && c != '_' && c != '.' {
+			return false
+		}
+	}
+
+	return true
+}
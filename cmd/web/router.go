@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -16,50 +16,201 @@ import (
 )
 
 func NewRouter(container *container.Container, mux *http.ServeMux) (*chi.Mux, error) {
-	loginComponent, err := components.NewLoginComponent(container)
+	cfg := container.Config
+
+	assets, err := web.NewAssets(cfg.Server.DevMode, cfg.Server.AssetsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load web assets: %w", err)
+	}
+
+	staticFiles, err := assets.Static()
+	if err != nil {
+		return nil, err
+	}
+
+	loginComponent, err := components.NewLoginComponent(container, assets)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create login component: %w", err)
 	}
 
-	dashboardComponent, err := components.NewDashboardComponent(container)
+	dashboardComponent, err := components.NewDashboardComponent(container, assets)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dashboard component: %w", err)
 	}
 
-	transactionComponent, err := components.NewTransactionCreationComponent(container)
+	transactionComponent, err := components.NewTransactionCreationComponent(container, assets)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction component: %w", err)
 	}
 
+	transactionSearchComponent, err := components.NewTransactionSearchComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction search component: %w", err)
+	}
+
+	transactionDetailsComponent, err := components.NewTransactionDetailsComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction details component: %w", err)
+	}
+
+	categoriesComponent, err := components.NewCategoriesComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create categories component: %w", err)
+	}
+
+	balancesComponent, err := components.NewBalancesComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create balances component: %w", err)
+	}
+
+	payeesComponent, err := components.NewPayeesComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payees component: %w", err)
+	}
+
+	accountsComponent, err := components.NewAccountsComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create accounts component: %w", err)
+	}
+
+	loansComponent, err := components.NewLoansComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create loans component: %w", err)
+	}
+
+	investmentsComponent, err := components.NewInvestmentsComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create investments component: %w", err)
+	}
+
+	reconcileComponent, err := components.NewReconcileComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reconcile component: %w", err)
+	}
+
+	periodsComponent, err := components.NewPeriodsComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create periods component: %w", err)
+	}
+
+	reportsComponent, err := components.NewReportsComponent(assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reports component: %w", err)
+	}
+
+	importStatementComponent, err := components.NewImportStatementComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import statement component: %w", err)
+	}
+
+	twoFactorComponent, err := components.NewTwoFactorComponent(container, assets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create two-factor component: %w", err)
+	}
+
 	createTransactionSvc := create_transaction.NewCreateTransactionService(
 		container.TransactionRepository,
 		container.AccountRepository,
 		container.ProjectRepository,
+		container.CategoryRepository,
+		container.TransactionSplitRepository,
+		container.PayeeRepository,
 	)
 
-	sessionManager := session.NewSessionManager()
+	sessionManager := session.NewSessionManager(cfg)
 
 	router := chi.NewRouter()
-	router.Handle(web.RouteStatic, http.StripPrefix("/static/", http.FileServer(http.Dir(web.StaticDir+"/"))))
+	router.Use(middleware.RequestID(slog.Default()))
+	router.Use(middleware.AccessLog())
+	router.Use(middleware.Metrics(container.Metrics))
+	router.Use(middleware.BasePath(cfg.Server.BasePath))
+	router.Get(web.RouteHealthz, handlers.NewHealthHandler().Handle)
+	router.Get(web.RouteReadyz, handlers.NewReadinessHandler(container.DB).Handle)
+	if cfg.Metrics.Enabled {
+		router.Handle(web.RouteMetrics, container.Metrics.Handler())
+	}
+	router.Handle(web.RouteStatic, http.StripPrefix("/static/", http.FileServerFS(staticFiles)))
 	router.Route("/{projectSlug}", func(chiRouter chi.Router) {
 		chiRouter.Use(middleware.ProjectBased(container))
+		chiRouter.Use(middleware.BodyLimit(cfg.Attachments.MaxSize + web.RequestBodyOverhead))
+		chiRouter.Use(middleware.CSRFProtected(sessionManager))
 		chiRouter.Get("/", handlers.NewMainHandler(container).Handle)
 		chiRouter.Get(web.RouteLogin, handlers.NewLoginFormHandler(loginComponent, sessionManager).Handle)
 		chiRouter.Post(web.RouteLogin, handlers.NewLoginHandler(container, loginComponent, sessionManager).Handle)
-		chiRouter.Get(web.RouteLogout, handlers.NewLogoutHandler(container).Handle)
+		chiRouter.Get(web.RouteLoginTwoFactor, handlers.NewLoginTwoFactorFormHandler(loginComponent, sessionManager).Handle)
+		chiRouter.Post(web.RouteLoginTwoFactor, handlers.NewLoginTwoFactorHandler(container, loginComponent, sessionManager).Handle)
+		chiRouter.Get(web.RouteLogout, handlers.NewLogoutHandler(container, sessionManager).Handle)
 		chiRouter.Get(web.RouteDashboard, middleware.AuthRequired(container, sessionManager)(handlers.NewDashboardHandler(container, dashboardComponent).Handle))
 		chiRouter.Get(web.RouteCreateTransaction, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewCreateTransactionFormHandler(container, transactionComponent).Handle)))
 		chiRouter.Post(web.RouteCreateTransaction, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewCreateTransactionHandler(container, transactionComponent, createTransactionSvc).Handle)))
+		chiRouter.Get(web.RouteImportStatement, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewImportStatementFormHandler(importStatementComponent).Handle)))
+		chiRouter.Post(web.RouteImportStatement, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewPreviewStatementHandler(container, importStatementComponent).Handle)))
+		chiRouter.Post(web.RouteConfirmImport, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewConfirmImportHandler(container, importStatementComponent).Handle)))
 		chiRouter.Post(web.RouteCreateAccount, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewCreateAccountHandler(container.CreateAccountService).Handle)))
+		chiRouter.Get(web.RouteAccounts, middleware.AuthRequired(container, sessionManager)(handlers.NewAccountsHandler(accountsComponent).Handle))
+		chiRouter.Post(web.RouteAccounts, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewAddAccountHandler(container, accountsComponent).Handle)))
+		chiRouter.Post(web.RouteAccount, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewUpdateAccountHandler(container, accountsComponent).Handle)))
+		chiRouter.Post(web.RouteArchiveAccount, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewArchiveAccountHandler(container, accountsComponent, true).Handle)))
+		chiRouter.Post(web.RouteUnarchiveAccount, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewArchiveAccountHandler(container, accountsComponent, false).Handle)))
+		chiRouter.Post(web.RouteTransfer, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewRecordTransferHandler(container, accountsComponent).Handle)))
+		chiRouter.Post(web.RouteMoveAccount, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewMoveAccountHandler(container, accountsComponent).Handle)))
+		chiRouter.Get(web.RouteAccountStatements, middleware.AuthRequired(container, sessionManager)(handlers.NewCreditCardStatementsHandler(accountsComponent).Handle))
+		chiRouter.Get(web.RouteLoans, middleware.AuthRequired(container, sessionManager)(handlers.NewLoansHandler(loansComponent).Handle))
+		chiRouter.Post(web.RouteLoans, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewCreateLoanHandler(container, loansComponent).Handle)))
+		chiRouter.Get(web.RouteLoan, middleware.AuthRequired(container, sessionManager)(handlers.NewLoanHandler(loansComponent).Handle))
+		chiRouter.Post(web.RouteLoanRates, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewAddLoanRateHandler(container, loansComponent).Handle)))
+		chiRouter.Post(web.RouteDeleteLoanRate, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewDeleteLoanRateHandler(container, loansComponent).Handle)))
+		chiRouter.Post(web.RouteLoanPayments, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewRecordLoanPaymentHandler(container, loansComponent).Handle)))
+		chiRouter.Get(web.RouteInvestments, middleware.AuthRequired(container, sessionManager)(handlers.NewInvestmentsHandler(investmentsComponent).Handle))
+		chiRouter.Get(web.RouteInvestment, middleware.AuthRequired(container, sessionManager)(handlers.NewInvestmentHandler(investmentsComponent).Handle))
+		chiRouter.Post(web.RouteInvestmentOps, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewRecordInvestmentOperationHandler(container, investmentsComponent).Handle)))
+		chiRouter.Post(web.RouteSecurityPrices, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewSetSecurityPriceHandler(container, investmentsComponent).Handle)))
+		chiRouter.Post(web.RouteImportPrices, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewImportSecurityPricesHandler(container, investmentsComponent).Handle)))
+		chiRouter.Get(web.RoutePeriods, middleware.AuthRequired(container, sessionManager)(handlers.NewPeriodsHandler(periodsComponent).Handle))
+		chiRouter.Post(web.RoutePeriods, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewClosePeriodHandler(container, periodsComponent).Handle)))
+		chiRouter.Get(web.RouteReports, middleware.AuthRequired(container, sessionManager)(handlers.NewReportsHandler(container, reportsComponent).Handle))
+		chiRouter.Get(web.RouteReportsData, middleware.AuthRequired(container, sessionManager)(handlers.NewReportsDataHandler(container).Handle))
+		chiRouter.Get(web.RouteExportTransactions, middleware.AuthRequired(container, sessionManager)(handlers.NewExportTransactionsHandler(container).Handle))
+		chiRouter.Get(web.RouteExportBalances, middleware.AuthRequired(container, sessionManager)(handlers.NewExportBalancesHandler(container).Handle))
+		chiRouter.Get(web.RouteExportReport, middleware.AuthRequired(container, sessionManager)(handlers.NewExportReportHandler(container).Handle))
+		chiRouter.Get(web.RouteReconcile, middleware.AuthRequired(container, sessionManager)(handlers.NewReconcileHandler(reconcileComponent).Handle))
+		chiRouter.Post(web.RouteReconcile, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewStartReconciliationHandler(container, reconcileComponent).Handle)))
+		chiRouter.Post(web.RouteReconciliation, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewSaveReconciliationHandler(container, reconcileComponent).Handle)))
+		chiRouter.Post(web.RouteCancelReconcile, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewCancelReconciliationHandler(container, reconcileComponent).Handle)))
+		chiRouter.Get(web.RouteSearchTransaction, middleware.AuthRequired(container, sessionManager)(handlers.NewSearchTransactionsHandler(container, transactionSearchComponent).Handle))
+		chiRouter.Get(web.RouteTransaction, middleware.AuthRequired(container, sessionManager)(handlers.NewTransactionDetailsHandler(container, transactionDetailsComponent).Handle))
+		chiRouter.Post(web.RouteTransactionNotes, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewUpdateTransactionNotesHandler(container, transactionDetailsComponent).Handle)))
+		chiRouter.Post(web.RouteTransactionSplits, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewUpdateTransactionSplitsHandler(container, transactionDetailsComponent).Handle)))
+		chiRouter.Post(web.RouteShareTransaction, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewShareTransactionHandler(container, transactionDetailsComponent).Handle)))
+		chiRouter.Post(web.RouteUnshareTransaction, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewUnshareTransactionHandler(container, transactionDetailsComponent).Handle)))
+		chiRouter.Post(web.RouteTransactionStatus, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewUpdateTransactionStatusHandler(container, transactionDetailsComponent).Handle)))
+		chiRouter.Post(web.RouteTransactionPayee, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewUpdateTransactionPayeeHandler(container, transactionDetailsComponent).Handle)))
+		chiRouter.Post(web.RouteUploadAttachment, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewUploadAttachmentHandler(container, transactionDetailsComponent).Handle)))
+		chiRouter.Get(web.RouteAttachment, middleware.AuthRequired(container, sessionManager)(handlers.NewDownloadAttachmentHandler(container).Handle))
+		chiRouter.Get(web.RouteAttachmentThumb, middleware.AuthRequired(container, sessionManager)(handlers.NewAttachmentThumbnailHandler(container).Handle))
+		chiRouter.Post(web.RouteDeleteAttachment, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewDeleteAttachmentHandler(container).Handle)))
 		chiRouter.Post(web.RouteDeleteTransaction, middleware.AuthRequired(container, sessionManager)(handlers.NewDeleteTransactionHandler(container).Handle))
+		chiRouter.Get(web.RouteCategories, middleware.AuthRequired(container, sessionManager)(handlers.NewCategoriesHandler(categoriesComponent).Handle))
+		chiRouter.Post(web.RouteCategories, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewCreateCategoryHandler(container, categoriesComponent).Handle)))
+		chiRouter.Get(web.RoutePayees, middleware.AuthRequired(container, sessionManager)(handlers.NewPayeesHandler(payeesComponent).Handle))
+		chiRouter.Post(web.RoutePayees, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewCreatePayeeHandler(container, payeesComponent).Handle)))
+		chiRouter.Get(web.RoutePayee, middleware.AuthRequired(container, sessionManager)(handlers.NewPayeeHandler(payeesComponent).Handle))
+		chiRouter.Post(web.RoutePayeeAliases, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewAddPayeeAliasHandler(container, payeesComponent).Handle)))
+		chiRouter.Post(web.RouteDeletePayeeAlias, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewDeletePayeeAliasHandler(container, payeesComponent).Handle)))
+		chiRouter.Post(web.RoutePayeeCategory, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewUpdatePayeeCategoryHandler(container, payeesComponent).Handle)))
+		chiRouter.Post(web.RouteMergePayee, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewMergePayeeHandler(container, payeesComponent).Handle)))
+		chiRouter.Get(web.RouteBalances, middleware.AuthRequired(container, sessionManager)(handlers.NewBalancesHandler(container, balancesComponent).Handle))
+		chiRouter.Post(web.RouteSettle, middleware.AuthRequired(container, sessionManager)(middleware.ReadOnlyProhibited(container)(handlers.NewRecordSettlementHandler(container, balancesComponent).Handle)))
+		chiRouter.Get(web.RouteTwoFactor, middleware.AuthRequired(container, sessionManager)(handlers.NewTwoFactorFormHandler(container, twoFactorComponent).Handle))
+		chiRouter.Post(web.RouteTwoFactor, middleware.AuthRequired(container, sessionManager)(handlers.NewEnableTwoFactorHandler(container, twoFactorComponent).Handle))
+		chiRouter.Post(web.RouteDisableTwoFactor, middleware.AuthRequired(container, sessionManager)(handlers.NewDisableTwoFactorHandler(container, twoFactorComponent).Handle))
 	})
 
-	mux.Handle("/", router)
+	if cfg.Server.BasePath == "" {
+		mux.Handle("/", router)
+	} else {
+		mux.Handle(cfg.Server.BasePath+"/", http.StripPrefix(cfg.Server.BasePath, router))
+	}
 
 	return router, nil
 }
-
-func Start(addr string, mux *http.ServeMux) {
-	log.Printf("Server starting on %s", addr)
-	log.Fatal(http.ListenAndServe(addr, mux))
-}
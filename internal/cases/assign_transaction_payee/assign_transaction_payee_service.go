@@ -0,0 +1,99 @@
+package assign_transaction_payee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_account"
+	"gofin/internal/cases/validate_payee"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type AssignTransactionPayeeService struct {
+	transactionRepo    models.TransactionRepository
+	payeeRepo          models.PayeeRepository
+	validateAccountSvc *validate_account.ValidateAccountService
+	validatePeriodSvc  *validate_period.ValidatePeriodService
+	validatePayeeSvc   *validate_payee.ValidatePayeeService
+}
+
+func NewAssignTransactionPayeeService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, payeeRepo models.PayeeRepository, projectRepo models.ProjectRepository) *AssignTransactionPayeeService {
+	return &AssignTransactionPayeeService{
+		transactionRepo:    transactionRepo,
+		payeeRepo:          payeeRepo,
+		validateAccountSvc: validate_account.NewValidateAccountService(accountRepo),
+		validatePeriodSvc:  validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+		validatePayeeSvc:   validate_payee.NewValidatePayeeService(payeeRepo),
+	}
+}
+
+// AssignPayee links a transaction to a payee, or unlinks it when payeeID is nil.
+// When the transaction's name is not an alias of any payee yet, it becomes one of
+// the chosen payee so the next transaction with that name is matched on its own.
+func (s *AssignTransactionPayeeService) AssignPayee(ctx context.Context, projectID, transactionID uuid.UUID, payeeID *uuid.UUID) error {
+	transaction, err := s.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := s.validateAccountSvc.ValidateAccountForProject(ctx, projectID, transaction.AccountID); err != nil {
+		return fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := transaction.EnsureEditable(); err != nil {
+		return err
+	}
+
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, transaction.TransactionDate); err != nil {
+		return err
+	}
+
+	if payeeID != nil {
+		payee, err := s.validatePayeeSvc.GetPayeeForProject(ctx, projectID, *payeeID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.learnAlias(ctx, payee, transaction.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := s.transactionRepo.UpdatePayee(ctx, transactionID, payeeID); err != nil {
+		return fmt.Errorf("failed to update payee: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("transaction payee updated",
+		slog.String("transaction_id", transactionID.String()),
+	)
+
+	return nil
+}
+
+func (s *AssignTransactionPayeeService) learnAlias(ctx context.Context, payee *models.Payee, name string) error {
+	normalized := models.NormalizePayeeName(name)
+	if normalized == "" {
+		return nil
+	}
+
+	aliases, err := s.payeeRepo.GetAliasesByProjectID(ctx, payee.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to get payee aliases: %w", err)
+	}
+
+	for _, alias := range aliases {
+		if alias.Alias == normalized {
+			return nil
+		}
+	}
+
+	if err := s.payeeRepo.AddAlias(ctx, models.NewPayeeAlias(payee, normalized)); err != nil {
+		return fmt.Errorf("failed to add payee alias: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package assign_transaction_payee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+)
+
+func TestAssignTransactionPayeeService_AssignPayee(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewAssignTransactionPayeeService(transactionRepo, accountRepo, payeeRepo, projectRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(ctx, project)
+	projectID := project.ID
+	account := models.NewAccount(projectID, "Main", "PLN")
+	accountRepo.Create(ctx, account)
+
+	payee := models.NewPayee(projectID, "Biedronka", nil)
+	foreign := models.NewPayee(uuid.New(), "Elsewhere", nil)
+	payeeRepo.Create(ctx, payee)
+	payeeRepo.Create(ctx, foreign)
+
+	transaction := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 10, Name: "JMP S.A. 0042", Type: models.Debit})
+	transactionRepo.Create(ctx, transaction)
+
+	if err := service.AssignPayee(ctx, projectID, transaction.ID, &foreign.ID); err == nil {
+		t.Error("Expected error for a payee from another project")
+	}
+
+	if err := service.AssignPayee(ctx, uuid.New(), transaction.ID, &payee.ID); err == nil {
+		t.Error("Expected error for a transaction from another project")
+	}
+
+	if err := service.AssignPayee(ctx, projectID, transaction.ID, &payee.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got, _ := transactionRepo.GetByID(ctx, transaction.ID); got.PayeeID == nil || *got.PayeeID != payee.ID {
+		t.Errorf("Expected the payee to be assigned, got %v", got.PayeeID)
+	}
+
+	aliases, _ := payeeRepo.GetAliasesByProjectID(ctx, projectID)
+	if len(aliases) != 1 || aliases[0].Alias != "jmp s a" || aliases[0].PayeeID != payee.ID {
+		t.Errorf("Expected the transaction name to be learned as an alias, got %+v", aliases)
+	}
+
+	if err := service.AssignPayee(ctx, projectID, transaction.ID, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got, _ := transactionRepo.GetByID(ctx, transaction.ID); got.PayeeID != nil {
+		t.Errorf("Expected the payee to be cleared, got %v", got.PayeeID)
+	}
+}
@@ -0,0 +1,201 @@
+package backup_project
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+const (
+	// ArchiveFormat tells a project backup apart from any other JSON file.
+	ArchiveFormat = "gofin-project-backup"
+	// ArchiveVersion is bumped whenever the layout of Data changes. Restores
+	// refuse archives newer than they understand. Version 2 added loans,
+	// investments, shared expenses, settlements, transfers, reconciliations,
+	// period lock events and attachments.
+	ArchiveVersion = 2
+)
+
+// Archive is a self-describing backup of one project. Checksum is the SHA-256
+// of the compact JSON of Data and Counts repeat how many records of each kind
+// it holds, so a truncated or hand-edited file is refused on restore.
+type Archive struct {
+	Format    string      `json:"format"`
+	Version   int         `json:"version"`
+	CreatedAt time.Time   `json:"created_at"`
+	Checksum  string      `json:"checksum"`
+	Counts    Counts      `json:"counts"`
+	Data      ProjectData `json:"data"`
+}
+
+type Counts struct {
+	Accesses             int `json:"accesses"`
+	Accounts             int `json:"accounts"`
+	Categories           int `json:"categories"`
+	Payees               int `json:"payees"`
+	PayeeAliases         int `json:"payee_aliases"`
+	Transactions         int `json:"transactions"`
+	Splits               int `json:"splits"`
+	Loans                int `json:"loans"`
+	LoanRatePeriods      int `json:"loan_rate_periods"`
+	Securities           int `json:"securities"`
+	SecurityPrices       int `json:"security_prices"`
+	InvestmentOperations int `json:"investment_operations"`
+	SharedExpenses       int `json:"shared_expenses"`
+	ExpenseShares        int `json:"expense_shares"`
+	Settlements          int `json:"settlements"`
+	Transfers            int `json:"transfers"`
+	Reconciliations      int `json:"reconciliations"`
+	PeriodLockEvents     int `json:"period_lock_events"`
+	Attachments          int `json:"attachments"`
+}
+
+// ProjectData is everything a project is restored from. IDs are the ones of
+// the backed-up database; a restore gives every record a new one. The records
+// added in version 2 are left out of the JSON when empty, so the checksum of a
+// version 1 archive still matches.
+type ProjectData struct {
+	Project      *models.Project            `json:"project"`
+	Accesses     []*AccessRecord            `json:"accesses"`
+	Accounts     []*models.Account          `json:"accounts"`
+	Categories   []*models.Category         `json:"categories"`
+	Payees       []*models.Payee            `json:"payees"`
+	PayeeAliases []*models.PayeeAlias       `json:"payee_aliases"`
+	Transactions []*models.Transaction      `json:"transactions"`
+	Splits       []*models.TransactionSplit `json:"splits"`
+
+	Loans                []*models.Loan                `json:"loans,omitempty"`
+	LoanRatePeriods      []*models.LoanRatePeriod      `json:"loan_rate_periods,omitempty"`
+	Securities           []*models.Security            `json:"securities,omitempty"`
+	SecurityPrices       []*models.SecurityPrice       `json:"security_prices,omitempty"`
+	InvestmentOperations []*models.InvestmentOperation `json:"investment_operations,omitempty"`
+	SharedExpenses       []*models.SharedExpense       `json:"shared_expenses,omitempty"`
+	ExpenseShares        []*models.ExpenseShare        `json:"expense_shares,omitempty"`
+	Settlements          []*models.Settlement          `json:"settlements,omitempty"`
+	Transfers            []*models.AccountTransfer     `json:"transfers,omitempty"`
+	Reconciliations      []*models.Reconciliation      `json:"reconciliations,omitempty"`
+	PeriodLockEvents     []*models.PeriodLockEvent     `json:"period_lock_events,omitempty"`
+	Attachments          []*AttachmentRecord           `json:"attachments,omitempty"`
+}
+
+// AccessRecord is an access as it is backed up: the PIN only as its hash, and
+// without the TOTP secret and recovery codes, so accesses that used a second
+// factor enroll again after a restore.
+type AccessRecord struct {
+	ID          uuid.UUID `json:"id"`
+	UID         string    `json:"uid"`
+	PinHash     string    `json:"pin_hash"`
+	Name        string    `json:"name"`
+	ReadOnly    bool      `json:"readonly"`
+	TOTPEnabled bool      `json:"totp_enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AttachmentRecord is an attachment with its file, and its thumbnail when it
+// has one, as the blob store keeps them outside the database.
+type AttachmentRecord struct {
+	models.Attachment
+	Content   []byte `json:"content"`
+	Thumbnail []byte `json:"thumbnail,omitempty"`
+}
+
+func (d *ProjectData) counts() Counts {
+	return Counts{
+		Accesses:             len(d.Accesses),
+		Accounts:             len(d.Accounts),
+		Categories:           len(d.Categories),
+		Payees:               len(d.Payees),
+		PayeeAliases:         len(d.PayeeAliases),
+		Transactions:         len(d.Transactions),
+		Splits:               len(d.Splits),
+		Loans:                len(d.Loans),
+		LoanRatePeriods:      len(d.LoanRatePeriods),
+		Securities:           len(d.Securities),
+		SecurityPrices:       len(d.SecurityPrices),
+		InvestmentOperations: len(d.InvestmentOperations),
+		SharedExpenses:       len(d.SharedExpenses),
+		ExpenseShares:        len(d.ExpenseShares),
+		Settlements:          len(d.Settlements),
+		Transfers:            len(d.Transfers),
+		Reconciliations:      len(d.Reconciliations),
+		PeriodLockEvents:     len(d.PeriodLockEvents),
+		Attachments:          len(d.Attachments),
+	}
+}
+
+func (d *ProjectData) checksum() (string, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode backup data: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// NewArchive wraps data with its format, counts and checksum.
+func NewArchive(data ProjectData) (*Archive, error) {
+	checksum, err := data.checksum()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Archive{
+		Format:    ArchiveFormat,
+		Version:   ArchiveVersion,
+		CreatedAt: time.Now().UTC(),
+		Checksum:  checksum,
+		Counts:    data.counts(),
+		Data:      data,
+	}, nil
+}
+
+func WriteArchive(w io.Writer, archive *Archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(archive); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return nil
+}
+
+// ReadArchive decodes a backup and checks that it is one, that this version
+// can read it and that its data is complete and unchanged.
+func ReadArchive(r io.Reader) (*Archive, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+
+	if archive.Format != ArchiveFormat {
+		return nil, fmt.Errorf("not a gofin project backup")
+	}
+
+	if archive.Version < 1 || archive.Version > ArchiveVersion {
+		return nil, fmt.Errorf("backup version %d is not supported, expected at most %d", archive.Version, ArchiveVersion)
+	}
+
+	if archive.Data.Project == nil {
+		return nil, fmt.Errorf("backup holds no project")
+	}
+
+	if counts := archive.Data.counts(); counts != archive.Counts {
+		return nil, fmt.Errorf("backup is incomplete: it lists %+v but holds %+v", archive.Counts, counts)
+	}
+
+	checksum, err := archive.Data.checksum()
+	if err != nil {
+		return nil, err
+	}
+	if checksum != archive.Checksum {
+		return nil, fmt.Errorf("backup checksum does not match, the file was changed or damaged")
+	}
+
+	return &archive, nil
+}
@@ -0,0 +1,109 @@
+package backup_project
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func newTestArchive(t *testing.T) *Archive {
+	t.Helper()
+
+	project := models.NewProject("Home", "home")
+	account := models.NewAccount(project.ID, "Checking", money.Currency("PLN"))
+	date := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	transaction := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 12.34, Name: "Groceries", Type: models.Debit, TransactionDate: &date})
+
+	archive, err := NewArchive(ProjectData{
+		Project:      project,
+		Accesses:     []*AccessRecord{{ID: uuid.New(), UID: "anna", PinHash: "hash", Name: "Anna"}},
+		Accounts:     []*models.Account{account},
+		Transactions: []*models.Transaction{transaction},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	return archive
+}
+
+func TestReadArchive(t *testing.T) {
+	tests := []struct {
+		name        string
+		edit        func(raw map[string]any)
+		expectError string
+	}{
+		{name: "unchanged"},
+		{
+			name: "version 1 archive",
+			edit: func(raw map[string]any) { raw["version"] = 1 },
+		},
+		{
+			name:        "other JSON file",
+			edit:        func(raw map[string]any) { raw["format"] = "something-else" },
+			expectError: "not a gofin project backup",
+		},
+		{
+			name:        "newer version",
+			edit:        func(raw map[string]any) { raw["version"] = ArchiveVersion + 1 },
+			expectError: "not supported",
+		},
+		{
+			name: "edited transaction",
+			edit: func(raw map[string]any) {
+				transaction := raw["data"].(map[string]any)["transactions"].([]any)[0].(map[string]any)
+				transaction["value"] = 99.99
+			},
+			expectError: "checksum",
+		},
+		{
+			name: "dropped transaction",
+			edit: func(raw map[string]any) {
+				raw["data"].(map[string]any)["transactions"] = []any{}
+			},
+			expectError: "incomplete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteArchive(&buf, newTestArchive(t)); err != nil {
+				t.Fatalf("Failed to write archive: %v", err)
+			}
+
+			if tt.edit != nil {
+				var raw map[string]any
+				if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+					t.Fatalf("Failed to decode archive: %v", err)
+				}
+				tt.edit(raw)
+				edited, _ := json.Marshal(raw)
+				buf = *bytes.NewBuffer(edited)
+			}
+
+			archive, err := ReadArchive(&buf)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if archive.Counts.Transactions != 1 || archive.Data.Transactions[0].Value != 12.34 {
+				t.Errorf("Expected the transaction to survive the round trip, got %+v", archive.Data.Transactions)
+			}
+			if archive.Data.Accesses[0].PinHash != "hash" {
+				t.Errorf("Expected the PIN hash to be kept, got %q", archive.Data.Accesses[0].PinHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package backup_project
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type BackupProjectService struct {
+	projectRepo        models.ProjectRepository
+	accessRepo         models.AccessRepository
+	accountRepo        models.AccountRepository
+	transactionRepo    models.TransactionRepository
+	categoryRepo       models.CategoryRepository
+	splitRepo          models.TransactionSplitRepository
+	payeeRepo          models.PayeeRepository
+	loanRepo           models.LoanRepository
+	securityRepo       models.SecurityRepository
+	operationRepo      models.InvestmentOperationRepository
+	sharedExpenseRepo  models.SharedExpenseRepository
+	settlementRepo     models.SettlementRepository
+	transferRepo       models.AccountTransferRepository
+	reconciliationRepo models.ReconciliationRepository
+	periodLockRepo     models.PeriodLockEventRepository
+	attachmentRepo     models.AttachmentRepository
+	blobs              models.BlobStore
+}
+
+func NewBackupProjectService(projectRepo models.ProjectRepository, accessRepo models.AccessRepository, accountRepo models.AccountRepository, transactionRepo models.TransactionRepository, categoryRepo models.CategoryRepository, splitRepo models.TransactionSplitRepository, payeeRepo models.PayeeRepository, loanRepo models.LoanRepository, securityRepo models.SecurityRepository, operationRepo models.InvestmentOperationRepository, sharedExpenseRepo models.SharedExpenseRepository, settlementRepo models.SettlementRepository, transferRepo models.AccountTransferRepository, reconciliationRepo models.ReconciliationRepository, periodLockRepo models.PeriodLockEventRepository, attachmentRepo models.AttachmentRepository, blobs models.BlobStore) *BackupProjectService {
+	return &BackupProjectService{
+		projectRepo:        projectRepo,
+		accessRepo:         accessRepo,
+		accountRepo:        accountRepo,
+		transactionRepo:    transactionRepo,
+		categoryRepo:       categoryRepo,
+		splitRepo:          splitRepo,
+		payeeRepo:          payeeRepo,
+		loanRepo:           loanRepo,
+		securityRepo:       securityRepo,
+		operationRepo:      operationRepo,
+		sharedExpenseRepo:  sharedExpenseRepo,
+		settlementRepo:     settlementRepo,
+		transferRepo:       transferRepo,
+		reconciliationRepo: reconciliationRepo,
+		periodLockRepo:     periodLockRepo,
+		attachmentRepo:     attachmentRepo,
+		blobs:              blobs,
+	}
+}
+
+// Backup writes the project with its settings, accesses, accounts, categories,
+// payees, every transaction and split, the loans, investments, shared expenses,
+// settlements, transfers, reconciliations and period lock history, and every
+// attachment with its file as an archive.
+func (s *BackupProjectService) Backup(ctx context.Context, w io.Writer, projectID uuid.UUID) (*Archive, error) {
+	data, err := s.collect(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := NewArchive(*data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := WriteArchive(w, archive); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("project backed up",
+		slog.String("project_id", projectID.String()),
+		slog.Int("accounts", archive.Counts.Accounts),
+		slog.Int("transactions", archive.Counts.Transactions))
+
+	return archive, nil
+}
+
+func (s *BackupProjectService) collect(ctx context.Context, projectID uuid.UUID) (*ProjectData, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	data := &ProjectData{Project: project}
+
+	accesses, err := s.accessRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accesses: %w", err)
+	}
+	for _, access := range accesses {
+		data.Accesses = append(data.Accesses, &AccessRecord{
+			ID:          access.ID,
+			UID:         access.UID,
+			PinHash:     access.PinHash,
+			Name:        access.Name,
+			ReadOnly:    access.ReadOnly,
+			TOTPEnabled: access.TOTPEnabled,
+			CreatedAt:   access.CreatedAt,
+		})
+	}
+
+	data.Accounts, err = s.accountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	data.Categories, err = s.categoryRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project categories: %w", err)
+	}
+
+	data.Payees, err = s.payeeRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project payees: %w", err)
+	}
+
+	data.PayeeAliases, err = s.payeeRepo.GetAliasesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payee aliases: %w", err)
+	}
+
+	var transactionIDs []uuid.UUID
+	for _, account := range data.Accounts {
+		transactions, err := s.transactionRepo.GetByAccountID(ctx, account.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions of account %s: %w", account.Name, err)
+		}
+		for _, transaction := range transactions {
+			data.Transactions = append(data.Transactions, transaction)
+			transactionIDs = append(transactionIDs, transaction.ID)
+		}
+	}
+
+	if len(transactionIDs) > 0 {
+		data.Splits, err = s.splitRepo.GetByTransactionIDs(ctx, transactionIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction splits: %w", err)
+		}
+	}
+
+	if err := s.collectAccountRecords(ctx, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.collectProjectRecords(ctx, projectID, data); err != nil {
+		return nil, err
+	}
+
+	for _, transactionID := range transactionIDs {
+		if err := s.collectAttachments(ctx, transactionID, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
+// collectAccountRecords adds what hangs off single accounts: investment
+// operations and reconciliations.
+func (s *BackupProjectService) collectAccountRecords(ctx context.Context, data *ProjectData) error {
+	for _, account := range data.Accounts {
+		operations, err := s.operationRepo.GetByAccountID(ctx, account.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get investment operations of account %s: %w", account.Name, err)
+		}
+		data.InvestmentOperations = append(data.InvestmentOperations, operations...)
+
+		reconciliations, err := s.reconciliationRepo.GetByAccountID(ctx, account.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get reconciliations of account %s: %w", account.Name, err)
+		}
+		data.Reconciliations = append(data.Reconciliations, reconciliations...)
+	}
+
+	return nil
+}
+
+// collectProjectRecords adds the loans with their rates, the securities with
+// their prices and the records kept per project.
+func (s *BackupProjectService) collectProjectRecords(ctx context.Context, projectID uuid.UUID, data *ProjectData) error {
+	var err error
+
+	data.Loans, err = s.loanRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project loans: %w", err)
+	}
+	for _, loan := range data.Loans {
+		periods, err := s.loanRepo.GetRatePeriods(ctx, loan.AccountID)
+		if err != nil {
+			return fmt.Errorf("failed to get loan rate periods: %w", err)
+		}
+		data.LoanRatePeriods = append(data.LoanRatePeriods, periods...)
+	}
+
+	data.Securities, err = s.securityRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project securities: %w", err)
+	}
+	for _, security := range data.Securities {
+		prices, err := s.securityRepo.GetPrices(ctx, security.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get prices of %s: %w", security.Ticker, err)
+		}
+		data.SecurityPrices = append(data.SecurityPrices, prices...)
+	}
+
+	data.SharedExpenses, err = s.sharedExpenseRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get shared expenses: %w", err)
+	}
+
+	data.ExpenseShares, err = s.sharedExpenseRepo.GetSharesByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get expense shares: %w", err)
+	}
+
+	data.Settlements, err = s.settlementRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get settlements: %w", err)
+	}
+
+	data.Transfers, err = s.transferRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get transfers: %w", err)
+	}
+
+	data.PeriodLockEvents, err = s.periodLockRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get period lock events: %w", err)
+	}
+
+	return nil
+}
+
+func (s *BackupProjectService) collectAttachments(ctx context.Context, transactionID uuid.UUID, data *ProjectData) error {
+	attachments, err := s.attachmentRepo.GetByTransactionID(ctx, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		record := &AttachmentRecord{Attachment: *attachment}
+
+		record.Content, err = s.readBlob(ctx, attachment.BlobKey())
+		if err != nil {
+			return fmt.Errorf("failed to read attachment %s: %w", attachment.FileName, err)
+		}
+
+		if attachment.HasThumbnail {
+			record.Thumbnail, err = s.readBlob(ctx, attachment.ThumbnailKey())
+			if err != nil {
+				return fmt.Errorf("failed to read thumbnail of %s: %w", attachment.FileName, err)
+			}
+		}
+
+		data.Attachments = append(data.Attachments, record)
+	}
+
+	return nil
+}
+
+func (s *BackupProjectService) readBlob(ctx context.Context, key string) ([]byte, error) {
+	content, err := s.blobs.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	return io.ReadAll(content)
+}
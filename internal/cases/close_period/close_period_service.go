@@ -0,0 +1,125 @@
+package close_period
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+const dateFormat = "2006-01-02"
+
+type ClosePeriodService struct {
+	projectRepo models.ProjectRepository
+	eventRepo   models.PeriodLockEventRepository
+}
+
+func NewClosePeriodService(projectRepo models.ProjectRepository, eventRepo models.PeriodLockEventRepository) *ClosePeriodService {
+	return &ClosePeriodService{
+		projectRepo: projectRepo,
+		eventRepo:   eventRepo,
+	}
+}
+
+// ClosePeriod closes the books of the project up to and including until, which
+// has to be in the past and after the periods already closed.
+func (s *ClosePeriodService) ClosePeriod(ctx context.Context, projectID uuid.UUID, until time.Time, actor string) (*models.Project, error) {
+	until = time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC)
+
+	if strings.TrimSpace(actor) == "" {
+		return nil, fmt.Errorf("the person closing the period is required")
+	}
+
+	now := time.Now()
+	if !until.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)) {
+		return nil, fmt.Errorf("cannot close a period that has not ended yet")
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if project.LockedUntil != nil && !until.After(*project.LockedUntil) {
+		return nil, fmt.Errorf("the books are already closed up to %s", project.LockedUntil.Format(dateFormat))
+	}
+
+	return s.changeLock(ctx, project, &until, models.PeriodClosed, actor, "")
+}
+
+// ReopenPeriod moves the closing date of the project back to until, or reopens
+// every period when until is nil. Reopening is reserved to administrators, so
+// only the CLI offers it, and it needs a reason for the audit trail.
+func (s *ClosePeriodService) ReopenPeriod(ctx context.Context, projectID uuid.UUID, until *time.Time, actor, reason string) (*models.Project, error) {
+	if strings.TrimSpace(actor) == "" {
+		return nil, fmt.Errorf("the person reopening the period is required")
+	}
+
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("a reason is required to reopen a period")
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if project.LockedUntil == nil {
+		return nil, fmt.Errorf("no period of the project is closed")
+	}
+
+	if until != nil {
+		date := time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC)
+		if !date.Before(*project.LockedUntil) {
+			return nil, fmt.Errorf("the books are closed up to %s, reopening has to move that date back", project.LockedUntil.Format(dateFormat))
+		}
+		until = &date
+	}
+
+	return s.changeLock(ctx, project, until, models.PeriodReopened, actor, strings.TrimSpace(reason))
+}
+
+// GetAuditTrail returns every close and reopen of the project newest first.
+func (s *ClosePeriodService) GetAuditTrail(ctx context.Context, projectID uuid.UUID) ([]*models.PeriodLockEvent, error) {
+	events, err := s.eventRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get period lock events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (s *ClosePeriodService) changeLock(ctx context.Context, project *models.Project, until *time.Time, action models.PeriodLockAction, actor, reason string) (*models.Project, error) {
+	previous := project.LockedUntil
+
+	project.LockedUntil = until
+	project.UpdatedAt = time.Now()
+
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	event := models.NewPeriodLockEvent(project, action, previous, strings.TrimSpace(actor), reason)
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to record period lock event: %w", err)
+	}
+
+	lockedUntil := ""
+	if until != nil {
+		lockedUntil = until.Format(dateFormat)
+	}
+
+	logging.FromContext(ctx).Info("period lock changed",
+		slog.String("project_id", project.ID.String()),
+		slog.String("action", action.String()),
+		slog.String("locked_until", lockedUntil),
+		slog.String("actor", event.Actor),
+	)
+
+	return project, nil
+}
@@ -0,0 +1,122 @@
+package close_period
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+)
+
+func TestClosePeriodService_ClosePeriod(t *testing.T) {
+	march := models.MonthEnd(2024, time.March)
+	may := models.MonthEnd(2024, time.May)
+
+	tests := []struct {
+		name        string
+		lockedUntil *time.Time
+		until       time.Time
+		actor       string
+		expectError bool
+	}{
+		{name: "first close", until: march, actor: "Anna"},
+		{name: "later month", lockedUntil: &march, until: may, actor: "Anna"},
+		{name: "already closed", lockedUntil: &may, until: march, actor: "Anna", expectError: true},
+		{name: "same month again", lockedUntil: &march, until: march, actor: "Anna", expectError: true},
+		{name: "period not ended", until: time.Now(), actor: "Anna", expectError: true},
+		{name: "no actor", until: march, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			projectRepo := database.NewProjectInMemoryRepository()
+			eventRepo := database.NewPeriodLockEventInMemoryRepository()
+			service := NewClosePeriodService(projectRepo, eventRepo)
+
+			project := models.NewProject("Test Project", "test-project")
+			project.LockedUntil = tt.lockedUntil
+			projectRepo.Create(ctx, project)
+
+			updated, err := service.ClosePeriod(ctx, project.ID, tt.until, tt.actor)
+			events, _ := service.GetAuditTrail(ctx, project.ID)
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("Expected an error, got none")
+				}
+				if len(events) != 0 {
+					t.Errorf("Expected no audit entry for a refused close, got %d", len(events))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if updated.LockedUntil == nil || !updated.LockedUntil.Equal(tt.until) {
+				t.Errorf("Expected the books closed up to %v, got %v", tt.until, updated.LockedUntil)
+			}
+
+			if len(events) != 1 || events[0].Action != models.PeriodClosed || events[0].Actor != tt.actor {
+				t.Fatalf("Expected one close in the audit trail, got %+v", events)
+			}
+			if (events[0].PreviousLockedUntil == nil) != (tt.lockedUntil == nil) {
+				t.Errorf("Expected the previous closing date %v to be recorded, got %v", tt.lockedUntil, events[0].PreviousLockedUntil)
+			}
+		})
+	}
+}
+
+func TestClosePeriodService_ReopenPeriod(t *testing.T) {
+	ctx := context.Background()
+	projectRepo := database.NewProjectInMemoryRepository()
+	eventRepo := database.NewPeriodLockEventInMemoryRepository()
+	service := NewClosePeriodService(projectRepo, eventRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(ctx, project)
+
+	march := models.MonthEnd(2024, time.March)
+	february := models.MonthEnd(2024, time.February)
+
+	if _, err := service.ReopenPeriod(ctx, project.ID, nil, "Admin", "Correction"); err == nil {
+		t.Error("Expected an error reopening a project without closed periods")
+	}
+
+	if _, err := service.ClosePeriod(ctx, project.ID, march, "Anna"); err != nil {
+		t.Fatalf("Expected no error closing March, got %v", err)
+	}
+
+	if _, err := service.ReopenPeriod(ctx, project.ID, &february, "Admin", " "); err == nil {
+		t.Error("Expected an error reopening without a reason")
+	}
+
+	if _, err := service.ReopenPeriod(ctx, project.ID, &march, "Admin", "Correction"); err == nil {
+		t.Error("Expected an error reopening without moving the closing date back")
+	}
+
+	updated, err := service.ReopenPeriod(ctx, project.ID, &february, "Admin", "Late invoice")
+	if err != nil {
+		t.Fatalf("Expected no error reopening March, got %v", err)
+	}
+	if !updated.LockedUntil.Equal(february) || updated.EnsurePeriodOpen(march) != nil || updated.EnsurePeriodOpen(february) == nil {
+		t.Errorf("Expected the books closed up to February only, got %v", updated.LockedUntil)
+	}
+
+	updated, err = service.ReopenPeriod(ctx, project.ID, nil, "Admin", "Restatement")
+	if err != nil {
+		t.Fatalf("Expected no error reopening every period, got %v", err)
+	}
+	if updated.LockedUntil != nil {
+		t.Errorf("Expected every period open, got %v", updated.LockedUntil)
+	}
+
+	events, err := service.GetAuditTrail(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("Failed to get audit trail: %v", err)
+	}
+	if len(events) != 3 || events[0].Reason != "Restatement" || events[1].Action != models.PeriodReopened || !events[1].PreviousLockedUntil.Equal(march) {
+		t.Errorf("Expected the close and both reopens newest first, got %+v", events)
+	}
+}
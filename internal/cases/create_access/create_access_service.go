@@ -1,10 +1,13 @@
 package create_access
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid"
 	"gofin/internal/models"
+	"gofin/pkg/logging"
 	"gofin/pkg/password"
 	"gofin/pkg/random"
 )
@@ -21,17 +24,17 @@ func NewCreateAccessService(accessRepo models.AccessRepository, projectRepo mode
 	}
 }
 
-func (s *CreateAccessService) CreateAccess(projectSlug, name string, readonly bool) (*models.Access, string, error) {
+func (s *CreateAccessService) CreateAccess(ctx context.Context, projectSlug, name string, readonly bool) (*models.Access, string, error) {
 	if name == "" {
 		return nil, "", fmt.Errorf("name is required")
 	}
 
-	project, err := s.projectRepo.GetBySlug(projectSlug)
+	project, err := s.projectRepo.GetBySlug(ctx, projectSlug)
 	if err != nil {
 		return nil, "", fmt.Errorf("project not found: %w", err)
 	}
 
-	uid, err := s.generateUniqueUID(project.ID)
+	uid, err := s.generateUniqueUID(ctx, project.ID)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to generate unique UID: %w", err)
 	}
@@ -52,20 +55,26 @@ func (s *CreateAccessService) CreateAccess(projectSlug, name string, readonly bo
 
 	accessRecord := models.NewAccess(project.ID, uid, hashedPIN, name, readonly)
 
-	if err := s.accessRepo.Create(accessRecord); err != nil {
+	if err := s.accessRepo.Create(ctx, accessRecord); err != nil {
 		return nil, "", fmt.Errorf("failed to create access: %w", err)
 	}
 
+	logging.FromContext(ctx).Info("access created",
+		slog.String("project_id", project.ID.String()),
+		slog.String("access_id", accessRecord.ID.String()),
+		slog.Bool("readonly", readonly),
+	)
+
 	return accessRecord, pin, nil
 }
 
-func (s *CreateAccessService) generateUniqueUID(projectID uuid.UUID) (string, error) {
+func (s *CreateAccessService) generateUniqueUID(ctx context.Context, projectID uuid.UUID) (string, error) {
 	const maxAttempts = 100
 
 	for i := 0; i < maxAttempts; i++ {
 		uid := random.GenerateRandomNumber(2)
 
-		exists, err := s.accessRepo.ExistsByUID(projectID, uid)
+		exists, err := s.accessRepo.ExistsByUID(ctx, projectID, uid)
 		if err != nil {
 			return "", fmt.Errorf("failed to check UID existence: %w", err)
 		}
@@ -1,6 +1,7 @@
 package create_access
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
@@ -24,7 +25,7 @@ func TestCreateAccessService_CreateAccess(t *testing.T) {
 			readonly:    false,
 			repoSetup: func(projectRepo models.ProjectRepository, accessRepo models.AccessRepository) {
 				project := models.NewProject("Test Project", "test-project")
-				projectRepo.Create(project)
+				projectRepo.Create(context.Background(), project)
 			},
 			wantErr: false,
 		},
@@ -35,7 +36,7 @@ func TestCreateAccessService_CreateAccess(t *testing.T) {
 			readonly:    true,
 			repoSetup: func(projectRepo models.ProjectRepository, accessRepo models.AccessRepository) {
 				project := models.NewProject("Test Project", "test-project")
-				projectRepo.Create(project)
+				projectRepo.Create(context.Background(), project)
 			},
 			wantErr: false,
 		},
@@ -54,7 +55,7 @@ func TestCreateAccessService_CreateAccess(t *testing.T) {
 			readonly:    false,
 			repoSetup: func(projectRepo models.ProjectRepository, accessRepo models.AccessRepository) {
 				project := models.NewProject("Test Project", "test-project")
-				projectRepo.Create(project)
+				projectRepo.Create(context.Background(), project)
 			},
 			wantErr: true,
 		},
@@ -67,7 +68,7 @@ func TestCreateAccessService_CreateAccess(t *testing.T) {
 			service := NewCreateAccessService(accessRepo, projectRepo)
 			tt.repoSetup(projectRepo, accessRepo)
 
-			access, plainPIN, err := service.CreateAccess(tt.projectSlug, tt.accessName, tt.readonly)
+			access, plainPIN, err := service.CreateAccess(context.Background(), tt.projectSlug, tt.accessName, tt.readonly)
 
 			if tt.wantErr {
 				if err == nil {
@@ -137,7 +138,7 @@ func TestCreateAccessService_generateUniqueUID(t *testing.T) {
 			repoSetup: func(accessRepo models.AccessRepository, projectID uuid.UUID, existingUIDs []string) {
 				for _, uid := range existingUIDs {
 					access := models.NewAccess(projectID, uid, "12345678", "Test", false)
-					accessRepo.Create(access)
+					accessRepo.Create(context.Background(), access)
 				}
 			},
 			wantErr:        false,
@@ -150,7 +151,7 @@ func TestCreateAccessService_generateUniqueUID(t *testing.T) {
 			repoSetup: func(accessRepo models.AccessRepository, projectID uuid.UUID, existingUIDs []string) {
 				for _, uid := range existingUIDs {
 					access := models.NewAccess(projectID, uid, "12345678", "Test", false)
-					accessRepo.Create(access)
+					accessRepo.Create(context.Background(), access)
 				}
 			},
 			wantErr:        false,
@@ -165,7 +166,7 @@ func TestCreateAccessService_generateUniqueUID(t *testing.T) {
 			service := NewCreateAccessService(accessRepo, projectRepo)
 			tt.repoSetup(accessRepo, tt.projectID, tt.existingUIDs)
 
-			uid, err := service.generateUniqueUID(tt.projectID)
+			uid, err := service.generateUniqueUID(context.Background(), tt.projectID)
 
 			if tt.wantErr {
 				if err == nil {
@@ -183,7 +184,7 @@ func TestCreateAccessService_generateUniqueUID(t *testing.T) {
 				t.Errorf("generateUniqueUID() UID length = %v, want %v", len(uid), tt.expectedLength)
 			}
 
-			exists, err := accessRepo.ExistsByUID(tt.projectID, uid)
+			exists, err := accessRepo.ExistsByUID(context.Background(), tt.projectID, uid)
 			if err != nil {
 				t.Errorf("generateUniqueUID() failed to check UID existence: %v", err)
 				return
@@ -1,13 +1,20 @@
 package create_account
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"strings"
 
 	"github.com/google/uuid"
 	"gofin/internal/models"
+	"gofin/pkg/logging"
 	"gofin/pkg/money"
 )
 
+// MaxNameLength caps account names so they fit selects and balance lists.
+const MaxNameLength = 50
+
 type CreateAccountService struct {
 	accountRepo models.AccountRepository
 }
@@ -18,18 +25,53 @@ func NewCreateAccountService(accountRepo models.AccountRepository) *CreateAccoun
 	}
 }
 
+// CreateAccountData describes a new account. An empty Type means a checking account.
+// The credit card terms are ignored for other account types.
 type CreateAccountData struct {
-	ProjectID uuid.UUID
-	Name      string
-	Currency  money.Currency
+	ProjectID    uuid.UUID
+	Name         string
+	Currency     money.Currency
+	Type         models.AccountType
+	Description  string
+	CreditLimit  float64
+	StatementDay int
+	DueDay       int
 }
 
-func (s *CreateAccountService) CreateAccount(data CreateAccountData) (*models.Account, error) {
+// CreateAccount adds an account at the end of the project's display order.
+func (s *CreateAccountService) CreateAccount(ctx context.Context, data CreateAccountData) (*models.Account, error) {
+	data.Name = strings.TrimSpace(data.Name)
+	data.Description = strings.TrimSpace(data.Description)
+
 	if data.Name == "" {
 		return nil, fmt.Errorf("account name is required")
 	}
 
-	exists, err := s.accountRepo.ExistsByName(data.ProjectID, data.Name)
+	if len(data.Name) > MaxNameLength {
+		return nil, fmt.Errorf("account name cannot be longer than %d characters", MaxNameLength)
+	}
+
+	if data.Type == "" {
+		data.Type = models.AccountChecking
+	}
+
+	if !data.Type.IsValid() {
+		return nil, fmt.Errorf("invalid account type: %s", data.Type)
+	}
+
+	if len(data.Description) > models.MaxAccountDescriptionLength {
+		return nil, fmt.Errorf("description cannot be longer than %d characters", models.MaxAccountDescriptionLength)
+	}
+
+	if data.Type != models.AccountCreditCard {
+		data.CreditLimit, data.StatementDay, data.DueDay = 0, 0, 0
+	}
+
+	if err := models.ValidateCreditCardTerms(data.CreditLimit, data.StatementDay, data.DueDay); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.accountRepo.ExistsByName(ctx, data.ProjectID, data.Name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if account exists: %w", err)
 	}
@@ -38,11 +80,33 @@ func (s *CreateAccountService) CreateAccount(data CreateAccountData) (*models.Ac
 		return nil, fmt.Errorf("account with name '%s' already exists for this project", data.Name)
 	}
 
+	accounts, err := s.accountRepo.GetByProjectID(ctx, data.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
 	account := models.NewAccount(data.ProjectID, data.Name, data.Currency)
+	account.Type = data.Type
+	account.Description = data.Description
+	account.CreditLimit = data.CreditLimit
+	account.StatementDay = data.StatementDay
+	account.DueDay = data.DueDay
+	for _, existing := range accounts {
+		if existing.Position >= account.Position {
+			account.Position = existing.Position + 1
+		}
+	}
 
-	if err := s.accountRepo.Create(account); err != nil {
+	if err := s.accountRepo.Create(ctx, account); err != nil {
 		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
 
+	logging.FromContext(ctx).Info("account created",
+		slog.String("project_id", account.ProjectID.String()),
+		slog.String("account_id", account.ID.String()),
+		slog.String("currency", account.Currency.String()),
+		slog.String("type", account.Type.String()),
+	)
+
 	return account, nil
 }
@@ -1,6 +1,7 @@
 package create_account
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/uuid"
@@ -39,6 +40,28 @@ func TestCreateAccountService_CreateAccount(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "successful account creation with type and description",
+			data: CreateAccountData{
+				ProjectID:   projectID,
+				Name:        "Visa",
+				Currency:    money.Currency("PLN"),
+				Type:        models.AccountCreditCard,
+				Description: "Statement on the 5th",
+			},
+			expectError: false,
+		},
+		{
+			name: "error when account type is invalid",
+			data: CreateAccountData{
+				ProjectID: projectID,
+				Name:      "Wallet",
+				Currency:  money.Currency("PLN"),
+				Type:      models.AccountType("piggy_bank"),
+			},
+			expectError: true,
+			errorMsg:    "invalid account type: piggy_bank",
+		},
 		{
 			name: "error when account name is empty",
 			data: CreateAccountData{
@@ -65,10 +88,10 @@ func TestCreateAccountService_CreateAccount(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.name == "error when account name already exists" {
 				existingAccount := models.NewAccount(projectID, "Duplicate Account", money.Currency("PLN"))
-				accountRepo.Create(existingAccount)
+				accountRepo.Create(context.Background(), existingAccount)
 			}
 
-			account, err := service.CreateAccount(tt.data)
+			account, err := service.CreateAccount(context.Background(), tt.data)
 
 			if tt.expectError {
 				if err == nil {
@@ -99,7 +122,80 @@ func TestCreateAccountService_CreateAccount(t *testing.T) {
 				if account.ProjectID != tt.data.ProjectID {
 					t.Errorf("Expected account project ID '%s', got '%s'", tt.data.ProjectID, account.ProjectID)
 				}
+				if tt.data.Type != "" && account.Type != tt.data.Type {
+					t.Errorf("Expected account type '%s', got '%s'", tt.data.Type, account.Type)
+				}
+				if tt.data.Type == "" && account.Type != models.AccountChecking {
+					t.Errorf("Expected a checking account by default, got '%s'", account.Type)
+				}
 			}
 		})
 	}
 }
+
+func TestCreateAccountService_CreateAccount_Position(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	service := NewCreateAccountService(accountRepo)
+
+	projectID := uuid.New()
+	for i, name := range []string{"First", "Second", "Third"} {
+		account, err := service.CreateAccount(ctx, CreateAccountData{ProjectID: projectID, Name: name, Currency: money.PLN})
+		if err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+		if account.Position != i {
+			t.Errorf("Expected %s at position %d, got %d", name, i, account.Position)
+		}
+	}
+}
+
+func TestCreateAccountService_CreateAccount_CreditCardTerms(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	service := NewCreateAccountService(accountRepo)
+
+	projectID := uuid.New()
+
+	card, err := service.CreateAccount(ctx, CreateAccountData{
+		ProjectID:    projectID,
+		Name:         "Visa",
+		Currency:     money.PLN,
+		Type:         models.AccountCreditCard,
+		CreditLimit:  5000,
+		StatementDay: 25,
+		DueDay:       15,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create credit card: %v", err)
+	}
+	if card.CreditLimit != 5000 || card.StatementDay != 25 || card.DueDay != 15 {
+		t.Errorf("Expected the credit card terms to be kept, got %+v", card)
+	}
+
+	savings, err := service.CreateAccount(ctx, CreateAccountData{
+		ProjectID:    projectID,
+		Name:         "Savings",
+		Currency:     money.PLN,
+		Type:         models.AccountSavings,
+		CreditLimit:  5000,
+		StatementDay: 25,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create savings account: %v", err)
+	}
+	if savings.CreditLimit != 0 || savings.StatementDay != 0 {
+		t.Errorf("Expected credit card terms to be dropped for other types, got %+v", savings)
+	}
+
+	_, err = service.CreateAccount(ctx, CreateAccountData{
+		ProjectID: projectID,
+		Name:      "Amex",
+		Currency:  money.PLN,
+		Type:      models.AccountCreditCard,
+		DueDay:    10,
+	})
+	if err == nil || err.Error() != "payment due day requires a statement closing day" {
+		t.Errorf("Expected a missing closing day error, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package create_category
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+// MaxNameLength caps category names so they fit the dashboard and split selects.
+const MaxNameLength = 50
+
+type CreateCategoryService struct {
+	categoryRepo models.CategoryRepository
+}
+
+func NewCreateCategoryService(categoryRepo models.CategoryRepository) *CreateCategoryService {
+	return &CreateCategoryService{
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (s *CreateCategoryService) CreateCategory(ctx context.Context, projectID uuid.UUID, name string) (*models.Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("category name is required")
+	}
+
+	if len(name) > MaxNameLength {
+		return nil, fmt.Errorf("category name cannot be longer than %d characters", MaxNameLength)
+	}
+
+	exists, err := s.categoryRepo.ExistsByName(ctx, projectID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if category exists: %w", err)
+	}
+
+	if exists {
+		return nil, fmt.Errorf("category with name '%s' already exists for this project", name)
+	}
+
+	category := models.NewCategory(projectID, name)
+
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("category created",
+		slog.String("project_id", projectID.String()),
+		slog.String("category_id", category.ID.String()),
+	)
+
+	return category, nil
+}
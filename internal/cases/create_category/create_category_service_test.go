@@ -0,0 +1,85 @@
+package create_category
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+)
+
+func TestCreateCategoryService_CreateCategory(t *testing.T) {
+	categoryRepo := database.NewCategoryInMemoryRepository()
+	service := NewCreateCategoryService(categoryRepo)
+
+	projectID := uuid.New()
+	if _, err := service.CreateCategory(context.Background(), projectID, "Groceries"); err != nil {
+		t.Fatalf("Failed to create existing category: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		projectID uuid.UUID
+		category  string
+		wantName  string
+		errorMsg  string
+	}{
+		{
+			name:      "successful category creation",
+			projectID: projectID,
+			category:  "  Pharmacy ",
+			wantName:  "Pharmacy",
+		},
+		{
+			name:      "same name in another project",
+			projectID: uuid.New(),
+			category:  "Groceries",
+			wantName:  "Groceries",
+		},
+		{
+			name:      "error when name is empty",
+			projectID: projectID,
+			category:  "   ",
+			errorMsg:  "category name is required",
+		},
+		{
+			name:      "error when name is too long",
+			projectID: projectID,
+			category:  strings.Repeat("a", MaxNameLength+1),
+			errorMsg:  "category name cannot be longer than 50 characters",
+		},
+		{
+			name:      "error when name exists ignoring case",
+			projectID: projectID,
+			category:  "groceries",
+			errorMsg:  "category with name 'groceries' already exists for this project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := service.CreateCategory(context.Background(), tt.projectID, tt.category)
+
+			if tt.errorMsg != "" {
+				if err == nil {
+					t.Fatalf("Expected error but got none")
+				}
+				if err.Error() != tt.errorMsg {
+					t.Errorf("Expected error message '%s', got '%s'", tt.errorMsg, err.Error())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if category.Name != tt.wantName {
+				t.Errorf("Expected category name '%s', got '%s'", tt.wantName, category.Name)
+			}
+			if category.ProjectID != tt.projectID {
+				t.Errorf("Expected project ID '%s', got '%s'", tt.projectID, category.ProjectID)
+			}
+		})
+	}
+}
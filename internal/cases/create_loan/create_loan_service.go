@@ -0,0 +1,96 @@
+package create_loan
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/create_account"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	"gofin/pkg/money"
+)
+
+type CreateLoanService struct {
+	loanRepo             models.LoanRepository
+	transactionRepo      models.TransactionRepository
+	createAccountService *create_account.CreateAccountService
+	validatePeriodSvc    *validate_period.ValidatePeriodService
+}
+
+func NewCreateLoanService(loanRepo models.LoanRepository, accountRepo models.AccountRepository, transactionRepo models.TransactionRepository, projectRepo models.ProjectRepository) *CreateLoanService {
+	return &CreateLoanService{
+		loanRepo:             loanRepo,
+		transactionRepo:      transactionRepo,
+		createAccountService: create_account.NewCreateAccountService(accountRepo),
+		validatePeriodSvc:    validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+	}
+}
+
+// CreateLoanData describes a new loan. AnnualRate is in percent and the first
+// installment is due on PaymentDay of the month after StartDate.
+type CreateLoanData struct {
+	Name        string
+	Currency    money.Currency
+	Description string
+	Principal   float64
+	AnnualRate  float64
+	TermMonths  int
+	PaymentDay  int
+	StartDate   time.Time
+}
+
+// CreateLoan opens a loan account and books the principal on it as a debit on
+// the start date, so the account balance shows the debt.
+func (s *CreateLoanService) CreateLoan(ctx context.Context, projectID uuid.UUID, data CreateLoanData) (*models.Loan, error) {
+	if err := models.ValidateLoanTerms(data.Principal, data.AnnualRate, data.TermMonths, data.PaymentDay); err != nil {
+		return nil, err
+	}
+
+	if data.StartDate.IsZero() {
+		return nil, fmt.Errorf("start date is required")
+	}
+
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, data.StartDate); err != nil {
+		return nil, err
+	}
+
+	account, err := s.createAccountService.CreateAccount(ctx, create_account.CreateAccountData{
+		ProjectID:   projectID,
+		Name:        data.Name,
+		Currency:    data.Currency,
+		Type:        models.AccountLoan,
+		Description: data.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	loan := models.NewLoan(account, data.Principal, data.AnnualRate, data.TermMonths, data.PaymentDay, data.StartDate)
+	if err := s.loanRepo.Create(ctx, loan); err != nil {
+		return nil, fmt.Errorf("failed to create loan: %w", err)
+	}
+
+	startDate := data.StartDate
+	disbursement := models.NewTransaction(models.TransactionData{
+		AccountID:       account.ID,
+		Value:           data.Principal,
+		Name:            fmt.Sprintf("%s: principal", account.Name),
+		Type:            models.Debit,
+		TransactionDate: &startDate,
+	})
+	if err := s.transactionRepo.Create(ctx, disbursement); err != nil {
+		return nil, fmt.Errorf("failed to book loan principal: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("loan created",
+		slog.String("project_id", projectID.String()),
+		slog.String("account_id", account.ID.String()),
+		slog.Int("term_months", data.TermMonths),
+	)
+
+	return loan, nil
+}
@@ -0,0 +1,97 @@
+package create_loan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestCreateLoanService_CreateLoan(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		data        CreateLoanData
+		expectError bool
+	}{
+		{
+			name: "valid loan",
+			data: CreateLoanData{Name: "Mortgage", Currency: money.PLN, Principal: 12000, AnnualRate: 12, TermMonths: 12, PaymentDay: 10, StartDate: start},
+		},
+		{
+			name:        "non-positive principal",
+			data:        CreateLoanData{Name: "Mortgage", Currency: money.PLN, Principal: 0, AnnualRate: 12, TermMonths: 12, PaymentDay: 10, StartDate: start},
+			expectError: true,
+		},
+		{
+			name:        "negative rate",
+			data:        CreateLoanData{Name: "Mortgage", Currency: money.PLN, Principal: 1000, AnnualRate: -1, TermMonths: 12, PaymentDay: 10, StartDate: start},
+			expectError: true,
+		},
+		{
+			name:        "term too long",
+			data:        CreateLoanData{Name: "Mortgage", Currency: money.PLN, Principal: 1000, AnnualRate: 5, TermMonths: models.MaxLoanTermMonths + 1, PaymentDay: 10, StartDate: start},
+			expectError: true,
+		},
+		{
+			name:        "invalid payment day",
+			data:        CreateLoanData{Name: "Mortgage", Currency: money.PLN, Principal: 1000, AnnualRate: 5, TermMonths: 12, PaymentDay: 32, StartDate: start},
+			expectError: true,
+		},
+		{
+			name:        "missing start date",
+			data:        CreateLoanData{Name: "Mortgage", Currency: money.PLN, Principal: 1000, AnnualRate: 5, TermMonths: 12, PaymentDay: 10},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			loanRepo := database.NewLoanInMemoryRepository()
+			accountRepo := database.NewAccountInMemoryRepository()
+			transactionRepo := database.NewTransactionInMemoryRepository()
+			projectRepo := database.NewProjectInMemoryRepository()
+			service := NewCreateLoanService(loanRepo, accountRepo, transactionRepo, projectRepo)
+			project := models.NewProject("Test Project", "test-project")
+			projectRepo.Create(ctx, project)
+			projectID := project.ID
+
+			loan, err := service.CreateLoan(ctx, projectID, tt.data)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				accounts, _ := accountRepo.GetByProjectID(ctx, projectID)
+				if len(accounts) != 0 {
+					t.Errorf("Expected no account to be created, got %d", len(accounts))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			account, err := accountRepo.GetByID(ctx, loan.AccountID)
+			if err != nil {
+				t.Fatalf("Expected loan account to exist, got %v", err)
+			}
+			if account.Type != models.AccountLoan || account.ProjectID != projectID {
+				t.Errorf("Expected a loan account in the project, got %+v", account)
+			}
+
+			transactions, _ := transactionRepo.GetByAccountID(ctx, account.ID)
+			if len(transactions) != 1 || transactions[0].Type != models.Debit || transactions[0].Value != tt.data.Principal {
+				t.Fatalf("Expected the principal booked as a debit, got %+v", transactions)
+			}
+			if !transactions[0].TransactionDate.Equal(start) {
+				t.Errorf("Expected the principal booked on the start date, got %v", transactions[0].TransactionDate)
+			}
+		})
+	}
+}
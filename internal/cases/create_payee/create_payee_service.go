@@ -0,0 +1,113 @@
+package create_payee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/match_payee"
+	"gofin/internal/cases/validate_category"
+	"gofin/internal/cases/validate_payee"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+// MaxNameLength caps payee names so they fit transaction lists and selects.
+const MaxNameLength = 100
+
+type CreatePayeeData struct {
+	Name              string
+	Aliases           []string
+	DefaultCategoryID *uuid.UUID
+}
+
+type CreatePayeeService struct {
+	payeeRepo           models.PayeeRepository
+	validatePayeeSvc    *validate_payee.ValidatePayeeService
+	validateCategorySvc *validate_category.ValidateCategoryService
+	matchPayeeSvc       *match_payee.MatchPayeeService
+}
+
+func NewCreatePayeeService(payeeRepo models.PayeeRepository, categoryRepo models.CategoryRepository, transactionRepo models.TransactionRepository, projectRepo models.ProjectRepository) *CreatePayeeService {
+	return &CreatePayeeService{
+		payeeRepo:           payeeRepo,
+		validatePayeeSvc:    validate_payee.NewValidatePayeeService(payeeRepo),
+		validateCategorySvc: validate_category.NewValidateCategoryService(categoryRepo),
+		matchPayeeSvc:       match_payee.NewMatchPayeeService(payeeRepo, transactionRepo, projectRepo),
+	}
+}
+
+// CreatePayee adds a payee whose own name always acts as an alias, then links
+// existing transactions that match it.
+func (s *CreatePayeeService) CreatePayee(ctx context.Context, projectID uuid.UUID, data CreatePayeeData) (*models.Payee, error) {
+	name := strings.TrimSpace(data.Name)
+	if name == "" {
+		return nil, fmt.Errorf("payee name is required")
+	}
+
+	if len(name) > MaxNameLength {
+		return nil, fmt.Errorf("payee name cannot be longer than %d characters", MaxNameLength)
+	}
+
+	exists, err := s.payeeRepo.ExistsByName(ctx, projectID, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if payee exists: %w", err)
+	}
+
+	if exists {
+		return nil, fmt.Errorf("payee with name '%s' already exists for this project", name)
+	}
+
+	if data.DefaultCategoryID != nil {
+		if err := s.validateCategorySvc.ValidateCategoryForProject(ctx, projectID, *data.DefaultCategoryID); err != nil {
+			return nil, err
+		}
+	}
+
+	var aliases []string
+	for _, alias := range append([]string{name}, data.Aliases...) {
+		normalized := models.NormalizePayeeName(alias)
+		if normalized == "" {
+			if alias == name {
+				return nil, fmt.Errorf("payee name must contain letters")
+			}
+			continue
+		}
+		if !slices.Contains(aliases, normalized) {
+			aliases = append(aliases, normalized)
+		}
+	}
+
+	payee := models.NewPayee(projectID, name, data.DefaultCategoryID)
+
+	for _, alias := range aliases {
+		if err := s.validatePayeeSvc.ValidateAliasAvailable(ctx, projectID, payee.ID, alias); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.payeeRepo.Create(ctx, payee); err != nil {
+		return nil, fmt.Errorf("failed to create payee: %w", err)
+	}
+
+	for _, alias := range aliases {
+		if err := s.payeeRepo.AddAlias(ctx, models.NewPayeeAlias(payee, alias)); err != nil {
+			return nil, fmt.Errorf("failed to add payee alias: %w", err)
+		}
+	}
+
+	logging.FromContext(ctx).Info("payee created",
+		slog.String("project_id", projectID.String()),
+		slog.String("payee_id", payee.ID.String()),
+		slog.Int("aliases", len(aliases)),
+	)
+
+	if _, err := s.matchPayeeSvc.LinkUnassigned(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
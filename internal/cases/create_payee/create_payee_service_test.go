@@ -0,0 +1,131 @@
+package create_payee
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+)
+
+func TestCreatePayeeService_CreatePayee(t *testing.T) {
+	ctx := context.Background()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	categoryRepo := database.NewCategoryInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	project := models.NewProject("Home", "home")
+	projectRepo.Create(ctx, project)
+	service := NewCreatePayeeService(payeeRepo, categoryRepo, transactionRepo, projectRepo)
+
+	projectID := project.ID
+	groceries := models.NewCategory(projectID, "Groceries")
+	categoryRepo.Create(ctx, groceries)
+	foreignCategory := models.NewCategory(uuid.New(), "Elsewhere")
+	categoryRepo.Create(ctx, foreignCategory)
+
+	if _, err := service.CreatePayee(ctx, projectID, CreatePayeeData{Name: "Lidl"}); err != nil {
+		t.Fatalf("Failed to create existing payee: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		data        CreatePayeeData
+		wantAliases []string
+		errorMsg    string
+	}{
+		{
+			name:        "successful payee creation with aliases",
+			data:        CreatePayeeData{Name: " Biedronka ", Aliases: []string{"JMP S.A. BIEDRONKA", "biedronka 123"}, DefaultCategoryID: &groceries.ID},
+			wantAliases: []string{"biedronka", "jmp s a biedronka"},
+		},
+		{
+			name:     "error when name is empty",
+			data:     CreatePayeeData{Name: "  "},
+			errorMsg: "payee name is required",
+		},
+		{
+			name:     "error when name is too long",
+			data:     CreatePayeeData{Name: strings.Repeat("a", MaxNameLength+1)},
+			errorMsg: "payee name cannot be longer than 100 characters",
+		},
+		{
+			name:     "error when name has no letters",
+			data:     CreatePayeeData{Name: "123"},
+			errorMsg: "payee name must contain letters",
+		},
+		{
+			name:     "error when name exists ignoring case",
+			data:     CreatePayeeData{Name: "LIDL"},
+			errorMsg: "payee with name 'LIDL' already exists for this project",
+		},
+		{
+			name:     "error when alias belongs to another payee",
+			data:     CreatePayeeData{Name: "Lidl Polska", Aliases: []string{"LIDL 0042"}},
+			errorMsg: "alias 'lidl' is already used by payee 'Lidl'",
+		},
+		{
+			name:     "error when category belongs to another project",
+			data:     CreatePayeeData{Name: "Orlen", DefaultCategoryID: &foreignCategory.ID},
+			errorMsg: "category does not belong to the specified project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payee, err := service.CreatePayee(ctx, projectID, tt.data)
+
+			if tt.errorMsg != "" {
+				if err == nil || err.Error() != tt.errorMsg {
+					t.Fatalf("Expected error %q, got %v", tt.errorMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			aliases, _ := payeeRepo.GetAliasesByProjectID(ctx, projectID)
+			var got []string
+			for _, alias := range aliases {
+				if alias.PayeeID == payee.ID {
+					got = append(got, alias.Alias)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantAliases, ",") {
+				t.Errorf("Expected aliases %v, got %v", tt.wantAliases, got)
+			}
+		})
+	}
+}
+
+func TestCreatePayeeService_LinksExistingTransactions(t *testing.T) {
+	ctx := context.Background()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	project := models.NewProject("Home", "home")
+	projectRepo.Create(ctx, project)
+	service := NewCreatePayeeService(payeeRepo, database.NewCategoryInMemoryRepository(), transactionRepo, projectRepo)
+
+	projectID := project.ID
+	matching := models.NewTransaction(models.TransactionData{AccountID: uuid.New(), Value: 12, Name: "ORLEN 4410", Type: models.Debit})
+	other := models.NewTransaction(models.TransactionData{AccountID: uuid.New(), Value: 3, Name: "Kiosk", Type: models.Debit})
+	transactionRepo.Create(ctx, matching)
+	transactionRepo.Create(ctx, other)
+
+	payee, err := service.CreatePayee(ctx, projectID, CreatePayeeData{Name: "Orlen"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got, _ := transactionRepo.GetByID(ctx, matching.ID); got.PayeeID == nil || *got.PayeeID != payee.ID {
+		t.Errorf("Expected the existing transaction to be linked, got %v", got.PayeeID)
+	}
+	if got, _ := transactionRepo.GetByID(ctx, other.ID); got.PayeeID != nil {
+		t.Errorf("Expected an unrelated transaction to stay unlinked, got %v", got.PayeeID)
+	}
+}
@@ -1,9 +1,12 @@
 package create_project
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 
 	"gofin/internal/models"
+	"gofin/pkg/logging"
 	"gofin/pkg/slug"
 )
 
@@ -17,27 +20,29 @@ func NewCreateProjectService(projectRepo models.ProjectRepository) *CreateProjec
 	}
 }
 
-func (s *CreateProjectService) CreateProject(name, customSlug string) (*models.Project, error) {
+func (s *CreateProjectService) CreateProject(ctx context.Context, name, customSlug string) (*models.Project, error) {
 	if name == "" {
 		return nil, fmt.Errorf("project name is required")
 	}
 
-	finalSlug, err := s.determineSlug(name, customSlug)
+	finalSlug, err := s.determineSlug(ctx, name, customSlug)
 	if err != nil {
 		return nil, err
 	}
 
 	project := models.NewProject(name, finalSlug)
 
-	err = s.projectRepo.Create(project)
+	err = s.projectRepo.Create(ctx, project)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create project: %w", err)
 	}
 
+	logging.FromContext(ctx).Info("project created", slog.String("project_id", project.ID.String()), slog.String("slug", project.Slug))
+
 	return project, nil
 }
 
-func (s *CreateProjectService) determineSlug(name, customSlug string) (string, error) {
+func (s *CreateProjectService) determineSlug(ctx context.Context, name, customSlug string) (string, error) {
 	if customSlug != "" {
 		err := slug.Validate(customSlug)
 		if err != nil {
@@ -47,13 +52,13 @@ func (s *CreateProjectService) determineSlug(name, customSlug string) (string, e
 	}
 
 	baseSlug := slug.Generate(name)
-	return s.ensureUniqueSlug(baseSlug)
+	return s.ensureUniqueSlug(ctx, baseSlug)
 }
 
 const maxSlugAttempts = 50
 
-func (s *CreateProjectService) ensureUniqueSlug(baseSlug string) (string, error) {
-	exists, err := s.projectRepo.ExistsBySlug(baseSlug)
+func (s *CreateProjectService) ensureUniqueSlug(ctx context.Context, baseSlug string) (string, error) {
+	exists, err := s.projectRepo.ExistsBySlug(ctx, baseSlug)
 	if err != nil {
 		return "", fmt.Errorf("failed to check slug availability: %w", err)
 	}
@@ -64,7 +69,7 @@ func (s *CreateProjectService) ensureUniqueSlug(baseSlug string) (string, error)
 
 	for counter := 1; counter <= maxSlugAttempts; counter++ {
 		candidateSlug := fmt.Sprintf("%s-%d", baseSlug, counter)
-		exists, err := s.projectRepo.ExistsBySlug(candidateSlug)
+		exists, err := s.projectRepo.ExistsBySlug(ctx, candidateSlug)
 		if err != nil {
 			return "", fmt.Errorf("failed to check slug availability: %w", err)
 		}
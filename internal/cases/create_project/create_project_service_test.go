@@ -1,6 +1,7 @@
 package create_project
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -53,7 +54,7 @@ func TestCreateProjectService_CreateProject(t *testing.T) {
 			customSlug:  "existing-slug",
 			repoSetup: func(r models.ProjectRepository) {
 				existingProject := models.NewProject("Existing Project", "existing-slug")
-				r.Create(existingProject)
+				r.Create(context.Background(), existingProject)
 			},
 			wantErr: true,
 		},
@@ -65,7 +66,7 @@ func TestCreateProjectService_CreateProject(t *testing.T) {
 			service := NewCreateProjectService(repository)
 			tt.repoSetup(repository)
 
-			project, err := service.CreateProject(tt.projectName, tt.customSlug)
+			project, err := service.CreateProject(context.Background(), tt.projectName, tt.customSlug)
 
 			if tt.wantErr {
 				if err == nil {
@@ -138,10 +139,10 @@ func TestCreateProjectService_ensureUniqueSlug(t *testing.T) {
 
 			for _, slug := range tt.existingSlugs {
 				project := models.NewProject("Test Project", slug)
-				repository.Create(project)
+				repository.Create(context.Background(), project)
 			}
 
-			slug, err := service.ensureUniqueSlug(tt.baseSlug)
+			slug, err := service.ensureUniqueSlug(context.Background(), tt.baseSlug)
 
 			if tt.wantErr {
 				if err == nil {
@@ -196,7 +197,7 @@ func TestCreateProjectService_determineSlug(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repository := database.NewProjectInMemoryRepository()
 			service := NewCreateProjectService(repository)
-			slug, err := service.determineSlug(tt.projectName, tt.customSlug)
+			slug, err := service.determineSlug(context.Background(), tt.projectName, tt.customSlug)
 
 			if tt.wantErr {
 				if err == nil {
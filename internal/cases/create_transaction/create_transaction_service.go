@@ -1,39 +1,79 @@
 package create_transaction
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"gofin/internal/cases/match_payee"
 	"gofin/internal/cases/validate_account"
+	"gofin/internal/cases/validate_category"
+	"gofin/internal/cases/validate_period"
 	"gofin/internal/models"
+	"gofin/pkg/logging"
 )
 
 type CreateTransactionService struct {
-	transactionRepo    models.TransactionRepository
-	accountRepo        models.AccountRepository
-	projectRepo        models.ProjectRepository
-	validateAccountSvc *validate_account.ValidateAccountService
+	transactionRepo     models.TransactionRepository
+	accountRepo         models.AccountRepository
+	projectRepo         models.ProjectRepository
+	splitRepo           models.TransactionSplitRepository
+	validateAccountSvc  *validate_account.ValidateAccountService
+	validateCategorySvc *validate_category.ValidateCategoryService
+	validatePeriodSvc   *validate_period.ValidatePeriodService
+	matchPayeeSvc       *match_payee.MatchPayeeService
 }
 
-func NewCreateTransactionService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, projectRepo models.ProjectRepository) *CreateTransactionService {
+func NewCreateTransactionService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, projectRepo models.ProjectRepository, categoryRepo models.CategoryRepository, splitRepo models.TransactionSplitRepository, payeeRepo models.PayeeRepository) *CreateTransactionService {
 	return &CreateTransactionService{
-		transactionRepo:    transactionRepo,
-		accountRepo:        accountRepo,
-		projectRepo:        projectRepo,
-		validateAccountSvc: validate_account.NewValidateAccountService(accountRepo),
+		transactionRepo:     transactionRepo,
+		accountRepo:         accountRepo,
+		projectRepo:         projectRepo,
+		splitRepo:           splitRepo,
+		validateAccountSvc:  validate_account.NewValidateAccountService(accountRepo),
+		validateCategorySvc: validate_category.NewValidateCategoryService(categoryRepo),
+		validatePeriodSvc:   validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+		matchPayeeSvc:       match_payee.NewMatchPayeeService(payeeRepo, transactionRepo, projectRepo),
 	}
 }
 
-func (s *CreateTransactionService) CreateGroupedTransactions(projectID uuid.UUID, transactions []models.TransactionData) ([]*models.Transaction, error) {
+// CreateGroupedTransactions creates transactions sharing one group. Each is linked
+// to the payee its name matches, and a payee's default category applies when the
+// transaction comes without a category or splits.
+func (s *CreateTransactionService) CreateGroupedTransactions(ctx context.Context, projectID uuid.UUID, transactions []models.TransactionData) ([]*models.Transaction, error) {
 	if len(transactions) == 0 {
 		return nil, fmt.Errorf("at least one transaction is required")
 	}
 
-	for _, txData := range transactions {
-		if err := s.validateAccountSvc.ValidateAccountForProject(projectID, txData.AccountID); err != nil {
+	matcher, err := s.matchPayeeSvc.LoadMatcher(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions = slices.Clone(transactions)
+	for i, txData := range transactions {
+		txData, err := matcher.Apply(txData)
+		if err != nil {
+			return nil, err
+		}
+		transactions[i] = txData
+
+		if err := s.validateAccountSvc.ValidateAccountActive(ctx, projectID, txData.AccountID); err != nil {
+			return nil, err
+		}
+
+		if err := s.validateCategorySvc.ValidateAssignment(ctx, projectID, txData.Value, txData.CategoryID, txData.Splits); err != nil {
 			return nil, err
 		}
+
+		if txData.TransactionDate != nil {
+			if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, *txData.TransactionDate); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	groupID := uuid.New()
@@ -46,13 +86,26 @@ func (s *CreateTransactionService) CreateGroupedTransactions(projectID uuid.UUID
 
 		transaction := models.NewTransaction(txData, groupID)
 
-		if err := s.transactionRepo.Create(transaction); err != nil {
+		if err := s.transactionRepo.Create(ctx, transaction); err != nil {
 			return nil, fmt.Errorf("failed to create transaction: %w", err)
 		}
 
+		if len(txData.Splits) > 0 {
+			splits := models.NewTransactionSplits(transaction.ID, txData.Splits)
+			if err := s.splitRepo.ReplaceForTransaction(ctx, transaction.ID, splits); err != nil {
+				return nil, fmt.Errorf("failed to create transaction splits: %w", err)
+			}
+		}
+
 		createdTransactions = append(createdTransactions, transaction)
 	}
 
+	logging.FromContext(ctx).Info("transactions created",
+		slog.String("project_id", projectID.String()),
+		slog.String("group_id", groupID.String()),
+		slog.Int("count", len(createdTransactions)),
+	)
+
 	return createdTransactions, nil
 }
 
@@ -65,6 +118,10 @@ func (s *CreateTransactionService) validateTransactionData(data models.Transacti
 		return fmt.Errorf("value must be positive")
 	}
 
+	if len(data.Notes) > models.MaxNotesLength {
+		return fmt.Errorf("notes cannot be longer than %d characters", models.MaxNotesLength)
+	}
+
 	if !data.Type.IsValid() {
 		return fmt.Errorf("invalid transaction type: %s", data.Type)
 	}
@@ -1,7 +1,9 @@
 package create_transaction
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"gofin/internal/infrastructure/database"
@@ -25,11 +27,11 @@ func TestCreateTransactionService_CreateGroupedTransactions(t *testing.T) {
 			repoSetup: func(accountRepo models.AccountRepository, transactionRepo models.TransactionRepository, projectRepo models.ProjectRepository, accountIDs []uuid.UUID, projectID uuid.UUID) {
 				account1 := models.NewAccount(projectID, "Account 1", "USD")
 				account1.ID = accountIDs[0]
-				accountRepo.Create(account1)
+				accountRepo.Create(context.Background(), account1)
 
 				account2 := models.NewAccount(projectID, "Account 2", "USD")
 				account2.ID = accountIDs[1]
-				accountRepo.Create(account2)
+				accountRepo.Create(context.Background(), account2)
 			},
 			wantErr:   false,
 			wantCount: 2,
@@ -57,7 +59,7 @@ func TestCreateTransactionService_CreateGroupedTransactions(t *testing.T) {
 			accountRepo := database.NewAccountInMemoryRepository()
 			transactionRepo := database.NewTransactionInMemoryRepository()
 			projectRepo := database.NewProjectInMemoryRepository()
-			service := NewCreateTransactionService(transactionRepo, accountRepo, projectRepo)
+			service := NewCreateTransactionService(transactionRepo, accountRepo, projectRepo, database.NewCategoryInMemoryRepository(), database.NewTransactionSplitInMemoryRepository(), database.NewPayeeInMemoryRepository())
 
 			var accountIDs []uuid.UUID
 			for _, tx := range tt.transactions {
@@ -66,12 +68,12 @@ func TestCreateTransactionService_CreateGroupedTransactions(t *testing.T) {
 			var projectID uuid.UUID
 			if len(tt.transactions) > 0 {
 				project := models.NewProject("Test Project", "test-project")
-				projectRepo.Create(project)
+				projectRepo.Create(context.Background(), project)
 				projectID = project.ID
 			}
 
 			tt.repoSetup(accountRepo, transactionRepo, projectRepo, accountIDs, projectID)
-			transactions, err := service.CreateGroupedTransactions(projectID, tt.transactions)
+			transactions, err := service.CreateGroupedTransactions(context.Background(), projectID, tt.transactions)
 
 			if tt.wantErr {
 				if err == nil {
@@ -104,3 +106,142 @@ func TestCreateTransactionService_CreateGroupedTransactions(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTransactionService_CreateGroupedTransactions_Splits(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	categoryRepo := database.NewCategoryInMemoryRepository()
+	splitRepo := database.NewTransactionSplitInMemoryRepository()
+	service := NewCreateTransactionService(transactionRepo, accountRepo, database.NewProjectInMemoryRepository(), categoryRepo, splitRepo, database.NewPayeeInMemoryRepository())
+
+	projectID := uuid.New()
+	account := models.NewAccount(projectID, "Account", "PLN")
+	accountRepo.Create(ctx, account)
+
+	groceries := models.NewCategory(projectID, "Groceries")
+	household := models.NewCategory(projectID, "Household")
+	categoryRepo.Create(ctx, groceries)
+	categoryRepo.Create(ctx, household)
+
+	receipt := models.TransactionData{
+		AccountID: account.ID,
+		Value:     42.50,
+		Name:      "Supermarket",
+		Type:      models.Debit,
+		Splits: []models.SplitData{
+			{CategoryID: groceries.ID, Amount: 30},
+			{CategoryID: household.ID, Amount: 12.49},
+		},
+	}
+
+	if _, err := service.CreateGroupedTransactions(ctx, projectID, []models.TransactionData{receipt}); err == nil {
+		t.Fatal("Expected error when splits do not add up to the value")
+	}
+
+	receipt.Splits[1].Amount = 12.50
+	created, err := service.CreateGroupedTransactions(ctx, projectID, []models.TransactionData{receipt})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	splits, err := splitRepo.GetByTransactionID(ctx, created[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to get splits: %v", err)
+	}
+	if len(splits) != 2 || splits[0].CategoryID != groceries.ID || splits[1].Amount != 12.50 {
+		t.Errorf("Expected the two split lines to be stored in order, got %+v", splits)
+	}
+
+	unknownCategory := uuid.New()
+	unknown := models.TransactionData{AccountID: account.ID, Value: 5, Name: "Other", Type: models.Debit, CategoryID: &unknownCategory}
+	if _, err := service.CreateGroupedTransactions(ctx, projectID, []models.TransactionData{unknown}); err == nil {
+		t.Error("Expected error for an unknown category")
+	}
+}
+
+func TestCreateTransactionService_CreateGroupedTransactions_Payees(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	categoryRepo := database.NewCategoryInMemoryRepository()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	service := NewCreateTransactionService(transactionRepo, accountRepo, database.NewProjectInMemoryRepository(), categoryRepo, database.NewTransactionSplitInMemoryRepository(), payeeRepo)
+
+	projectID := uuid.New()
+	account := models.NewAccount(projectID, "Account", "PLN")
+	accountRepo.Create(ctx, account)
+
+	groceries := models.NewCategory(projectID, "Groceries")
+	fuel := models.NewCategory(projectID, "Fuel")
+	categoryRepo.Create(ctx, groceries)
+	categoryRepo.Create(ctx, fuel)
+
+	biedronka := models.NewPayee(projectID, "Biedronka", &groceries.ID)
+	payeeRepo.Create(ctx, biedronka)
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(biedronka, "biedronka"))
+
+	created, err := service.CreateGroupedTransactions(ctx, projectID, []models.TransactionData{
+		{AccountID: account.ID, Value: 20, Name: "BIEDRONKA 123", Type: models.Debit},
+		{AccountID: account.ID, Value: 10, Name: "biedronka", Type: models.Debit, CategoryID: &fuel.ID},
+		{AccountID: account.ID, Value: 5, Name: "Kiosk", Type: models.Debit},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if created[0].PayeeID == nil || *created[0].PayeeID != biedronka.ID {
+		t.Errorf("Expected the first transaction to match the payee, got %v", created[0].PayeeID)
+	}
+	if created[0].CategoryID == nil || *created[0].CategoryID != groceries.ID {
+		t.Errorf("Expected the payee default category, got %v", created[0].CategoryID)
+	}
+	if created[1].CategoryID == nil || *created[1].CategoryID != fuel.ID {
+		t.Errorf("Expected an explicit category to win over the payee default, got %v", created[1].CategoryID)
+	}
+	if created[2].PayeeID != nil || created[2].CategoryID != nil {
+		t.Errorf("Expected no payee or category for an unknown name, got %v / %v", created[2].PayeeID, created[2].CategoryID)
+	}
+
+	otherProjectPayee := models.NewPayee(uuid.New(), "Elsewhere", nil)
+	payeeRepo.Create(ctx, otherProjectPayee)
+	foreign := models.TransactionData{AccountID: account.ID, Value: 5, Name: "Other", Type: models.Debit, PayeeID: &otherProjectPayee.ID}
+	if _, err := service.CreateGroupedTransactions(ctx, projectID, []models.TransactionData{foreign}); err == nil {
+		t.Error("Expected error for a payee from another project")
+	}
+}
+
+func TestCreateTransactionService_CreateGroupedTransactions_ClosedPeriod(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewCreateTransactionService(transactionRepo, accountRepo, projectRepo, database.NewCategoryInMemoryRepository(), database.NewTransactionSplitInMemoryRepository(), database.NewPayeeInMemoryRepository())
+
+	lockedUntil := models.MonthEnd(2024, time.March)
+	project := models.NewProject("Test Project", "test-project")
+	project.LockedUntil = &lockedUntil
+	projectRepo.Create(ctx, project)
+
+	account := models.NewAccount(project.ID, "Account", "PLN")
+	accountRepo.Create(ctx, account)
+
+	closed := time.Date(2024, time.March, 31, 18, 0, 0, 0, time.UTC)
+	open := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	batch := []models.TransactionData{
+		{AccountID: account.ID, Value: 10, Name: "April", Type: models.Debit, TransactionDate: &open},
+		{AccountID: account.ID, Value: 10, Name: "March", Type: models.Debit, TransactionDate: &closed},
+	}
+	if _, err := service.CreateGroupedTransactions(ctx, project.ID, batch); err == nil {
+		t.Fatal("Expected an error for a transaction dated in a closed period")
+	}
+
+	if transactions, _ := transactionRepo.GetByAccountID(ctx, account.ID); len(transactions) != 0 {
+		t.Errorf("Expected no transaction of the batch to be created, got %d", len(transactions))
+	}
+
+	if _, err := service.CreateGroupedTransactions(ctx, project.ID, batch[:1]); err != nil {
+		t.Errorf("Expected no error after the closed period, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package credit_card_statements
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+// ReminderDays is how close a payment due date has to be before the dashboard
+// reminds about it.
+const ReminderDays = 10
+
+// DefaultCycles is how many closed statements GetStatements returns when asked
+// for none.
+const DefaultCycles = 6
+
+type CreditCardStatementsService struct {
+	accountRepo     models.AccountRepository
+	transactionRepo models.TransactionRepository
+}
+
+func NewCreditCardStatementsService(accountRepo models.AccountRepository, transactionRepo models.TransactionRepository) *CreditCardStatementsService {
+	return &CreditCardStatementsService{
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Statement sums one billing cycle. Charges are debits and Payments top-ups made
+// during the cycle; Balance is what was owed on the card when the cycle closed.
+type Statement struct {
+	models.StatementCycle
+	Charges  float64 `json:"charges"`
+	Payments float64 `json:"payments"`
+	Balance  float64 `json:"balance"`
+	Count    int     `json:"count"`
+}
+
+type CreditCardSummary struct {
+	Account *models.Account `json:"account"`
+	// Owed is the current debt on the card, negative when it was overpaid.
+	Owed float64 `json:"owed"`
+	// AvailableCredit is the limit minus Owed, zero when the card has no limit.
+	AvailableCredit float64 `json:"available_credit"`
+	// Current is the open cycle, Statements the closed ones, newest first.
+	Current    Statement   `json:"current"`
+	Statements []Statement `json:"statements"`
+	// Unpaid is what is left of the last statement after the payments made
+	// since it closed.
+	Unpaid float64 `json:"unpaid"`
+}
+
+// PaymentReminder is an unpaid statement whose due date is near or has passed.
+type PaymentReminder struct {
+	AccountID   uuid.UUID `json:"account_id"`
+	AccountName string    `json:"account_name"`
+	Currency    string    `json:"currency"`
+	Amount      float64   `json:"amount"`
+	DueDate     time.Time `json:"due_date"`
+	DaysLeft    int       `json:"days_left"`
+}
+
+// GetStatements returns the open cycle and up to cycles closed statements of a
+// credit card as of now. Transactions dated after now are left out.
+func (s *CreditCardStatementsService) GetStatements(ctx context.Context, projectID, accountID uuid.UUID, cycles int, now time.Time) (*CreditCardSummary, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	if account.ProjectID != projectID {
+		return nil, fmt.Errorf("account does not belong to the specified project")
+	}
+
+	if !account.HasStatements() {
+		return nil, fmt.Errorf("account '%s' is not a credit card with a statement closing day", account.Name)
+	}
+
+	if cycles <= 0 {
+		cycles = DefaultCycles
+	}
+
+	transactions, err := s.transactionRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account transactions: %w", err)
+	}
+
+	return summarize(account, transactions, cycles, now), nil
+}
+
+// GetPaymentReminders lists the credit cards of a project whose last statement
+// is not paid off and falls due within ReminderDays of now, or already has.
+func (s *CreditCardStatementsService) GetPaymentReminders(ctx context.Context, projectID uuid.UUID, now time.Time) ([]PaymentReminder, error) {
+	accounts, err := s.accountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var reminders []PaymentReminder
+	for _, account := range models.ActiveAccounts(accounts) {
+		if !account.HasStatements() || account.DueDay == 0 {
+			continue
+		}
+
+		transactions, err := s.transactionRepo.GetByAccountID(ctx, account.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account transactions: %w", err)
+		}
+
+		summary := summarize(account, transactions, 1, now)
+		if len(summary.Statements) == 0 || summary.Unpaid < 0.005 {
+			continue
+		}
+
+		dueDate := summary.Statements[0].DueDate
+		daysLeft := int(math.Round(dueDate.Sub(today).Hours() / 24))
+		if daysLeft > ReminderDays {
+			continue
+		}
+
+		reminders = append(reminders, PaymentReminder{
+			AccountID:   account.ID,
+			AccountName: account.Name,
+			Currency:    account.Currency.String(),
+			Amount:      summary.Unpaid,
+			DueDate:     dueDate,
+			DaysLeft:    daysLeft,
+		})
+	}
+
+	return reminders, nil
+}
+
+func summarize(account *models.Account, transactions []*models.Transaction, cycles int, now time.Time) *CreditCardSummary {
+	current := account.StatementCycleAt(now)
+	closed := make([]models.StatementCycle, 0, cycles)
+	for cycle := account.PreviousCycle(current); len(closed) < cycles; cycle = account.PreviousCycle(cycle) {
+		closed = append(closed, cycle)
+	}
+
+	summary := &CreditCardSummary{
+		Account:    account,
+		Current:    Statement{StatementCycle: current},
+		Statements: make([]Statement, len(closed)),
+	}
+	for i, cycle := range closed {
+		summary.Statements[i].StatementCycle = cycle
+	}
+
+	lastClosing := closed[0].End.AddDate(0, 0, 1)
+	var paidSinceClosing float64
+
+	for _, transaction := range transactions {
+		if transaction.TransactionDate.After(now) {
+			continue
+		}
+
+		owedChange := transaction.Value
+		if transaction.Type == models.TopUp {
+			owedChange = -transaction.Value
+		}
+		summary.Owed += owedChange
+
+		if !transaction.TransactionDate.Before(lastClosing) && transaction.Type == models.TopUp {
+			paidSinceClosing += transaction.Value
+		}
+
+		addToStatement(&summary.Current, transaction)
+		for i := range summary.Statements {
+			if transaction.TransactionDate.Before(summary.Statements[i].End.AddDate(0, 0, 1)) {
+				summary.Statements[i].Balance += owedChange
+			}
+			addToStatement(&summary.Statements[i], transaction)
+		}
+	}
+
+	summary.Current.Balance = summary.Owed
+	if account.CreditLimit > 0 {
+		summary.AvailableCredit = account.CreditLimit - summary.Owed
+	}
+
+	summary.Unpaid = max(summary.Statements[0].Balance-paidSinceClosing, 0)
+
+	return summary
+}
+
+func addToStatement(statement *Statement, transaction *models.Transaction) {
+	if !statement.Contains(transaction.TransactionDate) {
+		return
+	}
+
+	statement.Count++
+	if transaction.Type == models.Debit {
+		statement.Charges += transaction.Value
+	} else {
+		statement.Payments += transaction.Value
+	}
+}
@@ -0,0 +1,176 @@
+package credit_card_statements
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+func setup(t *testing.T) (*CreditCardStatementsService, *database.AccountInMemoryRepository, *models.Account) {
+	t.Helper()
+
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	service := NewCreditCardStatementsService(accountRepo, transactionRepo)
+
+	card := models.NewAccount(uuid.New(), "Visa", money.PLN)
+	card.Type = models.AccountCreditCard
+	card.CreditLimit = 1000
+	card.StatementDay = 15
+	card.DueDay = 5
+	accountRepo.Create(ctx, card)
+
+	for _, tx := range []struct {
+		value float64
+		kind  models.TransactionType
+		date  time.Time
+	}{
+		{100, models.Debit, date(2024, time.March, 20)},
+		{50, models.Debit, date(2024, time.April, 10)},
+		{30, models.TopUp, date(2024, time.April, 12)},
+		{40, models.Debit, date(2024, time.April, 20)},
+		{60, models.TopUp, date(2024, time.April, 25)},
+		{999, models.Debit, date(2024, time.May, 10)},
+	} {
+		transactionDate := tx.date
+		transactionRepo.Create(ctx, models.NewTransaction(models.TransactionData{
+			AccountID:       card.ID,
+			Value:           tx.value,
+			Name:            "Card",
+			Type:            tx.kind,
+			TransactionDate: &transactionDate,
+		}))
+	}
+
+	return service, accountRepo, card
+}
+
+func TestCreditCardStatementsService_GetStatements(t *testing.T) {
+	ctx := context.Background()
+	service, accountRepo, card := setup(t)
+
+	summary, err := service.GetStatements(ctx, card.ProjectID, card.ID, 2, date(2024, time.May, 1))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if summary.Owed != 100 {
+		t.Errorf("Expected 100 owed, got %v", summary.Owed)
+	}
+	if summary.AvailableCredit != 900 {
+		t.Errorf("Expected 900 available credit, got %v", summary.AvailableCredit)
+	}
+	if summary.Unpaid != 60 {
+		t.Errorf("Expected 60 left to pay, got %v", summary.Unpaid)
+	}
+
+	current := summary.Current
+	if !current.Start.Equal(time.Date(2024, time.April, 16, 0, 0, 0, 0, time.UTC)) || !current.End.Equal(time.Date(2024, time.May, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the open cycle to run April 16 - May 15, got %v - %v", current.Start, current.End)
+	}
+	if current.Charges != 40 || current.Payments != 60 || current.Count != 2 {
+		t.Errorf("Unexpected open cycle %+v", current)
+	}
+
+	if len(summary.Statements) != 2 {
+		t.Fatalf("Expected 2 statements, got %d", len(summary.Statements))
+	}
+
+	last := summary.Statements[0]
+	if last.Charges != 150 || last.Payments != 30 || last.Balance != 120 || last.Count != 3 {
+		t.Errorf("Unexpected last statement %+v", last)
+	}
+	if !last.DueDate.Equal(time.Date(2024, time.May, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the last statement to be due May 5, got %v", last.DueDate)
+	}
+
+	if previous := summary.Statements[1]; previous.Count != 0 || previous.Balance != 0 {
+		t.Errorf("Expected an empty statement before the first charge, got %+v", previous)
+	}
+
+	checking := models.NewAccount(card.ProjectID, "Main", money.PLN)
+	accountRepo.Create(ctx, checking)
+	if _, err := service.GetStatements(ctx, card.ProjectID, checking.ID, 0, time.Now()); err == nil {
+		t.Error("Expected error for an account that is not a credit card")
+	}
+
+	if _, err := service.GetStatements(ctx, uuid.New(), card.ID, 0, time.Now()); err == nil {
+		t.Error("Expected error for an account from another project")
+	}
+}
+
+func TestCreditCardStatementsService_GetPaymentReminders(t *testing.T) {
+	ctx := context.Background()
+	service, accountRepo, card := setup(t)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		archive  bool
+		expected int
+	}{
+		{name: "due date too far away", now: date(2024, time.April, 20), expected: -1},
+		{name: "due date close", now: date(2024, time.May, 1), expected: 4},
+		{name: "overdue", now: date(2024, time.May, 7), expected: -2},
+		{name: "archived cards are skipped", now: date(2024, time.May, 1), archive: true, expected: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.archive {
+				archivedAt := time.Now()
+				card.ArchivedAt = &archivedAt
+				accountRepo.Update(ctx, card)
+			}
+
+			reminders, err := service.GetPaymentReminders(ctx, card.ProjectID, tt.now)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if tt.expected == -1 {
+				if len(reminders) != 0 {
+					t.Errorf("Expected no reminders, got %+v", reminders)
+				}
+				return
+			}
+
+			if len(reminders) != 1 {
+				t.Fatalf("Expected 1 reminder, got %+v", reminders)
+			}
+			if reminders[0].DaysLeft != tt.expected || reminders[0].Amount != 60 {
+				t.Errorf("Unexpected reminder %+v", reminders[0])
+			}
+		})
+	}
+}
+
+func TestStatementCycleClampsShortMonths(t *testing.T) {
+	account := models.NewAccount(uuid.New(), "Visa", money.PLN)
+	account.Type = models.AccountCreditCard
+	account.StatementDay = 31
+	account.DueDay = 10
+
+	cycle := account.StatementCycleAt(date(2024, time.February, 10))
+	if !cycle.Start.Equal(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)) || !cycle.End.Equal(time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the cycle to run February 1 - 29, got %v - %v", cycle.Start, cycle.End)
+	}
+	if !cycle.DueDate.Equal(time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the cycle to be due March 10, got %v", cycle.DueDate)
+	}
+
+	next := account.StatementCycleAt(date(2024, time.March, 1))
+	if !next.Start.Equal(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)) || !next.End.Equal(time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the next cycle to run March 1 - 31, got %v - %v", next.Start, next.End)
+	}
+}
@@ -1,32 +1,162 @@
 package delete_transaction
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid"
+	"gofin/internal/cases/transaction_attachments"
+	"gofin/internal/cases/validate_period"
 	"gofin/internal/models"
+	"gofin/pkg/logging"
 )
 
 type DeleteTransactionService struct {
-	transactionRepo models.TransactionRepository
+	transactionRepo   models.TransactionRepository
+	splitRepo         models.TransactionSplitRepository
+	sharedExpenseRepo models.SharedExpenseRepository
+	settlementRepo    models.SettlementRepository
+	transferRepo      models.AccountTransferRepository
+	operationRepo     models.InvestmentOperationRepository
+	attachmentsSvc    *transaction_attachments.TransactionAttachmentsService
+	validatePeriodSvc *validate_period.ValidatePeriodService
 }
 
-func NewDeleteTransactionService(transactionRepo models.TransactionRepository) *DeleteTransactionService {
+func NewDeleteTransactionService(transactionRepo models.TransactionRepository, splitRepo models.TransactionSplitRepository, sharedExpenseRepo models.SharedExpenseRepository, settlementRepo models.SettlementRepository, transferRepo models.AccountTransferRepository, operationRepo models.InvestmentOperationRepository, attachmentsSvc *transaction_attachments.TransactionAttachmentsService, accountRepo models.AccountRepository, projectRepo models.ProjectRepository) *DeleteTransactionService {
 	return &DeleteTransactionService{
-		transactionRepo: transactionRepo,
+		transactionRepo:   transactionRepo,
+		splitRepo:         splitRepo,
+		sharedExpenseRepo: sharedExpenseRepo,
+		settlementRepo:    settlementRepo,
+		transferRepo:      transferRepo,
+		operationRepo:     operationRepo,
+		attachmentsSvc:    attachmentsSvc,
+		validatePeriodSvc: validate_period.NewValidatePeriodService(projectRepo, accountRepo),
 	}
 }
 
-func (s *DeleteTransactionService) DeleteTransaction(transactionID uuid.UUID) error {
-	_, err := s.transactionRepo.GetByID(transactionID)
+// DeleteTransaction deletes the transaction with its attachments, splits and
+// shares. A settlement or transfer leg takes its record and the other legs with it,
+// so member balances and reports never count half of a money movement.
+func (s *DeleteTransactionService) DeleteTransaction(ctx context.Context, transactionID uuid.UUID) error {
+	transaction, err := s.transactionRepo.GetByID(ctx, transactionID)
 	if err != nil {
 		return fmt.Errorf("transaction not found: %w", err)
 	}
 
-	err = s.transactionRepo.DeleteByID(transactionID)
-	if err != nil {
+	transactions := []*models.Transaction{transaction}
+	var settlements []*models.Settlement
+	var transfers []*models.AccountTransfer
+	if transaction.GroupID != nil {
+		settlements, err = s.settlementRepo.GetByGroupID(ctx, *transaction.GroupID)
+		if err != nil {
+			return fmt.Errorf("failed to get settlements: %w", err)
+		}
+		transfers, err = s.transferRepo.GetByGroupID(ctx, *transaction.GroupID)
+		if err != nil {
+			return fmt.Errorf("failed to get transfers: %w", err)
+		}
+		if len(settlements) > 0 || len(transfers) > 0 {
+			transactions, err = s.transactionRepo.GetByGroupID(ctx, *transaction.GroupID)
+			if err != nil {
+				return fmt.Errorf("failed to get grouped transactions: %w", err)
+			}
+		}
+	}
+
+	operations := make(map[uuid.UUID]*models.InvestmentOperation, len(transactions))
+	for _, transaction := range transactions {
+		if err := transaction.EnsureEditable(); err != nil {
+			return err
+		}
+
+		if err := s.validatePeriodSvc.ValidateTransactionOpen(ctx, transaction); err != nil {
+			return err
+		}
+
+		operation, err := s.findInvestmentOperation(ctx, transaction)
+		if err != nil {
+			return err
+		}
+		operations[transaction.ID] = operation
+	}
+
+	for _, settlement := range settlements {
+		if err := s.settlementRepo.DeleteByID(ctx, settlement.ID); err != nil {
+			return fmt.Errorf("failed to delete settlement: %w", err)
+		}
+	}
+
+	for _, transfer := range transfers {
+		if err := s.transferRepo.DeleteByID(ctx, transfer.ID); err != nil {
+			return fmt.Errorf("failed to delete transfer: %w", err)
+		}
+	}
+
+	for _, transaction := range transactions {
+		if err := s.deleteTransaction(ctx, transaction.ID, operations[transaction.ID]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *DeleteTransactionService) deleteTransaction(ctx context.Context, transactionID uuid.UUID, operation *models.InvestmentOperation) error {
+	if err := s.attachmentsSvc.DeleteTransactionAttachments(ctx, transactionID); err != nil {
+		return fmt.Errorf("failed to delete transaction attachments: %w", err)
+	}
+
+	if err := s.splitRepo.DeleteByTransactionID(ctx, transactionID); err != nil {
+		return fmt.Errorf("failed to delete transaction splits: %w", err)
+	}
+
+	if err := s.sharedExpenseRepo.DeleteByTransactionID(ctx, transactionID); err != nil {
+		return fmt.Errorf("failed to delete shared expense: %w", err)
+	}
+
+	if operation != nil {
+		if err := s.operationRepo.Delete(ctx, operation.ID); err != nil {
+			return fmt.Errorf("failed to delete investment operation: %w", err)
+		}
+	}
+
+	if err := s.transactionRepo.DeleteByID(ctx, transactionID); err != nil {
 		return fmt.Errorf("failed to delete transaction: %w", err)
 	}
 
+	logging.FromContext(ctx).Info("transaction deleted", slog.String("transaction_id", transactionID.String()))
+
 	return nil
 }
+
+// findInvestmentOperation returns the buy, sell or dividend that booked the
+// transaction, if any. Deleting a purchase is refused while later sales still
+// need the securities it bought.
+func (s *DeleteTransactionService) findInvestmentOperation(ctx context.Context, transaction *models.Transaction) (*models.InvestmentOperation, error) {
+	operations, err := s.operationRepo.GetByAccountID(ctx, transaction.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get investment operations: %w", err)
+	}
+
+	var found *models.InvestmentOperation
+	remaining := make([]*models.InvestmentOperation, 0, len(operations))
+	for _, operation := range operations {
+		if operation.TransactionID == transaction.ID {
+			found = operation
+			continue
+		}
+		remaining = append(remaining, operation)
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+
+	if _, err := models.BuildHoldings(remaining, models.CostFIFO); err != nil {
+		return nil, fmt.Errorf("the transaction records a purchase needed by later sales: %w", err)
+	}
+
+	return found, nil
+}
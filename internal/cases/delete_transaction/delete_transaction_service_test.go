@@ -1,23 +1,42 @@
 package delete_transaction
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"gofin/internal/cases/transaction_attachments"
 	"gofin/internal/infrastructure/database"
+	"gofin/internal/infrastructure/storage"
 	"gofin/internal/models"
 	"gofin/pkg/money"
 )
 
+func newTestService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, projectRepo models.ProjectRepository) *DeleteTransactionService {
+	attachmentsSvc := transaction_attachments.NewTransactionAttachmentsService(
+		database.NewAttachmentInMemoryRepository(),
+		transactionRepo,
+		accountRepo,
+		storage.NewInMemoryBlobStore(),
+		transaction_attachments.Limits{MaxSize: 1 << 20, ContentTypes: []string{"text/plain"}},
+	)
+
+	return NewDeleteTransactionService(transactionRepo, database.NewTransactionSplitInMemoryRepository(), database.NewSharedExpenseInMemoryRepository(), database.NewSettlementInMemoryRepository(), database.NewAccountTransferInMemoryRepository(), database.NewInvestmentOperationInMemoryRepository(), attachmentsSvc, accountRepo, projectRepo)
+}
+
 func TestDeleteTransactionService_DeleteTransaction(t *testing.T) {
 	transactionRepo := database.NewTransactionInMemoryRepository()
-	service := NewDeleteTransactionService(transactionRepo)
-
-	projectID := uuid.New()
-	account := models.NewAccount(projectID, "Test Account", money.PLN)
 	accountRepo := database.NewAccountInMemoryRepository()
-	accountRepo.Create(account)
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := newTestService(transactionRepo, accountRepo, projectRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(context.Background(), project)
+	account := models.NewAccount(project.ID, "Test Account", money.PLN)
+	accountRepo.Create(context.Background(), account)
 
 	transaction := models.NewTransaction(models.TransactionData{
 		AccountID: account.ID,
@@ -26,26 +45,86 @@ func TestDeleteTransactionService_DeleteTransaction(t *testing.T) {
 		Type:      models.Debit,
 	}, uuid.New())
 
-	transactionRepo.Create(transaction)
+	transactionRepo.Create(context.Background(), transaction)
 
-	err := service.DeleteTransaction(transaction.ID)
+	err := service.DeleteTransaction(context.Background(), transaction.ID)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	_, err = transactionRepo.GetByID(transaction.ID)
+	_, err = transactionRepo.GetByID(context.Background(), transaction.ID)
 	if err == nil {
 		t.Errorf("Expected transaction to be deleted, but it still exists")
 	}
 }
 
+func TestDeleteTransactionService_DeleteTransaction_Reconciled(t *testing.T) {
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	service := newTestService(transactionRepo, database.NewAccountInMemoryRepository(), database.NewProjectInMemoryRepository())
+
+	transaction := models.NewTransaction(models.TransactionData{
+		AccountID: uuid.New(),
+		Value:     100.0,
+		Name:      "Test Transaction",
+		Type:      models.Debit,
+	})
+	transaction.Status = models.StatusReconciled
+	transactionRepo.Create(context.Background(), transaction)
+
+	if err := service.DeleteTransaction(context.Background(), transaction.ID); !errors.Is(err, models.ErrTransactionReconciled) {
+		t.Fatalf("Expected ErrTransactionReconciled deleting a reconciled transaction, got %v", err)
+	}
+
+	if _, err := transactionRepo.GetByID(context.Background(), transaction.ID); err != nil {
+		t.Errorf("Expected the reconciled transaction to be kept, got %v", err)
+	}
+}
+
+func TestDeleteTransactionService_DeleteTransaction_ClosedPeriod(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := newTestService(transactionRepo, accountRepo, projectRepo)
+
+	lockedUntil := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	project := models.NewProject("Test Project", "test-project")
+	project.LockedUntil = &lockedUntil
+	projectRepo.Create(ctx, project)
+	account := models.NewAccount(project.ID, "Test Account", money.PLN)
+	accountRepo.Create(ctx, account)
+
+	closed := time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC)
+	open := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)
+	var transactions []*models.Transaction
+	for _, date := range []time.Time{closed, open} {
+		transaction := models.NewTransaction(models.TransactionData{
+			AccountID:       account.ID,
+			Value:           100.0,
+			Name:            "Test Transaction",
+			Type:            models.Debit,
+			TransactionDate: &date,
+		})
+		transactionRepo.Create(ctx, transaction)
+		transactions = append(transactions, transaction)
+	}
+
+	if err := service.DeleteTransaction(ctx, transactions[0].ID); !errors.Is(err, models.ErrPeriodClosed) {
+		t.Errorf("Expected ErrPeriodClosed deleting a transaction in a closed period, got %v", err)
+	}
+
+	if err := service.DeleteTransaction(ctx, transactions[1].ID); err != nil {
+		t.Errorf("Expected no error deleting a transaction after the closed period, got %v", err)
+	}
+}
+
 func TestDeleteTransactionService_DeleteTransaction_NotFound(t *testing.T) {
 	transactionRepo := database.NewTransactionInMemoryRepository()
-	service := NewDeleteTransactionService(transactionRepo)
+	service := newTestService(transactionRepo, database.NewAccountInMemoryRepository(), database.NewProjectInMemoryRepository())
 
 	nonExistentID := uuid.New()
 
-	err := service.DeleteTransaction(nonExistentID)
+	err := service.DeleteTransaction(context.Background(), nonExistentID)
 	if err == nil {
 		t.Fatalf("Expected error for non-existent transaction, got nil")
 	}
@@ -58,12 +137,14 @@ func TestDeleteTransactionService_DeleteTransaction_NotFound(t *testing.T) {
 
 func TestDeleteTransactionService_DeleteTransaction_RepositoryError(t *testing.T) {
 	transactionRepo := database.NewTransactionInMemoryRepository()
-	service := NewDeleteTransactionService(transactionRepo)
-
-	projectID := uuid.New()
-	account := models.NewAccount(projectID, "Test Account", money.PLN)
 	accountRepo := database.NewAccountInMemoryRepository()
-	accountRepo.Create(account)
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := newTestService(transactionRepo, accountRepo, projectRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(context.Background(), project)
+	account := models.NewAccount(project.ID, "Test Account", money.PLN)
+	accountRepo.Create(context.Background(), account)
 
 	transaction := models.NewTransaction(models.TransactionData{
 		AccountID: account.ID,
@@ -72,14 +153,14 @@ func TestDeleteTransactionService_DeleteTransaction_RepositoryError(t *testing.T
 		Type:      models.Debit,
 	}, uuid.New())
 
-	transactionRepo.Create(transaction)
+	transactionRepo.Create(context.Background(), transaction)
 
-	err := service.DeleteTransaction(transaction.ID)
+	err := service.DeleteTransaction(context.Background(), transaction.ID)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	err = service.DeleteTransaction(transaction.ID)
+	err = service.DeleteTransaction(context.Background(), transaction.ID)
 	if err == nil {
 		t.Fatalf("Expected error when deleting already deleted transaction, got nil")
 	}
@@ -89,3 +170,213 @@ func TestDeleteTransactionService_DeleteTransaction_RepositoryError(t *testing.T
 		t.Errorf("Expected error to contain '%s', got '%s'", expectedError, err.Error())
 	}
 }
+
+func TestDeleteTransactionService_DeleteTransaction_RemovesAttachments(t *testing.T) {
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	attachmentRepo := database.NewAttachmentInMemoryRepository()
+	blobs := storage.NewInMemoryBlobStore()
+	attachmentsSvc := transaction_attachments.NewTransactionAttachmentsService(
+		attachmentRepo,
+		transactionRepo,
+		accountRepo,
+		blobs,
+		transaction_attachments.Limits{MaxSize: 1 << 20, ContentTypes: []string{"text/plain"}},
+	)
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewDeleteTransactionService(transactionRepo, database.NewTransactionSplitInMemoryRepository(), database.NewSharedExpenseInMemoryRepository(), database.NewSettlementInMemoryRepository(), database.NewAccountTransferInMemoryRepository(), database.NewInvestmentOperationInMemoryRepository(), attachmentsSvc, accountRepo, projectRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(context.Background(), project)
+	projectID := project.ID
+	account := models.NewAccount(projectID, "Test Account", money.PLN)
+	accountRepo.Create(context.Background(), account)
+
+	transaction := models.NewTransaction(models.TransactionData{
+		AccountID: account.ID,
+		Value:     100.0,
+		Name:      "Test Transaction",
+		Type:      models.Debit,
+	}, uuid.New())
+	transactionRepo.Create(context.Background(), transaction)
+
+	attachment, err := attachmentsSvc.Upload(context.Background(), projectID, transaction.ID, "receipt.txt", strings.NewReader("receipt"))
+	if err != nil {
+		t.Fatalf("Expected no error uploading attachment, got %v", err)
+	}
+
+	if err := service.DeleteTransaction(context.Background(), transaction.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := attachmentRepo.GetByID(context.Background(), attachment.ID); err == nil {
+		t.Error("Expected attachment to be deleted with its transaction")
+	}
+
+	if exists, _ := blobs.Exists(context.Background(), attachment.BlobKey()); exists {
+		t.Error("Expected unreferenced attachment content to be deleted")
+	}
+}
+
+func TestDeleteTransactionService_DeleteTransaction_RemovesInvestmentOperation(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	operationRepo := database.NewInvestmentOperationInMemoryRepository()
+	attachmentsSvc := transaction_attachments.NewTransactionAttachmentsService(
+		database.NewAttachmentInMemoryRepository(),
+		transactionRepo,
+		accountRepo,
+		storage.NewInMemoryBlobStore(),
+		transaction_attachments.Limits{MaxSize: 1 << 20, ContentTypes: []string{"text/plain"}},
+	)
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewDeleteTransactionService(transactionRepo, database.NewTransactionSplitInMemoryRepository(), database.NewSharedExpenseInMemoryRepository(), database.NewSettlementInMemoryRepository(), database.NewAccountTransferInMemoryRepository(), operationRepo, attachmentsSvc, accountRepo, projectRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(ctx, project)
+	account := models.NewAccount(project.ID, "Broker", money.PLN)
+	account.Type = models.AccountInvestment
+	accountRepo.Create(ctx, account)
+
+	securityID := uuid.New()
+	bought := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	sold := bought.AddDate(0, 3, 0)
+	var transactions []*models.Transaction
+	for _, operation := range []*models.InvestmentOperation{
+		models.NewInvestmentOperation(account.ID, securityID, models.OperationBuy, 10, 100, 0, 0, bought),
+		models.NewInvestmentOperation(account.ID, securityID, models.OperationSell, 10, 120, 0, 0, sold),
+	} {
+		transaction := models.NewTransaction(models.TransactionData{
+			AccountID:       account.ID,
+			Value:           operation.Amount,
+			Name:            operation.Type.String(),
+			Type:            models.Debit,
+			TransactionDate: &operation.Date,
+		})
+		transactionRepo.Create(ctx, transaction)
+		operation.TransactionID = transaction.ID
+		operationRepo.Create(ctx, operation)
+		transactions = append(transactions, transaction)
+	}
+
+	if err := service.DeleteTransaction(ctx, transactions[0].ID); err == nil {
+		t.Error("Expected an error deleting a purchase needed by a later sale")
+	}
+
+	if err := service.DeleteTransaction(ctx, transactions[1].ID); err != nil {
+		t.Fatalf("Expected no error deleting the sale, got %v", err)
+	}
+
+	if err := service.DeleteTransaction(ctx, transactions[0].ID); err != nil {
+		t.Fatalf("Expected no error deleting the purchase, got %v", err)
+	}
+
+	if operations, _ := operationRepo.GetByAccountID(ctx, account.ID); len(operations) != 0 {
+		t.Errorf("Expected the operations to be deleted with their transactions, got %d", len(operations))
+	}
+}
+
+func TestDeleteTransactionService_DeleteTransaction_Settlement(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	settlementRepo := database.NewSettlementInMemoryRepository()
+	attachmentsSvc := transaction_attachments.NewTransactionAttachmentsService(
+		database.NewAttachmentInMemoryRepository(),
+		transactionRepo,
+		accountRepo,
+		storage.NewInMemoryBlobStore(),
+		transaction_attachments.Limits{MaxSize: 1 << 20, ContentTypes: []string{"text/plain"}},
+	)
+	service := NewDeleteTransactionService(transactionRepo, database.NewTransactionSplitInMemoryRepository(), database.NewSharedExpenseInMemoryRepository(), settlementRepo, database.NewAccountTransferInMemoryRepository(), database.NewInvestmentOperationInMemoryRepository(), attachmentsSvc, accountRepo, projectRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(ctx, project)
+	from := models.NewAccount(project.ID, "Bartek PLN", money.PLN)
+	to := models.NewAccount(project.ID, "Anna PLN", money.PLN)
+	accountRepo.Create(ctx, from)
+	accountRepo.Create(ctx, to)
+
+	// recordSettlement stores a settlement paid from one account into the other,
+	// the top-up leg optionally reconciled.
+	recordSettlement := func(reconciled bool) (*models.Settlement, *models.Transaction, *models.Transaction) {
+		groupID := uuid.New()
+		debit := models.NewTransaction(models.TransactionData{AccountID: from.ID, Value: 15, Name: "Settlement", Type: models.Debit}, groupID)
+		topUp := models.NewTransaction(models.TransactionData{AccountID: to.ID, Value: 15, Name: "Settlement", Type: models.TopUp}, groupID)
+		if reconciled {
+			topUp.Status = models.StatusReconciled
+		}
+		transactionRepo.Create(ctx, debit)
+		transactionRepo.Create(ctx, topUp)
+
+		settlement := models.NewSettlement(project.ID, uuid.New(), uuid.New(), 15, money.PLN, &groupID)
+		settlementRepo.Create(ctx, settlement)
+		return settlement, debit, topUp
+	}
+
+	settlement, debit, topUp := recordSettlement(false)
+	if err := service.DeleteTransaction(ctx, debit.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if settlements, _ := settlementRepo.GetByGroupID(ctx, *settlement.GroupID); len(settlements) != 0 {
+		t.Error("Expected the settlement to be deleted with its transaction")
+	}
+	if _, err := transactionRepo.GetByID(ctx, topUp.ID); err == nil {
+		t.Error("Expected the other leg of the settlement to be deleted too")
+	}
+
+	settlement, debit, topUp = recordSettlement(true)
+	if err := service.DeleteTransaction(ctx, debit.ID); err == nil {
+		t.Fatal("Expected an error deleting a settlement with a reconciled leg")
+	}
+	if settlements, _ := settlementRepo.GetByGroupID(ctx, *settlement.GroupID); len(settlements) != 1 {
+		t.Error("Expected the settlement to be kept")
+	}
+	for _, transaction := range []*models.Transaction{debit, topUp} {
+		if _, err := transactionRepo.GetByID(ctx, transaction.ID); err != nil {
+			t.Errorf("Expected transaction %s to be kept, got %v", transaction.Name, err)
+		}
+	}
+}
+
+func TestDeleteTransactionService_DeleteTransaction_Transfer(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	transferRepo := database.NewAccountTransferInMemoryRepository()
+	attachmentsSvc := transaction_attachments.NewTransactionAttachmentsService(
+		database.NewAttachmentInMemoryRepository(),
+		transactionRepo,
+		accountRepo,
+		storage.NewInMemoryBlobStore(),
+		transaction_attachments.Limits{MaxSize: 1 << 20, ContentTypes: []string{"text/plain"}},
+	)
+	service := NewDeleteTransactionService(transactionRepo, database.NewTransactionSplitInMemoryRepository(), database.NewSharedExpenseInMemoryRepository(), database.NewSettlementInMemoryRepository(), transferRepo, database.NewInvestmentOperationInMemoryRepository(), attachmentsSvc, accountRepo, projectRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(ctx, project)
+	from := models.NewAccount(project.ID, "Checking", money.PLN)
+	to := models.NewAccount(project.ID, "Savings", money.PLN)
+	accountRepo.Create(ctx, from)
+	accountRepo.Create(ctx, to)
+
+	groupID := uuid.New()
+	debit := models.NewTransaction(models.TransactionData{AccountID: from.ID, Value: 250, Name: "Transfer", Type: models.Debit}, groupID)
+	topUp := models.NewTransaction(models.TransactionData{AccountID: to.ID, Value: 250, Name: "Transfer", Type: models.TopUp}, groupID)
+	transactionRepo.Create(ctx, debit)
+	transactionRepo.Create(ctx, topUp)
+	transferRepo.Create(ctx, models.NewAccountTransfer(project.ID, debit, topUp))
+
+	if err := service.DeleteTransaction(ctx, topUp.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if transfers, _ := transferRepo.GetByGroupID(ctx, groupID); len(transfers) != 0 {
+		t.Error("Expected the transfer to be deleted with its transaction")
+	}
+	if _, err := transactionRepo.GetByID(ctx, debit.ID); err == nil {
+		t.Error("Expected the other leg of the transfer to be deleted too")
+	}
+}
@@ -0,0 +1,185 @@
+package enroll_two_factor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/verify_two_factor"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	"gofin/pkg/password"
+	"gofin/pkg/totp"
+)
+
+const (
+	issuer            = "Gofin"
+	recoveryCodeCount = 10
+)
+
+type EnrollTwoFactorService struct {
+	accessRepo       models.AccessRepository
+	projectRepo      models.ProjectRepository
+	recoveryCodeRepo models.RecoveryCodeRepository
+	verifySvc        *verify_two_factor.VerifyTwoFactorService
+}
+
+func NewEnrollTwoFactorService(accessRepo models.AccessRepository, projectRepo models.ProjectRepository, recoveryCodeRepo models.RecoveryCodeRepository) *EnrollTwoFactorService {
+	return &EnrollTwoFactorService{
+		accessRepo:       accessRepo,
+		projectRepo:      projectRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		verifySvc:        verify_two_factor.NewVerifyTwoFactorService(accessRepo, recoveryCodeRepo),
+	}
+}
+
+type EnrollmentData struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// StartEnrollment returns the pending secret for the access, generating one if
+// needed. The secret only becomes active once ConfirmEnrollment verifies a code
+// generated from it.
+func (s *EnrollTwoFactorService) StartEnrollment(ctx context.Context, accessID uuid.UUID) (*EnrollmentData, error) {
+	access, err := s.accessRepo.GetByID(ctx, accessID)
+	if err != nil {
+		return nil, fmt.Errorf("access not found: %w", err)
+	}
+
+	if access.TOTPEnabled {
+		return nil, fmt.Errorf("two-factor authentication is already enabled")
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, access.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	if access.TOTPSecret == "" {
+		secret, err := totp.GenerateSecret()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate secret: %w", err)
+		}
+
+		access.TOTPSecret = secret
+		access.UpdatedAt = time.Now()
+
+		if err := s.accessRepo.Update(ctx, access); err != nil {
+			return nil, fmt.Errorf("failed to store pending secret: %w", err)
+		}
+	}
+
+	return &EnrollmentData{
+		Secret:          access.TOTPSecret,
+		ProvisioningURI: totp.ProvisioningURI(access.TOTPSecret, issuer, fmt.Sprintf("%s (%s/%s)", access.Name, project.Slug, access.UID)),
+	}, nil
+}
+
+func (s *EnrollTwoFactorService) ConfirmEnrollment(ctx context.Context, accessID uuid.UUID, code string) ([]string, error) {
+	access, err := s.accessRepo.GetByID(ctx, accessID)
+	if err != nil {
+		return nil, fmt.Errorf("access not found: %w", err)
+	}
+
+	if access.TOTPEnabled {
+		return nil, fmt.Errorf("two-factor authentication is already enabled")
+	}
+
+	if access.TOTPSecret == "" {
+		return nil, fmt.Errorf("two-factor enrollment has not been started")
+	}
+
+	counter, ok := totp.Match(access.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("invalid authentication code")
+	}
+
+	recoveryCodes, err := s.regenerateRecoveryCodes(ctx, access.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	access.TOTPEnabled = true
+	access.TOTPLastCounter = counter
+	access.TwoFactorFailures = 0
+	access.UpdatedAt = time.Now()
+
+	if err := s.accessRepo.Update(ctx, access); err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("two-factor authentication enabled", slog.String("access_id", access.ID.String()))
+
+	return recoveryCodes, nil
+}
+
+// DisableTwoFactor checks the code the way the login does, with the same
+// lockout and replay protection, before removing the secret.
+func (s *EnrollTwoFactorService) DisableTwoFactor(ctx context.Context, accessID uuid.UUID, code string) error {
+	access, err := s.accessRepo.GetByID(ctx, accessID)
+	if err != nil {
+		return fmt.Errorf("access not found: %w", err)
+	}
+
+	if !access.TOTPEnabled {
+		return fmt.Errorf("two-factor authentication is not enabled")
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, access.ProjectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	if access.RequiresTwoFactor(project) {
+		return fmt.Errorf("project policy requires two-factor authentication for read-write access")
+	}
+
+	access, err = s.verifySvc.Verify(ctx, access.ID, code)
+	if err != nil {
+		return err
+	}
+
+	if err := s.recoveryCodeRepo.DeleteByAccessID(ctx, access.ID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	access.TOTPSecret = ""
+	access.TOTPEnabled = false
+	access.TOTPLastCounter = 0
+	access.TwoFactorFailures = 0
+	access.TwoFactorFailedAt = nil
+	access.UpdatedAt = time.Now()
+
+	if err := s.accessRepo.Update(ctx, access); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("two-factor authentication disabled", slog.String("access_id", access.ID.String()))
+
+	return nil
+}
+
+func (s *EnrollTwoFactorService) regenerateRecoveryCodes(ctx context.Context, accessID uuid.UUID) ([]string, error) {
+	plainCodes, err := totp.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+
+	var codes []*models.RecoveryCode
+	for _, plainCode := range plainCodes {
+		hash, err := password.Hash(plainCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash recovery code: %w", err)
+		}
+		codes = append(codes, models.NewRecoveryCode(accessID, hash))
+	}
+
+	if err := s.recoveryCodeRepo.ReplaceForAccess(ctx, accessID, codes); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	return plainCodes, nil
+}
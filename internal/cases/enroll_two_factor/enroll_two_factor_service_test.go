@@ -0,0 +1,173 @@
+package enroll_two_factor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"gofin/internal/cases/verify_two_factor"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/password"
+	"gofin/pkg/totp"
+)
+
+func TestMain(m *testing.M) {
+	password.DefaultParams = &password.Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+	os.Exit(m.Run())
+}
+
+func setupService(t *testing.T, requireTwoFactor, readonly bool) (*EnrollTwoFactorService, *models.Access, models.RecoveryCodeRepository) {
+	projectRepo := database.NewProjectInMemoryRepository()
+	accessRepo := database.NewAccessInMemoryRepository()
+	recoveryCodeRepo := database.NewRecoveryCodeInMemoryRepository()
+
+	project := models.NewProject("Test Project", "test-project")
+	project.RequireTwoFactor = requireTwoFactor
+	if err := projectRepo.Create(context.Background(), project); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	access := models.NewAccess(project.ID, "12", "hash", "Test Access", readonly)
+	if err := accessRepo.Create(context.Background(), access); err != nil {
+		t.Fatalf("Failed to create access: %v", err)
+	}
+
+	return NewEnrollTwoFactorService(accessRepo, projectRepo, recoveryCodeRepo), access, recoveryCodeRepo
+}
+
+func TestEnrollTwoFactorService_Enrollment(t *testing.T) {
+	service, access, recoveryCodeRepo := setupService(t, false, false)
+
+	enrollment, err := service.StartEnrollment(context.Background(), access.ID)
+	if err != nil {
+		t.Fatalf("StartEnrollment() unexpected error: %v", err)
+	}
+
+	if enrollment.Secret == "" || enrollment.ProvisioningURI == "" {
+		t.Fatalf("StartEnrollment() returned empty enrollment data")
+	}
+
+	if access.TOTPEnabled {
+		t.Errorf("StartEnrollment() should not enable two-factor authentication")
+	}
+
+	if _, err := service.ConfirmEnrollment(context.Background(), access.ID, "abcdef"); err == nil {
+		t.Errorf("ConfirmEnrollment() expected error for invalid code, got nil")
+	}
+
+	code, err := totp.GenerateCode(enrollment.Secret, time.Now())
+	if err != nil {
+		t.Fatalf("GenerateCode() unexpected error: %v", err)
+	}
+
+	recoveryCodes, err := service.ConfirmEnrollment(context.Background(), access.ID, code)
+	if err != nil {
+		t.Fatalf("ConfirmEnrollment() unexpected error: %v", err)
+	}
+
+	if len(recoveryCodes) != recoveryCodeCount {
+		t.Errorf("ConfirmEnrollment() recovery codes = %d, want %d", len(recoveryCodes), recoveryCodeCount)
+	}
+
+	if !access.TOTPEnabled {
+		t.Errorf("ConfirmEnrollment() should enable two-factor authentication")
+	}
+
+	stored, _ := recoveryCodeRepo.GetUnusedByAccessID(context.Background(), access.ID)
+	if len(stored) != recoveryCodeCount {
+		t.Errorf("ConfirmEnrollment() stored recovery codes = %d, want %d", len(stored), recoveryCodeCount)
+	}
+
+	if _, err := service.StartEnrollment(context.Background(), access.ID); err == nil {
+		t.Errorf("StartEnrollment() expected error when already enabled, got nil")
+	}
+}
+
+func TestEnrollTwoFactorService_ConfirmWithoutStart(t *testing.T) {
+	service, access, _ := setupService(t, false, false)
+
+	if _, err := service.ConfirmEnrollment(context.Background(), access.ID, "123456"); err == nil {
+		t.Errorf("ConfirmEnrollment() expected error when enrollment not started, got nil")
+	}
+}
+
+func TestEnrollTwoFactorService_DisableTwoFactor(t *testing.T) {
+	tests := []struct {
+		name             string
+		requireTwoFactor bool
+		readonly         bool
+		wantErr          bool
+	}{
+		{name: "success when policy does not require", requireTwoFactor: false, readonly: false, wantErr: false},
+		{name: "success for read-only access under policy", requireTwoFactor: true, readonly: true, wantErr: false},
+		{name: "error for read-write access under policy", requireTwoFactor: true, readonly: false, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, access, _ := setupService(t, tt.requireTwoFactor, tt.readonly)
+
+			enrollment, err := service.StartEnrollment(context.Background(), access.ID)
+			if err != nil {
+				t.Fatalf("StartEnrollment() unexpected error: %v", err)
+			}
+
+			code, _ := totp.GenerateCode(enrollment.Secret, time.Now())
+			if _, err := service.ConfirmEnrollment(context.Background(), access.ID, code); err != nil {
+				t.Fatalf("ConfirmEnrollment() unexpected error: %v", err)
+			}
+
+			next, _ := totp.GenerateCode(enrollment.Secret, time.Now().Add(totp.Period*time.Second))
+			err = service.DisableTwoFactor(context.Background(), access.ID, next)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("DisableTwoFactor() expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("DisableTwoFactor() unexpected error: %v", err)
+			}
+
+			if access.TOTPEnabled || access.TOTPSecret != "" {
+				t.Errorf("DisableTwoFactor() should clear two-factor settings")
+			}
+		})
+	}
+}
+
+func TestEnrollTwoFactorService_DisableTwoFactor_ProtectsCode(t *testing.T) {
+	ctx := context.Background()
+	service, access, _ := setupService(t, false, false)
+
+	enrollment, err := service.StartEnrollment(ctx, access.ID)
+	if err != nil {
+		t.Fatalf("StartEnrollment() unexpected error: %v", err)
+	}
+
+	code, _ := totp.GenerateCode(enrollment.Secret, time.Now())
+	if _, err := service.ConfirmEnrollment(ctx, access.ID, code); err != nil {
+		t.Fatalf("ConfirmEnrollment() unexpected error: %v", err)
+	}
+
+	if err := service.DisableTwoFactor(ctx, access.ID, code); err == nil {
+		t.Fatal("DisableTwoFactor() expected error for the code already used to enable")
+	}
+
+	for i := 1; i < verify_two_factor.MaxFailures; i++ {
+		service.DisableTwoFactor(ctx, access.ID, "000000")
+	}
+
+	next, _ := totp.GenerateCode(enrollment.Secret, time.Now().Add(totp.Period*time.Second))
+	if err := service.DisableTwoFactor(ctx, access.ID, next); !errors.Is(err, verify_two_factor.ErrTooManyAttempts) {
+		t.Errorf("DisableTwoFactor() error = %v, want ErrTooManyAttempts", err)
+	}
+	if !access.TOTPEnabled {
+		t.Error("Expected two-factor authentication to stay enabled")
+	}
+}
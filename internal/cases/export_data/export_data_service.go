@@ -0,0 +1,299 @@
+package export_data
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/get_project_balance"
+	"gofin/internal/cases/get_reports"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	"gofin/pkg/export"
+)
+
+type ExportDataService struct {
+	transactionRepo models.TransactionRepository
+	accountRepo     models.AccountRepository
+	categoryRepo    models.CategoryRepository
+	splitRepo       models.TransactionSplitRepository
+	payeeRepo       models.PayeeRepository
+	balanceSvc      *get_project_balance.GetProjectBalanceService
+	reportsSvc      *get_reports.GetReportsService
+}
+
+func NewExportDataService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, categoryRepo models.CategoryRepository, splitRepo models.TransactionSplitRepository, payeeRepo models.PayeeRepository, settlementRepo models.SettlementRepository, transferRepo models.AccountTransferRepository) *ExportDataService {
+	return &ExportDataService{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		categoryRepo:    categoryRepo,
+		splitRepo:       splitRepo,
+		payeeRepo:       payeeRepo,
+		balanceSvc:      get_project_balance.NewGetProjectBalanceService(accountRepo),
+		reportsSvc:      get_reports.NewGetReportsService(transactionRepo, accountRepo, categoryRepo, splitRepo, payeeRepo, settlementRepo, transferRepo),
+	}
+}
+
+// ExportTransactions writes every transaction of the project matching query,
+// without paging, in the order the query sorts them. Amounts are signed, so
+// debits are negative and a column sums to the change in balance.
+func (s *ExportDataService) ExportTransactions(ctx context.Context, w io.Writer, format export.Format, projectID uuid.UUID, query models.TransactionQuery) error {
+	query.ProjectID = &projectID
+	query.AccountID = nil
+	query.Limit = 0
+	query.Cursor = ""
+
+	if err := query.Validate(); err != nil {
+		return fmt.Errorf("invalid export filters: %w", err)
+	}
+
+	accounts, err := s.accountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	accountsByID := make(map[uuid.UUID]*models.Account, len(accounts))
+	for _, account := range accounts {
+		accountsByID[account.ID] = account
+	}
+
+	for _, accountID := range query.AccountIDs {
+		if accountsByID[accountID] == nil {
+			return fmt.Errorf("account %s does not belong to project", accountID)
+		}
+	}
+
+	transactions, err := s.transactionRepo.GetTransactionsWithFilters(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	categoryNames, err := s.categoryNames(ctx, projectID, transactions)
+	if err != nil {
+		return err
+	}
+
+	payees, err := s.payeeRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project payees: %w", err)
+	}
+
+	payeeNames := make(map[uuid.UUID]string, len(payees))
+	for _, payee := range payees {
+		payeeNames[payee.ID] = payee.Name
+	}
+
+	table := export.Table{
+		Title: "Transactions",
+		Columns: []export.Column{
+			{Title: "Date"},
+			{Title: "Account"},
+			{Title: "Name"},
+			{Title: "Payee"},
+			{Title: "Category"},
+			{Title: "Type"},
+			{Title: "Amount", Numeric: true},
+			{Title: "Currency"},
+			{Title: "Status"},
+			{Title: "Notes"},
+		},
+	}
+
+	for _, transaction := range transactions {
+		var accountName, currency, payee string
+		if account := accountsByID[transaction.AccountID]; account != nil {
+			accountName = account.Name
+			currency = account.Currency.String()
+		}
+		if transaction.PayeeID != nil {
+			payee = payeeNames[*transaction.PayeeID]
+		}
+
+		table.Rows = append(table.Rows, []string{
+			transaction.TransactionDate.Format(config.DateFormat),
+			accountName,
+			transaction.Name,
+			payee,
+			categoryNames[transaction.ID],
+			transaction.Type.String(),
+			formatAmount(transaction.SignedValue()),
+			currency,
+			transaction.Status.String(),
+			transaction.Notes,
+		})
+	}
+
+	title := "Transactions"
+	if query.StartDate != nil {
+		title += " from " + query.StartDate.Format(config.DateFormat)
+	}
+	if query.EndDate != nil {
+		title += " to " + query.EndDate.Format(config.DateFormat)
+	}
+
+	return export.Write(w, format, export.Document{Title: title, Tables: []export.Table{table}})
+}
+
+// ExportBalances writes the balance of every account at the end of asOf,
+// followed by the total per currency.
+func (s *ExportDataService) ExportBalances(ctx context.Context, w io.Writer, format export.Format, projectID uuid.UUID, asOf time.Time) error {
+	end := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, asOf.Location()).AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	transactions, err := s.transactionRepo.GetByProjectIDWithDateRange(ctx, projectID, nil, &end)
+	if err != nil {
+		return fmt.Errorf("failed to get project transactions: %w", err)
+	}
+
+	balances, err := s.balanceSvc.GetProjectBalancesFromTransactions(ctx, projectID, transactions)
+	if err != nil {
+		return err
+	}
+
+	accounts := export.Table{
+		Title:   "Accounts",
+		Columns: []export.Column{{Title: "Account"}, {Title: "Currency"}, {Title: "Balance", Numeric: true}},
+	}
+	for _, balance := range balances.AccountBalances {
+		accounts.Rows = append(accounts.Rows, []string{balance.Name, balance.Currency, formatAmount(balance.Balance)})
+	}
+
+	totals := export.Table{
+		Title:   "Totals",
+		Columns: []export.Column{{Title: "Currency"}, {Title: "Balance", Numeric: true}},
+	}
+	currencyTotals := balances.CurrencyTotals
+	sort.Slice(currencyTotals, func(i, j int) bool {
+		return currencyTotals[i].Currency < currencyTotals[j].Currency
+	})
+	for _, total := range currencyTotals {
+		totals.Rows = append(totals.Rows, []string{total.Currency, formatAmount(total.Balance)})
+	}
+
+	title := "Balances as of " + asOf.Format(config.DateFormat)
+	return export.Write(w, format, export.Document{Title: title, Tables: []export.Table{accounts, totals}})
+}
+
+// ExportReport writes the reports of the reports page, a table each.
+func (s *ExportDataService) ExportReport(ctx context.Context, w io.Writer, format export.Format, query get_reports.ReportQuery) error {
+	report, err := s.reportsSvc.GetReport(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	incomeExpense := export.Table{
+		Title:   "Income vs Expenses",
+		Columns: []export.Column{{Title: "Month"}, {Title: "Income", Numeric: true}, {Title: "Expenses", Numeric: true}, {Title: "Net", Numeric: true}},
+	}
+	for _, month := range report.IncomeExpense {
+		incomeExpense.Rows = append(incomeExpense.Rows, []string{month.Month, formatAmount(month.Income), formatAmount(month.Expense), formatAmount(month.Net)})
+	}
+	incomeExpense.Rows = append(incomeExpense.Rows, []string{"Total", formatAmount(report.Income), formatAmount(report.Expense), formatAmount(report.Net())})
+
+	categories := export.Table{
+		Title:   "Categories",
+		Columns: []export.Column{{Title: "Category"}, {Title: "Spent", Numeric: true}, {Title: "Received", Numeric: true}, {Title: "Share %", Numeric: true}},
+	}
+	for _, category := range report.Categories {
+		categories.Rows = append(categories.Rows, []string{category.Name, formatAmount(category.Spent), formatAmount(category.Received), formatAmount(category.Share)})
+	}
+
+	payees := export.Table{
+		Title:   "Top Payees",
+		Columns: []export.Column{{Title: "Payee"}, {Title: "Transactions", Numeric: true}, {Title: "Spent", Numeric: true}, {Title: "Received", Numeric: true}},
+	}
+	for _, payee := range report.TopPayees {
+		payees.Rows = append(payees.Rows, []string{payee.Name, strconv.Itoa(payee.Count), formatAmount(payee.Spent), formatAmount(payee.Received)})
+	}
+
+	yearOverYear := export.Table{
+		Title: "Year over Year",
+		Columns: []export.Column{
+			{Title: "Month"}, {Title: "Income", Numeric: true}, {Title: "Income a year earlier", Numeric: true},
+			{Title: "Expenses", Numeric: true}, {Title: "Expenses a year earlier", Numeric: true}, {Title: "Change %", Numeric: true},
+		},
+	}
+	for _, month := range report.YearOverYear.Months {
+		yearOverYear.Rows = append(yearOverYear.Rows, []string{
+			month.Month, formatAmount(month.Income), formatAmount(month.PreviousIncome),
+			formatAmount(month.Expense), formatAmount(month.PreviousExpense), formatChange(month.Change),
+		})
+	}
+	yearOverYear.Rows = append(yearOverYear.Rows, []string{
+		"Total", formatAmount(report.YearOverYear.Income), formatAmount(report.YearOverYear.PreviousIncome),
+		formatAmount(report.YearOverYear.Expense), formatAmount(report.YearOverYear.PreviousExpense), formatChange(report.YearOverYear.Change),
+	})
+
+	cashFlow := export.Table{
+		Title:   "Cash Flow",
+		Columns: []export.Column{{Title: "Month"}, {Title: "Opening", Numeric: true}, {Title: "Inflows", Numeric: true}, {Title: "Outflows", Numeric: true}, {Title: "Closing", Numeric: true}},
+	}
+	for _, month := range report.CashFlow.Months {
+		cashFlow.Rows = append(cashFlow.Rows, []string{month.Month, formatAmount(month.Opening), formatAmount(month.Inflows), formatAmount(month.Outflows), formatAmount(month.Closing)})
+	}
+	cashFlow.Rows = append(cashFlow.Rows, []string{
+		"Total", formatAmount(report.CashFlow.Opening), formatAmount(report.CashFlow.Inflows), formatAmount(report.CashFlow.Outflows), formatAmount(report.CashFlow.Closing),
+	})
+
+	title := fmt.Sprintf("Report %s to %s in %s", report.From.Format(config.DateFormat), report.To.Format(config.DateFormat), report.Currency)
+	return export.Write(w, format, export.Document{
+		Title:  title,
+		Tables: []export.Table{incomeExpense, categories, payees, yearOverYear, cashFlow},
+	})
+}
+
+// categoryNames names the category of every transaction, listing the
+// categories of the lines of a split transaction.
+func (s *ExportDataService) categoryNames(ctx context.Context, projectID uuid.UUID, transactions []*models.Transaction) (map[uuid.UUID]string, error) {
+	categories, err := s.categoryRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project categories: %w", err)
+	}
+
+	names := make(map[uuid.UUID]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+
+	transactionIDs := make([]uuid.UUID, 0, len(transactions))
+	for _, transaction := range transactions {
+		transactionIDs = append(transactionIDs, transaction.ID)
+	}
+
+	splits, err := s.splitRepo.GetByTransactionIDs(ctx, transactionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction splits: %w", err)
+	}
+
+	splitNames := make(map[uuid.UUID][]string)
+	for _, split := range splits {
+		splitNames[split.TransactionID] = append(splitNames[split.TransactionID], names[split.CategoryID])
+	}
+
+	result := make(map[uuid.UUID]string, len(transactions))
+	for _, transaction := range transactions {
+		switch {
+		case len(splitNames[transaction.ID]) > 0:
+			result[transaction.ID] = strings.Join(splitNames[transaction.ID], ", ")
+		case transaction.CategoryID != nil:
+			result[transaction.ID] = names[*transaction.CategoryID]
+		}
+	}
+
+	return result, nil
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+func formatChange(change *float64) string {
+	if change == nil {
+		return ""
+	}
+	return formatAmount(*change)
+}
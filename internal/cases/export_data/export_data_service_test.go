@@ -0,0 +1,142 @@
+package export_data
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/get_reports"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/export"
+	"gofin/pkg/money"
+)
+
+func TestExportDataService_ExportTransactions(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	categoryRepo := database.NewCategoryInMemoryRepository()
+	splitRepo := database.NewTransactionSplitInMemoryRepository()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	service := NewExportDataService(transactionRepo, accountRepo, categoryRepo, splitRepo, payeeRepo, database.NewSettlementInMemoryRepository(), database.NewAccountTransferInMemoryRepository())
+
+	projectID := uuid.New()
+	account := models.NewAccount(projectID, "Main", money.PLN)
+	accountRepo.Create(ctx, account)
+
+	groceries := models.NewCategory(projectID, "Groceries")
+	pharmacy := models.NewCategory(projectID, "Pharmacy")
+	categoryRepo.Create(ctx, groceries)
+	categoryRepo.Create(ctx, pharmacy)
+
+	shop := models.NewPayee(projectID, "Shop", nil)
+	payeeRepo.Create(ctx, shop)
+
+	may := time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2024, time.June, 10, 0, 0, 0, 0, time.UTC)
+
+	receipt := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 100, Name: "Receipt", Type: models.Debit, TransactionDate: &may, PayeeID: &shop.ID, Notes: "milk, bread"}, uuid.New())
+	transactionRepo.Create(ctx, receipt)
+	splitRepo.ReplaceForTransaction(ctx, receipt.ID, models.NewTransactionSplits(receipt.ID, []models.SplitData{
+		{CategoryID: groceries.ID, Amount: 70},
+		{CategoryID: pharmacy.ID, Amount: 30},
+	}))
+	transactionRepo.Create(ctx, models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 1000, Name: "Salary", Type: models.TopUp, TransactionDate: &june}, uuid.New()))
+
+	tests := []struct {
+		name        string
+		query       models.TransactionQuery
+		expectRows  [][]string
+		expectError bool
+	}{
+		{
+			name:  "Every transaction",
+			query: models.TransactionQuery{SortBy: models.SortByDate, SortDirection: models.SortAscending},
+			expectRows: [][]string{
+				{"2024-05-10", "Main", "Receipt", "Shop", "Groceries, Pharmacy", "debit", "-100.00", "PLN", "uncleared", "milk, bread"},
+				{"2024-06-10", "Main", "Salary", "", "", "top-up", "1000.00", "PLN", "uncleared", ""},
+			},
+		},
+		{
+			name:  "Filtered by type",
+			query: models.TransactionQuery{Type: models.TopUp},
+			expectRows: [][]string{
+				{"2024-06-10", "Main", "Salary", "", "", "top-up", "1000.00", "PLN", "uncleared", ""},
+			},
+		},
+		{
+			name:        "Account of another project",
+			query:       models.TransactionQuery{AccountIDs: []uuid.UUID{uuid.New()}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			err := service.ExportTransactions(ctx, &buffer, export.CSV, projectID, tt.query)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			records, err := csv.NewReader(&buffer).ReadAll()
+			if err != nil {
+				t.Fatalf("Expected valid CSV, got %v", err)
+			}
+			if len(records) != len(tt.expectRows)+1 {
+				t.Fatalf("Expected %d rows and a header, got %d", len(tt.expectRows), len(records))
+			}
+			for i, row := range tt.expectRows {
+				if strings.Join(records[i+1], "|") != strings.Join(row, "|") {
+					t.Errorf("Expected row %v, got %v", row, records[i+1])
+				}
+			}
+		})
+	}
+
+	t.Run("XLSX workbook", func(t *testing.T) {
+		var buffer bytes.Buffer
+		if err := service.ExportTransactions(ctx, &buffer, export.XLSX, projectID, models.TransactionQuery{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatalf("Expected a zip archive, got %v", err)
+		}
+
+		found := false
+		for _, file := range archive.File {
+			if file.Name == "xl/worksheets/sheet1.xml" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected a worksheet in the workbook")
+		}
+	})
+
+	t.Run("PDF report", func(t *testing.T) {
+		var buffer bytes.Buffer
+		query := get_reports.ReportQuery{ProjectID: projectID, From: may, To: june}
+		if err := service.ExportReport(ctx, &buffer, export.PDF, query); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if !bytes.HasPrefix(buffer.Bytes(), []byte("%PDF-")) || !bytes.HasSuffix(buffer.Bytes(), []byte("%%EOF\n")) {
+			t.Errorf("Expected a PDF document")
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package get_category_summary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+// UncategorizedName labels the total of transactions without a category.
+const UncategorizedName = "Uncategorized"
+
+type GetCategorySummaryService struct {
+	categoryRepo models.CategoryRepository
+	accountRepo  models.AccountRepository
+	splitRepo    models.TransactionSplitRepository
+}
+
+func NewGetCategorySummaryService(categoryRepo models.CategoryRepository, accountRepo models.AccountRepository, splitRepo models.TransactionSplitRepository) *GetCategorySummaryService {
+	return &GetCategorySummaryService{
+		categoryRepo: categoryRepo,
+		accountRepo:  accountRepo,
+		splitRepo:    splitRepo,
+	}
+}
+
+type totalKey struct {
+	categoryID uuid.UUID
+	currency   string
+}
+
+// GetCategoryTotalsFromTransactions sums transactions per category and account
+// currency. A split transaction counts towards the categories of its lines rather
+// than as a whole, so a receipt split between groceries and pharmacy shows up in both.
+func (s *GetCategorySummaryService) GetCategoryTotalsFromTransactions(ctx context.Context, projectID uuid.UUID, transactions []*models.Transaction) ([]models.CategoryTotal, error) {
+	categories, err := s.categoryRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project categories: %w", err)
+	}
+
+	accounts, err := s.accountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	transactionIDs := make([]uuid.UUID, 0, len(transactions))
+	for _, transaction := range transactions {
+		transactionIDs = append(transactionIDs, transaction.ID)
+	}
+
+	splits, err := s.splitRepo.GetByTransactionIDs(ctx, transactionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction splits: %w", err)
+	}
+
+	splitsByTransaction := make(map[uuid.UUID][]*models.TransactionSplit)
+	for _, split := range splits {
+		splitsByTransaction[split.TransactionID] = append(splitsByTransaction[split.TransactionID], split)
+	}
+
+	currencies := make(map[uuid.UUID]string)
+	for _, account := range accounts {
+		currencies[account.ID] = account.Currency.String()
+	}
+
+	totals := make(map[totalKey]*models.CategoryTotal)
+	add := func(categoryID *uuid.UUID, currency string, transactionType models.TransactionType, amount float64) {
+		key := totalKey{currency: currency}
+		if categoryID != nil {
+			key.categoryID = *categoryID
+		}
+
+		total, exists := totals[key]
+		if !exists {
+			total = &models.CategoryTotal{CategoryID: categoryID, Currency: currency}
+			totals[key] = total
+		}
+
+		if transactionType == models.Debit {
+			total.Spent += amount
+		} else {
+			total.Received += amount
+		}
+	}
+
+	for _, transaction := range transactions {
+		currency := currencies[transaction.AccountID]
+
+		if transactionSplits := splitsByTransaction[transaction.ID]; len(transactionSplits) > 0 {
+			for _, split := range transactionSplits {
+				categoryID := split.CategoryID
+				add(&categoryID, currency, transaction.Type, split.Amount)
+			}
+			continue
+		}
+
+		add(transaction.CategoryID, currency, transaction.Type, transaction.Value)
+	}
+
+	names := make(map[uuid.UUID]string)
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+
+	result := make([]models.CategoryTotal, 0, len(totals))
+	for _, total := range totals {
+		total.Name = UncategorizedName
+		if total.CategoryID != nil {
+			total.Name = names[*total.CategoryID]
+		}
+		result = append(result, *total)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].CategoryID == nil) != (result[j].CategoryID == nil) {
+			return result[j].CategoryID == nil
+		}
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Currency < result[j].Currency
+	})
+
+	return result, nil
+}
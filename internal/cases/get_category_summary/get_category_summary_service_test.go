@@ -0,0 +1,68 @@
+package get_category_summary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestGetCategorySummaryService_GetCategoryTotalsFromTransactions(t *testing.T) {
+	ctx := context.Background()
+	categoryRepo := database.NewCategoryInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	splitRepo := database.NewTransactionSplitInMemoryRepository()
+	service := NewGetCategorySummaryService(categoryRepo, accountRepo, splitRepo)
+
+	projectID := uuid.New()
+	pln := models.NewAccount(projectID, "PLN", money.PLN)
+	eur := models.NewAccount(projectID, "EUR", money.EUR)
+	accountRepo.Create(ctx, pln)
+	accountRepo.Create(ctx, eur)
+
+	groceries := models.NewCategory(projectID, "Groceries")
+	pharmacy := models.NewCategory(projectID, "Pharmacy")
+	categoryRepo.Create(ctx, groceries)
+	categoryRepo.Create(ctx, pharmacy)
+
+	receipt := models.NewTransaction(models.TransactionData{AccountID: pln.ID, Value: 100, Name: "Receipt", Type: models.Debit}, uuid.New())
+	splitRepo.ReplaceForTransaction(ctx, receipt.ID, models.NewTransactionSplits(receipt.ID, []models.SplitData{
+		{CategoryID: groceries.ID, Amount: 70},
+		{CategoryID: pharmacy.ID, Amount: 30},
+	}))
+
+	shopping := models.NewTransaction(models.TransactionData{AccountID: pln.ID, Value: 25, Name: "Shop", Type: models.Debit, CategoryID: &groceries.ID}, uuid.New())
+	refund := models.NewTransaction(models.TransactionData{AccountID: pln.ID, Value: 5, Name: "Refund", Type: models.TopUp, CategoryID: &groceries.ID}, uuid.New())
+	abroad := models.NewTransaction(models.TransactionData{AccountID: eur.ID, Value: 12, Name: "Abroad", Type: models.Debit, CategoryID: &groceries.ID}, uuid.New())
+	salary := models.NewTransaction(models.TransactionData{AccountID: pln.ID, Value: 1000, Name: "Salary", Type: models.TopUp}, uuid.New())
+
+	totals, err := service.GetCategoryTotalsFromTransactions(ctx, projectID, []*models.Transaction{receipt, shopping, refund, abroad, salary})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []models.CategoryTotal{
+		{Name: "Groceries", Currency: "EUR", Spent: 12},
+		{Name: "Groceries", Currency: "PLN", Spent: 95, Received: 5},
+		{Name: "Pharmacy", Currency: "PLN", Spent: 30},
+		{Name: UncategorizedName, Currency: "PLN", Received: 1000},
+	}
+
+	if len(totals) != len(expected) {
+		t.Fatalf("Expected %d totals, got %d: %+v", len(expected), len(totals), totals)
+	}
+
+	for i, want := range expected {
+		got := totals[i]
+		if got.Name != want.Name || got.Currency != want.Currency || got.Spent != want.Spent || got.Received != want.Received {
+			t.Errorf("Total %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	if totals[3].CategoryID != nil {
+		t.Errorf("Expected uncategorized total to have no category ID")
+	}
+}
@@ -0,0 +1,155 @@
+package get_loan_schedule
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type GetLoanScheduleService struct {
+	loanRepo        models.LoanRepository
+	accountRepo     models.AccountRepository
+	transactionRepo models.TransactionRepository
+}
+
+func NewGetLoanScheduleService(loanRepo models.LoanRepository, accountRepo models.AccountRepository, transactionRepo models.TransactionRepository) *GetLoanScheduleService {
+	return &GetLoanScheduleService{
+		loanRepo:        loanRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+type LoanSummary struct {
+	Loan     *models.Loan             `json:"loan"`
+	Account  *models.Account          `json:"account"`
+	Periods  []*models.LoanRatePeriod `json:"periods"`
+	Schedule []models.Installment     `json:"schedule"`
+	// TotalInterest is the interest over the whole schedule.
+	TotalInterest float64 `json:"total_interest"`
+	// Outstanding is the debt booked on the loan account as of now.
+	Outstanding float64 `json:"outstanding"`
+	// NextInstallment is the first installment due after now, nil once the
+	// schedule has ended.
+	NextInstallment *models.Installment `json:"next_installment,omitempty"`
+}
+
+// Simulation compares the schedule with and without an early repayment.
+type Simulation struct {
+	Prepayment        models.LoanPrepayment `json:"prepayment"`
+	Baseline          []models.Installment  `json:"baseline"`
+	Schedule          []models.Installment  `json:"schedule"`
+	InterestSaved     float64               `json:"interest_saved"`
+	InstallmentsSaved int                   `json:"installments_saved"`
+	// NewPayment is the installment due after the repayment.
+	NewPayment float64 `json:"new_payment"`
+}
+
+// GetSchedule returns the amortization schedule of a loan account with its rate
+// periods and the debt outstanding as of now.
+func (s *GetLoanScheduleService) GetSchedule(ctx context.Context, projectID, accountID uuid.UUID, now time.Time) (*LoanSummary, error) {
+	loan, err := s.loanRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("loan not found: %w", err)
+	}
+
+	if loan.ProjectID != projectID {
+		return nil, fmt.Errorf("loan does not belong to the specified project")
+	}
+
+	return s.summarize(ctx, loan, now)
+}
+
+// GetLoans returns the summaries of every loan in a project.
+func (s *GetLoanScheduleService) GetLoans(ctx context.Context, projectID uuid.UUID, now time.Time) ([]*LoanSummary, error) {
+	loans, err := s.loanRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project loans: %w", err)
+	}
+
+	summaries := make([]*LoanSummary, 0, len(loans))
+	for _, loan := range loans {
+		summary, err := s.summarize(ctx, loan, now)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// SimulateEarlyRepayment lays out the schedule as if amount was repaid early on
+// date, either lowering the installments or shortening the term.
+func (s *GetLoanScheduleService) SimulateEarlyRepayment(ctx context.Context, projectID, accountID uuid.UUID, prepayment models.LoanPrepayment) (*Simulation, error) {
+	if prepayment.Amount <= 0 {
+		return nil, fmt.Errorf("repayment amount must be positive")
+	}
+
+	summary, err := s.GetSchedule(ctx, projectID, accountID, prepayment.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary.NextInstallment == nil {
+		return nil, fmt.Errorf("the loan schedule ends before %s", prepayment.Date.Format("2006-01-02"))
+	}
+
+	schedule := models.BuildAmortizationSchedule(summary.Loan, summary.Periods, []models.LoanPrepayment{prepayment})
+
+	simulation := &Simulation{
+		Prepayment:        prepayment,
+		Baseline:          summary.Schedule,
+		Schedule:          schedule,
+		InterestSaved:     summary.TotalInterest - models.TotalInterest(schedule),
+		InstallmentsSaved: len(summary.Schedule) - len(schedule),
+	}
+
+	for _, installment := range schedule {
+		if installment.Number > summary.NextInstallment.Number {
+			simulation.NewPayment = installment.Payment
+			break
+		}
+	}
+
+	return simulation, nil
+}
+
+func (s *GetLoanScheduleService) summarize(ctx context.Context, loan *models.Loan, now time.Time) (*LoanSummary, error) {
+	account, err := s.accountRepo.GetByID(ctx, loan.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	periods, err := s.loanRepo.GetRatePeriods(ctx, loan.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate periods: %w", err)
+	}
+
+	transactions, err := s.transactionRepo.GetByAccountID(ctx, loan.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account transactions: %w", err)
+	}
+
+	schedule := models.BuildAmortizationSchedule(loan, periods, nil)
+	summary := &LoanSummary{
+		Loan:          loan,
+		Account:       account,
+		Periods:       periods,
+		Schedule:      schedule,
+		TotalInterest: models.TotalInterest(schedule),
+		Outstanding:   models.LoanOutstanding(transactions, now),
+	}
+
+	for i := range schedule {
+		if schedule[i].Date.After(now) {
+			summary.NextInstallment = &schedule[i]
+			break
+		}
+	}
+
+	return summary, nil
+}
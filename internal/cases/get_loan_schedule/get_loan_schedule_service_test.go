@@ -0,0 +1,198 @@
+package get_loan_schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func setup(t *testing.T, rate float64) (*GetLoanScheduleService, *database.LoanInMemoryRepository, *models.Loan) {
+	t.Helper()
+
+	ctx := context.Background()
+	loanRepo := database.NewLoanInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	service := NewGetLoanScheduleService(loanRepo, accountRepo, transactionRepo)
+
+	account := models.NewAccount(uuid.New(), "Car loan", money.PLN)
+	account.Type = models.AccountLoan
+	accountRepo.Create(ctx, account)
+
+	start := date(2024, time.January, 15)
+	loan := models.NewLoan(account, 12000, rate, 12, 31, start)
+	loanRepo.Create(ctx, loan)
+
+	transactionRepo.Create(ctx, models.NewTransaction(models.TransactionData{
+		AccountID:       account.ID,
+		Value:           12000,
+		Name:            "Car loan: principal",
+		Type:            models.Debit,
+		TransactionDate: &start,
+	}))
+	repaid := date(2024, time.February, 29)
+	transactionRepo.Create(ctx, models.NewTransaction(models.TransactionData{
+		AccountID:       account.ID,
+		Value:           946.19,
+		Name:            "Car loan: principal",
+		Type:            models.TopUp,
+		TransactionDate: &repaid,
+	}))
+
+	return service, loanRepo, loan
+}
+
+func TestGetLoanScheduleService_GetSchedule(t *testing.T) {
+	ctx := context.Background()
+	service, _, loan := setup(t, 12)
+
+	summary, err := service.GetSchedule(ctx, loan.ProjectID, loan.AccountID, date(2024, time.March, 10))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(summary.Schedule) != 12 {
+		t.Fatalf("Expected 12 installments, got %d", len(summary.Schedule))
+	}
+
+	first := summary.Schedule[0]
+	if first.Payment != 1066.19 || first.Interest != 120 || first.Principal != 946.19 {
+		t.Errorf("Expected the first installment to be 946.19 + 120.00, got %+v", first)
+	}
+	if !first.Date.Equal(date(2024, time.February, 29)) {
+		t.Errorf("Expected the payment day clamped to February 29, got %v", first.Date)
+	}
+
+	last := summary.Schedule[11]
+	if last.Balance != 0 {
+		t.Errorf("Expected the loan repaid by the last installment, got %v left", last.Balance)
+	}
+	if summary.TotalInterest < 794 || summary.TotalInterest > 795 {
+		t.Errorf("Expected about 794 of interest, got %v", summary.TotalInterest)
+	}
+
+	if summary.Outstanding != 11053.81 {
+		t.Errorf("Expected 11053.81 outstanding, got %v", summary.Outstanding)
+	}
+	if summary.NextInstallment == nil || summary.NextInstallment.Number != 2 {
+		t.Errorf("Expected the second installment next, got %+v", summary.NextInstallment)
+	}
+
+	if _, err := service.GetSchedule(ctx, uuid.New(), loan.AccountID, date(2024, time.March, 10)); err == nil {
+		t.Error("Expected an error for another project")
+	}
+}
+
+func TestGetLoanScheduleService_VariableRate(t *testing.T) {
+	ctx := context.Background()
+	service, loanRepo, loan := setup(t, 0)
+
+	loanRepo.AddRatePeriod(ctx, models.NewLoanRatePeriod(loan.AccountID, date(2024, time.July, 1), 12))
+
+	summary, err := service.GetSchedule(ctx, loan.ProjectID, loan.AccountID, date(2024, time.March, 10))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, installment := range summary.Schedule[:5] {
+		if installment.Interest != 0 || installment.Payment != 1000 {
+			t.Errorf("Expected interest-free installments of 1000 before July, got %+v", installment)
+		}
+	}
+
+	july := summary.Schedule[5]
+	if july.Rate != 12 || july.Interest != 70 {
+		t.Errorf("Expected 1%% monthly interest on 7000 from July, got %+v", july)
+	}
+	if summary.Schedule[11].Balance != 0 {
+		t.Errorf("Expected the loan repaid over the same term, got %v left", summary.Schedule[11].Balance)
+	}
+}
+
+func TestGetLoanScheduleService_SimulateEarlyRepayment(t *testing.T) {
+	ctx := context.Background()
+	service, _, loan := setup(t, 12)
+
+	tests := []struct {
+		name          string
+		reducePayment bool
+	}{
+		{name: "shorter term", reducePayment: false},
+		{name: "lower payment", reducePayment: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			simulation, err := service.SimulateEarlyRepayment(ctx, loan.ProjectID, loan.AccountID, models.LoanPrepayment{
+				Date:          date(2024, time.April, 1),
+				Amount:        3000,
+				ReducePayment: tt.reducePayment,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if simulation.InterestSaved <= 0 {
+				t.Errorf("Expected interest to be saved, got %v", simulation.InterestSaved)
+			}
+
+			if tt.reducePayment {
+				if simulation.InstallmentsSaved != 0 {
+					t.Errorf("Expected the term to stay, got %d installments saved", simulation.InstallmentsSaved)
+				}
+				if simulation.NewPayment >= 1066.19 {
+					t.Errorf("Expected a lower installment, got %v", simulation.NewPayment)
+				}
+			} else {
+				if simulation.InstallmentsSaved < 2 {
+					t.Errorf("Expected the loan to end sooner, got %d installments saved", simulation.InstallmentsSaved)
+				}
+				if simulation.NewPayment != 1066.19 {
+					t.Errorf("Expected the installment to stay, got %v", simulation.NewPayment)
+				}
+			}
+
+			if simulation.Schedule[2].Prepayment != 3000 {
+				t.Errorf("Expected the repayment applied with the April installment, got %+v", simulation.Schedule[2])
+			}
+		})
+	}
+
+	if _, err := service.SimulateEarlyRepayment(ctx, loan.ProjectID, loan.AccountID, models.LoanPrepayment{Date: date(2024, time.April, 1)}); err == nil {
+		t.Error("Expected an error for a zero repayment")
+	}
+	if _, err := service.SimulateEarlyRepayment(ctx, loan.ProjectID, loan.AccountID, models.LoanPrepayment{Date: date(2026, time.January, 1), Amount: 100}); err == nil {
+		t.Error("Expected an error after the schedule has ended")
+	}
+}
+
+func TestGetLoanScheduleService_SimulateEarlyRepaymentBeforeRateChange(t *testing.T) {
+	ctx := context.Background()
+	service, loanRepo, loan := setup(t, 12)
+
+	loanRepo.AddRatePeriod(ctx, models.NewLoanRatePeriod(loan.AccountID, date(2024, time.August, 1), 6))
+
+	simulation, err := service.SimulateEarlyRepayment(ctx, loan.ProjectID, loan.AccountID, models.LoanPrepayment{
+		Date:   date(2024, time.April, 1),
+		Amount: 3000,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if simulation.InstallmentsSaved < 2 {
+		t.Errorf("Expected the shorter term to survive the rate change, got %d installments saved", simulation.InstallmentsSaved)
+	}
+	if last := simulation.Schedule[len(simulation.Schedule)-1]; last.Balance != 0 {
+		t.Errorf("Expected the loan repaid by the last installment, got %v left", last.Balance)
+	}
+}
@@ -0,0 +1,107 @@
+package get_payee_history
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_payee"
+	"gofin/internal/models"
+)
+
+type GetPayeeHistoryService struct {
+	payeeRepo        models.PayeeRepository
+	transactionRepo  models.TransactionRepository
+	accountRepo      models.AccountRepository
+	validatePayeeSvc *validate_payee.ValidatePayeeService
+}
+
+func NewGetPayeeHistoryService(payeeRepo models.PayeeRepository, transactionRepo models.TransactionRepository, accountRepo models.AccountRepository) *GetPayeeHistoryService {
+	return &GetPayeeHistoryService{
+		payeeRepo:        payeeRepo,
+		transactionRepo:  transactionRepo,
+		accountRepo:      accountRepo,
+		validatePayeeSvc: validate_payee.NewValidatePayeeService(payeeRepo),
+	}
+}
+
+type PayeeHistory struct {
+	Payee        *models.Payee
+	Aliases      []*models.PayeeAlias
+	Transactions []*models.Transaction
+	Totals       []models.PayeeTotal
+}
+
+// GetHistory returns a payee with its aliases and every linked transaction,
+// newest first, together with what was spent and received per currency.
+func (s *GetPayeeHistoryService) GetHistory(ctx context.Context, projectID, payeeID uuid.UUID) (*PayeeHistory, error) {
+	payee, err := s.validatePayeeSvc.GetPayeeForProject(ctx, projectID, payeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectAliases, err := s.payeeRepo.GetAliasesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payee aliases: %w", err)
+	}
+
+	var aliases []*models.PayeeAlias
+	for _, alias := range projectAliases {
+		if alias.PayeeID == payeeID {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	transactions, err := s.transactionRepo.GetTransactionsWithFilters(ctx, models.TransactionQuery{
+		ProjectID:     &projectID,
+		PayeeID:       &payeeID,
+		SortBy:        models.SortByDate,
+		SortDirection: models.SortDescending,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payee transactions: %w", err)
+	}
+
+	accounts, err := s.accountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	currencies := make(map[uuid.UUID]string)
+	for _, account := range accounts {
+		currencies[account.ID] = account.Currency.String()
+	}
+
+	totals := make(map[string]*models.PayeeTotal)
+	for _, transaction := range transactions {
+		currency := currencies[transaction.AccountID]
+		total, exists := totals[currency]
+		if !exists {
+			total = &models.PayeeTotal{Currency: currency}
+			totals[currency] = total
+		}
+
+		total.Count++
+		if transaction.Type == models.Debit {
+			total.Spent += transaction.Value
+		} else {
+			total.Received += transaction.Value
+		}
+	}
+
+	history := &PayeeHistory{
+		Payee:        payee,
+		Aliases:      aliases,
+		Transactions: transactions,
+	}
+	for _, total := range totals {
+		history.Totals = append(history.Totals, *total)
+	}
+
+	sort.Slice(history.Totals, func(i, j int) bool {
+		return history.Totals[i].Currency < history.Totals[j].Currency
+	})
+
+	return history, nil
+}
@@ -0,0 +1,76 @@
+package get_payee_history
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+)
+
+func TestGetPayeeHistoryService_GetHistory(t *testing.T) {
+	ctx := context.Background()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	service := NewGetPayeeHistoryService(payeeRepo, transactionRepo, accountRepo)
+
+	projectID := uuid.New()
+	pln := models.NewAccount(projectID, "Main", "PLN")
+	eur := models.NewAccount(projectID, "Travel", "EUR")
+	accountRepo.Create(ctx, pln)
+	accountRepo.Create(ctx, eur)
+
+	payee := models.NewPayee(projectID, "Biedronka", nil)
+	other := models.NewPayee(projectID, "Lidl", nil)
+	payeeRepo.Create(ctx, payee)
+	payeeRepo.Create(ctx, other)
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(payee, "biedronka"))
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(other, "lidl"))
+
+	older := time.Now().AddDate(0, -1, 0)
+	newer := time.Now().AddDate(0, 0, -1)
+	for _, data := range []models.TransactionData{
+		{AccountID: pln.ID, Value: 30, Name: "Biedronka", Type: models.Debit, TransactionDate: &older, PayeeID: &payee.ID},
+		{AccountID: pln.ID, Value: 5, Name: "Biedronka refund", Type: models.TopUp, TransactionDate: &newer, PayeeID: &payee.ID},
+		{AccountID: eur.ID, Value: 12, Name: "Biedronka", Type: models.Debit, TransactionDate: &newer, PayeeID: &payee.ID},
+		{AccountID: pln.ID, Value: 99, Name: "Lidl", Type: models.Debit, PayeeID: &other.ID},
+	} {
+		transactionRepo.Create(ctx, models.NewTransaction(data))
+	}
+
+	history, err := service.GetHistory(ctx, projectID, payee.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(history.Aliases) != 1 || history.Aliases[0].Alias != "biedronka" {
+		t.Errorf("Expected only the payee's own alias, got %+v", history.Aliases)
+	}
+
+	if len(history.Transactions) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(history.Transactions))
+	}
+	if history.Transactions[2].Value != 30 {
+		t.Errorf("Expected the oldest transaction last, got %v", history.Transactions[2].Value)
+	}
+
+	want := []models.PayeeTotal{
+		{Currency: "EUR", Spent: 12, Count: 1},
+		{Currency: "PLN", Spent: 30, Received: 5, Count: 2},
+	}
+	if len(history.Totals) != len(want) {
+		t.Fatalf("Expected %d totals, got %+v", len(want), history.Totals)
+	}
+	for i := range want {
+		if history.Totals[i] != want[i] {
+			t.Errorf("Total %d = %+v, want %+v", i, history.Totals[i], want[i])
+		}
+	}
+
+	if _, err := service.GetHistory(ctx, uuid.New(), payee.ID); err == nil {
+		t.Error("Expected error for a payee from another project")
+	}
+}
@@ -0,0 +1,190 @@
+package get_portfolio
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type GetPortfolioService struct {
+	operationRepo   models.InvestmentOperationRepository
+	securityRepo    models.SecurityRepository
+	accountRepo     models.AccountRepository
+	transactionRepo models.TransactionRepository
+}
+
+func NewGetPortfolioService(operationRepo models.InvestmentOperationRepository, securityRepo models.SecurityRepository, accountRepo models.AccountRepository, transactionRepo models.TransactionRepository) *GetPortfolioService {
+	return &GetPortfolioService{
+		operationRepo:   operationRepo,
+		securityRepo:    securityRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Position values a holding at the latest price known on the valuation date.
+// Without a price history the last trade price is used; Priced is false when
+// neither exists, and the position is then carried at cost.
+type Position struct {
+	models.Holding
+	Security       *models.Security `json:"security"`
+	Price          float64          `json:"price"`
+	PriceDate      time.Time        `json:"price_date"`
+	Priced         bool             `json:"priced"`
+	MarketValue    float64          `json:"market_value"`
+	UnrealisedGain float64          `json:"unrealised_gain"`
+}
+
+type Portfolio struct {
+	Account    *models.Account               `json:"account"`
+	Method     models.CostMethod             `json:"method"`
+	AsOf       time.Time                     `json:"as_of"`
+	Positions  []Position                    `json:"positions"`
+	Operations []*models.InvestmentOperation `json:"operations"`
+	// Cash is the currency balance of the account.
+	Cash           float64 `json:"cash"`
+	MarketValue    float64 `json:"market_value"`
+	CostBasis      float64 `json:"cost_basis"`
+	UnrealisedGain float64 `json:"unrealised_gain"`
+	RealisedGain   float64 `json:"realised_gain"`
+	Dividends      float64 `json:"dividends"`
+	// TotalValue is the cash plus the market value of the positions.
+	TotalValue float64 `json:"total_value"`
+}
+
+// GetPortfolio values an investment account as of asOf, with gains worked out
+// using method. Operations and transactions dated after asOf are left out.
+func (s *GetPortfolioService) GetPortfolio(ctx context.Context, projectID, accountID uuid.UUID, method models.CostMethod, asOf time.Time) (*Portfolio, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	if account.ProjectID != projectID {
+		return nil, fmt.Errorf("account does not belong to the specified project")
+	}
+
+	if account.Type != models.AccountInvestment {
+		return nil, fmt.Errorf("account '%s' is not an investment account", account.Name)
+	}
+
+	return s.value(ctx, account, method, asOf)
+}
+
+// GetPortfolios values every active investment account of a project.
+func (s *GetPortfolioService) GetPortfolios(ctx context.Context, projectID uuid.UUID, method models.CostMethod, asOf time.Time) ([]*Portfolio, error) {
+	accounts, err := s.accountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	var portfolios []*Portfolio
+	for _, account := range models.ActiveAccounts(accounts) {
+		if account.Type != models.AccountInvestment {
+			continue
+		}
+
+		portfolio, err := s.value(ctx, account, method, asOf)
+		if err != nil {
+			return nil, err
+		}
+		portfolios = append(portfolios, portfolio)
+	}
+
+	return portfolios, nil
+}
+
+func (s *GetPortfolioService) value(ctx context.Context, account *models.Account, method models.CostMethod, asOf time.Time) (*Portfolio, error) {
+	allOperations, err := s.operationRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get investment operations: %w", err)
+	}
+
+	var operations []*models.InvestmentOperation
+	for _, operation := range allOperations {
+		if !operation.Date.After(asOf) {
+			operations = append(operations, operation)
+		}
+	}
+
+	holdings, err := models.BuildHoldings(operations, method)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.transactionRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account transactions: %w", err)
+	}
+
+	portfolio := &Portfolio{
+		Account:    account,
+		Method:     method,
+		AsOf:       asOf,
+		Operations: operations,
+	}
+
+	for _, transaction := range transactions {
+		if transaction.TransactionDate.After(asOf) {
+			continue
+		}
+		if transaction.Type == models.TopUp {
+			portfolio.Cash += transaction.Value
+		} else {
+			portfolio.Cash -= transaction.Value
+		}
+	}
+
+	for _, holding := range holdings {
+		security, err := s.securityRepo.GetByID(ctx, holding.SecurityID)
+		if err != nil {
+			return nil, fmt.Errorf("security not found: %w", err)
+		}
+
+		prices, err := s.securityRepo.GetPrices(ctx, security.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get security prices: %w", err)
+		}
+
+		position := Position{Holding: *holding, Security: security}
+		if price, ok := models.PriceAt(prices, asOf); ok {
+			position.Price, position.PriceDate, position.Priced = price.Price, price.Date, true
+		}
+		for _, operation := range operations {
+			if operation.SecurityID == security.ID && operation.Type != models.OperationDividend && !operation.Date.Before(position.PriceDate) {
+				position.Price, position.PriceDate, position.Priced = operation.Price, operation.Date, true
+			}
+		}
+
+		position.MarketValue = position.CostBasis
+		if position.Priced {
+			position.MarketValue = roundCents(position.Quantity * position.Price)
+		}
+		position.UnrealisedGain = roundCents(position.MarketValue - position.CostBasis)
+
+		portfolio.Positions = append(portfolio.Positions, position)
+		portfolio.MarketValue += position.MarketValue
+		portfolio.CostBasis += position.CostBasis
+		portfolio.UnrealisedGain += position.UnrealisedGain
+		portfolio.RealisedGain += position.RealisedGain
+		portfolio.Dividends += position.Dividends
+	}
+
+	portfolio.Cash = roundCents(portfolio.Cash)
+	portfolio.MarketValue = roundCents(portfolio.MarketValue)
+	portfolio.CostBasis = roundCents(portfolio.CostBasis)
+	portfolio.UnrealisedGain = roundCents(portfolio.UnrealisedGain)
+	portfolio.RealisedGain = roundCents(portfolio.RealisedGain)
+	portfolio.Dividends = roundCents(portfolio.Dividends)
+	portfolio.TotalValue = roundCents(portfolio.Cash + portfolio.MarketValue)
+
+	return portfolio, nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,165 @@
+package get_portfolio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestGetPortfolioService_GetPortfolio(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name               string
+		method             models.CostMethod
+		asOf               time.Time
+		expectedQuantity   float64
+		expectedCostBasis  float64
+		expectedRealised   float64
+		expectedValue      float64
+		expectedUnrealised float64
+		expectedDividends  float64
+		expectedCash       float64
+	}{
+		{
+			name:               "fifo",
+			method:             models.CostFIFO,
+			asOf:               day(time.December, 31),
+			expectedQuantity:   10,
+			expectedCostBasis:  2000,
+			expectedRealised:   500,
+			expectedValue:      2500,
+			expectedUnrealised: 500,
+			expectedDividends:  30,
+			expectedCash:       3530,
+		},
+		{
+			name:               "average cost",
+			method:             models.CostAverage,
+			asOf:               day(time.December, 31),
+			expectedQuantity:   10,
+			expectedCostBasis:  1600,
+			expectedRealised:   100,
+			expectedValue:      2500,
+			expectedUnrealised: 900,
+			expectedDividends:  30,
+			expectedCash:       3530,
+		},
+		{
+			name:               "before the sale, valued at the last trade",
+			method:             models.CostFIFO,
+			asOf:               day(time.March, 15),
+			expectedQuantity:   20,
+			expectedCostBasis:  3200,
+			expectedValue:      4000,
+			expectedUnrealised: 800,
+			expectedCash:       1800,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			operationRepo := database.NewInvestmentOperationInMemoryRepository()
+			securityRepo := database.NewSecurityInMemoryRepository()
+			accountRepo := database.NewAccountInMemoryRepository()
+			transactionRepo := database.NewTransactionInMemoryRepository()
+			service := NewGetPortfolioService(operationRepo, securityRepo, accountRepo, transactionRepo)
+
+			projectID := uuid.New()
+			account := models.NewAccount(projectID, "Broker", money.PLN)
+			account.Type = models.AccountInvestment
+			accountRepo.Create(ctx, account)
+
+			deposited := day(time.January, 1)
+			transactionRepo.Create(ctx, models.NewTransaction(models.TransactionData{
+				AccountID:       account.ID,
+				Value:           5000,
+				Name:            "Deposit",
+				Type:            models.TopUp,
+				TransactionDate: &deposited,
+			}))
+
+			security := models.NewSecurity(projectID, "VWCE", "", money.PLN)
+			securityRepo.Create(ctx, security)
+			securityRepo.SetPrice(ctx, models.NewSecurityPrice(security.ID, day(time.November, 30), 250))
+
+			operations := []*models.InvestmentOperation{
+				models.NewInvestmentOperation(account.ID, security.ID, models.OperationBuy, 10, 120, 0, 0, day(time.February, 1)),
+				models.NewInvestmentOperation(account.ID, security.ID, models.OperationBuy, 10, 200, 0, 0, day(time.March, 1)),
+				models.NewInvestmentOperation(account.ID, security.ID, models.OperationSell, 10, 170, 0, 0, day(time.May, 1)),
+				models.NewInvestmentOperation(account.ID, security.ID, models.OperationDividend, 0, 0, 0, 30, day(time.June, 1)),
+			}
+			for _, operation := range operations {
+				transactionType := models.TopUp
+				if operation.Type == models.OperationBuy {
+					transactionType = models.Debit
+				}
+				transaction := models.NewTransaction(models.TransactionData{
+					AccountID:       account.ID,
+					Value:           operation.Amount,
+					Name:            operation.Type.String(),
+					Type:            transactionType,
+					TransactionDate: &operation.Date,
+				})
+				transactionRepo.Create(ctx, transaction)
+				operation.TransactionID = transaction.ID
+				operationRepo.Create(ctx, operation)
+			}
+
+			portfolio, err := service.GetPortfolio(ctx, projectID, account.ID, tt.method, tt.asOf)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if len(portfolio.Positions) != 1 {
+				t.Fatalf("Expected one position, got %d", len(portfolio.Positions))
+			}
+
+			position := portfolio.Positions[0]
+			if position.Quantity != tt.expectedQuantity || position.CostBasis != tt.expectedCostBasis {
+				t.Errorf("Expected %v held at %v, got %v at %v", tt.expectedQuantity, tt.expectedCostBasis, position.Quantity, position.CostBasis)
+			}
+
+			if portfolio.RealisedGain != tt.expectedRealised || portfolio.Dividends != tt.expectedDividends {
+				t.Errorf("Expected %v realised and %v dividends, got %v and %v", tt.expectedRealised, tt.expectedDividends, portfolio.RealisedGain, portfolio.Dividends)
+			}
+
+			if portfolio.MarketValue != tt.expectedValue || portfolio.UnrealisedGain != tt.expectedUnrealised {
+				t.Errorf("Expected %v market value and %v unrealised, got %v and %v", tt.expectedValue, tt.expectedUnrealised, portfolio.MarketValue, portfolio.UnrealisedGain)
+			}
+
+			if portfolio.Cash != tt.expectedCash || portfolio.TotalValue != tt.expectedCash+tt.expectedValue {
+				t.Errorf("Expected %v cash and %v in total, got %v and %v", tt.expectedCash, tt.expectedCash+tt.expectedValue, portfolio.Cash, portfolio.TotalValue)
+			}
+		})
+	}
+}
+
+func TestGetPortfolioService_GetPortfolioRejectsOtherAccounts(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	service := NewGetPortfolioService(database.NewInvestmentOperationInMemoryRepository(), database.NewSecurityInMemoryRepository(), accountRepo, database.NewTransactionInMemoryRepository())
+
+	projectID := uuid.New()
+	checking := models.NewAccount(projectID, "Checking", money.PLN)
+	foreign := models.NewAccount(uuid.New(), "Foreign", money.PLN)
+	foreign.Type = models.AccountInvestment
+	accountRepo.Create(ctx, checking)
+	accountRepo.Create(ctx, foreign)
+
+	if _, err := service.GetPortfolio(ctx, projectID, checking.ID, models.CostFIFO, time.Now()); err == nil {
+		t.Error("Expected an error for a non-investment account")
+	}
+
+	if _, err := service.GetPortfolio(ctx, projectID, foreign.ID, models.CostFIFO, time.Now()); err == nil {
+		t.Error("Expected an error for an account of another project")
+	}
+}
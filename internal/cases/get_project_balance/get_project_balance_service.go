@@ -1,6 +1,7 @@
 package get_project_balance
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -23,8 +24,8 @@ type ProjectBalanceData struct {
 	CurrencyTotals  []models.CurrencyTotal  `json:"currency_totals"`
 }
 
-func (s *GetProjectBalanceService) GetProjectBalancesFromTransactions(projectID uuid.UUID, transactions []*models.Transaction) (*ProjectBalanceData, error) {
-	accounts, err := s.accountRepo.GetByProjectID(projectID)
+func (s *GetProjectBalanceService) GetProjectBalancesFromTransactions(ctx context.Context, projectID uuid.UUID, transactions []*models.Transaction) (*ProjectBalanceData, error) {
+	accounts, err := s.accountRepo.GetByProjectID(ctx, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get project accounts: %w", err)
 	}
@@ -1,6 +1,7 @@
 package get_project_balance
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -18,8 +19,8 @@ func TestGetProjectBalanceService_GetProjectBalancesFromTransactions(t *testing.
 	account1 := models.NewAccount(projectID, "Savings", money.PLN)
 	account2 := models.NewAccount(projectID, "Checking", money.EUR)
 
-	accountRepo.Create(account1)
-	accountRepo.Create(account2)
+	accountRepo.Create(context.Background(), account1)
+	accountRepo.Create(context.Background(), account2)
 
 	now := time.Now()
 	transactionDate1 := now.Add(-2 * time.Hour)
@@ -51,7 +52,7 @@ func TestGetProjectBalanceService_GetProjectBalancesFromTransactions(t *testing.
 
 	transactions := []*models.Transaction{transaction1, transaction2, transaction3}
 
-	result, err := service.GetProjectBalancesFromTransactions(projectID, transactions)
+	result, err := service.GetProjectBalancesFromTransactions(context.Background(), projectID, transactions)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -118,7 +119,7 @@ func TestGetProjectBalanceService_GetProjectBalancesFromTransactions_EmptyProjec
 	projectID := uuid.New()
 	transactions := []*models.Transaction{}
 
-	result, err := service.GetProjectBalancesFromTransactions(projectID, transactions)
+	result, err := service.GetProjectBalancesFromTransactions(context.Background(), projectID, transactions)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -1,6 +1,7 @@
 package get_project_transactions
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,7 +18,7 @@ func NewGetProjectTransactionsService(transactionRepo models.TransactionReposito
 	}
 }
 
-func (s *GetProjectTransactionsService) GetProjectTransactions(projectID uuid.UUID, year int, month int) ([]*models.Transaction, error) {
+func (s *GetProjectTransactionsService) GetProjectTransactions(ctx context.Context, projectID uuid.UUID, year int, month int) ([]*models.Transaction, error) {
 	startDate, endDate := s.calculateDateRange(year, month)
 
 	query := models.TransactionQuery{
@@ -26,7 +27,7 @@ func (s *GetProjectTransactionsService) GetProjectTransactions(projectID uuid.UU
 		EndDate:   endDate,
 	}
 
-	return s.transactionRepo.GetTransactionsWithFilters(query)
+	return s.transactionRepo.GetTransactionsWithFilters(ctx, query)
 }
 
 func (s *GetProjectTransactionsService) calculateDateRange(year int, month int) (*time.Time, *time.Time) {
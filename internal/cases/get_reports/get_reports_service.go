@@ -0,0 +1,465 @@
+package get_reports
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/get_category_summary"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+// TopPayeesLimit caps how many payees the top payees report lists.
+const TopPayeesLimit = 10
+
+// MaxReportYears caps the length of a report range.
+const MaxReportYears = 10
+
+// MonthFormat is how report months are keyed, both on the page and in JSON.
+const MonthFormat = "2006-01"
+
+type GetReportsService struct {
+	transactionRepo    models.TransactionRepository
+	accountRepo        models.AccountRepository
+	payeeRepo          models.PayeeRepository
+	settlementRepo     models.SettlementRepository
+	transferRepo       models.AccountTransferRepository
+	categorySummarySvc *get_category_summary.GetCategorySummaryService
+}
+
+func NewGetReportsService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, categoryRepo models.CategoryRepository, splitRepo models.TransactionSplitRepository, payeeRepo models.PayeeRepository, settlementRepo models.SettlementRepository, transferRepo models.AccountTransferRepository) *GetReportsService {
+	return &GetReportsService{
+		transactionRepo:    transactionRepo,
+		accountRepo:        accountRepo,
+		payeeRepo:          payeeRepo,
+		settlementRepo:     settlementRepo,
+		transferRepo:       transferRepo,
+		categorySummarySvc: get_category_summary.NewGetCategorySummaryService(categoryRepo, accountRepo, splitRepo),
+	}
+}
+
+// ReportQuery selects the days and the currency a report covers. Both ends of
+// the range are inclusive; an empty currency picks the first account's currency.
+type ReportQuery struct {
+	ProjectID uuid.UUID
+	From      time.Time
+	To        time.Time
+	Currency  string
+}
+
+type Report struct {
+	From          time.Time         `json:"from"`
+	To            time.Time         `json:"to"`
+	Currency      string            `json:"currency"`
+	Currencies    []string          `json:"currencies"`
+	Income        float64           `json:"income"`
+	Expense       float64           `json:"expense"`
+	IncomeExpense []MonthlyTotal    `json:"income_expense"`
+	Categories    []CategoryShare   `json:"categories"`
+	TopPayees     []PayeeSpending   `json:"top_payees"`
+	YearOverYear  YearOverYear      `json:"year_over_year"`
+	CashFlow      CashFlowStatement `json:"cash_flow"`
+}
+
+// Net is what was left after expenses over the whole range.
+func (r *Report) Net() float64 {
+	return r.Income - r.Expense
+}
+
+type MonthlyTotal struct {
+	Month   string  `json:"month"`
+	Income  float64 `json:"income"`
+	Expense float64 `json:"expense"`
+	Net     float64 `json:"net"`
+}
+
+// CategoryShare is a category total together with its share, in percent, of
+// everything spent in the range.
+type CategoryShare struct {
+	models.CategoryTotal
+	Share float64 `json:"share"`
+}
+
+type PayeeSpending struct {
+	PayeeID  uuid.UUID `json:"payee_id"`
+	Name     string    `json:"name"`
+	Spent    float64   `json:"spent"`
+	Received float64   `json:"received"`
+	Count    int       `json:"count"`
+}
+
+// YearOverYear compares every month of the range with the same month a year
+// earlier. Change is the expense change in percent and is nil when nothing was
+// spent a year earlier.
+type YearOverYear struct {
+	Months          []YearOverYearMonth `json:"months"`
+	Income          float64             `json:"income"`
+	Expense         float64             `json:"expense"`
+	PreviousIncome  float64             `json:"previous_income"`
+	PreviousExpense float64             `json:"previous_expense"`
+	Change          *float64            `json:"change,omitempty"`
+}
+
+type YearOverYearMonth struct {
+	Month           string   `json:"month"`
+	PreviousMonth   string   `json:"previous_month"`
+	Income          float64  `json:"income"`
+	Expense         float64  `json:"expense"`
+	PreviousIncome  float64  `json:"previous_income"`
+	PreviousExpense float64  `json:"previous_expense"`
+	Change          *float64 `json:"change,omitempty"`
+}
+
+// CashFlowStatement walks the balance of every account in the report currency
+// from the opening balance at the start of the range to the closing balance at
+// its end.
+type CashFlowStatement struct {
+	Opening  float64         `json:"opening"`
+	Inflows  float64         `json:"inflows"`
+	Outflows float64         `json:"outflows"`
+	Closing  float64         `json:"closing"`
+	Months   []CashFlowMonth `json:"months"`
+}
+
+type CashFlowMonth struct {
+	Month    string  `json:"month"`
+	Opening  float64 `json:"opening"`
+	Inflows  float64 `json:"inflows"`
+	Outflows float64 `json:"outflows"`
+	Closing  float64 `json:"closing"`
+}
+
+// GetReport builds every report for the range in a single currency, since
+// amounts in different currencies are never added up. Money moved inside the
+// project is neither income nor expense: transfers between its accounts,
+// settlements between members and loan principal are left out of every report
+// but the cash flow, which still follows the account balances.
+func (s *GetReportsService) GetReport(ctx context.Context, query ReportQuery) (*Report, error) {
+	from := startOfDay(query.From)
+	to := startOfDay(query.To).AddDate(0, 0, 1).Add(-time.Nanosecond)
+	if to.Before(from) {
+		return nil, fmt.Errorf("report range ends on %s, before it starts on %s", query.To.Format("2006-01-02"), query.From.Format("2006-01-02"))
+	}
+	if from.AddDate(MaxReportYears, 0, 0).Before(to) {
+		return nil, fmt.Errorf("report range cannot be longer than %d years", MaxReportYears)
+	}
+
+	accounts, err := s.accountRepo.GetByProjectID(ctx, query.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	report := &Report{From: from, To: to, Currency: query.Currency}
+
+	currencies := make(map[uuid.UUID]string)
+	seen := make(map[string]bool)
+	for _, account := range accounts {
+		currency := account.Currency.String()
+		currencies[account.ID] = currency
+		if !seen[currency] {
+			seen[currency] = true
+			report.Currencies = append(report.Currencies, currency)
+		}
+	}
+
+	if report.Currency == "" {
+		if len(report.Currencies) > 0 {
+			report.Currency = report.Currencies[0]
+		}
+	} else {
+		currency, err := money.ParseCurrency(report.Currency)
+		if err != nil {
+			return nil, err
+		}
+		report.Currency = currency.String()
+	}
+
+	transactions, err := s.transactionRepo.GetByProjectIDWithDateRange(ctx, query.ProjectID, nil, &to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project transactions: %w", err)
+	}
+
+	settlements, err := s.settlementRepo.GetByProjectID(ctx, query.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project settlements: %w", err)
+	}
+
+	transfers, err := s.transferRepo.GetByProjectID(ctx, query.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project transfers: %w", err)
+	}
+
+	internal := newInternalMovements(accounts, settlements, transfers)
+
+	previousFrom := from.AddDate(-1, 0, 0)
+	previousTo := to.AddDate(-1, 0, 0)
+
+	var inRange, movements, previous []*models.Transaction
+	for _, transaction := range transactions {
+		if currencies[transaction.AccountID] != report.Currency {
+			continue
+		}
+
+		date := transaction.TransactionDate
+		if date.Before(from) {
+			report.CashFlow.Opening += transaction.SignedValue()
+		} else if !internal.transfers[transaction.ID] {
+			movements = append(movements, transaction)
+		}
+
+		if internal.contains(transaction) {
+			continue
+		}
+
+		if !date.Before(from) {
+			inRange = append(inRange, transaction)
+		}
+		if !date.Before(previousFrom) && !date.After(previousTo) {
+			previous = append(previous, transaction)
+		}
+	}
+
+	months := monthsBetween(from, to)
+	report.IncomeExpense = monthlyTotals(months, inRange)
+	for _, month := range report.IncomeExpense {
+		report.Income += month.Income
+		report.Expense += month.Expense
+	}
+
+	report.Categories, err = s.categoryShares(ctx, query.ProjectID, report.Currency, inRange)
+	if err != nil {
+		return nil, err
+	}
+
+	report.TopPayees, err = s.topPayees(ctx, query.ProjectID, inRange)
+	if err != nil {
+		return nil, err
+	}
+
+	report.YearOverYear = yearOverYear(months, report.IncomeExpense, previous)
+	report.CashFlow = cashFlow(report.CashFlow.Opening, monthlyTotals(months, movements))
+
+	return report, nil
+}
+
+func (s *GetReportsService) categoryShares(ctx context.Context, projectID uuid.UUID, currency string, transactions []*models.Transaction) ([]CategoryShare, error) {
+	totals, err := s.categorySummarySvc.GetCategoryTotalsFromTransactions(ctx, projectID, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	var spent float64
+	shares := make([]CategoryShare, 0, len(totals))
+	for _, total := range totals {
+		if total.Currency != currency {
+			continue
+		}
+		spent += total.Spent
+		shares = append(shares, CategoryShare{CategoryTotal: total})
+	}
+
+	for i := range shares {
+		if spent > 0 {
+			shares[i].Share = shares[i].Spent / spent * 100
+		}
+	}
+
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].Spent > shares[j].Spent
+	})
+
+	return shares, nil
+}
+
+func (s *GetReportsService) topPayees(ctx context.Context, projectID uuid.UUID, transactions []*models.Transaction) ([]PayeeSpending, error) {
+	payees, err := s.payeeRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project payees: %w", err)
+	}
+
+	names := make(map[uuid.UUID]string)
+	for _, payee := range payees {
+		names[payee.ID] = payee.Name
+	}
+
+	totals := make(map[uuid.UUID]*PayeeSpending)
+	for _, transaction := range transactions {
+		if transaction.PayeeID == nil {
+			continue
+		}
+
+		total, exists := totals[*transaction.PayeeID]
+		if !exists {
+			total = &PayeeSpending{PayeeID: *transaction.PayeeID, Name: names[*transaction.PayeeID]}
+			totals[*transaction.PayeeID] = total
+		}
+
+		total.Count++
+		if transaction.Type == models.Debit {
+			total.Spent += transaction.Value
+		} else {
+			total.Received += transaction.Value
+		}
+	}
+
+	result := make([]PayeeSpending, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Spent != result[j].Spent {
+			return result[i].Spent > result[j].Spent
+		}
+		return result[i].Name < result[j].Name
+	})
+
+	if len(result) > TopPayeesLimit {
+		result = result[:TopPayeesLimit]
+	}
+
+	return result, nil
+}
+
+// internalMovements are the transactions that move money inside the project.
+// Transfers are the legs of a recorded transfer, such as the principal legs of a
+// loan payment. Every transaction on a loan account is principal, and every leg
+// of a settlement squares up expenses already counted when they were paid.
+type internalMovements struct {
+	transfers   map[uuid.UUID]bool
+	loans       map[uuid.UUID]bool
+	settlements map[uuid.UUID]bool
+}
+
+func newInternalMovements(accounts []*models.Account, settlements []*models.Settlement, transfers []*models.AccountTransfer) *internalMovements {
+	internal := &internalMovements{
+		transfers:   make(map[uuid.UUID]bool),
+		loans:       make(map[uuid.UUID]bool),
+		settlements: make(map[uuid.UUID]bool),
+	}
+
+	for _, account := range accounts {
+		if account.Type == models.AccountLoan {
+			internal.loans[account.ID] = true
+		}
+	}
+
+	for _, settlement := range settlements {
+		if settlement.GroupID != nil {
+			internal.settlements[*settlement.GroupID] = true
+		}
+	}
+
+	for _, transfer := range transfers {
+		internal.transfers[transfer.FromTransactionID] = true
+		internal.transfers[transfer.ToTransactionID] = true
+	}
+
+	return internal
+}
+
+func (m *internalMovements) contains(transaction *models.Transaction) bool {
+	if m.transfers[transaction.ID] || m.loans[transaction.AccountID] {
+		return true
+	}
+	return transaction.GroupID != nil && m.settlements[*transaction.GroupID]
+}
+
+func monthlyTotals(months []time.Time, transactions []*models.Transaction) []MonthlyTotal {
+	totals := make([]MonthlyTotal, len(months))
+	index := make(map[string]int)
+	for i, month := range months {
+		key := month.Format(MonthFormat)
+		totals[i].Month = key
+		index[key] = i
+	}
+
+	for _, transaction := range transactions {
+		i, exists := index[transaction.TransactionDate.Format(MonthFormat)]
+		if !exists {
+			continue
+		}
+		if transaction.Type == models.Debit {
+			totals[i].Expense += transaction.Value
+		} else {
+			totals[i].Income += transaction.Value
+		}
+	}
+
+	for i := range totals {
+		totals[i].Net = totals[i].Income - totals[i].Expense
+	}
+
+	return totals
+}
+
+func yearOverYear(months []time.Time, current []MonthlyTotal, transactions []*models.Transaction) YearOverYear {
+	previousMonths := make([]time.Time, len(months))
+	for i, month := range months {
+		previousMonths[i] = month.AddDate(-1, 0, 0)
+	}
+	previous := monthlyTotals(previousMonths, transactions)
+
+	result := YearOverYear{Months: make([]YearOverYearMonth, len(months))}
+	for i := range months {
+		result.Months[i] = YearOverYearMonth{
+			Month:           current[i].Month,
+			PreviousMonth:   previous[i].Month,
+			Income:          current[i].Income,
+			Expense:         current[i].Expense,
+			PreviousIncome:  previous[i].Income,
+			PreviousExpense: previous[i].Expense,
+			Change:          percentChange(previous[i].Expense, current[i].Expense),
+		}
+
+		result.Income += current[i].Income
+		result.Expense += current[i].Expense
+		result.PreviousIncome += previous[i].Income
+		result.PreviousExpense += previous[i].Expense
+	}
+	result.Change = percentChange(result.PreviousExpense, result.Expense)
+
+	return result
+}
+
+func cashFlow(opening float64, months []MonthlyTotal) CashFlowStatement {
+	statement := CashFlowStatement{Opening: opening, Closing: opening, Months: make([]CashFlowMonth, len(months))}
+	for i, month := range months {
+		statement.Months[i] = CashFlowMonth{
+			Month:    month.Month,
+			Opening:  statement.Closing,
+			Inflows:  month.Income,
+			Outflows: month.Expense,
+			Closing:  statement.Closing + month.Net,
+		}
+
+		statement.Inflows += month.Income
+		statement.Outflows += month.Expense
+		statement.Closing += month.Net
+	}
+
+	return statement
+}
+
+func percentChange(previous, current float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := (current - previous) / previous * 100
+	return &change
+}
+
+func monthsBetween(from, to time.Time) []time.Time {
+	var months []time.Time
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location()); !month.After(to); month = month.AddDate(0, 1, 0) {
+		months = append(months, month)
+	}
+	return months
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
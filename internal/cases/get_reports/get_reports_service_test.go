@@ -0,0 +1,248 @@
+package get_reports
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestGetReportsService_GetReport(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	categoryRepo := database.NewCategoryInMemoryRepository()
+	splitRepo := database.NewTransactionSplitInMemoryRepository()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	service := NewGetReportsService(transactionRepo, accountRepo, categoryRepo, splitRepo, payeeRepo, database.NewSettlementInMemoryRepository(), database.NewAccountTransferInMemoryRepository())
+
+	projectID := uuid.New()
+	pln := models.NewAccount(projectID, "PLN", money.PLN)
+	eur := models.NewAccount(projectID, "EUR", money.EUR)
+	accountRepo.Create(ctx, pln)
+	accountRepo.Create(ctx, eur)
+
+	groceries := models.NewCategory(projectID, "Groceries")
+	categoryRepo.Create(ctx, groceries)
+
+	shop := models.NewPayee(projectID, "Shop", nil)
+	employer := models.NewPayee(projectID, "Employer", nil)
+	payeeRepo.Create(ctx, shop)
+	payeeRepo.Create(ctx, employer)
+
+	add := func(account *models.Account, date string, transactionType models.TransactionType, value float64, payee *models.Payee, categoryID *uuid.UUID) {
+		transactionDate, _ := time.Parse("2006-01-02", date)
+		data := models.TransactionData{AccountID: account.ID, Value: value, Name: "Transaction", Type: transactionType, TransactionDate: &transactionDate, CategoryID: categoryID}
+		if payee != nil {
+			data.PayeeID = &payee.ID
+		}
+		transactionRepo.Create(ctx, models.NewTransaction(data, uuid.New()))
+	}
+
+	add(pln, "2023-02-10", models.Debit, 50, shop, &groceries.ID)
+	add(pln, "2023-12-31", models.TopUp, 500, employer, nil)
+	add(pln, "2024-01-05", models.TopUp, 1000, employer, nil)
+	add(pln, "2024-01-20", models.Debit, 200, shop, &groceries.ID)
+	add(pln, "2024-02-10", models.Debit, 100, shop, &groceries.ID)
+	add(pln, "2024-02-11", models.Debit, 100, nil, nil)
+	add(eur, "2024-02-12", models.Debit, 30, shop, &groceries.ID)
+	add(pln, "2024-04-01", models.Debit, 999, shop, nil)
+
+	from, _ := time.Parse("2006-01-02", "2024-01-01")
+	to, _ := time.Parse("2006-01-02", "2024-03-31")
+
+	tests := []struct {
+		name          string
+		query         ReportQuery
+		expectError   bool
+		expectIncome  float64
+		expectExpense float64
+		expectOpening float64
+		expectClosing float64
+	}{
+		{
+			name:          "Defaults to the first account currency",
+			query:         ReportQuery{ProjectID: projectID, From: from, To: to},
+			expectIncome:  1000,
+			expectExpense: 400,
+			expectOpening: 450,
+			expectClosing: 1050,
+		},
+		{
+			name:          "Other currency",
+			query:         ReportQuery{ProjectID: projectID, From: from, To: to, Currency: "EUR"},
+			expectExpense: 30,
+			expectClosing: -30,
+		},
+		{
+			name:        "Range ends before it starts",
+			query:       ReportQuery{ProjectID: projectID, From: to, To: from},
+			expectError: true,
+		},
+		{
+			name:        "Unknown currency",
+			query:       ReportQuery{ProjectID: projectID, From: from, To: to, Currency: "XYZ"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := service.GetReport(ctx, tt.query)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if report.Income != tt.expectIncome || report.Expense != tt.expectExpense {
+				t.Errorf("Expected income %v and expense %v, got %v and %v", tt.expectIncome, tt.expectExpense, report.Income, report.Expense)
+			}
+			if report.CashFlow.Opening != tt.expectOpening || report.CashFlow.Closing != tt.expectClosing {
+				t.Errorf("Expected opening %v and closing %v, got %v and %v", tt.expectOpening, tt.expectClosing, report.CashFlow.Opening, report.CashFlow.Closing)
+			}
+			if len(report.IncomeExpense) != 3 || len(report.CashFlow.Months) != 3 {
+				t.Errorf("Expected 3 months, got %d and %d", len(report.IncomeExpense), len(report.CashFlow.Months))
+			}
+		})
+	}
+
+	report, err := service.GetReport(ctx, ReportQuery{ProjectID: projectID, From: from, To: to})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := report.IncomeExpense[1]; got.Month != "2024-02" || got.Expense != 200 || got.Net != -200 {
+		t.Errorf("Expected February expense 200, got %+v", got)
+	}
+
+	if len(report.Categories) != 2 || report.Categories[0].Name != "Groceries" || report.Categories[0].Spent != 300 || report.Categories[0].Share != 75 {
+		t.Errorf("Expected groceries first with 75%% of spending, got %+v", report.Categories)
+	}
+
+	if len(report.TopPayees) != 2 || report.TopPayees[0].Name != "Shop" || report.TopPayees[0].Spent != 300 || report.TopPayees[0].Count != 2 {
+		t.Errorf("Expected shop as the top payee, got %+v", report.TopPayees)
+	}
+
+	february := report.YearOverYear.Months[1]
+	if february.PreviousMonth != "2023-02" || february.PreviousExpense != 50 || february.Change == nil || *february.Change != 300 {
+		t.Errorf("Expected February to compare with 50 spent a year earlier, got %+v", february)
+	}
+	if report.YearOverYear.Months[0].Change != nil {
+		t.Errorf("Expected no change for January with nothing spent a year earlier")
+	}
+
+	if march := report.CashFlow.Months[2]; march.Opening != 1050 || march.Closing != 1050 {
+		t.Errorf("Expected March to carry the balance over, got %+v", march)
+	}
+}
+
+func TestGetReportsService_GetReport_InternalMovements(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	settlementRepo := database.NewSettlementInMemoryRepository()
+	transferRepo := database.NewAccountTransferInMemoryRepository()
+	service := NewGetReportsService(transactionRepo, accountRepo, database.NewCategoryInMemoryRepository(), database.NewTransactionSplitInMemoryRepository(), payeeRepo, settlementRepo, transferRepo)
+
+	projectID := uuid.New()
+	checking := models.NewAccount(projectID, "Checking", money.PLN)
+	savings := models.NewAccount(projectID, "Savings", money.PLN)
+	mortgage := models.NewAccount(projectID, "Mortgage", money.PLN)
+	mortgage.Type = models.AccountLoan
+	accountRepo.Create(ctx, checking)
+	accountRepo.Create(ctx, savings)
+	accountRepo.Create(ctx, mortgage)
+
+	bank := models.NewPayee(projectID, "Bank", nil)
+	payeeRepo.Create(ctx, bank)
+
+	date, _ := time.Parse("2006-01-02", "2024-01-15")
+	add := func(account *models.Account, transactionType models.TransactionType, value float64, payee *models.Payee, groupID ...uuid.UUID) *models.Transaction {
+		data := models.TransactionData{AccountID: account.ID, Value: value, Name: "Transaction", Type: transactionType, TransactionDate: &date}
+		if payee != nil {
+			data.PayeeID = &payee.ID
+		}
+		transaction := models.NewTransaction(data, groupID...)
+		transactionRepo.Create(ctx, transaction)
+		return transaction
+	}
+
+	add(checking, models.TopUp, 1000, nil, uuid.New())
+
+	transfer := uuid.New()
+	transferRepo.Create(ctx, models.NewAccountTransfer(projectID, add(checking, models.Debit, 300, bank, transfer), add(savings, models.TopUp, 300, bank, transfer)))
+
+	add(mortgage, models.Debit, 10000, nil)
+
+	payment := uuid.New()
+	transferRepo.Create(ctx, models.NewAccountTransfer(projectID, add(checking, models.Debit, 500, nil, payment), add(mortgage, models.TopUp, 500, nil, payment)))
+	add(checking, models.Debit, 50, nil, payment)
+
+	settlement := uuid.New()
+	add(checking, models.Debit, 80, nil, settlement)
+	settlementRepo.Create(ctx, models.NewSettlement(projectID, uuid.New(), uuid.New(), 80, money.PLN, &settlement))
+
+	batch := uuid.New()
+	add(checking, models.Debit, 20, nil, batch)
+	add(checking, models.Debit, 20, nil, batch)
+
+	report, err := service.GetReport(ctx, ReportQuery{ProjectID: projectID, From: date, To: date})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Income != 1000 {
+		t.Errorf("Expected only the salary as income, got %v", report.Income)
+	}
+	if report.Expense != 90 {
+		t.Errorf("Expected the interest and the batch of two purchases as expense, got %v", report.Expense)
+	}
+
+	if len(report.TopPayees) != 0 {
+		t.Errorf("Expected the transfer to leave payees out, got %+v", report.TopPayees)
+	}
+
+	// Transfers net to zero, while the loan and the settlement change balances.
+	if report.CashFlow.Inflows != 1000 || report.CashFlow.Outflows != 10170 || report.CashFlow.Closing != -9170 {
+		t.Errorf("Expected inflows 1000, outflows 10170 and closing -9170, got %+v", report.CashFlow)
+	}
+}
+
+func TestGetReportsService_GetReport_UnrecordedEqualAmounts(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	service := NewGetReportsService(transactionRepo, accountRepo, database.NewCategoryInMemoryRepository(), database.NewTransactionSplitInMemoryRepository(), database.NewPayeeInMemoryRepository(), database.NewSettlementInMemoryRepository(), database.NewAccountTransferInMemoryRepository())
+
+	projectID := uuid.New()
+	checking := models.NewAccount(projectID, "Checking", money.PLN)
+	savings := models.NewAccount(projectID, "Savings", money.PLN)
+	accountRepo.Create(ctx, checking)
+	accountRepo.Create(ctx, savings)
+
+	// A salary and the rent entered together share a group and an amount, but
+	// nobody recorded them as a transfer.
+	date, _ := time.Parse("2006-01-02", "2024-01-15")
+	batch := uuid.New()
+	transactionRepo.Create(ctx, models.NewTransaction(models.TransactionData{AccountID: checking.ID, Value: 1500, Name: "Salary", Type: models.TopUp, TransactionDate: &date}, batch))
+	transactionRepo.Create(ctx, models.NewTransaction(models.TransactionData{AccountID: savings.ID, Value: 1500, Name: "Rent", Type: models.Debit, TransactionDate: &date}, batch))
+
+	report, err := service.GetReport(ctx, ReportQuery{ProjectID: projectID, From: date, To: date})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if report.Income != 1500 || report.Expense != 1500 {
+		t.Errorf("Expected the salary as income and the rent as expense, got %v and %v", report.Income, report.Expense)
+	}
+}
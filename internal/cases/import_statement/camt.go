@@ -0,0 +1,190 @@
+package import_statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The structs below cover the parts of camt.053 read on import. Elements are
+// matched by local name, so every version of the namespace is accepted, and
+// both the older flat party names and the Pty wrapper of later versions are
+// read.
+
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	IBAN     string      `xml:"Acct>Id>IBAN"`
+	Other    string      `xml:"Acct>Id>Othr>Id"`
+	Currency string      `xml:"Acct>Ccy"`
+	Entries  []camtEntry `xml:"Ntry"`
+}
+
+type camtEntry struct {
+	Reference      string            `xml:"NtryRef"`
+	Amount         camtAmount        `xml:"Amt"`
+	CreditDebit    string            `xml:"CdtDbtInd"`
+	Status         camtStatus        `xml:"Sts"`
+	BookingDate    camtDate          `xml:"BookgDt"`
+	ValueDate      camtDate          `xml:"ValDt"`
+	ServicerRef    string            `xml:"AcctSvcrRef"`
+	AdditionalInfo string            `xml:"AddtlNtryInf"`
+	Details        []camtTransaction `xml:"NtryDtls>TxDtls"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type camtTransaction struct {
+	ServicerRef    string   `xml:"Refs>AcctSvcrRef"`
+	EndToEndID     string   `xml:"Refs>EndToEndId"`
+	Debtor         string   `xml:"RltdPties>Dbtr>Nm"`
+	DebtorParty    string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+	Creditor       string   `xml:"RltdPties>Cdtr>Nm"`
+	CreditorParty  string   `xml:"RltdPties>Cdtr>Pty>Nm"`
+	Remittance     []string `xml:"RmtInf>Ustrd"`
+	AdditionalInfo string   `xml:"AddtlTxInf"`
+}
+
+const (
+	camtBooked = "BOOK"
+	camtDebit  = "DBIT"
+
+	// camtNotProvided is what banks put in a reference they were not given.
+	camtNotProvided = "NOTPROVIDED"
+)
+
+// ParseCAMT053 reads the statements of an ISO 20022 camt.053 file. Pending
+// and informational entries are left out; only booked ones count.
+func ParseCAMT053(data []byte) ([]Statement, error) {
+	var document camtDocument
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid CAMT.053 file: %w", err)
+	}
+
+	if len(document.Statements) == 0 {
+		return nil, fmt.Errorf("no statement found in CAMT.053 file")
+	}
+
+	statements := make([]Statement, 0, len(document.Statements))
+	for _, camt := range document.Statements {
+		statement := Statement{
+			AccountNumber: camt.IBAN,
+			Currency:      strings.ToUpper(camt.Currency),
+		}
+		if statement.AccountNumber == "" {
+			statement.AccountNumber = camt.Other
+		}
+
+		for _, entry := range camt.Entries {
+			status := strings.TrimSpace(entry.Status.Code + entry.Status.Text)
+			if status != "" && status != camtBooked {
+				continue
+			}
+
+			parsed, err := parseCAMTEntry(entry)
+			if err != nil {
+				return nil, err
+			}
+			statement.Entries = append(statement.Entries, parsed)
+
+			if statement.Currency == "" {
+				statement.Currency = strings.ToUpper(entry.Amount.Currency)
+			}
+		}
+
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
+
+func parseCAMTEntry(entry camtEntry) (StatementEntry, error) {
+	var details camtTransaction
+	if len(entry.Details) > 0 {
+		details = entry.Details[0]
+	}
+
+	externalID := firstProvided(entry.ServicerRef, details.ServicerRef, entry.Reference, details.EndToEndID)
+
+	amount, err := parseStatementAmount(entry.Amount.Value)
+	if err != nil {
+		return StatementEntry{}, fmt.Errorf("entry %s: %w", externalID, err)
+	}
+	if entry.CreditDebit == camtDebit {
+		amount = -amount
+	}
+
+	date, err := entry.BookingDate.parse()
+	if err != nil {
+		date, err = entry.ValueDate.parse()
+	}
+	if err != nil {
+		return StatementEntry{}, fmt.Errorf("entry %s: %w", externalID, err)
+	}
+
+	// The other party is the creditor of money going out and the debtor of
+	// money coming in.
+	counterparty := firstProvided(details.Debtor, details.DebtorParty)
+	if amount < 0 {
+		counterparty = firstProvided(details.Creditor, details.CreditorParty)
+	}
+
+	remittance := strings.TrimSpace(strings.Join(details.Remittance, " "))
+	description := firstProvided(remittance, details.AdditionalInfo, entry.AdditionalInfo)
+
+	name, memo := counterparty, description
+	if name == "" {
+		name, memo = description, ""
+	}
+
+	return StatementEntry{
+		ExternalID: externalID,
+		Date:       date,
+		Amount:     amount,
+		Name:       name,
+		Memo:       memo,
+	}, nil
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	switch {
+	case d.DateTime != "":
+		parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(d.DateTime))
+		if err != nil {
+			// ISODateTime may come without a zone.
+			parsed, err = time.Parse("2006-01-02T15:04:05", strings.TrimSpace(d.DateTime))
+		}
+		return parsed, err
+	case d.Date != "":
+		return time.Parse(time.DateOnly, strings.TrimSpace(d.Date))
+	default:
+		return time.Time{}, fmt.Errorf("missing booking date")
+	}
+}
+
+// firstProvided returns the first value that is set and is not a placeholder.
+func firstProvided(values ...string) string {
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && value != camtNotProvided {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,324 @@
+package import_statement
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/create_transaction"
+	"gofin/internal/cases/match_payee"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+const (
+	// defaultEntryName names entries the bank sent without any description.
+	defaultEntryName = "Bank transaction"
+
+	SkipAlreadyImported = "already imported"
+	SkipClosedPeriod    = "in a closed period"
+	SkipZeroAmount      = "zero amount"
+	SkipRepeated        = "repeated in the file"
+)
+
+type ImportStatementService struct {
+	transactionRepo      models.TransactionRepository
+	accountRepo          models.AccountRepository
+	projectRepo          models.ProjectRepository
+	createTransactionSvc *create_transaction.CreateTransactionService
+	matchPayeeSvc        *match_payee.MatchPayeeService
+}
+
+func NewImportStatementService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, projectRepo models.ProjectRepository, categoryRepo models.CategoryRepository, splitRepo models.TransactionSplitRepository, payeeRepo models.PayeeRepository) *ImportStatementService {
+	return &ImportStatementService{
+		transactionRepo:      transactionRepo,
+		accountRepo:          accountRepo,
+		projectRepo:          projectRepo,
+		createTransactionSvc: create_transaction.NewCreateTransactionService(transactionRepo, accountRepo, projectRepo, categoryRepo, splitRepo, payeeRepo),
+		matchPayeeSvc:        match_payee.NewMatchPayeeService(payeeRepo, transactionRepo, projectRepo),
+	}
+}
+
+// ImportPreview is what importing a statement file would add, for the user
+// to review before confirming.
+type ImportPreview struct {
+	Statements []StatementPreview
+}
+
+// StatementPreview is one statement of the file mapped to a project account.
+type StatementPreview struct {
+	AccountNumber string
+	Currency      string
+	Account       *models.Account
+	Rows          []PreviewRow
+}
+
+// PreviewRow is an entry as it would be created, with its payee and the
+// payee's default category filled in. Rows with a SkipReason are not imported.
+type PreviewRow struct {
+	Entry      StatementEntry
+	Data       models.TransactionData
+	PayeeName  string
+	SkipReason string
+}
+
+// ImportResult counts what an import created and what it left out because it
+// was imported before.
+type ImportResult struct {
+	Created []*models.Transaction
+	Skipped int
+}
+
+// NewRows counts the rows that would be imported.
+func (p *ImportPreview) NewRows() int {
+	count := 0
+	for _, statement := range p.Statements {
+		for _, row := range statement.Rows {
+			if row.SkipReason == "" {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// TransactionData returns the rows that would be imported, as they are.
+func (p *ImportPreview) TransactionData() []models.TransactionData {
+	var data []models.TransactionData
+	for _, statement := range p.Statements {
+		for _, row := range statement.Rows {
+			if row.SkipReason == "" {
+				data = append(data, row.Data)
+			}
+		}
+	}
+	return data
+}
+
+// Preview parses a statement file and maps each of its statements to an
+// account: accountID when given, which only works for a single statement,
+// otherwise the active account whose name or description holds the
+// statement's account number, otherwise the only active account in the
+// statement's currency.
+func (s *ImportStatementService) Preview(ctx context.Context, projectID uuid.UUID, data []byte, accountID *uuid.UUID) (*ImportPreview, error) {
+	statements, err := ParseStatements(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if accountID != nil && len(statements) > 1 {
+		return nil, fmt.Errorf("the file holds %d statements, leave the account empty to match them by account number", len(statements))
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	accounts, err := s.accountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	matcher, err := s.matchPayeeSvc.LoadMatcher(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &ImportPreview{}
+	for _, statement := range statements {
+		account, err := mapAccount(statement, models.ActiveAccounts(accounts), accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		imported, err := s.importedIDs(ctx, account.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		statementPreview := StatementPreview{
+			AccountNumber: statement.AccountNumber,
+			Currency:      statement.Currency,
+			Account:       account,
+		}
+
+		seen := make(map[string]bool, len(statement.Entries))
+		for _, entry := range statement.Entries {
+			row, err := newPreviewRow(entry, account, matcher)
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case imported[entry.ExternalID]:
+				row.SkipReason = SkipAlreadyImported
+			case seen[entry.ExternalID]:
+				row.SkipReason = SkipRepeated
+			case row.Data.Value == 0:
+				row.SkipReason = SkipZeroAmount
+			case project.EnsurePeriodOpen(entry.Date) != nil:
+				row.SkipReason = SkipClosedPeriod
+			}
+			seen[entry.ExternalID] = true
+
+			statementPreview.Rows = append(statementPreview.Rows, row)
+		}
+
+		preview.Statements = append(preview.Statements, statementPreview)
+	}
+
+	return preview, nil
+}
+
+// Import creates the reviewed transactions in one group, leaving out those
+// whose external ID was imported before into the same account, so confirming
+// a preview twice or importing overlapping statements adds nothing twice.
+func (s *ImportStatementService) Import(ctx context.Context, projectID uuid.UUID, transactions []models.TransactionData) (*ImportResult, error) {
+	result := &ImportResult{}
+
+	importedByAccount := make(map[uuid.UUID]map[string]bool)
+	var toCreate []models.TransactionData
+	for _, txData := range transactions {
+		if txData.ExternalID == "" {
+			return nil, fmt.Errorf("transaction '%s' has no bank transaction ID", txData.Name)
+		}
+
+		imported, ok := importedByAccount[txData.AccountID]
+		if !ok {
+			var err error
+			imported, err = s.importedIDs(ctx, txData.AccountID)
+			if err != nil {
+				return nil, err
+			}
+			importedByAccount[txData.AccountID] = imported
+		}
+
+		if imported[txData.ExternalID] {
+			result.Skipped++
+			continue
+		}
+		imported[txData.ExternalID] = true
+
+		toCreate = append(toCreate, txData)
+	}
+
+	if len(toCreate) > 0 {
+		created, err := s.createTransactionSvc.CreateGroupedTransactions(ctx, projectID, toCreate)
+		if err != nil {
+			return nil, err
+		}
+		result.Created = created
+	}
+
+	logging.FromContext(ctx).Info("statement imported",
+		slog.String("project_id", projectID.String()),
+		slog.Int("created", len(result.Created)),
+		slog.Int("skipped", result.Skipped),
+	)
+
+	return result, nil
+}
+
+func (s *ImportStatementService) importedIDs(ctx context.Context, accountID uuid.UUID) (map[string]bool, error) {
+	externalIDs, err := s.transactionRepo.GetExternalIDs(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get imported transactions: %w", err)
+	}
+
+	imported := make(map[string]bool, len(externalIDs))
+	for _, externalID := range externalIDs {
+		imported[externalID] = true
+	}
+	return imported, nil
+}
+
+func newPreviewRow(entry StatementEntry, account *models.Account, matcher *match_payee.PayeeMatcher) (PreviewRow, error) {
+	transactionType := models.TopUp
+	if entry.Amount < 0 {
+		transactionType = models.Debit
+	}
+
+	name := entry.Name
+	if name == "" {
+		name = defaultEntryName
+	}
+
+	notes := entry.Memo
+	if len(notes) > models.MaxNotesLength {
+		notes = strings.ToValidUTF8(notes[:models.MaxNotesLength], "")
+	}
+
+	date := entry.Date
+	data, err := matcher.Apply(models.TransactionData{
+		AccountID:       account.ID,
+		Value:           math.Round(math.Abs(entry.Amount)*100) / 100,
+		Name:            name,
+		Type:            transactionType,
+		TransactionDate: &date,
+		Notes:           notes,
+		ExternalID:      entry.ExternalID,
+	})
+	if err != nil {
+		return PreviewRow{}, err
+	}
+
+	row := PreviewRow{Entry: entry, Data: data}
+	if payee := matcher.Match(name); payee != nil {
+		row.PayeeName = payee.Name
+	}
+
+	return row, nil
+}
+
+// mapAccount picks the account a statement is imported into. An account
+// picked by the user must be active and in the statement's currency.
+func mapAccount(statement Statement, accounts []*models.Account, accountID *uuid.UUID) (*models.Account, error) {
+	if accountID != nil {
+		for _, account := range accounts {
+			if account.ID != *accountID {
+				continue
+			}
+			if statement.Currency != "" && statement.Currency != account.Currency.String() {
+				return nil, fmt.Errorf("statement is in %s but account '%s' is in %s", statement.Currency, account.Name, account.Currency)
+			}
+			return account, nil
+		}
+		return nil, fmt.Errorf("account not found or archived")
+	}
+
+	if number := normalizeAccountNumber(statement.AccountNumber); number != "" {
+		for _, account := range accounts {
+			if strings.Contains(normalizeAccountNumber(account.Name+" "+account.Description), number) {
+				return account, nil
+			}
+		}
+	}
+
+	var inCurrency []*models.Account
+	for _, account := range accounts {
+		if account.Currency.String() == statement.Currency {
+			inCurrency = append(inCurrency, account)
+		}
+	}
+	if len(inCurrency) == 1 {
+		return inCurrency[0], nil
+	}
+
+	return nil, fmt.Errorf("no account matches statement %s in %s, pick the account to import into or put the account number in its description", statement.AccountNumber, statement.Currency)
+}
+
+// normalizeAccountNumber drops spaces, dashes and letter case so IBANs match
+// however they are written.
+func normalizeAccountNumber(number string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, number)
+}
@@ -0,0 +1,213 @@
+package import_statement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+type testRepos struct {
+	transactions *database.TransactionInMemoryRepository
+	accounts     *database.AccountInMemoryRepository
+	projects     models.ProjectRepository
+	categories   *database.CategoryInMemoryRepository
+	payees       *database.PayeeInMemoryRepository
+}
+
+func newTestService() (*ImportStatementService, testRepos) {
+	repos := testRepos{
+		transactions: database.NewTransactionInMemoryRepository(),
+		accounts:     database.NewAccountInMemoryRepository(),
+		projects:     database.NewProjectInMemoryRepository(),
+		categories:   database.NewCategoryInMemoryRepository(),
+		payees:       database.NewPayeeInMemoryRepository(),
+	}
+	service := NewImportStatementService(repos.transactions, repos.accounts, repos.projects, repos.categories, database.NewTransactionSplitInMemoryRepository(), repos.payees)
+	return service, repos
+}
+
+func TestImportStatementService_Preview(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		setup         func(repos testRepos, project *models.Project) (*uuid.UUID, uuid.UUID)
+		expectSkips   []string
+		expectError   bool
+		expectPayee   string
+		expectDefault bool
+	}{
+		{
+			name: "Matched by account number in description",
+			setup: func(repos testRepos, project *models.Project) (*uuid.UUID, uuid.UUID) {
+				other := models.NewAccount(project.ID, "Cash", money.PLN)
+				main := models.NewAccount(project.ID, "Main", money.PLN)
+				main.Description = "IBAN PL61 1090 1014 0000 0712 1981 2874"
+				repos.accounts.Create(ctx, other)
+				repos.accounts.Create(ctx, main)
+				return nil, main.ID
+			},
+			expectSkips: []string{"", "", ""},
+		},
+		{
+			name: "Only account in the currency",
+			setup: func(repos testRepos, project *models.Project) (*uuid.UUID, uuid.UUID) {
+				main := models.NewAccount(project.ID, "Main", money.PLN)
+				repos.accounts.Create(ctx, main)
+				repos.accounts.Create(ctx, models.NewAccount(project.ID, "Travel", money.EUR))
+				return nil, main.ID
+			},
+			expectSkips: []string{"", "", ""},
+		},
+		{
+			name: "No account matches",
+			setup: func(repos testRepos, project *models.Project) (*uuid.UUID, uuid.UUID) {
+				repos.accounts.Create(ctx, models.NewAccount(project.ID, "Main", money.PLN))
+				repos.accounts.Create(ctx, models.NewAccount(project.ID, "Savings", money.PLN))
+				return nil, uuid.Nil
+			},
+			expectError: true,
+		},
+		{
+			name: "Picked account in another currency",
+			setup: func(repos testRepos, project *models.Project) (*uuid.UUID, uuid.UUID) {
+				travel := models.NewAccount(project.ID, "Travel", money.EUR)
+				repos.accounts.Create(ctx, travel)
+				return &travel.ID, travel.ID
+			},
+			expectError: true,
+		},
+		{
+			name: "Already imported and closed period entries are skipped",
+			setup: func(repos testRepos, project *models.Project) (*uuid.UUID, uuid.UUID) {
+				main := models.NewAccount(project.ID, "Main", money.PLN)
+				repos.accounts.Create(ctx, main)
+
+				date := time.Date(2024, time.May, 4, 0, 0, 0, 0, time.UTC)
+				repos.transactions.Create(ctx, models.NewTransaction(models.TransactionData{AccountID: main.ID, Value: 300, Name: "Rent", Type: models.TopUp, TransactionDate: &date, ExternalID: "REF-002"}))
+
+				lockedUntil := time.Date(2024, time.May, 3, 0, 0, 0, 0, time.UTC)
+				project.LockedUntil = &lockedUntil
+				return &main.ID, main.ID
+			},
+			expectSkips: []string{SkipClosedPeriod, SkipAlreadyImported, ""},
+		},
+		{
+			name: "Payee and its default category are matched",
+			setup: func(repos testRepos, project *models.Project) (*uuid.UUID, uuid.UUID) {
+				main := models.NewAccount(project.ID, "Main", money.PLN)
+				repos.accounts.Create(ctx, main)
+
+				bills := models.NewCategory(project.ID, "Bills")
+				repos.categories.Create(ctx, bills)
+				energy := models.NewPayee(project.ID, "Energy", &bills.ID)
+				repos.payees.Create(ctx, energy)
+				repos.payees.AddAlias(ctx, models.NewPayeeAlias(energy, "energy ltd"))
+				return &main.ID, main.ID
+			},
+			expectSkips:   []string{"", "", ""},
+			expectPayee:   "Energy",
+			expectDefault: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repos := newTestService()
+			project := models.NewProject("Home", "home")
+			accountID, expectAccountID := tt.setup(repos, project)
+			repos.projects.Create(ctx, project)
+
+			preview, err := service.Preview(ctx, project.ID, []byte(camt053), accountID)
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			statement := preview.Statements[0]
+			if statement.Account.ID != expectAccountID {
+				t.Errorf("expected account %s, got %s", expectAccountID, statement.Account.ID)
+			}
+
+			if len(statement.Rows) != len(tt.expectSkips) {
+				t.Fatalf("expected %d rows, got %d", len(tt.expectSkips), len(statement.Rows))
+			}
+			for i, reason := range tt.expectSkips {
+				if statement.Rows[i].SkipReason != reason {
+					t.Errorf("row %d: expected skip reason %q, got %q", i, reason, statement.Rows[i].SkipReason)
+				}
+			}
+
+			energy := statement.Rows[0]
+			if energy.Data.Type != models.Debit || energy.Data.Value != 120 || energy.Data.ExternalID != "REF-001" {
+				t.Errorf("expected a 120 debit with ID REF-001, got %+v", energy.Data)
+			}
+			if energy.PayeeName != tt.expectPayee {
+				t.Errorf("expected payee %q, got %q", tt.expectPayee, energy.PayeeName)
+			}
+			if (energy.Data.CategoryID != nil) != tt.expectDefault {
+				t.Errorf("expected default category %v, got %v", tt.expectDefault, energy.Data.CategoryID)
+			}
+		})
+	}
+}
+
+func TestImportStatementService_Import(t *testing.T) {
+	ctx := context.Background()
+	service, repos := newTestService()
+
+	project := models.NewProject("Home", "home")
+	repos.projects.Create(ctx, project)
+	main := models.NewAccount(project.ID, "Main", money.PLN)
+	repos.accounts.Create(ctx, main)
+
+	preview, err := service.Preview(ctx, project.ID, []byte(camt053), &main.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := service.Import(ctx, project.ID, preview.TransactionData())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Created) != 3 || result.Skipped != 0 {
+		t.Fatalf("expected 3 created and none skipped, got %d and %d", len(result.Created), result.Skipped)
+	}
+
+	again, err := service.Import(ctx, project.ID, preview.TransactionData())
+	if err != nil {
+		t.Fatalf("unexpected error on re-import: %v", err)
+	}
+	if len(again.Created) != 0 || again.Skipped != 3 {
+		t.Errorf("expected re-import to skip all 3, got %d created and %d skipped", len(again.Created), again.Skipped)
+	}
+
+	reviewed, err := service.Preview(ctx, project.ID, []byte(camt053), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reviewed.NewRows() != 0 {
+		t.Errorf("expected nothing new after import, got %d rows", reviewed.NewRows())
+	}
+
+	transactions, _ := repos.transactions.GetByAccountID(ctx, main.ID)
+	if len(transactions) != 3 {
+		t.Errorf("expected 3 transactions on the account, got %d", len(transactions))
+	}
+
+	if _, err := service.Import(ctx, project.ID, []models.TransactionData{{AccountID: main.ID, Value: 1, Name: "Manual", Type: models.Debit}}); err == nil {
+		t.Error("expected error for a transaction without a bank ID")
+	}
+}
@@ -0,0 +1,185 @@
+package import_statement
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ofxElement is a node of an OFX document. Leaves have a value, aggregates
+// have children.
+type ofxElement struct {
+	name     string
+	value    string
+	children []*ofxElement
+}
+
+// ParseOFX reads the bank and credit card statements of an OFX or QFX file.
+// Both OFX 1.x, which is SGML with unclosed leaf tags, and the XML of OFX 2.x
+// are accepted.
+func ParseOFX(data []byte) ([]Statement, error) {
+	root, err := parseOFXTree(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var statements []Statement
+	for _, response := range root.all("STMTRS", "CCSTMTRS") {
+		statement := Statement{
+			AccountNumber: response.find("ACCTID"),
+			Currency:      strings.ToUpper(response.find("CURDEF")),
+		}
+
+		for _, transaction := range response.all("STMTTRN") {
+			entry, err := parseOFXTransaction(transaction)
+			if err != nil {
+				return nil, err
+			}
+			statement.Entries = append(statement.Entries, entry)
+		}
+
+		statements = append(statements, statement)
+	}
+
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no bank or credit card statement found in OFX file")
+	}
+
+	return statements, nil
+}
+
+func parseOFXTransaction(transaction *ofxElement) (StatementEntry, error) {
+	fitID := transaction.find("FITID")
+
+	amount, err := parseStatementAmount(transaction.find("TRNAMT"))
+	if err != nil {
+		return StatementEntry{}, fmt.Errorf("transaction %s: %w", fitID, err)
+	}
+
+	date, err := parseOFXDate(transaction.find("DTPOSTED"))
+	if err != nil {
+		return StatementEntry{}, fmt.Errorf("transaction %s: %w", fitID, err)
+	}
+
+	name := transaction.find("NAME")
+	memo := transaction.find("MEMO")
+	if name == "" {
+		name, memo = memo, ""
+	}
+
+	return StatementEntry{
+		ExternalID: fitID,
+		Date:       date,
+		Amount:     amount,
+		Name:       name,
+		Memo:       memo,
+	}, nil
+}
+
+// parseOFXDate reads the date and time of an OFX timestamp such as
+// 20240115120000.000[-5:EST], keeping the bank's wall clock.
+func parseOFXDate(value string) (time.Time, error) {
+	digits := value
+	if i := strings.IndexFunc(value, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		digits = value[:i]
+	}
+
+	switch {
+	case len(digits) >= 14:
+		return time.Parse("20060102150405", digits[:14])
+	case len(digits) >= 8:
+		return time.Parse("20060102", digits[:8])
+	default:
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+}
+
+// parseOFXTree builds the element tree. A tag followed by text is a leaf and
+// needs no closing tag; any other tag opens an aggregate that lasts until its
+// closing tag.
+func parseOFXTree(data []byte) (*ofxElement, error) {
+	start := bytes.IndexByte(data, '<')
+	if start < 0 {
+		return nil, fmt.Errorf("OFX file has no content")
+	}
+	body := string(data[start:])
+
+	root := &ofxElement{}
+	stack := []*ofxElement{root}
+
+	for len(body) > 0 {
+		open := strings.IndexByte(body, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated tag in OFX file")
+		}
+		tag := strings.TrimSpace(body[open+1 : open+end])
+		body = body[open+end+1:]
+
+		text := body
+		if next := strings.IndexByte(body, '<'); next >= 0 {
+			text = body[:next]
+		}
+		text = strings.TrimSpace(text)
+
+		switch {
+		case tag == "" || tag[0] == '?' || tag[0] == '!' || strings.HasSuffix(tag, "/"):
+			continue
+		case tag[0] == '/':
+			name := strings.ToUpper(strings.TrimSpace(tag[1:]))
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+			continue
+		}
+
+		name := strings.ToUpper(strings.Fields(tag)[0])
+		element := &ofxElement{name: name}
+		parent := stack[len(stack)-1]
+		parent.children = append(parent.children, element)
+
+		if text != "" {
+			element.value = html.UnescapeString(text)
+		} else {
+			stack = append(stack, element)
+		}
+	}
+
+	return root, nil
+}
+
+// find returns the value of the first leaf named name below e.
+func (e *ofxElement) find(name string) string {
+	for _, child := range e.children {
+		if child.name == name && child.value != "" {
+			return child.value
+		}
+		if value := child.find(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// all returns the elements below e named any of names, without looking
+// inside the ones found.
+func (e *ofxElement) all(names ...string) []*ofxElement {
+	var found []*ofxElement
+	for _, child := range e.children {
+		if slices.Contains(names, child.name) {
+			found = append(found, child)
+		} else {
+			found = append(found, child.all(names...)...)
+		}
+	}
+	return found
+}
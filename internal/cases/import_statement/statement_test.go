@@ -0,0 +1,251 @@
+package import_statement
+
+import (
+	"testing"
+	"time"
+)
+
+const ofxSGML = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+ENCODING:USASCII
+CHARSET:1252
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20240601</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<STMTRS>
+<CURDEF>PLN
+<BANKACCTFROM>
+<BANKID>10901014
+<ACCTID>PL61 1090 1014 0000 0712 1981 2874
+<ACCTTYPE>CHECKING
+</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20240501
+<DTEND>20240531
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240510120000.000[+2:CEST]
+<TRNAMT>-45,90
+<FITID>2024051001
+<NAME>Biedronka &amp; Co
+<MEMO>Card payment
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240515
+<TRNAMT>5000.00
+<FITID>2024051501
+<MEMO>Salary May
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const ofxXML = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CURDEF>EUR</CURDEF>
+        <CCACCTFROM><ACCTID>4111111111111111</ACCTID></CCACCTFROM>
+        <BANKTRANLIST>
+          <STMTTRN>
+            <TRNTYPE>DEBIT</TRNTYPE>
+            <DTPOSTED>20240602</DTPOSTED>
+            <TRNAMT>-12.50</TRNAMT>
+            <FITID>CC-1</FITID>
+            <PAYEE><NAME>Bookshop</NAME></PAYEE>
+            <MEMO></MEMO>
+          </STMTTRN>
+        </BANKTRANLIST>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+const camt053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>STMT-1</MsgId></GrpHdr>
+    <Stmt>
+      <Id>2024-05</Id>
+      <Acct><Id><IBAN>PL61109010140000071219812874</IBAN></Id><Ccy>PLN</Ccy></Acct>
+      <Ntry>
+        <Amt Ccy="PLN">120.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2024-05-03</Dt></BookgDt>
+        <AcctSvcrRef>REF-001</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>NOTPROVIDED</EndToEndId></Refs>
+          <RltdPties><Cdtr><Pty><Nm>Energy Ltd</Nm></Pty></Cdtr></RltdPties>
+          <RmtInf><Ustrd>Invoice 5/2024</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="PLN">300.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2024-05-04T09:30:00+02:00</DtTm></BookgDt>
+        <NtryDtls><TxDtls>
+          <Refs><AcctSvcrRef>REF-002</AcctSvcrRef></Refs>
+          <RltdPties><Dbtr><Nm>Jan Kowalski</Nm></Dbtr></RltdPties>
+          <RmtInf><Ustrd>Rent</Ustrd><Ustrd>share</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="PLN">10.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2024-05-05</Dt></BookgDt>
+        <AcctSvcrRef>REF-003</AcctSvcrRef>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="PLN">9.99</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2024-05-06</Dt></BookgDt>
+        <AddtlNtryInf>Account fee</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+func TestParseStatements(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          string
+		expectAccount string
+		expectCurr    string
+		expectEntries []StatementEntry
+		expectError   bool
+	}{
+		{
+			name:          "OFX 1.x SGML",
+			data:          ofxSGML,
+			expectAccount: "PL61 1090 1014 0000 0712 1981 2874",
+			expectCurr:    "PLN",
+			expectEntries: []StatementEntry{
+				{ExternalID: "2024051001", Date: time.Date(2024, time.May, 10, 12, 0, 0, 0, time.UTC), Amount: -45.90, Name: "Biedronka & Co", Memo: "Card payment"},
+				{ExternalID: "2024051501", Date: time.Date(2024, time.May, 15, 0, 0, 0, 0, time.UTC), Amount: 5000, Name: "Salary May"},
+			},
+		},
+		{
+			name:          "OFX 2.x XML credit card",
+			data:          ofxXML,
+			expectAccount: "4111111111111111",
+			expectCurr:    "EUR",
+			expectEntries: []StatementEntry{
+				{ExternalID: "CC-1", Date: time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC), Amount: -12.50, Name: "Bookshop"},
+			},
+		},
+		{
+			name:          "CAMT.053 skips pending entries",
+			data:          camt053,
+			expectAccount: "PL61109010140000071219812874",
+			expectCurr:    "PLN",
+			expectEntries: []StatementEntry{
+				{ExternalID: "REF-001", Date: time.Date(2024, time.May, 3, 0, 0, 0, 0, time.UTC), Amount: -120, Name: "Energy Ltd", Memo: "Invoice 5/2024"},
+				{ExternalID: "REF-002", Date: time.Date(2024, time.May, 4, 9, 30, 0, 0, time.FixedZone("", 2*60*60)), Amount: 300, Name: "Jan Kowalski", Memo: "Rent share"},
+				{Date: time.Date(2024, time.May, 6, 0, 0, 0, 0, time.UTC), Amount: -9.99, Name: "Account fee"},
+			},
+		},
+		{
+			name:        "Unknown format",
+			data:        "Date;Amount\n2024-05-01;10\n",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := ParseStatements([]byte(tt.data))
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(statements) != 1 {
+				t.Fatalf("expected 1 statement, got %d", len(statements))
+			}
+
+			statement := statements[0]
+			if statement.AccountNumber != tt.expectAccount {
+				t.Errorf("expected account %q, got %q", tt.expectAccount, statement.AccountNumber)
+			}
+			if statement.Currency != tt.expectCurr {
+				t.Errorf("expected currency %s, got %s", tt.expectCurr, statement.Currency)
+			}
+
+			if len(statement.Entries) != len(tt.expectEntries) {
+				t.Fatalf("expected %d entries, got %d: %+v", len(tt.expectEntries), len(statement.Entries), statement.Entries)
+			}
+
+			for i, expected := range tt.expectEntries {
+				entry := statement.Entries[i]
+				if expected.ExternalID != "" && entry.ExternalID != expected.ExternalID {
+					t.Errorf("entry %d: expected ID %s, got %s", i, expected.ExternalID, entry.ExternalID)
+				}
+				if entry.ExternalID == "" {
+					t.Errorf("entry %d: expected an ID to be assigned", i)
+				}
+				if !entry.Date.Equal(expected.Date) {
+					t.Errorf("entry %d: expected date %v, got %v", i, expected.Date, entry.Date)
+				}
+				if entry.Amount != expected.Amount {
+					t.Errorf("entry %d: expected amount %.2f, got %.2f", i, expected.Amount, entry.Amount)
+				}
+				if entry.Name != expected.Name || entry.Memo != expected.Memo {
+					t.Errorf("entry %d: expected %q / %q, got %q / %q", i, expected.Name, expected.Memo, entry.Name, entry.Memo)
+				}
+			}
+		})
+	}
+}
+
+func TestParseStatements_DerivedIDsAreStable(t *testing.T) {
+	data := []byte(`<?xml version="1.0"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"><BkToCstmrStmt><Stmt>
+<Acct><Id><IBAN>PL00</IBAN></Id></Acct>
+<Ntry><Amt Ccy="PLN">5.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><BookgDt><Dt>2024-05-06</Dt></BookgDt><AddtlNtryInf>Coffee</AddtlNtryInf></Ntry>
+<Ntry><Amt Ccy="PLN">5.00</Amt><CdtDbtInd>DBIT</CdtDbtInd><BookgDt><Dt>2024-05-06</Dt></BookgDt><AddtlNtryInf>Coffee</AddtlNtryInf></Ntry>
+</Stmt></BkToCstmrStmt></Document>`)
+
+	first, err := ParseStatements(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := ParseStatements(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries := first[0].Entries
+	if entries[0].ExternalID == entries[1].ExternalID {
+		t.Error("expected equal entries on one day to get different IDs")
+	}
+	if first[0].Currency != "PLN" {
+		t.Errorf("expected currency taken from the amounts, got %q", first[0].Currency)
+	}
+	for i := range entries {
+		if entries[i].ExternalID != second[0].Entries[i].ExternalID {
+			t.Errorf("entry %d: expected the same ID on every parse", i)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package match_payee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type MatchPayeeService struct {
+	payeeRepo       models.PayeeRepository
+	transactionRepo models.TransactionRepository
+	projectRepo     models.ProjectRepository
+}
+
+func NewMatchPayeeService(payeeRepo models.PayeeRepository, transactionRepo models.TransactionRepository, projectRepo models.ProjectRepository) *MatchPayeeService {
+	return &MatchPayeeService{
+		payeeRepo:       payeeRepo,
+		transactionRepo: transactionRepo,
+		projectRepo:     projectRepo,
+	}
+}
+
+// PayeeMatcher holds a project's payees and aliases so a batch of transactions,
+// such as a form submission or an imported statement, is matched with one load.
+type PayeeMatcher struct {
+	payees  map[uuid.UUID]*models.Payee
+	aliases []*models.PayeeAlias
+}
+
+func (s *MatchPayeeService) LoadMatcher(ctx context.Context, projectID uuid.UUID) (*PayeeMatcher, error) {
+	payees, err := s.payeeRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project payees: %w", err)
+	}
+
+	aliases, err := s.payeeRepo.GetAliasesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payee aliases: %w", err)
+	}
+
+	matcher := &PayeeMatcher{
+		payees:  make(map[uuid.UUID]*models.Payee, len(payees)),
+		aliases: aliases,
+	}
+	for _, payee := range payees {
+		matcher.payees[payee.ID] = payee
+	}
+
+	return matcher, nil
+}
+
+// Match returns the payee a transaction name resolves to, or nil.
+func (m *PayeeMatcher) Match(name string) *models.Payee {
+	payeeID := models.MatchPayee(name, m.aliases)
+	if payeeID == nil {
+		return nil
+	}
+	return m.payees[*payeeID]
+}
+
+// Apply links data to a payee and fills in the payee's default category. An
+// explicit PayeeID must belong to the project; otherwise the name is matched.
+// The default category is only used when the transaction has no category or
+// splits of its own.
+func (m *PayeeMatcher) Apply(data models.TransactionData) (models.TransactionData, error) {
+	var payee *models.Payee
+	if data.PayeeID != nil {
+		var exists bool
+		payee, exists = m.payees[*data.PayeeID]
+		if !exists {
+			return data, fmt.Errorf("payee not found")
+		}
+	} else {
+		payee = m.Match(data.Name)
+	}
+
+	if payee == nil {
+		return data, nil
+	}
+
+	payeeID := payee.ID
+	data.PayeeID = &payeeID
+
+	if data.CategoryID == nil && len(data.Splits) == 0 && payee.DefaultCategoryID != nil {
+		categoryID := *payee.DefaultCategoryID
+		data.CategoryID = &categoryID
+	}
+
+	return data, nil
+}
+
+// LinkUnassigned matches every project transaction that has no payee yet, so a
+// new payee or alias also picks up history recorded before it existed.
+// Categories of existing transactions are left alone, and so are reconciled
+// transactions and transactions in a closed period.
+func (s *MatchPayeeService) LinkUnassigned(ctx context.Context, projectID uuid.UUID) (int, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("project not found: %w", err)
+	}
+
+	matcher, err := s.LoadMatcher(ctx, projectID)
+	if err != nil {
+		return 0, err
+	}
+
+	transactions, err := s.transactionRepo.GetByProjectIDWithDateRange(ctx, projectID, nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get project transactions: %w", err)
+	}
+
+	linked := 0
+	for _, transaction := range transactions {
+		if transaction.PayeeID != nil {
+			continue
+		}
+
+		if transaction.EnsureEditable() != nil || project.EnsurePeriodOpen(transaction.TransactionDate) != nil {
+			continue
+		}
+
+		payee := matcher.Match(transaction.Name)
+		if payee == nil {
+			continue
+		}
+
+		payeeID := payee.ID
+		if err := s.transactionRepo.UpdatePayee(ctx, transaction.ID, &payeeID); err != nil {
+			return linked, fmt.Errorf("failed to link transaction to payee: %w", err)
+		}
+		linked++
+	}
+
+	if linked > 0 {
+		logging.FromContext(ctx).Info("transactions linked to payees",
+			slog.String("project_id", projectID.String()),
+			slog.Int("count", linked),
+		)
+	}
+
+	return linked, nil
+}
@@ -0,0 +1,96 @@
+package match_payee
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+)
+
+func TestPayeeMatcher_Match(t *testing.T) {
+	ctx := context.Background()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	service := NewMatchPayeeService(payeeRepo, database.NewTransactionInMemoryRepository(), database.NewProjectInMemoryRepository())
+
+	projectID := uuid.New()
+	biedronka := models.NewPayee(projectID, "Biedronka", nil)
+	express := models.NewPayee(projectID, "Biedronka Express", nil)
+	payeeRepo.Create(ctx, biedronka)
+	payeeRepo.Create(ctx, express)
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(biedronka, "biedronka"))
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(express, "biedronka express"))
+
+	matcher, err := service.LoadMatcher(ctx, projectID)
+	if err != nil {
+		t.Fatalf("Failed to load matcher: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want *models.Payee
+	}{
+		{name: "Biedronka", want: biedronka},
+		{name: "BIEDRONKA 123", want: biedronka},
+		{name: "biedronka, Warszawa", want: biedronka},
+		{name: "Biedronka Express 7", want: express},
+		{name: "Biedronkowo", want: nil},
+		{name: "Sklep Biedronka", want: nil},
+		{name: "1234", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Match(tt.name); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPayeeService_LinkUnassigned_SkipsLocked(t *testing.T) {
+	ctx := context.Background()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewMatchPayeeService(payeeRepo, transactionRepo, projectRepo)
+
+	lockedUntil := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	project := models.NewProject("Home", "home")
+	project.LockedUntil = &lockedUntil
+	projectRepo.Create(ctx, project)
+	orlen := models.NewPayee(project.ID, "Orlen", nil)
+	payeeRepo.Create(ctx, orlen)
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(orlen, "orlen"))
+
+	closed := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	open := time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)
+	newTransaction := func(date time.Time, status models.TransactionStatus) *models.Transaction {
+		transaction := models.NewTransaction(models.TransactionData{AccountID: uuid.New(), Value: 200, Name: "ORLEN 4410", Type: models.Debit, TransactionDate: &date})
+		transaction.Status = status
+		transactionRepo.Create(ctx, transaction)
+		return transaction
+	}
+	inClosedPeriod := newTransaction(closed, models.StatusCleared)
+	reconciled := newTransaction(open, models.StatusReconciled)
+	editable := newTransaction(open, models.StatusCleared)
+
+	linked, err := service.LinkUnassigned(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if linked != 1 {
+		t.Errorf("Expected 1 linked transaction, got %d", linked)
+	}
+
+	if got, _ := transactionRepo.GetByID(ctx, editable.ID); got.PayeeID == nil || *got.PayeeID != orlen.ID {
+		t.Errorf("Expected the editable transaction to be linked, got %v", got.PayeeID)
+	}
+	for _, locked := range []*models.Transaction{inClosedPeriod, reconciled} {
+		if got, _ := transactionRepo.GetByID(ctx, locked.ID); got.PayeeID != nil {
+			t.Errorf("Expected transaction dated %s with status %s to stay unlinked, got %v", got.TransactionDate.Format("2006-01-02"), got.Status, got.PayeeID)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package merge_payees
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_payee"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type MergePayeesService struct {
+	payeeRepo        models.PayeeRepository
+	transactionRepo  models.TransactionRepository
+	projectRepo      models.ProjectRepository
+	validatePayeeSvc *validate_payee.ValidatePayeeService
+}
+
+func NewMergePayeesService(payeeRepo models.PayeeRepository, transactionRepo models.TransactionRepository, projectRepo models.ProjectRepository) *MergePayeesService {
+	return &MergePayeesService{
+		payeeRepo:        payeeRepo,
+		transactionRepo:  transactionRepo,
+		projectRepo:      projectRepo,
+		validatePayeeSvc: validate_payee.NewValidatePayeeService(payeeRepo),
+	}
+}
+
+// MergePayees folds source into target: its transactions and aliases move to
+// target and source is deleted. Target keeps its default category unless it has
+// none, in which case it takes over the one from source. The merge is refused
+// while any transaction of source is reconciled or in a closed period, as those
+// cannot change payee.
+func (s *MergePayeesService) MergePayees(ctx context.Context, projectID, sourceID, targetID uuid.UUID) (*models.Payee, error) {
+	if sourceID == targetID {
+		return nil, fmt.Errorf("cannot merge a payee into itself")
+	}
+
+	source, err := s.validatePayeeSvc.GetPayeeForProject(ctx, projectID, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.validatePayeeSvc.GetPayeeForProject(ctx, projectID, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureTransactionsEditable(ctx, projectID, source); err != nil {
+		return nil, err
+	}
+
+	if target.DefaultCategoryID == nil && source.DefaultCategoryID != nil {
+		if err := s.payeeRepo.UpdateDefaultCategory(ctx, targetID, source.DefaultCategoryID); err != nil {
+			return nil, fmt.Errorf("failed to update payee: %w", err)
+		}
+	}
+
+	if err := s.transactionRepo.ReassignPayee(ctx, sourceID, targetID); err != nil {
+		return nil, fmt.Errorf("failed to move payee transactions: %w", err)
+	}
+
+	if err := s.payeeRepo.Merge(ctx, sourceID, targetID); err != nil {
+		return nil, fmt.Errorf("failed to merge payees: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("payees merged",
+		slog.String("project_id", projectID.String()),
+		slog.String("source_id", sourceID.String()),
+		slog.String("target_id", targetID.String()),
+	)
+
+	return s.payeeRepo.GetByID(ctx, targetID)
+}
+
+func (s *MergePayeesService) ensureTransactionsEditable(ctx context.Context, projectID uuid.UUID, source *models.Payee) error {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	transactions, err := s.transactionRepo.GetByProjectIDWithDateRange(ctx, projectID, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get project transactions: %w", err)
+	}
+
+	for _, transaction := range transactions {
+		if transaction.PayeeID == nil || *transaction.PayeeID != source.ID {
+			continue
+		}
+
+		if err := transaction.EnsureEditable(); err != nil {
+			return fmt.Errorf("cannot merge payee '%s': %w", source.Name, err)
+		}
+		if err := project.EnsurePeriodOpen(transaction.TransactionDate); err != nil {
+			return fmt.Errorf("cannot merge payee '%s': %w", source.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package merge_payees
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+)
+
+func TestMergePayeesService_MergePayees(t *testing.T) {
+	ctx := context.Background()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	project := models.NewProject("Home", "home")
+	projectRepo.Create(ctx, project)
+	service := NewMergePayeesService(payeeRepo, transactionRepo, projectRepo)
+
+	projectID := project.ID
+	categoryID := uuid.New()
+	target := models.NewPayee(projectID, "Biedronka", nil)
+	source := models.NewPayee(projectID, "JMP Biedronka", &categoryID)
+	foreign := models.NewPayee(uuid.New(), "Elsewhere", nil)
+	payeeRepo.Create(ctx, target)
+	payeeRepo.Create(ctx, source)
+	payeeRepo.Create(ctx, foreign)
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(target, "biedronka"))
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(source, "jmp biedronka"))
+
+	transaction := models.NewTransaction(models.TransactionData{AccountID: uuid.New(), Value: 10, Name: "JMP BIEDRONKA", Type: models.Debit, PayeeID: &source.ID})
+	transactionRepo.Create(ctx, transaction)
+
+	if _, err := service.MergePayees(ctx, projectID, source.ID, source.ID); err == nil {
+		t.Error("Expected error when merging a payee into itself")
+	}
+
+	if _, err := service.MergePayees(ctx, projectID, foreign.ID, target.ID); err == nil {
+		t.Error("Expected error when merging a payee from another project")
+	}
+
+	merged, err := service.MergePayees(ctx, projectID, source.ID, target.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if merged.DefaultCategoryID == nil || *merged.DefaultCategoryID != categoryID {
+		t.Errorf("Expected the target to take over the default category, got %v", merged.DefaultCategoryID)
+	}
+
+	if _, err := payeeRepo.GetByID(ctx, source.ID); err == nil {
+		t.Error("Expected the source payee to be deleted")
+	}
+
+	if got, _ := transactionRepo.GetByID(ctx, transaction.ID); got.PayeeID == nil || *got.PayeeID != target.ID {
+		t.Errorf("Expected the transaction to move to the target, got %v", got.PayeeID)
+	}
+
+	aliases, _ := payeeRepo.GetAliasesByProjectID(ctx, projectID)
+	if len(aliases) != 2 || aliases[0].PayeeID != target.ID || aliases[1].PayeeID != target.ID {
+		t.Errorf("Expected both aliases to belong to the target, got %+v", aliases)
+	}
+}
+
+func TestMergePayeesService_MergePayees_LockedTransactions(t *testing.T) {
+	lockedUntil := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	closed := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	open := time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		date   time.Time
+		status models.TransactionStatus
+	}{
+		{name: "reconciled transaction", date: open, status: models.StatusReconciled},
+		{name: "transaction in a closed period", date: closed, status: models.StatusCleared},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			payeeRepo := database.NewPayeeInMemoryRepository()
+			transactionRepo := database.NewTransactionInMemoryRepository()
+			projectRepo := database.NewProjectInMemoryRepository()
+			service := NewMergePayeesService(payeeRepo, transactionRepo, projectRepo)
+
+			project := models.NewProject("Home", "home")
+			project.LockedUntil = &lockedUntil
+			projectRepo.Create(ctx, project)
+			target := models.NewPayee(project.ID, "Biedronka", nil)
+			source := models.NewPayee(project.ID, "JMP Biedronka", nil)
+			payeeRepo.Create(ctx, target)
+			payeeRepo.Create(ctx, source)
+
+			transaction := models.NewTransaction(models.TransactionData{AccountID: uuid.New(), Value: 10, Name: "JMP BIEDRONKA", Type: models.Debit, PayeeID: &source.ID, TransactionDate: &tt.date})
+			transaction.Status = tt.status
+			transactionRepo.Create(ctx, transaction)
+
+			if _, err := service.MergePayees(ctx, project.ID, source.ID, target.ID); err == nil {
+				t.Fatal("Expected error merging a payee with locked transactions")
+			}
+
+			if _, err := payeeRepo.GetByID(ctx, source.ID); err != nil {
+				t.Errorf("Expected the source payee to be kept, got %v", err)
+			}
+			if got, _ := transactionRepo.GetByID(ctx, transaction.ID); got.PayeeID == nil || *got.PayeeID != source.ID {
+				t.Errorf("Expected the transaction to keep its payee, got %v", got.PayeeID)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package reconcile_account
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type ReconcileAccountService struct {
+	reconciliationRepo models.ReconciliationRepository
+	accountRepo        models.AccountRepository
+	transactionRepo    models.TransactionRepository
+	validatePeriodSvc  *validate_period.ValidatePeriodService
+}
+
+func NewReconcileAccountService(reconciliationRepo models.ReconciliationRepository, accountRepo models.AccountRepository, transactionRepo models.TransactionRepository, projectRepo models.ProjectRepository) *ReconcileAccountService {
+	return &ReconcileAccountService{
+		reconciliationRepo: reconciliationRepo,
+		accountRepo:        accountRepo,
+		transactionRepo:    transactionRepo,
+		validatePeriodSvc:  validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+	}
+}
+
+// Session is the state of an account against the bank. Without an open
+// reconciliation, Reconciliation is nil and Transactions lists every
+// transaction not reconciled yet; otherwise only those on the statement.
+type Session struct {
+	Account        *models.Account
+	Reconciliation *models.Reconciliation
+	Transactions   []*models.Transaction
+	// ReconciledBalance adds up the transactions locked by earlier
+	// reconciliations.
+	ReconciledBalance float64
+	// ClearedBalance adds the transactions ticked off on the statement to the
+	// reconciled balance.
+	ClearedBalance float64
+	// Difference is what the statement balance still differs by.
+	Difference float64
+	History    []*models.Reconciliation
+}
+
+// StartReconciliation opens a reconciliation of the account against a bank
+// statement ending on statementDate with statementBalance.
+func (s *ReconcileAccountService) StartReconciliation(ctx context.Context, projectID, accountID uuid.UUID, statementDate time.Time, statementBalance float64) (*models.Reconciliation, error) {
+	account, err := s.getAccount(ctx, projectID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if statementDate.IsZero() {
+		return nil, fmt.Errorf("statement date is required")
+	}
+
+	if _, err := s.reconciliationRepo.GetOpenByAccountID(ctx, account.ID); err == nil {
+		return nil, fmt.Errorf("account '%s' already has an open reconciliation", account.Name)
+	}
+
+	history, err := s.reconciliationRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reconciliations: %w", err)
+	}
+
+	reconciliation := models.NewReconciliation(account.ID, statementDate, statementBalance)
+	if len(history) > 0 && reconciliation.StatementDate.Before(history[0].StatementDate) {
+		return nil, fmt.Errorf("the statement cannot end before the last reconciled one on %s", history[0].StatementDate.Format("2006-01-02"))
+	}
+
+	if err := s.reconciliationRepo.Create(ctx, reconciliation); err != nil {
+		return nil, fmt.Errorf("failed to create reconciliation: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("reconciliation started",
+		slog.String("account_id", account.ID.String()),
+		slog.String("reconciliation_id", reconciliation.ID.String()),
+	)
+
+	return reconciliation, nil
+}
+
+// GetSession returns the open reconciliation of the account, if any, with the
+// balances it compares.
+func (s *ReconcileAccountService) GetSession(ctx context.Context, projectID, accountID uuid.UUID) (*Session, error) {
+	account, err := s.getAccount(ctx, projectID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation, err := s.reconciliationRepo.GetOpenByAccountID(ctx, account.ID)
+	if err != nil {
+		reconciliation = nil
+	}
+
+	return s.session(ctx, account, reconciliation)
+}
+
+// Save records which transactions on the statement are ticked off as cleared,
+// so the reconciliation can be picked up later. Transactions in a closed period
+// can neither be ticked nor unticked.
+func (s *ReconcileAccountService) Save(ctx context.Context, projectID, reconciliationID uuid.UUID, clearedIDs []uuid.UUID) (*Session, error) {
+	reconciliation, account, err := s.getOpenReconciliation(ctx, projectID, reconciliationID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.session(ctx, account, reconciliation)
+	if err != nil {
+		return nil, err
+	}
+
+	onStatement := make(map[uuid.UUID]bool, len(session.Transactions))
+	for _, transaction := range session.Transactions {
+		onStatement[transaction.ID] = true
+	}
+
+	cleared := make(map[uuid.UUID]bool, len(clearedIDs))
+	for _, id := range clearedIDs {
+		if !onStatement[id] {
+			return nil, fmt.Errorf("transaction %s is not on the statement", id)
+		}
+		cleared[id] = true
+	}
+
+	for _, transaction := range session.Transactions {
+		if !cleared[transaction.ID] && transaction.Status == models.StatusUncleared {
+			continue
+		}
+
+		if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, transaction.TransactionDate); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, transaction := range session.Transactions {
+		status := models.StatusUncleared
+		if cleared[transaction.ID] {
+			status = models.StatusCleared
+		}
+
+		if transaction.Status == status {
+			continue
+		}
+
+		if err := s.transactionRepo.UpdateStatus(ctx, transaction.ID, status); err != nil {
+			return nil, fmt.Errorf("failed to update transaction status: %w", err)
+		}
+	}
+
+	return s.session(ctx, account, reconciliation)
+}
+
+// Finish saves the ticked transactions and, when they agree with the statement
+// balance, marks them reconciled and closes the reconciliation. Reconciled
+// transactions can no longer be changed or deleted.
+func (s *ReconcileAccountService) Finish(ctx context.Context, projectID, reconciliationID uuid.UUID, clearedIDs []uuid.UUID) error {
+	session, err := s.Save(ctx, projectID, reconciliationID, clearedIDs)
+	if err != nil {
+		return err
+	}
+
+	if !models.IsBalanced(session.Difference) {
+		return fmt.Errorf("the cleared balance differs from the statement by %.2f", session.Difference)
+	}
+
+	for _, transaction := range session.Transactions {
+		if transaction.Status != models.StatusCleared {
+			continue
+		}
+
+		if err := s.transactionRepo.UpdateStatus(ctx, transaction.ID, models.StatusReconciled); err != nil {
+			return fmt.Errorf("failed to update transaction status: %w", err)
+		}
+	}
+
+	if err := s.reconciliationRepo.Finish(ctx, reconciliationID, time.Now()); err != nil {
+		return fmt.Errorf("failed to finish reconciliation: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("reconciliation finished",
+		slog.String("account_id", session.Account.ID.String()),
+		slog.String("reconciliation_id", reconciliationID.String()),
+	)
+
+	return nil
+}
+
+// Cancel drops an open reconciliation. Transactions ticked off stay cleared.
+func (s *ReconcileAccountService) Cancel(ctx context.Context, projectID, reconciliationID uuid.UUID) error {
+	if _, _, err := s.getOpenReconciliation(ctx, projectID, reconciliationID); err != nil {
+		return err
+	}
+
+	if err := s.reconciliationRepo.Delete(ctx, reconciliationID); err != nil {
+		return fmt.Errorf("failed to cancel reconciliation: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("reconciliation cancelled", slog.String("reconciliation_id", reconciliationID.String()))
+
+	return nil
+}
+
+func (s *ReconcileAccountService) session(ctx context.Context, account *models.Account, reconciliation *models.Reconciliation) (*Session, error) {
+	transactions, err := s.transactionRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account transactions: %w", err)
+	}
+
+	history, err := s.reconciliationRepo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reconciliations: %w", err)
+	}
+
+	session := &Session{Account: account, Reconciliation: reconciliation}
+	for _, past := range history {
+		if past.IsFinished() {
+			session.History = append(session.History, past)
+		}
+	}
+
+	for _, transaction := range transactions {
+		if transaction.IsReconciled() {
+			session.ReconciledBalance += transaction.SignedValue()
+			continue
+		}
+
+		if reconciliation != nil && !reconciliation.Covers(transaction.TransactionDate) {
+			continue
+		}
+
+		session.Transactions = append(session.Transactions, transaction)
+		if transaction.Status == models.StatusCleared {
+			session.ClearedBalance += transaction.SignedValue()
+		}
+	}
+
+	sort.SliceStable(session.Transactions, func(i, j int) bool {
+		return session.Transactions[i].TransactionDate.Before(session.Transactions[j].TransactionDate)
+	})
+
+	session.ReconciledBalance = roundCents(session.ReconciledBalance)
+	session.ClearedBalance = roundCents(session.ReconciledBalance + session.ClearedBalance)
+	if reconciliation != nil {
+		session.Difference = roundCents(reconciliation.StatementBalance - session.ClearedBalance)
+	}
+
+	return session, nil
+}
+
+func (s *ReconcileAccountService) getAccount(ctx context.Context, projectID, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	if account.ProjectID != projectID {
+		return nil, fmt.Errorf("account does not belong to the specified project")
+	}
+
+	return account, nil
+}
+
+func (s *ReconcileAccountService) getOpenReconciliation(ctx context.Context, projectID, reconciliationID uuid.UUID) (*models.Reconciliation, *models.Account, error) {
+	reconciliation, err := s.reconciliationRepo.GetByID(ctx, reconciliationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reconciliation not found: %w", err)
+	}
+
+	account, err := s.getAccount(ctx, projectID, reconciliation.AccountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if reconciliation.IsFinished() {
+		return nil, nil, fmt.Errorf("reconciliation is already finished")
+	}
+
+	return reconciliation, account, nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
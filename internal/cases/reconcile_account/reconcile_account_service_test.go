@@ -0,0 +1,177 @@
+package reconcile_account
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestReconcileAccountService_Finish(t *testing.T) {
+	statementDate := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		statementBalance   float64
+		ticked             []string
+		expectError        bool
+		expectedReconciled []string
+	}{
+		{name: "balanced", statementBalance: 900, ticked: []string{"salary", "rent"}, expectedReconciled: []string{"salary", "rent"}},
+		{name: "balanced with an uncleared transaction left", statementBalance: 1000, ticked: []string{"salary"}, expectedReconciled: []string{"salary"}},
+		{name: "difference left", statementBalance: 950, ticked: []string{"salary", "rent"}, expectError: true},
+		{name: "transaction after the statement", statementBalance: 850, ticked: []string{"salary", "rent", "april"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			reconciliationRepo := database.NewReconciliationInMemoryRepository()
+			accountRepo := database.NewAccountInMemoryRepository()
+			transactionRepo := database.NewTransactionInMemoryRepository()
+			projectRepo := database.NewProjectInMemoryRepository()
+			service := NewReconcileAccountService(reconciliationRepo, accountRepo, transactionRepo, projectRepo)
+
+			project := models.NewProject("Test Project", "test-project")
+			projectRepo.Create(ctx, project)
+			projectID := project.ID
+			account := models.NewAccount(projectID, "Checking", money.PLN)
+			accountRepo.Create(ctx, account)
+
+			transactions := make(map[string]*models.Transaction)
+			for name, data := range map[string]struct {
+				value float64
+				kind  models.TransactionType
+				date  time.Time
+			}{
+				"salary": {1000, models.TopUp, statementDate.AddDate(0, 0, -20)},
+				"rent":   {100, models.Debit, statementDate},
+				"april":  {50, models.Debit, statementDate.AddDate(0, 0, 1)},
+			} {
+				transaction := models.NewTransaction(models.TransactionData{
+					AccountID:       account.ID,
+					Value:           data.value,
+					Name:            name,
+					Type:            data.kind,
+					TransactionDate: &data.date,
+				})
+				transactionRepo.Create(ctx, transaction)
+				transactions[name] = transaction
+			}
+
+			reconciliation, err := service.StartReconciliation(ctx, projectID, account.ID, statementDate, tt.statementBalance)
+			if err != nil {
+				t.Fatalf("Failed to start reconciliation: %v", err)
+			}
+
+			var ticked []uuid.UUID
+			for _, name := range tt.ticked {
+				ticked = append(ticked, transactions[name].ID)
+			}
+
+			err = service.Finish(ctx, projectID, reconciliation.ID, ticked)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				for _, transaction := range transactions {
+					if transaction.IsReconciled() {
+						t.Errorf("Expected %s to stay unreconciled", transaction.Name)
+					}
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			reconciled := 0
+			for _, transaction := range transactions {
+				if transaction.IsReconciled() {
+					reconciled++
+				}
+			}
+			if reconciled != len(tt.expectedReconciled) {
+				t.Errorf("Expected %d reconciled transactions, got %d", len(tt.expectedReconciled), reconciled)
+			}
+			for _, name := range tt.expectedReconciled {
+				if !transactions[name].IsReconciled() {
+					t.Errorf("Expected %s to be reconciled", name)
+				}
+			}
+
+			session, err := service.GetSession(ctx, projectID, account.ID)
+			if err != nil {
+				t.Fatalf("Failed to get session: %v", err)
+			}
+			if session.Reconciliation != nil || len(session.History) != 1 || session.ReconciledBalance != tt.statementBalance {
+				t.Errorf("Expected a closed reconciliation at %v, got %+v", tt.statementBalance, session)
+			}
+
+			if _, err := service.StartReconciliation(ctx, projectID, account.ID, statementDate.AddDate(0, 0, -1), 0); err == nil {
+				t.Error("Expected an error for a statement ending before the last reconciled one")
+			}
+		})
+	}
+}
+
+func TestReconcileAccountService_Save(t *testing.T) {
+	ctx := context.Background()
+	reconciliationRepo := database.NewReconciliationInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewReconcileAccountService(reconciliationRepo, accountRepo, transactionRepo, projectRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(ctx, project)
+	projectID := project.ID
+	account := models.NewAccount(projectID, "Checking", money.PLN)
+	accountRepo.Create(ctx, account)
+
+	date := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	paid := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 40, Name: "Groceries", Type: models.Debit, TransactionDate: &date})
+	transactionRepo.Create(ctx, paid)
+
+	if _, err := service.StartReconciliation(ctx, uuid.New(), account.ID, date, -40); err == nil {
+		t.Error("Expected an error for an account of another project")
+	}
+
+	reconciliation, err := service.StartReconciliation(ctx, projectID, account.ID, date, -40)
+	if err != nil {
+		t.Fatalf("Failed to start reconciliation: %v", err)
+	}
+
+	if _, err := service.StartReconciliation(ctx, projectID, account.ID, date, -40); err == nil {
+		t.Error("Expected an error for a second open reconciliation")
+	}
+
+	session, err := service.Save(ctx, projectID, reconciliation.ID, []uuid.UUID{paid.ID})
+	if err != nil {
+		t.Fatalf("Failed to save reconciliation: %v", err)
+	}
+	if paid.Status != models.StatusCleared || session.ClearedBalance != -40 || session.Difference != 0 {
+		t.Errorf("Expected the ticked transaction cleared with no difference left, got %+v", session)
+	}
+
+	if _, err := service.Save(ctx, projectID, reconciliation.ID, []uuid.UUID{uuid.New()}); err == nil {
+		t.Error("Expected an error for a transaction not on the statement")
+	}
+
+	if err := service.Cancel(ctx, projectID, reconciliation.ID); err != nil {
+		t.Fatalf("Failed to cancel reconciliation: %v", err)
+	}
+	if paid.Status != models.StatusCleared {
+		t.Error("Expected the transaction to stay cleared after cancelling")
+	}
+
+	session, _ = service.GetSession(ctx, projectID, account.ID)
+	if session.Reconciliation != nil {
+		t.Error("Expected no open reconciliation after cancelling")
+	}
+}
@@ -0,0 +1,190 @@
+package record_investment_operation
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type RecordInvestmentOperationService struct {
+	operationRepo     models.InvestmentOperationRepository
+	securityRepo      models.SecurityRepository
+	accountRepo       models.AccountRepository
+	transactionRepo   models.TransactionRepository
+	validatePeriodSvc *validate_period.ValidatePeriodService
+}
+
+func NewRecordInvestmentOperationService(operationRepo models.InvestmentOperationRepository, securityRepo models.SecurityRepository, accountRepo models.AccountRepository, transactionRepo models.TransactionRepository, projectRepo models.ProjectRepository) *RecordInvestmentOperationService {
+	return &RecordInvestmentOperationService{
+		operationRepo:     operationRepo,
+		securityRepo:      securityRepo,
+		accountRepo:       accountRepo,
+		transactionRepo:   transactionRepo,
+		validatePeriodSvc: validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+	}
+}
+
+// OperationData describes a trade on an investment account. Quantity, Price and
+// Fees apply to buys and sells, Amount to dividends. SecurityName names a new
+// security the first time its ticker is bought.
+type OperationData struct {
+	AccountID    uuid.UUID
+	Ticker       string
+	SecurityName string
+	Type         models.InvestmentOperationType
+	Quantity     float64
+	Price        float64
+	Fees         float64
+	Amount       float64
+	Date         time.Time
+}
+
+// RecordOperation stores a buy, sell or dividend and books the cash it moved on
+// the investment account: a debit for a buy, a top-up for a sale or dividend.
+func (s *RecordInvestmentOperationService) RecordOperation(ctx context.Context, projectID uuid.UUID, data OperationData) (*models.InvestmentOperation, error) {
+	if err := validate(data); err != nil {
+		return nil, err
+	}
+
+	if data.Date.IsZero() {
+		data.Date = time.Now()
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, data.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	if account.ProjectID != projectID {
+		return nil, fmt.Errorf("account does not belong to the specified project")
+	}
+
+	if account.Type != models.AccountInvestment {
+		return nil, fmt.Errorf("account '%s' is not an investment account", account.Name)
+	}
+
+	if account.IsArchived() {
+		return nil, fmt.Errorf("account '%s' is archived", account.Name)
+	}
+
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, data.Date); err != nil {
+		return nil, err
+	}
+
+	security, err := s.securityRepo.GetByTicker(ctx, projectID, data.Ticker)
+	if err != nil {
+		if data.Type != models.OperationBuy {
+			return nil, fmt.Errorf("security '%s' not found", models.NormalizeTicker(data.Ticker))
+		}
+
+		security = models.NewSecurity(projectID, data.Ticker, data.SecurityName, account.Currency)
+		if err := s.securityRepo.Create(ctx, security); err != nil {
+			return nil, fmt.Errorf("failed to create security: %w", err)
+		}
+	}
+
+	if security.Currency != account.Currency {
+		return nil, fmt.Errorf("security '%s' is traded in %s, not %s", security.Ticker, security.Currency, account.Currency)
+	}
+
+	operation := models.NewInvestmentOperation(account.ID, security.ID, data.Type, data.Quantity, data.Price, data.Fees, data.Amount, data.Date)
+	if operation.Amount <= 0 {
+		return nil, fmt.Errorf("fees cannot exceed the proceeds of a sale")
+	}
+
+	if operation.Type == models.OperationSell {
+		operations, err := s.operationRepo.GetByAccountID(ctx, account.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get investment operations: %w", err)
+		}
+
+		var held []*models.InvestmentOperation
+		for _, existing := range operations {
+			if existing.SecurityID == security.ID {
+				held = append(held, existing)
+			}
+		}
+
+		if _, err := models.BuildHoldings(append(held, operation), models.CostFIFO); err != nil {
+			return nil, err
+		}
+	}
+
+	transactionType := models.TopUp
+	if operation.Type == models.OperationBuy {
+		transactionType = models.Debit
+	}
+
+	date := operation.Date
+	transaction := models.NewTransaction(models.TransactionData{
+		AccountID:       account.ID,
+		Value:           operation.Amount,
+		Name:            transactionName(operation, security),
+		Type:            transactionType,
+		TransactionDate: &date,
+	})
+	if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("failed to create investment transaction: %w", err)
+	}
+
+	operation.TransactionID = transaction.ID
+	if err := s.operationRepo.Create(ctx, operation); err != nil {
+		return nil, fmt.Errorf("failed to record investment operation: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("investment operation recorded",
+		slog.String("project_id", projectID.String()),
+		slog.String("account_id", account.ID.String()),
+		slog.String("operation_id", operation.ID.String()),
+		slog.String("type", operation.Type.String()),
+	)
+
+	return operation, nil
+}
+
+func validate(data OperationData) error {
+	if err := models.ValidateTicker(data.Ticker); err != nil {
+		return err
+	}
+
+	switch data.Type {
+	case models.OperationBuy, models.OperationSell:
+		if data.Quantity <= 0 {
+			return fmt.Errorf("quantity must be positive")
+		}
+		if data.Price <= 0 {
+			return fmt.Errorf("price must be positive")
+		}
+		if data.Fees < 0 {
+			return fmt.Errorf("fees cannot be negative")
+		}
+	case models.OperationDividend:
+		if data.Amount <= 0 {
+			return fmt.Errorf("dividend amount must be positive")
+		}
+	default:
+		return fmt.Errorf("invalid operation type: %s", data.Type)
+	}
+
+	return nil
+}
+
+func transactionName(operation *models.InvestmentOperation, security *models.Security) string {
+	quantity := strconv.FormatFloat(operation.Quantity, 'f', -1, 64)
+
+	switch operation.Type {
+	case models.OperationBuy:
+		return fmt.Sprintf("Buy %s %s @ %.2f", quantity, security.Ticker, operation.Price)
+	case models.OperationSell:
+		return fmt.Sprintf("Sell %s %s @ %.2f", quantity, security.Ticker, operation.Price)
+	default:
+		return fmt.Sprintf("Dividend %s", security.Ticker)
+	}
+}
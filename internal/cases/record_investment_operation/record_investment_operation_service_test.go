@@ -0,0 +1,139 @@
+package record_investment_operation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestRecordInvestmentOperationService_RecordOperation(t *testing.T) {
+	bought := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	sold := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		account          string
+		data             OperationData
+		expectError      bool
+		expectedAmount   float64
+		expectedTxType   models.TransactionType
+		expectedSecurity bool
+	}{
+		{
+			name:           "buy of a held security",
+			account:        "broker",
+			data:           OperationData{Ticker: "vwce", Type: models.OperationBuy, Quantity: 5, Price: 110, Fees: 2, Date: sold},
+			expectedAmount: 552,
+			expectedTxType: models.Debit,
+		},
+		{
+			name:             "buy creates the security",
+			account:          "broker",
+			data:             OperationData{Ticker: "CSPX", SecurityName: "S&P 500", Type: models.OperationBuy, Quantity: 1, Price: 500, Date: sold},
+			expectedAmount:   500,
+			expectedTxType:   models.Debit,
+			expectedSecurity: true,
+		},
+		{
+			name:           "sell after fees",
+			account:        "broker",
+			data:           OperationData{Ticker: "VWCE", Type: models.OperationSell, Quantity: 4, Price: 120, Fees: 1, Date: sold},
+			expectedAmount: 479,
+			expectedTxType: models.TopUp,
+		},
+		{
+			name:           "dividend",
+			account:        "broker",
+			data:           OperationData{Ticker: "VWCE", Type: models.OperationDividend, Amount: 12.5, Date: sold},
+			expectedAmount: 12.5,
+			expectedTxType: models.TopUp,
+		},
+		{name: "sell more than held", account: "broker", data: OperationData{Ticker: "VWCE", Type: models.OperationSell, Quantity: 11, Price: 120, Date: sold}, expectError: true},
+		{name: "sell before the purchase", account: "broker", data: OperationData{Ticker: "VWCE", Type: models.OperationSell, Quantity: 1, Price: 120, Date: bought.AddDate(0, 0, -1)}, expectError: true},
+		{name: "sell of an unknown security", account: "broker", data: OperationData{Ticker: "CSPX", Type: models.OperationSell, Quantity: 1, Price: 500, Date: sold}, expectError: true},
+		{name: "fees above the proceeds", account: "broker", data: OperationData{Ticker: "VWCE", Type: models.OperationSell, Quantity: 1, Price: 1, Fees: 2, Date: sold}, expectError: true},
+		{name: "zero quantity", account: "broker", data: OperationData{Ticker: "VWCE", Type: models.OperationBuy, Price: 100, Date: sold}, expectError: true},
+		{name: "missing ticker", account: "broker", data: OperationData{Type: models.OperationBuy, Quantity: 1, Price: 100, Date: sold}, expectError: true},
+		{name: "not an investment account", account: "checking", data: OperationData{Ticker: "VWCE", Type: models.OperationBuy, Quantity: 1, Price: 100, Date: sold}, expectError: true},
+		{name: "security in another currency", account: "euro", data: OperationData{Ticker: "VWCE", Type: models.OperationBuy, Quantity: 1, Price: 100, Date: sold}, expectError: true},
+		{name: "other project", account: "foreign", data: OperationData{Ticker: "VWCE", Type: models.OperationBuy, Quantity: 1, Price: 100, Date: sold}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			operationRepo := database.NewInvestmentOperationInMemoryRepository()
+			securityRepo := database.NewSecurityInMemoryRepository()
+			accountRepo := database.NewAccountInMemoryRepository()
+			transactionRepo := database.NewTransactionInMemoryRepository()
+			projectRepo := database.NewProjectInMemoryRepository()
+			service := NewRecordInvestmentOperationService(operationRepo, securityRepo, accountRepo, transactionRepo, projectRepo)
+
+			project := models.NewProject("Test Project", "test-project")
+			projectRepo.Create(ctx, project)
+			projectID := project.ID
+			accounts := map[string]*models.Account{
+				"broker":   models.NewAccount(projectID, "Broker", money.PLN),
+				"euro":     models.NewAccount(projectID, "Broker EUR", money.EUR),
+				"checking": models.NewAccount(projectID, "Checking", money.PLN),
+				"foreign":  models.NewAccount(uuid.New(), "Foreign", money.PLN),
+			}
+			for name, account := range accounts {
+				if name != "checking" {
+					account.Type = models.AccountInvestment
+				}
+				accountRepo.Create(ctx, account)
+			}
+
+			if _, err := service.RecordOperation(ctx, projectID, OperationData{
+				AccountID: accounts["broker"].ID,
+				Ticker:    "VWCE",
+				Type:      models.OperationBuy,
+				Quantity:  10,
+				Price:     100,
+				Date:      bought,
+			}); err != nil {
+				t.Fatalf("Failed to record the initial purchase: %v", err)
+			}
+
+			tt.data.AccountID = accounts[tt.account].ID
+			operation, err := service.RecordOperation(ctx, projectID, tt.data)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				operations, _ := operationRepo.GetByAccountID(ctx, accounts["broker"].ID)
+				if len(operations) != 1 {
+					t.Errorf("Expected only the initial purchase, got %d operations", len(operations))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if operation.Amount != tt.expectedAmount {
+				t.Errorf("Expected amount %v, got %v", tt.expectedAmount, operation.Amount)
+			}
+
+			transaction, err := transactionRepo.GetByID(ctx, operation.TransactionID)
+			if err != nil {
+				t.Fatalf("Expected a cash transaction, got %v", err)
+			}
+			if transaction.Value != tt.expectedAmount || transaction.Type != tt.expectedTxType || transaction.AccountID != accounts["broker"].ID {
+				t.Errorf("Expected a %v %s transaction on the account, got %+v", tt.expectedAmount, tt.expectedTxType, transaction)
+			}
+
+			securities, _ := securityRepo.GetByProjectID(ctx, projectID)
+			if expected := map[bool]int{false: 1, true: 2}[tt.expectedSecurity]; len(securities) != expected {
+				t.Errorf("Expected %d securities, got %d", expected, len(securities))
+			}
+		})
+	}
+}
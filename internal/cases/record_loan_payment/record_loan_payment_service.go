@@ -0,0 +1,191 @@
+package record_loan_payment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type RecordLoanPaymentService struct {
+	loanRepo          models.LoanRepository
+	accountRepo       models.AccountRepository
+	transactionRepo   models.TransactionRepository
+	transferRepo      models.AccountTransferRepository
+	validatePeriodSvc *validate_period.ValidatePeriodService
+}
+
+func NewRecordLoanPaymentService(loanRepo models.LoanRepository, accountRepo models.AccountRepository, transactionRepo models.TransactionRepository, transferRepo models.AccountTransferRepository, projectRepo models.ProjectRepository) *RecordLoanPaymentService {
+	return &RecordLoanPaymentService{
+		loanRepo:          loanRepo,
+		accountRepo:       accountRepo,
+		transactionRepo:   transactionRepo,
+		transferRepo:      transferRepo,
+		validatePeriodSvc: validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+	}
+}
+
+// LoanPaymentData describes a payment made from FromAccountID towards the loan
+// held on LoanAccountID.
+type LoanPaymentData struct {
+	LoanAccountID uuid.UUID
+	FromAccountID uuid.UUID
+	Amount        float64
+	Date          time.Time
+}
+
+// LoanPayment is how a payment was split between principal and interest.
+type LoanPayment struct {
+	GroupID      uuid.UUID             `json:"group_id"`
+	Principal    float64               `json:"principal"`
+	Interest     float64               `json:"interest"`
+	Transactions []*models.Transaction `json:"transactions"`
+}
+
+// RecordPayment splits a payment into the interest accrued over a month on the
+// outstanding debt, at the rate in force on the payment date, and the principal
+// repaid with the rest. The paying account is debited with both parts as
+// separate transactions and the loan account is topped up with the principal,
+// all in one group. The principal legs are recorded as a transfer, since only the
+// interest is an expense.
+func (s *RecordLoanPaymentService) RecordPayment(ctx context.Context, projectID uuid.UUID, data LoanPaymentData) (*LoanPayment, error) {
+	if data.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	if data.Date.IsZero() {
+		data.Date = time.Now()
+	}
+
+	loan, err := s.loanRepo.GetByAccountID(ctx, data.LoanAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("loan not found: %w", err)
+	}
+
+	if loan.ProjectID != projectID {
+		return nil, fmt.Errorf("loan does not belong to the specified project")
+	}
+
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, data.Date); err != nil {
+		return nil, err
+	}
+
+	loanAccount, err := s.accountRepo.GetByID(ctx, data.LoanAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	if data.FromAccountID == data.LoanAccountID {
+		return nil, fmt.Errorf("the paying account must differ from the loan account")
+	}
+
+	fromAccount, err := s.accountRepo.GetByID(ctx, data.FromAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	if fromAccount.ProjectID != projectID {
+		return nil, fmt.Errorf("account does not belong to the specified project")
+	}
+
+	if fromAccount.IsArchived() {
+		return nil, fmt.Errorf("account '%s' is archived", fromAccount.Name)
+	}
+
+	if fromAccount.Currency != loanAccount.Currency {
+		return nil, fmt.Errorf("account '%s' is in %s, not %s", fromAccount.Name, fromAccount.Currency, loanAccount.Currency)
+	}
+
+	transactions, err := s.transactionRepo.GetByAccountID(ctx, data.LoanAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account transactions: %w", err)
+	}
+
+	periods, err := s.loanRepo.GetRatePeriods(ctx, data.LoanAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate periods: %w", err)
+	}
+
+	outstanding := models.LoanOutstanding(transactions, data.Date)
+	if outstanding < 0.005 {
+		return nil, fmt.Errorf("loan '%s' is already repaid", loanAccount.Name)
+	}
+
+	interest := roundCents(outstanding * loan.RateAt(data.Date, periods) / 1200)
+	if data.Amount < interest {
+		return nil, fmt.Errorf("amount must cover the %.2f interest due", interest)
+	}
+
+	principal := roundCents(data.Amount - interest)
+	if principal > outstanding {
+		return nil, fmt.Errorf("amount exceeds the %.2f owed", roundCents(outstanding+interest))
+	}
+
+	groupID := uuid.New()
+	date := data.Date
+	payment := &LoanPayment{
+		GroupID:   groupID,
+		Principal: principal,
+		Interest:  interest,
+	}
+
+	if principal > 0 {
+		payment.Transactions = append(payment.Transactions,
+			models.NewTransaction(models.TransactionData{
+				AccountID:       fromAccount.ID,
+				Value:           principal,
+				Name:            fmt.Sprintf("%s: principal", loanAccount.Name),
+				Type:            models.Debit,
+				TransactionDate: &date,
+			}, groupID),
+			models.NewTransaction(models.TransactionData{
+				AccountID:       loanAccount.ID,
+				Value:           principal,
+				Name:            fmt.Sprintf("%s: principal", loanAccount.Name),
+				Type:            models.TopUp,
+				TransactionDate: &date,
+			}, groupID),
+		)
+	}
+
+	if interest > 0 {
+		payment.Transactions = append(payment.Transactions, models.NewTransaction(models.TransactionData{
+			AccountID:       fromAccount.ID,
+			Value:           interest,
+			Name:            fmt.Sprintf("%s: interest", loanAccount.Name),
+			Type:            models.Debit,
+			TransactionDate: &date,
+		}, groupID))
+	}
+
+	for _, transaction := range payment.Transactions {
+		if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+			return nil, fmt.Errorf("failed to create loan payment transaction: %w", err)
+		}
+	}
+
+	if principal > 0 {
+		transfer := models.NewAccountTransfer(projectID, payment.Transactions[0], payment.Transactions[1])
+		if err := s.transferRepo.Create(ctx, transfer); err != nil {
+			return nil, fmt.Errorf("failed to record loan payment transfer: %w", err)
+		}
+	}
+
+	logging.FromContext(ctx).Info("loan payment recorded",
+		slog.String("project_id", projectID.String()),
+		slog.String("account_id", loanAccount.ID.String()),
+		slog.String("group_id", groupID.String()),
+	)
+
+	return payment, nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
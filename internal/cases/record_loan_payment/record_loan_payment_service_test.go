@@ -0,0 +1,130 @@
+package record_loan_payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestRecordLoanPaymentService_RecordPayment(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	paid := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		amount            float64
+		from              string
+		expectError       bool
+		expectedPrincipal float64
+		expectedInterest  float64
+	}{
+		{name: "regular installment", amount: 1066.19, from: "checking", expectedPrincipal: 946.19, expectedInterest: 120},
+		{name: "interest only", amount: 120, from: "checking", expectedPrincipal: 0, expectedInterest: 120},
+		{name: "full repayment", amount: 12120, from: "checking", expectedPrincipal: 12000, expectedInterest: 120},
+		{name: "below interest", amount: 100, from: "checking", expectError: true},
+		{name: "more than owed", amount: 12120.01, from: "checking", expectError: true},
+		{name: "other currency", amount: 1066.19, from: "euro", expectError: true},
+		{name: "archived account", amount: 1066.19, from: "archived", expectError: true},
+		{name: "other project", amount: 1066.19, from: "foreign", expectError: true},
+		{name: "from the loan itself", amount: 1066.19, from: "loan", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			loanRepo := database.NewLoanInMemoryRepository()
+			accountRepo := database.NewAccountInMemoryRepository()
+			transactionRepo := database.NewTransactionInMemoryRepository()
+			transferRepo := database.NewAccountTransferInMemoryRepository()
+			projectRepo := database.NewProjectInMemoryRepository()
+			service := NewRecordLoanPaymentService(loanRepo, accountRepo, transactionRepo, transferRepo, projectRepo)
+
+			project := models.NewProject("Test Project", "test-project")
+			projectRepo.Create(ctx, project)
+			projectID := project.ID
+			loanAccount := models.NewAccount(projectID, "Mortgage", money.PLN)
+			loanAccount.Type = models.AccountLoan
+			archivedAt := time.Now()
+			archived := models.NewAccount(projectID, "Old", money.PLN)
+			archived.ArchivedAt = &archivedAt
+			accounts := map[string]*models.Account{
+				"loan":     loanAccount,
+				"checking": models.NewAccount(projectID, "Checking", money.PLN),
+				"euro":     models.NewAccount(projectID, "Euro", money.EUR),
+				"archived": archived,
+				"foreign":  models.NewAccount(uuid.New(), "Foreign", money.PLN),
+			}
+			for _, account := range accounts {
+				accountRepo.Create(ctx, account)
+			}
+
+			loanRepo.Create(ctx, models.NewLoan(loanAccount, 12000, 12, 12, 10, start))
+			transactionRepo.Create(ctx, models.NewTransaction(models.TransactionData{
+				AccountID:       loanAccount.ID,
+				Value:           12000,
+				Name:            "Mortgage: principal",
+				Type:            models.Debit,
+				TransactionDate: &start,
+			}))
+
+			payment, err := service.RecordPayment(ctx, projectID, LoanPaymentData{
+				LoanAccountID: loanAccount.ID,
+				FromAccountID: accounts[tt.from].ID,
+				Amount:        tt.amount,
+				Date:          paid,
+			})
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if payment.Principal != tt.expectedPrincipal || payment.Interest != tt.expectedInterest {
+				t.Errorf("Expected %v principal and %v interest, got %+v", tt.expectedPrincipal, tt.expectedInterest, payment)
+			}
+
+			group, _ := transactionRepo.GetByGroupID(ctx, payment.GroupID)
+			if len(group) != len(payment.Transactions) {
+				t.Errorf("Expected %d grouped transactions, got %d", len(payment.Transactions), len(group))
+			}
+
+			wantTransfers := 0
+			if tt.expectedPrincipal > 0 {
+				wantTransfers = 1
+			}
+			transfers, _ := transferRepo.GetByGroupID(ctx, payment.GroupID)
+			if len(transfers) != wantTransfers {
+				t.Fatalf("Expected %d transfers, got %d", wantTransfers, len(transfers))
+			}
+			for _, transfer := range transfers {
+				if transfer.Amount != tt.expectedPrincipal {
+					t.Errorf("Expected the transfer to carry the %v principal, got %v", tt.expectedPrincipal, transfer.Amount)
+				}
+			}
+
+			var paidFrom float64
+			checking, _ := transactionRepo.GetByAccountID(ctx, accounts["checking"].ID)
+			for _, transaction := range checking {
+				paidFrom += transaction.Value
+			}
+			if paidFrom != tt.amount {
+				t.Errorf("Expected %v debited from the paying account, got %v", tt.amount, paidFrom)
+			}
+
+			loanTransactions, _ := transactionRepo.GetByAccountID(ctx, loanAccount.ID)
+			if outstanding := models.LoanOutstanding(loanTransactions, paid); outstanding != 12000-tt.expectedPrincipal {
+				t.Errorf("Expected %v outstanding, got %v", 12000-tt.expectedPrincipal, outstanding)
+			}
+		})
+	}
+}
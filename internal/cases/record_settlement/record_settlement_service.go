@@ -0,0 +1,157 @@
+package record_settlement
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	"gofin/pkg/money"
+)
+
+type RecordSettlementService struct {
+	settlementRepo    models.SettlementRepository
+	transactionRepo   models.TransactionRepository
+	accountRepo       models.AccountRepository
+	accessRepo        models.AccessRepository
+	validatePeriodSvc *validate_period.ValidatePeriodService
+}
+
+func NewRecordSettlementService(settlementRepo models.SettlementRepository, transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, accessRepo models.AccessRepository, projectRepo models.ProjectRepository) *RecordSettlementService {
+	return &RecordSettlementService{
+		settlementRepo:    settlementRepo,
+		transactionRepo:   transactionRepo,
+		accountRepo:       accountRepo,
+		accessRepo:        accessRepo,
+		validatePeriodSvc: validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+	}
+}
+
+// SettlementData describes a payment between two members. FromAccountID is debited
+// with the amount; ToAccountID, when given, receives it as a top-up.
+type SettlementData struct {
+	FromID        uuid.UUID
+	ToID          uuid.UUID
+	Amount        float64
+	Currency      money.Currency
+	FromAccountID uuid.UUID
+	ToAccountID   *uuid.UUID
+}
+
+// RecordSettlement stores the settlement in the member ledger and creates the
+// grouped transactions that move the money between the chosen accounts.
+func (s *RecordSettlementService) RecordSettlement(ctx context.Context, projectID uuid.UUID, data SettlementData) (*models.Settlement, error) {
+	if data.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	if data.FromID == data.ToID {
+		return nil, fmt.Errorf("a member cannot settle with themselves")
+	}
+
+	from, err := s.getMember(ctx, projectID, data.FromID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.getMember(ctx, projectID, data.ToID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateAccount(ctx, projectID, data.FromAccountID, data.Currency); err != nil {
+		return nil, err
+	}
+
+	if data.ToAccountID != nil {
+		if *data.ToAccountID == data.FromAccountID {
+			return nil, fmt.Errorf("the receiving account must differ from the paying account")
+		}
+		if err := s.validateAccount(ctx, projectID, *data.ToAccountID, data.Currency); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, now); err != nil {
+		return nil, err
+	}
+
+	groupID := uuid.New()
+	name := fmt.Sprintf("Settlement: %s → %s", from.Name, to.Name)
+
+	transactions := []*models.Transaction{models.NewTransaction(models.TransactionData{
+		AccountID:       data.FromAccountID,
+		Value:           data.Amount,
+		Name:            name,
+		Type:            models.Debit,
+		TransactionDate: &now,
+	}, groupID)}
+
+	if data.ToAccountID != nil {
+		transactions = append(transactions, models.NewTransaction(models.TransactionData{
+			AccountID:       *data.ToAccountID,
+			Value:           data.Amount,
+			Name:            name,
+			Type:            models.TopUp,
+			TransactionDate: &now,
+		}, groupID))
+	}
+
+	for _, transaction := range transactions {
+		if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+			return nil, fmt.Errorf("failed to create settlement transaction: %w", err)
+		}
+	}
+
+	settlement := models.NewSettlement(projectID, data.FromID, data.ToID, data.Amount, data.Currency, &groupID)
+	if err := s.settlementRepo.Create(ctx, settlement); err != nil {
+		return nil, fmt.Errorf("failed to record settlement: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("settlement recorded",
+		slog.String("project_id", projectID.String()),
+		slog.String("settlement_id", settlement.ID.String()),
+		slog.String("currency", data.Currency.String()),
+	)
+
+	return settlement, nil
+}
+
+func (s *RecordSettlementService) getMember(ctx context.Context, projectID, accessID uuid.UUID) (*models.Access, error) {
+	access, err := s.accessRepo.GetByID(ctx, accessID)
+	if err != nil {
+		return nil, fmt.Errorf("member not found: %w", err)
+	}
+
+	if access.ProjectID != projectID {
+		return nil, fmt.Errorf("member does not belong to the specified project")
+	}
+
+	return access, nil
+}
+
+func (s *RecordSettlementService) validateAccount(ctx context.Context, projectID, accountID uuid.UUID, currency money.Currency) error {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("account not found: %w", err)
+	}
+
+	if account.ProjectID != projectID {
+		return fmt.Errorf("account does not belong to the specified project")
+	}
+
+	if account.IsArchived() {
+		return fmt.Errorf("account %s is archived", account.Name)
+	}
+
+	if account.Currency != currency {
+		return fmt.Errorf("account %s is in %s, not %s", account.Name, account.Currency, currency)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package record_settlement
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestRecordSettlementService_RecordSettlement(t *testing.T) {
+	ctx := context.Background()
+	settlementRepo := database.NewSettlementInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	accessRepo := database.NewAccessInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewRecordSettlementService(settlementRepo, transactionRepo, accountRepo, accessRepo, projectRepo)
+
+	project := models.NewProject("Home", "home")
+	projectRepo.Create(ctx, project)
+	projectID := project.ID
+	anna := models.NewAccess(projectID, "01", "hash", "Anna", false)
+	bartek := models.NewAccess(projectID, "02", "hash", "Bartek", false)
+	accessRepo.Create(ctx, anna)
+	accessRepo.Create(ctx, bartek)
+
+	annaAccount := models.NewAccount(projectID, "Anna PLN", money.PLN)
+	bartekAccount := models.NewAccount(projectID, "Bartek PLN", money.PLN)
+	euroAccount := models.NewAccount(projectID, "Bartek EUR", money.EUR)
+	for _, account := range []*models.Account{annaAccount, bartekAccount, euroAccount} {
+		accountRepo.Create(ctx, account)
+	}
+
+	tests := []struct {
+		name             string
+		data             SettlementData
+		errorMsg         string
+		wantTransactions int
+	}{
+		{
+			name:             "settlement between two accounts",
+			data:             SettlementData{FromID: bartek.ID, ToID: anna.ID, Amount: 15, Currency: money.PLN, FromAccountID: bartekAccount.ID, ToAccountID: &annaAccount.ID},
+			wantTransactions: 2,
+		},
+		{
+			name:             "settlement paid in cash only debits the payer",
+			data:             SettlementData{FromID: bartek.ID, ToID: anna.ID, Amount: 5, Currency: money.PLN, FromAccountID: bartekAccount.ID},
+			wantTransactions: 1,
+		},
+		{
+			name:     "error when account currency differs",
+			data:     SettlementData{FromID: bartek.ID, ToID: anna.ID, Amount: 5, Currency: money.PLN, FromAccountID: euroAccount.ID},
+			errorMsg: "account Bartek EUR is in EUR, not PLN",
+		},
+		{
+			name:     "error when settling with oneself",
+			data:     SettlementData{FromID: anna.ID, ToID: anna.ID, Amount: 5, Currency: money.PLN, FromAccountID: annaAccount.ID},
+			errorMsg: "a member cannot settle with themselves",
+		},
+		{
+			name:     "error when amount is not positive",
+			data:     SettlementData{FromID: bartek.ID, ToID: anna.ID, Amount: 0, Currency: money.PLN, FromAccountID: bartekAccount.ID},
+			errorMsg: "amount must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settlement, err := service.RecordSettlement(ctx, projectID, tt.data)
+
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error containing '%s', got %v", tt.errorMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			transactions, _ := transactionRepo.GetByGroupID(ctx, *settlement.GroupID)
+			if len(transactions) != tt.wantTransactions {
+				t.Fatalf("Expected %d transactions, got %d", tt.wantTransactions, len(transactions))
+			}
+			for _, transaction := range transactions {
+				if transaction.Value != tt.data.Amount || transaction.Name != "Settlement: Bartek → Anna" {
+					t.Errorf("Unexpected settlement transaction %+v", transaction)
+				}
+				if transaction.AccountID == tt.data.FromAccountID && transaction.Type != models.Debit {
+					t.Errorf("Expected the paying account to be debited")
+				}
+			}
+		})
+	}
+
+	settlements, _ := settlementRepo.GetByProjectID(ctx, projectID)
+	if len(settlements) != 2 {
+		t.Errorf("Expected 2 settlements recorded, got %d", len(settlements))
+	}
+}
+
+func TestRecordSettlementService_RecordSettlement_ClosedPeriod(t *testing.T) {
+	ctx := context.Background()
+	settlementRepo := database.NewSettlementInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	accessRepo := database.NewAccessInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewRecordSettlementService(settlementRepo, transactionRepo, accountRepo, accessRepo, projectRepo)
+
+	lockedUntil := models.MonthEnd(time.Now().Year(), time.Now().Month())
+	project := models.NewProject("Home", "home")
+	project.LockedUntil = &lockedUntil
+	projectRepo.Create(ctx, project)
+
+	anna := models.NewAccess(project.ID, "01", "hash", "Anna", false)
+	bartek := models.NewAccess(project.ID, "02", "hash", "Bartek", false)
+	accessRepo.Create(ctx, anna)
+	accessRepo.Create(ctx, bartek)
+	account := models.NewAccount(project.ID, "Bartek PLN", money.PLN)
+	accountRepo.Create(ctx, account)
+
+	_, err := service.RecordSettlement(ctx, project.ID, SettlementData{FromID: bartek.ID, ToID: anna.ID, Amount: 5, Currency: money.PLN, FromAccountID: account.ID})
+	if err == nil || !strings.Contains(err.Error(), "closed period") {
+		t.Errorf("Expected a closed period error, got %v", err)
+	}
+
+	settlements, _ := settlementRepo.GetByProjectID(ctx, project.ID)
+	transactions, _ := transactionRepo.GetByAccountID(ctx, account.ID)
+	if len(settlements) != 0 || len(transactions) != 0 {
+		t.Errorf("Expected nothing recorded, got %d settlements and %d transactions", len(settlements), len(transactions))
+	}
+}
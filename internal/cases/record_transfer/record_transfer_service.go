@@ -0,0 +1,133 @@
+package record_transfer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type RecordTransferService struct {
+	transferRepo      models.AccountTransferRepository
+	transactionRepo   models.TransactionRepository
+	accountRepo       models.AccountRepository
+	validatePeriodSvc *validate_period.ValidatePeriodService
+}
+
+func NewRecordTransferService(transferRepo models.AccountTransferRepository, transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, projectRepo models.ProjectRepository) *RecordTransferService {
+	return &RecordTransferService{
+		transferRepo:      transferRepo,
+		transactionRepo:   transactionRepo,
+		accountRepo:       accountRepo,
+		validatePeriodSvc: validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+	}
+}
+
+// TransferData describes money moved from one account of the project to another.
+// Both accounts must hold the same currency.
+type TransferData struct {
+	FromAccountID uuid.UUID
+	ToAccountID   uuid.UUID
+	Amount        float64
+	Name          string
+	Date          *time.Time
+}
+
+// RecordTransfer creates the debit and top-up of a transfer under one group and
+// records the group as a transfer, so reports do not count it as income or expense.
+func (s *RecordTransferService) RecordTransfer(ctx context.Context, projectID uuid.UUID, data TransferData) (*models.AccountTransfer, error) {
+	if data.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	if data.FromAccountID == data.ToAccountID {
+		return nil, fmt.Errorf("the receiving account must differ from the paying account")
+	}
+
+	from, err := s.getAccount(ctx, projectID, data.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.getAccount(ctx, projectID, data.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.Currency != to.Currency {
+		return nil, fmt.Errorf("account %s is in %s, not %s", to.Name, to.Currency, from.Currency)
+	}
+
+	date := time.Now()
+	if data.Date != nil {
+		date = *data.Date
+	}
+
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, date); err != nil {
+		return nil, err
+	}
+
+	name := data.Name
+	if name == "" {
+		name = fmt.Sprintf("Transfer: %s → %s", from.Name, to.Name)
+	}
+
+	groupID := uuid.New()
+	transactions := []*models.Transaction{
+		models.NewTransaction(models.TransactionData{
+			AccountID:       from.ID,
+			Value:           data.Amount,
+			Name:            name,
+			Type:            models.Debit,
+			TransactionDate: &date,
+		}, groupID),
+		models.NewTransaction(models.TransactionData{
+			AccountID:       to.ID,
+			Value:           data.Amount,
+			Name:            name,
+			Type:            models.TopUp,
+			TransactionDate: &date,
+		}, groupID),
+	}
+
+	for _, transaction := range transactions {
+		if err := s.transactionRepo.Create(ctx, transaction); err != nil {
+			return nil, fmt.Errorf("failed to create transfer transaction: %w", err)
+		}
+	}
+
+	transfer := models.NewAccountTransfer(projectID, transactions[0], transactions[1])
+	if err := s.transferRepo.Create(ctx, transfer); err != nil {
+		return nil, fmt.Errorf("failed to record transfer: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("transfer recorded",
+		slog.String("project_id", projectID.String()),
+		slog.String("transfer_id", transfer.ID.String()),
+		slog.String("currency", from.Currency.String()),
+	)
+
+	return transfer, nil
+}
+
+func (s *RecordTransferService) getAccount(ctx context.Context, projectID, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	if account.ProjectID != projectID {
+		return nil, fmt.Errorf("account does not belong to the specified project")
+	}
+
+	if account.IsArchived() {
+		return nil, fmt.Errorf("account %s is archived", account.Name)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,132 @@
+package record_transfer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestRecordTransferService_RecordTransfer(t *testing.T) {
+	ctx := context.Background()
+	transferRepo := database.NewAccountTransferInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewRecordTransferService(transferRepo, transactionRepo, accountRepo, projectRepo)
+
+	project := models.NewProject("Home", "home")
+	projectRepo.Create(ctx, project)
+	projectID := project.ID
+
+	checking := models.NewAccount(projectID, "Checking", money.PLN)
+	savings := models.NewAccount(projectID, "Savings", money.PLN)
+	euro := models.NewAccount(projectID, "Euro", money.EUR)
+	other := models.NewAccount(models.NewProject("Other", "other").ID, "Other", money.PLN)
+	for _, account := range []*models.Account{checking, savings, euro, other} {
+		accountRepo.Create(ctx, account)
+	}
+
+	tests := []struct {
+		name     string
+		data     TransferData
+		errorMsg string
+	}{
+		{
+			name: "transfer between two accounts",
+			data: TransferData{FromAccountID: checking.ID, ToAccountID: savings.ID, Amount: 250},
+		},
+		{
+			name:     "error when account currency differs",
+			data:     TransferData{FromAccountID: checking.ID, ToAccountID: euro.ID, Amount: 250},
+			errorMsg: "account Euro is in EUR, not PLN",
+		},
+		{
+			name:     "error when transferring to the same account",
+			data:     TransferData{FromAccountID: checking.ID, ToAccountID: checking.ID, Amount: 250},
+			errorMsg: "the receiving account must differ from the paying account",
+		},
+		{
+			name:     "error when account belongs to another project",
+			data:     TransferData{FromAccountID: checking.ID, ToAccountID: other.ID, Amount: 250},
+			errorMsg: "account does not belong to the specified project",
+		},
+		{
+			name:     "error when amount is not positive",
+			data:     TransferData{FromAccountID: checking.ID, ToAccountID: savings.ID, Amount: 0},
+			errorMsg: "amount must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfer, err := service.RecordTransfer(ctx, projectID, tt.data)
+
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error containing '%s', got %v", tt.errorMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			transactions, _ := transactionRepo.GetByGroupID(ctx, transfer.GroupID)
+			if len(transactions) != 2 {
+				t.Fatalf("Expected 2 transactions, got %d", len(transactions))
+			}
+			for _, transaction := range transactions {
+				if transaction.Value != tt.data.Amount || transaction.Name != "Transfer: Checking → Savings" {
+					t.Errorf("Unexpected transfer transaction %+v", transaction)
+				}
+				if transaction.AccountID == tt.data.FromAccountID && transaction.Type != models.Debit {
+					t.Errorf("Expected the paying account to be debited")
+				}
+				if transaction.AccountID == tt.data.ToAccountID && transaction.Type != models.TopUp {
+					t.Errorf("Expected the receiving account to be topped up")
+				}
+			}
+		})
+	}
+
+	transfers, _ := transferRepo.GetByProjectID(ctx, projectID)
+	if len(transfers) != 1 {
+		t.Errorf("Expected 1 transfer recorded, got %d", len(transfers))
+	}
+}
+
+func TestRecordTransferService_RecordTransfer_ClosedPeriod(t *testing.T) {
+	ctx := context.Background()
+	transferRepo := database.NewAccountTransferInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewRecordTransferService(transferRepo, transactionRepo, accountRepo, projectRepo)
+
+	lockedUntil := models.MonthEnd(time.Now().Year(), time.Now().Month())
+	project := models.NewProject("Home", "home")
+	project.LockedUntil = &lockedUntil
+	projectRepo.Create(ctx, project)
+
+	checking := models.NewAccount(project.ID, "Checking", money.PLN)
+	savings := models.NewAccount(project.ID, "Savings", money.PLN)
+	accountRepo.Create(ctx, checking)
+	accountRepo.Create(ctx, savings)
+
+	_, err := service.RecordTransfer(ctx, project.ID, TransferData{FromAccountID: checking.ID, ToAccountID: savings.ID, Amount: 5})
+	if err == nil || !strings.Contains(err.Error(), "closed period") {
+		t.Errorf("Expected a closed period error, got %v", err)
+	}
+
+	transfers, _ := transferRepo.GetByProjectID(ctx, project.ID)
+	transactions, _ := transactionRepo.GetByAccountID(ctx, checking.ID)
+	if len(transfers) != 0 || len(transactions) != 0 {
+		t.Errorf("Expected nothing recorded, got %d transfers and %d transactions", len(transfers), len(transactions))
+	}
+}
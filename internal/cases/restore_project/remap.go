@@ -0,0 +1,198 @@
+package restore_project
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/backup_project"
+	"gofin/internal/models"
+)
+
+// restore copies archived records into the restored project, handing out a
+// new ID for every archived one and rewriting the references between them.
+type restore struct {
+	project *models.Project
+	ids     map[uuid.UUID]uuid.UUID
+	now     time.Time
+}
+
+func newRestore(project *models.Project) *restore {
+	return &restore{
+		project: project,
+		ids:     make(map[uuid.UUID]uuid.UUID),
+		now:     time.Now(),
+	}
+}
+
+// id returns the new ID of an archived one, the same on every call.
+func (r *restore) id(archived uuid.UUID) uuid.UUID {
+	if id, ok := r.ids[archived]; ok {
+		return id
+	}
+	id := uuid.New()
+	r.ids[archived] = id
+	return id
+}
+
+func (r *restore) optionalID(archived *uuid.UUID) *uuid.UUID {
+	if archived == nil {
+		return nil
+	}
+	id := r.id(*archived)
+	return &id
+}
+
+func (r *restore) access(record *backup_project.AccessRecord) *models.Access {
+	return &models.Access{
+		ID:        r.id(record.ID),
+		ProjectID: r.project.ID,
+		UID:       record.UID,
+		PinHash:   record.PinHash,
+		Name:      record.Name,
+		ReadOnly:  record.ReadOnly,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: r.now,
+	}
+}
+
+func (r *restore) account(archived *models.Account) *models.Account {
+	account := *archived
+	account.ID = r.id(archived.ID)
+	account.ProjectID = r.project.ID
+	return &account
+}
+
+func (r *restore) category(archived *models.Category) *models.Category {
+	category := *archived
+	category.ID = r.id(archived.ID)
+	category.ProjectID = r.project.ID
+	return &category
+}
+
+func (r *restore) payee(archived *models.Payee) *models.Payee {
+	payee := *archived
+	payee.ID = r.id(archived.ID)
+	payee.ProjectID = r.project.ID
+	payee.DefaultCategoryID = r.optionalID(archived.DefaultCategoryID)
+	return &payee
+}
+
+func (r *restore) alias(archived *models.PayeeAlias) *models.PayeeAlias {
+	alias := *archived
+	alias.ID = r.id(archived.ID)
+	alias.PayeeID = r.id(archived.PayeeID)
+	alias.ProjectID = r.project.ID
+	return &alias
+}
+
+func (r *restore) transaction(archived *models.Transaction) *models.Transaction {
+	transaction := *archived
+	transaction.ID = r.id(archived.ID)
+	transaction.AccountID = r.id(archived.AccountID)
+	transaction.CategoryID = r.optionalID(archived.CategoryID)
+	transaction.PayeeID = r.optionalID(archived.PayeeID)
+	transaction.GroupID = r.optionalID(archived.GroupID)
+	return &transaction
+}
+
+func (r *restore) split(archived *models.TransactionSplit) *models.TransactionSplit {
+	split := *archived
+	split.ID = r.id(archived.ID)
+	split.TransactionID = r.id(archived.TransactionID)
+	split.CategoryID = r.id(archived.CategoryID)
+	return &split
+}
+
+func (r *restore) loan(archived *models.Loan) *models.Loan {
+	loan := *archived
+	loan.AccountID = r.id(archived.AccountID)
+	loan.ProjectID = r.project.ID
+	return &loan
+}
+
+func (r *restore) ratePeriod(archived *models.LoanRatePeriod) *models.LoanRatePeriod {
+	period := *archived
+	period.ID = r.id(archived.ID)
+	period.AccountID = r.id(archived.AccountID)
+	return &period
+}
+
+func (r *restore) security(archived *models.Security) *models.Security {
+	security := *archived
+	security.ID = r.id(archived.ID)
+	security.ProjectID = r.project.ID
+	return &security
+}
+
+func (r *restore) price(archived *models.SecurityPrice) *models.SecurityPrice {
+	price := *archived
+	price.SecurityID = r.id(archived.SecurityID)
+	return &price
+}
+
+func (r *restore) operation(archived *models.InvestmentOperation) *models.InvestmentOperation {
+	operation := *archived
+	operation.ID = r.id(archived.ID)
+	operation.AccountID = r.id(archived.AccountID)
+	operation.SecurityID = r.id(archived.SecurityID)
+	operation.TransactionID = r.id(archived.TransactionID)
+	return &operation
+}
+
+func (r *restore) sharedExpense(archived *models.SharedExpense) *models.SharedExpense {
+	expense := *archived
+	expense.ID = r.id(archived.ID)
+	expense.ProjectID = r.project.ID
+	expense.TransactionID = r.id(archived.TransactionID)
+	expense.PayerID = r.id(archived.PayerID)
+	return &expense
+}
+
+func (r *restore) share(archived *models.ExpenseShare) *models.ExpenseShare {
+	share := *archived
+	share.ID = r.id(archived.ID)
+	share.ExpenseID = r.id(archived.ExpenseID)
+	share.AccessID = r.id(archived.AccessID)
+	return &share
+}
+
+func (r *restore) settlement(archived *models.Settlement) *models.Settlement {
+	settlement := *archived
+	settlement.ID = r.id(archived.ID)
+	settlement.ProjectID = r.project.ID
+	settlement.FromID = r.id(archived.FromID)
+	settlement.ToID = r.id(archived.ToID)
+	settlement.GroupID = r.optionalID(archived.GroupID)
+	return &settlement
+}
+
+func (r *restore) transfer(archived *models.AccountTransfer) *models.AccountTransfer {
+	transfer := *archived
+	transfer.ID = r.id(archived.ID)
+	transfer.ProjectID = r.project.ID
+	transfer.FromTransactionID = r.id(archived.FromTransactionID)
+	transfer.ToTransactionID = r.id(archived.ToTransactionID)
+	transfer.GroupID = r.id(archived.GroupID)
+	return &transfer
+}
+
+func (r *restore) reconciliation(archived *models.Reconciliation) *models.Reconciliation {
+	reconciliation := *archived
+	reconciliation.ID = r.id(archived.ID)
+	reconciliation.AccountID = r.id(archived.AccountID)
+	return &reconciliation
+}
+
+func (r *restore) periodLockEvent(archived *models.PeriodLockEvent) *models.PeriodLockEvent {
+	event := *archived
+	event.ID = r.id(archived.ID)
+	event.ProjectID = r.project.ID
+	return &event
+}
+
+func (r *restore) attachment(record *backup_project.AttachmentRecord) *models.Attachment {
+	attachment := record.Attachment
+	attachment.ID = r.id(record.ID)
+	attachment.TransactionID = r.id(record.TransactionID)
+	return &attachment
+}
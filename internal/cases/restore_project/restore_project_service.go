@@ -0,0 +1,354 @@
+package restore_project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/backup_project"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	"gofin/pkg/slug"
+)
+
+type RestoreProjectService struct {
+	projectRepo        models.ProjectRepository
+	accessRepo         models.AccessRepository
+	accountRepo        models.AccountRepository
+	transactionRepo    models.TransactionRepository
+	categoryRepo       models.CategoryRepository
+	splitRepo          models.TransactionSplitRepository
+	payeeRepo          models.PayeeRepository
+	loanRepo           models.LoanRepository
+	securityRepo       models.SecurityRepository
+	operationRepo      models.InvestmentOperationRepository
+	sharedExpenseRepo  models.SharedExpenseRepository
+	settlementRepo     models.SettlementRepository
+	transferRepo       models.AccountTransferRepository
+	reconciliationRepo models.ReconciliationRepository
+	periodLockRepo     models.PeriodLockEventRepository
+	attachmentRepo     models.AttachmentRepository
+	blobs              models.BlobStore
+}
+
+func NewRestoreProjectService(projectRepo models.ProjectRepository, accessRepo models.AccessRepository, accountRepo models.AccountRepository, transactionRepo models.TransactionRepository, categoryRepo models.CategoryRepository, splitRepo models.TransactionSplitRepository, payeeRepo models.PayeeRepository, loanRepo models.LoanRepository, securityRepo models.SecurityRepository, operationRepo models.InvestmentOperationRepository, sharedExpenseRepo models.SharedExpenseRepository, settlementRepo models.SettlementRepository, transferRepo models.AccountTransferRepository, reconciliationRepo models.ReconciliationRepository, periodLockRepo models.PeriodLockEventRepository, attachmentRepo models.AttachmentRepository, blobs models.BlobStore) *RestoreProjectService {
+	return &RestoreProjectService{
+		projectRepo:        projectRepo,
+		accessRepo:         accessRepo,
+		accountRepo:        accountRepo,
+		transactionRepo:    transactionRepo,
+		categoryRepo:       categoryRepo,
+		splitRepo:          splitRepo,
+		payeeRepo:          payeeRepo,
+		loanRepo:           loanRepo,
+		securityRepo:       securityRepo,
+		operationRepo:      operationRepo,
+		sharedExpenseRepo:  sharedExpenseRepo,
+		settlementRepo:     settlementRepo,
+		transferRepo:       transferRepo,
+		reconciliationRepo: reconciliationRepo,
+		periodLockRepo:     periodLockRepo,
+		attachmentRepo:     attachmentRepo,
+		blobs:              blobs,
+	}
+}
+
+// RestoreOptions replace the slug and name of the backed-up project, e.g. to
+// restore a copy next to the original. Empty keeps the archived ones.
+type RestoreOptions struct {
+	Slug string
+	Name string
+}
+
+type RestoreResult struct {
+	Project *models.Project
+	Counts  backup_project.Counts
+	// TwoFactorReset is how many accesses have to enroll their second factor
+	// again, as TOTP secrets are not backed up.
+	TwoFactorReset int
+}
+
+// Restore recreates a backed-up project as a new project. Every record gets a
+// new ID, so a backup can be restored into the database it came from as well
+// as into another one. The archive is checked in full before anything is
+// written, and the restored balances are compared with it afterwards.
+func (s *RestoreProjectService) Restore(ctx context.Context, r io.Reader, options RestoreOptions) (*RestoreResult, error) {
+	archive, err := backup_project.ReadArchive(r)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &archive.Data
+	if err := validate(data); err != nil {
+		return nil, fmt.Errorf("backup failed the integrity check: %w", err)
+	}
+
+	project, err := s.newProject(ctx, data.Project, options)
+	if err != nil {
+		return nil, err
+	}
+
+	restore := newRestore(project)
+	result := &RestoreResult{Project: project, Counts: archive.Counts}
+
+	if err := s.projectRepo.Create(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	for _, record := range data.Accesses {
+		if record.TOTPEnabled {
+			result.TwoFactorReset++
+		}
+		if err := s.accessRepo.Create(ctx, restore.access(record)); err != nil {
+			return nil, fmt.Errorf("failed to restore access %s: %w", record.UID, err)
+		}
+	}
+
+	for _, account := range data.Accounts {
+		if err := s.accountRepo.Create(ctx, restore.account(account)); err != nil {
+			return nil, fmt.Errorf("failed to restore account %s: %w", account.Name, err)
+		}
+	}
+
+	for _, category := range data.Categories {
+		if err := s.categoryRepo.Create(ctx, restore.category(category)); err != nil {
+			return nil, fmt.Errorf("failed to restore category %s: %w", category.Name, err)
+		}
+	}
+
+	for _, payee := range data.Payees {
+		if err := s.payeeRepo.Create(ctx, restore.payee(payee)); err != nil {
+			return nil, fmt.Errorf("failed to restore payee %s: %w", payee.Name, err)
+		}
+	}
+
+	for _, alias := range data.PayeeAliases {
+		if err := s.payeeRepo.AddAlias(ctx, restore.alias(alias)); err != nil {
+			return nil, fmt.Errorf("failed to restore payee alias %s: %w", alias.Alias, err)
+		}
+	}
+
+	for _, transaction := range data.Transactions {
+		if err := s.transactionRepo.Create(ctx, restore.transaction(transaction)); err != nil {
+			return nil, fmt.Errorf("failed to restore transaction %s: %w", transaction.Name, err)
+		}
+	}
+
+	splitsByTransaction := make(map[uuid.UUID][]*models.TransactionSplit)
+	var splitOrder []uuid.UUID
+	for _, split := range data.Splits {
+		restored := restore.split(split)
+		if _, ok := splitsByTransaction[restored.TransactionID]; !ok {
+			splitOrder = append(splitOrder, restored.TransactionID)
+		}
+		splitsByTransaction[restored.TransactionID] = append(splitsByTransaction[restored.TransactionID], restored)
+	}
+	for _, transactionID := range splitOrder {
+		if err := s.splitRepo.ReplaceForTransaction(ctx, transactionID, splitsByTransaction[transactionID]); err != nil {
+			return nil, fmt.Errorf("failed to restore transaction splits: %w", err)
+		}
+	}
+
+	if err := s.restoreAccountRecords(ctx, data, restore); err != nil {
+		return nil, err
+	}
+
+	if err := s.restoreProjectRecords(ctx, data, restore); err != nil {
+		return nil, err
+	}
+
+	for _, record := range data.Attachments {
+		if err := s.restoreAttachment(ctx, record, restore); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.verify(ctx, data, restore); err != nil {
+		return nil, fmt.Errorf("restored project %s does not match the backup: %w", project.Slug, err)
+	}
+
+	logging.FromContext(ctx).Info("project restored",
+		slog.String("project_id", project.ID.String()),
+		slog.String("slug", project.Slug),
+		slog.String("backup_project_id", data.Project.ID.String()),
+		slog.Int("transactions", result.Counts.Transactions))
+
+	return result, nil
+}
+
+func (s *RestoreProjectService) newProject(ctx context.Context, archived *models.Project, options RestoreOptions) (*models.Project, error) {
+	projectSlug := archived.Slug
+	if options.Slug != "" {
+		projectSlug = options.Slug
+	}
+	if err := slug.Validate(projectSlug); err != nil {
+		return nil, fmt.Errorf("invalid slug: %w", err)
+	}
+
+	exists, err := s.projectRepo.ExistsBySlug(ctx, projectSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check slug availability: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("project %s already exists, restore it under another slug", projectSlug)
+	}
+
+	name := archived.Name
+	if options.Name != "" {
+		name = options.Name
+	}
+
+	return &models.Project{
+		ID:               uuid.New(),
+		Slug:             projectSlug,
+		Name:             name,
+		RequireTwoFactor: archived.RequireTwoFactor,
+		LockedUntil:      archived.LockedUntil,
+		CreatedAt:        archived.CreatedAt,
+		UpdatedAt:        time.Now(),
+	}, nil
+}
+
+// restoreAccountRecords recreates the loans and their rates, the securities
+// with their prices, the investment operations and the reconciliations.
+func (s *RestoreProjectService) restoreAccountRecords(ctx context.Context, data *backup_project.ProjectData, restore *restore) error {
+	for _, loan := range data.Loans {
+		if err := s.loanRepo.Create(ctx, restore.loan(loan)); err != nil {
+			return fmt.Errorf("failed to restore loan: %w", err)
+		}
+	}
+
+	for _, period := range data.LoanRatePeriods {
+		if err := s.loanRepo.AddRatePeriod(ctx, restore.ratePeriod(period)); err != nil {
+			return fmt.Errorf("failed to restore loan rate period: %w", err)
+		}
+	}
+
+	for _, security := range data.Securities {
+		if err := s.securityRepo.Create(ctx, restore.security(security)); err != nil {
+			return fmt.Errorf("failed to restore security %s: %w", security.Ticker, err)
+		}
+	}
+
+	for _, price := range data.SecurityPrices {
+		if err := s.securityRepo.SetPrice(ctx, restore.price(price)); err != nil {
+			return fmt.Errorf("failed to restore security price: %w", err)
+		}
+	}
+
+	for _, operation := range data.InvestmentOperations {
+		if err := s.operationRepo.Create(ctx, restore.operation(operation)); err != nil {
+			return fmt.Errorf("failed to restore investment operation: %w", err)
+		}
+	}
+
+	for _, reconciliation := range data.Reconciliations {
+		if err := s.reconciliationRepo.Create(ctx, restore.reconciliation(reconciliation)); err != nil {
+			return fmt.Errorf("failed to restore reconciliation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// restoreProjectRecords recreates the shared expenses with their shares, the
+// settlements, the transfers and the period lock history.
+func (s *RestoreProjectService) restoreProjectRecords(ctx context.Context, data *backup_project.ProjectData, restore *restore) error {
+	shares := make(map[uuid.UUID][]*models.ExpenseShare)
+	for _, share := range data.ExpenseShares {
+		shares[share.ExpenseID] = append(shares[share.ExpenseID], restore.share(share))
+	}
+
+	for _, expense := range data.SharedExpenses {
+		if err := s.sharedExpenseRepo.Create(ctx, restore.sharedExpense(expense), shares[expense.ID]); err != nil {
+			return fmt.Errorf("failed to restore shared expense: %w", err)
+		}
+	}
+
+	for _, settlement := range data.Settlements {
+		if err := s.settlementRepo.Create(ctx, restore.settlement(settlement)); err != nil {
+			return fmt.Errorf("failed to restore settlement: %w", err)
+		}
+	}
+
+	for _, transfer := range data.Transfers {
+		if err := s.transferRepo.Create(ctx, restore.transfer(transfer)); err != nil {
+			return fmt.Errorf("failed to restore transfer: %w", err)
+		}
+	}
+
+	for _, event := range data.PeriodLockEvents {
+		if err := s.periodLockRepo.Create(ctx, restore.periodLockEvent(event)); err != nil {
+			return fmt.Errorf("failed to restore period lock event: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// restoreAttachment stores the file and thumbnail under their checksum, unless
+// the blob store already holds them, and recreates the attachment.
+func (s *RestoreProjectService) restoreAttachment(ctx context.Context, record *backup_project.AttachmentRecord, restore *restore) error {
+	attachment := restore.attachment(record)
+
+	if err := s.putIfMissing(ctx, attachment.BlobKey(), record.Content); err != nil {
+		return fmt.Errorf("failed to restore attachment %s: %w", attachment.FileName, err)
+	}
+
+	if attachment.HasThumbnail {
+		if err := s.putIfMissing(ctx, attachment.ThumbnailKey(), record.Thumbnail); err != nil {
+			return fmt.Errorf("failed to restore thumbnail of %s: %w", attachment.FileName, err)
+		}
+	}
+
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		return fmt.Errorf("failed to restore attachment %s: %w", attachment.FileName, err)
+	}
+
+	return nil
+}
+
+func (s *RestoreProjectService) putIfMissing(ctx context.Context, key string, content []byte) error {
+	exists, err := s.blobs.Exists(ctx, key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return s.blobs.Put(ctx, key, bytes.NewReader(content))
+}
+
+// verify reads every restored account back and compares its transaction count
+// and balance with the archive.
+func (s *RestoreProjectService) verify(ctx context.Context, data *backup_project.ProjectData, restore *restore) error {
+	expectedCount := make(map[uuid.UUID]int)
+	expectedCents := make(map[uuid.UUID]int64)
+	for _, transaction := range data.Transactions {
+		expectedCount[transaction.AccountID]++
+		expectedCents[transaction.AccountID] += models.ToCents(transaction.SignedValue())
+	}
+
+	for _, account := range data.Accounts {
+		transactions, err := s.transactionRepo.GetByAccountID(ctx, restore.ids[account.ID])
+		if err != nil {
+			return fmt.Errorf("failed to read back account %s: %w", account.Name, err)
+		}
+
+		var cents int64
+		for _, transaction := range transactions {
+			cents += models.ToCents(transaction.SignedValue())
+		}
+
+		if len(transactions) != expectedCount[account.ID] || cents != expectedCents[account.ID] {
+			return fmt.Errorf("account %s has %d transactions with a balance of %.2f, expected %d with %.2f",
+				account.Name, len(transactions), float64(cents)/100, expectedCount[account.ID], float64(expectedCents[account.ID])/100)
+		}
+	}
+
+	return nil
+}
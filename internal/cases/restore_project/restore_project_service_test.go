@@ -0,0 +1,486 @@
+package restore_project
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/backup_project"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/infrastructure/storage"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+type testRepos struct {
+	projects        models.ProjectRepository
+	accesses        *database.AccessInMemoryRepository
+	accounts        *database.AccountInMemoryRepository
+	transactions    *database.TransactionInMemoryRepository
+	categories      *database.CategoryInMemoryRepository
+	splits          *database.TransactionSplitInMemoryRepository
+	payees          *database.PayeeInMemoryRepository
+	loans           *database.LoanInMemoryRepository
+	securities      *database.SecurityInMemoryRepository
+	operations      *database.InvestmentOperationInMemoryRepository
+	shared          *database.SharedExpenseInMemoryRepository
+	settlements     *database.SettlementInMemoryRepository
+	transfers       *database.AccountTransferInMemoryRepository
+	reconciliations *database.ReconciliationInMemoryRepository
+	periodLocks     *database.PeriodLockEventInMemoryRepository
+	attachments     *database.AttachmentInMemoryRepository
+	blobs           *storage.InMemoryBlobStore
+}
+
+func newTestRepos() testRepos {
+	return testRepos{
+		projects:        database.NewProjectInMemoryRepository(),
+		accesses:        database.NewAccessInMemoryRepository(),
+		accounts:        database.NewAccountInMemoryRepository(),
+		transactions:    database.NewTransactionInMemoryRepository(),
+		categories:      database.NewCategoryInMemoryRepository(),
+		splits:          database.NewTransactionSplitInMemoryRepository(),
+		payees:          database.NewPayeeInMemoryRepository(),
+		loans:           database.NewLoanInMemoryRepository(),
+		securities:      database.NewSecurityInMemoryRepository(),
+		operations:      database.NewInvestmentOperationInMemoryRepository(),
+		shared:          database.NewSharedExpenseInMemoryRepository(),
+		settlements:     database.NewSettlementInMemoryRepository(),
+		transfers:       database.NewAccountTransferInMemoryRepository(),
+		reconciliations: database.NewReconciliationInMemoryRepository(),
+		periodLocks:     database.NewPeriodLockEventInMemoryRepository(),
+		attachments:     database.NewAttachmentInMemoryRepository(),
+		blobs:           storage.NewInMemoryBlobStore(),
+	}
+}
+
+func (r testRepos) backupService() *backup_project.BackupProjectService {
+	return backup_project.NewBackupProjectService(r.projects, r.accesses, r.accounts, r.transactions, r.categories, r.splits, r.payees, r.loans, r.securities, r.operations, r.shared, r.settlements, r.transfers, r.reconciliations, r.periodLocks, r.attachments, r.blobs)
+}
+
+func (r testRepos) restoreService() *RestoreProjectService {
+	return NewRestoreProjectService(r.projects, r.accesses, r.accounts, r.transactions, r.categories, r.splits, r.payees, r.loans, r.securities, r.operations, r.shared, r.settlements, r.transfers, r.reconciliations, r.periodLocks, r.attachments, r.blobs)
+}
+
+// backupTestProject fills repos with a small project and returns its backup.
+func backupTestProject(t *testing.T, repos testRepos) (*models.Project, []byte) {
+	t.Helper()
+	ctx := context.Background()
+
+	project := models.NewProject("Home", "home")
+	lockedUntil := models.MonthEnd(2024, time.January)
+	project.LockedUntil = &lockedUntil
+	project.RequireTwoFactor = true
+	repos.projects.Create(ctx, project)
+
+	anna := models.NewAccess(project.ID, "anna", "pin-hash", "Anna", false)
+	anna.TOTPEnabled = true
+	anna.TOTPSecret = "secret"
+	repos.accesses.Create(ctx, anna)
+	repos.accesses.Create(ctx, models.NewAccess(project.ID, "viewer", "viewer-hash", "Viewer", true))
+
+	account := models.NewAccount(project.ID, "Checking", money.Currency("PLN"))
+	repos.accounts.Create(ctx, account)
+
+	food := models.NewCategory(project.ID, "Food")
+	health := models.NewCategory(project.ID, "Health")
+	repos.categories.Create(ctx, food)
+	repos.categories.Create(ctx, health)
+
+	shop := models.NewPayee(project.ID, "Shop", &food.ID)
+	repos.payees.Create(ctx, shop)
+	repos.payees.AddAlias(ctx, models.NewPayeeAlias(shop, "shop"))
+
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+	salary := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 1000, Name: "Salary", Type: models.TopUp, TransactionDate: &date, ExternalID: "FIT-1"})
+	receipt := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 30, Name: "Shop", Type: models.Debit, TransactionDate: &date, PayeeID: &shop.ID})
+	repos.transactions.Create(ctx, salary)
+	repos.transactions.Create(ctx, receipt)
+	repos.splits.ReplaceForTransaction(ctx, receipt.ID, models.NewTransactionSplits(receipt.ID, []models.SplitData{
+		{CategoryID: food.ID, Amount: 20},
+		{CategoryID: health.ID, Amount: 10, Memo: "Vitamins"},
+	}))
+
+	addTestProjectRecords(t, repos, project, anna, account, receipt)
+
+	backup := repos.backupService()
+	var buf bytes.Buffer
+	if _, err := backup.Backup(ctx, &buf, project.ID); err != nil {
+		t.Fatalf("Failed to back up project: %v", err)
+	}
+
+	return project, buf.Bytes()
+}
+
+// addTestProjectRecords adds a loan, an investment, a shared expense with a
+// settlement, a transfer, a finished reconciliation, a period lock event and
+// an attachment to the project.
+func addTestProjectRecords(t *testing.T, repos testRepos, project *models.Project, member *models.Access, checking *models.Account, receipt *models.Transaction) {
+	t.Helper()
+	ctx := context.Background()
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	savings := models.NewAccount(project.ID, "Savings", money.PLN)
+	savings.Position = 1
+	mortgage := models.NewAccount(project.ID, "Mortgage", money.PLN)
+	mortgage.Type = models.AccountLoan
+	mortgage.Position = 2
+	brokerage := models.NewAccount(project.ID, "Brokerage", money.PLN)
+	brokerage.Type = models.AccountInvestment
+	brokerage.Position = 3
+	for _, account := range []*models.Account{savings, mortgage, brokerage} {
+		repos.accounts.Create(ctx, account)
+	}
+
+	repos.loans.Create(ctx, models.NewLoan(mortgage, 12000, 6, 12, 10, date))
+	repos.loans.AddRatePeriod(ctx, models.NewLoanRatePeriod(mortgage.ID, date.AddDate(0, 6, 0), 7))
+	repos.transactions.Create(ctx, models.NewTransaction(models.TransactionData{AccountID: mortgage.ID, Value: 12000, Name: "Mortgage: principal", Type: models.Debit, TransactionDate: &date}))
+
+	transfer := uuid.New()
+	debit := models.NewTransaction(models.TransactionData{AccountID: savings.ID, Value: 500, Name: "Transfer", Type: models.Debit, TransactionDate: &date}, transfer)
+	topUp := models.NewTransaction(models.TransactionData{AccountID: brokerage.ID, Value: 500, Name: "Transfer", Type: models.TopUp, TransactionDate: &date}, transfer)
+	repos.transactions.Create(ctx, debit)
+	repos.transactions.Create(ctx, topUp)
+	repos.transfers.Create(ctx, models.NewAccountTransfer(project.ID, debit, topUp))
+
+	security := models.NewSecurity(project.ID, "VWCE", "FTSE All-World", money.PLN)
+	repos.securities.Create(ctx, security)
+	repos.securities.SetPrice(ctx, models.NewSecurityPrice(security.ID, date, 110))
+	purchase := models.NewTransaction(models.TransactionData{AccountID: brokerage.ID, Value: 400, Name: "Buy VWCE", Type: models.Debit, TransactionDate: &date})
+	repos.transactions.Create(ctx, purchase)
+	operation := models.NewInvestmentOperation(brokerage.ID, security.ID, models.OperationBuy, 4, 100, 0, 400, date)
+	operation.TransactionID = purchase.ID
+	repos.operations.Create(ctx, operation)
+
+	other := models.NewAccess(project.ID, "bartek", "bartek-hash", "Bartek", false)
+	repos.accesses.Create(ctx, other)
+	expense := models.NewSharedExpense(project.ID, receipt.ID, member.ID, models.ShareEqual, 30, money.PLN)
+	shares, err := models.ComputeShares(expense.ID, models.ShareEqual, 30, []models.ShareData{{AccessID: member.ID}, {AccessID: other.ID}})
+	if err != nil {
+		t.Fatalf("Failed to compute shares: %v", err)
+	}
+	repos.shared.Create(ctx, expense, shares)
+
+	settlement := uuid.New()
+	repos.transactions.Create(ctx, models.NewTransaction(models.TransactionData{AccountID: savings.ID, Value: 15, Name: "Settlement", Type: models.Debit, TransactionDate: &date}, settlement))
+	repos.settlements.Create(ctx, models.NewSettlement(project.ID, other.ID, member.ID, 15, money.PLN, &settlement))
+
+	reconciliation := models.NewReconciliation(checking.ID, date, 970)
+	reconciliation.FinishedAt = &date
+	repos.reconciliations.Create(ctx, reconciliation)
+
+	repos.periodLocks.Create(ctx, models.NewPeriodLockEvent(project, models.PeriodClosed, nil, "anna", "January filed"))
+
+	content := []byte("receipt")
+	sum := sha256.Sum256(content)
+	attachment := models.NewAttachment(receipt.ID, "receipt.txt", "text/plain", int64(len(content)), hex.EncodeToString(sum[:]))
+	repos.blobs.Put(ctx, attachment.BlobKey(), bytes.NewReader(content))
+	repos.attachments.Create(ctx, attachment)
+}
+
+func TestRestoreProjectService_Restore_ProjectRecords(t *testing.T) {
+	ctx := context.Background()
+	_, archive := backupTestProject(t, newTestRepos())
+
+	repos := newTestRepos()
+	result, err := repos.restoreService().Restore(ctx, bytes.NewReader(archive), RestoreOptions{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	project := result.Project
+
+	accounts, _ := repos.accounts.GetByProjectID(ctx, project.ID)
+	byName := make(map[string]*models.Account)
+	for _, account := range accounts {
+		byName[account.Name] = account
+	}
+	checking, mortgage, brokerage := byName["Checking"], byName["Mortgage"], byName["Brokerage"]
+	if checking == nil || mortgage == nil || brokerage == nil {
+		t.Fatalf("Expected the accounts to be restored, got %d", len(accounts))
+	}
+
+	if loan, err := repos.loans.GetByAccountID(ctx, mortgage.ID); err != nil || loan.Principal != 12000 || loan.ProjectID != project.ID {
+		t.Errorf("Expected the loan terms on the restored mortgage, got %+v (%v)", loan, err)
+	}
+	if periods, _ := repos.loans.GetRatePeriods(ctx, mortgage.ID); len(periods) != 1 || periods[0].AnnualRate != 7 {
+		t.Errorf("Expected the rate period on the restored mortgage, got %+v", periods)
+	}
+
+	security, err := repos.securities.GetByTicker(ctx, project.ID, "VWCE")
+	if err != nil {
+		t.Fatalf("Expected the security to be restored: %v", err)
+	}
+	if prices, _ := repos.securities.GetPrices(ctx, security.ID); len(prices) != 1 || prices[0].Price != 110 {
+		t.Errorf("Expected the price to be restored, got %+v", prices)
+	}
+	operations, _ := repos.operations.GetByAccountID(ctx, brokerage.ID)
+	if len(operations) != 1 || operations[0].SecurityID != security.ID {
+		t.Fatalf("Expected the operation to point at the restored security, got %+v", operations)
+	}
+	if purchase, err := repos.transactions.GetByID(ctx, operations[0].TransactionID); err != nil || purchase.AccountID != brokerage.ID {
+		t.Errorf("Expected the operation to point at the restored purchase, got %v", err)
+	}
+
+	transfers, _ := repos.transfers.GetByProjectID(ctx, project.ID)
+	if len(transfers) != 1 {
+		t.Fatalf("Expected one transfer, got %d", len(transfers))
+	}
+	if legs, _ := repos.transactions.GetByGroupID(ctx, transfers[0].GroupID); len(legs) != 2 {
+		t.Errorf("Expected the transfer group to hold both restored legs, got %d", len(legs))
+	}
+
+	anna, _ := repos.accesses.GetByUID(ctx, project.ID, "anna")
+	receipts, _ := repos.transactions.GetByAccountID(ctx, checking.ID)
+	var receipt *models.Transaction
+	for _, transaction := range receipts {
+		if transaction.Name == "Shop" {
+			receipt = transaction
+		}
+	}
+	expense, shares, err := repos.shared.GetByTransactionID(ctx, receipt.ID)
+	if err != nil || expense.PayerID != anna.ID || len(shares) != 2 {
+		t.Errorf("Expected the shared expense on the restored receipt, got %+v, %d shares (%v)", expense, len(shares), err)
+	}
+
+	settlements, _ := repos.settlements.GetByProjectID(ctx, project.ID)
+	if len(settlements) != 1 || settlements[0].ToID != anna.ID {
+		t.Fatalf("Expected the settlement to point at the restored member, got %+v", settlements)
+	}
+	if legs, _ := repos.transactions.GetByGroupID(ctx, *settlements[0].GroupID); len(legs) != 1 {
+		t.Errorf("Expected the settlement group to hold its restored leg, got %d", len(legs))
+	}
+
+	if reconciliations, _ := repos.reconciliations.GetByAccountID(ctx, checking.ID); len(reconciliations) != 1 || reconciliations[0].FinishedAt == nil {
+		t.Errorf("Expected the finished reconciliation to be restored, got %+v", reconciliations)
+	}
+	if events, _ := repos.periodLocks.GetByProjectID(ctx, project.ID); len(events) != 1 || events[0].Reason != "January filed" {
+		t.Errorf("Expected the period lock history to be restored, got %+v", events)
+	}
+
+	attachments, _ := repos.attachments.GetByTransactionID(ctx, receipt.ID)
+	if len(attachments) != 1 {
+		t.Fatalf("Expected the attachment to be restored, got %d", len(attachments))
+	}
+	content, err := repos.blobs.Open(ctx, attachments[0].BlobKey())
+	if err != nil {
+		t.Fatalf("Expected the attachment file to be restored: %v", err)
+	}
+	defer content.Close()
+	if data, _ := io.ReadAll(content); string(data) != "receipt" {
+		t.Errorf("Expected the attachment content, got %q", data)
+	}
+}
+
+func TestRestoreProjectService_Restore(t *testing.T) {
+	ctx := context.Background()
+	source := newTestRepos()
+	original, archive := backupTestProject(t, source)
+
+	tests := []struct {
+		name        string
+		sameRepos   bool
+		options     RestoreOptions
+		expectError string
+	}{
+		{name: "into another database", options: RestoreOptions{}},
+		{name: "next to the original", sameRepos: true, options: RestoreOptions{Slug: "home-copy", Name: "Home copy"}},
+		{name: "slug taken", sameRepos: true, expectError: "already exists"},
+		{name: "invalid slug", options: RestoreOptions{Slug: "Not A Slug"}, expectError: "invalid slug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repos := newTestRepos()
+			if tt.sameRepos {
+				repos = source
+			}
+			service := repos.restoreService()
+
+			result, err := service.Restore(ctx, bytes.NewReader(archive), tt.options)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			project := result.Project
+			if project.ID == original.ID {
+				t.Error("Expected the restored project to get a new ID")
+			}
+			if tt.options.Slug != "" && (project.Slug != tt.options.Slug || project.Name != tt.options.Name) {
+				t.Errorf("Expected slug %s and name %s, got %s and %s", tt.options.Slug, tt.options.Name, project.Slug, project.Name)
+			}
+			if !project.RequireTwoFactor || project.LockedUntil == nil || !project.LockedUntil.Equal(*original.LockedUntil) {
+				t.Errorf("Expected the project settings to be restored, got %+v", project)
+			}
+			if result.TwoFactorReset != 1 {
+				t.Errorf("Expected one access to enroll again, got %d", result.TwoFactorReset)
+			}
+
+			anna, err := repos.accesses.GetByUID(ctx, project.ID, "anna")
+			if err != nil {
+				t.Fatalf("Expected the access to be restored: %v", err)
+			}
+			if anna.PinHash != "pin-hash" || anna.TOTPEnabled || anna.TOTPSecret != "" {
+				t.Errorf("Expected the PIN hash without a second factor, got %+v", anna)
+			}
+
+			accounts, _ := repos.accounts.GetByProjectID(ctx, project.ID)
+			if len(accounts) != 4 || accounts[0].Name != "Checking" {
+				t.Fatalf("Expected four accounts starting with Checking, got %d", len(accounts))
+			}
+			transactions, _ := repos.transactions.GetByAccountID(ctx, accounts[0].ID)
+			if len(transactions) != 2 {
+				t.Fatalf("Expected two transactions, got %d", len(transactions))
+			}
+
+			categories, _ := repos.categories.GetByProjectID(ctx, project.ID)
+			categoryIDs := make(map[uuid.UUID]bool)
+			for _, category := range categories {
+				categoryIDs[category.ID] = true
+			}
+			payees, _ := repos.payees.GetByProjectID(ctx, project.ID)
+			if len(payees) != 1 || payees[0].DefaultCategoryID == nil || !categoryIDs[*payees[0].DefaultCategoryID] {
+				t.Fatalf("Expected the payee to point at a restored category, got %+v", payees)
+			}
+			aliases, _ := repos.payees.GetAliasesByProjectID(ctx, project.ID)
+			if len(aliases) != 1 || aliases[0].PayeeID != payees[0].ID {
+				t.Errorf("Expected the alias to point at the restored payee, got %+v", aliases)
+			}
+
+			for _, transaction := range transactions {
+				if transaction.Name != "Shop" {
+					if transaction.ExternalID != "FIT-1" {
+						t.Errorf("Expected the external ID to be kept, got %q", transaction.ExternalID)
+					}
+					continue
+				}
+				if transaction.PayeeID == nil || *transaction.PayeeID != payees[0].ID {
+					t.Errorf("Expected the transaction to point at the restored payee, got %v", transaction.PayeeID)
+				}
+				splits, _ := repos.splits.GetByTransactionID(ctx, transaction.ID)
+				if len(splits) != 2 {
+					t.Fatalf("Expected two splits, got %d", len(splits))
+				}
+				for _, split := range splits {
+					if !categoryIDs[split.CategoryID] {
+						t.Errorf("Expected split %s to point at a restored category", split.Memo)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRestoreProjectService_RefusesInconsistentBackup(t *testing.T) {
+	ctx := context.Background()
+	source := newTestRepos()
+	original, _ := backupTestProject(t, source)
+
+	tests := []struct {
+		name        string
+		edit        func(data *backup_project.ProjectData)
+		expectError string
+	}{
+		{
+			name:        "transaction of a missing account",
+			edit:        func(data *backup_project.ProjectData) { data.Transactions[0].AccountID = uuid.New() },
+			expectError: "account that is not in the backup",
+		},
+		{
+			name:        "splits not adding up",
+			edit:        func(data *backup_project.ProjectData) { data.Splits[0].Amount = 25 },
+			expectError: "splits add up",
+		},
+		{
+			name:        "account of another project",
+			edit:        func(data *backup_project.ProjectData) { data.Accounts[0].ProjectID = uuid.New() },
+			expectError: "belongs to another project",
+		},
+		{
+			name:        "transfer of a missing transaction",
+			edit:        func(data *backup_project.ProjectData) { data.Transfers[0].ToTransactionID = uuid.New() },
+			expectError: "transfer",
+		},
+		{
+			name:        "operation of a missing security",
+			edit:        func(data *backup_project.ProjectData) { data.InvestmentOperations[0].SecurityID = uuid.New() },
+			expectError: "not in the backup",
+		},
+		{
+			name:        "loan on a checking account",
+			edit:        func(data *backup_project.ProjectData) { data.Loans[0].AccountID = data.Accounts[0].ID },
+			expectError: "not on a loan account",
+		},
+		{
+			name:        "attachment content changed",
+			edit:        func(data *backup_project.ProjectData) { data.Attachments[0].Content = []byte("forged") },
+			expectError: "checksum",
+		},
+		{
+			name:        "duplicate access",
+			edit:        func(data *backup_project.ProjectData) { data.Accesses[1].UID = data.Accesses[0].UID },
+			expectError: "listed twice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Edited data gets a fresh checksum, as a consistent-looking but
+			// broken archive would have.
+			backup := source.backupService()
+			var buf bytes.Buffer
+			archive, err := backup.Backup(ctx, &buf, original.ID)
+			if err != nil {
+				t.Fatalf("Failed to back up project: %v", err)
+			}
+			data := copyData(t, archive.Data)
+			tt.edit(&data)
+			edited, err := backup_project.NewArchive(data)
+			if err != nil {
+				t.Fatalf("Failed to create archive: %v", err)
+			}
+			buf.Reset()
+			backup_project.WriteArchive(&buf, edited)
+
+			repos := newTestRepos()
+			service := repos.restoreService()
+			_, err = service.Restore(ctx, &buf, RestoreOptions{})
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Fatalf("Expected an error containing %q, got %v", tt.expectError, err)
+			}
+
+			if exists, _ := repos.projects.ExistsBySlug(ctx, original.Slug); exists {
+				t.Error("Expected nothing to be written for an inconsistent backup")
+			}
+		})
+	}
+}
+
+// copyData round-trips data through an archive so edits don't reach the
+// records held by the source repositories.
+func copyData(t *testing.T, data backup_project.ProjectData) backup_project.ProjectData {
+	t.Helper()
+
+	archive, err := backup_project.NewArchive(data)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	var buf bytes.Buffer
+	backup_project.WriteArchive(&buf, archive)
+	copied, err := backup_project.ReadArchive(&buf)
+	if err != nil {
+		t.Fatalf("Failed to read archive: %v", err)
+	}
+	return copied.Data
+}
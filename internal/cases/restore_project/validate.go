@@ -0,0 +1,342 @@
+package restore_project
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/backup_project"
+	"gofin/internal/models"
+)
+
+// validate checks that the archived records are whole and refer only to each
+// other, so a restore does not stop half way on data the database refuses.
+func validate(data *backup_project.ProjectData) error {
+	projectID := data.Project.ID
+	if data.Project.Name == "" {
+		return fmt.Errorf("project has no name")
+	}
+
+	ids := make(map[uuid.UUID]string)
+	add := func(kind string, id uuid.UUID) error {
+		if id == uuid.Nil {
+			return fmt.Errorf("%s without an ID", kind)
+		}
+		if other, ok := ids[id]; ok {
+			return fmt.Errorf("ID %s is used by a %s and a %s", id, other, kind)
+		}
+		ids[id] = kind
+		return nil
+	}
+	refers := func(kind string, id uuid.UUID) bool {
+		return ids[id] == kind
+	}
+	inProject := func(kind, name string, id uuid.UUID) error {
+		if id != projectID {
+			return fmt.Errorf("%s %s belongs to another project", kind, name)
+		}
+		return nil
+	}
+
+	uids := make(map[string]bool)
+	for _, access := range data.Accesses {
+		if err := add("access", access.ID); err != nil {
+			return err
+		}
+		if access.UID == "" || access.PinHash == "" {
+			return fmt.Errorf("access %s has no UID or PIN hash", access.ID)
+		}
+		if uids[access.UID] {
+			return fmt.Errorf("access UID %s is listed twice", access.UID)
+		}
+		uids[access.UID] = true
+	}
+
+	accounts := make(map[uuid.UUID]*models.Account, len(data.Accounts))
+	accountNames := make(map[string]bool)
+	for _, account := range data.Accounts {
+		if err := add("account", account.ID); err != nil {
+			return err
+		}
+		if err := inProject("account", account.Name, account.ProjectID); err != nil {
+			return err
+		}
+		if !account.Currency.IsValid() {
+			return fmt.Errorf("account %s has an invalid currency %q", account.Name, account.Currency)
+		}
+		if !account.Type.IsValid() {
+			return fmt.Errorf("account %s has an invalid type %q", account.Name, account.Type)
+		}
+		if accountNames[account.Name] {
+			return fmt.Errorf("account name %s is listed twice", account.Name)
+		}
+		accountNames[account.Name] = true
+		accounts[account.ID] = account
+	}
+
+	categoryNames := make(map[string]bool)
+	for _, category := range data.Categories {
+		if err := add("category", category.ID); err != nil {
+			return err
+		}
+		if err := inProject("category", category.Name, category.ProjectID); err != nil {
+			return err
+		}
+		if categoryNames[category.Name] {
+			return fmt.Errorf("category name %s is listed twice", category.Name)
+		}
+		categoryNames[category.Name] = true
+	}
+
+	payeeNames := make(map[string]bool)
+	for _, payee := range data.Payees {
+		if err := add("payee", payee.ID); err != nil {
+			return err
+		}
+		if err := inProject("payee", payee.Name, payee.ProjectID); err != nil {
+			return err
+		}
+		if payee.DefaultCategoryID != nil && !refers("category", *payee.DefaultCategoryID) {
+			return fmt.Errorf("payee %s has a default category that is not in the backup", payee.Name)
+		}
+		if payeeNames[payee.Name] {
+			return fmt.Errorf("payee name %s is listed twice", payee.Name)
+		}
+		payeeNames[payee.Name] = true
+	}
+
+	aliases := make(map[string]bool)
+	for _, alias := range data.PayeeAliases {
+		if err := add("payee alias", alias.ID); err != nil {
+			return err
+		}
+		if err := inProject("payee alias", alias.Alias, alias.ProjectID); err != nil {
+			return err
+		}
+		if !refers("payee", alias.PayeeID) {
+			return fmt.Errorf("payee alias %s belongs to a payee that is not in the backup", alias.Alias)
+		}
+		if aliases[alias.Alias] {
+			return fmt.Errorf("payee alias %s is listed twice", alias.Alias)
+		}
+		aliases[alias.Alias] = true
+	}
+
+	transactions := make(map[uuid.UUID]*models.Transaction, len(data.Transactions))
+	type externalKey struct {
+		accountID  uuid.UUID
+		externalID string
+	}
+	externalIDs := make(map[externalKey]bool)
+	groups := make(map[uuid.UUID]bool)
+	for _, transaction := range data.Transactions {
+		if err := add("transaction", transaction.ID); err != nil {
+			return err
+		}
+		if !refers("account", transaction.AccountID) {
+			return fmt.Errorf("transaction %s belongs to an account that is not in the backup", transaction.Name)
+		}
+		if !transaction.Type.IsValid() {
+			return fmt.Errorf("transaction %s has an invalid type %q", transaction.Name, transaction.Type)
+		}
+		if _, err := models.ParseTransactionStatus(transaction.Status.String()); err != nil {
+			return fmt.Errorf("transaction %s: %w", transaction.Name, err)
+		}
+		if transaction.CategoryID != nil && !refers("category", *transaction.CategoryID) {
+			return fmt.Errorf("transaction %s has a category that is not in the backup", transaction.Name)
+		}
+		if transaction.PayeeID != nil && !refers("payee", *transaction.PayeeID) {
+			return fmt.Errorf("transaction %s has a payee that is not in the backup", transaction.Name)
+		}
+		if transaction.ExternalID != "" {
+			key := externalKey{transaction.AccountID, transaction.ExternalID}
+			if externalIDs[key] {
+				return fmt.Errorf("external ID %s is listed twice in one account", transaction.ExternalID)
+			}
+			externalIDs[key] = true
+		}
+		if transaction.GroupID != nil {
+			groups[*transaction.GroupID] = true
+		}
+		transactions[transaction.ID] = transaction
+	}
+
+	splits := make(map[uuid.UUID][]models.SplitData)
+	for _, split := range data.Splits {
+		if err := add("split", split.ID); err != nil {
+			return err
+		}
+		if transactions[split.TransactionID] == nil {
+			return fmt.Errorf("split %s belongs to a transaction that is not in the backup", split.ID)
+		}
+		if !refers("category", split.CategoryID) {
+			return fmt.Errorf("split %s has a category that is not in the backup", split.ID)
+		}
+		splits[split.TransactionID] = append(splits[split.TransactionID], models.SplitData{
+			CategoryID: split.CategoryID,
+			Amount:     split.Amount,
+			Memo:       split.Memo,
+		})
+	}
+	for transactionID, lines := range splits {
+		transaction := transactions[transactionID]
+		if err := models.ValidateSplits(transaction.Value, lines); err != nil {
+			return fmt.Errorf("transaction %s: %w", transaction.Name, err)
+		}
+	}
+
+	loans := make(map[uuid.UUID]bool)
+	for _, loan := range data.Loans {
+		account := accounts[loan.AccountID]
+		if account == nil || account.Type != models.AccountLoan {
+			return fmt.Errorf("loan %s is not on a loan account in the backup", loan.AccountID)
+		}
+		if err := inProject("loan", account.Name, loan.ProjectID); err != nil {
+			return err
+		}
+		if loans[loan.AccountID] {
+			return fmt.Errorf("loan of account %s is listed twice", account.Name)
+		}
+		loans[loan.AccountID] = true
+	}
+
+	for _, period := range data.LoanRatePeriods {
+		if err := add("loan rate period", period.ID); err != nil {
+			return err
+		}
+		if !loans[period.AccountID] {
+			return fmt.Errorf("loan rate period %s belongs to a loan that is not in the backup", period.ID)
+		}
+	}
+
+	tickers := make(map[string]bool)
+	for _, security := range data.Securities {
+		if err := add("security", security.ID); err != nil {
+			return err
+		}
+		if err := inProject("security", security.Ticker, security.ProjectID); err != nil {
+			return err
+		}
+		if !security.Currency.IsValid() {
+			return fmt.Errorf("security %s has an invalid currency %q", security.Ticker, security.Currency)
+		}
+		if tickers[security.Ticker] {
+			return fmt.Errorf("security %s is listed twice", security.Ticker)
+		}
+		tickers[security.Ticker] = true
+	}
+
+	for _, price := range data.SecurityPrices {
+		if !refers("security", price.SecurityID) {
+			return fmt.Errorf("price of %s belongs to a security that is not in the backup", price.Date.Format("2006-01-02"))
+		}
+	}
+
+	for _, operation := range data.InvestmentOperations {
+		if err := add("investment operation", operation.ID); err != nil {
+			return err
+		}
+		if !refers("account", operation.AccountID) || !refers("security", operation.SecurityID) || transactions[operation.TransactionID] == nil {
+			return fmt.Errorf("investment operation %s refers to records that are not in the backup", operation.ID)
+		}
+		if _, err := models.ParseInvestmentOperationType(operation.Type.String()); err != nil {
+			return fmt.Errorf("investment operation %s: %w", operation.ID, err)
+		}
+	}
+
+	sharedTransactions := make(map[uuid.UUID]bool)
+	for _, expense := range data.SharedExpenses {
+		if err := add("shared expense", expense.ID); err != nil {
+			return err
+		}
+		if err := inProject("shared expense", expense.ID.String(), expense.ProjectID); err != nil {
+			return err
+		}
+		if transactions[expense.TransactionID] == nil || !refers("access", expense.PayerID) {
+			return fmt.Errorf("shared expense %s refers to records that are not in the backup", expense.ID)
+		}
+		if !expense.Method.IsValid() {
+			return fmt.Errorf("shared expense %s has an invalid method %q", expense.ID, expense.Method)
+		}
+		if sharedTransactions[expense.TransactionID] {
+			return fmt.Errorf("transaction %s is shared twice", transactions[expense.TransactionID].Name)
+		}
+		sharedTransactions[expense.TransactionID] = true
+	}
+
+	for _, share := range data.ExpenseShares {
+		if err := add("expense share", share.ID); err != nil {
+			return err
+		}
+		if !refers("shared expense", share.ExpenseID) || !refers("access", share.AccessID) {
+			return fmt.Errorf("expense share %s refers to records that are not in the backup", share.ID)
+		}
+	}
+
+	for _, settlement := range data.Settlements {
+		if err := add("settlement", settlement.ID); err != nil {
+			return err
+		}
+		if err := inProject("settlement", settlement.ID.String(), settlement.ProjectID); err != nil {
+			return err
+		}
+		if !refers("access", settlement.FromID) || !refers("access", settlement.ToID) {
+			return fmt.Errorf("settlement %s is between members that are not in the backup", settlement.ID)
+		}
+		if settlement.GroupID != nil && !groups[*settlement.GroupID] {
+			return fmt.Errorf("settlement %s has transactions that are not in the backup", settlement.ID)
+		}
+	}
+
+	for _, transfer := range data.Transfers {
+		if err := add("transfer", transfer.ID); err != nil {
+			return err
+		}
+		if err := inProject("transfer", transfer.ID.String(), transfer.ProjectID); err != nil {
+			return err
+		}
+		for _, legID := range []uuid.UUID{transfer.FromTransactionID, transfer.ToTransactionID} {
+			leg := transactions[legID]
+			if leg == nil || leg.GroupID == nil || *leg.GroupID != transfer.GroupID {
+				return fmt.Errorf("transfer %s has transactions that are not in the backup", transfer.ID)
+			}
+		}
+	}
+
+	for _, reconciliation := range data.Reconciliations {
+		if err := add("reconciliation", reconciliation.ID); err != nil {
+			return err
+		}
+		if !refers("account", reconciliation.AccountID) {
+			return fmt.Errorf("reconciliation %s belongs to an account that is not in the backup", reconciliation.ID)
+		}
+	}
+
+	for _, event := range data.PeriodLockEvents {
+		if err := add("period lock event", event.ID); err != nil {
+			return err
+		}
+		if err := inProject("period lock event", event.ID.String(), event.ProjectID); err != nil {
+			return err
+		}
+	}
+
+	for _, attachment := range data.Attachments {
+		if err := add("attachment", attachment.ID); err != nil {
+			return err
+		}
+		if transactions[attachment.TransactionID] == nil {
+			return fmt.Errorf("attachment %s belongs to a transaction that is not in the backup", attachment.FileName)
+		}
+		sum := sha256.Sum256(attachment.Content)
+		if hex.EncodeToString(sum[:]) != attachment.Checksum || int64(len(attachment.Content)) != attachment.Size {
+			return fmt.Errorf("attachment %s does not match its checksum", attachment.FileName)
+		}
+		if attachment.HasThumbnail && len(attachment.Thumbnail) == 0 {
+			return fmt.Errorf("attachment %s is missing its thumbnail", attachment.FileName)
+		}
+	}
+
+	return nil
+}
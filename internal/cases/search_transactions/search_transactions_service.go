@@ -0,0 +1,69 @@
+package search_transactions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+type SearchTransactionsService struct {
+	transactionRepo models.TransactionRepository
+	accountRepo     models.AccountRepository
+}
+
+func NewSearchTransactionsService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository) *SearchTransactionsService {
+	return &SearchTransactionsService{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// Search runs query within projectID. Any project or account scope already set on
+// query is replaced, and the page size is clamped to MaxPageSize.
+func (s *SearchTransactionsService) Search(ctx context.Context, projectID uuid.UUID, query models.TransactionQuery) (*models.TransactionPage, error) {
+	query.ProjectID = &projectID
+	query.AccountID = nil
+
+	switch {
+	case query.Limit <= 0:
+		query.Limit = DefaultPageSize
+	case query.Limit > MaxPageSize:
+		query.Limit = MaxPageSize
+	}
+
+	if err := query.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid search: %w", err)
+	}
+
+	if len(query.AccountIDs) > 0 {
+		accounts, err := s.accountRepo.GetByProjectID(ctx, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project accounts: %w", err)
+		}
+
+		projectAccounts := make(map[uuid.UUID]bool, len(accounts))
+		for _, account := range accounts {
+			projectAccounts[account.ID] = true
+		}
+
+		for _, accountID := range query.AccountIDs {
+			if !projectAccounts[accountID] {
+				return nil, fmt.Errorf("account %s does not belong to project", accountID)
+			}
+		}
+	}
+
+	page, err := s.transactionRepo.SearchTransactions(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transactions: %w", err)
+	}
+
+	return page, nil
+}
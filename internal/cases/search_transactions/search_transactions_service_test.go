@@ -0,0 +1,220 @@
+package search_transactions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+type fixture struct {
+	service   *SearchTransactionsService
+	projectID uuid.UUID
+	savings   *models.Account
+	checking  *models.Account
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+
+	projectID := uuid.New()
+	savings := models.NewAccount(projectID, "Savings", money.PLN)
+	checking := models.NewAccount(projectID, "Checking", money.PLN)
+	accountRepo.Create(context.Background(), savings)
+	accountRepo.Create(context.Background(), checking)
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	data := []struct {
+		account *models.Account
+		name    string
+		value   float64
+		kind    models.TransactionType
+		days    int
+	}{
+		{savings, "Grocery store", 45.5, models.Debit, 0},
+		{savings, "Salary March", 5000, models.TopUp, 1},
+		{checking, "Grocery market", 12.25, models.Debit, 2},
+		{checking, "Rent", 1800, models.Debit, 3},
+		{checking, "Coffee", 4.5, models.Debit, 4},
+	}
+
+	for _, d := range data {
+		date := base.AddDate(0, 0, d.days)
+		transaction := models.NewTransaction(models.TransactionData{
+			AccountID:       d.account.ID,
+			Value:           d.value,
+			Name:            d.name,
+			Type:            d.kind,
+			TransactionDate: &date,
+		}, uuid.New())
+		if err := transactionRepo.Create(context.Background(), transaction); err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+	}
+
+	return fixture{
+		service:   NewSearchTransactionsService(transactionRepo, accountRepo),
+		projectID: projectID,
+		savings:   savings,
+		checking:  checking,
+	}
+}
+
+func names(transactions []*models.Transaction) string {
+	result := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		result[i] = transaction.Name
+	}
+	return strings.Join(result, ",")
+}
+
+func TestSearchTransactionsService_Search(t *testing.T) {
+	f := newFixture(t)
+	minValue, maxValue := 10.0, 100.0
+
+	tests := []struct {
+		name     string
+		query    models.TransactionQuery
+		expected string
+	}{
+		{
+			name:     "default sort is newest first",
+			query:    models.TransactionQuery{},
+			expected: "Coffee,Rent,Grocery market,Salary March,Grocery store",
+		},
+		{
+			name:     "search matches every term case-insensitively",
+			query:    models.TransactionQuery{Search: "GROCERY mar"},
+			expected: "Grocery market",
+		},
+		{
+			name:     "amount range",
+			query:    models.TransactionQuery{MinValue: &minValue, MaxValue: &maxValue},
+			expected: "Grocery market,Grocery store",
+		},
+		{
+			name:     "type filter",
+			query:    models.TransactionQuery{Type: models.TopUp},
+			expected: "Salary March",
+		},
+		{
+			name:     "account filter",
+			query:    models.TransactionQuery{AccountIDs: []uuid.UUID{f.savings.ID}},
+			expected: "Salary March,Grocery store",
+		},
+		{
+			name:     "sort by value ascending",
+			query:    models.TransactionQuery{SortBy: models.SortByValue, SortDirection: models.SortAscending},
+			expected: "Coffee,Grocery market,Grocery store,Rent,Salary March",
+		},
+		{
+			name:     "sort by name descending",
+			query:    models.TransactionQuery{SortBy: models.SortByName},
+			expected: "Salary March,Rent,Grocery store,Grocery market,Coffee",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := f.service.Search(context.Background(), f.projectID, tt.query)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if got := names(page.Transactions); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+
+			if page.NextCursor != "" {
+				t.Errorf("Expected no next cursor, got %s", page.NextCursor)
+			}
+		})
+	}
+}
+
+func TestSearchTransactionsService_Search_Pagination(t *testing.T) {
+	f := newFixture(t)
+	query := models.TransactionQuery{SortBy: models.SortByValue, Limit: 2}
+
+	var pages []string
+	for {
+		page, err := f.service.Search(context.Background(), f.projectID, query)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		pages = append(pages, names(page.Transactions))
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	expected := []string{"Salary March,Rent", "Grocery store,Grocery market", "Coffee"}
+	if strings.Join(pages, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected pages %v, got %v", expected, pages)
+	}
+}
+
+func TestSearchTransactionsService_Search_Errors(t *testing.T) {
+	f := newFixture(t)
+	minValue, maxValue := 100.0, 10.0
+
+	first, err := f.service.Search(context.Background(), f.projectID, models.TransactionQuery{Limit: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		query         models.TransactionQuery
+		expectedError string
+	}{
+		{
+			name:          "account from another project",
+			query:         models.TransactionQuery{AccountIDs: []uuid.UUID{uuid.New()}},
+			expectedError: "does not belong to project",
+		},
+		{
+			name:          "inverted amount range",
+			query:         models.TransactionQuery{MinValue: &minValue, MaxValue: &maxValue},
+			expectedError: "max value cannot be below min value",
+		},
+		{
+			name:          "invalid sort field",
+			query:         models.TransactionQuery{SortBy: "color"},
+			expectedError: "invalid sort field",
+		},
+		{
+			name:          "malformed cursor",
+			query:         models.TransactionQuery{Cursor: "not a cursor"},
+			expectedError: "invalid cursor",
+		},
+		{
+			name:          "cursor from a different sort order",
+			query:         models.TransactionQuery{Cursor: first.NextCursor, SortBy: models.SortByName},
+			expectedError: "cursor does not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.Search(context.Background(), f.projectID, tt.query)
+			if err == nil {
+				t.Fatalf("Expected error containing %q, got nil", tt.expectedError)
+			}
+
+			if !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error to contain '%s', got '%s'", tt.expectedError, err.Error())
+			}
+		})
+	}
+}
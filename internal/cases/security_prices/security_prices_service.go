@@ -0,0 +1,118 @@
+package security_prices
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+// DateFormat is the date layout of price files.
+const DateFormat = "2006-01-02"
+
+type SecurityPricesService struct {
+	securityRepo models.SecurityRepository
+}
+
+func NewSecurityPricesService(securityRepo models.SecurityRepository) *SecurityPricesService {
+	return &SecurityPricesService{
+		securityRepo: securityRepo,
+	}
+}
+
+// SetPrice stores the price of a security on a day, replacing the one entered
+// for that day before.
+func (s *SecurityPricesService) SetPrice(ctx context.Context, projectID uuid.UUID, ticker string, date time.Time, price float64) error {
+	security, err := s.securityRepo.GetByTicker(ctx, projectID, ticker)
+	if err != nil {
+		return fmt.Errorf("security '%s' not found", models.NormalizeTicker(ticker))
+	}
+
+	if price <= 0 {
+		return fmt.Errorf("price must be positive")
+	}
+
+	if err := s.securityRepo.SetPrice(ctx, models.NewSecurityPrice(security.ID, date, price)); err != nil {
+		return fmt.Errorf("failed to set price: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("security price set",
+		slog.String("security_id", security.ID.String()),
+		slog.String("date", date.Format(DateFormat)),
+	)
+
+	return nil
+}
+
+// ImportPrices reads a CSV price history with ticker, date and price columns,
+// with or without a header row. Nothing is stored unless every row is valid
+// and names a known security. It returns how many prices were stored.
+func (s *SecurityPricesService) ImportPrices(ctx context.Context, projectID uuid.UUID, r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	securities := make(map[string]*models.Security)
+	var prices []*models.SecurityPrice
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "ticker") {
+			continue
+		}
+
+		ticker := models.NormalizeTicker(record[0])
+		security, known := securities[ticker]
+		if !known {
+			if security, err = s.securityRepo.GetByTicker(ctx, projectID, ticker); err != nil {
+				return 0, fmt.Errorf("line %d: security '%s' not found", line, ticker)
+			}
+			securities[ticker] = security
+		}
+
+		date, err := time.Parse(DateFormat, strings.TrimSpace(record[1]))
+		if err != nil {
+			return 0, fmt.Errorf("line %d: invalid date '%s'", line, record[1])
+		}
+
+		price, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		if err != nil || price <= 0 {
+			return 0, fmt.Errorf("line %d: invalid price '%s'", line, record[2])
+		}
+
+		prices = append(prices, models.NewSecurityPrice(security.ID, date, price))
+	}
+
+	if len(prices) == 0 {
+		return 0, fmt.Errorf("the file has no prices")
+	}
+
+	for _, price := range prices {
+		if err := s.securityRepo.SetPrice(ctx, price); err != nil {
+			return 0, fmt.Errorf("failed to set price: %w", err)
+		}
+	}
+
+	logging.FromContext(ctx).Info("security prices imported",
+		slog.String("project_id", projectID.String()),
+		slog.Int("count", len(prices)),
+	)
+
+	return len(prices), nil
+}
@@ -0,0 +1,98 @@
+package security_prices
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestSecurityPricesService_ImportPrices(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		expectError   bool
+		expectedCount int
+		expectedLast  float64
+		expectedDays  int
+	}{
+		{name: "with header", file: "ticker,date,price\nVWCE,2024-06-01,110.5\nvwce,2024-06-02,111\n", expectedCount: 2, expectedLast: 111, expectedDays: 2},
+		{name: "without header", file: "VWCE,2024-06-01,110.5\n", expectedCount: 1, expectedLast: 110.5, expectedDays: 1},
+		{name: "same day twice keeps the last", file: "VWCE,2024-06-01,110.5\nVWCE,2024-06-01,112\n", expectedCount: 2, expectedLast: 112, expectedDays: 1},
+		{name: "unknown ticker", file: "VWCE,2024-06-01,110.5\nCSPX,2024-06-01,500\n", expectError: true},
+		{name: "invalid date", file: "VWCE,01.06.2024,110.5\n", expectError: true},
+		{name: "invalid price", file: "VWCE,2024-06-01,-1\n", expectError: true},
+		{name: "missing column", file: "VWCE,2024-06-01\n", expectError: true},
+		{name: "empty file", file: "ticker,date,price\n", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			securityRepo := database.NewSecurityInMemoryRepository()
+			service := NewSecurityPricesService(securityRepo)
+
+			projectID := uuid.New()
+			security := models.NewSecurity(projectID, "VWCE", "FTSE All-World", money.EUR)
+			securityRepo.Create(ctx, security)
+
+			count, err := service.ImportPrices(ctx, projectID, strings.NewReader(tt.file))
+			prices, _ := securityRepo.GetPrices(ctx, security.ID)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				if len(prices) != 0 {
+					t.Errorf("Expected no prices stored, got %d", len(prices))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if count != tt.expectedCount {
+				t.Errorf("Expected %d prices imported, got %d", tt.expectedCount, count)
+			}
+
+			if len(prices) != tt.expectedDays || prices[len(prices)-1].Price != tt.expectedLast {
+				t.Errorf("Expected %d days ending at %v, got %+v", tt.expectedDays, tt.expectedLast, prices)
+			}
+		})
+	}
+}
+
+func TestSecurityPricesService_SetPrice(t *testing.T) {
+	ctx := context.Background()
+	securityRepo := database.NewSecurityInMemoryRepository()
+	service := NewSecurityPricesService(securityRepo)
+
+	projectID := uuid.New()
+	security := models.NewSecurity(projectID, "VWCE", "", money.EUR)
+	securityRepo.Create(ctx, security)
+
+	date := time.Date(2024, time.June, 1, 15, 30, 0, 0, time.UTC)
+	if err := service.SetPrice(ctx, projectID, "vwce", date, 110); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	prices, _ := securityRepo.GetPrices(ctx, security.ID)
+	if len(prices) != 1 || prices[0].Price != 110 || !prices[0].Date.Equal(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected one price on the day, got %+v", prices)
+	}
+
+	if err := service.SetPrice(ctx, projectID, "VWCE", date, 0); err == nil {
+		t.Error("Expected an error for a zero price")
+	}
+
+	if err := service.SetPrice(ctx, uuid.New(), "VWCE", date, 110); err == nil {
+		t.Error("Expected an error for a security of another project")
+	}
+}
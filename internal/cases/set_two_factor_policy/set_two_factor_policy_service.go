@@ -0,0 +1,39 @@
+package set_two_factor_policy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type SetTwoFactorPolicyService struct {
+	projectRepo models.ProjectRepository
+}
+
+func NewSetTwoFactorPolicyService(projectRepo models.ProjectRepository) *SetTwoFactorPolicyService {
+	return &SetTwoFactorPolicyService{
+		projectRepo: projectRepo,
+	}
+}
+
+func (s *SetTwoFactorPolicyService) SetTwoFactorPolicy(ctx context.Context, projectSlug string, required bool) (*models.Project, error) {
+	project, err := s.projectRepo.GetBySlug(ctx, projectSlug)
+	if err != nil {
+		return nil, fmt.Errorf("project not found: %w", err)
+	}
+
+	project.RequireTwoFactor = required
+	project.UpdatedAt = time.Now()
+
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("two-factor policy updated", slog.String("project_id", project.ID.String()), slog.Bool("required", required))
+
+	return project, nil
+}
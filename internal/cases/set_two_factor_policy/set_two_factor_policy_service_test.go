@@ -0,0 +1,49 @@
+package set_two_factor_policy
+
+import (
+	"context"
+	"testing"
+
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+)
+
+func TestSetTwoFactorPolicyService_SetTwoFactorPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectSlug string
+		required    bool
+		wantErr     bool
+	}{
+		{name: "success when enabling policy", projectSlug: "test-project", required: true, wantErr: false},
+		{name: "success when disabling policy", projectSlug: "test-project", required: false, wantErr: false},
+		{name: "error when project not found", projectSlug: "missing-project", required: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectRepo := database.NewProjectInMemoryRepository()
+			projectRepo.Create(context.Background(), models.NewProject("Test Project", "test-project"))
+			service := NewSetTwoFactorPolicyService(projectRepo)
+
+			project, err := service.SetTwoFactorPolicy(context.Background(), tt.projectSlug, tt.required)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("SetTwoFactorPolicy() expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("SetTwoFactorPolicy() unexpected error: %v", err)
+				return
+			}
+
+			stored, _ := projectRepo.GetBySlug(context.Background(), tt.projectSlug)
+			if project.RequireTwoFactor != tt.required || stored.RequireTwoFactor != tt.required {
+				t.Errorf("SetTwoFactorPolicy() RequireTwoFactor = %v, want %v", stored.RequireTwoFactor, tt.required)
+			}
+		})
+	}
+}
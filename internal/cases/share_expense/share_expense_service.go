@@ -0,0 +1,138 @@
+package share_expense
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_account"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type ShareExpenseService struct {
+	sharedExpenseRepo  models.SharedExpenseRepository
+	transactionRepo    models.TransactionRepository
+	accountRepo        models.AccountRepository
+	accessRepo         models.AccessRepository
+	validateAccountSvc *validate_account.ValidateAccountService
+	validatePeriodSvc  *validate_period.ValidatePeriodService
+}
+
+func NewShareExpenseService(sharedExpenseRepo models.SharedExpenseRepository, transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, accessRepo models.AccessRepository, projectRepo models.ProjectRepository) *ShareExpenseService {
+	return &ShareExpenseService{
+		sharedExpenseRepo:  sharedExpenseRepo,
+		transactionRepo:    transactionRepo,
+		accountRepo:        accountRepo,
+		accessRepo:         accessRepo,
+		validateAccountSvc: validate_account.NewValidateAccountService(accountRepo),
+		validatePeriodSvc:  validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+	}
+}
+
+type ShareExpenseData struct {
+	PayerID      uuid.UUID
+	Method       models.ShareMethod
+	Participants []models.ShareData
+}
+
+// ShareTransaction records who paid for a debit transaction and how it is divided
+// between members, replacing any earlier split of the same transaction.
+func (s *ShareExpenseService) ShareTransaction(ctx context.Context, projectID, transactionID uuid.UUID, data ShareExpenseData) (*models.SharedExpense, error) {
+	transaction, err := s.getProjectTransaction(ctx, projectID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Type != models.Debit {
+		return nil, fmt.Errorf("only debit transactions can be shared")
+	}
+
+	if err := s.validateMember(ctx, projectID, data.PayerID); err != nil {
+		return nil, fmt.Errorf("invalid payer: %w", err)
+	}
+
+	for _, participant := range data.Participants {
+		if err := s.validateMember(ctx, projectID, participant.AccessID); err != nil {
+			return nil, fmt.Errorf("invalid participant: %w", err)
+		}
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, transaction.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction account: %w", err)
+	}
+
+	expense := models.NewSharedExpense(projectID, transactionID, data.PayerID, data.Method, transaction.Value, account.Currency)
+	shares, err := models.ComputeShares(expense.ID, data.Method, transaction.Value, data.Participants)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.sharedExpenseRepo.DeleteByTransactionID(ctx, transactionID); err != nil {
+		return nil, fmt.Errorf("failed to replace shared expense: %w", err)
+	}
+
+	if err := s.sharedExpenseRepo.Create(ctx, expense, shares); err != nil {
+		return nil, fmt.Errorf("failed to save shared expense: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("expense shared",
+		slog.String("transaction_id", transactionID.String()),
+		slog.String("method", data.Method.String()),
+		slog.Int("participants", len(shares)),
+	)
+
+	return expense, nil
+}
+
+func (s *ShareExpenseService) UnshareTransaction(ctx context.Context, projectID, transactionID uuid.UUID) error {
+	if _, err := s.getProjectTransaction(ctx, projectID, transactionID); err != nil {
+		return err
+	}
+
+	if err := s.sharedExpenseRepo.DeleteByTransactionID(ctx, transactionID); err != nil {
+		return fmt.Errorf("failed to remove shared expense: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("expense unshared", slog.String("transaction_id", transactionID.String()))
+
+	return nil
+}
+
+func (s *ShareExpenseService) getProjectTransaction(ctx context.Context, projectID, transactionID uuid.UUID) (*models.Transaction, error) {
+	transaction, err := s.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := s.validateAccountSvc.ValidateAccountForProject(ctx, projectID, transaction.AccountID); err != nil {
+		return nil, fmt.Errorf("transaction not found: %w", err)
+	}
+
+	// Shares move member balances, so they are locked with the transaction.
+	if err := transaction.EnsureEditable(); err != nil {
+		return nil, err
+	}
+
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, transaction.TransactionDate); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+func (s *ShareExpenseService) validateMember(ctx context.Context, projectID, accessID uuid.UUID) error {
+	access, err := s.accessRepo.GetByID(ctx, accessID)
+	if err != nil {
+		return fmt.Errorf("member not found: %w", err)
+	}
+
+	if access.ProjectID != projectID {
+		return fmt.Errorf("member does not belong to the specified project")
+	}
+
+	return nil
+}
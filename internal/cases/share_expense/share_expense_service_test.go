@@ -0,0 +1,227 @@
+package share_expense
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestShareExpenseService_ShareTransaction(t *testing.T) {
+	ctx := context.Background()
+	sharedRepo := database.NewSharedExpenseInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	accessRepo := database.NewAccessInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewShareExpenseService(sharedRepo, transactionRepo, accountRepo, accessRepo, projectRepo)
+
+	project := models.NewProject("Flat", "flat")
+	projectRepo.Create(ctx, project)
+	projectID := project.ID
+	account := models.NewAccount(projectID, "Flat", money.PLN)
+	accountRepo.Create(ctx, account)
+
+	anna := models.NewAccess(projectID, "01", "hash", "Anna", false)
+	bartek := models.NewAccess(projectID, "02", "hash", "Bartek", false)
+	celina := models.NewAccess(projectID, "03", "hash", "Celina", false)
+	outsider := models.NewAccess(uuid.New(), "04", "hash", "Outsider", false)
+	for _, access := range []*models.Access{anna, bartek, celina, outsider} {
+		accessRepo.Create(ctx, access)
+	}
+
+	groceries := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 100, Name: "Groceries", Type: models.Debit}, uuid.New())
+	refund := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 10, Name: "Refund", Type: models.TopUp}, uuid.New())
+	rent := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 10000, Name: "Rent", Type: models.Debit}, uuid.New())
+	transactionRepo.Create(ctx, groceries)
+	transactionRepo.Create(ctx, refund)
+	transactionRepo.Create(ctx, rent)
+
+	everyone := []models.ShareData{{AccessID: anna.ID}, {AccessID: bartek.ID}, {AccessID: celina.ID}}
+
+	tests := []struct {
+		name          string
+		transactionID uuid.UUID
+		data          ShareExpenseData
+		wantAmounts   []float64
+		errorMsg      string
+	}{
+		{
+			name:          "equal shares give leftover cents to the first members",
+			transactionID: groceries.ID,
+			data:          ShareExpenseData{PayerID: anna.ID, Method: models.ShareEqual, Participants: everyone},
+			wantAmounts:   []float64{33.34, 33.33, 33.33},
+		},
+		{
+			name:          "percentage shares",
+			transactionID: groceries.ID,
+			data: ShareExpenseData{PayerID: bartek.ID, Method: models.SharePercentage, Participants: []models.ShareData{
+				{AccessID: anna.ID, Percentage: 50},
+				{AccessID: bartek.ID, Percentage: 25},
+				{AccessID: celina.ID, Percentage: 25},
+			}},
+			wantAmounts: []float64{50, 25, 25},
+		},
+		{
+			name:          "percentage shares rounding over the total give the extra cents back",
+			transactionID: rent.ID,
+			data: ShareExpenseData{PayerID: anna.ID, Method: models.SharePercentage, Participants: []models.ShareData{
+				{AccessID: anna.ID, Percentage: 60.0004},
+				{AccessID: bartek.ID, Percentage: 40.0004},
+			}},
+			wantAmounts: []float64{6000, 4000},
+		},
+		{
+			name:          "exact shares",
+			transactionID: groceries.ID,
+			data: ShareExpenseData{PayerID: anna.ID, Method: models.ShareExact, Participants: []models.ShareData{
+				{AccessID: bartek.ID, Amount: 60},
+				{AccessID: celina.ID, Amount: 40},
+			}},
+			wantAmounts: []float64{60, 40},
+		},
+		{
+			name:          "error when percentages do not add up",
+			transactionID: groceries.ID,
+			data: ShareExpenseData{PayerID: anna.ID, Method: models.SharePercentage, Participants: []models.ShareData{
+				{AccessID: anna.ID, Percentage: 50},
+				{AccessID: bartek.ID, Percentage: 40},
+			}},
+			errorMsg: "percentages add up to 90.00% instead of 100%",
+		},
+		{
+			name:          "error when exact amounts do not add up",
+			transactionID: groceries.ID,
+			data: ShareExpenseData{PayerID: anna.ID, Method: models.ShareExact, Participants: []models.ShareData{
+				{AccessID: bartek.ID, Amount: 60},
+			}},
+			errorMsg: "shares add up to 60.00 but the expense is 100.00",
+		},
+		{
+			name:          "error when a participant is from another project",
+			transactionID: groceries.ID,
+			data:          ShareExpenseData{PayerID: anna.ID, Method: models.ShareEqual, Participants: []models.ShareData{{AccessID: outsider.ID}}},
+			errorMsg:      "member does not belong to the specified project",
+		},
+		{
+			name:          "error when a member takes part twice",
+			transactionID: groceries.ID,
+			data:          ShareExpenseData{PayerID: anna.ID, Method: models.ShareEqual, Participants: []models.ShareData{{AccessID: anna.ID}, {AccessID: anna.ID}}},
+			errorMsg:      "each member can only take part once",
+		},
+		{
+			name:          "error when the transaction is not a debit",
+			transactionID: refund.ID,
+			data:          ShareExpenseData{PayerID: anna.ID, Method: models.ShareEqual, Participants: everyone},
+			errorMsg:      "only debit transactions can be shared",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ShareTransaction(ctx, projectID, tt.transactionID, tt.data)
+
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error containing '%s', got %v", tt.errorMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			expense, shares, err := sharedRepo.GetByTransactionID(ctx, tt.transactionID)
+			if err != nil {
+				t.Fatalf("Failed to get shared expense: %v", err)
+			}
+			if expense.PayerID != tt.data.PayerID || expense.Currency != money.PLN {
+				t.Errorf("Expected payer %s in PLN, got %s in %s", tt.data.PayerID, expense.PayerID, expense.Currency)
+			}
+			if len(shares) != len(tt.wantAmounts) {
+				t.Fatalf("Expected %d shares, got %d", len(tt.wantAmounts), len(shares))
+			}
+			for i, want := range tt.wantAmounts {
+				if shares[i].Amount != want {
+					t.Errorf("Share %d: expected %.2f, got %.2f", i, want, shares[i].Amount)
+				}
+			}
+		})
+	}
+
+	if err := service.UnshareTransaction(ctx, projectID, groceries.ID); err != nil {
+		t.Fatalf("Expected no error unsharing, got %v", err)
+	}
+	if _, _, err := sharedRepo.GetByTransactionID(ctx, groceries.ID); err == nil {
+		t.Error("Expected shared expense to be removed")
+	}
+}
+
+func TestShareExpenseService_LockedTransactions(t *testing.T) {
+	ctx := context.Background()
+	sharedRepo := database.NewSharedExpenseInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	accessRepo := database.NewAccessInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewShareExpenseService(sharedRepo, transactionRepo, accountRepo, accessRepo, projectRepo)
+
+	lockedUntil := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	project := models.NewProject("Flat", "flat")
+	project.LockedUntil = &lockedUntil
+	projectRepo.Create(ctx, project)
+	account := models.NewAccount(project.ID, "Flat", money.PLN)
+	accountRepo.Create(ctx, account)
+
+	anna := models.NewAccess(project.ID, "01", "hash", "Anna", false)
+	bartek := models.NewAccess(project.ID, "02", "hash", "Bartek", false)
+	accessRepo.Create(ctx, anna)
+	accessRepo.Create(ctx, bartek)
+	participants := []models.ShareData{{AccessID: anna.ID}, {AccessID: bartek.ID}}
+
+	closed := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	open := time.Date(2024, time.April, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		date   time.Time
+		status models.TransactionStatus
+		want   error
+	}{
+		{name: "transaction in a closed period", date: closed, status: models.StatusCleared, want: models.ErrPeriodClosed},
+		{name: "reconciled transaction", date: open, status: models.StatusReconciled, want: models.ErrTransactionReconciled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 100, Name: "Groceries", Type: models.Debit, TransactionDate: &tt.date})
+			transaction.Status = tt.status
+			transactionRepo.Create(ctx, transaction)
+
+			expense := models.NewSharedExpense(project.ID, transaction.ID, anna.ID, models.ShareEqual, 100, money.PLN)
+			shares, _ := models.ComputeShares(expense.ID, models.ShareEqual, 100, participants)
+			sharedRepo.Create(ctx, expense, shares)
+
+			_, err := service.ShareTransaction(ctx, project.ID, transaction.ID, ShareExpenseData{PayerID: bartek.ID, Method: models.ShareEqual, Participants: participants})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ShareTransaction() error = %v, want %v", err, tt.want)
+			}
+
+			if err := service.UnshareTransaction(ctx, project.ID, transaction.ID); !errors.Is(err, tt.want) {
+				t.Errorf("UnshareTransaction() error = %v, want %v", err, tt.want)
+			}
+
+			kept, _, err := sharedRepo.GetByTransactionID(ctx, transaction.ID)
+			if err != nil || kept.PayerID != anna.ID {
+				t.Errorf("Expected the shared expense to be kept unchanged, got %+v (%v)", kept, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package shared_balances
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type memberCents struct {
+	id    uuid.UUID
+	name  string
+	cents int64
+}
+
+// SettleUp suggests the transfers that bring every balance back to zero. Within each
+// currency the member who owes the most pays the member who is owed the most, so
+// every transfer clears at least one of them and n members need at most n-1 transfers.
+func SettleUp(balances []models.MemberBalance) []models.Transfer {
+	byCurrency := make(map[string][]models.MemberBalance)
+	var currencies []string
+	for _, balance := range balances {
+		currency := balance.Currency.String()
+		if _, seen := byCurrency[currency]; !seen {
+			currencies = append(currencies, currency)
+		}
+		byCurrency[currency] = append(byCurrency[currency], balance)
+	}
+	sort.Strings(currencies)
+
+	var transfers []models.Transfer
+	for _, currency := range currencies {
+		transfers = append(transfers, settleCurrency(byCurrency[currency])...)
+	}
+
+	return transfers
+}
+
+func settleCurrency(balances []models.MemberBalance) []models.Transfer {
+	var debtors, creditors []*memberCents
+	for _, balance := range balances {
+		cents := models.ToCents(balance.Balance)
+		member := &memberCents{id: balance.AccessID, name: balance.Name}
+		switch {
+		case cents < 0:
+			member.cents = -cents
+			debtors = append(debtors, member)
+		case cents > 0:
+			member.cents = cents
+			creditors = append(creditors, member)
+		}
+	}
+
+	if len(balances) == 0 {
+		return nil
+	}
+	currency := balances[0].Currency
+
+	var transfers []models.Transfer
+	for len(debtors) > 0 && len(creditors) > 0 {
+		sortByCents(debtors)
+		sortByCents(creditors)
+
+		debtor, creditor := debtors[0], creditors[0]
+		amount := min(debtor.cents, creditor.cents)
+
+		transfers = append(transfers, models.Transfer{
+			FromID:   debtor.id,
+			FromName: debtor.name,
+			ToID:     creditor.id,
+			ToName:   creditor.name,
+			Amount:   float64(amount) / 100,
+			Currency: currency,
+		})
+
+		debtor.cents -= amount
+		creditor.cents -= amount
+		if debtor.cents == 0 {
+			debtors = debtors[1:]
+		}
+		if creditor.cents == 0 {
+			creditors = creditors[1:]
+		}
+	}
+
+	return transfers
+}
+
+// sortByCents orders members by amount, largest first, breaking ties by name and ID
+// so the suggestion is stable between page loads.
+func sortByCents(members []*memberCents) {
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].cents != members[j].cents {
+			return members[i].cents > members[j].cents
+		}
+		if members[i].name != members[j].name {
+			return members[i].name < members[j].name
+		}
+		return members[i].id.String() < members[j].id.String()
+	})
+}
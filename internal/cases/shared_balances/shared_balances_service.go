@@ -0,0 +1,116 @@
+package shared_balances
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+type SharedBalancesService struct {
+	sharedExpenseRepo models.SharedExpenseRepository
+	settlementRepo    models.SettlementRepository
+	accessRepo        models.AccessRepository
+}
+
+func NewSharedBalancesService(sharedExpenseRepo models.SharedExpenseRepository, settlementRepo models.SettlementRepository, accessRepo models.AccessRepository) *SharedBalancesService {
+	return &SharedBalancesService{
+		sharedExpenseRepo: sharedExpenseRepo,
+		settlementRepo:    settlementRepo,
+		accessRepo:        accessRepo,
+	}
+}
+
+type SharedBalancesData struct {
+	ProjectID   uuid.UUID              `json:"project_id"`
+	Balances    []models.MemberBalance `json:"balances"`
+	Transfers   []models.Transfer      `json:"transfers"`
+	Settlements []*models.Settlement   `json:"settlements"`
+}
+
+type ledgerKey struct {
+	accessID uuid.UUID
+	currency money.Currency
+}
+
+// GetBalances builds the member ledger: what each member paid for shared expenses,
+// their own shares of them, and the settlements they sent or received.
+func (s *SharedBalancesService) GetBalances(ctx context.Context, projectID uuid.UUID) (*SharedBalancesData, error) {
+	members, err := s.accessRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project members: %w", err)
+	}
+
+	expenses, err := s.sharedExpenseRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared expenses: %w", err)
+	}
+
+	shares, err := s.sharedExpenseRepo.GetSharesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expense shares: %w", err)
+	}
+
+	settlements, err := s.settlementRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlements: %w", err)
+	}
+
+	names := make(map[uuid.UUID]string, len(members))
+	for _, member := range members {
+		names[member.ID] = member.Name
+	}
+
+	ledger := make(map[ledgerKey]*models.MemberBalance)
+	entry := func(accessID uuid.UUID, currency money.Currency) *models.MemberBalance {
+		key := ledgerKey{accessID: accessID, currency: currency}
+		balance, exists := ledger[key]
+		if !exists {
+			balance = &models.MemberBalance{AccessID: accessID, Name: names[accessID], Currency: currency}
+			ledger[key] = balance
+		}
+		return balance
+	}
+
+	currencies := make(map[uuid.UUID]money.Currency, len(expenses))
+	for _, expense := range expenses {
+		currencies[expense.ID] = expense.Currency
+		payer := entry(expense.PayerID, expense.Currency)
+		payer.Paid += expense.Amount
+		payer.Balance += expense.Amount
+	}
+
+	for _, share := range shares {
+		participant := entry(share.AccessID, currencies[share.ExpenseID])
+		participant.Owed += share.Amount
+		participant.Balance -= share.Amount
+	}
+
+	for _, settlement := range settlements {
+		entry(settlement.FromID, settlement.Currency).Balance += settlement.Amount
+		entry(settlement.ToID, settlement.Currency).Balance -= settlement.Amount
+	}
+
+	balances := make([]models.MemberBalance, 0, len(ledger))
+	for _, balance := range ledger {
+		balance.Balance = float64(models.ToCents(balance.Balance)) / 100
+		balances = append(balances, *balance)
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Currency != balances[j].Currency {
+			return balances[i].Currency < balances[j].Currency
+		}
+		return balances[i].Name < balances[j].Name
+	})
+
+	return &SharedBalancesData{
+		ProjectID:   projectID,
+		Balances:    balances,
+		Transfers:   SettleUp(balances),
+		Settlements: settlements,
+	}, nil
+}
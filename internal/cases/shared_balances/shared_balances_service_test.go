@@ -0,0 +1,126 @@
+package shared_balances
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestSharedBalancesService_GetBalances(t *testing.T) {
+	ctx := context.Background()
+	sharedRepo := database.NewSharedExpenseInMemoryRepository()
+	settlementRepo := database.NewSettlementInMemoryRepository()
+	accessRepo := database.NewAccessInMemoryRepository()
+	service := NewSharedBalancesService(sharedRepo, settlementRepo, accessRepo)
+
+	projectID := uuid.New()
+	anna := models.NewAccess(projectID, "01", "hash", "Anna", false)
+	bartek := models.NewAccess(projectID, "02", "hash", "Bartek", false)
+	celina := models.NewAccess(projectID, "03", "hash", "Celina", false)
+	for _, access := range []*models.Access{anna, bartek, celina} {
+		accessRepo.Create(ctx, access)
+	}
+
+	share := func(payer *models.Access, amount float64, currency money.Currency, participants ...*models.Access) {
+		t.Helper()
+		expense := models.NewSharedExpense(projectID, uuid.New(), payer.ID, models.ShareEqual, amount, currency)
+		var data []models.ShareData
+		for _, participant := range participants {
+			data = append(data, models.ShareData{AccessID: participant.ID})
+		}
+		shares, err := models.ComputeShares(expense.ID, models.ShareEqual, amount, data)
+		if err != nil {
+			t.Fatalf("Failed to compute shares: %v", err)
+		}
+		if err := sharedRepo.Create(ctx, expense, shares); err != nil {
+			t.Fatalf("Failed to create shared expense: %v", err)
+		}
+	}
+
+	// Anna paid 90 PLN for all three, Bartek paid 30 PLN for himself and Celina and
+	// 20 EUR for himself and Anna. Celina already paid Anna back 10 PLN.
+	share(anna, 90, money.PLN, anna, bartek, celina)
+	share(bartek, 30, money.PLN, bartek, celina)
+	share(bartek, 20, money.EUR, anna, bartek)
+	settlementRepo.Create(ctx, models.NewSettlement(projectID, celina.ID, anna.ID, 10, money.PLN, nil))
+
+	data, err := service.GetBalances(ctx, projectID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedBalances := []struct {
+		name     string
+		currency money.Currency
+		balance  float64
+	}{
+		{"Anna", money.EUR, -10},
+		{"Bartek", money.EUR, 10},
+		{"Anna", money.PLN, 50},
+		{"Bartek", money.PLN, -15},
+		{"Celina", money.PLN, -35},
+	}
+
+	if len(data.Balances) != len(expectedBalances) {
+		t.Fatalf("Expected %d balances, got %d: %+v", len(expectedBalances), len(data.Balances), data.Balances)
+	}
+	for i, want := range expectedBalances {
+		got := data.Balances[i]
+		if got.Name != want.name || got.Currency != want.currency || got.Balance != want.balance {
+			t.Errorf("Balance %d: expected %s %.2f %s, got %s %.2f %s", i, want.name, want.balance, want.currency, got.Name, got.Balance, got.Currency)
+		}
+	}
+
+	expectedTransfers := []models.Transfer{
+		{FromID: anna.ID, ToID: bartek.ID, Amount: 10, Currency: money.EUR},
+		{FromID: celina.ID, ToID: anna.ID, Amount: 35, Currency: money.PLN},
+		{FromID: bartek.ID, ToID: anna.ID, Amount: 15, Currency: money.PLN},
+	}
+
+	if len(data.Transfers) != len(expectedTransfers) {
+		t.Fatalf("Expected %d transfers, got %d: %+v", len(expectedTransfers), len(data.Transfers), data.Transfers)
+	}
+	for i, want := range expectedTransfers {
+		got := data.Transfers[i]
+		if got.FromID != want.FromID || got.ToID != want.ToID || got.Amount != want.Amount || got.Currency != want.Currency {
+			t.Errorf("Transfer %d: expected %s → %s %.2f %s, got %s → %s %.2f %s", i, want.FromID, want.ToID, want.Amount, want.Currency, got.FromName, got.ToName, got.Amount, got.Currency)
+		}
+	}
+}
+
+func TestSettleUp(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	balances := []models.MemberBalance{
+		{AccessID: ids[0], Name: "A", Currency: money.PLN, Balance: 40},
+		{AccessID: ids[1], Name: "B", Currency: money.PLN, Balance: -10},
+		{AccessID: ids[2], Name: "C", Currency: money.PLN, Balance: -30},
+		{AccessID: ids[3], Name: "D", Currency: money.PLN, Balance: 0},
+	}
+
+	transfers := SettleUp(balances)
+	if len(transfers) != 2 {
+		t.Fatalf("Expected 2 transfers, got %d: %+v", len(transfers), transfers)
+	}
+
+	settled := make(map[uuid.UUID]int64)
+	for _, balance := range balances {
+		settled[balance.AccessID] = models.ToCents(balance.Balance)
+	}
+	for _, transfer := range transfers {
+		settled[transfer.FromID] += models.ToCents(transfer.Amount)
+		settled[transfer.ToID] -= models.ToCents(transfer.Amount)
+	}
+	for id, cents := range settled {
+		if cents != 0 {
+			t.Errorf("Expected member %s to be settled, %d cents left", id, cents)
+		}
+	}
+
+	if transfers := SettleUp(nil); len(transfers) != 0 {
+		t.Errorf("Expected no transfers for no balances, got %+v", transfers)
+	}
+}
@@ -0,0 +1,107 @@
+package transaction_attachments
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	thumbnailSize    = 240
+	thumbnailQuality = 80
+
+	// maxThumbnailPixels guards against decompression bombs: a tiny file can declare
+	// enormous dimensions and exhaust memory when decoded.
+	maxThumbnailPixels = 40_000_000
+)
+
+func isThumbnailable(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	default:
+		return false
+	}
+}
+
+// makeThumbnail scales the image to fit a thumbnailSize square, averaging the source
+// pixels behind every target pixel, and encodes it as JPEG.
+func makeThumbnail(data []byte) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxThumbnailPixels {
+		return nil, fmt.Errorf("image dimensions %dx%d are not supported", config.Width, config.Height)
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := source.Bounds()
+	width, height := fitWithin(bounds.Dx(), bounds.Dy(), thumbnailSize)
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+
+			thumbnail.Set(x, y, averageColor(source, x0, y0, x1, y1))
+		}
+	}
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, thumbnail, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+func fitWithin(width, height, limit int) (int, int) {
+	if width <= limit && height <= limit {
+		return width, height
+	}
+
+	if width >= height {
+		return limit, max(height*limit/width, 1)
+	}
+
+	return max(width*limit/height, 1), limit
+}
+
+// averageColor blends the block over white so transparent areas do not turn black
+// in the JPEG output.
+func averageColor(source image.Image, x0, y0, x1, y1 int) color.RGBA {
+	var r, g, b, count uint64
+
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			pr, pg, pb, pa := source.At(x, y).RGBA()
+			white := 0xffff - pa
+			r += uint64(pr + white)
+			g += uint64(pg + white)
+			b += uint64(pb + white)
+			count++
+		}
+	}
+
+	return color.RGBA{
+		R: uint8(r / count >> 8),
+		G: uint8(g / count >> 8),
+		B: uint8(b / count >> 8),
+		A: 0xff,
+	}
+}
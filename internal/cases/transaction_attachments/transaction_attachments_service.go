@@ -0,0 +1,289 @@
+package transaction_attachments
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_account"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+const maxFileNameLength = 255
+
+// Limits restricts what can be uploaded. ContentTypes are compared with the type
+// sniffed from the first bytes of the file.
+type Limits struct {
+	MaxSize      int64
+	ContentTypes []string
+}
+
+type TransactionAttachmentsService struct {
+	attachmentRepo     models.AttachmentRepository
+	transactionRepo    models.TransactionRepository
+	blobs              models.BlobStore
+	limits             Limits
+	validateAccountSvc *validate_account.ValidateAccountService
+}
+
+func NewTransactionAttachmentsService(attachmentRepo models.AttachmentRepository, transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, blobs models.BlobStore, limits Limits) *TransactionAttachmentsService {
+	return &TransactionAttachmentsService{
+		attachmentRepo:     attachmentRepo,
+		transactionRepo:    transactionRepo,
+		blobs:              blobs,
+		limits:             limits,
+		validateAccountSvc: validate_account.NewValidateAccountService(accountRepo),
+	}
+}
+
+// Upload stores content as an attachment of the transaction. Content already stored
+// under the same checksum is reused rather than written again.
+func (s *TransactionAttachmentsService) Upload(ctx context.Context, projectID, transactionID uuid.UUID, fileName string, content io.Reader) (*models.Attachment, error) {
+	if _, err := s.transactionForProject(ctx, projectID, transactionID); err != nil {
+		return nil, err
+	}
+
+	fileName = sanitizeFileName(fileName)
+	if fileName == "" {
+		return nil, fmt.Errorf("file name is required")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, s.limits.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	if int64(len(data)) > s.limits.MaxSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", s.limits.MaxSize)
+	}
+
+	contentType := detectContentType(data)
+	if !slices.Contains(s.limits.ContentTypes, contentType) {
+		return nil, fmt.Errorf("file type %s is not allowed", contentType)
+	}
+
+	sum := sha256.Sum256(data)
+	attachment := models.NewAttachment(transactionID, fileName, contentType, int64(len(data)), hex.EncodeToString(sum[:]))
+
+	if err := s.putIfMissing(ctx, attachment.BlobKey(), data); err != nil {
+		return nil, err
+	}
+
+	if isThumbnailable(contentType) {
+		thumbnail, err := makeThumbnail(data)
+		if err != nil {
+			logging.FromContext(ctx).Warn("failed to create thumbnail", slog.String("checksum", attachment.Checksum), logging.Err(err))
+		} else if err := s.putIfMissing(ctx, attachment.ThumbnailKey(), thumbnail); err != nil {
+			return nil, err
+		} else {
+			attachment.HasThumbnail = true
+		}
+	}
+
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("attachment uploaded",
+		slog.String("transaction_id", transactionID.String()),
+		slog.String("attachment_id", attachment.ID.String()),
+		slog.Int64("size", attachment.Size),
+	)
+
+	return attachment, nil
+}
+
+func (s *TransactionAttachmentsService) List(ctx context.Context, projectID, transactionID uuid.UUID) ([]*models.Attachment, error) {
+	if _, err := s.transactionForProject(ctx, projectID, transactionID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetByTransactionID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// Open returns the attachment and its content. The caller closes the reader.
+func (s *TransactionAttachmentsService) Open(ctx context.Context, projectID, attachmentID uuid.UUID) (*models.Attachment, io.ReadCloser, error) {
+	attachment, err := s.attachmentForProject(ctx, projectID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Open(ctx, attachment.BlobKey())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+
+	return attachment, content, nil
+}
+
+func (s *TransactionAttachmentsService) OpenThumbnail(ctx context.Context, projectID, attachmentID uuid.UUID) (io.ReadCloser, error) {
+	attachment, err := s.attachmentForProject(ctx, projectID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !attachment.HasThumbnail {
+		return nil, fmt.Errorf("attachment has no thumbnail")
+	}
+
+	content, err := s.blobs.Open(ctx, attachment.ThumbnailKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open thumbnail: %w", err)
+	}
+
+	return content, nil
+}
+
+func (s *TransactionAttachmentsService) Delete(ctx context.Context, projectID, attachmentID uuid.UUID) (*models.Attachment, error) {
+	attachment, err := s.attachmentForProject(ctx, projectID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.delete(ctx, attachment); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("attachment deleted", slog.String("attachment_id", attachmentID.String()))
+
+	return attachment, nil
+}
+
+// DeleteTransactionAttachments removes every attachment of a transaction that is
+// being deleted, together with blobs no other attachment uses.
+func (s *TransactionAttachmentsService) DeleteTransactionAttachments(ctx context.Context, transactionID uuid.UUID) error {
+	attachments, err := s.attachmentRepo.GetByTransactionID(ctx, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		if err := s.delete(ctx, attachment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *TransactionAttachmentsService) delete(ctx context.Context, attachment *models.Attachment) error {
+	if err := s.attachmentRepo.DeleteByID(ctx, attachment.ID); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	remaining, err := s.attachmentRepo.CountByChecksum(ctx, attachment.Checksum)
+	if err != nil {
+		return fmt.Errorf("failed to check attachment references: %w", err)
+	}
+
+	if remaining > 0 {
+		return nil
+	}
+
+	if err := s.blobs.Delete(ctx, attachment.BlobKey()); err != nil {
+		return fmt.Errorf("failed to delete attachment content: %w", err)
+	}
+
+	if err := s.blobs.Delete(ctx, attachment.ThumbnailKey()); err != nil {
+		return fmt.Errorf("failed to delete attachment thumbnail: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TransactionAttachmentsService) putIfMissing(ctx context.Context, key string, data []byte) error {
+	exists, err := s.blobs.Exists(ctx, key)
+	if err != nil {
+		return fmt.Errorf("failed to check stored content: %w", err)
+	}
+
+	if exists {
+		return nil
+	}
+
+	if err := s.blobs.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to store content: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TransactionAttachmentsService) transactionForProject(ctx context.Context, projectID, transactionID uuid.UUID) (*models.Transaction, error) {
+	transaction, err := s.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := s.validateAccountSvc.ValidateAccountForProject(ctx, projectID, transaction.AccountID); err != nil {
+		return nil, fmt.Errorf("transaction not found: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func (s *TransactionAttachmentsService) attachmentForProject(ctx context.Context, projectID, attachmentID uuid.UUID) (*models.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, fmt.Errorf("attachment not found: %w", err)
+	}
+
+	if _, err := s.transactionForProject(ctx, projectID, attachment.TransactionID); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func detectContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
+
+// sanitizeFileName keeps only the base name the browser sent, without control
+// characters, so it is safe to echo back in a Content-Disposition header.
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	if name == "." || name == "/" {
+		return ""
+	}
+
+	if len(name) > maxFileNameLength {
+		extension := filepath.Ext(name)
+		if len(extension) > 16 {
+			extension = ""
+		}
+		name = strings.ToValidUTF8(name[:maxFileNameLength-len(extension)], "") + extension
+	}
+
+	return name
+}
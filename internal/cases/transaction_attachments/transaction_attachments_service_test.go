@@ -0,0 +1,228 @@
+package transaction_attachments
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/infrastructure/storage"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+type fixture struct {
+	service        *TransactionAttachmentsService
+	attachmentRepo *database.AttachmentInMemoryRepository
+	blobs          *storage.InMemoryBlobStore
+	projectID      uuid.UUID
+	transaction    *models.Transaction
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	attachmentRepo := database.NewAttachmentInMemoryRepository()
+	blobs := storage.NewInMemoryBlobStore()
+
+	projectID := uuid.New()
+	account := models.NewAccount(projectID, "Main", money.PLN)
+	accountRepo.Create(context.Background(), account)
+
+	transaction := models.NewTransaction(models.TransactionData{
+		AccountID: account.ID,
+		Value:     25.0,
+		Name:      "Groceries",
+		Type:      models.Debit,
+	}, uuid.New())
+	transactionRepo.Create(context.Background(), transaction)
+
+	service := NewTransactionAttachmentsService(attachmentRepo, transactionRepo, accountRepo, blobs, Limits{
+		MaxSize:      64 << 10,
+		ContentTypes: []string{"image/png", "application/pdf", "text/plain"},
+	})
+
+	return fixture{
+		service:        service,
+		attachmentRepo: attachmentRepo,
+		blobs:          blobs,
+		projectID:      projectID,
+		transaction:    transaction,
+	}
+}
+
+func pngImage(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode test image: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestTransactionAttachmentsService_Upload(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	attachment, err := f.service.Upload(ctx, f.projectID, f.transaction.ID, `C:\scans\receipt.png`, bytes.NewReader(pngImage(t, 600, 300)))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if attachment.FileName != "receipt.png" {
+		t.Errorf("Expected file name receipt.png, got %s", attachment.FileName)
+	}
+
+	if attachment.ContentType != "image/png" {
+		t.Errorf("Expected content type image/png, got %s", attachment.ContentType)
+	}
+
+	if !attachment.HasThumbnail {
+		t.Fatal("Expected a thumbnail for an image attachment")
+	}
+
+	thumbnail, err := f.service.OpenThumbnail(ctx, f.projectID, attachment.ID)
+	if err != nil {
+		t.Fatalf("Expected thumbnail to open, got %v", err)
+	}
+	defer thumbnail.Close()
+
+	decoded, err := jpeg.Decode(thumbnail)
+	if err != nil {
+		t.Fatalf("Expected thumbnail to be a JPEG, got %v", err)
+	}
+
+	if bounds := decoded.Bounds(); bounds.Dx() != thumbnailSize || bounds.Dy() != thumbnailSize/2 {
+		t.Errorf("Expected thumbnail %dx%d, got %dx%d", thumbnailSize, thumbnailSize/2, bounds.Dx(), bounds.Dy())
+	}
+
+	_, content, err := f.service.Open(ctx, f.projectID, attachment.ID)
+	if err != nil {
+		t.Fatalf("Expected attachment to open, got %v", err)
+	}
+	defer content.Close()
+
+	data, _ := io.ReadAll(content)
+	if int64(len(data)) != attachment.Size {
+		t.Errorf("Expected %d bytes, got %d", attachment.Size, len(data))
+	}
+}
+
+func TestTransactionAttachmentsService_Upload_Rejected(t *testing.T) {
+	f := newFixture(t)
+
+	tests := []struct {
+		name          string
+		projectID     uuid.UUID
+		fileName      string
+		content       []byte
+		expectedError string
+	}{
+		{
+			name:          "too large",
+			projectID:     f.projectID,
+			fileName:      "big.txt",
+			content:       bytes.Repeat([]byte("a"), 64<<10+1),
+			expectedError: "larger than",
+		},
+		{
+			name:          "type not allowed",
+			projectID:     f.projectID,
+			fileName:      "page.html",
+			content:       []byte("<html><body>hi</body></html>"),
+			expectedError: "text/html is not allowed",
+		},
+		{
+			name:          "empty file",
+			projectID:     f.projectID,
+			fileName:      "empty.txt",
+			content:       nil,
+			expectedError: "file is empty",
+		},
+		{
+			name:          "transaction of another project",
+			projectID:     uuid.New(),
+			fileName:      "receipt.txt",
+			content:       []byte("receipt"),
+			expectedError: "transaction not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.Upload(context.Background(), tt.projectID, f.transaction.ID, tt.fileName, bytes.NewReader(tt.content))
+			if err == nil {
+				t.Fatalf("Expected error containing %q, got nil", tt.expectedError)
+			}
+
+			if !strings.Contains(err.Error(), tt.expectedError) {
+				t.Errorf("Expected error to contain '%s', got '%s'", tt.expectedError, err.Error())
+			}
+		})
+	}
+}
+
+func TestTransactionAttachmentsService_Delete_KeepsSharedContent(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	first, err := f.service.Upload(ctx, f.projectID, f.transaction.ID, "a.txt", strings.NewReader("same receipt"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	second, err := f.service.Upload(ctx, f.projectID, f.transaction.ID, "b.txt", strings.NewReader("same receipt"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if first.Checksum != second.Checksum {
+		t.Fatalf("Expected identical content to share a checksum")
+	}
+
+	if _, err := f.service.Delete(ctx, f.projectID, first.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if exists, _ := f.blobs.Exists(ctx, second.BlobKey()); !exists {
+		t.Fatal("Expected content to stay while another attachment uses it")
+	}
+
+	if _, err := f.service.Delete(ctx, f.projectID, second.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if exists, _ := f.blobs.Exists(ctx, second.BlobKey()); exists {
+		t.Error("Expected content to be removed with its last attachment")
+	}
+}
+
+func TestTransactionAttachmentsService_Open_OtherProject(t *testing.T) {
+	f := newFixture(t)
+
+	attachment, err := f.service.Upload(context.Background(), f.projectID, f.transaction.ID, "a.txt", strings.NewReader("receipt"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, _, err := f.service.Open(context.Background(), uuid.New(), attachment.ID); err == nil {
+		t.Error("Expected attachments of another project to be hidden")
+	}
+}
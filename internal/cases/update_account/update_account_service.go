@@ -0,0 +1,204 @@
+package update_account
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/create_account"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type UpdateAccountService struct {
+	accountRepo models.AccountRepository
+}
+
+func NewUpdateAccountService(accountRepo models.AccountRepository) *UpdateAccountService {
+	return &UpdateAccountService{
+		accountRepo: accountRepo,
+	}
+}
+
+// UpdateAccountData holds the editable details of an account. The currency is
+// fixed once transactions may have been recorded in it, and the credit card
+// terms are cleared for other account types.
+type UpdateAccountData struct {
+	Name         string
+	Type         models.AccountType
+	Description  string
+	CreditLimit  float64
+	StatementDay int
+	DueDay       int
+}
+
+func (s *UpdateAccountService) UpdateAccount(ctx context.Context, projectID, accountID uuid.UUID, data UpdateAccountData) (*models.Account, error) {
+	account, err := s.getAccount(ctx, projectID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(data.Name)
+	description := strings.TrimSpace(data.Description)
+
+	if name == "" {
+		return nil, fmt.Errorf("account name is required")
+	}
+
+	if len(name) > create_account.MaxNameLength {
+		return nil, fmt.Errorf("account name cannot be longer than %d characters", create_account.MaxNameLength)
+	}
+
+	if !data.Type.IsValid() {
+		return nil, fmt.Errorf("invalid account type: %s", data.Type)
+	}
+
+	if len(description) > models.MaxAccountDescriptionLength {
+		return nil, fmt.Errorf("description cannot be longer than %d characters", models.MaxAccountDescriptionLength)
+	}
+
+	if data.Type != models.AccountCreditCard {
+		data.CreditLimit, data.StatementDay, data.DueDay = 0, 0, 0
+	}
+
+	if err := models.ValidateCreditCardTerms(data.CreditLimit, data.StatementDay, data.DueDay); err != nil {
+		return nil, err
+	}
+
+	if name != account.Name {
+		exists, err := s.accountRepo.ExistsByName(ctx, projectID, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if account exists: %w", err)
+		}
+
+		if exists {
+			return nil, fmt.Errorf("account with name '%s' already exists for this project", name)
+		}
+	}
+
+	account.Name = name
+	account.Type = data.Type
+	account.Description = description
+	account.CreditLimit = data.CreditLimit
+	account.StatementDay = data.StatementDay
+	account.DueDay = data.DueDay
+
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("account updated",
+		slog.String("account_id", accountID.String()),
+	)
+
+	return account, nil
+}
+
+// ArchiveAccount hides an account from forms. Its transactions and balance stay
+// in history and it can be restored with UnarchiveAccount.
+func (s *UpdateAccountService) ArchiveAccount(ctx context.Context, projectID, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.getAccount(ctx, projectID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.IsArchived() {
+		return nil, fmt.Errorf("account '%s' is already archived", account.Name)
+	}
+
+	now := time.Now()
+	account.ArchivedAt = &now
+
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to archive account: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("account archived",
+		slog.String("account_id", accountID.String()),
+	)
+
+	return account, nil
+}
+
+func (s *UpdateAccountService) UnarchiveAccount(ctx context.Context, projectID, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.getAccount(ctx, projectID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !account.IsArchived() {
+		return nil, fmt.Errorf("account '%s' is not archived", account.Name)
+	}
+
+	account.ArchivedAt = nil
+
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to unarchive account: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("account unarchived",
+		slog.String("account_id", accountID.String()),
+	)
+
+	return account, nil
+}
+
+// MoveAccount shifts an account offset places in the display order, negative
+// meaning up, and renumbers the project's accounts from zero.
+func (s *UpdateAccountService) MoveAccount(ctx context.Context, projectID, accountID uuid.UUID, offset int) error {
+	accounts, err := s.accountRepo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project accounts: %w", err)
+	}
+
+	from := -1
+	for i, account := range accounts {
+		if account.ID == accountID {
+			from = i
+			break
+		}
+	}
+
+	if from < 0 {
+		return fmt.Errorf("account not found")
+	}
+
+	to := min(max(from+offset, 0), len(accounts)-1)
+	moved := accounts[from]
+	accounts = append(accounts[:from], accounts[from+1:]...)
+	accounts = append(accounts[:to], append([]*models.Account{moved}, accounts[to:]...)...)
+
+	for i, account := range accounts {
+		if account.Position == i {
+			continue
+		}
+
+		account.Position = i
+		if err := s.accountRepo.Update(ctx, account); err != nil {
+			return fmt.Errorf("failed to reorder accounts: %w", err)
+		}
+	}
+
+	logging.FromContext(ctx).Info("account moved",
+		slog.String("account_id", accountID.String()),
+		slog.Int("position", to),
+	)
+
+	return nil
+}
+
+func (s *UpdateAccountService) getAccount(ctx context.Context, projectID, accountID uuid.UUID) (*models.Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+
+	if account.ProjectID != projectID {
+		return nil, fmt.Errorf("account does not belong to the specified project")
+	}
+
+	return account, nil
+}
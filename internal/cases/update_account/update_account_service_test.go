@@ -0,0 +1,166 @@
+package update_account
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestUpdateAccountService_UpdateAccount(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	service := NewUpdateAccountService(accountRepo)
+
+	projectID := uuid.New()
+	account := models.NewAccount(projectID, "Main", money.PLN)
+	other := models.NewAccount(projectID, "Savings", money.PLN)
+	accountRepo.Create(ctx, account)
+	accountRepo.Create(ctx, other)
+
+	tests := []struct {
+		name      string
+		projectID uuid.UUID
+		data      UpdateAccountData
+		errorMsg  string
+	}{
+		{
+			name:      "successful rename",
+			projectID: projectID,
+			data:      UpdateAccountData{Name: " Daily ", Type: models.AccountCash, Description: "Wallet"},
+		},
+		{
+			name:      "error when name is taken",
+			projectID: projectID,
+			data:      UpdateAccountData{Name: "Savings", Type: models.AccountChecking},
+			errorMsg:  "account with name 'Savings' already exists for this project",
+		},
+		{
+			name:      "error when name is empty",
+			projectID: projectID,
+			data:      UpdateAccountData{Name: " ", Type: models.AccountChecking},
+			errorMsg:  "account name is required",
+		},
+		{
+			name:      "error when type is invalid",
+			projectID: projectID,
+			data:      UpdateAccountData{Name: "Daily", Type: "piggy_bank"},
+			errorMsg:  "invalid account type: piggy_bank",
+		},
+		{
+			name:      "error when account belongs to another project",
+			projectID: uuid.New(),
+			data:      UpdateAccountData{Name: "Daily", Type: models.AccountChecking},
+			errorMsg:  "account does not belong to the specified project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := service.UpdateAccount(ctx, tt.projectID, account.ID, tt.data)
+
+			if tt.errorMsg != "" {
+				if err == nil || err.Error() != tt.errorMsg {
+					t.Fatalf("Expected error %q, got %v", tt.errorMsg, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if updated.Name != "Daily" || updated.Type != models.AccountCash || updated.Description != "Wallet" {
+				t.Errorf("Unexpected account %+v", updated)
+			}
+		})
+	}
+
+	if exists, _ := accountRepo.ExistsByName(ctx, projectID, "Main"); exists {
+		t.Error("Expected the old name to be free after renaming")
+	}
+}
+
+func TestUpdateAccountService_Archive(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	service := NewUpdateAccountService(accountRepo)
+
+	projectID := uuid.New()
+	account := models.NewAccount(projectID, "Old card", money.PLN)
+	accountRepo.Create(ctx, account)
+
+	if _, err := service.UnarchiveAccount(ctx, projectID, account.ID); err == nil {
+		t.Error("Expected error when unarchiving an active account")
+	}
+
+	archived, err := service.ArchiveAccount(ctx, projectID, account.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !archived.IsArchived() {
+		t.Error("Expected the account to be archived")
+	}
+
+	if _, err := service.ArchiveAccount(ctx, projectID, account.ID); err == nil {
+		t.Error("Expected error when archiving twice")
+	}
+
+	restored, err := service.UnarchiveAccount(ctx, projectID, account.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if restored.IsArchived() {
+		t.Error("Expected the account to be active again")
+	}
+}
+
+func TestUpdateAccountService_MoveAccount(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	service := NewUpdateAccountService(accountRepo)
+
+	projectID := uuid.New()
+	var ids []uuid.UUID
+	for i, name := range []string{"A", "B", "C"} {
+		account := models.NewAccount(projectID, name, money.PLN)
+		account.Position = i
+		accountRepo.Create(ctx, account)
+		ids = append(ids, account.ID)
+	}
+
+	order := func() string {
+		accounts, _ := accountRepo.GetByProjectID(ctx, projectID)
+		var names string
+		for _, account := range accounts {
+			names += account.Name
+		}
+		return names
+	}
+
+	steps := []struct {
+		accountID uuid.UUID
+		offset    int
+		want      string
+	}{
+		{accountID: ids[2], offset: -1, want: "ACB"},
+		{accountID: ids[2], offset: -1, want: "CAB"},
+		{accountID: ids[2], offset: -1, want: "CAB"},
+		{accountID: ids[0], offset: 5, want: "CBA"},
+	}
+
+	for _, step := range steps {
+		if err := service.MoveAccount(ctx, projectID, step.accountID, step.offset); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got := order(); got != step.want {
+			t.Errorf("Expected order %s, got %s", step.want, got)
+		}
+	}
+
+	if err := service.MoveAccount(ctx, uuid.New(), ids[0], 1); err == nil {
+		t.Error("Expected error for an account from another project")
+	}
+}
@@ -0,0 +1,105 @@
+package update_loan
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type UpdateLoanService struct {
+	loanRepo models.LoanRepository
+}
+
+func NewUpdateLoanService(loanRepo models.LoanRepository) *UpdateLoanService {
+	return &UpdateLoanService{
+		loanRepo: loanRepo,
+	}
+}
+
+// AddRatePeriod sets a new annual rate for the installments due on or after
+// startsOn, as variable-rate loans are reset.
+func (s *UpdateLoanService) AddRatePeriod(ctx context.Context, projectID, accountID uuid.UUID, startsOn time.Time, annualRate float64) (*models.LoanRatePeriod, error) {
+	loan, err := s.getLoan(ctx, projectID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := models.ValidateAnnualRate(annualRate); err != nil {
+		return nil, err
+	}
+
+	if !startsOn.After(loan.StartDate) {
+		return nil, fmt.Errorf("a rate change must start after the loan start date")
+	}
+
+	periods, err := s.loanRepo.GetRatePeriods(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate periods: %w", err)
+	}
+
+	for _, period := range periods {
+		if period.StartsOn.Equal(startsOn) {
+			return nil, fmt.Errorf("a rate change on %s already exists", startsOn.Format("2006-01-02"))
+		}
+	}
+
+	period := models.NewLoanRatePeriod(accountID, startsOn, annualRate)
+	if err := s.loanRepo.AddRatePeriod(ctx, period); err != nil {
+		return nil, fmt.Errorf("failed to add rate period: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("loan rate period added",
+		slog.String("account_id", accountID.String()),
+		slog.String("period_id", period.ID.String()),
+	)
+
+	return period, nil
+}
+
+func (s *UpdateLoanService) RemoveRatePeriod(ctx context.Context, projectID, accountID, periodID uuid.UUID) error {
+	if _, err := s.getLoan(ctx, projectID, accountID); err != nil {
+		return err
+	}
+
+	periods, err := s.loanRepo.GetRatePeriods(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to get rate periods: %w", err)
+	}
+
+	for _, period := range periods {
+		if period.ID != periodID {
+			continue
+		}
+
+		if err := s.loanRepo.DeleteRatePeriod(ctx, periodID); err != nil {
+			return fmt.Errorf("failed to remove rate period: %w", err)
+		}
+
+		logging.FromContext(ctx).Info("loan rate period removed",
+			slog.String("account_id", accountID.String()),
+			slog.String("period_id", periodID.String()),
+		)
+
+		return nil
+	}
+
+	return fmt.Errorf("rate period not found")
+}
+
+func (s *UpdateLoanService) getLoan(ctx context.Context, projectID, accountID uuid.UUID) (*models.Loan, error) {
+	loan, err := s.loanRepo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("loan not found: %w", err)
+	}
+
+	if loan.ProjectID != projectID {
+		return nil, fmt.Errorf("loan does not belong to the specified project")
+	}
+
+	return loan, nil
+}
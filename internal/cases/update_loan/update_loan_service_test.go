@@ -0,0 +1,69 @@
+package update_loan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestUpdateLoanService_RatePeriods(t *testing.T) {
+	ctx := context.Background()
+	loanRepo := database.NewLoanInMemoryRepository()
+	service := NewUpdateLoanService(loanRepo)
+
+	start := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	account := models.NewAccount(uuid.New(), "Mortgage", money.PLN)
+	loan := models.NewLoan(account, 12000, 6, 24, 10, start)
+	loanRepo.Create(ctx, loan)
+
+	tests := []struct {
+		name        string
+		projectID   uuid.UUID
+		startsOn    time.Time
+		rate        float64
+		expectError bool
+	}{
+		{name: "valid change", projectID: loan.ProjectID, startsOn: start.AddDate(0, 6, 0), rate: 7.5},
+		{name: "duplicate start", projectID: loan.ProjectID, startsOn: start.AddDate(0, 6, 0), rate: 8, expectError: true},
+		{name: "before loan start", projectID: loan.ProjectID, startsOn: start, rate: 8, expectError: true},
+		{name: "invalid rate", projectID: loan.ProjectID, startsOn: start.AddDate(0, 9, 0), rate: 101, expectError: true},
+		{name: "wrong project", projectID: uuid.New(), startsOn: start.AddDate(0, 9, 0), rate: 8, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AddRatePeriod(ctx, tt.projectID, loan.AccountID, tt.startsOn, tt.rate)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+
+	periods, _ := loanRepo.GetRatePeriods(ctx, loan.AccountID)
+	if len(periods) != 1 || periods[0].AnnualRate != 7.5 {
+		t.Fatalf("Expected one rate period at 7.5%%, got %+v", periods)
+	}
+
+	if err := service.RemoveRatePeriod(ctx, uuid.New(), loan.AccountID, periods[0].ID); err == nil {
+		t.Error("Expected removing from another project to fail")
+	}
+	if err := service.RemoveRatePeriod(ctx, loan.ProjectID, loan.AccountID, uuid.New()); err == nil {
+		t.Error("Expected removing an unknown period to fail")
+	}
+	if err := service.RemoveRatePeriod(ctx, loan.ProjectID, loan.AccountID, periods[0].ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	periods, _ = loanRepo.GetRatePeriods(ctx, loan.AccountID)
+	if len(periods) != 0 {
+		t.Errorf("Expected no rate periods left, got %d", len(periods))
+	}
+}
@@ -0,0 +1,135 @@
+package update_payee
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/match_payee"
+	"gofin/internal/cases/validate_category"
+	"gofin/internal/cases/validate_payee"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type UpdatePayeeService struct {
+	payeeRepo           models.PayeeRepository
+	validatePayeeSvc    *validate_payee.ValidatePayeeService
+	validateCategorySvc *validate_category.ValidateCategoryService
+	matchPayeeSvc       *match_payee.MatchPayeeService
+}
+
+func NewUpdatePayeeService(payeeRepo models.PayeeRepository, categoryRepo models.CategoryRepository, transactionRepo models.TransactionRepository, projectRepo models.ProjectRepository) *UpdatePayeeService {
+	return &UpdatePayeeService{
+		payeeRepo:           payeeRepo,
+		validatePayeeSvc:    validate_payee.NewValidatePayeeService(payeeRepo),
+		validateCategorySvc: validate_category.NewValidateCategoryService(categoryRepo),
+		matchPayeeSvc:       match_payee.NewMatchPayeeService(payeeRepo, transactionRepo, projectRepo),
+	}
+}
+
+// AddAlias teaches a payee another spelling and links existing transactions
+// that now match it.
+func (s *UpdatePayeeService) AddAlias(ctx context.Context, projectID, payeeID uuid.UUID, alias string) (*models.PayeeAlias, error) {
+	payee, err := s.validatePayeeSvc.GetPayeeForProject(ctx, projectID, payeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := models.NormalizePayeeName(alias)
+	if normalized == "" {
+		return nil, fmt.Errorf("alias must contain letters")
+	}
+
+	aliases, err := s.payeeRepo.GetAliasesByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payee aliases: %w", err)
+	}
+
+	for _, existing := range aliases {
+		if existing.Alias == normalized && existing.PayeeID == payeeID {
+			return nil, fmt.Errorf("payee already has alias '%s'", normalized)
+		}
+	}
+
+	if err := s.validatePayeeSvc.ValidateAliasAvailable(ctx, projectID, payeeID, normalized); err != nil {
+		return nil, err
+	}
+
+	payeeAlias := models.NewPayeeAlias(payee, normalized)
+	if err := s.payeeRepo.AddAlias(ctx, payeeAlias); err != nil {
+		return nil, fmt.Errorf("failed to add payee alias: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("payee alias added",
+		slog.String("payee_id", payeeID.String()),
+		slog.String("alias_id", payeeAlias.ID.String()),
+	)
+
+	if _, err := s.matchPayeeSvc.LinkUnassigned(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	return payeeAlias, nil
+}
+
+// RemoveAlias stops a spelling from matching the payee. Transactions already
+// linked through it keep their payee.
+func (s *UpdatePayeeService) RemoveAlias(ctx context.Context, projectID, payeeID, aliasID uuid.UUID) error {
+	if _, err := s.validatePayeeSvc.GetPayeeForProject(ctx, projectID, payeeID); err != nil {
+		return err
+	}
+
+	aliases, err := s.payeeRepo.GetAliasesByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get payee aliases: %w", err)
+	}
+
+	found := false
+	for _, alias := range aliases {
+		if alias.ID == aliasID && alias.PayeeID == payeeID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("payee alias not found")
+	}
+
+	if err := s.payeeRepo.DeleteAlias(ctx, aliasID); err != nil {
+		return fmt.Errorf("failed to remove payee alias: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("payee alias removed",
+		slog.String("payee_id", payeeID.String()),
+		slog.String("alias_id", aliasID.String()),
+	)
+
+	return nil
+}
+
+// SetDefaultCategory changes the category new transactions of the payee get when
+// they are created without one. Pass nil to clear it.
+func (s *UpdatePayeeService) SetDefaultCategory(ctx context.Context, projectID, payeeID uuid.UUID, categoryID *uuid.UUID) error {
+	if _, err := s.validatePayeeSvc.GetPayeeForProject(ctx, projectID, payeeID); err != nil {
+		return err
+	}
+
+	if categoryID != nil {
+		if err := s.validateCategorySvc.ValidateCategoryForProject(ctx, projectID, *categoryID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.payeeRepo.UpdateDefaultCategory(ctx, payeeID, categoryID); err != nil {
+		return fmt.Errorf("failed to update payee: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("payee default category updated",
+		slog.String("payee_id", payeeID.String()),
+	)
+
+	return nil
+}
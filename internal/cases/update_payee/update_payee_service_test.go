@@ -0,0 +1,111 @@
+package update_payee
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+)
+
+func TestUpdatePayeeService_Aliases(t *testing.T) {
+	ctx := context.Background()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	project := models.NewProject("Home", "home")
+	projectRepo.Create(ctx, project)
+	service := NewUpdatePayeeService(payeeRepo, database.NewCategoryInMemoryRepository(), transactionRepo, projectRepo)
+
+	projectID := project.ID
+	orlen := models.NewPayee(projectID, "Orlen", nil)
+	lidl := models.NewPayee(projectID, "Lidl", nil)
+	payeeRepo.Create(ctx, orlen)
+	payeeRepo.Create(ctx, lidl)
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(orlen, "orlen"))
+	payeeRepo.AddAlias(ctx, models.NewPayeeAlias(lidl, "lidl"))
+
+	transaction := models.NewTransaction(models.TransactionData{AccountID: uuid.New(), Value: 200, Name: "PKN STACJA 12", Type: models.Debit})
+	transactionRepo.Create(ctx, transaction)
+
+	alias, err := service.AddAlias(ctx, projectID, orlen.ID, "PKN Stacja")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if alias.Alias != "pkn stacja" {
+		t.Errorf("Expected a normalized alias, got %q", alias.Alias)
+	}
+
+	if got, _ := transactionRepo.GetByID(ctx, transaction.ID); got.PayeeID == nil || *got.PayeeID != orlen.ID {
+		t.Errorf("Expected the matching transaction to be linked, got %v", got.PayeeID)
+	}
+
+	errorCases := []struct {
+		name     string
+		payeeID  uuid.UUID
+		alias    string
+		errorMsg string
+	}{
+		{name: "duplicate alias", payeeID: orlen.ID, alias: "ORLEN", errorMsg: "payee already has alias 'orlen'"},
+		{name: "alias of another payee", payeeID: orlen.ID, alias: "Lidl 12", errorMsg: "alias 'lidl' is already used by payee 'Lidl'"},
+		{name: "alias without letters", payeeID: orlen.ID, alias: "42", errorMsg: "alias must contain letters"},
+	}
+
+	for _, tt := range errorCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.AddAlias(ctx, projectID, tt.payeeID, tt.alias); err == nil || err.Error() != tt.errorMsg {
+				t.Errorf("Expected error %q, got %v", tt.errorMsg, err)
+			}
+		})
+	}
+
+	if err := service.RemoveAlias(ctx, projectID, lidl.ID, alias.ID); err == nil {
+		t.Error("Expected error when removing an alias of another payee")
+	}
+
+	if err := service.RemoveAlias(ctx, projectID, orlen.ID, alias.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got, _ := transactionRepo.GetByID(ctx, transaction.ID); got.PayeeID == nil {
+		t.Error("Expected linked transactions to keep their payee after the alias is removed")
+	}
+}
+
+func TestUpdatePayeeService_SetDefaultCategory(t *testing.T) {
+	ctx := context.Background()
+	payeeRepo := database.NewPayeeInMemoryRepository()
+	categoryRepo := database.NewCategoryInMemoryRepository()
+	service := NewUpdatePayeeService(payeeRepo, categoryRepo, database.NewTransactionInMemoryRepository(), database.NewProjectInMemoryRepository())
+
+	projectID := uuid.New()
+	payee := models.NewPayee(projectID, "Orlen", nil)
+	payeeRepo.Create(ctx, payee)
+	fuel := models.NewCategory(projectID, "Fuel")
+	foreign := models.NewCategory(uuid.New(), "Fuel")
+	categoryRepo.Create(ctx, fuel)
+	categoryRepo.Create(ctx, foreign)
+
+	if err := service.SetDefaultCategory(ctx, projectID, payee.ID, &foreign.ID); err == nil {
+		t.Error("Expected error for a category from another project")
+	}
+
+	if err := service.SetDefaultCategory(ctx, projectID, payee.ID, &fuel.ID); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got, _ := payeeRepo.GetByID(ctx, payee.ID); got.DefaultCategoryID == nil || *got.DefaultCategoryID != fuel.ID {
+		t.Errorf("Expected the default category to be set, got %v", got.DefaultCategoryID)
+	}
+
+	if err := service.SetDefaultCategory(ctx, uuid.New(), payee.ID, nil); err == nil {
+		t.Error("Expected error for a payee from another project")
+	}
+
+	if err := service.SetDefaultCategory(ctx, projectID, payee.ID, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got, _ := payeeRepo.GetByID(ctx, payee.ID); got.DefaultCategoryID != nil {
+		t.Errorf("Expected the default category to be cleared, got %v", got.DefaultCategoryID)
+	}
+}
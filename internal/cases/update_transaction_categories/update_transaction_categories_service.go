@@ -0,0 +1,73 @@
+package update_transaction_categories
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_account"
+	"gofin/internal/cases/validate_category"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type UpdateTransactionCategoriesService struct {
+	transactionRepo     models.TransactionRepository
+	splitRepo           models.TransactionSplitRepository
+	validateAccountSvc  *validate_account.ValidateAccountService
+	validatePeriodSvc   *validate_period.ValidatePeriodService
+	validateCategorySvc *validate_category.ValidateCategoryService
+}
+
+func NewUpdateTransactionCategoriesService(transactionRepo models.TransactionRepository, splitRepo models.TransactionSplitRepository, accountRepo models.AccountRepository, categoryRepo models.CategoryRepository, projectRepo models.ProjectRepository) *UpdateTransactionCategoriesService {
+	return &UpdateTransactionCategoriesService{
+		transactionRepo:     transactionRepo,
+		splitRepo:           splitRepo,
+		validateAccountSvc:  validate_account.NewValidateAccountService(accountRepo),
+		validatePeriodSvc:   validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+		validateCategorySvc: validate_category.NewValidateCategoryService(categoryRepo),
+	}
+}
+
+// UpdateCategories replaces how a transaction is categorised. Passing splits turns it
+// into a split transaction and clears its own category; passing none removes any
+// existing splits and leaves categoryID (possibly nil) in charge.
+func (s *UpdateTransactionCategoriesService) UpdateCategories(ctx context.Context, projectID, transactionID uuid.UUID, categoryID *uuid.UUID, splits []models.SplitData) error {
+	transaction, err := s.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := s.validateAccountSvc.ValidateAccountForProject(ctx, projectID, transaction.AccountID); err != nil {
+		return fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := transaction.EnsureEditable(); err != nil {
+		return err
+	}
+
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, transaction.TransactionDate); err != nil {
+		return err
+	}
+
+	if err := s.validateCategorySvc.ValidateAssignment(ctx, projectID, transaction.Value, categoryID, splits); err != nil {
+		return err
+	}
+
+	if err := s.splitRepo.ReplaceForTransaction(ctx, transactionID, models.NewTransactionSplits(transactionID, splits)); err != nil {
+		return fmt.Errorf("failed to save splits: %w", err)
+	}
+
+	if err := s.transactionRepo.UpdateCategory(ctx, transactionID, categoryID); err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("transaction categories updated",
+		slog.String("transaction_id", transactionID.String()),
+		slog.Int("splits", len(splits)),
+	)
+
+	return nil
+}
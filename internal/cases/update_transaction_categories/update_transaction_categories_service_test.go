@@ -0,0 +1,115 @@
+package update_transaction_categories
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestUpdateTransactionCategoriesService_UpdateCategories(t *testing.T) {
+	ctx := context.Background()
+	transactionRepo := database.NewTransactionInMemoryRepository()
+	splitRepo := database.NewTransactionSplitInMemoryRepository()
+	accountRepo := database.NewAccountInMemoryRepository()
+	categoryRepo := database.NewCategoryInMemoryRepository()
+	projectRepo := database.NewProjectInMemoryRepository()
+	service := NewUpdateTransactionCategoriesService(transactionRepo, splitRepo, accountRepo, categoryRepo, projectRepo)
+
+	project := models.NewProject("Test Project", "test-project")
+	projectRepo.Create(ctx, project)
+	projectID := project.ID
+	account := models.NewAccount(projectID, "Main", money.PLN)
+	accountRepo.Create(ctx, account)
+
+	groceries := models.NewCategory(projectID, "Groceries")
+	pharmacy := models.NewCategory(projectID, "Pharmacy")
+	foreign := models.NewCategory(uuid.New(), "Foreign")
+	for _, category := range []*models.Category{groceries, pharmacy, foreign} {
+		categoryRepo.Create(ctx, category)
+	}
+
+	transaction := models.NewTransaction(models.TransactionData{
+		AccountID: account.ID,
+		Value:     100.10,
+		Name:      "Supermarket",
+		Type:      models.Debit,
+	}, uuid.New())
+	transactionRepo.Create(ctx, transaction)
+
+	tests := []struct {
+		name       string
+		categoryID *uuid.UUID
+		splits     []models.SplitData
+		errorMsg   string
+		wantSplits int
+	}{
+		{
+			name: "split across two categories",
+			splits: []models.SplitData{
+				{CategoryID: groceries.ID, Amount: 80.05, Memo: "food"},
+				{CategoryID: pharmacy.ID, Amount: 20.05},
+			},
+			wantSplits: 2,
+		},
+		{
+			name: "error when splits do not add up",
+			splits: []models.SplitData{
+				{CategoryID: groceries.ID, Amount: 80},
+				{CategoryID: pharmacy.ID, Amount: 20},
+			},
+			errorMsg:   "splits add up to 100.00 but the transaction value is 100.10",
+			wantSplits: 2,
+		},
+		{
+			name:       "error when split category belongs to another project",
+			splits:     []models.SplitData{{CategoryID: foreign.ID, Amount: 100.10}},
+			errorMsg:   "category does not belong to the specified project",
+			wantSplits: 2,
+		},
+		{
+			name:       "error when both category and splits are given",
+			categoryID: &groceries.ID,
+			splits:     []models.SplitData{{CategoryID: pharmacy.ID, Amount: 100.10}},
+			errorMsg:   "a split transaction cannot have a category of its own",
+			wantSplits: 2,
+		},
+		{
+			name:       "single category replaces splits",
+			categoryID: &groceries.ID,
+			wantSplits: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.UpdateCategories(ctx, projectID, transaction.ID, tt.categoryID, tt.splits)
+
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Errorf("Expected error containing '%s', got %v", tt.errorMsg, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+
+			splits, _ := splitRepo.GetByTransactionID(ctx, transaction.ID)
+			if len(splits) != tt.wantSplits {
+				t.Errorf("Expected %d splits, got %d", tt.wantSplits, len(splits))
+			}
+		})
+	}
+
+	updated, _ := transactionRepo.GetByID(ctx, transaction.ID)
+	if updated.CategoryID == nil || *updated.CategoryID != groceries.ID {
+		t.Errorf("Expected transaction category to be groceries, got %v", updated.CategoryID)
+	}
+
+	if err := service.UpdateCategories(ctx, uuid.New(), transaction.ID, nil, nil); err == nil {
+		t.Error("Expected error updating a transaction from another project")
+	}
+}
@@ -0,0 +1,60 @@
+package update_transaction_notes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_account"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type UpdateTransactionNotesService struct {
+	transactionRepo    models.TransactionRepository
+	validateAccountSvc *validate_account.ValidateAccountService
+	validatePeriodSvc  *validate_period.ValidatePeriodService
+}
+
+func NewUpdateTransactionNotesService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, projectRepo models.ProjectRepository) *UpdateTransactionNotesService {
+	return &UpdateTransactionNotesService{
+		transactionRepo:    transactionRepo,
+		validateAccountSvc: validate_account.NewValidateAccountService(accountRepo),
+		validatePeriodSvc:  validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+	}
+}
+
+func (s *UpdateTransactionNotesService) UpdateNotes(ctx context.Context, projectID, transactionID uuid.UUID, notes string) error {
+	notes = strings.TrimSpace(notes)
+	if len(notes) > models.MaxNotesLength {
+		return fmt.Errorf("notes cannot be longer than %d characters", models.MaxNotesLength)
+	}
+
+	transaction, err := s.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := s.validateAccountSvc.ValidateAccountForProject(ctx, projectID, transaction.AccountID); err != nil {
+		return fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := transaction.EnsureEditable(); err != nil {
+		return err
+	}
+
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, transaction.TransactionDate); err != nil {
+		return err
+	}
+
+	if err := s.transactionRepo.UpdateNotes(ctx, transactionID, notes); err != nil {
+		return fmt.Errorf("failed to update notes: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("transaction notes updated", slog.String("transaction_id", transactionID.String()))
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package update_transaction_status
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/validate_account"
+	"gofin/internal/cases/validate_period"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+)
+
+type UpdateTransactionStatusService struct {
+	transactionRepo    models.TransactionRepository
+	validateAccountSvc *validate_account.ValidateAccountService
+	validatePeriodSvc  *validate_period.ValidatePeriodService
+}
+
+func NewUpdateTransactionStatusService(transactionRepo models.TransactionRepository, accountRepo models.AccountRepository, projectRepo models.ProjectRepository) *UpdateTransactionStatusService {
+	return &UpdateTransactionStatusService{
+		transactionRepo:    transactionRepo,
+		validateAccountSvc: validate_account.NewValidateAccountService(accountRepo),
+		validatePeriodSvc:  validate_period.NewValidatePeriodService(projectRepo, accountRepo),
+	}
+}
+
+// UpdateStatus marks a transaction cleared or uncleared. Transactions only
+// become reconciled by finishing a reconciliation, and stay so.
+func (s *UpdateTransactionStatusService) UpdateStatus(ctx context.Context, projectID, transactionID uuid.UUID, status models.TransactionStatus) error {
+	if status == models.StatusReconciled {
+		return fmt.Errorf("transactions are reconciled by finishing a reconciliation")
+	}
+
+	transaction, err := s.transactionRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		return fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := s.validateAccountSvc.ValidateAccountForProject(ctx, projectID, transaction.AccountID); err != nil {
+		return fmt.Errorf("transaction not found: %w", err)
+	}
+
+	if err := transaction.EnsureEditable(); err != nil {
+		return err
+	}
+
+	if err := s.validatePeriodSvc.ValidatePeriodOpen(ctx, projectID, transaction.TransactionDate); err != nil {
+		return err
+	}
+
+	if err := s.transactionRepo.UpdateStatus(ctx, transactionID, status); err != nil {
+		return fmt.Errorf("failed to update status: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("transaction status updated",
+		slog.String("transaction_id", transactionID.String()),
+		slog.String("status", status.String()),
+	)
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package update_transaction_status
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+)
+
+func TestUpdateTransactionStatusService_UpdateStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		initial        models.TransactionStatus
+		status         models.TransactionStatus
+		otherProject   bool
+		expectError    bool
+		expectedStatus models.TransactionStatus
+	}{
+		{name: "clear", initial: models.StatusUncleared, status: models.StatusCleared, expectedStatus: models.StatusCleared},
+		{name: "unclear", initial: models.StatusCleared, status: models.StatusUncleared, expectedStatus: models.StatusUncleared},
+		{name: "reconcile directly", initial: models.StatusCleared, status: models.StatusReconciled, expectError: true, expectedStatus: models.StatusCleared},
+		{name: "reconciled is locked", initial: models.StatusReconciled, status: models.StatusUncleared, expectError: true, expectedStatus: models.StatusReconciled},
+		{name: "other project", initial: models.StatusUncleared, status: models.StatusCleared, otherProject: true, expectError: true, expectedStatus: models.StatusUncleared},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			transactionRepo := database.NewTransactionInMemoryRepository()
+			accountRepo := database.NewAccountInMemoryRepository()
+			projectRepo := database.NewProjectInMemoryRepository()
+			service := NewUpdateTransactionStatusService(transactionRepo, accountRepo, projectRepo)
+
+			project := models.NewProject("Test Project", "test-project")
+			projectRepo.Create(ctx, project)
+			projectID := project.ID
+			account := models.NewAccount(projectID, "Checking", money.PLN)
+			accountRepo.Create(ctx, account)
+
+			transaction := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 10, Name: "Coffee", Type: models.Debit})
+			transaction.Status = tt.initial
+			transactionRepo.Create(ctx, transaction)
+
+			requestProject := projectID
+			if tt.otherProject {
+				requestProject = uuid.New()
+			}
+
+			err := service.UpdateStatus(ctx, requestProject, transaction.ID, tt.status)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+
+			stored, _ := transactionRepo.GetByID(ctx, transaction.ID)
+			if stored.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tt.expectedStatus, stored.Status)
+			}
+		})
+	}
+}
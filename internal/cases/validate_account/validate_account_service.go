@@ -1,6 +1,7 @@
 package validate_account
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -17,16 +18,16 @@ func NewValidateAccountService(accountRepo models.AccountRepository) *ValidateAc
 	}
 }
 
-func (s *ValidateAccountService) ValidateAccountExists(accountID uuid.UUID) error {
-	_, err := s.accountRepo.GetByID(accountID)
+func (s *ValidateAccountService) ValidateAccountExists(ctx context.Context, accountID uuid.UUID) error {
+	_, err := s.accountRepo.GetByID(ctx, accountID)
 	if err != nil {
 		return fmt.Errorf("account not found: %w", err)
 	}
 	return nil
 }
 
-func (s *ValidateAccountService) ValidateAccountForProject(projectID uuid.UUID, accountID uuid.UUID) error {
-	account, err := s.accountRepo.GetByID(accountID)
+func (s *ValidateAccountService) ValidateAccountForProject(ctx context.Context, projectID uuid.UUID, accountID uuid.UUID) error {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
 	if err != nil {
 		return fmt.Errorf("account not found: %w", err)
 	}
@@ -37,3 +38,22 @@ func (s *ValidateAccountService) ValidateAccountForProject(projectID uuid.UUID,
 
 	return nil
 }
+
+// ValidateAccountActive checks the account belongs to projectID and is not
+// archived, so new transactions can be recorded on it.
+func (s *ValidateAccountService) ValidateAccountActive(ctx context.Context, projectID uuid.UUID, accountID uuid.UUID) error {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("account not found: %w", err)
+	}
+
+	if account.ProjectID != projectID {
+		return fmt.Errorf("account does not belong to the specified project")
+	}
+
+	if account.IsArchived() {
+		return fmt.Errorf("account '%s' is archived", account.Name)
+	}
+
+	return nil
+}
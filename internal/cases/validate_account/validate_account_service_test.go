@@ -1,7 +1,9 @@
 package validate_account
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"gofin/internal/infrastructure/database"
@@ -26,7 +28,7 @@ func TestValidateAccountService_ValidateAccountExists(t *testing.T) {
 				project := models.NewProject("Test Project", "test-project")
 				account := models.NewAccount(project.ID, "Test Account", money.USD)
 				account.ID = accountID
-				accountRepo.Create(account)
+				accountRepo.Create(context.Background(), account)
 			},
 			wantErr: false,
 		},
@@ -46,7 +48,7 @@ func TestValidateAccountService_ValidateAccountExists(t *testing.T) {
 			service := NewValidateAccountService(accountRepo)
 			tt.repoSetup(accountRepo)
 
-			err := service.ValidateAccountExists(tt.accountID)
+			err := service.ValidateAccountExists(context.Background(), tt.accountID)
 
 			if tt.wantErr {
 				if err == nil {
@@ -88,7 +90,7 @@ func TestValidateAccountService_ValidateAccountForProject(t *testing.T) {
 				project.ID = projectID
 				account := models.NewAccount(project.ID, "Test Account", money.USD)
 				account.ID = accountID
-				accountRepo.Create(account)
+				accountRepo.Create(context.Background(), account)
 			},
 			wantErr: false,
 		},
@@ -110,7 +112,7 @@ func TestValidateAccountService_ValidateAccountForProject(t *testing.T) {
 				project.ID = otherProjectID
 				account := models.NewAccount(project.ID, "Test Account", money.USD)
 				account.ID = accountID
-				accountRepo.Create(account)
+				accountRepo.Create(context.Background(), account)
 			},
 			wantErr:  true,
 			errorMsg: "account does not belong to the specified project",
@@ -123,7 +125,7 @@ func TestValidateAccountService_ValidateAccountForProject(t *testing.T) {
 			service := NewValidateAccountService(accountRepo)
 			tt.repoSetup(accountRepo)
 
-			err := service.ValidateAccountForProject(tt.projectID, tt.accountID)
+			err := service.ValidateAccountForProject(context.Background(), tt.projectID, tt.accountID)
 
 			if tt.wantErr {
 				if err == nil {
@@ -158,3 +160,33 @@ func containsSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestValidateAccountService_ValidateAccountActive(t *testing.T) {
+	ctx := context.Background()
+	accountRepo := database.NewAccountInMemoryRepository()
+	service := NewValidateAccountService(accountRepo)
+
+	projectID := uuid.New()
+	active := models.NewAccount(projectID, "Main", money.PLN)
+	archived := models.NewAccount(projectID, "Old", money.PLN)
+	archivedAt := time.Now()
+	archived.ArchivedAt = &archivedAt
+	accountRepo.Create(ctx, active)
+	accountRepo.Create(ctx, archived)
+
+	if err := service.ValidateAccountActive(ctx, projectID, active.ID); err != nil {
+		t.Errorf("Expected no error for an active account, got %v", err)
+	}
+
+	if err := service.ValidateAccountActive(ctx, projectID, archived.ID); err == nil || err.Error() != "account 'Old' is archived" {
+		t.Errorf("Expected an archived account error, got %v", err)
+	}
+
+	if err := service.ValidateAccountActive(ctx, uuid.New(), active.ID); err == nil {
+		t.Error("Expected error for an account from another project")
+	}
+
+	if err := service.ValidateAccountForProject(ctx, projectID, archived.ID); err != nil {
+		t.Errorf("Expected archived accounts to still belong to the project, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package validate_category
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type ValidateCategoryService struct {
+	categoryRepo models.CategoryRepository
+}
+
+func NewValidateCategoryService(categoryRepo models.CategoryRepository) *ValidateCategoryService {
+	return &ValidateCategoryService{
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (s *ValidateCategoryService) ValidateCategoryForProject(ctx context.Context, projectID uuid.UUID, categoryID uuid.UUID) error {
+	category, err := s.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("category not found: %w", err)
+	}
+
+	if category.ProjectID != projectID {
+		return fmt.Errorf("category does not belong to the specified project")
+	}
+
+	return nil
+}
+
+// ValidateAssignment checks the category and split lines a transaction of the given
+// value is about to receive. A transaction is either split or has a single category.
+func (s *ValidateCategoryService) ValidateAssignment(ctx context.Context, projectID uuid.UUID, value float64, categoryID *uuid.UUID, splits []models.SplitData) error {
+	if categoryID != nil && len(splits) > 0 {
+		return fmt.Errorf("a split transaction cannot have a category of its own")
+	}
+
+	if categoryID != nil {
+		if err := s.ValidateCategoryForProject(ctx, projectID, *categoryID); err != nil {
+			return err
+		}
+	}
+
+	if err := models.ValidateSplits(value, splits); err != nil {
+		return err
+	}
+
+	for _, split := range splits {
+		if err := s.ValidateCategoryForProject(ctx, projectID, split.CategoryID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package validate_payee
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type ValidatePayeeService struct {
+	payeeRepo models.PayeeRepository
+}
+
+func NewValidatePayeeService(payeeRepo models.PayeeRepository) *ValidatePayeeService {
+	return &ValidatePayeeService{
+		payeeRepo: payeeRepo,
+	}
+}
+
+// GetPayeeForProject loads a payee and checks it belongs to projectID.
+func (s *ValidatePayeeService) GetPayeeForProject(ctx context.Context, projectID, payeeID uuid.UUID) (*models.Payee, error) {
+	payee, err := s.payeeRepo.GetByID(ctx, payeeID)
+	if err != nil {
+		return nil, fmt.Errorf("payee not found: %w", err)
+	}
+
+	if payee.ProjectID != projectID {
+		return nil, fmt.Errorf("payee does not belong to the specified project")
+	}
+
+	return payee, nil
+}
+
+// ValidateAliasAvailable checks that alias, already normalized, is not claimed by
+// another payee of the project. An alias the payee itself owns is fine.
+func (s *ValidatePayeeService) ValidateAliasAvailable(ctx context.Context, projectID, payeeID uuid.UUID, alias string) error {
+	aliases, err := s.payeeRepo.GetAliasesByProjectID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get payee aliases: %w", err)
+	}
+
+	for _, existing := range aliases {
+		if existing.Alias != alias || existing.PayeeID == payeeID {
+			continue
+		}
+
+		owner, err := s.payeeRepo.GetByID(ctx, existing.PayeeID)
+		if err != nil {
+			return fmt.Errorf("alias '%s' is already used by another payee", alias)
+		}
+		return fmt.Errorf("alias '%s' is already used by payee '%s'", alias, owner.Name)
+	}
+
+	return nil
+}
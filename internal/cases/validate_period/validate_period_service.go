@@ -0,0 +1,44 @@
+package validate_period
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type ValidatePeriodService struct {
+	projectRepo models.ProjectRepository
+	accountRepo models.AccountRepository
+}
+
+func NewValidatePeriodService(projectRepo models.ProjectRepository, accountRepo models.AccountRepository) *ValidatePeriodService {
+	return &ValidatePeriodService{
+		projectRepo: projectRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+// ValidatePeriodOpen checks a transaction dated date may be recorded in the
+// project, that is the date is after the project's closed periods.
+func (s *ValidatePeriodService) ValidatePeriodOpen(ctx context.Context, projectID uuid.UUID, date time.Time) error {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("project not found: %w", err)
+	}
+
+	return project.EnsurePeriodOpen(date)
+}
+
+// ValidateTransactionOpen checks an existing transaction may be changed or
+// deleted, finding its project through the account it is booked on.
+func (s *ValidatePeriodService) ValidateTransactionOpen(ctx context.Context, transaction *models.Transaction) error {
+	account, err := s.accountRepo.GetByID(ctx, transaction.AccountID)
+	if err != nil {
+		return fmt.Errorf("account not found: %w", err)
+	}
+
+	return s.ValidatePeriodOpen(ctx, account.ProjectID, transaction.TransactionDate)
+}
@@ -0,0 +1,132 @@
+package verify_two_factor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/logging"
+	"gofin/pkg/password"
+	"gofin/pkg/totp"
+)
+
+// MaxFailures is how many wrong codes in a row lock the second factor for
+// LockoutDuration after the last of them.
+const (
+	MaxFailures     = 5
+	LockoutDuration = 15 * time.Minute
+)
+
+var ErrTooManyAttempts = errors.New("too many failed attempts, try again later")
+
+type VerifyTwoFactorService struct {
+	accessRepo       models.AccessRepository
+	recoveryCodeRepo models.RecoveryCodeRepository
+}
+
+func NewVerifyTwoFactorService(accessRepo models.AccessRepository, recoveryCodeRepo models.RecoveryCodeRepository) *VerifyTwoFactorService {
+	return &VerifyTwoFactorService{
+		accessRepo:       accessRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+	}
+}
+
+// Verify accepts either a TOTP code newer than the last one used or one of the
+// unused recovery codes. A recovery code is consumed on success. After
+// MaxFailures wrong codes every attempt fails with ErrTooManyAttempts until
+// LockoutDuration has passed since the last one; only a correct code clears
+// the failures.
+func (s *VerifyTwoFactorService) Verify(ctx context.Context, accessID uuid.UUID, code string) (*models.Access, error) {
+	access, err := s.accessRepo.GetByID(ctx, accessID)
+	if err != nil {
+		return nil, fmt.Errorf("access not found: %w", err)
+	}
+
+	if !access.TOTPEnabled {
+		return nil, fmt.Errorf("two-factor authentication is not enabled")
+	}
+
+	now := time.Now()
+	stored, lastCounter := access.TwoFactorFailures, access.TOTPLastCounter
+	failures := stored
+	if failures >= MaxFailures {
+		if access.TwoFactorFailedAt != nil && now.Sub(*access.TwoFactorFailedAt) < LockoutDuration {
+			return nil, ErrTooManyAttempts
+		}
+		failures = 0
+	}
+
+	// The attempt counts as a failure until the code proves right, so parallel
+	// attempts cannot get past MaxFailures.
+	failures++
+	counted, err := s.accessRepo.SetTwoFactorFailures(ctx, access.ID, stored, failures, &now)
+	if err != nil {
+		return nil, err
+	}
+	if !counted {
+		return nil, fmt.Errorf("another code is being checked, try again")
+	}
+
+	if counter, ok := totp.Match(access.TOTPSecret, code, now); ok && counter > lastCounter {
+		used, err := s.accessRepo.UseTOTPCounter(ctx, access.ID, counter)
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			return s.accessRepo.GetByID(ctx, access.ID)
+		}
+	}
+
+	used, err := s.useRecoveryCode(ctx, access.ID, code)
+	if err != nil {
+		return nil, err
+	}
+	if used {
+		if _, err := s.accessRepo.SetTwoFactorFailures(ctx, access.ID, failures, 0, nil); err != nil {
+			return nil, err
+		}
+		return s.accessRepo.GetByID(ctx, access.ID)
+	}
+
+	if failures >= MaxFailures {
+		logging.FromContext(ctx).Warn("two-factor attempts exhausted", slog.String("access_id", access.ID.String()))
+		return nil, ErrTooManyAttempts
+	}
+
+	return nil, fmt.Errorf("invalid authentication code")
+}
+
+// useRecoveryCode reports whether code is one of the unused recovery codes and
+// consumes it. Codes without the shape of a recovery code are not hashed.
+func (s *VerifyTwoFactorService) useRecoveryCode(ctx context.Context, accessID uuid.UUID, code string) (bool, error) {
+	normalized := totp.NormalizeRecoveryCode(code)
+	if !totp.IsRecoveryCode(normalized) {
+		return false, nil
+	}
+
+	codes, err := s.recoveryCodeRepo.GetUnusedByAccessID(ctx, accessID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get recovery codes: %w", err)
+	}
+
+	for _, recoveryCode := range codes {
+		valid, err := password.Verify(normalized, recoveryCode.CodeHash)
+		if err != nil || !valid {
+			continue
+		}
+
+		if err := s.recoveryCodeRepo.MarkUsed(ctx, recoveryCode.ID); err != nil {
+			return false, fmt.Errorf("failed to use recovery code: %w", err)
+		}
+
+		logging.FromContext(ctx).Info("recovery code used", slog.String("access_id", accessID.String()), slog.Int("remaining", len(codes)-1))
+
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,163 @@
+package verify_two_factor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/password"
+	"gofin/pkg/totp"
+)
+
+func TestVerifyTwoFactorService_Verify(t *testing.T) {
+	accessRepo := database.NewAccessInMemoryRepository()
+	recoveryCodeRepo := database.NewRecoveryCodeInMemoryRepository()
+	service := NewVerifyTwoFactorService(accessRepo, recoveryCodeRepo)
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() unexpected error: %v", err)
+	}
+
+	access := models.NewAccess(models.NewProject("Test Project", "test-project").ID, "12", "hash", "Test Access", false)
+	access.TOTPSecret = secret
+	access.TOTPEnabled = true
+	accessRepo.Create(context.Background(), access)
+
+	disabledAccess := models.NewAccess(access.ProjectID, "13", "hash", "Disabled Access", false)
+	accessRepo.Create(context.Background(), disabledAccess)
+
+	recoveryHash, _ := password.Hash("abcde-fghjk")
+	recoveryCodeRepo.ReplaceForAccess(context.Background(), access.ID, []*models.RecoveryCode{models.NewRecoveryCode(access.ID, recoveryHash)})
+
+	validCode, _ := totp.GenerateCode(secret, time.Now())
+
+	tests := []struct {
+		name    string
+		access  *models.Access
+		code    string
+		wantErr bool
+	}{
+		{name: "success with valid totp code", access: access, code: validCode, wantErr: false},
+		{name: "error with invalid code", access: access, code: "abcdef", wantErr: true},
+		{name: "success with recovery code", access: access, code: "ABCDE-FGHJK", wantErr: false},
+		{name: "error when recovery code reused", access: access, code: "abcde-fghjk", wantErr: true},
+		{name: "error when two-factor disabled", access: disabledAccess, code: validCode, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Verify(context.Background(), tt.access.ID, tt.code)
+
+			if tt.wantErr && err == nil {
+				t.Errorf("Verify() expected error, got nil")
+			}
+
+			if !tt.wantErr && err != nil {
+				t.Errorf("Verify() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func newTwoFactorAccess(t *testing.T, accessRepo models.AccessRepository) *models.Access {
+	t.Helper()
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() unexpected error: %v", err)
+	}
+
+	access := models.NewAccess(models.NewProject("Test Project", "test-project").ID, "12", "hash", "Test Access", false)
+	access.TOTPSecret = secret
+	access.TOTPEnabled = true
+	accessRepo.Create(context.Background(), access)
+
+	return access
+}
+
+func TestVerifyTwoFactorService_Verify_RejectsReplay(t *testing.T) {
+	ctx := context.Background()
+	accessRepo := database.NewAccessInMemoryRepository()
+	service := NewVerifyTwoFactorService(accessRepo, database.NewRecoveryCodeInMemoryRepository())
+	access := newTwoFactorAccess(t, accessRepo)
+
+	code, _ := totp.GenerateCode(access.TOTPSecret, time.Now())
+	if _, err := service.Verify(ctx, access.ID, code); err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+
+	if _, err := service.Verify(ctx, access.ID, code); err == nil {
+		t.Error("Verify() expected error for a code that was already used")
+	}
+
+	previous, _ := totp.GenerateCode(access.TOTPSecret, time.Now().Add(-totp.Period*time.Second))
+	if previous != code {
+		if _, err := service.Verify(ctx, access.ID, previous); err == nil {
+			t.Error("Verify() expected error for a code older than the last one used")
+		}
+	}
+}
+
+func TestVerifyTwoFactorService_Verify_TooManyAttempts(t *testing.T) {
+	ctx := context.Background()
+	accessRepo := database.NewAccessInMemoryRepository()
+	service := NewVerifyTwoFactorService(accessRepo, database.NewRecoveryCodeInMemoryRepository())
+	access := newTwoFactorAccess(t, accessRepo)
+
+	for i := 1; i < MaxFailures; i++ {
+		if _, err := service.Verify(ctx, access.ID, "000000"); err == nil || errors.Is(err, ErrTooManyAttempts) {
+			t.Fatalf("Verify() attempt %d: expected invalid code error, got %v", i, err)
+		}
+	}
+
+	if _, err := service.Verify(ctx, access.ID, "000000"); !errors.Is(err, ErrTooManyAttempts) {
+		t.Fatalf("Verify() expected ErrTooManyAttempts on attempt %d, got %v", MaxFailures, err)
+	}
+
+	code, _ := totp.GenerateCode(access.TOTPSecret, time.Now())
+	if _, err := service.Verify(ctx, access.ID, code); !errors.Is(err, ErrTooManyAttempts) {
+		t.Errorf("Verify() expected a valid code to be refused once attempts are exhausted, got %v", err)
+	}
+
+	// A new PIN login does not help: only the end of the lockout does.
+	lastFailure := time.Now().Add(-LockoutDuration - time.Second)
+	if _, err := accessRepo.SetTwoFactorFailures(ctx, access.ID, MaxFailures, MaxFailures, &lastFailure); err != nil {
+		t.Fatalf("SetTwoFactorFailures() unexpected error: %v", err)
+	}
+
+	if _, err := service.Verify(ctx, access.ID, code); err != nil {
+		t.Errorf("Verify() unexpected error once the lockout is over: %v", err)
+	}
+	if got, _ := accessRepo.GetByID(ctx, access.ID); got.TwoFactorFailures != 0 || got.TwoFactorFailedAt != nil {
+		t.Errorf("Expected failures to reset after a successful code, got %d", got.TwoFactorFailures)
+	}
+}
+
+func TestVerifyTwoFactorService_Verify_FailuresOutliveLogins(t *testing.T) {
+	ctx := context.Background()
+	accessRepo := database.NewAccessInMemoryRepository()
+	recoveryCodeRepo := database.NewRecoveryCodeInMemoryRepository()
+	service := NewVerifyTwoFactorService(accessRepo, recoveryCodeRepo)
+	access := newTwoFactorAccess(t, accessRepo)
+
+	recoveryHash, _ := password.Hash("abcde-fghjk")
+	recoveryCodeRepo.ReplaceForAccess(ctx, access.ID, []*models.RecoveryCode{models.NewRecoveryCode(access.ID, recoveryHash)})
+
+	for i := 0; i < 2; i++ {
+		service.Verify(ctx, access.ID, "000000")
+	}
+	if got, _ := accessRepo.GetByID(ctx, access.ID); got.TwoFactorFailures != 2 || got.TwoFactorFailedAt == nil {
+		t.Fatalf("Expected 2 failures with their time, got %d", got.TwoFactorFailures)
+	}
+
+	if _, err := service.Verify(ctx, access.ID, "abcde-fghjk"); err != nil {
+		t.Fatalf("Verify() unexpected error with a recovery code: %v", err)
+	}
+	if got, _ := accessRepo.GetByID(ctx, access.ID); got.TwoFactorFailures != 0 {
+		t.Errorf("Expected a recovery code to reset the failures, got %d", got.TwoFactorFailures)
+	}
+}
@@ -2,69 +2,339 @@ package container
 
 import (
 	"fmt"
-	"path/filepath"
 
+	"gofin/internal/cases/assign_transaction_payee"
+	"gofin/internal/cases/backup_project"
+	"gofin/internal/cases/close_period"
 	"gofin/internal/cases/create_access"
 	"gofin/internal/cases/create_account"
+	"gofin/internal/cases/create_category"
+	"gofin/internal/cases/create_loan"
+	"gofin/internal/cases/create_payee"
 	"gofin/internal/cases/create_project"
 	"gofin/internal/cases/create_transaction"
+	"gofin/internal/cases/credit_card_statements"
 	"gofin/internal/cases/delete_transaction"
+	"gofin/internal/cases/enroll_two_factor"
+	"gofin/internal/cases/export_data"
+	"gofin/internal/cases/get_category_summary"
+	"gofin/internal/cases/get_loan_schedule"
+	"gofin/internal/cases/get_payee_history"
+	"gofin/internal/cases/get_portfolio"
 	"gofin/internal/cases/get_project_balance"
 	"gofin/internal/cases/get_project_transactions"
+	"gofin/internal/cases/get_reports"
+	"gofin/internal/cases/import_statement"
+	"gofin/internal/cases/match_payee"
+	"gofin/internal/cases/merge_payees"
+	"gofin/internal/cases/reconcile_account"
+	"gofin/internal/cases/record_investment_operation"
+	"gofin/internal/cases/record_loan_payment"
+	"gofin/internal/cases/record_settlement"
+	"gofin/internal/cases/record_transfer"
+	"gofin/internal/cases/restore_project"
+	"gofin/internal/cases/search_transactions"
+	"gofin/internal/cases/security_prices"
+	"gofin/internal/cases/set_two_factor_policy"
+	"gofin/internal/cases/share_expense"
+	"gofin/internal/cases/shared_balances"
+	"gofin/internal/cases/transaction_attachments"
+	"gofin/internal/cases/update_account"
+	"gofin/internal/cases/update_loan"
+	"gofin/internal/cases/update_payee"
+	"gofin/internal/cases/update_transaction_categories"
+	"gofin/internal/cases/update_transaction_notes"
+	"gofin/internal/cases/update_transaction_status"
+	"gofin/internal/cases/verify_two_factor"
 	"gofin/internal/infrastructure/database"
+	"gofin/internal/infrastructure/storage"
 	"gofin/internal/models"
-	"gofin/web"
+	"gofin/pkg/config"
+	"gofin/pkg/metrics"
 )
 
 type Container struct {
-	ProjectRepository             models.ProjectRepository
-	AccessRepository              models.AccessRepository
-	AccountRepository             models.AccountRepository
-	TransactionRepository         models.TransactionRepository
-	CreateProjectService          *create_project.CreateProjectService
-	CreateAccessService           *create_access.CreateAccessService
-	CreateAccountService          *create_account.CreateAccountService
-	CreateTransactionService      *create_transaction.CreateTransactionService
-	DeleteTransactionService      *delete_transaction.DeleteTransactionService
-	GetProjectBalanceService      *get_project_balance.GetProjectBalanceService
-	GetProjectTransactionsService *get_project_transactions.GetProjectTransactionsService
-	DB                            database.Database
+	ProjectRepository                  models.ProjectRepository
+	AccessRepository                   models.AccessRepository
+	AccountRepository                  models.AccountRepository
+	TransactionRepository              models.TransactionRepository
+	RecoveryCodeRepository             models.RecoveryCodeRepository
+	AttachmentRepository               models.AttachmentRepository
+	CategoryRepository                 models.CategoryRepository
+	TransactionSplitRepository         models.TransactionSplitRepository
+	SharedExpenseRepository            models.SharedExpenseRepository
+	SettlementRepository               models.SettlementRepository
+	AccountTransferRepository          models.AccountTransferRepository
+	PayeeRepository                    models.PayeeRepository
+	LoanRepository                     models.LoanRepository
+	SecurityRepository                 models.SecurityRepository
+	InvestmentOperationRepository      models.InvestmentOperationRepository
+	ReconciliationRepository           models.ReconciliationRepository
+	PeriodLockEventRepository          models.PeriodLockEventRepository
+	BlobStore                          models.BlobStore
+	CreateProjectService               *create_project.CreateProjectService
+	CreateAccessService                *create_access.CreateAccessService
+	CreateAccountService               *create_account.CreateAccountService
+	UpdateAccountService               *update_account.UpdateAccountService
+	CreditCardStatementsService        *credit_card_statements.CreditCardStatementsService
+	CreateLoanService                  *create_loan.CreateLoanService
+	UpdateLoanService                  *update_loan.UpdateLoanService
+	GetLoanScheduleService             *get_loan_schedule.GetLoanScheduleService
+	RecordLoanPaymentService           *record_loan_payment.RecordLoanPaymentService
+	RecordInvestmentOperationService   *record_investment_operation.RecordInvestmentOperationService
+	SecurityPricesService              *security_prices.SecurityPricesService
+	GetPortfolioService                *get_portfolio.GetPortfolioService
+	ReconcileAccountService            *reconcile_account.ReconcileAccountService
+	ClosePeriodService                 *close_period.ClosePeriodService
+	GetReportsService                  *get_reports.GetReportsService
+	ExportDataService                  *export_data.ExportDataService
+	ImportStatementService             *import_statement.ImportStatementService
+	BackupProjectService               *backup_project.BackupProjectService
+	RestoreProjectService              *restore_project.RestoreProjectService
+	UpdateTransactionStatusService     *update_transaction_status.UpdateTransactionStatusService
+	CreateTransactionService           *create_transaction.CreateTransactionService
+	DeleteTransactionService           *delete_transaction.DeleteTransactionService
+	GetProjectBalanceService           *get_project_balance.GetProjectBalanceService
+	GetProjectTransactionsService      *get_project_transactions.GetProjectTransactionsService
+	SearchTransactionsService          *search_transactions.SearchTransactionsService
+	EnrollTwoFactorService             *enroll_two_factor.EnrollTwoFactorService
+	VerifyTwoFactorService             *verify_two_factor.VerifyTwoFactorService
+	SetTwoFactorPolicyService          *set_two_factor_policy.SetTwoFactorPolicyService
+	UpdateTransactionNotesService      *update_transaction_notes.UpdateTransactionNotesService
+	AttachmentsService                 *transaction_attachments.TransactionAttachmentsService
+	CreateCategoryService              *create_category.CreateCategoryService
+	UpdateTransactionCategoriesService *update_transaction_categories.UpdateTransactionCategoriesService
+	GetCategorySummaryService          *get_category_summary.GetCategorySummaryService
+	ShareExpenseService                *share_expense.ShareExpenseService
+	SharedBalancesService              *shared_balances.SharedBalancesService
+	RecordSettlementService            *record_settlement.RecordSettlementService
+	RecordTransferService              *record_transfer.RecordTransferService
+	CreatePayeeService                 *create_payee.CreatePayeeService
+	UpdatePayeeService                 *update_payee.UpdatePayeeService
+	MergePayeesService                 *merge_payees.MergePayeesService
+	MatchPayeeService                  *match_payee.MatchPayeeService
+	AssignTransactionPayeeService      *assign_transaction_payee.AssignTransactionPayeeService
+	GetPayeeHistoryService             *get_payee_history.GetPayeeHistoryService
+	Metrics                            metrics.Recorder
+	DB                                 database.Database
+	Config                             *config.Config
+}
+
+type repositories struct {
+	project      models.ProjectRepository
+	access       models.AccessRepository
+	account      models.AccountRepository
+	transaction  models.TransactionRepository
+	recoveryCode models.RecoveryCodeRepository
+	attachment   models.AttachmentRepository
+	category     models.CategoryRepository
+	split        models.TransactionSplitRepository
+	shared       models.SharedExpenseRepository
+	settlement   models.SettlementRepository
+	transfer     models.AccountTransferRepository
+	payee        models.PayeeRepository
+	loan         models.LoanRepository
+	security     models.SecurityRepository
+	investment   models.InvestmentOperationRepository
+	reconcile    models.ReconciliationRepository
+	periodLock   models.PeriodLockEventRepository
+	blobs        models.BlobStore
 }
 
 func NewContainer(dbPath string) (*Container, error) {
-	db, err := database.NewDB(dbPath)
+	return NewContainerFromConfig(defaultConfigWithDatabase(dbPath))
+}
+
+// NewContainerFromConfig opens the SQLite database at the configured path or,
+// with the postgres driver, the PostgreSQL database at the DSN, which any number
+// of instances can share.
+func NewContainerFromConfig(cfg *config.Config) (*Container, error) {
+	if cfg.Database.Driver == config.DatabaseDriverPostgres {
+		return newPostgresContainer(cfg)
+	}
+
+	db, err := database.NewDB(cfg.Database.Path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
-	projectRepo := database.NewProjectSqliteRepository(db.GetConnection())
-	accessRepo := database.NewAccessSqliteRepository(db.GetConnection())
-	accountRepo := database.NewAccountSqliteRepository(db.GetConnection())
-	transactionRepo := database.NewTransactionSqliteRepository(db.GetConnection())
-	createProjectService := create_project.NewCreateProjectService(projectRepo)
-	createAccessService := create_access.NewCreateAccessService(accessRepo, projectRepo)
-	createAccountService := create_account.NewCreateAccountService(accountRepo)
-	createTransactionService := create_transaction.NewCreateTransactionService(transactionRepo, accountRepo, projectRepo)
-	deleteTransactionService := delete_transaction.NewDeleteTransactionService(transactionRepo)
-	getProjectBalanceService := get_project_balance.NewGetProjectBalanceService(accountRepo)
-	getProjectTransactionsService := get_project_transactions.NewGetProjectTransactionsService(transactionRepo)
+	var recorder metrics.Recorder = metrics.NewNoop()
+	if cfg.Metrics.Enabled {
+		recorder = metrics.NewPrometheus(db.GetConnection())
+	}
+
+	repos := repositories{
+		project:      database.NewProjectSqliteRepository(db.GetConnection(), recorder),
+		access:       database.NewAccessSqliteRepository(db.GetConnection(), recorder),
+		account:      database.NewAccountSqliteRepository(db.GetConnection(), recorder),
+		transaction:  database.NewTransactionSqliteRepository(db.GetConnection(), recorder),
+		recoveryCode: database.NewRecoveryCodeSqliteRepository(db.GetConnection(), recorder),
+		attachment:   database.NewAttachmentSqliteRepository(db.GetConnection(), recorder),
+		category:     database.NewCategorySqliteRepository(db.GetConnection(), recorder),
+		split:        database.NewTransactionSplitSqliteRepository(db.GetConnection(), recorder),
+		shared:       database.NewSharedExpenseSqliteRepository(db.GetConnection(), recorder),
+		settlement:   database.NewSettlementSqliteRepository(db.GetConnection(), recorder),
+		transfer:     database.NewAccountTransferSqliteRepository(db.GetConnection(), recorder),
+		payee:        database.NewPayeeSqliteRepository(db.GetConnection(), recorder),
+		loan:         database.NewLoanSqliteRepository(db.GetConnection(), recorder),
+		security:     database.NewSecuritySqliteRepository(db.GetConnection(), recorder),
+		investment:   database.NewInvestmentOperationSqliteRepository(db.GetConnection(), recorder),
+		reconcile:    database.NewReconciliationSqliteRepository(db.GetConnection(), recorder),
+		periodLock:   database.NewPeriodLockEventSqliteRepository(db.GetConnection(), recorder),
+		blobs:        storage.NewLocalBlobStore(cfg.Attachments.Dir),
+	}
+
+	return newContainer(repos, db, recorder, cfg), nil
+}
+
+func newPostgresContainer(cfg *config.Config) (*Container, error) {
+	db, err := database.NewPostgresDB(cfg.Database.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize postgres database: %w", err)
+	}
+
+	var recorder metrics.Recorder = metrics.NewNoop()
+	if cfg.Metrics.Enabled {
+		recorder = metrics.NewPrometheus(db.GetConnection())
+	}
+
+	repos := repositories{
+		project:      database.NewProjectPostgresRepository(db.GetConnection(), recorder),
+		access:       database.NewAccessPostgresRepository(db.GetConnection(), recorder),
+		account:      database.NewAccountPostgresRepository(db.GetConnection(), recorder),
+		transaction:  database.NewTransactionPostgresRepository(db.GetConnection(), recorder),
+		recoveryCode: database.NewRecoveryCodePostgresRepository(db.GetConnection(), recorder),
+		attachment:   database.NewAttachmentPostgresRepository(db.GetConnection(), recorder),
+		category:     database.NewCategoryPostgresRepository(db.GetConnection(), recorder),
+		split:        database.NewTransactionSplitPostgresRepository(db.GetConnection(), recorder),
+		shared:       database.NewSharedExpensePostgresRepository(db.GetConnection(), recorder),
+		settlement:   database.NewSettlementPostgresRepository(db.GetConnection(), recorder),
+		transfer:     database.NewAccountTransferPostgresRepository(db.GetConnection(), recorder),
+		payee:        database.NewPayeePostgresRepository(db.GetConnection(), recorder),
+		loan:         database.NewLoanPostgresRepository(db.GetConnection(), recorder),
+		security:     database.NewSecurityPostgresRepository(db.GetConnection(), recorder),
+		investment:   database.NewInvestmentOperationPostgresRepository(db.GetConnection(), recorder),
+		reconcile:    database.NewReconciliationPostgresRepository(db.GetConnection(), recorder),
+		periodLock:   database.NewPeriodLockEventPostgresRepository(db.GetConnection(), recorder),
+		blobs:        storage.NewLocalBlobStore(cfg.Attachments.Dir),
+	}
+
+	return newContainer(repos, db, recorder, cfg), nil
+}
+
+// NewInMemoryContainer wires every service to in-memory repositories and records no
+// metrics. It is meant for tests and needs no database file.
+func NewInMemoryContainer() *Container {
+	repos := repositories{
+		project:      database.NewProjectInMemoryRepository(),
+		access:       database.NewAccessInMemoryRepository(),
+		account:      database.NewAccountInMemoryRepository(),
+		transaction:  database.NewTransactionInMemoryRepository(),
+		recoveryCode: database.NewRecoveryCodeInMemoryRepository(),
+		attachment:   database.NewAttachmentInMemoryRepository(),
+		category:     database.NewCategoryInMemoryRepository(),
+		split:        database.NewTransactionSplitInMemoryRepository(),
+		shared:       database.NewSharedExpenseInMemoryRepository(),
+		settlement:   database.NewSettlementInMemoryRepository(),
+		transfer:     database.NewAccountTransferInMemoryRepository(),
+		payee:        database.NewPayeeInMemoryRepository(),
+		loan:         database.NewLoanInMemoryRepository(),
+		security:     database.NewSecurityInMemoryRepository(),
+		investment:   database.NewInvestmentOperationInMemoryRepository(),
+		reconcile:    database.NewReconciliationInMemoryRepository(),
+		periodLock:   database.NewPeriodLockEventInMemoryRepository(),
+		blobs:        storage.NewInMemoryBlobStore(),
+	}
+
+	return newContainer(repos, database.NewInMemoryDB(), metrics.NewNoop(), config.Default())
+}
+
+func newContainer(repos repositories, db database.Database, recorder metrics.Recorder, cfg *config.Config) *Container {
+	attachmentsSvc := transaction_attachments.NewTransactionAttachmentsService(
+		repos.attachment,
+		repos.transaction,
+		repos.account,
+		repos.blobs,
+		transaction_attachments.Limits{
+			MaxSize:      cfg.Attachments.MaxSize,
+			ContentTypes: cfg.Attachments.AllowedTypes,
+		},
+	)
 
 	return &Container{
-		ProjectRepository:             projectRepo,
-		AccessRepository:              accessRepo,
-		AccountRepository:             accountRepo,
-		TransactionRepository:         transactionRepo,
-		CreateProjectService:          createProjectService,
-		CreateAccessService:           createAccessService,
-		CreateAccountService:          createAccountService,
-		CreateTransactionService:      createTransactionService,
-		DeleteTransactionService:      deleteTransactionService,
-		GetProjectBalanceService:      getProjectBalanceService,
-		GetProjectTransactionsService: getProjectTransactionsService,
-		DB:                            db,
-	}, nil
+		ProjectRepository:                  repos.project,
+		AccessRepository:                   repos.access,
+		AccountRepository:                  repos.account,
+		TransactionRepository:              repos.transaction,
+		RecoveryCodeRepository:             repos.recoveryCode,
+		AttachmentRepository:               repos.attachment,
+		CategoryRepository:                 repos.category,
+		TransactionSplitRepository:         repos.split,
+		SharedExpenseRepository:            repos.shared,
+		SettlementRepository:               repos.settlement,
+		AccountTransferRepository:          repos.transfer,
+		PayeeRepository:                    repos.payee,
+		LoanRepository:                     repos.loan,
+		SecurityRepository:                 repos.security,
+		InvestmentOperationRepository:      repos.investment,
+		ReconciliationRepository:           repos.reconcile,
+		PeriodLockEventRepository:          repos.periodLock,
+		BlobStore:                          repos.blobs,
+		CreateProjectService:               create_project.NewCreateProjectService(repos.project),
+		CreateAccessService:                create_access.NewCreateAccessService(repos.access, repos.project),
+		CreateAccountService:               create_account.NewCreateAccountService(repos.account),
+		UpdateAccountService:               update_account.NewUpdateAccountService(repos.account),
+		CreditCardStatementsService:        credit_card_statements.NewCreditCardStatementsService(repos.account, repos.transaction),
+		CreateLoanService:                  create_loan.NewCreateLoanService(repos.loan, repos.account, repos.transaction, repos.project),
+		UpdateLoanService:                  update_loan.NewUpdateLoanService(repos.loan),
+		GetLoanScheduleService:             get_loan_schedule.NewGetLoanScheduleService(repos.loan, repos.account, repos.transaction),
+		RecordLoanPaymentService:           record_loan_payment.NewRecordLoanPaymentService(repos.loan, repos.account, repos.transaction, repos.transfer, repos.project),
+		RecordInvestmentOperationService:   record_investment_operation.NewRecordInvestmentOperationService(repos.investment, repos.security, repos.account, repos.transaction, repos.project),
+		SecurityPricesService:              security_prices.NewSecurityPricesService(repos.security),
+		GetPortfolioService:                get_portfolio.NewGetPortfolioService(repos.investment, repos.security, repos.account, repos.transaction),
+		ClosePeriodService:                 close_period.NewClosePeriodService(repos.project, repos.periodLock),
+		GetReportsService:                  get_reports.NewGetReportsService(repos.transaction, repos.account, repos.category, repos.split, repos.payee, repos.settlement, repos.transfer),
+		ExportDataService:                  export_data.NewExportDataService(repos.transaction, repos.account, repos.category, repos.split, repos.payee, repos.settlement, repos.transfer),
+		ImportStatementService:             import_statement.NewImportStatementService(repos.transaction, repos.account, repos.project, repos.category, repos.split, repos.payee),
+		BackupProjectService:               backup_project.NewBackupProjectService(repos.project, repos.access, repos.account, repos.transaction, repos.category, repos.split, repos.payee, repos.loan, repos.security, repos.investment, repos.shared, repos.settlement, repos.transfer, repos.reconcile, repos.periodLock, repos.attachment, repos.blobs),
+		RestoreProjectService:              restore_project.NewRestoreProjectService(repos.project, repos.access, repos.account, repos.transaction, repos.category, repos.split, repos.payee, repos.loan, repos.security, repos.investment, repos.shared, repos.settlement, repos.transfer, repos.reconcile, repos.periodLock, repos.attachment, repos.blobs),
+		ReconcileAccountService:            reconcile_account.NewReconcileAccountService(repos.reconcile, repos.account, repos.transaction, repos.project),
+		UpdateTransactionStatusService:     update_transaction_status.NewUpdateTransactionStatusService(repos.transaction, repos.account, repos.project),
+		CreateTransactionService:           create_transaction.NewCreateTransactionService(repos.transaction, repos.account, repos.project, repos.category, repos.split, repos.payee),
+		DeleteTransactionService:           delete_transaction.NewDeleteTransactionService(repos.transaction, repos.split, repos.shared, repos.settlement, repos.transfer, repos.investment, attachmentsSvc, repos.account, repos.project),
+		GetProjectBalanceService:           get_project_balance.NewGetProjectBalanceService(repos.account),
+		GetProjectTransactionsService:      get_project_transactions.NewGetProjectTransactionsService(repos.transaction),
+		SearchTransactionsService:          search_transactions.NewSearchTransactionsService(repos.transaction, repos.account),
+		EnrollTwoFactorService:             enroll_two_factor.NewEnrollTwoFactorService(repos.access, repos.project, repos.recoveryCode),
+		VerifyTwoFactorService:             verify_two_factor.NewVerifyTwoFactorService(repos.access, repos.recoveryCode),
+		SetTwoFactorPolicyService:          set_two_factor_policy.NewSetTwoFactorPolicyService(repos.project),
+		UpdateTransactionNotesService:      update_transaction_notes.NewUpdateTransactionNotesService(repos.transaction, repos.account, repos.project),
+		AttachmentsService:                 attachmentsSvc,
+		CreateCategoryService:              create_category.NewCreateCategoryService(repos.category),
+		UpdateTransactionCategoriesService: update_transaction_categories.NewUpdateTransactionCategoriesService(repos.transaction, repos.split, repos.account, repos.category, repos.project),
+		GetCategorySummaryService:          get_category_summary.NewGetCategorySummaryService(repos.category, repos.account, repos.split),
+		ShareExpenseService:                share_expense.NewShareExpenseService(repos.shared, repos.transaction, repos.account, repos.access, repos.project),
+		SharedBalancesService:              shared_balances.NewSharedBalancesService(repos.shared, repos.settlement, repos.access),
+		RecordSettlementService:            record_settlement.NewRecordSettlementService(repos.settlement, repos.transaction, repos.account, repos.access, repos.project),
+		RecordTransferService:              record_transfer.NewRecordTransferService(repos.transfer, repos.transaction, repos.account, repos.project),
+		CreatePayeeService:                 create_payee.NewCreatePayeeService(repos.payee, repos.category, repos.transaction, repos.project),
+		UpdatePayeeService:                 update_payee.NewUpdatePayeeService(repos.payee, repos.category, repos.transaction, repos.project),
+		MergePayeesService:                 merge_payees.NewMergePayeesService(repos.payee, repos.transaction, repos.project),
+		MatchPayeeService:                  match_payee.NewMatchPayeeService(repos.payee, repos.transaction, repos.project),
+		AssignTransactionPayeeService:      assign_transaction_payee.NewAssignTransactionPayeeService(repos.transaction, repos.account, repos.payee, repos.project),
+		GetPayeeHistoryService:             get_payee_history.NewGetPayeeHistoryService(repos.payee, repos.transaction, repos.account),
+		Metrics:                            recorder,
+		DB:                                 db,
+		Config:                             cfg,
+	}
 }
 
 func NewContainerWithDefaultConfig() (*Container, error) {
-	return NewContainer(filepath.Join(".", web.DatabaseFile))
+	return NewContainerFromConfig(config.Default())
+}
+
+func defaultConfigWithDatabase(dbPath string) *config.Config {
+	cfg := config.Default()
+	cfg.Database.Path = dbPath
+	return cfg
 }
@@ -1,8 +1,11 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"gofin/internal/models"
@@ -19,7 +22,11 @@ func NewAccessInMemoryRepository() *AccessInMemoryRepository {
 	}
 }
 
-func (r *AccessInMemoryRepository) Create(access *models.Access) error {
+func (r *AccessInMemoryRepository) Create(ctx context.Context, access *models.Access) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -32,7 +39,11 @@ func (r *AccessInMemoryRepository) Create(access *models.Access) error {
 	return nil
 }
 
-func (r *AccessInMemoryRepository) GetByProjectID(projectID uuid.UUID) ([]*models.Access, error) {
+func (r *AccessInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Access, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -43,10 +54,18 @@ func (r *AccessInMemoryRepository) GetByProjectID(projectID uuid.UUID) ([]*model
 		}
 	}
 
+	slices.SortFunc(accesses, func(a, b *models.Access) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
 	return accesses, nil
 }
 
-func (r *AccessInMemoryRepository) GetByUID(projectID uuid.UUID, uid string) (*models.Access, error) {
+func (r *AccessInMemoryRepository) GetByUID(ctx context.Context, projectID uuid.UUID, uid string) (*models.Access, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -59,7 +78,11 @@ func (r *AccessInMemoryRepository) GetByUID(projectID uuid.UUID, uid string) (*m
 	return access, nil
 }
 
-func (r *AccessInMemoryRepository) ExistsByUID(projectID uuid.UUID, uid string) (bool, error) {
+func (r *AccessInMemoryRepository) ExistsByUID(ctx context.Context, projectID uuid.UUID, uid string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -68,7 +91,11 @@ func (r *AccessInMemoryRepository) ExistsByUID(projectID uuid.UUID, uid string)
 	return exists, nil
 }
 
-func (r *AccessInMemoryRepository) GetByID(id uuid.UUID) (*models.Access, error) {
+func (r *AccessInMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Access, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -81,6 +108,64 @@ func (r *AccessInMemoryRepository) GetByID(id uuid.UUID) (*models.Access, error)
 	return nil, fmt.Errorf("access with ID '%s' not found", id.String())
 }
 
+func (r *AccessInMemoryRepository) Update(ctx context.Context, access *models.Access) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, existing := range r.accesses {
+		if existing.ID == access.ID {
+			delete(r.accesses, key)
+			r.accesses[r.getKey(access.ProjectID, access.UID)] = access
+			return nil
+		}
+	}
+
+	return fmt.Errorf("access with ID '%s' not found", access.ID.String())
+}
+
+func (r *AccessInMemoryRepository) SetTwoFactorFailures(ctx context.Context, id uuid.UUID, expected, failures int, failedAt *time.Time) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, access := range r.accesses {
+		if access.ID == id && access.TwoFactorFailures == expected {
+			access.TwoFactorFailures = failures
+			access.TwoFactorFailedAt = failedAt
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *AccessInMemoryRepository) UseTOTPCounter(ctx context.Context, id uuid.UUID, counter int64) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, access := range r.accesses {
+		if access.ID == id && access.TOTPLastCounter < counter {
+			access.TOTPLastCounter = counter
+			access.TwoFactorFailures = 0
+			access.TwoFactorFailedAt = nil
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (r *AccessInMemoryRepository) getKey(projectID uuid.UUID, uid string) string {
 	return fmt.Sprintf("%s:%s", projectID.String(), uid)
 }
@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type AccessPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewAccessPostgresRepository(db *sql.DB, observer QueryObserver) *AccessPostgresRepository {
+	return &AccessPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *AccessPostgresRepository) Create(ctx context.Context, access *models.Access) error {
+	query := `
+		INSERT INTO access (id, project_id, uid, pin_hash, name, readonly, totp_secret, totp_enabled, totp_last_counter, two_factor_failures, two_factor_failed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		access.ID.String(),
+		access.ProjectID.String(),
+		access.UID,
+		access.PinHash,
+		access.Name,
+		access.ReadOnly,
+		access.TOTPSecret,
+		access.TOTPEnabled,
+		access.TOTPLastCounter,
+		access.TwoFactorFailures,
+		access.TwoFactorFailedAt,
+		access.CreatedAt,
+		access.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create access: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AccessPostgresRepository) Update(ctx context.Context, access *models.Access) error {
+	query := `
+		UPDATE access
+		SET pin_hash = $1, name = $2, readonly = $3, totp_secret = $4, totp_enabled = $5, totp_last_counter = $6, two_factor_failures = $7, two_factor_failed_at = $8, updated_at = $9
+		WHERE id = $10
+	`
+
+	result, err := r.db.ExecContext(ctx,
+		query,
+		access.PinHash,
+		access.Name,
+		access.ReadOnly,
+		access.TOTPSecret,
+		access.TOTPEnabled,
+		access.TOTPLastCounter,
+		access.TwoFactorFailures,
+		access.TwoFactorFailedAt,
+		access.UpdatedAt,
+		access.ID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update access: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("access not found")
+	}
+
+	return nil
+}
+
+func (r *AccessPostgresRepository) SetTwoFactorFailures(ctx context.Context, id uuid.UUID, expected, failures int, failedAt *time.Time) (bool, error) {
+	query := `
+		UPDATE access
+		SET two_factor_failures = $1, two_factor_failed_at = $2
+		WHERE id = $3 AND two_factor_failures = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, failures, failedAt, id.String(), expected)
+	if err != nil {
+		return false, fmt.Errorf("failed to update two-factor failures: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *AccessPostgresRepository) UseTOTPCounter(ctx context.Context, id uuid.UUID, counter int64) (bool, error) {
+	query := `
+		UPDATE access
+		SET totp_last_counter = $1, two_factor_failures = 0, two_factor_failed_at = NULL
+		WHERE id = $2 AND totp_last_counter < $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, counter, id.String(), counter)
+	if err != nil {
+		return false, fmt.Errorf("failed to use TOTP counter: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *AccessPostgresRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Access, error) {
+	query := `
+		SELECT id, project_id, uid, pin_hash, name, readonly, totp_secret, totp_enabled, totp_last_counter, two_factor_failures, two_factor_failed_at, created_at, updated_at
+		FROM access
+		WHERE project_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query access by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var accesses []*models.Access
+	for rows.Next() {
+		access, err := scanAccess(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan access: %w", err)
+		}
+		accesses = append(accesses, access)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating access rows: %w", err)
+	}
+
+	return accesses, nil
+}
+
+func (r *AccessPostgresRepository) GetByUID(ctx context.Context, projectID uuid.UUID, uid string) (*models.Access, error) {
+	query := `
+		SELECT id, project_id, uid, pin_hash, name, readonly, totp_secret, totp_enabled, totp_last_counter, two_factor_failures, two_factor_failed_at, created_at, updated_at
+		FROM access
+		WHERE project_id = $1 AND uid = $2
+	`
+
+	row := r.db.QueryRowContext(ctx, query, projectID.String(), uid)
+	return scanAccess(row)
+}
+
+func (r *AccessPostgresRepository) ExistsByUID(ctx context.Context, projectID uuid.UUID, uid string) (bool, error) {
+	query := `
+		SELECT COUNT(1)
+		FROM access
+		WHERE project_id = $1 AND uid = $2
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, projectID.String(), uid).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check access existence: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *AccessPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Access, error) {
+	query := `
+		SELECT id, project_id, uid, pin_hash, name, readonly, totp_secret, totp_enabled, totp_last_counter, two_factor_failures, two_factor_failed_at, created_at, updated_at
+		FROM access
+		WHERE id = $1
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanAccess(row)
+}
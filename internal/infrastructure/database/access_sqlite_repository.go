@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -10,20 +11,20 @@ import (
 )
 
 type AccessSqliteRepository struct {
-	db *sql.DB
+	db instrumentedDB
 }
 
-func NewAccessSqliteRepository(db *sql.DB) *AccessSqliteRepository {
-	return &AccessSqliteRepository{db: db}
+func NewAccessSqliteRepository(db *sql.DB, observer QueryObserver) *AccessSqliteRepository {
+	return &AccessSqliteRepository{db: newInstrumentedDB(db, observer)}
 }
 
-func (r *AccessSqliteRepository) Create(access *models.Access) error {
+func (r *AccessSqliteRepository) Create(ctx context.Context, access *models.Access) error {
 	query := `
-		INSERT INTO access (id, project_id, uid, pin_hash, name, readonly, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO access (id, project_id, uid, pin_hash, name, readonly, totp_secret, totp_enabled, totp_last_counter, two_factor_failures, two_factor_failed_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.Exec(
+	_, err := r.db.ExecContext(ctx,
 		query,
 		access.ID.String(),
 		access.ProjectID.String(),
@@ -31,6 +32,11 @@ func (r *AccessSqliteRepository) Create(access *models.Access) error {
 		access.PinHash,
 		access.Name,
 		access.ReadOnly,
+		access.TOTPSecret,
+		access.TOTPEnabled,
+		access.TOTPLastCounter,
+		access.TwoFactorFailures,
+		access.TwoFactorFailedAt,
 		access.CreatedAt,
 		access.UpdatedAt,
 	)
@@ -42,15 +48,91 @@ func (r *AccessSqliteRepository) Create(access *models.Access) error {
 	return nil
 }
 
-func (r *AccessSqliteRepository) GetByProjectID(projectID uuid.UUID) ([]*models.Access, error) {
+func (r *AccessSqliteRepository) Update(ctx context.Context, access *models.Access) error {
 	query := `
-		SELECT id, project_id, uid, pin_hash, name, readonly, created_at, updated_at
+		UPDATE access
+		SET pin_hash = ?, name = ?, readonly = ?, totp_secret = ?, totp_enabled = ?, totp_last_counter = ?, two_factor_failures = ?, two_factor_failed_at = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx,
+		query,
+		access.PinHash,
+		access.Name,
+		access.ReadOnly,
+		access.TOTPSecret,
+		access.TOTPEnabled,
+		access.TOTPLastCounter,
+		access.TwoFactorFailures,
+		access.TwoFactorFailedAt,
+		access.UpdatedAt,
+		access.ID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update access: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("access not found")
+	}
+
+	return nil
+}
+
+func (r *AccessSqliteRepository) SetTwoFactorFailures(ctx context.Context, id uuid.UUID, expected, failures int, failedAt *time.Time) (bool, error) {
+	query := `
+		UPDATE access
+		SET two_factor_failures = ?, two_factor_failed_at = ?
+		WHERE id = ? AND two_factor_failures = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, failures, failedAt, id.String(), expected)
+	if err != nil {
+		return false, fmt.Errorf("failed to update two-factor failures: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *AccessSqliteRepository) UseTOTPCounter(ctx context.Context, id uuid.UUID, counter int64) (bool, error) {
+	query := `
+		UPDATE access
+		SET totp_last_counter = ?, two_factor_failures = 0, two_factor_failed_at = NULL
+		WHERE id = ? AND totp_last_counter < ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, counter, id.String(), counter)
+	if err != nil {
+		return false, fmt.Errorf("failed to use TOTP counter: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *AccessSqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Access, error) {
+	query := `
+		SELECT id, project_id, uid, pin_hash, name, readonly, totp_secret, totp_enabled, totp_last_counter, two_factor_failures, two_factor_failed_at, created_at, updated_at
 		FROM access
 		WHERE project_id = ?
 		ORDER BY created_at ASC
 	`
 
-	rows, err := r.db.Query(query, projectID.String())
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query access by project_id: %w", err)
 	}
@@ -58,7 +140,7 @@ func (r *AccessSqliteRepository) GetByProjectID(projectID uuid.UUID) ([]*models.
 
 	var accesses []*models.Access
 	for rows.Next() {
-		access, err := r.scanAccess(rows)
+		access, err := scanAccess(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan access: %w", err)
 		}
@@ -72,18 +154,18 @@ func (r *AccessSqliteRepository) GetByProjectID(projectID uuid.UUID) ([]*models.
 	return accesses, nil
 }
 
-func (r *AccessSqliteRepository) GetByUID(projectID uuid.UUID, uid string) (*models.Access, error) {
+func (r *AccessSqliteRepository) GetByUID(ctx context.Context, projectID uuid.UUID, uid string) (*models.Access, error) {
 	query := `
-		SELECT id, project_id, uid, pin_hash, name, readonly, created_at, updated_at
+		SELECT id, project_id, uid, pin_hash, name, readonly, totp_secret, totp_enabled, totp_last_counter, two_factor_failures, two_factor_failed_at, created_at, updated_at
 		FROM access
 		WHERE project_id = ? AND uid = ?
 	`
 
-	row := r.db.QueryRow(query, projectID.String(), uid)
-	return r.scanAccess(row)
+	row := r.db.QueryRowContext(ctx, query, projectID.String(), uid)
+	return scanAccess(row)
 }
 
-func (r *AccessSqliteRepository) ExistsByUID(projectID uuid.UUID, uid string) (bool, error) {
+func (r *AccessSqliteRepository) ExistsByUID(ctx context.Context, projectID uuid.UUID, uid string) (bool, error) {
 	query := `
 		SELECT COUNT(1)
 		FROM access
@@ -91,7 +173,7 @@ func (r *AccessSqliteRepository) ExistsByUID(projectID uuid.UUID, uid string) (b
 	`
 
 	var count int
-	err := r.db.QueryRow(query, projectID.String(), uid).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, projectID.String(), uid).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check access existence: %w", err)
 	}
@@ -99,25 +181,28 @@ func (r *AccessSqliteRepository) ExistsByUID(projectID uuid.UUID, uid string) (b
 	return count > 0, nil
 }
 
-func (r *AccessSqliteRepository) GetByID(id uuid.UUID) (*models.Access, error) {
+func (r *AccessSqliteRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Access, error) {
 	query := `
-		SELECT id, project_id, uid, pin_hash, name, readonly, created_at, updated_at
+		SELECT id, project_id, uid, pin_hash, name, readonly, totp_secret, totp_enabled, totp_last_counter, two_factor_failures, two_factor_failed_at, created_at, updated_at
 		FROM access
 		WHERE id = ?
 	`
 
-	row := r.db.QueryRow(query, id.String())
-	return r.scanAccess(row)
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanAccess(row)
 }
 
-func (r *AccessSqliteRepository) scanAccess(scanner interface {
+func scanAccess(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*models.Access, error) {
-	var id, projectID, uid, pinHash, name string
-	var readonly bool
+	var id, projectID, uid, pinHash, name, totpSecret string
+	var readonly, totpEnabled bool
+	var totpLastCounter int64
+	var twoFactorFailures int
+	var twoFactorFailedAt sql.NullTime
 	var createdAt, updatedAt time.Time
 
-	err := scanner.Scan(&id, &projectID, &uid, &pinHash, &name, &readonly, &createdAt, &updatedAt)
+	err := scanner.Scan(&id, &projectID, &uid, &pinHash, &name, &readonly, &totpSecret, &totpEnabled, &totpLastCounter, &twoFactorFailures, &twoFactorFailedAt, &createdAt, &updatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("access not found")
@@ -135,14 +220,23 @@ func (r *AccessSqliteRepository) scanAccess(scanner interface {
 		return nil, fmt.Errorf("invalid project ID: %w", err)
 	}
 
-	return &models.Access{
-		ID:        accessID,
-		ProjectID: projID,
-		UID:       uid,
-		PinHash:   pinHash,
-		Name:      name,
-		ReadOnly:  readonly,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
-	}, nil
+	access := &models.Access{
+		ID:                accessID,
+		ProjectID:         projID,
+		UID:               uid,
+		PinHash:           pinHash,
+		Name:              name,
+		ReadOnly:          readonly,
+		TOTPSecret:        totpSecret,
+		TOTPEnabled:       totpEnabled,
+		TOTPLastCounter:   totpLastCounter,
+		TwoFactorFailures: twoFactorFailures,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}
+	if twoFactorFailedAt.Valid {
+		access.TwoFactorFailedAt = &twoFactorFailedAt.Time
+	}
+
+	return access, nil
 }
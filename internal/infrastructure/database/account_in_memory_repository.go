@@ -1,8 +1,11 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"gofin/internal/models"
@@ -19,7 +22,11 @@ func NewAccountInMemoryRepository() *AccountInMemoryRepository {
 	}
 }
 
-func (r *AccountInMemoryRepository) Create(account *models.Account) error {
+func (r *AccountInMemoryRepository) Create(ctx context.Context, account *models.Account) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -32,7 +39,11 @@ func (r *AccountInMemoryRepository) Create(account *models.Account) error {
 	return nil
 }
 
-func (r *AccountInMemoryRepository) GetByProjectID(projectID uuid.UUID) ([]*models.Account, error) {
+func (r *AccountInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -43,10 +54,21 @@ func (r *AccountInMemoryRepository) GetByProjectID(projectID uuid.UUID) ([]*mode
 		}
 	}
 
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].Position != accounts[j].Position {
+			return accounts[i].Position < accounts[j].Position
+		}
+		return accounts[i].CreatedAt.Before(accounts[j].CreatedAt)
+	})
+
 	return accounts, nil
 }
 
-func (r *AccountInMemoryRepository) GetByID(id uuid.UUID) (*models.Account, error) {
+func (r *AccountInMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -59,7 +81,11 @@ func (r *AccountInMemoryRepository) GetByID(id uuid.UUID) (*models.Account, erro
 	return nil, fmt.Errorf("account with ID '%s' not found", id.String())
 }
 
-func (r *AccountInMemoryRepository) ExistsByName(projectID uuid.UUID, name string) (bool, error) {
+func (r *AccountInMemoryRepository) ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -68,6 +94,33 @@ func (r *AccountInMemoryRepository) ExistsByName(projectID uuid.UUID, name strin
 	return exists, nil
 }
 
+func (r *AccountInMemoryRepository) Update(ctx context.Context, account *models.Account) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, existing := range r.accounts {
+		if existing.ID != account.ID {
+			continue
+		}
+
+		newKey := r.getKey(account.ProjectID, account.Name)
+		if other, taken := r.accounts[newKey]; taken && other.ID != account.ID {
+			return fmt.Errorf("account with name '%s' already exists for project", account.Name)
+		}
+
+		account.UpdatedAt = time.Now()
+		delete(r.accounts, key)
+		r.accounts[newKey] = account
+		return nil
+	}
+
+	return fmt.Errorf("account not found")
+}
+
 func (r *AccountInMemoryRepository) getKey(projectID uuid.UUID, name string) string {
 	return fmt.Sprintf("%s:%s", projectID.String(), name)
 }
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type AccountPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewAccountPostgresRepository(db *sql.DB, observer QueryObserver) *AccountPostgresRepository {
+	return &AccountPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *AccountPostgresRepository) Create(ctx context.Context, account *models.Account) error {
+	query := `
+		INSERT INTO accounts (` + accountColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		account.ID.String(),
+		account.ProjectID.String(),
+		account.Name,
+		account.Currency.String(),
+		account.Type.String(),
+		account.Description,
+		account.Position,
+		account.ArchivedAt,
+		account.CreditLimit,
+		account.StatementDay,
+		account.DueDay,
+		account.CreatedAt,
+		account.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AccountPostgresRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Account, error) {
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE project_id = $1
+		ORDER BY position ASC, created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query accounts by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*models.Account
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating account rows: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (r *AccountPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	query := `
+		SELECT ` + accountColumns + `
+		FROM accounts
+		WHERE id = $1
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanAccount(row)
+}
+
+func (r *AccountPostgresRepository) ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error) {
+	query := `SELECT COUNT(*) FROM accounts WHERE project_id = $1 AND name = $2`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, projectID.String(), name).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check account existence: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *AccountPostgresRepository) Update(ctx context.Context, account *models.Account) error {
+	query := `
+		UPDATE accounts
+		SET name = $1, type = $2, description = $3, position = $4, archived_at = $5,
+			credit_limit = $6, statement_day = $7, due_day = $8, updated_at = $9
+		WHERE id = $10
+	`
+
+	account.UpdatedAt = time.Now()
+	result, err := r.db.ExecContext(ctx,
+		query,
+		account.Name,
+		account.Type.String(),
+		account.Description,
+		account.Position,
+		account.ArchivedAt,
+		account.CreditLimit,
+		account.StatementDay,
+		account.DueDay,
+		account.UpdatedAt,
+		account.ID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("account not found")
+	}
+
+	return nil
+}
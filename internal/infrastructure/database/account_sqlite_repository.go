@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -9,26 +10,35 @@ import (
 	"gofin/internal/models"
 )
 
+const accountColumns = "id, project_id, name, currency, type, description, position, archived_at, credit_limit, statement_day, due_day, created_at, updated_at"
+
 type AccountSqliteRepository struct {
-	db *sql.DB
+	db instrumentedDB
 }
 
-func NewAccountSqliteRepository(db *sql.DB) *AccountSqliteRepository {
-	return &AccountSqliteRepository{db: db}
+func NewAccountSqliteRepository(db *sql.DB, observer QueryObserver) *AccountSqliteRepository {
+	return &AccountSqliteRepository{db: newInstrumentedDB(db, observer)}
 }
 
-func (r *AccountSqliteRepository) Create(account *models.Account) error {
+func (r *AccountSqliteRepository) Create(ctx context.Context, account *models.Account) error {
 	query := `
-		INSERT INTO accounts (id, project_id, name, currency, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO accounts (` + accountColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.Exec(
+	_, err := r.db.ExecContext(ctx,
 		query,
 		account.ID.String(),
 		account.ProjectID.String(),
 		account.Name,
 		account.Currency.String(),
+		account.Type.String(),
+		account.Description,
+		account.Position,
+		account.ArchivedAt,
+		account.CreditLimit,
+		account.StatementDay,
+		account.DueDay,
 		account.CreatedAt,
 		account.UpdatedAt,
 	)
@@ -40,15 +50,15 @@ func (r *AccountSqliteRepository) Create(account *models.Account) error {
 	return nil
 }
 
-func (r *AccountSqliteRepository) GetByProjectID(projectID uuid.UUID) ([]*models.Account, error) {
+func (r *AccountSqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Account, error) {
 	query := `
-		SELECT id, project_id, name, currency, created_at, updated_at
+		SELECT ` + accountColumns + `
 		FROM accounts
 		WHERE project_id = ?
-		ORDER BY created_at ASC
+		ORDER BY position ASC, created_at ASC
 	`
 
-	rows, err := r.db.Query(query, projectID.String())
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query accounts by project_id: %w", err)
 	}
@@ -56,7 +66,7 @@ func (r *AccountSqliteRepository) GetByProjectID(projectID uuid.UUID) ([]*models
 
 	var accounts []*models.Account
 	for rows.Next() {
-		account, err := r.scanAccount(rows)
+		account, err := scanAccount(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan account: %w", err)
 		}
@@ -70,22 +80,22 @@ func (r *AccountSqliteRepository) GetByProjectID(projectID uuid.UUID) ([]*models
 	return accounts, nil
 }
 
-func (r *AccountSqliteRepository) GetByID(id uuid.UUID) (*models.Account, error) {
+func (r *AccountSqliteRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Account, error) {
 	query := `
-		SELECT id, project_id, name, currency, created_at, updated_at
+		SELECT ` + accountColumns + `
 		FROM accounts
 		WHERE id = ?
 	`
 
-	row := r.db.QueryRow(query, id.String())
-	return r.scanAccount(row)
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanAccount(row)
 }
 
-func (r *AccountSqliteRepository) ExistsByName(projectID uuid.UUID, name string) (bool, error) {
+func (r *AccountSqliteRepository) ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error) {
 	query := `SELECT COUNT(*) FROM accounts WHERE project_id = ? AND name = ?`
 
 	var count int
-	err := r.db.QueryRow(query, projectID.String(), name).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, projectID.String(), name).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check account existence: %w", err)
 	}
@@ -93,13 +103,54 @@ func (r *AccountSqliteRepository) ExistsByName(projectID uuid.UUID, name string)
 	return count > 0, nil
 }
 
-func (r *AccountSqliteRepository) scanAccount(scanner interface {
+func (r *AccountSqliteRepository) Update(ctx context.Context, account *models.Account) error {
+	query := `
+		UPDATE accounts
+		SET name = ?, type = ?, description = ?, position = ?, archived_at = ?,
+			credit_limit = ?, statement_day = ?, due_day = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	account.UpdatedAt = time.Now()
+	result, err := r.db.ExecContext(ctx,
+		query,
+		account.Name,
+		account.Type.String(),
+		account.Description,
+		account.Position,
+		account.ArchivedAt,
+		account.CreditLimit,
+		account.StatementDay,
+		account.DueDay,
+		account.UpdatedAt,
+		account.ID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("account not found")
+	}
+
+	return nil
+}
+
+func scanAccount(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*models.Account, error) {
-	var id, projectID, name, currency string
+	var id, projectID, name, currency, accountType, description string
+	var position, statementDay, dueDay int
+	var creditLimit float64
+	var archivedAt sql.NullTime
 	var createdAt, updatedAt time.Time
 
-	err := scanner.Scan(&id, &projectID, &name, &currency, &createdAt, &updatedAt)
+	err := scanner.Scan(&id, &projectID, &name, &currency, &accountType, &description, &position, &archivedAt, &creditLimit, &statementDay, &dueDay, &createdAt, &updatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("account not found")
@@ -122,12 +173,30 @@ func (r *AccountSqliteRepository) scanAccount(scanner interface {
 		return nil, fmt.Errorf("invalid currency: %w", err)
 	}
 
-	return &models.Account{
-		ID:        accountID,
-		ProjectID: projID,
-		Name:      name,
-		Currency:  currencyType,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
-	}, nil
+	parsedType, err := models.ParseAccountType(accountType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account type: %w", err)
+	}
+
+	account := &models.Account{
+		ID:           accountID,
+		ProjectID:    projID,
+		Name:         name,
+		Currency:     currencyType,
+		Type:         parsedType,
+		Description:  description,
+		Position:     position,
+		CreditLimit:  creditLimit,
+		StatementDay: statementDay,
+		DueDay:       dueDay,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+	}
+
+	if archivedAt.Valid {
+		archived := archivedAt.Time
+		account.ArchivedAt = &archived
+	}
+
+	return account, nil
 }
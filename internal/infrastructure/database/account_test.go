@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+)
+
+func TestAccountSqliteRepository(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewAccountSqliteRepository(db.GetConnection(), metrics.NewNoop())
+
+	projectID := uuid.New()
+	second := models.NewAccount(projectID, "Savings", "PLN")
+	second.Position = 1
+	first := models.NewAccount(projectID, "Visa", "PLN")
+	first.Type = models.AccountCreditCard
+	first.CreditLimit = 2500
+	first.StatementDay = 28
+	first.DueDay = 20
+	for _, account := range []*models.Account{second, first} {
+		if err := repo.Create(ctx, account); err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+	}
+
+	accounts, err := repo.GetByProjectID(ctx, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get accounts: %v", err)
+	}
+	if len(accounts) != 2 || accounts[0].ID != first.ID {
+		t.Fatalf("Expected accounts in position order, got %+v", accounts)
+	}
+
+	stored := accounts[0]
+	if stored.Type != models.AccountCreditCard || stored.CreditLimit != 2500 || stored.StatementDay != 28 || stored.DueDay != 20 {
+		t.Errorf("Expected the credit card to round-trip, got %+v", stored)
+	}
+
+	archivedAt := time.Now()
+	stored.Name = "Old Visa"
+	stored.Description = "Closed"
+	stored.Position = 2
+	stored.ArchivedAt = &archivedAt
+	if err := repo.Update(ctx, stored); err != nil {
+		t.Fatalf("Failed to update account: %v", err)
+	}
+
+	updated, err := repo.GetByID(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("Failed to get account: %v", err)
+	}
+	if updated.Name != "Old Visa" || updated.Description != "Closed" || updated.Position != 2 || !updated.IsArchived() {
+		t.Errorf("Expected the update to be saved, got %+v", updated)
+	}
+
+	if exists, _ := repo.ExistsByName(ctx, projectID, "Visa"); exists {
+		t.Error("Expected the old name to be free after renaming")
+	}
+
+	if err := repo.Update(ctx, models.NewAccount(projectID, "Missing", "PLN")); err == nil {
+		t.Error("Expected error when updating a missing account")
+	}
+}
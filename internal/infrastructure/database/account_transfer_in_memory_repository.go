@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type AccountTransferInMemoryRepository struct {
+	transfers map[uuid.UUID]*models.AccountTransfer
+	mu        sync.RWMutex
+}
+
+func NewAccountTransferInMemoryRepository() *AccountTransferInMemoryRepository {
+	return &AccountTransferInMemoryRepository{
+		transfers: make(map[uuid.UUID]*models.AccountTransfer),
+	}
+}
+
+func (r *AccountTransferInMemoryRepository) Create(ctx context.Context, transfer *models.AccountTransfer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.transfers[transfer.ID]; exists {
+		return fmt.Errorf("transfer with ID '%s' already exists", transfer.ID)
+	}
+
+	r.transfers[transfer.ID] = transfer
+	return nil
+}
+
+func (r *AccountTransferInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.AccountTransfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var transfers []*models.AccountTransfer
+	for _, transfer := range r.transfers {
+		if transfer.ProjectID == projectID {
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].CreatedAt.After(transfers[j].CreatedAt)
+	})
+
+	return transfers, nil
+}
+
+func (r *AccountTransferInMemoryRepository) GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*models.AccountTransfer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var transfers []*models.AccountTransfer
+	for _, transfer := range r.transfers {
+		if transfer.GroupID == groupID {
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	return transfers, nil
+}
+
+func (r *AccountTransferInMemoryRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.transfers[id]; !exists {
+		return fmt.Errorf("transfer with ID '%s' not found", id)
+	}
+
+	delete(r.transfers, id)
+	return nil
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type AccountTransferPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewAccountTransferPostgresRepository(db *sql.DB, observer QueryObserver) *AccountTransferPostgresRepository {
+	return &AccountTransferPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *AccountTransferPostgresRepository) Create(ctx context.Context, transfer *models.AccountTransfer) error {
+	query := `
+		INSERT INTO account_transfers (id, project_id, from_transaction_id, to_transaction_id, amount, group_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		transfer.ID.String(),
+		transfer.ProjectID.String(),
+		transfer.FromTransactionID.String(),
+		transfer.ToTransactionID.String(),
+		transfer.Amount,
+		transfer.GroupID.String(),
+		transfer.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AccountTransferPostgresRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.AccountTransfer, error) {
+	query := `
+		SELECT id, project_id, from_transaction_id, to_transaction_id, amount, group_id, created_at
+		FROM account_transfers
+		WHERE project_id = $1
+		ORDER BY created_at DESC
+	`
+
+	return r.query(ctx, query, projectID.String())
+}
+
+func (r *AccountTransferPostgresRepository) GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*models.AccountTransfer, error) {
+	query := `
+		SELECT id, project_id, from_transaction_id, to_transaction_id, amount, group_id, created_at
+		FROM account_transfers
+		WHERE group_id = $1
+	`
+
+	return r.query(ctx, query, groupID.String())
+}
+
+func (r *AccountTransferPostgresRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM account_transfers WHERE id = $1`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete transfer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("transfer with ID '%s' not found", id)
+	}
+
+	return nil
+}
+
+func (r *AccountTransferPostgresRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.AccountTransfer, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*models.AccountTransfer
+	for rows.Next() {
+		transfer, err := scanAccountTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transfer rows: %w", err)
+	}
+
+	return transfers, nil
+}
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type AccountTransferSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewAccountTransferSqliteRepository(db *sql.DB, observer QueryObserver) *AccountTransferSqliteRepository {
+	return &AccountTransferSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *AccountTransferSqliteRepository) Create(ctx context.Context, transfer *models.AccountTransfer) error {
+	query := `
+		INSERT INTO account_transfers (id, project_id, from_transaction_id, to_transaction_id, amount, group_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		transfer.ID.String(),
+		transfer.ProjectID.String(),
+		transfer.FromTransactionID.String(),
+		transfer.ToTransactionID.String(),
+		transfer.Amount,
+		transfer.GroupID.String(),
+		transfer.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AccountTransferSqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.AccountTransfer, error) {
+	query := `
+		SELECT id, project_id, from_transaction_id, to_transaction_id, amount, group_id, created_at
+		FROM account_transfers
+		WHERE project_id = ?
+		ORDER BY created_at DESC
+	`
+
+	return r.query(ctx, query, projectID.String())
+}
+
+func (r *AccountTransferSqliteRepository) GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*models.AccountTransfer, error) {
+	query := `
+		SELECT id, project_id, from_transaction_id, to_transaction_id, amount, group_id, created_at
+		FROM account_transfers
+		WHERE group_id = ?
+	`
+
+	return r.query(ctx, query, groupID.String())
+}
+
+func (r *AccountTransferSqliteRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM account_transfers WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete transfer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("transfer with ID '%s' not found", id)
+	}
+
+	return nil
+}
+
+func (r *AccountTransferSqliteRepository) query(ctx context.Context, query string, args ...interface{}) ([]*models.AccountTransfer, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*models.AccountTransfer
+	for rows.Next() {
+		transfer, err := scanAccountTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transfer rows: %w", err)
+	}
+
+	return transfers, nil
+}
+
+func scanAccountTransfer(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.AccountTransfer, error) {
+	var id, projectID, fromTransactionID, toTransactionID, groupID string
+	var amount float64
+	var createdAt time.Time
+
+	if err := scanner.Scan(&id, &projectID, &fromTransactionID, &toTransactionID, &amount, &groupID, &createdAt); err != nil {
+		return nil, fmt.Errorf("failed to scan transfer row: %w", err)
+	}
+
+	transfer := &models.AccountTransfer{Amount: amount, CreatedAt: createdAt}
+	var err error
+	if transfer.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid transfer ID: %w", err)
+	}
+	if transfer.ProjectID, err = uuid.Parse(projectID); err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	if transfer.FromTransactionID, err = uuid.Parse(fromTransactionID); err != nil {
+		return nil, fmt.Errorf("invalid transaction ID: %w", err)
+	}
+	if transfer.ToTransactionID, err = uuid.Parse(toTransactionID); err != nil {
+		return nil, fmt.Errorf("invalid transaction ID: %w", err)
+	}
+	if transfer.GroupID, err = uuid.Parse(groupID); err != nil {
+		return nil, fmt.Errorf("invalid group ID: %w", err)
+	}
+
+	return transfer, nil
+}
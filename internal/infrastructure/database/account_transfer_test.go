@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+)
+
+func TestAccountTransferSqliteRepository(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewAccountTransferSqliteRepository(db.GetConnection(), metrics.NewNoop())
+
+	projectID := uuid.New()
+	groupID := uuid.New()
+	debit := models.NewTransaction(models.TransactionData{AccountID: uuid.New(), Value: 250, Name: "Transfer", Type: models.Debit}, groupID)
+	topUp := models.NewTransaction(models.TransactionData{AccountID: uuid.New(), Value: 250, Name: "Transfer", Type: models.TopUp}, groupID)
+
+	transfer := models.NewAccountTransfer(projectID, debit, topUp)
+	if err := repo.Create(ctx, transfer); err != nil {
+		t.Fatalf("Failed to create transfer: %v", err)
+	}
+
+	transfers, err := repo.GetByProjectID(ctx, projectID)
+	if err != nil || len(transfers) != 1 {
+		t.Fatalf("Expected 1 transfer, got %d (%v)", len(transfers), err)
+	}
+	stored := transfers[0]
+	if stored.FromTransactionID != debit.ID || stored.ToTransactionID != topUp.ID || stored.GroupID != groupID || stored.Amount != 250 {
+		t.Errorf("Unexpected transfer %+v", stored)
+	}
+
+	if grouped, err := repo.GetByGroupID(ctx, groupID); err != nil || len(grouped) != 1 || grouped[0].ID != transfer.ID {
+		t.Errorf("Expected the transfer by its group, got %v (%v)", grouped, err)
+	}
+	if err := repo.DeleteByID(ctx, transfer.ID); err != nil {
+		t.Fatalf("Failed to delete transfer: %v", err)
+	}
+	if remaining, _ := repo.GetByProjectID(ctx, projectID); len(remaining) != 0 {
+		t.Errorf("Expected the transfer to be deleted, got %d", len(remaining))
+	}
+	if err := repo.DeleteByID(ctx, transfer.ID); err == nil {
+		t.Error("Expected an error deleting a missing transfer")
+	}
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type AttachmentInMemoryRepository struct {
+	attachments map[string]*models.Attachment
+	mu          sync.RWMutex
+}
+
+func NewAttachmentInMemoryRepository() *AttachmentInMemoryRepository {
+	return &AttachmentInMemoryRepository{
+		attachments: make(map[string]*models.Attachment),
+	}
+}
+
+func (r *AttachmentInMemoryRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := attachment.ID.String()
+	if _, exists := r.attachments[key]; exists {
+		return fmt.Errorf("attachment with ID '%s' already exists", key)
+	}
+
+	r.attachments[key] = attachment
+	return nil
+}
+
+func (r *AttachmentInMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attachment, exists := r.attachments[id.String()]
+	if !exists {
+		return nil, fmt.Errorf("attachment not found")
+	}
+
+	return attachment, nil
+}
+
+func (r *AttachmentInMemoryRepository) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*models.Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var attachments []*models.Attachment
+	for _, attachment := range r.attachments {
+		if attachment.TransactionID == transactionID {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+
+	return attachments, nil
+}
+
+func (r *AttachmentInMemoryRepository) CountByChecksum(ctx context.Context, checksum string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, attachment := range r.attachments {
+		if attachment.Checksum == checksum {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (r *AttachmentInMemoryRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.attachments[id.String()]; !exists {
+		return fmt.Errorf("attachment not found")
+	}
+
+	delete(r.attachments, id.String())
+	return nil
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type AttachmentPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewAttachmentPostgresRepository(db *sql.DB, observer QueryObserver) *AttachmentPostgresRepository {
+	return &AttachmentPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *AttachmentPostgresRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	query := `
+		INSERT INTO attachments (id, transaction_id, file_name, content_type, size, checksum, has_thumbnail, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		attachment.ID.String(),
+		attachment.TransactionID.String(),
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.HasThumbnail,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AttachmentPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	query := `
+		SELECT id, transaction_id, file_name, content_type, size, checksum, has_thumbnail, created_at
+		FROM attachments
+		WHERE id = $1
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanAttachment(row)
+}
+
+func (r *AttachmentPostgresRepository) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*models.Attachment, error) {
+	query := `
+		SELECT id, transaction_id, file_name, content_type, size, checksum, has_thumbnail, created_at
+		FROM attachments
+		WHERE transaction_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, transactionID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments by transaction_id: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []*models.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachment rows: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentPostgresRepository) CountByChecksum(ctx context.Context, checksum string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM attachments WHERE checksum = $1`, checksum).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count attachments by checksum: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *AttachmentPostgresRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM attachments WHERE id = $1`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("attachment not found")
+	}
+
+	return nil
+}
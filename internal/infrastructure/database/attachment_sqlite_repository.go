@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type AttachmentSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewAttachmentSqliteRepository(db *sql.DB, observer QueryObserver) *AttachmentSqliteRepository {
+	return &AttachmentSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *AttachmentSqliteRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	query := `
+		INSERT INTO attachments (id, transaction_id, file_name, content_type, size, checksum, has_thumbnail, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		attachment.ID.String(),
+		attachment.TransactionID.String(),
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.HasThumbnail,
+		attachment.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AttachmentSqliteRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	query := `
+		SELECT id, transaction_id, file_name, content_type, size, checksum, has_thumbnail, created_at
+		FROM attachments
+		WHERE id = ?
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanAttachment(row)
+}
+
+func (r *AttachmentSqliteRepository) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*models.Attachment, error) {
+	query := `
+		SELECT id, transaction_id, file_name, content_type, size, checksum, has_thumbnail, created_at
+		FROM attachments
+		WHERE transaction_id = ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, transactionID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments by transaction_id: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []*models.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachment rows: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentSqliteRepository) CountByChecksum(ctx context.Context, checksum string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM attachments WHERE checksum = ?`, checksum).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count attachments by checksum: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *AttachmentSqliteRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM attachments WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("attachment not found")
+	}
+
+	return nil
+}
+
+func scanAttachment(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Attachment, error) {
+	var id, transactionID, fileName, contentType, checksum string
+	var size int64
+	var hasThumbnail bool
+	var createdAt time.Time
+
+	err := scanner.Scan(&id, &transactionID, &fileName, &contentType, &size, &checksum, &hasThumbnail, &createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("attachment not found")
+		}
+		return nil, fmt.Errorf("failed to scan attachment row: %w", err)
+	}
+
+	attachmentID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment ID: %w", err)
+	}
+
+	transactionUUID, err := uuid.Parse(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction ID: %w", err)
+	}
+
+	return &models.Attachment{
+		ID:            attachmentID,
+		TransactionID: transactionUUID,
+		FileName:      fileName,
+		ContentType:   contentType,
+		Size:          size,
+		Checksum:      checksum,
+		HasThumbnail:  hasThumbnail,
+		CreatedAt:     createdAt,
+	}, nil
+}
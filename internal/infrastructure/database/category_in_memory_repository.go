@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type CategoryInMemoryRepository struct {
+	categories map[string]*models.Category
+	mu         sync.RWMutex
+}
+
+func NewCategoryInMemoryRepository() *CategoryInMemoryRepository {
+	return &CategoryInMemoryRepository{
+		categories: make(map[string]*models.Category),
+	}
+}
+
+func (r *CategoryInMemoryRepository) Create(ctx context.Context, category *models.Category) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := category.ID.String()
+	if _, exists := r.categories[key]; exists {
+		return fmt.Errorf("category with ID '%s' already exists", key)
+	}
+
+	r.categories[key] = category
+	return nil
+}
+
+func (r *CategoryInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var categories []*models.Category
+	for _, category := range r.categories {
+		if category.ProjectID == projectID {
+			categories = append(categories, category)
+		}
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return strings.ToLower(categories[i].Name) < strings.ToLower(categories[j].Name)
+	})
+
+	return categories, nil
+}
+
+func (r *CategoryInMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	category, exists := r.categories[id.String()]
+	if !exists {
+		return nil, fmt.Errorf("category not found")
+	}
+
+	return category, nil
+}
+
+func (r *CategoryInMemoryRepository) ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, category := range r.categories {
+		if category.ProjectID == projectID && strings.EqualFold(category.Name, name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type CategoryPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewCategoryPostgresRepository(db *sql.DB, observer QueryObserver) *CategoryPostgresRepository {
+	return &CategoryPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *CategoryPostgresRepository) Create(ctx context.Context, category *models.Category) error {
+	query := `
+		INSERT INTO categories (id, project_id, name, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		category.ID.String(),
+		category.ProjectID.String(),
+		category.Name,
+		category.CreatedAt,
+		category.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CategoryPostgresRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Category, error) {
+	query := `
+		SELECT id, project_id, name, created_at, updated_at
+		FROM categories
+		WHERE project_id = $1
+		ORDER BY lower(name) ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categories by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*models.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating category rows: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	query := `
+		SELECT id, project_id, name, created_at, updated_at
+		FROM categories
+		WHERE id = $1
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanCategory(row)
+}
+
+func (r *CategoryPostgresRepository) ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error) {
+	query := `SELECT COUNT(*) FROM categories WHERE project_id = $1 AND lower(name) = lower($2)`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, projectID.String(), name).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check category existence: %w", err)
+	}
+
+	return count > 0, nil
+}
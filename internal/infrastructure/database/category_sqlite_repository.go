@@ -0,0 +1,126 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type CategorySqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewCategorySqliteRepository(db *sql.DB, observer QueryObserver) *CategorySqliteRepository {
+	return &CategorySqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *CategorySqliteRepository) Create(ctx context.Context, category *models.Category) error {
+	query := `
+		INSERT INTO categories (id, project_id, name, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		category.ID.String(),
+		category.ProjectID.String(),
+		category.Name,
+		category.CreatedAt,
+		category.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CategorySqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Category, error) {
+	query := `
+		SELECT id, project_id, name, created_at, updated_at
+		FROM categories
+		WHERE project_id = ?
+		ORDER BY name COLLATE NOCASE ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categories by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var categories []*models.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating category rows: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *CategorySqliteRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Category, error) {
+	query := `
+		SELECT id, project_id, name, created_at, updated_at
+		FROM categories
+		WHERE id = ?
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanCategory(row)
+}
+
+func (r *CategorySqliteRepository) ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error) {
+	query := `SELECT COUNT(*) FROM categories WHERE project_id = ? AND name = ? COLLATE NOCASE`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, projectID.String(), name).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check category existence: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func scanCategory(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Category, error) {
+	var id, projectID, name string
+	var createdAt, updatedAt time.Time
+
+	err := scanner.Scan(&id, &projectID, &name, &createdAt, &updatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("category not found")
+		}
+		return nil, fmt.Errorf("failed to scan category row: %w", err)
+	}
+
+	categoryID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid category ID: %w", err)
+	}
+
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	return &models.Category{
+		ID:        categoryID,
+		ProjectID: projectUUID,
+		Name:      name,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+func TestInMemoryRepositories_HonourCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	project := models.NewProject("Test Project", "test-project")
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "project create",
+			call: func() error { return NewProjectInMemoryRepository().Create(ctx, project) },
+		},
+		{
+			name: "access lookup",
+			call: func() error {
+				_, err := NewAccessInMemoryRepository().GetByUID(ctx, project.ID, "12")
+				return err
+			},
+		},
+		{
+			name: "account listing",
+			call: func() error {
+				_, err := NewAccountInMemoryRepository().GetByProjectID(ctx, project.ID)
+				return err
+			},
+		},
+		{
+			name: "transaction deletion",
+			call: func() error { return NewTransactionInMemoryRepository().DeleteByID(ctx, uuid.New()) },
+		},
+		{
+			name: "recovery code listing",
+			call: func() error {
+				_, err := NewRecoveryCodeInMemoryRepository().GetUnusedByAccessID(ctx, uuid.New())
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", err)
+			}
+		})
+	}
+}
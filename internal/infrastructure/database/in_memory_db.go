@@ -0,0 +1,29 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// InMemoryDB stands in for the SQLite database when every repository is in memory.
+type InMemoryDB struct{}
+
+func NewInMemoryDB() *InMemoryDB {
+	return &InMemoryDB{}
+}
+
+func (db *InMemoryDB) Close() error {
+	return nil
+}
+
+func (db *InMemoryDB) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (db *InMemoryDB) CheckSchema(ctx context.Context) error {
+	return nil
+}
+
+func (db *InMemoryDB) SnapshotInto(ctx context.Context, path string) error {
+	return fmt.Errorf("an in-memory database cannot be snapshotted")
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"gofin/pkg/logging"
+)
+
+// QueryObserver receives the duration of every repository statement, e.g. for metrics.
+type QueryObserver interface {
+	ObserveQuery(statement string, duration time.Duration, err error)
+}
+
+// instrumentedDB wraps the connection used by the SQL repositories so every
+// statement is reported to the observer and logged at debug level with its duration,
+// failures at warn level.
+type instrumentedDB struct {
+	*sql.DB
+	observer QueryObserver
+}
+
+func newInstrumentedDB(db *sql.DB, observer QueryObserver) instrumentedDB {
+	return instrumentedDB{DB: db, observer: observer}
+}
+
+func (db instrumentedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	start := time.Now()
+	result, err := db.DB.ExecContext(ctx, query, args...)
+	db.observe(ctx, query, start, err)
+	return result, err
+}
+
+func (db instrumentedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	db.observe(ctx, query, start, err)
+	return rows, err
+}
+
+func (db instrumentedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	start := time.Now()
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	db.observe(ctx, query, start, row.Err())
+	return row
+}
+
+func (db instrumentedDB) observe(ctx context.Context, query string, start time.Time, err error) {
+	statement := describeQuery(query)
+	duration := time.Since(start)
+	db.observer.ObserveQuery(statement, duration, err)
+
+	attrs := []any{
+		slog.String("statement", statement),
+		slog.Duration("duration", duration),
+	}
+
+	if err != nil && err != sql.ErrNoRows {
+		logging.FromContext(ctx).WarnContext(ctx, "database query failed", append(attrs, logging.Err(err))...)
+		return
+	}
+
+	logging.FromContext(ctx).DebugContext(ctx, "database query", attrs...)
+}
+
+// describeQuery reduces a statement to its verb and main table, e.g. "SELECT accounts",
+// keeping log records short and free of bound values.
+func describeQuery(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	verb := strings.ToUpper(fields[0])
+	tableAfter := map[string]string{
+		"SELECT": "FROM",
+		"INSERT": "INTO",
+		"DELETE": "FROM",
+		"UPDATE": "UPDATE",
+	}[verb]
+
+	for i, field := range fields {
+		if strings.EqualFold(field, tableAfter) && i+1 < len(fields) {
+			return verb + " " + strings.Trim(fields[i+1], "(),;")
+		}
+	}
+
+	return verb
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type InvestmentOperationInMemoryRepository struct {
+	operations map[string]*models.InvestmentOperation
+	mu         sync.RWMutex
+}
+
+func NewInvestmentOperationInMemoryRepository() *InvestmentOperationInMemoryRepository {
+	return &InvestmentOperationInMemoryRepository{
+		operations: make(map[string]*models.InvestmentOperation),
+	}
+}
+
+func (r *InvestmentOperationInMemoryRepository) Create(ctx context.Context, operation *models.InvestmentOperation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := operation.ID.String()
+	if _, exists := r.operations[key]; exists {
+		return fmt.Errorf("investment operation with ID '%s' already exists", key)
+	}
+
+	r.operations[key] = operation
+	return nil
+}
+
+func (r *InvestmentOperationInMemoryRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.InvestmentOperation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var operations []*models.InvestmentOperation
+	for _, operation := range r.operations {
+		if operation.AccountID == accountID {
+			operations = append(operations, operation)
+		}
+	}
+
+	sort.Slice(operations, func(i, j int) bool {
+		if !operations[i].Date.Equal(operations[j].Date) {
+			return operations[i].Date.Before(operations[j].Date)
+		}
+		return operations[i].CreatedAt.Before(operations[j].CreatedAt)
+	})
+
+	return operations, nil
+}
+
+func (r *InvestmentOperationInMemoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := id.String()
+	if _, exists := r.operations[key]; !exists {
+		return fmt.Errorf("investment operation not found")
+	}
+
+	delete(r.operations, key)
+	return nil
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type InvestmentOperationPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewInvestmentOperationPostgresRepository(db *sql.DB, observer QueryObserver) *InvestmentOperationPostgresRepository {
+	return &InvestmentOperationPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *InvestmentOperationPostgresRepository) Create(ctx context.Context, operation *models.InvestmentOperation) error {
+	query := `
+		INSERT INTO investment_operations (` + investmentOperationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		operation.ID.String(),
+		operation.AccountID.String(),
+		operation.SecurityID.String(),
+		operation.Type.String(),
+		operation.Quantity,
+		operation.Price,
+		operation.Fees,
+		operation.Amount,
+		operation.Date,
+		operation.TransactionID.String(),
+		operation.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create investment operation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InvestmentOperationPostgresRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.InvestmentOperation, error) {
+	query := `
+		SELECT ` + investmentOperationColumns + `
+		FROM investment_operations
+		WHERE account_id = $1
+		ORDER BY date ASC, created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query investment operations by account_id: %w", err)
+	}
+	defer rows.Close()
+
+	var operations []*models.InvestmentOperation
+	for rows.Next() {
+		operation, err := scanInvestmentOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan investment operation: %w", err)
+		}
+		operations = append(operations, operation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating investment operation rows: %w", err)
+	}
+
+	return operations, nil
+}
+
+func (r *InvestmentOperationPostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM investment_operations WHERE id = $1`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete investment operation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("investment operation not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+const investmentOperationColumns = "id, account_id, security_id, type, quantity, price, fees, amount, date, transaction_id, created_at"
+
+type InvestmentOperationSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewInvestmentOperationSqliteRepository(db *sql.DB, observer QueryObserver) *InvestmentOperationSqliteRepository {
+	return &InvestmentOperationSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *InvestmentOperationSqliteRepository) Create(ctx context.Context, operation *models.InvestmentOperation) error {
+	query := `
+		INSERT INTO investment_operations (` + investmentOperationColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		operation.ID.String(),
+		operation.AccountID.String(),
+		operation.SecurityID.String(),
+		operation.Type.String(),
+		operation.Quantity,
+		operation.Price,
+		operation.Fees,
+		operation.Amount,
+		operation.Date,
+		operation.TransactionID.String(),
+		operation.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create investment operation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *InvestmentOperationSqliteRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.InvestmentOperation, error) {
+	query := `
+		SELECT ` + investmentOperationColumns + `
+		FROM investment_operations
+		WHERE account_id = ?
+		ORDER BY date ASC, created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query investment operations by account_id: %w", err)
+	}
+	defer rows.Close()
+
+	var operations []*models.InvestmentOperation
+	for rows.Next() {
+		operation, err := scanInvestmentOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan investment operation: %w", err)
+		}
+		operations = append(operations, operation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating investment operation rows: %w", err)
+	}
+
+	return operations, nil
+}
+
+func (r *InvestmentOperationSqliteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM investment_operations WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete investment operation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("investment operation not found")
+	}
+
+	return nil
+}
+
+func scanInvestmentOperation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.InvestmentOperation, error) {
+	var id, accountID, securityID, operationType, transactionID string
+	var operation models.InvestmentOperation
+
+	err := scanner.Scan(&id, &accountID, &securityID, &operationType, &operation.Quantity, &operation.Price,
+		&operation.Fees, &operation.Amount, &operation.Date, &transactionID, &operation.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan investment operation row: %w", err)
+	}
+
+	if operation.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid investment operation ID: %w", err)
+	}
+
+	if operation.AccountID, err = uuid.Parse(accountID); err != nil {
+		return nil, fmt.Errorf("invalid account ID: %w", err)
+	}
+
+	if operation.SecurityID, err = uuid.Parse(securityID); err != nil {
+		return nil, fmt.Errorf("invalid security ID: %w", err)
+	}
+
+	if operation.TransactionID, err = uuid.Parse(transactionID); err != nil {
+		return nil, fmt.Errorf("invalid transaction ID: %w", err)
+	}
+
+	if operation.Type, err = models.ParseInvestmentOperationType(operationType); err != nil {
+		return nil, err
+	}
+
+	return &operation, nil
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+)
+
+func TestSecuritySqliteRepository(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewSecuritySqliteRepository(db.GetConnection(), metrics.NewNoop())
+
+	projectID := uuid.New()
+	security := models.NewSecurity(projectID, "vwce.de", "FTSE All-World", "EUR")
+	if err := repo.Create(ctx, security); err != nil {
+		t.Fatalf("Failed to create security: %v", err)
+	}
+
+	if err := repo.Create(ctx, models.NewSecurity(projectID, "VWCE.DE", "", "EUR")); err == nil {
+		t.Error("Expected an error for a duplicate ticker")
+	}
+
+	stored, err := repo.GetByTicker(ctx, projectID, "Vwce.de")
+	if err != nil {
+		t.Fatalf("Failed to get security by ticker: %v", err)
+	}
+	if stored.ID != security.ID || stored.Ticker != "VWCE.DE" || stored.Name != "FTSE All-World" || stored.Currency != "EUR" {
+		t.Errorf("Expected the security to round-trip, got %+v", stored)
+	}
+
+	if _, err := repo.GetByTicker(ctx, uuid.New(), "VWCE.DE"); err == nil {
+		t.Error("Expected an error for a ticker of another project")
+	}
+
+	later := time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC)
+	earlier := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for _, price := range []*models.SecurityPrice{
+		models.NewSecurityPrice(security.ID, later, 111),
+		models.NewSecurityPrice(security.ID, earlier, 110),
+		models.NewSecurityPrice(security.ID, later, 112),
+	} {
+		if err := repo.SetPrice(ctx, price); err != nil {
+			t.Fatalf("Failed to set price: %v", err)
+		}
+	}
+
+	prices, err := repo.GetPrices(ctx, security.ID)
+	if err != nil {
+		t.Fatalf("Failed to get prices: %v", err)
+	}
+	if len(prices) != 2 || !prices[0].Date.Equal(earlier) || prices[1].Price != 112 {
+		t.Errorf("Expected two date-ordered prices with the later one replaced, got %+v", prices)
+	}
+}
+
+func TestInvestmentOperationSqliteRepository(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewInvestmentOperationSqliteRepository(db.GetConnection(), metrics.NewNoop())
+
+	accountID := uuid.New()
+	securityID := uuid.New()
+	sold := models.NewInvestmentOperation(accountID, securityID, models.OperationSell, 4, 120, 1, 0, time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC))
+	bought := models.NewInvestmentOperation(accountID, securityID, models.OperationBuy, 10, 100, 2, 0, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))
+	bought.TransactionID = uuid.New()
+	for _, operation := range []*models.InvestmentOperation{sold, bought} {
+		if err := repo.Create(ctx, operation); err != nil {
+			t.Fatalf("Failed to create operation: %v", err)
+		}
+	}
+
+	operations, err := repo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		t.Fatalf("Failed to get operations: %v", err)
+	}
+	if len(operations) != 2 || operations[0].ID != bought.ID {
+		t.Fatalf("Expected two operations oldest first, got %+v", operations)
+	}
+
+	stored := operations[0]
+	if stored.Type != models.OperationBuy || stored.Quantity != 10 || stored.Price != 100 || stored.Fees != 2 ||
+		stored.Amount != 1002 || stored.TransactionID != bought.TransactionID || !stored.Date.Equal(bought.Date) {
+		t.Errorf("Expected the operation to round-trip, got %+v", stored)
+	}
+
+	if err := repo.Delete(ctx, sold.ID); err != nil {
+		t.Fatalf("Failed to delete operation: %v", err)
+	}
+	if err := repo.Delete(ctx, sold.ID); err == nil {
+		t.Error("Expected an error deleting a missing operation")
+	}
+
+	operations, _ = repo.GetByAccountID(ctx, accountID)
+	if len(operations) != 1 {
+		t.Errorf("Expected one operation left, got %d", len(operations))
+	}
+}
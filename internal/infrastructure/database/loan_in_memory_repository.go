@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type LoanInMemoryRepository struct {
+	loans   map[string]*models.Loan
+	periods map[string]*models.LoanRatePeriod
+	mu      sync.RWMutex
+}
+
+func NewLoanInMemoryRepository() *LoanInMemoryRepository {
+	return &LoanInMemoryRepository{
+		loans:   make(map[string]*models.Loan),
+		periods: make(map[string]*models.LoanRatePeriod),
+	}
+}
+
+func (r *LoanInMemoryRepository) Create(ctx context.Context, loan *models.Loan) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := loan.AccountID.String()
+	if _, exists := r.loans[key]; exists {
+		return fmt.Errorf("loan for account '%s' already exists", key)
+	}
+
+	r.loans[key] = loan
+	return nil
+}
+
+func (r *LoanInMemoryRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (*models.Loan, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	loan, exists := r.loans[accountID.String()]
+	if !exists {
+		return nil, fmt.Errorf("loan not found")
+	}
+
+	return loan, nil
+}
+
+func (r *LoanInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Loan, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var loans []*models.Loan
+	for _, loan := range r.loans {
+		if loan.ProjectID == projectID {
+			loans = append(loans, loan)
+		}
+	}
+
+	sort.Slice(loans, func(i, j int) bool {
+		return loans[i].StartDate.Before(loans[j].StartDate)
+	})
+
+	return loans, nil
+}
+
+func (r *LoanInMemoryRepository) AddRatePeriod(ctx context.Context, period *models.LoanRatePeriod) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.periods[period.ID.String()] = period
+	return nil
+}
+
+func (r *LoanInMemoryRepository) GetRatePeriods(ctx context.Context, accountID uuid.UUID) ([]*models.LoanRatePeriod, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var periods []*models.LoanRatePeriod
+	for _, period := range r.periods {
+		if period.AccountID == accountID {
+			periods = append(periods, period)
+		}
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].StartsOn.Before(periods[j].StartsOn)
+	})
+
+	return periods, nil
+}
+
+func (r *LoanInMemoryRepository) DeleteRatePeriod(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := id.String()
+	if _, exists := r.periods[key]; !exists {
+		return fmt.Errorf("loan rate period not found")
+	}
+
+	delete(r.periods, key)
+	return nil
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type LoanPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewLoanPostgresRepository(db *sql.DB, observer QueryObserver) *LoanPostgresRepository {
+	return &LoanPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *LoanPostgresRepository) Create(ctx context.Context, loan *models.Loan) error {
+	query := `
+		INSERT INTO loans (` + loanColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		loan.AccountID.String(),
+		loan.ProjectID.String(),
+		loan.Principal,
+		loan.AnnualRate,
+		loan.TermMonths,
+		loan.PaymentDay,
+		loan.StartDate,
+		loan.CreatedAt,
+		loan.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create loan: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoanPostgresRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (*models.Loan, error) {
+	query := `SELECT ` + loanColumns + ` FROM loans WHERE account_id = $1`
+
+	row := r.db.QueryRowContext(ctx, query, accountID.String())
+	return scanLoan(row)
+}
+
+func (r *LoanPostgresRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Loan, error) {
+	query := `
+		SELECT ` + loanColumns + `
+		FROM loans
+		WHERE project_id = $1
+		ORDER BY start_date ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query loans by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var loans []*models.Loan
+	for rows.Next() {
+		loan, err := scanLoan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan loan: %w", err)
+		}
+		loans = append(loans, loan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan rows: %w", err)
+	}
+
+	return loans, nil
+}
+
+func (r *LoanPostgresRepository) AddRatePeriod(ctx context.Context, period *models.LoanRatePeriod) error {
+	query := `
+		INSERT INTO loan_rate_periods (id, account_id, starts_on, annual_rate, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		period.ID.String(),
+		period.AccountID.String(),
+		period.StartsOn,
+		period.AnnualRate,
+		period.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create loan rate period: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoanPostgresRepository) GetRatePeriods(ctx context.Context, accountID uuid.UUID) ([]*models.LoanRatePeriod, error) {
+	query := `
+		SELECT id, account_id, starts_on, annual_rate, created_at
+		FROM loan_rate_periods
+		WHERE account_id = $1
+		ORDER BY starts_on ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query loan rate periods: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []*models.LoanRatePeriod
+	for rows.Next() {
+		period, err := scanLoanRatePeriod(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan loan rate period: %w", err)
+		}
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan rate period rows: %w", err)
+	}
+
+	return periods, nil
+}
+
+func (r *LoanPostgresRepository) DeleteRatePeriod(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM loan_rate_periods WHERE id = $1`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete loan rate period: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("loan rate period not found")
+	}
+
+	return nil
+}
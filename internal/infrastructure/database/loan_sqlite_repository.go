@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+const loanColumns = "account_id, project_id, principal, annual_rate, term_months, payment_day, start_date, created_at, updated_at"
+
+type LoanSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewLoanSqliteRepository(db *sql.DB, observer QueryObserver) *LoanSqliteRepository {
+	return &LoanSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *LoanSqliteRepository) Create(ctx context.Context, loan *models.Loan) error {
+	query := `
+		INSERT INTO loans (` + loanColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		loan.AccountID.String(),
+		loan.ProjectID.String(),
+		loan.Principal,
+		loan.AnnualRate,
+		loan.TermMonths,
+		loan.PaymentDay,
+		loan.StartDate,
+		loan.CreatedAt,
+		loan.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create loan: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoanSqliteRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (*models.Loan, error) {
+	query := `SELECT ` + loanColumns + ` FROM loans WHERE account_id = ?`
+
+	row := r.db.QueryRowContext(ctx, query, accountID.String())
+	return scanLoan(row)
+}
+
+func (r *LoanSqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Loan, error) {
+	query := `
+		SELECT ` + loanColumns + `
+		FROM loans
+		WHERE project_id = ?
+		ORDER BY start_date ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query loans by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var loans []*models.Loan
+	for rows.Next() {
+		loan, err := scanLoan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan loan: %w", err)
+		}
+		loans = append(loans, loan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan rows: %w", err)
+	}
+
+	return loans, nil
+}
+
+func (r *LoanSqliteRepository) AddRatePeriod(ctx context.Context, period *models.LoanRatePeriod) error {
+	query := `
+		INSERT INTO loan_rate_periods (id, account_id, starts_on, annual_rate, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		period.ID.String(),
+		period.AccountID.String(),
+		period.StartsOn,
+		period.AnnualRate,
+		period.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create loan rate period: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoanSqliteRepository) GetRatePeriods(ctx context.Context, accountID uuid.UUID) ([]*models.LoanRatePeriod, error) {
+	query := `
+		SELECT id, account_id, starts_on, annual_rate, created_at
+		FROM loan_rate_periods
+		WHERE account_id = ?
+		ORDER BY starts_on ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query loan rate periods: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []*models.LoanRatePeriod
+	for rows.Next() {
+		period, err := scanLoanRatePeriod(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan loan rate period: %w", err)
+		}
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan rate period rows: %w", err)
+	}
+
+	return periods, nil
+}
+
+func (r *LoanSqliteRepository) DeleteRatePeriod(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM loan_rate_periods WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete loan rate period: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("loan rate period not found")
+	}
+
+	return nil
+}
+
+func scanLoan(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Loan, error) {
+	var accountID, projectID string
+	var loan models.Loan
+
+	err := scanner.Scan(&accountID, &projectID, &loan.Principal, &loan.AnnualRate, &loan.TermMonths, &loan.PaymentDay, &loan.StartDate, &loan.CreatedAt, &loan.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("loan not found")
+		}
+		return nil, fmt.Errorf("failed to scan loan row: %w", err)
+	}
+
+	if loan.AccountID, err = uuid.Parse(accountID); err != nil {
+		return nil, fmt.Errorf("invalid account ID: %w", err)
+	}
+
+	if loan.ProjectID, err = uuid.Parse(projectID); err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	return &loan, nil
+}
+
+func scanLoanRatePeriod(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.LoanRatePeriod, error) {
+	var id, accountID string
+	var period models.LoanRatePeriod
+
+	err := scanner.Scan(&id, &accountID, &period.StartsOn, &period.AnnualRate, &period.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan loan rate period row: %w", err)
+	}
+
+	if period.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid loan rate period ID: %w", err)
+	}
+
+	if period.AccountID, err = uuid.Parse(accountID); err != nil {
+		return nil, fmt.Errorf("invalid account ID: %w", err)
+	}
+
+	return &period, nil
+}
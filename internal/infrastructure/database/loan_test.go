@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+)
+
+func TestLoanSqliteRepository(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewLoanSqliteRepository(db.GetConnection(), metrics.NewNoop())
+
+	start := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+	account := models.NewAccount(uuid.New(), "Mortgage", "PLN")
+	loan := models.NewLoan(account, 250000, 7.25, 360, 10, start)
+	if err := repo.Create(ctx, loan); err != nil {
+		t.Fatalf("Failed to create loan: %v", err)
+	}
+
+	stored, err := repo.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("Failed to get loan: %v", err)
+	}
+	if stored.ProjectID != account.ProjectID || stored.Principal != 250000 || stored.AnnualRate != 7.25 ||
+		stored.TermMonths != 360 || stored.PaymentDay != 10 || !stored.StartDate.Equal(start) {
+		t.Errorf("Expected the loan to round-trip, got %+v", stored)
+	}
+
+	loans, err := repo.GetByProjectID(ctx, account.ProjectID)
+	if err != nil || len(loans) != 1 {
+		t.Fatalf("Expected one project loan, got %v (%v)", loans, err)
+	}
+
+	if _, err := repo.GetByAccountID(ctx, uuid.New()); err == nil {
+		t.Error("Expected an error for an unknown loan")
+	}
+
+	later := models.NewLoanRatePeriod(account.ID, start.AddDate(2, 0, 0), 6.5)
+	earlier := models.NewLoanRatePeriod(account.ID, start.AddDate(1, 0, 0), 8)
+	for _, period := range []*models.LoanRatePeriod{later, earlier} {
+		if err := repo.AddRatePeriod(ctx, period); err != nil {
+			t.Fatalf("Failed to add rate period: %v", err)
+		}
+	}
+
+	periods, err := repo.GetRatePeriods(ctx, account.ID)
+	if err != nil {
+		t.Fatalf("Failed to get rate periods: %v", err)
+	}
+	if len(periods) != 2 || periods[0].ID != earlier.ID || periods[1].AnnualRate != 6.5 {
+		t.Fatalf("Expected rate periods ordered by start, got %+v", periods)
+	}
+
+	if err := repo.DeleteRatePeriod(ctx, earlier.ID); err != nil {
+		t.Fatalf("Failed to delete rate period: %v", err)
+	}
+	if err := repo.DeleteRatePeriod(ctx, earlier.ID); err == nil {
+		t.Error("Expected an error deleting a missing rate period")
+	}
+
+	periods, _ = repo.GetRatePeriods(ctx, account.ID)
+	if len(periods) != 1 || periods[0].ID != later.ID {
+		t.Errorf("Expected only the later period left, got %+v", periods)
+	}
+}
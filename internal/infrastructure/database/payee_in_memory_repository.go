@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type PayeeInMemoryRepository struct {
+	payees  map[string]*models.Payee
+	aliases map[string]*models.PayeeAlias
+	mu      sync.RWMutex
+}
+
+func NewPayeeInMemoryRepository() *PayeeInMemoryRepository {
+	return &PayeeInMemoryRepository{
+		payees:  make(map[string]*models.Payee),
+		aliases: make(map[string]*models.PayeeAlias),
+	}
+}
+
+func (r *PayeeInMemoryRepository) Create(ctx context.Context, payee *models.Payee) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := payee.ID.String()
+	if _, exists := r.payees[key]; exists {
+		return fmt.Errorf("payee with ID '%s' already exists", key)
+	}
+
+	r.payees[key] = payee
+	return nil
+}
+
+func (r *PayeeInMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Payee, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	payee, exists := r.payees[id.String()]
+	if !exists {
+		return nil, fmt.Errorf("payee not found")
+	}
+
+	return payee, nil
+}
+
+func (r *PayeeInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Payee, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var payees []*models.Payee
+	for _, payee := range r.payees {
+		if payee.ProjectID == projectID {
+			payees = append(payees, payee)
+		}
+	}
+
+	sort.Slice(payees, func(i, j int) bool {
+		return strings.ToLower(payees[i].Name) < strings.ToLower(payees[j].Name)
+	})
+
+	return payees, nil
+}
+
+func (r *PayeeInMemoryRepository) ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, payee := range r.payees {
+		if payee.ProjectID == projectID && strings.EqualFold(payee.Name, name) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *PayeeInMemoryRepository) UpdateDefaultCategory(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	payee, exists := r.payees[id.String()]
+	if !exists {
+		return fmt.Errorf("payee not found")
+	}
+
+	payee.DefaultCategoryID = categoryID
+	payee.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *PayeeInMemoryRepository) AddAlias(ctx context.Context, alias *models.PayeeAlias) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.aliases {
+		if existing.ProjectID == alias.ProjectID && existing.Alias == alias.Alias {
+			return fmt.Errorf("payee alias '%s' already exists", alias.Alias)
+		}
+	}
+
+	r.aliases[alias.ID.String()] = alias
+	return nil
+}
+
+func (r *PayeeInMemoryRepository) GetAliasesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.PayeeAlias, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var aliases []*models.PayeeAlias
+	for _, alias := range r.aliases {
+		if alias.ProjectID == projectID {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	sort.Slice(aliases, func(i, j int) bool {
+		return aliases[i].Alias < aliases[j].Alias
+	})
+
+	return aliases, nil
+}
+
+func (r *PayeeInMemoryRepository) DeleteAlias(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := id.String()
+	if _, exists := r.aliases[key]; !exists {
+		return fmt.Errorf("payee alias not found")
+	}
+
+	delete(r.aliases, key)
+	return nil
+}
+
+func (r *PayeeInMemoryRepository) Merge(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.payees[sourceID.String()]; !exists {
+		return fmt.Errorf("payee not found")
+	}
+
+	target, exists := r.payees[targetID.String()]
+	if !exists {
+		return fmt.Errorf("payee not found")
+	}
+
+	for _, alias := range r.aliases {
+		if alias.PayeeID == sourceID {
+			alias.PayeeID = targetID
+		}
+	}
+
+	delete(r.payees, sourceID.String())
+	target.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,299 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type PayeeSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewPayeeSqliteRepository(db *sql.DB, observer QueryObserver) *PayeeSqliteRepository {
+	return &PayeeSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *PayeeSqliteRepository) Create(ctx context.Context, payee *models.Payee) error {
+	query := `
+		INSERT INTO payees (id, project_id, name, default_category_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		payee.ID.String(),
+		payee.ProjectID.String(),
+		payee.Name,
+		nullableUUID(payee.DefaultCategoryID),
+		payee.CreatedAt,
+		payee.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create payee: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PayeeSqliteRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Payee, error) {
+	query := `
+		SELECT id, project_id, name, default_category_id, created_at, updated_at
+		FROM payees
+		WHERE id = ?
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanPayee(row)
+}
+
+func (r *PayeeSqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Payee, error) {
+	query := `
+		SELECT id, project_id, name, default_category_id, created_at, updated_at
+		FROM payees
+		WHERE project_id = ?
+		ORDER BY name COLLATE NOCASE ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payees by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var payees []*models.Payee
+	for rows.Next() {
+		payee, err := scanPayee(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payee: %w", err)
+		}
+		payees = append(payees, payee)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payee rows: %w", err)
+	}
+
+	return payees, nil
+}
+
+func (r *PayeeSqliteRepository) ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error) {
+	query := `SELECT COUNT(*) FROM payees WHERE project_id = ? AND name = ? COLLATE NOCASE`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, projectID.String(), name).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check payee existence: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *PayeeSqliteRepository) UpdateDefaultCategory(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID) error {
+	query := `UPDATE payees SET default_category_id = ?, updated_at = ? WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, nullableUUID(categoryID), time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to update payee default category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("payee not found")
+	}
+
+	return nil
+}
+
+func (r *PayeeSqliteRepository) AddAlias(ctx context.Context, alias *models.PayeeAlias) error {
+	query := `
+		INSERT INTO payee_aliases (id, payee_id, project_id, alias, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		alias.ID.String(),
+		alias.PayeeID.String(),
+		alias.ProjectID.String(),
+		alias.Alias,
+		alias.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create payee alias: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PayeeSqliteRepository) GetAliasesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.PayeeAlias, error) {
+	query := `
+		SELECT id, payee_id, project_id, alias, created_at
+		FROM payee_aliases
+		WHERE project_id = ?
+		ORDER BY alias ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payee aliases by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []*models.PayeeAlias
+	for rows.Next() {
+		alias, err := scanPayeeAlias(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payee alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payee alias rows: %w", err)
+	}
+
+	return aliases, nil
+}
+
+func (r *PayeeSqliteRepository) DeleteAlias(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM payee_aliases WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete payee alias: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("payee alias not found")
+	}
+
+	return nil
+}
+
+func (r *PayeeSqliteRepository) Merge(ctx context.Context, sourceID, targetID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE payee_aliases SET payee_id = ? WHERE payee_id = ?`, targetID.String(), sourceID.String()); err != nil {
+		return fmt.Errorf("failed to move payee aliases: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM payees WHERE id = ?`, sourceID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete merged payee: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("payee not found")
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE payees SET updated_at = ? WHERE id = ?`, time.Now(), targetID.String()); err != nil {
+		return fmt.Errorf("failed to update payee: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit payee merge: %w", err)
+	}
+
+	return nil
+}
+
+func scanPayee(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Payee, error) {
+	var id, projectID, name string
+	var defaultCategoryIDStr sql.NullString
+	var createdAt, updatedAt time.Time
+
+	err := scanner.Scan(&id, &projectID, &name, &defaultCategoryIDStr, &createdAt, &updatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("payee not found")
+		}
+		return nil, fmt.Errorf("failed to scan payee row: %w", err)
+	}
+
+	payeeID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payee ID: %w", err)
+	}
+
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	var defaultCategoryID *uuid.UUID
+	if defaultCategoryIDStr.Valid && defaultCategoryIDStr.String != "" {
+		categoryUUID, err := uuid.Parse(defaultCategoryIDStr.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category ID: %w", err)
+		}
+		defaultCategoryID = &categoryUUID
+	}
+
+	return &models.Payee{
+		ID:                payeeID,
+		ProjectID:         projectUUID,
+		Name:              name,
+		DefaultCategoryID: defaultCategoryID,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}, nil
+}
+
+func scanPayeeAlias(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.PayeeAlias, error) {
+	var id, payeeID, projectID, alias string
+	var createdAt time.Time
+
+	if err := scanner.Scan(&id, &payeeID, &projectID, &alias, &createdAt); err != nil {
+		return nil, fmt.Errorf("failed to scan payee alias row: %w", err)
+	}
+
+	aliasID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payee alias ID: %w", err)
+	}
+
+	payeeUUID, err := uuid.Parse(payeeID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payee ID: %w", err)
+	}
+
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	return &models.PayeeAlias{
+		ID:        aliasID,
+		PayeeID:   payeeUUID,
+		ProjectID: projectUUID,
+		Alias:     alias,
+		CreatedAt: createdAt,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+)
+
+func TestPayeeSqliteRepository(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	recorder := metrics.NewNoop()
+	repo := NewPayeeSqliteRepository(db.GetConnection(), recorder)
+	accountRepo := NewAccountSqliteRepository(db.GetConnection(), recorder)
+	transactionRepo := NewTransactionSqliteRepository(db.GetConnection(), recorder)
+
+	projectID := uuid.New()
+	categoryID := uuid.New()
+	target := models.NewPayee(projectID, "Biedronka", &categoryID)
+	source := models.NewPayee(projectID, "JMP", nil)
+	for _, payee := range []*models.Payee{target, source} {
+		if err := repo.Create(ctx, payee); err != nil {
+			t.Fatalf("Failed to create payee: %v", err)
+		}
+	}
+
+	if exists, err := repo.ExistsByName(ctx, projectID, "BIEDRONKA"); err != nil || !exists {
+		t.Errorf("Expected payee names to be compared without case, got %v (%v)", exists, err)
+	}
+
+	if err := repo.AddAlias(ctx, models.NewPayeeAlias(target, "biedronka")); err != nil {
+		t.Fatalf("Failed to add alias: %v", err)
+	}
+	if err := repo.AddAlias(ctx, models.NewPayeeAlias(source, "jmp")); err != nil {
+		t.Fatalf("Failed to add alias: %v", err)
+	}
+	if err := repo.AddAlias(ctx, models.NewPayeeAlias(source, "biedronka")); err == nil {
+		t.Error("Expected an alias to be unique within a project")
+	}
+
+	stored, err := repo.GetByID(ctx, target.ID)
+	if err != nil {
+		t.Fatalf("Failed to get payee: %v", err)
+	}
+	if stored.DefaultCategoryID == nil || *stored.DefaultCategoryID != categoryID {
+		t.Errorf("Expected the default category to round-trip, got %v", stored.DefaultCategoryID)
+	}
+
+	account := models.NewAccount(projectID, "Main", "PLN")
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+	transaction := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: 10, Name: "JMP 12", Type: models.Debit, PayeeID: &source.ID})
+	if err := transactionRepo.Create(ctx, transaction); err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	if err := transactionRepo.ReassignPayee(ctx, source.ID, target.ID); err != nil {
+		t.Fatalf("Failed to reassign payee: %v", err)
+	}
+	if err := repo.Merge(ctx, source.ID, target.ID); err != nil {
+		t.Fatalf("Failed to merge payees: %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, source.ID); err == nil {
+		t.Error("Expected the merged payee to be deleted")
+	}
+
+	aliases, err := repo.GetAliasesByProjectID(ctx, projectID)
+	if err != nil {
+		t.Fatalf("Failed to get aliases: %v", err)
+	}
+	if len(aliases) != 2 || aliases[0].PayeeID != target.ID || aliases[1].PayeeID != target.ID {
+		t.Errorf("Expected every alias to move to the target, got %+v", aliases)
+	}
+
+	history, err := transactionRepo.GetTransactionsWithFilters(ctx, models.TransactionQuery{ProjectID: &projectID, PayeeID: &target.ID})
+	if err != nil {
+		t.Fatalf("Failed to filter by payee: %v", err)
+	}
+	if len(history) != 1 || history[0].PayeeID == nil || *history[0].PayeeID != target.ID {
+		t.Errorf("Expected the transaction under the target payee, got %+v", history)
+	}
+
+	if err := repo.DeleteAlias(ctx, aliases[0].ID); err != nil {
+		t.Errorf("Failed to delete alias: %v", err)
+	}
+	if err := repo.DeleteAlias(ctx, aliases[0].ID); err == nil {
+		t.Error("Expected error when deleting a missing alias")
+	}
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type PeriodLockEventInMemoryRepository struct {
+	events map[string]*models.PeriodLockEvent
+	mu     sync.RWMutex
+}
+
+func NewPeriodLockEventInMemoryRepository() *PeriodLockEventInMemoryRepository {
+	return &PeriodLockEventInMemoryRepository{
+		events: make(map[string]*models.PeriodLockEvent),
+	}
+}
+
+func (r *PeriodLockEventInMemoryRepository) Create(ctx context.Context, event *models.PeriodLockEvent) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := event.ID.String()
+	if _, exists := r.events[key]; exists {
+		return fmt.Errorf("period lock event with ID '%s' already exists", key)
+	}
+
+	r.events[key] = event
+	return nil
+}
+
+func (r *PeriodLockEventInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.PeriodLockEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var events []*models.PeriodLockEvent
+	for _, event := range r.events {
+		if event.ProjectID == projectID {
+			events = append(events, event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].CreatedAt.After(events[j].CreatedAt)
+	})
+
+	return events, nil
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type PeriodLockEventPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewPeriodLockEventPostgresRepository(db *sql.DB, observer QueryObserver) *PeriodLockEventPostgresRepository {
+	return &PeriodLockEventPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *PeriodLockEventPostgresRepository) Create(ctx context.Context, event *models.PeriodLockEvent) error {
+	query := `
+		INSERT INTO period_lock_events (` + periodLockEventColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		event.ID.String(),
+		event.ProjectID.String(),
+		event.Action.String(),
+		event.LockedUntil,
+		event.PreviousLockedUntil,
+		event.Actor,
+		event.Reason,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create period lock event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PeriodLockEventPostgresRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.PeriodLockEvent, error) {
+	query := `
+		SELECT ` + periodLockEventColumns + `
+		FROM period_lock_events
+		WHERE project_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get period lock events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.PeriodLockEvent
+	for rows.Next() {
+		event, err := scanPeriodLockEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
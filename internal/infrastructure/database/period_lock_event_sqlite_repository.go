@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+const periodLockEventColumns = "id, project_id, action, locked_until, previous_locked_until, actor, reason, created_at"
+
+type PeriodLockEventSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewPeriodLockEventSqliteRepository(db *sql.DB, observer QueryObserver) *PeriodLockEventSqliteRepository {
+	return &PeriodLockEventSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *PeriodLockEventSqliteRepository) Create(ctx context.Context, event *models.PeriodLockEvent) error {
+	query := `
+		INSERT INTO period_lock_events (` + periodLockEventColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		event.ID.String(),
+		event.ProjectID.String(),
+		event.Action.String(),
+		event.LockedUntil,
+		event.PreviousLockedUntil,
+		event.Actor,
+		event.Reason,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create period lock event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PeriodLockEventSqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.PeriodLockEvent, error) {
+	query := `
+		SELECT ` + periodLockEventColumns + `
+		FROM period_lock_events
+		WHERE project_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get period lock events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.PeriodLockEvent
+	for rows.Next() {
+		event, err := scanPeriodLockEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func scanPeriodLockEvent(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.PeriodLockEvent, error) {
+	var id, projectID, action, actor, reason string
+	var lockedUntil, previousLockedUntil sql.NullTime
+	var createdAt time.Time
+
+	err := scanner.Scan(&id, &projectID, &action, &lockedUntil, &previousLockedUntil, &actor, &reason, &createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan period lock event row: %w", err)
+	}
+
+	eventID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid period lock event ID: %w", err)
+	}
+
+	projectUUID, err := uuid.Parse(projectID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	event := &models.PeriodLockEvent{
+		ID:        eventID,
+		ProjectID: projectUUID,
+		Action:    models.PeriodLockAction(action),
+		Actor:     actor,
+		Reason:    reason,
+		CreatedAt: createdAt,
+	}
+	if lockedUntil.Valid {
+		until := lockedUntil.Time
+		event.LockedUntil = &until
+	}
+	if previousLockedUntil.Valid {
+		previous := previousLockedUntil.Time
+		event.PreviousLockedUntil = &previous
+	}
+
+	return event, nil
+}
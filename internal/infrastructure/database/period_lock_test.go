@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+)
+
+func TestPeriodLockSqliteRepositories(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	projectRepo := NewProjectSqliteRepository(db.GetConnection(), metrics.NewNoop())
+	eventRepo := NewPeriodLockEventSqliteRepository(db.GetConnection(), metrics.NewNoop())
+
+	project := models.NewProject("Test Project", "test-project")
+	if err := projectRepo.Create(ctx, project); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	stored, err := projectRepo.GetBySlug(ctx, project.Slug)
+	if err != nil {
+		t.Fatalf("Failed to get project: %v", err)
+	}
+	if stored.LockedUntil != nil {
+		t.Errorf("Expected a new project to have every period open, got %v", stored.LockedUntil)
+	}
+
+	march := models.MonthEnd(2024, time.March)
+	project.LockedUntil = &march
+	if err := projectRepo.Update(ctx, project); err != nil {
+		t.Fatalf("Failed to update project: %v", err)
+	}
+
+	stored, err = projectRepo.GetByID(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("Failed to get project: %v", err)
+	}
+	if stored.LockedUntil == nil || !stored.LockedUntil.Equal(march) {
+		t.Errorf("Expected the books closed up to %v, got %v", march, stored.LockedUntil)
+	}
+
+	closed := models.NewPeriodLockEvent(project, models.PeriodClosed, nil, "Anna", "")
+	if err := eventRepo.Create(ctx, closed); err != nil {
+		t.Fatalf("Failed to create period lock event: %v", err)
+	}
+
+	project.LockedUntil = nil
+	reopened := models.NewPeriodLockEvent(project, models.PeriodReopened, &march, "Admin", "Late invoice")
+	reopened.CreatedAt = closed.CreatedAt.Add(time.Minute)
+	if err := eventRepo.Create(ctx, reopened); err != nil {
+		t.Fatalf("Failed to create period lock event: %v", err)
+	}
+
+	events, err := eventRepo.GetByProjectID(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("Failed to get period lock events: %v", err)
+	}
+	if len(events) != 2 || events[0].ID != reopened.ID || events[1].ID != closed.ID {
+		t.Fatalf("Expected both events newest first, got %+v", events)
+	}
+	if events[0].LockedUntil != nil || !events[0].PreviousLockedUntil.Equal(march) || events[0].Reason != "Late invoice" || events[0].Action != models.PeriodReopened {
+		t.Errorf("Expected the reopen to be stored as created, got %+v", events[0])
+	}
+	if !events[1].LockedUntil.Equal(march) || events[1].PreviousLockedUntil != nil || events[1].Actor != "Anna" {
+		t.Errorf("Expected the close to be stored as created, got %+v", events[1])
+	}
+}
@@ -0,0 +1,392 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// PostgresSchemaVersion is stored in the schema_version table once migrate has
+// run. Bump it whenever a migration is added.
+const PostgresSchemaVersion = 5
+
+// postgresMigrationLock is the advisory lock key held while migrating, so
+// instances starting together do not race on the schema.
+const postgresMigrationLock = 0x676f66696e
+
+// PostgresDB holds every repository table of the postgres driver, so any
+// number of instances can share one database.
+type PostgresDB struct {
+	conn *sql.DB
+}
+
+func NewPostgresDB(dsn string) (*PostgresDB, error) {
+	conn, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	db := &PostgresDB{conn: conn}
+	if err := db.migrate(context.Background()); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return db, nil
+}
+
+func (db *PostgresDB) migrate(ctx context.Context) error {
+	queries := []string{
+		`
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS projects (
+			id UUID PRIMARY KEY,
+			slug TEXT UNIQUE NOT NULL,
+			name TEXT NOT NULL,
+			require_two_factor BOOLEAN NOT NULL DEFAULT FALSE,
+			locked_until TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS access (
+			id UUID PRIMARY KEY,
+			project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+			uid TEXT NOT NULL,
+			pin_hash TEXT NOT NULL,
+			name TEXT NOT NULL,
+			readonly BOOLEAN NOT NULL DEFAULT FALSE,
+			totp_secret TEXT NOT NULL DEFAULT '',
+			totp_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+			totp_last_counter BIGINT NOT NULL DEFAULT 0,
+			two_factor_failures INTEGER NOT NULL DEFAULT 0,
+			two_factor_failed_at TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			UNIQUE (project_id, uid)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS accounts (
+			id UUID PRIMARY KEY,
+			project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			currency TEXT NOT NULL,
+			type TEXT NOT NULL DEFAULT 'checking',
+			description TEXT NOT NULL DEFAULT '',
+			position INTEGER NOT NULL DEFAULT 0,
+			archived_at TIMESTAMPTZ,
+			credit_limit DOUBLE PRECISION NOT NULL DEFAULT 0,
+			statement_day INTEGER NOT NULL DEFAULT 0,
+			due_day INTEGER NOT NULL DEFAULT 0,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			UNIQUE (project_id, name)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS transactions (
+			id UUID PRIMARY KEY,
+			account_id UUID NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+			value DOUBLE PRECISION NOT NULL,
+			name TEXT NOT NULL,
+			transaction_date TIMESTAMPTZ NOT NULL,
+			type TEXT NOT NULL,
+			notes TEXT NOT NULL DEFAULT '',
+			category_id UUID,
+			payee_id UUID,
+			group_id UUID,
+			status TEXT NOT NULL DEFAULT 'uncleared',
+			external_id TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS recovery_codes (
+			id UUID PRIMARY KEY,
+			access_id UUID NOT NULL REFERENCES access (id) ON DELETE CASCADE,
+			code_hash TEXT NOT NULL,
+			used_at TIMESTAMPTZ,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS attachments (
+			id UUID PRIMARY KEY,
+			transaction_id UUID NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+			file_name TEXT NOT NULL,
+			content_type TEXT NOT NULL,
+			size BIGINT NOT NULL,
+			checksum TEXT NOT NULL,
+			has_thumbnail BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS categories (
+			id UUID PRIMARY KEY,
+			project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS transaction_splits (
+			id UUID PRIMARY KEY,
+			transaction_id UUID NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+			category_id UUID NOT NULL REFERENCES categories (id),
+			amount DOUBLE PRECISION NOT NULL,
+			memo TEXT NOT NULL DEFAULT '',
+			position INTEGER NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS shared_expenses (
+			id UUID PRIMARY KEY,
+			project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+			transaction_id UUID NOT NULL UNIQUE REFERENCES transactions (id) ON DELETE CASCADE,
+			payer_id UUID NOT NULL REFERENCES access (id),
+			method TEXT NOT NULL CHECK (method IN ('equal', 'percentage', 'exact')),
+			amount DOUBLE PRECISION NOT NULL,
+			currency TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS expense_shares (
+			id UUID PRIMARY KEY,
+			expense_id UUID NOT NULL REFERENCES shared_expenses (id) ON DELETE CASCADE,
+			access_id UUID NOT NULL REFERENCES access (id),
+			amount DOUBLE PRECISION NOT NULL,
+			percentage DOUBLE PRECISION NOT NULL DEFAULT 0,
+			position INTEGER NOT NULL DEFAULT 0
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS settlements (
+			id UUID PRIMARY KEY,
+			project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+			from_access_id UUID NOT NULL REFERENCES access (id),
+			to_access_id UUID NOT NULL REFERENCES access (id),
+			amount DOUBLE PRECISION NOT NULL,
+			currency TEXT NOT NULL,
+			group_id UUID,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS account_transfers (
+			id UUID PRIMARY KEY,
+			project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+			from_transaction_id UUID NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+			to_transaction_id UUID NOT NULL REFERENCES transactions (id) ON DELETE CASCADE,
+			amount DOUBLE PRECISION NOT NULL,
+			group_id UUID NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS payees (
+			id UUID PRIMARY KEY,
+			project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			default_category_id UUID REFERENCES categories (id),
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS payee_aliases (
+			id UUID PRIMARY KEY,
+			payee_id UUID NOT NULL REFERENCES payees (id) ON DELETE CASCADE,
+			project_id UUID NOT NULL,
+			alias TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			UNIQUE (project_id, alias)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS loans (
+			account_id UUID PRIMARY KEY REFERENCES accounts (id) ON DELETE CASCADE,
+			project_id UUID NOT NULL,
+			principal DOUBLE PRECISION NOT NULL,
+			annual_rate DOUBLE PRECISION NOT NULL,
+			term_months INTEGER NOT NULL,
+			payment_day INTEGER NOT NULL,
+			start_date TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS loan_rate_periods (
+			id UUID PRIMARY KEY,
+			account_id UUID NOT NULL REFERENCES loans (account_id) ON DELETE CASCADE,
+			starts_on TIMESTAMPTZ NOT NULL,
+			annual_rate DOUBLE PRECISION NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS securities (
+			id UUID PRIMARY KEY,
+			project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+			ticker TEXT NOT NULL,
+			name TEXT NOT NULL DEFAULT '',
+			currency TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			UNIQUE (project_id, ticker)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS security_prices (
+			security_id UUID NOT NULL REFERENCES securities (id) ON DELETE CASCADE,
+			date TIMESTAMPTZ NOT NULL,
+			price DOUBLE PRECISION NOT NULL,
+			updated_at TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (security_id, date)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS investment_operations (
+			id UUID PRIMARY KEY,
+			account_id UUID NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+			security_id UUID NOT NULL REFERENCES securities (id),
+			type TEXT NOT NULL CHECK (type IN ('buy', 'sell', 'dividend')),
+			quantity DOUBLE PRECISION NOT NULL,
+			price DOUBLE PRECISION NOT NULL,
+			fees DOUBLE PRECISION NOT NULL,
+			amount DOUBLE PRECISION NOT NULL,
+			date TIMESTAMPTZ NOT NULL,
+			transaction_id UUID NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS reconciliations (
+			id UUID PRIMARY KEY,
+			account_id UUID NOT NULL REFERENCES accounts (id) ON DELETE CASCADE,
+			statement_date TIMESTAMPTZ NOT NULL,
+			statement_balance DOUBLE PRECISION NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			finished_at TIMESTAMPTZ
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS period_lock_events (
+			id UUID PRIMARY KEY,
+			project_id UUID NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+			action TEXT NOT NULL,
+			locked_until TIMESTAMPTZ,
+			previous_locked_until TIMESTAMPTZ,
+			actor TEXT NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMPTZ NOT NULL
+		);
+		`,
+		`DROP TABLE IF EXISTS sqlite_pairing;`,
+		`ALTER TABLE access ADD COLUMN IF NOT EXISTS totp_last_counter BIGINT NOT NULL DEFAULT 0;`,
+		`ALTER TABLE access ADD COLUMN IF NOT EXISTS two_factor_failures INTEGER NOT NULL DEFAULT 0;`,
+		`ALTER TABLE access ADD COLUMN IF NOT EXISTS two_factor_failed_at TIMESTAMPTZ;`,
+		`CREATE INDEX IF NOT EXISTS idx_transactions_account_date ON transactions (account_id, transaction_date);`,
+		`CREATE INDEX IF NOT EXISTS idx_transactions_group_id ON transactions (group_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_transactions_payee_id ON transactions (payee_id);`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_external_id ON transactions (account_id, external_id) WHERE external_id != '';`,
+		`CREATE INDEX IF NOT EXISTS idx_recovery_codes_access_id ON recovery_codes (access_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_attachments_transaction_id ON attachments (transaction_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_attachments_checksum ON attachments (checksum);`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_project_name ON categories (project_id, lower(name));`,
+		`CREATE INDEX IF NOT EXISTS idx_transaction_splits_transaction_id ON transaction_splits (transaction_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_shared_expenses_project_id ON shared_expenses (project_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_expense_shares_expense_id ON expense_shares (expense_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_settlements_project_id ON settlements (project_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_settlements_group_id ON settlements (group_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_account_transfers_project_id ON account_transfers (project_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_account_transfers_group_id ON account_transfers (group_id);`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_payees_project_name ON payees (project_id, lower(name));`,
+		`CREATE INDEX IF NOT EXISTS idx_payee_aliases_payee_id ON payee_aliases (payee_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_loans_project_id ON loans (project_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_loan_rate_periods_account_id ON loan_rate_periods (account_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_investment_operations_account_id ON investment_operations (account_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_reconciliations_account_id ON reconciliations (account_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_period_lock_events_project_id ON period_lock_events (project_id);`,
+	}
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", postgresMigrationLock); err != nil {
+		return fmt.Errorf("failed to lock schema: %w", err)
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to execute migration query: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_version"); err != nil {
+		return fmt.Errorf("failed to clear schema version: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_version (version) VALUES ($1)", PostgresSchemaVersion); err != nil {
+		return fmt.Errorf("failed to set schema version: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+
+	slog.Info("postgres database migrated", slog.Int("schema_version", PostgresSchemaVersion))
+	return nil
+}
+
+func (db *PostgresDB) Ping(ctx context.Context) error {
+	return db.conn.PingContext(ctx)
+}
+
+// CheckSchema reports an error when the database was not migrated to
+// PostgresSchemaVersion.
+func (db *PostgresDB) CheckSchema(ctx context.Context) error {
+	var version int
+	if err := db.conn.QueryRowContext(ctx, "SELECT version FROM schema_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if version != PostgresSchemaVersion {
+		return fmt.Errorf("schema version %d, expected %d", version, PostgresSchemaVersion)
+	}
+
+	return nil
+}
+
+// SnapshotInto is not supported; back PostgreSQL up with its own tools, such
+// as pg_dump.
+func (db *PostgresDB) SnapshotInto(ctx context.Context, path string) error {
+	return fmt.Errorf("a postgres database cannot be snapshotted, use pg_dump")
+}
+
+func (db *PostgresDB) Close() error {
+	return db.conn.Close()
+}
+
+func (db *PostgresDB) GetConnection() *sql.DB {
+	return db.conn
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -19,7 +20,11 @@ func NewProjectInMemoryRepository() models.ProjectRepository {
 	}
 }
 
-func (r *ProjectInMemoryRepository) Create(project *models.Project) error {
+func (r *ProjectInMemoryRepository) Create(ctx context.Context, project *models.Project) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -31,7 +36,11 @@ func (r *ProjectInMemoryRepository) Create(project *models.Project) error {
 	return nil
 }
 
-func (r *ProjectInMemoryRepository) GetBySlug(slug string) (*models.Project, error) {
+func (r *ProjectInMemoryRepository) GetBySlug(ctx context.Context, slug string) (*models.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -43,7 +52,11 @@ func (r *ProjectInMemoryRepository) GetBySlug(slug string) (*models.Project, err
 	return project, nil
 }
 
-func (r *ProjectInMemoryRepository) ExistsBySlug(slug string) (bool, error) {
+func (r *ProjectInMemoryRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -51,7 +64,11 @@ func (r *ProjectInMemoryRepository) ExistsBySlug(slug string) (bool, error) {
 	return exists, nil
 }
 
-func (r *ProjectInMemoryRepository) GetByID(id uuid.UUID) (*models.Project, error) {
+func (r *ProjectInMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -63,3 +80,22 @@ func (r *ProjectInMemoryRepository) GetByID(id uuid.UUID) (*models.Project, erro
 
 	return nil, fmt.Errorf("project with ID '%s' not found", id.String())
 }
+
+func (r *ProjectInMemoryRepository) Update(ctx context.Context, project *models.Project) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for slug, existing := range r.projects {
+		if existing.ID == project.ID {
+			delete(r.projects, slug)
+			r.projects[project.Slug] = project
+			return nil
+		}
+	}
+
+	return fmt.Errorf("project with ID '%s' not found", project.ID.String())
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type ProjectPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewProjectPostgresRepository(db *sql.DB, observer QueryObserver) models.ProjectRepository {
+	return &ProjectPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *ProjectPostgresRepository) Create(ctx context.Context, project *models.Project) error {
+	query := `
+		INSERT INTO projects (id, slug, name, require_two_factor, locked_until, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		project.ID.String(),
+		project.Slug,
+		project.Name,
+		project.RequireTwoFactor,
+		project.LockedUntil,
+		project.CreatedAt,
+		project.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProjectPostgresRepository) GetBySlug(ctx context.Context, slug string) (*models.Project, error) {
+	query := `
+		SELECT id, slug, name, require_two_factor, locked_until, created_at, updated_at
+		FROM projects
+		WHERE slug = $1
+	`
+
+	var project models.Project
+	var idStr string
+	var lockedUntil sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(
+		&idStr,
+		&project.Slug,
+		&project.Name,
+		&project.RequireTwoFactor,
+		&lockedUntil,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("project not found")
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	project.ID, err = uuid.Parse(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse project ID: %w", err)
+	}
+
+	if lockedUntil.Valid {
+		project.LockedUntil = &lockedUntil.Time
+	}
+
+	return &project, nil
+}
+
+func (r *ProjectPostgresRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	query := `SELECT COUNT(*) FROM projects WHERE slug = $1`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check project existence: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *ProjectPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	query := `SELECT id, slug, name, require_two_factor, locked_until, created_at, updated_at FROM projects WHERE id = $1`
+	row := r.db.QueryRowContext(ctx, query, id.String())
+
+	var project models.Project
+	var lockedUntil sql.NullTime
+	err := row.Scan(&project.ID, &project.Slug, &project.Name, &project.RequireTwoFactor, &lockedUntil, &project.CreatedAt, &project.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("project with ID '%s' not found", id.String())
+		}
+		return nil, fmt.Errorf("failed to get project by ID: %w", err)
+	}
+
+	if lockedUntil.Valid {
+		project.LockedUntil = &lockedUntil.Time
+	}
+
+	return &project, nil
+}
+
+func (r *ProjectPostgresRepository) Update(ctx context.Context, project *models.Project) error {
+	query := `
+		UPDATE projects
+		SET slug = $1, name = $2, require_two_factor = $3, locked_until = $4, updated_at = $5
+		WHERE id = $6
+	`
+
+	result, err := r.db.ExecContext(ctx,
+		query,
+		project.Slug,
+		project.Name,
+		project.RequireTwoFactor,
+		project.LockedUntil,
+		project.UpdatedAt,
+		project.ID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found")
+	}
+
+	return nil
+}
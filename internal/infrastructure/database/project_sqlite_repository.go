@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
@@ -9,24 +10,26 @@ import (
 )
 
 type ProjectSqliteRepository struct {
-	db *sql.DB
+	db instrumentedDB
 }
 
-func NewProjectSqliteRepository(db *sql.DB) models.ProjectRepository {
-	return &ProjectSqliteRepository{db: db}
+func NewProjectSqliteRepository(db *sql.DB, observer QueryObserver) models.ProjectRepository {
+	return &ProjectSqliteRepository{db: newInstrumentedDB(db, observer)}
 }
 
-func (r *ProjectSqliteRepository) Create(project *models.Project) error {
+func (r *ProjectSqliteRepository) Create(ctx context.Context, project *models.Project) error {
 	query := `
-		INSERT INTO projects (id, slug, name, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO projects (id, slug, name, require_two_factor, locked_until, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.Exec(
+	_, err := r.db.ExecContext(ctx,
 		query,
 		project.ID.String(),
 		project.Slug,
 		project.Name,
+		project.RequireTwoFactor,
+		project.LockedUntil,
 		project.CreatedAt,
 		project.UpdatedAt,
 	)
@@ -38,20 +41,23 @@ func (r *ProjectSqliteRepository) Create(project *models.Project) error {
 	return nil
 }
 
-func (r *ProjectSqliteRepository) GetBySlug(slug string) (*models.Project, error) {
+func (r *ProjectSqliteRepository) GetBySlug(ctx context.Context, slug string) (*models.Project, error) {
 	query := `
-		SELECT id, slug, name, created_at, updated_at
+		SELECT id, slug, name, require_two_factor, locked_until, created_at, updated_at
 		FROM projects
 		WHERE slug = ?
 	`
 
 	var project models.Project
 	var idStr string
+	var lockedUntil sql.NullTime
 
-	err := r.db.QueryRow(query, slug).Scan(
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(
 		&idStr,
 		&project.Slug,
 		&project.Name,
+		&project.RequireTwoFactor,
+		&lockedUntil,
 		&project.CreatedAt,
 		&project.UpdatedAt,
 	)
@@ -68,14 +74,18 @@ func (r *ProjectSqliteRepository) GetBySlug(slug string) (*models.Project, error
 		return nil, fmt.Errorf("failed to parse project ID: %w", err)
 	}
 
+	if lockedUntil.Valid {
+		project.LockedUntil = &lockedUntil.Time
+	}
+
 	return &project, nil
 }
 
-func (r *ProjectSqliteRepository) ExistsBySlug(slug string) (bool, error) {
+func (r *ProjectSqliteRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
 	query := `SELECT COUNT(*) FROM projects WHERE slug = ?`
 
 	var count int
-	err := r.db.QueryRow(query, slug).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, slug).Scan(&count)
 	if err != nil {
 		return false, fmt.Errorf("failed to check project existence: %w", err)
 	}
@@ -83,12 +93,13 @@ func (r *ProjectSqliteRepository) ExistsBySlug(slug string) (bool, error) {
 	return count > 0, nil
 }
 
-func (r *ProjectSqliteRepository) GetByID(id uuid.UUID) (*models.Project, error) {
-	query := `SELECT id, slug, name, created_at, updated_at FROM projects WHERE id = ?`
-	row := r.db.QueryRow(query, id.String())
+func (r *ProjectSqliteRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Project, error) {
+	query := `SELECT id, slug, name, require_two_factor, locked_until, created_at, updated_at FROM projects WHERE id = ?`
+	row := r.db.QueryRowContext(ctx, query, id.String())
 
 	var project models.Project
-	err := row.Scan(&project.ID, &project.Slug, &project.Name, &project.CreatedAt, &project.UpdatedAt)
+	var lockedUntil sql.NullTime
+	err := row.Scan(&project.ID, &project.Slug, &project.Name, &project.RequireTwoFactor, &lockedUntil, &project.CreatedAt, &project.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("project with ID '%s' not found", id.String())
@@ -96,5 +107,41 @@ func (r *ProjectSqliteRepository) GetByID(id uuid.UUID) (*models.Project, error)
 		return nil, fmt.Errorf("failed to get project by ID: %w", err)
 	}
 
+	if lockedUntil.Valid {
+		project.LockedUntil = &lockedUntil.Time
+	}
+
 	return &project, nil
 }
+
+func (r *ProjectSqliteRepository) Update(ctx context.Context, project *models.Project) error {
+	query := `
+		UPDATE projects
+		SET slug = ?, name = ?, require_two_factor = ?, locked_until = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx,
+		query,
+		project.Slug,
+		project.Name,
+		project.RequireTwoFactor,
+		project.LockedUntil,
+		project.UpdatedAt,
+		project.ID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("project not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type ReconciliationInMemoryRepository struct {
+	reconciliations map[string]*models.Reconciliation
+	mu              sync.RWMutex
+}
+
+func NewReconciliationInMemoryRepository() *ReconciliationInMemoryRepository {
+	return &ReconciliationInMemoryRepository{
+		reconciliations: make(map[string]*models.Reconciliation),
+	}
+}
+
+func (r *ReconciliationInMemoryRepository) Create(ctx context.Context, reconciliation *models.Reconciliation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := reconciliation.ID.String()
+	if _, exists := r.reconciliations[key]; exists {
+		return fmt.Errorf("reconciliation with ID '%s' already exists", key)
+	}
+
+	r.reconciliations[key] = reconciliation
+	return nil
+}
+
+func (r *ReconciliationInMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Reconciliation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reconciliation, exists := r.reconciliations[id.String()]
+	if !exists {
+		return nil, fmt.Errorf("reconciliation not found")
+	}
+
+	return reconciliation, nil
+}
+
+func (r *ReconciliationInMemoryRepository) GetOpenByAccountID(ctx context.Context, accountID uuid.UUID) (*models.Reconciliation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, reconciliation := range r.reconciliations {
+		if reconciliation.AccountID == accountID && !reconciliation.IsFinished() {
+			return reconciliation, nil
+		}
+	}
+
+	return nil, fmt.Errorf("reconciliation not found")
+}
+
+func (r *ReconciliationInMemoryRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Reconciliation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var reconciliations []*models.Reconciliation
+	for _, reconciliation := range r.reconciliations {
+		if reconciliation.AccountID == accountID {
+			reconciliations = append(reconciliations, reconciliation)
+		}
+	}
+
+	sort.Slice(reconciliations, func(i, j int) bool {
+		if !reconciliations[i].StatementDate.Equal(reconciliations[j].StatementDate) {
+			return reconciliations[i].StatementDate.After(reconciliations[j].StatementDate)
+		}
+		return reconciliations[i].CreatedAt.After(reconciliations[j].CreatedAt)
+	})
+
+	return reconciliations, nil
+}
+
+func (r *ReconciliationInMemoryRepository) Finish(ctx context.Context, id uuid.UUID, finishedAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reconciliation, exists := r.reconciliations[id.String()]
+	if !exists || reconciliation.IsFinished() {
+		return fmt.Errorf("reconciliation not found")
+	}
+
+	reconciliation.FinishedAt = &finishedAt
+	return nil
+}
+
+func (r *ReconciliationInMemoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := id.String()
+	if _, exists := r.reconciliations[key]; !exists {
+		return fmt.Errorf("reconciliation not found")
+	}
+
+	delete(r.reconciliations, key)
+	return nil
+}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type ReconciliationPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewReconciliationPostgresRepository(db *sql.DB, observer QueryObserver) *ReconciliationPostgresRepository {
+	return &ReconciliationPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *ReconciliationPostgresRepository) Create(ctx context.Context, reconciliation *models.Reconciliation) error {
+	query := `
+		INSERT INTO reconciliations (` + reconciliationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		reconciliation.ID.String(),
+		reconciliation.AccountID.String(),
+		reconciliation.StatementDate,
+		reconciliation.StatementBalance,
+		reconciliation.CreatedAt,
+		reconciliation.FinishedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create reconciliation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReconciliationPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Reconciliation, error) {
+	query := `SELECT ` + reconciliationColumns + ` FROM reconciliations WHERE id = $1`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanReconciliation(row)
+}
+
+func (r *ReconciliationPostgresRepository) GetOpenByAccountID(ctx context.Context, accountID uuid.UUID) (*models.Reconciliation, error) {
+	query := `SELECT ` + reconciliationColumns + ` FROM reconciliations WHERE account_id = $1 AND finished_at IS NULL`
+
+	row := r.db.QueryRowContext(ctx, query, accountID.String())
+	return scanReconciliation(row)
+}
+
+func (r *ReconciliationPostgresRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Reconciliation, error) {
+	query := `
+		SELECT ` + reconciliationColumns + `
+		FROM reconciliations
+		WHERE account_id = $1
+		ORDER BY statement_date DESC, created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reconciliations: %w", err)
+	}
+	defer rows.Close()
+
+	var reconciliations []*models.Reconciliation
+	for rows.Next() {
+		reconciliation, err := scanReconciliation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+
+	return reconciliations, rows.Err()
+}
+
+func (r *ReconciliationPostgresRepository) Finish(ctx context.Context, id uuid.UUID, finishedAt time.Time) error {
+	query := `UPDATE reconciliations SET finished_at = $1 WHERE id = $2 AND finished_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, finishedAt, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to finish reconciliation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("reconciliation not found")
+	}
+
+	return nil
+}
+
+func (r *ReconciliationPostgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM reconciliations WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete reconciliation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("reconciliation not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+const reconciliationColumns = "id, account_id, statement_date, statement_balance, created_at, finished_at"
+
+type ReconciliationSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewReconciliationSqliteRepository(db *sql.DB, observer QueryObserver) *ReconciliationSqliteRepository {
+	return &ReconciliationSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *ReconciliationSqliteRepository) Create(ctx context.Context, reconciliation *models.Reconciliation) error {
+	query := `
+		INSERT INTO reconciliations (` + reconciliationColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		reconciliation.ID.String(),
+		reconciliation.AccountID.String(),
+		reconciliation.StatementDate,
+		reconciliation.StatementBalance,
+		reconciliation.CreatedAt,
+		reconciliation.FinishedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create reconciliation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReconciliationSqliteRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Reconciliation, error) {
+	query := `SELECT ` + reconciliationColumns + ` FROM reconciliations WHERE id = ?`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanReconciliation(row)
+}
+
+func (r *ReconciliationSqliteRepository) GetOpenByAccountID(ctx context.Context, accountID uuid.UUID) (*models.Reconciliation, error) {
+	query := `SELECT ` + reconciliationColumns + ` FROM reconciliations WHERE account_id = ? AND finished_at IS NULL`
+
+	row := r.db.QueryRowContext(ctx, query, accountID.String())
+	return scanReconciliation(row)
+}
+
+func (r *ReconciliationSqliteRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Reconciliation, error) {
+	query := `
+		SELECT ` + reconciliationColumns + `
+		FROM reconciliations
+		WHERE account_id = ?
+		ORDER BY statement_date DESC, created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reconciliations: %w", err)
+	}
+	defer rows.Close()
+
+	var reconciliations []*models.Reconciliation
+	for rows.Next() {
+		reconciliation, err := scanReconciliation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reconciliations = append(reconciliations, reconciliation)
+	}
+
+	return reconciliations, rows.Err()
+}
+
+func (r *ReconciliationSqliteRepository) Finish(ctx context.Context, id uuid.UUID, finishedAt time.Time) error {
+	query := `UPDATE reconciliations SET finished_at = ? WHERE id = ? AND finished_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, finishedAt, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to finish reconciliation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("reconciliation not found")
+	}
+
+	return nil
+}
+
+func (r *ReconciliationSqliteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM reconciliations WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete reconciliation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("reconciliation not found")
+	}
+
+	return nil
+}
+
+func scanReconciliation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Reconciliation, error) {
+	var id, accountID string
+	var statementDate, createdAt time.Time
+	var statementBalance float64
+	var finishedAt sql.NullTime
+
+	err := scanner.Scan(&id, &accountID, &statementDate, &statementBalance, &createdAt, &finishedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("reconciliation not found")
+		}
+		return nil, fmt.Errorf("failed to scan reconciliation row: %w", err)
+	}
+
+	reconciliationID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reconciliation ID: %w", err)
+	}
+
+	accountUUID, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account ID: %w", err)
+	}
+
+	reconciliation := &models.Reconciliation{
+		ID:               reconciliationID,
+		AccountID:        accountUUID,
+		StatementDate:    statementDate,
+		StatementBalance: statementBalance,
+		CreatedAt:        createdAt,
+	}
+	if finishedAt.Valid {
+		finished := finishedAt.Time
+		reconciliation.FinishedAt = &finished
+	}
+
+	return reconciliation, nil
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+)
+
+func TestReconciliationSqliteRepository(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewReconciliationSqliteRepository(db.GetConnection(), metrics.NewNoop())
+
+	accountID := uuid.New()
+	january := models.NewReconciliation(accountID, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), 1200.5)
+	february := models.NewReconciliation(accountID, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), 980)
+	for _, reconciliation := range []*models.Reconciliation{january, february} {
+		if err := repo.Create(ctx, reconciliation); err != nil {
+			t.Fatalf("Failed to create reconciliation: %v", err)
+		}
+	}
+
+	if err := repo.Finish(ctx, january.ID, time.Now()); err != nil {
+		t.Fatalf("Failed to finish reconciliation: %v", err)
+	}
+	if err := repo.Finish(ctx, january.ID, time.Now()); err == nil {
+		t.Error("Expected an error finishing a reconciliation twice")
+	}
+
+	open, err := repo.GetOpenByAccountID(ctx, accountID)
+	if err != nil {
+		t.Fatalf("Failed to get open reconciliation: %v", err)
+	}
+	if open.ID != february.ID || open.StatementBalance != 980 || !open.StatementDate.Equal(february.StatementDate) || open.IsFinished() {
+		t.Errorf("Expected the February reconciliation to be open, got %+v", open)
+	}
+
+	reconciliations, err := repo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		t.Fatalf("Failed to get reconciliations: %v", err)
+	}
+	if len(reconciliations) != 2 || reconciliations[0].ID != february.ID || !reconciliations[1].IsFinished() {
+		t.Errorf("Expected two reconciliations newest first, got %+v", reconciliations)
+	}
+
+	if err := repo.Delete(ctx, february.ID); err != nil {
+		t.Fatalf("Failed to delete reconciliation: %v", err)
+	}
+	if _, err := repo.GetOpenByAccountID(ctx, accountID); err == nil {
+		t.Error("Expected no open reconciliation after deleting it")
+	}
+}
+
+func TestTransactionSqliteRepository_UpdateStatus(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewTransactionSqliteRepository(db.GetConnection(), metrics.NewNoop())
+
+	transaction := models.NewTransaction(models.TransactionData{AccountID: uuid.New(), Value: 10, Name: "Coffee", Type: models.Debit})
+	if err := repo.Create(ctx, transaction); err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	stored, _ := repo.GetByID(ctx, transaction.ID)
+	if stored.Status != models.StatusUncleared {
+		t.Errorf("Expected a new transaction to be uncleared, got %s", stored.Status)
+	}
+
+	if err := repo.UpdateStatus(ctx, transaction.ID, models.StatusReconciled); err != nil {
+		t.Fatalf("Failed to update status: %v", err)
+	}
+
+	stored, _ = repo.GetByID(ctx, transaction.ID)
+	if !stored.IsReconciled() {
+		t.Errorf("Expected the transaction to be reconciled, got %s", stored.Status)
+	}
+
+	if err := repo.UpdateStatus(ctx, uuid.New(), models.StatusCleared); err == nil {
+		t.Error("Expected an error for an unknown transaction")
+	}
+}
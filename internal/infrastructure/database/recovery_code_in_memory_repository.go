@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type RecoveryCodeInMemoryRepository struct {
+	codes map[string]*models.RecoveryCode
+	mu    sync.RWMutex
+}
+
+func NewRecoveryCodeInMemoryRepository() *RecoveryCodeInMemoryRepository {
+	return &RecoveryCodeInMemoryRepository{
+		codes: make(map[string]*models.RecoveryCode),
+	}
+}
+
+func (r *RecoveryCodeInMemoryRepository) ReplaceForAccess(ctx context.Context, accessID uuid.UUID, codes []*models.RecoveryCode) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteByAccessID(accessID)
+
+	for _, code := range codes {
+		code.AccessID = accessID
+		r.codes[code.ID.String()] = code
+	}
+
+	return nil
+}
+
+func (r *RecoveryCodeInMemoryRepository) GetUnusedByAccessID(ctx context.Context, accessID uuid.UUID) ([]*models.RecoveryCode, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var codes []*models.RecoveryCode
+	for _, code := range r.codes {
+		if code.AccessID == accessID && code.UsedAt == nil {
+			codes = append(codes, code)
+		}
+	}
+
+	return codes, nil
+}
+
+func (r *RecoveryCodeInMemoryRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	code, exists := r.codes[id.String()]
+	if !exists || code.UsedAt != nil {
+		return fmt.Errorf("recovery code not found")
+	}
+
+	now := time.Now()
+	code.UsedAt = &now
+	return nil
+}
+
+func (r *RecoveryCodeInMemoryRepository) DeleteByAccessID(ctx context.Context, accessID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteByAccessID(accessID)
+	return nil
+}
+
+func (r *RecoveryCodeInMemoryRepository) deleteByAccessID(accessID uuid.UUID) {
+	for key, code := range r.codes {
+		if code.AccessID == accessID {
+			delete(r.codes, key)
+		}
+	}
+}
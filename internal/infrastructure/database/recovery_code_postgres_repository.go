@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type RecoveryCodePostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewRecoveryCodePostgresRepository(db *sql.DB, observer QueryObserver) *RecoveryCodePostgresRepository {
+	return &RecoveryCodePostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *RecoveryCodePostgresRepository) ReplaceForAccess(ctx context.Context, accessID uuid.UUID, codes []*models.RecoveryCode) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE access_id = $1`, accessID.String()); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	query := `
+		INSERT INTO recovery_codes (id, access_id, code_hash, used_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	for _, code := range codes {
+		_, err := tx.ExecContext(ctx, query, code.ID.String(), accessID.String(), code.CodeHash, code.UsedAt, code.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecoveryCodePostgresRepository) GetUnusedByAccessID(ctx context.Context, accessID uuid.UUID) ([]*models.RecoveryCode, error) {
+	query := `
+		SELECT id, access_id, code_hash, used_at, created_at
+		FROM recovery_codes
+		WHERE access_id = $1 AND used_at IS NULL
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, accessID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recovery codes by access_id: %w", err)
+	}
+	defer rows.Close()
+
+	var codes []*models.RecoveryCode
+	for rows.Next() {
+		code, err := scanRecoveryCode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recovery code: %w", err)
+		}
+		codes = append(codes, code)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recovery code rows: %w", err)
+	}
+
+	return codes, nil
+}
+
+func (r *RecoveryCodePostgresRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE recovery_codes SET used_at = $1 WHERE id = $2 AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to mark recovery code as used: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("recovery code not found")
+	}
+
+	return nil
+}
+
+func (r *RecoveryCodePostgresRepository) DeleteByAccessID(ctx context.Context, accessID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM recovery_codes WHERE access_id = $1`, accessID.String()); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	return nil
+}
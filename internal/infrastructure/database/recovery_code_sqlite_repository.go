@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type RecoveryCodeSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewRecoveryCodeSqliteRepository(db *sql.DB, observer QueryObserver) *RecoveryCodeSqliteRepository {
+	return &RecoveryCodeSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *RecoveryCodeSqliteRepository) ReplaceForAccess(ctx context.Context, accessID uuid.UUID, codes []*models.RecoveryCode) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE access_id = ?`, accessID.String()); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	query := `
+		INSERT INTO recovery_codes (id, access_id, code_hash, used_at, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	for _, code := range codes {
+		_, err := tx.ExecContext(ctx, query, code.ID.String(), accessID.String(), code.CodeHash, code.UsedAt, code.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecoveryCodeSqliteRepository) GetUnusedByAccessID(ctx context.Context, accessID uuid.UUID) ([]*models.RecoveryCode, error) {
+	query := `
+		SELECT id, access_id, code_hash, used_at, created_at
+		FROM recovery_codes
+		WHERE access_id = ? AND used_at IS NULL
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, accessID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recovery codes by access_id: %w", err)
+	}
+	defer rows.Close()
+
+	var codes []*models.RecoveryCode
+	for rows.Next() {
+		code, err := scanRecoveryCode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recovery code: %w", err)
+		}
+		codes = append(codes, code)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recovery code rows: %w", err)
+	}
+
+	return codes, nil
+}
+
+func (r *RecoveryCodeSqliteRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE recovery_codes SET used_at = ? WHERE id = ? AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to mark recovery code as used: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("recovery code not found")
+	}
+
+	return nil
+}
+
+func (r *RecoveryCodeSqliteRepository) DeleteByAccessID(ctx context.Context, accessID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM recovery_codes WHERE access_id = ?`, accessID.String()); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	return nil
+}
+
+func scanRecoveryCode(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.RecoveryCode, error) {
+	var id, accessID, codeHash string
+	var usedAt sql.NullTime
+	var createdAt time.Time
+
+	err := scanner.Scan(&id, &accessID, &codeHash, &usedAt, &createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("recovery code not found")
+		}
+		return nil, fmt.Errorf("failed to scan recovery code row: %w", err)
+	}
+
+	codeID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recovery code ID: %w", err)
+	}
+
+	accessUUID, err := uuid.Parse(accessID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access ID: %w", err)
+	}
+
+	var usedAtPtr *time.Time
+	if usedAt.Valid {
+		usedAtPtr = &usedAt.Time
+	}
+
+	return &models.RecoveryCode{
+		ID:        codeID,
+		AccessID:  accessUUID,
+		CodeHash:  codeHash,
+		UsedAt:    usedAtPtr,
+		CreatedAt: createdAt,
+	}, nil
+}
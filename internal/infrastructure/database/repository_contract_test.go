@@ -0,0 +1,1129 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+)
+
+// postgresTestDSNEnv names the database the contract tests run against in
+// PostgreSQL. Every table is truncated before every test.
+const postgresTestDSNEnv = "GOFIN_TEST_POSTGRES_DSN"
+
+type contractRepositories struct {
+	projects        models.ProjectRepository
+	access          models.AccessRepository
+	accounts        models.AccountRepository
+	transactions    models.TransactionRepository
+	recoveryCodes   models.RecoveryCodeRepository
+	attachments     models.AttachmentRepository
+	categories      models.CategoryRepository
+	splits          models.TransactionSplitRepository
+	sharedExpenses  models.SharedExpenseRepository
+	settlements     models.SettlementRepository
+	transfers       models.AccountTransferRepository
+	payees          models.PayeeRepository
+	loans           models.LoanRepository
+	securities      models.SecurityRepository
+	operations      models.InvestmentOperationRepository
+	reconciliations models.ReconciliationRepository
+	periodLocks     models.PeriodLockEventRepository
+}
+
+// runContract runs test against fresh repositories of every backend.
+func runContract(t *testing.T, test func(t *testing.T, repos contractRepositories)) {
+	backends := []struct {
+		name string
+		open func(t *testing.T) contractRepositories
+	}{
+		{
+			name: "in-memory",
+			open: func(t *testing.T) contractRepositories {
+				return contractRepositories{
+					projects:        NewProjectInMemoryRepository(),
+					access:          NewAccessInMemoryRepository(),
+					accounts:        NewAccountInMemoryRepository(),
+					transactions:    NewTransactionInMemoryRepository(),
+					recoveryCodes:   NewRecoveryCodeInMemoryRepository(),
+					attachments:     NewAttachmentInMemoryRepository(),
+					categories:      NewCategoryInMemoryRepository(),
+					splits:          NewTransactionSplitInMemoryRepository(),
+					sharedExpenses:  NewSharedExpenseInMemoryRepository(),
+					settlements:     NewSettlementInMemoryRepository(),
+					transfers:       NewAccountTransferInMemoryRepository(),
+					payees:          NewPayeeInMemoryRepository(),
+					loans:           NewLoanInMemoryRepository(),
+					securities:      NewSecurityInMemoryRepository(),
+					operations:      NewInvestmentOperationInMemoryRepository(),
+					reconciliations: NewReconciliationInMemoryRepository(),
+					periodLocks:     NewPeriodLockEventInMemoryRepository(),
+				}
+			},
+		},
+		{
+			name: "sqlite",
+			open: func(t *testing.T) contractRepositories {
+				db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+				if err != nil {
+					t.Fatalf("Failed to open database: %v", err)
+				}
+				t.Cleanup(func() { db.Close() })
+
+				conn, observer := db.GetConnection(), metrics.NewNoop()
+				return contractRepositories{
+					projects:        NewProjectSqliteRepository(conn, observer),
+					access:          NewAccessSqliteRepository(conn, observer),
+					accounts:        NewAccountSqliteRepository(conn, observer),
+					transactions:    NewTransactionSqliteRepository(conn, observer),
+					recoveryCodes:   NewRecoveryCodeSqliteRepository(conn, observer),
+					attachments:     NewAttachmentSqliteRepository(conn, observer),
+					categories:      NewCategorySqliteRepository(conn, observer),
+					splits:          NewTransactionSplitSqliteRepository(conn, observer),
+					sharedExpenses:  NewSharedExpenseSqliteRepository(conn, observer),
+					settlements:     NewSettlementSqliteRepository(conn, observer),
+					transfers:       NewAccountTransferSqliteRepository(conn, observer),
+					payees:          NewPayeeSqliteRepository(conn, observer),
+					loans:           NewLoanSqliteRepository(conn, observer),
+					securities:      NewSecuritySqliteRepository(conn, observer),
+					operations:      NewInvestmentOperationSqliteRepository(conn, observer),
+					reconciliations: NewReconciliationSqliteRepository(conn, observer),
+					periodLocks:     NewPeriodLockEventSqliteRepository(conn, observer),
+				}
+			},
+		},
+		{
+			name: "postgres",
+			open: func(t *testing.T) contractRepositories {
+				dsn := os.Getenv(postgresTestDSNEnv)
+				if dsn == "" {
+					t.Skipf("%s is not set", postgresTestDSNEnv)
+				}
+
+				db, err := NewPostgresDB(dsn)
+				if err != nil {
+					t.Fatalf("Failed to open database: %v", err)
+				}
+				t.Cleanup(func() { db.Close() })
+
+				if _, err := db.GetConnection().Exec("TRUNCATE projects, access, accounts, transactions, recovery_codes, attachments, categories, transaction_splits, shared_expenses, expense_shares, settlements, account_transfers, payees, payee_aliases, loans, loan_rate_periods, securities, security_prices, investment_operations, reconciliations, period_lock_events"); err != nil {
+					t.Fatalf("Failed to empty database: %v", err)
+				}
+
+				conn, observer := db.GetConnection(), metrics.NewNoop()
+				return contractRepositories{
+					projects:        NewProjectPostgresRepository(conn, observer),
+					access:          NewAccessPostgresRepository(conn, observer),
+					accounts:        NewAccountPostgresRepository(conn, observer),
+					transactions:    NewTransactionPostgresRepository(conn, observer),
+					recoveryCodes:   NewRecoveryCodePostgresRepository(conn, observer),
+					attachments:     NewAttachmentPostgresRepository(conn, observer),
+					categories:      NewCategoryPostgresRepository(conn, observer),
+					splits:          NewTransactionSplitPostgresRepository(conn, observer),
+					sharedExpenses:  NewSharedExpensePostgresRepository(conn, observer),
+					settlements:     NewSettlementPostgresRepository(conn, observer),
+					transfers:       NewAccountTransferPostgresRepository(conn, observer),
+					payees:          NewPayeePostgresRepository(conn, observer),
+					loans:           NewLoanPostgresRepository(conn, observer),
+					securities:      NewSecurityPostgresRepository(conn, observer),
+					operations:      NewInvestmentOperationPostgresRepository(conn, observer),
+					reconciliations: NewReconciliationPostgresRepository(conn, observer),
+					periodLocks:     NewPeriodLockEventPostgresRepository(conn, observer),
+				}
+			},
+		},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			test(t, backend.open(t))
+		})
+	}
+}
+
+// contractTime is truncated to the second so every backend stores it exactly.
+func contractTime(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+func TestRepositoryContract_Projects(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+
+		project := models.NewProject("Home", "home")
+		project.CreatedAt = contractTime(2026, time.January, 1)
+		project.UpdatedAt = project.CreatedAt
+		if err := repos.projects.Create(ctx, project); err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		if err := repos.projects.Create(ctx, models.NewProject("Other", "home")); err == nil {
+			t.Error("Expected a duplicate slug to be rejected")
+		}
+
+		bySlug, err := repos.projects.GetBySlug(ctx, "home")
+		if err != nil {
+			t.Fatalf("Failed to get project by slug: %v", err)
+		}
+		if bySlug.ID != project.ID || bySlug.Name != "Home" || !bySlug.CreatedAt.Equal(project.CreatedAt) || bySlug.LockedUntil != nil {
+			t.Errorf("Expected the project to round-trip, got %+v", bySlug)
+		}
+
+		if exists, err := repos.projects.ExistsBySlug(ctx, "home"); err != nil || !exists {
+			t.Errorf("Expected the slug to exist, got %v, %v", exists, err)
+		}
+		if exists, err := repos.projects.ExistsBySlug(ctx, "missing"); err != nil || exists {
+			t.Errorf("Expected the slug to be free, got %v, %v", exists, err)
+		}
+		if _, err := repos.projects.GetBySlug(ctx, "missing"); err == nil {
+			t.Error("Expected an error for a missing slug")
+		}
+		if _, err := repos.projects.GetByID(ctx, uuid.New()); err == nil {
+			t.Error("Expected an error for a missing ID")
+		}
+
+		lockedUntil := contractTime(2026, time.March, 31)
+		updated := *project
+		updated.Name = "Household"
+		updated.RequireTwoFactor = true
+		updated.LockedUntil = &lockedUntil
+		if err := repos.projects.Update(ctx, &updated); err != nil {
+			t.Fatalf("Failed to update project: %v", err)
+		}
+
+		byID, err := repos.projects.GetByID(ctx, project.ID)
+		if err != nil {
+			t.Fatalf("Failed to get project by ID: %v", err)
+		}
+		if byID.Name != "Household" || !byID.RequireTwoFactor || byID.LockedUntil == nil || !byID.LockedUntil.Equal(lockedUntil) {
+			t.Errorf("Expected the update to be saved, got %+v", byID)
+		}
+
+		if err := repos.projects.Update(ctx, models.NewProject("Missing", "missing")); err == nil {
+			t.Error("Expected an error when updating a missing project")
+		}
+	})
+}
+
+func TestRepositoryContract_Access(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+
+		project := models.NewProject("Home", "home")
+		other := models.NewProject("Other", "other")
+		for _, p := range []*models.Project{project, other} {
+			if err := repos.projects.Create(ctx, p); err != nil {
+				t.Fatalf("Failed to create project: %v", err)
+			}
+		}
+
+		owner := models.NewAccess(project.ID, "alice", "hash-a", "Alice", false)
+		owner.CreatedAt = contractTime(2026, time.January, 2)
+		viewer := models.NewAccess(project.ID, "bob", "hash-b", "Bob", true)
+		viewer.CreatedAt = contractTime(2026, time.January, 1)
+		for _, access := range []*models.Access{owner, viewer, models.NewAccess(other.ID, "alice", "hash", "Alice", false)} {
+			if err := repos.access.Create(ctx, access); err != nil {
+				t.Fatalf("Failed to create access: %v", err)
+			}
+		}
+		if err := repos.access.Create(ctx, models.NewAccess(project.ID, "alice", "hash", "Alice", false)); err == nil {
+			t.Error("Expected a duplicate UID within a project to be rejected")
+		}
+
+		accesses, err := repos.access.GetByProjectID(ctx, project.ID)
+		if err != nil {
+			t.Fatalf("Failed to get accesses: %v", err)
+		}
+		if len(accesses) != 2 || accesses[0].ID != viewer.ID || accesses[1].ID != owner.ID {
+			t.Fatalf("Expected both accesses oldest first, got %+v", accesses)
+		}
+		if !accesses[0].ReadOnly || accesses[0].PinHash != "hash-b" || accesses[0].ProjectID != project.ID {
+			t.Errorf("Expected the access to round-trip, got %+v", accesses[0])
+		}
+
+		if exists, err := repos.access.ExistsByUID(ctx, project.ID, "bob"); err != nil || !exists {
+			t.Errorf("Expected bob to exist, got %v, %v", exists, err)
+		}
+		if exists, err := repos.access.ExistsByUID(ctx, other.ID, "bob"); err != nil || exists {
+			t.Errorf("Expected bob not to exist in the other project, got %v, %v", exists, err)
+		}
+		if _, err := repos.access.GetByUID(ctx, other.ID, "bob"); err == nil {
+			t.Error("Expected an error for a UID of another project")
+		}
+
+		updated := *owner
+		updated.TOTPSecret = "secret"
+		updated.TOTPEnabled = true
+		updated.TOTPLastCounter = 58177000
+		updated.TwoFactorFailures = 2
+		updated.PinHash = "new-hash"
+		if err := repos.access.Update(ctx, &updated); err != nil {
+			t.Fatalf("Failed to update access: %v", err)
+		}
+
+		byUID, err := repos.access.GetByUID(ctx, project.ID, "alice")
+		if err != nil {
+			t.Fatalf("Failed to get access by UID: %v", err)
+		}
+		if byUID.ID != owner.ID || byUID.TOTPSecret != "secret" || !byUID.TOTPEnabled || byUID.PinHash != "new-hash" || byUID.TOTPLastCounter != 58177000 || byUID.TwoFactorFailures != 2 {
+			t.Errorf("Expected the update to be saved, got %+v", byUID)
+		}
+
+		failedAt := contractTime(2024, time.March, 1)
+		if ok, err := repos.access.SetTwoFactorFailures(ctx, owner.ID, 2, 3, &failedAt); err != nil || !ok {
+			t.Fatalf("Expected the failures to be set from the expected count, got %v, %v", ok, err)
+		}
+		if ok, err := repos.access.SetTwoFactorFailures(ctx, owner.ID, 2, 3, &failedAt); err != nil || ok {
+			t.Errorf("Expected a stale count to be refused, got %v, %v", ok, err)
+		}
+		failed, _ := repos.access.GetByID(ctx, owner.ID)
+		if failed.TwoFactorFailures != 3 || failed.TwoFactorFailedAt == nil || !failed.TwoFactorFailedAt.Equal(failedAt) {
+			t.Errorf("Expected 3 failures at %s, got %d at %v", failedAt, failed.TwoFactorFailures, failed.TwoFactorFailedAt)
+		}
+
+		if ok, err := repos.access.UseTOTPCounter(ctx, owner.ID, 58177001); err != nil || !ok {
+			t.Fatalf("Expected a newer counter to be used, got %v, %v", ok, err)
+		}
+		for _, counter := range []int64{58177001, 58177000} {
+			if ok, err := repos.access.UseTOTPCounter(ctx, owner.ID, counter); err != nil || ok {
+				t.Errorf("Expected counter %d to be refused, got %v, %v", counter, ok, err)
+			}
+		}
+		used, _ := repos.access.GetByID(ctx, owner.ID)
+		if used.TOTPLastCounter != 58177001 || used.TwoFactorFailures != 0 || used.TwoFactorFailedAt != nil {
+			t.Errorf("Expected the counter used and the failures cleared, got %+v", used)
+		}
+
+		if _, err := repos.access.GetByID(ctx, uuid.New()); err == nil {
+			t.Error("Expected an error for a missing ID")
+		}
+		if err := repos.access.Update(ctx, models.NewAccess(project.ID, "carol", "hash", "Carol", false)); err == nil {
+			t.Error("Expected an error when updating a missing access")
+		}
+	})
+}
+
+func TestRepositoryContract_Accounts(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+
+		project := models.NewProject("Home", "home")
+		if err := repos.projects.Create(ctx, project); err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+
+		savings := models.NewAccount(project.ID, "Savings", "PLN")
+		savings.Position = 1
+		checking := models.NewAccount(project.ID, "Checking", "PLN")
+		checking.Position = 1
+		checking.CreatedAt = savings.CreatedAt.Add(time.Second)
+		visa := models.NewAccount(project.ID, "Visa", "EUR")
+		visa.Type = models.AccountCreditCard
+		visa.CreditLimit = 2500.5
+		visa.StatementDay = 28
+		visa.DueDay = 20
+		for _, account := range []*models.Account{checking, savings, visa} {
+			if err := repos.accounts.Create(ctx, account); err != nil {
+				t.Fatalf("Failed to create account: %v", err)
+			}
+		}
+		if err := repos.accounts.Create(ctx, models.NewAccount(project.ID, "Visa", "PLN")); err == nil {
+			t.Error("Expected a duplicate name within a project to be rejected")
+		}
+
+		accounts, err := repos.accounts.GetByProjectID(ctx, project.ID)
+		if err != nil {
+			t.Fatalf("Failed to get accounts: %v", err)
+		}
+		var order []string
+		for _, account := range accounts {
+			order = append(order, account.Name)
+		}
+		if !slices.Equal(order, []string{"Visa", "Savings", "Checking"}) {
+			t.Fatalf("Expected accounts by position then creation, got %v", order)
+		}
+
+		stored := accounts[0]
+		if stored.Currency.String() != "EUR" || stored.Type != models.AccountCreditCard || stored.CreditLimit != 2500.5 || stored.StatementDay != 28 || stored.DueDay != 20 || stored.IsArchived() {
+			t.Errorf("Expected the credit card to round-trip, got %+v", stored)
+		}
+
+		archivedAt := contractTime(2026, time.June, 30)
+		updated := *visa
+		updated.Name = "Old Visa"
+		updated.Description = "Closed"
+		updated.Position = 5
+		updated.ArchivedAt = &archivedAt
+		if err := repos.accounts.Update(ctx, &updated); err != nil {
+			t.Fatalf("Failed to update account: %v", err)
+		}
+
+		byID, err := repos.accounts.GetByID(ctx, visa.ID)
+		if err != nil {
+			t.Fatalf("Failed to get account: %v", err)
+		}
+		if byID.Name != "Old Visa" || byID.Description != "Closed" || byID.Position != 5 || byID.ArchivedAt == nil || !byID.ArchivedAt.Equal(archivedAt) {
+			t.Errorf("Expected the update to be saved, got %+v", byID)
+		}
+
+		if exists, _ := repos.accounts.ExistsByName(ctx, project.ID, "Visa"); exists {
+			t.Error("Expected the old name to be free after renaming")
+		}
+		if exists, _ := repos.accounts.ExistsByName(ctx, project.ID, "Old Visa"); !exists {
+			t.Error("Expected the new name to be taken")
+		}
+		if _, err := repos.accounts.GetByID(ctx, uuid.New()); err == nil {
+			t.Error("Expected an error for a missing ID")
+		}
+		if err := repos.accounts.Update(ctx, models.NewAccount(project.ID, "Missing", "PLN")); err == nil {
+			t.Error("Expected an error when updating a missing account")
+		}
+	})
+}
+
+func TestRepositoryContract_Transactions(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+
+		project := models.NewProject("Home", "home")
+		if err := repos.projects.Create(ctx, project); err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		checking := models.NewAccount(project.ID, "Checking", "PLN")
+		savings := models.NewAccount(project.ID, "Savings", "PLN")
+		for _, account := range []*models.Account{checking, savings} {
+			if err := repos.accounts.Create(ctx, account); err != nil {
+				t.Fatalf("Failed to create account: %v", err)
+			}
+		}
+
+		newTransaction := func(account *models.Account, name string, value float64, date time.Time) *models.Transaction {
+			transaction := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: value, Name: name, Type: models.Debit, TransactionDate: &date})
+			transaction.CreatedAt = date
+			transaction.UpdatedAt = date
+			return transaction
+		}
+
+		categoryID, payeeID, groupID := uuid.New(), uuid.New(), uuid.New()
+		salary := newTransaction(checking, "Salary", 5000, contractTime(2026, time.January, 10))
+		salary.Type = models.TopUp
+		salary.ExternalID = "bank-1"
+		salary.Status = models.StatusCleared
+		groceries := newTransaction(checking, "groceries", -120.25, contractTime(2026, time.January, 12))
+		groceries.Notes = "weekly shop"
+		groceries.CategoryID = &categoryID
+		groceries.PayeeID = &payeeID
+		rent := newTransaction(checking, "Rent", -2000, contractTime(2026, time.February, 1))
+		transferOut := newTransaction(checking, "Transfer", -300, contractTime(2026, time.February, 5))
+		transferOut.GroupID = &groupID
+		transferIn := newTransaction(savings, "Transfer", 300, contractTime(2026, time.February, 5))
+		transferIn.Type = models.TopUp
+		transferIn.GroupID = &groupID
+		transferIn.CreatedAt = transferIn.CreatedAt.Add(time.Second)
+
+		for _, transaction := range []*models.Transaction{salary, groceries, rent, transferOut, transferIn} {
+			if err := repos.transactions.Create(ctx, transaction); err != nil {
+				t.Fatalf("Failed to create transaction: %v", err)
+			}
+		}
+
+		duplicate := newTransaction(checking, "Salary again", 5000, contractTime(2026, time.January, 11))
+		duplicate.ExternalID = "bank-1"
+		if err := repos.transactions.Create(ctx, duplicate); err == nil {
+			t.Error("Expected a duplicate external ID within an account to be rejected")
+		}
+		elsewhere := newTransaction(savings, "Interest", 1, contractTime(2026, time.January, 31))
+		elsewhere.ExternalID = "bank-1"
+		if err := repos.transactions.Create(ctx, elsewhere); err != nil {
+			t.Errorf("Expected the same external ID in another account to be accepted, got %v", err)
+		}
+
+		stored, err := repos.transactions.GetByID(ctx, groceries.ID)
+		if err != nil {
+			t.Fatalf("Failed to get transaction: %v", err)
+		}
+		if stored.AccountID != checking.ID || stored.Value != -120.25 || stored.Name != "groceries" || stored.Notes != "weekly shop" ||
+			!stored.TransactionDate.Equal(groceries.TransactionDate) || stored.Type != groceries.Type || stored.Status != groceries.Status ||
+			stored.CategoryID == nil || *stored.CategoryID != categoryID || stored.PayeeID == nil || *stored.PayeeID != payeeID || stored.GroupID != nil {
+			t.Errorf("Expected the transaction to round-trip, got %+v", stored)
+		}
+
+		byAccount, err := repos.transactions.GetByAccountID(ctx, checking.ID)
+		if err != nil {
+			t.Fatalf("Failed to get transactions by account: %v", err)
+		}
+		assertTransactionOrder(t, "by account", byAccount, transferOut, rent, groceries, salary)
+
+		group, err := repos.transactions.GetByGroupID(ctx, groupID)
+		if err != nil {
+			t.Fatalf("Failed to get transactions by group: %v", err)
+		}
+		assertTransactionOrder(t, "by group", group, transferOut, transferIn)
+
+		start, end := contractTime(2026, time.January, 11), contractTime(2026, time.February, 1)
+		inRange, err := repos.transactions.GetByAccountIDWithDateRange(ctx, checking.ID, &start, &end)
+		if err != nil {
+			t.Fatalf("Failed to get transactions by date range: %v", err)
+		}
+		assertTransactionOrder(t, "by account and date range", inRange, groceries, rent)
+
+		inProject, err := repos.transactions.GetByProjectIDWithDateRange(ctx, project.ID, &end, nil)
+		if err != nil {
+			t.Fatalf("Failed to get project transactions by date range: %v", err)
+		}
+		if len(inProject) != 3 || inProject[0].ID != rent.ID {
+			t.Errorf("Expected rent and both transfer legs, got %d transactions", len(inProject))
+		}
+
+		externalIDs, err := repos.transactions.GetExternalIDs(ctx, checking.ID)
+		if err != nil || !slices.Equal(externalIDs, []string{"bank-1"}) {
+			t.Errorf("Expected the external ID of the salary, got %v, %v", externalIDs, err)
+		}
+
+		if err := repos.transactions.UpdateNotes(ctx, rent.ID, "January"); err != nil {
+			t.Fatalf("Failed to update notes: %v", err)
+		}
+		if err := repos.transactions.UpdateCategory(ctx, rent.ID, &categoryID); err != nil {
+			t.Fatalf("Failed to update category: %v", err)
+		}
+		if err := repos.transactions.UpdatePayee(ctx, rent.ID, &payeeID); err != nil {
+			t.Fatalf("Failed to update payee: %v", err)
+		}
+		if err := repos.transactions.UpdateStatus(ctx, rent.ID, models.StatusReconciled); err != nil {
+			t.Fatalf("Failed to update status: %v", err)
+		}
+		if err := repos.transactions.UpdateCategory(ctx, groceries.ID, nil); err != nil {
+			t.Fatalf("Failed to clear category: %v", err)
+		}
+
+		newPayeeID := uuid.New()
+		if err := repos.transactions.ReassignPayee(ctx, payeeID, newPayeeID); err != nil {
+			t.Fatalf("Failed to reassign payee: %v", err)
+		}
+
+		updatedRent, _ := repos.transactions.GetByID(ctx, rent.ID)
+		if updatedRent.Notes != "January" || updatedRent.CategoryID == nil || *updatedRent.CategoryID != categoryID ||
+			updatedRent.PayeeID == nil || *updatedRent.PayeeID != newPayeeID || updatedRent.Status != models.StatusReconciled {
+			t.Errorf("Expected the updates to be saved, got %+v", updatedRent)
+		}
+		updatedGroceries, _ := repos.transactions.GetByID(ctx, groceries.ID)
+		if updatedGroceries.CategoryID != nil || updatedGroceries.PayeeID == nil || *updatedGroceries.PayeeID != newPayeeID {
+			t.Errorf("Expected the category cleared and the payee reassigned, got %+v", updatedGroceries)
+		}
+
+		missing := uuid.New()
+		if err := repos.transactions.UpdateNotes(ctx, missing, "x"); err == nil {
+			t.Error("Expected an error when updating a missing transaction")
+		}
+
+		if err := repos.transactions.DeleteByID(ctx, elsewhere.ID); err != nil {
+			t.Fatalf("Failed to delete transaction: %v", err)
+		}
+		if _, err := repos.transactions.GetByID(ctx, elsewhere.ID); err == nil {
+			t.Error("Expected the deleted transaction to be gone")
+		}
+		if err := repos.transactions.DeleteByID(ctx, missing); err == nil {
+			t.Error("Expected an error when deleting a missing transaction")
+		}
+	})
+}
+
+func TestRepositoryContract_SearchTransactions(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+
+		project := models.NewProject("Home", "home")
+		if err := repos.projects.Create(ctx, project); err != nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+		account := models.NewAccount(project.ID, "Checking", "PLN")
+		if err := repos.accounts.Create(ctx, account); err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+
+		for i, spec := range []struct {
+			name  string
+			value float64
+			notes string
+		}{
+			{"Groceries", -80, "market"},
+			{"bakery", -12, ""},
+			{"Salary", 5000, ""},
+			{"Cinema", -40, "groceries money"},
+			{"apartment", -2000, ""},
+		} {
+			date := contractTime(2026, time.March, 1+i)
+			transaction := models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: spec.value, Name: spec.name, Type: models.Debit, TransactionDate: &date, Notes: spec.notes})
+			if spec.value > 0 {
+				transaction.Type = models.TopUp
+			}
+			if err := repos.transactions.Create(ctx, transaction); err != nil {
+				t.Fatalf("Failed to create transaction: %v", err)
+			}
+		}
+
+		minValue, maxValue := -100.0, -10.0
+		tests := []struct {
+			name   string
+			query  models.TransactionQuery
+			expect []string
+		}{
+			{
+				name:   "newest first by default",
+				query:  models.TransactionQuery{AccountID: &account.ID},
+				expect: []string{"apartment", "Cinema", "Salary", "bakery", "Groceries"},
+			},
+			{
+				name:   "by name ignoring case",
+				query:  models.TransactionQuery{ProjectID: &project.ID, SortBy: models.SortByName, SortDirection: models.SortAscending},
+				expect: []string{"apartment", "bakery", "Cinema", "Groceries", "Salary"},
+			},
+			{
+				name:   "by value",
+				query:  models.TransactionQuery{AccountID: &account.ID, SortBy: models.SortByValue, SortDirection: models.SortAscending},
+				expect: []string{"apartment", "Groceries", "Cinema", "bakery", "Salary"},
+			},
+			{
+				name:   "search in name and notes",
+				query:  models.TransactionQuery{AccountID: &account.ID, Search: "GROC"},
+				expect: []string{"Cinema", "Groceries"},
+			},
+			{
+				name:   "value range",
+				query:  models.TransactionQuery{AccountID: &account.ID, MinValue: &minValue, MaxValue: &maxValue},
+				expect: []string{"Cinema", "bakery", "Groceries"},
+			},
+			{
+				name:   "type",
+				query:  models.TransactionQuery{AccountID: &account.ID, Type: models.TopUp},
+				expect: []string{"Salary"},
+			},
+		}
+
+		for _, tt := range tests {
+			// Every query is also read two at a time, following the cursors.
+			for _, limit := range []int{0, 2} {
+				query := tt.query
+				query.Limit = limit
+
+				var names []string
+				for page := 0; page < 10; page++ {
+					result, err := repos.transactions.SearchTransactions(ctx, query)
+					if err != nil {
+						t.Fatalf("%s: expected no error, got %v", tt.name, err)
+					}
+					for _, transaction := range result.Transactions {
+						names = append(names, transaction.Name)
+					}
+					if result.NextCursor == "" {
+						break
+					}
+					query.Cursor = result.NextCursor
+				}
+
+				if !slices.Equal(names, tt.expect) {
+					t.Errorf("%s (limit %d): expected %v, got %v", tt.name, limit, tt.expect, names)
+				}
+			}
+		}
+	})
+}
+
+// contractFixture stores a project with one member, one account and one
+// transaction for the tests of the repositories that refer to them.
+type contractFixture struct {
+	project     *models.Project
+	member      *models.Access
+	account     *models.Account
+	transaction *models.Transaction
+}
+
+func newContractFixture(t *testing.T, repos contractRepositories) contractFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	fixture := contractFixture{project: models.NewProject("Home", "home")}
+	if err := repos.projects.Create(ctx, fixture.project); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	fixture.member = models.NewAccess(fixture.project.ID, "alice", "hash", "Alice", false)
+	if err := repos.access.Create(ctx, fixture.member); err != nil {
+		t.Fatalf("Failed to create access: %v", err)
+	}
+
+	fixture.account = models.NewAccount(fixture.project.ID, "Checking", "PLN")
+	if err := repos.accounts.Create(ctx, fixture.account); err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	fixture.transaction = fixture.newTransaction(t, repos, "Groceries", -120, contractTime(2026, time.January, 12))
+	return fixture
+}
+
+func (f contractFixture) newTransaction(t *testing.T, repos contractRepositories, name string, value float64, date time.Time) *models.Transaction {
+	t.Helper()
+
+	transaction := models.NewTransaction(models.TransactionData{AccountID: f.account.ID, Value: value, Name: name, Type: models.Debit, TransactionDate: &date})
+	if err := repos.transactions.Create(context.Background(), transaction); err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	return transaction
+}
+
+func TestRepositoryContract_RecoveryCodes(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+		fixture := newContractFixture(t, repos)
+
+		codes := []*models.RecoveryCode{
+			models.NewRecoveryCode(fixture.member.ID, "hash-1"),
+			models.NewRecoveryCode(fixture.member.ID, "hash-2"),
+		}
+		if err := repos.recoveryCodes.ReplaceForAccess(ctx, fixture.member.ID, codes); err != nil {
+			t.Fatalf("Failed to store recovery codes: %v", err)
+		}
+
+		if err := repos.recoveryCodes.MarkUsed(ctx, codes[0].ID); err != nil {
+			t.Fatalf("Failed to mark recovery code used: %v", err)
+		}
+		if err := repos.recoveryCodes.MarkUsed(ctx, codes[0].ID); err == nil {
+			t.Error("Expected a used recovery code not to be used again")
+		}
+
+		unused, err := repos.recoveryCodes.GetUnusedByAccessID(ctx, fixture.member.ID)
+		if err != nil {
+			t.Fatalf("Failed to get recovery codes: %v", err)
+		}
+		if len(unused) != 1 || unused[0].ID != codes[1].ID || unused[0].CodeHash != "hash-2" {
+			t.Errorf("Expected only the second code unused, got %+v", unused)
+		}
+
+		if err := repos.recoveryCodes.DeleteByAccessID(ctx, fixture.member.ID); err != nil {
+			t.Fatalf("Failed to delete recovery codes: %v", err)
+		}
+		if unused, _ := repos.recoveryCodes.GetUnusedByAccessID(ctx, fixture.member.ID); len(unused) != 0 {
+			t.Errorf("Expected no recovery codes after deleting them, got %d", len(unused))
+		}
+	})
+}
+
+func TestRepositoryContract_Attachments(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+		fixture := newContractFixture(t, repos)
+
+		receipt := models.NewAttachment(fixture.transaction.ID, "receipt.pdf", "application/pdf", 1024, "sum-a")
+		receipt.CreatedAt = contractTime(2026, time.January, 12)
+		photo := models.NewAttachment(fixture.transaction.ID, "photo.jpg", "image/jpeg", 2048, "sum-a")
+		photo.HasThumbnail = true
+		photo.CreatedAt = contractTime(2026, time.January, 13)
+		for _, attachment := range []*models.Attachment{photo, receipt} {
+			if err := repos.attachments.Create(ctx, attachment); err != nil {
+				t.Fatalf("Failed to create attachment: %v", err)
+			}
+		}
+
+		attachments, err := repos.attachments.GetByTransactionID(ctx, fixture.transaction.ID)
+		if err != nil {
+			t.Fatalf("Failed to get attachments: %v", err)
+		}
+		if len(attachments) != 2 || attachments[0].ID != receipt.ID || attachments[1].ID != photo.ID {
+			t.Fatalf("Expected both attachments oldest first, got %+v", attachments)
+		}
+
+		stored, err := repos.attachments.GetByID(ctx, photo.ID)
+		if err != nil {
+			t.Fatalf("Failed to get attachment: %v", err)
+		}
+		if stored.FileName != "photo.jpg" || stored.ContentType != "image/jpeg" || stored.Size != 2048 || !stored.HasThumbnail {
+			t.Errorf("Expected the attachment to round-trip, got %+v", stored)
+		}
+
+		if count, err := repos.attachments.CountByChecksum(ctx, "sum-a"); err != nil || count != 2 {
+			t.Errorf("Expected 2 attachments with the checksum, got %d, %v", count, err)
+		}
+
+		if err := repos.attachments.DeleteByID(ctx, photo.ID); err != nil {
+			t.Fatalf("Failed to delete attachment: %v", err)
+		}
+		if err := repos.attachments.DeleteByID(ctx, photo.ID); err == nil {
+			t.Error("Expected an error when deleting a missing attachment")
+		}
+		if _, err := repos.attachments.GetByID(ctx, photo.ID); err == nil {
+			t.Error("Expected the deleted attachment to be gone")
+		}
+	})
+}
+
+func TestRepositoryContract_CategoriesAndSplits(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+		fixture := newContractFixture(t, repos)
+
+		food := models.NewCategory(fixture.project.ID, "food")
+		home := models.NewCategory(fixture.project.ID, "Home")
+		for _, category := range []*models.Category{home, food} {
+			if err := repos.categories.Create(ctx, category); err != nil {
+				t.Fatalf("Failed to create category: %v", err)
+			}
+		}
+
+		categories, err := repos.categories.GetByProjectID(ctx, fixture.project.ID)
+		if err != nil {
+			t.Fatalf("Failed to get categories: %v", err)
+		}
+		if len(categories) != 2 || categories[0].ID != food.ID || categories[1].ID != home.ID {
+			t.Fatalf("Expected categories by name ignoring case, got %+v", categories)
+		}
+
+		if exists, err := repos.categories.ExistsByName(ctx, fixture.project.ID, "FOOD"); err != nil || !exists {
+			t.Errorf("Expected the name to exist ignoring case, got %v, %v", exists, err)
+		}
+		if _, err := repos.categories.GetByID(ctx, uuid.New()); err == nil {
+			t.Error("Expected an error for a missing category")
+		}
+
+		other := fixture.newTransaction(t, repos, "Rent", -2000, contractTime(2026, time.February, 1))
+		splits := models.NewTransactionSplits(fixture.transaction.ID, []models.SplitData{
+			{CategoryID: food.ID, Amount: 100, Memo: "market"},
+			{CategoryID: home.ID, Amount: 20},
+		})
+		if err := repos.splits.ReplaceForTransaction(ctx, fixture.transaction.ID, splits); err != nil {
+			t.Fatalf("Failed to store splits: %v", err)
+		}
+		if err := repos.splits.ReplaceForTransaction(ctx, other.ID, models.NewTransactionSplits(other.ID, []models.SplitData{{CategoryID: home.ID, Amount: 2000}})); err != nil {
+			t.Fatalf("Failed to store splits: %v", err)
+		}
+
+		stored, err := repos.splits.GetByTransactionID(ctx, fixture.transaction.ID)
+		if err != nil {
+			t.Fatalf("Failed to get splits: %v", err)
+		}
+		if len(stored) != 2 || stored[0].CategoryID != food.ID || stored[0].Memo != "market" || stored[1].Amount != 20 || stored[1].Position != 1 {
+			t.Errorf("Expected the splits in order, got %+v", stored)
+		}
+
+		if all, err := repos.splits.GetByTransactionIDs(ctx, []uuid.UUID{fixture.transaction.ID, other.ID}); err != nil || len(all) != 3 {
+			t.Errorf("Expected the splits of both transactions, got %d, %v", len(all), err)
+		}
+
+		if err := repos.splits.DeleteByTransactionID(ctx, fixture.transaction.ID); err != nil {
+			t.Fatalf("Failed to delete splits: %v", err)
+		}
+		if stored, _ := repos.splits.GetByTransactionID(ctx, fixture.transaction.ID); len(stored) != 0 {
+			t.Errorf("Expected no splits after deleting them, got %d", len(stored))
+		}
+	})
+}
+
+func TestRepositoryContract_Payees(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+		fixture := newContractFixture(t, repos)
+
+		category := models.NewCategory(fixture.project.ID, "Food")
+		if err := repos.categories.Create(ctx, category); err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+
+		market := models.NewPayee(fixture.project.ID, "market", &category.ID)
+		bakery := models.NewPayee(fixture.project.ID, "Bakery", nil)
+		for _, payee := range []*models.Payee{market, bakery} {
+			if err := repos.payees.Create(ctx, payee); err != nil {
+				t.Fatalf("Failed to create payee: %v", err)
+			}
+		}
+
+		payees, err := repos.payees.GetByProjectID(ctx, fixture.project.ID)
+		if err != nil {
+			t.Fatalf("Failed to get payees: %v", err)
+		}
+		if len(payees) != 2 || payees[0].ID != bakery.ID || payees[1].ID != market.ID {
+			t.Fatalf("Expected payees by name ignoring case, got %+v", payees)
+		}
+		if payees[1].DefaultCategoryID == nil || *payees[1].DefaultCategoryID != category.ID || payees[0].DefaultCategoryID != nil {
+			t.Errorf("Expected the default categories to round-trip, got %+v", payees)
+		}
+
+		if exists, err := repos.payees.ExistsByName(ctx, fixture.project.ID, "MARKET"); err != nil || !exists {
+			t.Errorf("Expected the name to exist ignoring case, got %v, %v", exists, err)
+		}
+
+		if err := repos.payees.UpdateDefaultCategory(ctx, bakery.ID, &category.ID); err != nil {
+			t.Fatalf("Failed to update default category: %v", err)
+		}
+		if stored, _ := repos.payees.GetByID(ctx, bakery.ID); stored == nil || stored.DefaultCategoryID == nil || *stored.DefaultCategoryID != category.ID {
+			t.Errorf("Expected the default category to be saved, got %+v", stored)
+		}
+		if err := repos.payees.UpdateDefaultCategory(ctx, uuid.New(), nil); err == nil {
+			t.Error("Expected an error when updating a missing payee")
+		}
+
+		alias := models.NewPayeeAlias(bakery, "BAKERY 123")
+		if err := repos.payees.AddAlias(ctx, alias); err != nil {
+			t.Fatalf("Failed to add alias: %v", err)
+		}
+		if err := repos.payees.AddAlias(ctx, models.NewPayeeAlias(market, "BAKERY 123")); err == nil {
+			t.Error("Expected a duplicate alias within a project to be rejected")
+		}
+
+		if err := repos.payees.Merge(ctx, bakery.ID, market.ID); err != nil {
+			t.Fatalf("Failed to merge payees: %v", err)
+		}
+		if _, err := repos.payees.GetByID(ctx, bakery.ID); err == nil {
+			t.Error("Expected the merged payee to be gone")
+		}
+		aliases, err := repos.payees.GetAliasesByProjectID(ctx, fixture.project.ID)
+		if err != nil {
+			t.Fatalf("Failed to get aliases: %v", err)
+		}
+		if len(aliases) != 1 || aliases[0].PayeeID != market.ID || aliases[0].Alias != "BAKERY 123" {
+			t.Errorf("Expected the alias moved to the target payee, got %+v", aliases)
+		}
+
+		if err := repos.payees.DeleteAlias(ctx, alias.ID); err != nil {
+			t.Fatalf("Failed to delete alias: %v", err)
+		}
+		if err := repos.payees.DeleteAlias(ctx, alias.ID); err == nil {
+			t.Error("Expected an error when deleting a missing alias")
+		}
+	})
+}
+
+func TestRepositoryContract_SharedExpensesSettlementsAndTransfers(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+		fixture := newContractFixture(t, repos)
+
+		bob := models.NewAccess(fixture.project.ID, "bob", "hash", "Bob", false)
+		if err := repos.access.Create(ctx, bob); err != nil {
+			t.Fatalf("Failed to create access: %v", err)
+		}
+
+		expense := models.NewSharedExpense(fixture.project.ID, fixture.transaction.ID, fixture.member.ID, models.ShareExact, 120, "PLN")
+		shares := []*models.ExpenseShare{
+			{ID: uuid.New(), ExpenseID: expense.ID, AccessID: bob.ID, Amount: 80},
+			{ID: uuid.New(), ExpenseID: expense.ID, AccessID: fixture.member.ID, Amount: 40},
+		}
+		if err := repos.sharedExpenses.Create(ctx, expense, shares); err != nil {
+			t.Fatalf("Failed to create shared expense: %v", err)
+		}
+
+		stored, storedShares, err := repos.sharedExpenses.GetByTransactionID(ctx, fixture.transaction.ID)
+		if err != nil {
+			t.Fatalf("Failed to get shared expense: %v", err)
+		}
+		if stored.ID != expense.ID || stored.Method != models.ShareExact || stored.PayerID != fixture.member.ID || stored.Currency != "PLN" {
+			t.Errorf("Expected the shared expense to round-trip, got %+v", stored)
+		}
+		if len(storedShares) != 2 || storedShares[0].AccessID != bob.ID || storedShares[1].Amount != 40 {
+			t.Errorf("Expected the shares in order, got %+v", storedShares)
+		}
+		if all, err := repos.sharedExpenses.GetSharesByProjectID(ctx, fixture.project.ID); err != nil || len(all) != 2 {
+			t.Errorf("Expected the shares of the project, got %d, %v", len(all), err)
+		}
+
+		if err := repos.sharedExpenses.DeleteByTransactionID(ctx, fixture.transaction.ID); err != nil {
+			t.Fatalf("Failed to delete shared expense: %v", err)
+		}
+		if _, _, err := repos.sharedExpenses.GetByTransactionID(ctx, fixture.transaction.ID); err == nil {
+			t.Error("Expected the shared expense to be gone")
+		}
+
+		groupID := uuid.New()
+		settlement := models.NewSettlement(fixture.project.ID, bob.ID, fixture.member.ID, 80, "PLN", &groupID)
+		if err := repos.settlements.Create(ctx, settlement); err != nil {
+			t.Fatalf("Failed to create settlement: %v", err)
+		}
+		byGroup, err := repos.settlements.GetByGroupID(ctx, groupID)
+		if err != nil || len(byGroup) != 1 || byGroup[0].FromID != bob.ID || byGroup[0].GroupID == nil || *byGroup[0].GroupID != groupID {
+			t.Errorf("Expected the settlement by group, got %+v, %v", byGroup, err)
+		}
+		if err := repos.settlements.DeleteByID(ctx, settlement.ID); err != nil {
+			t.Fatalf("Failed to delete settlement: %v", err)
+		}
+		if settlements, _ := repos.settlements.GetByProjectID(ctx, fixture.project.ID); len(settlements) != 0 {
+			t.Errorf("Expected no settlements after deleting, got %d", len(settlements))
+		}
+
+		transferGroup := uuid.New()
+		from := fixture.newTransaction(t, repos, "Transfer", -300, contractTime(2026, time.February, 5))
+		to := fixture.newTransaction(t, repos, "Transfer", 300, contractTime(2026, time.February, 5))
+		from.GroupID, to.GroupID = &transferGroup, &transferGroup
+		transfer := models.NewAccountTransfer(fixture.project.ID, from, to)
+		if err := repos.transfers.Create(ctx, transfer); err != nil {
+			t.Fatalf("Failed to create transfer: %v", err)
+		}
+		transfers, err := repos.transfers.GetByGroupID(ctx, transferGroup)
+		if err != nil || len(transfers) != 1 || transfers[0].FromTransactionID != from.ID || transfers[0].ToTransactionID != to.ID || transfers[0].Amount != -300 {
+			t.Errorf("Expected the transfer by group, got %+v, %v", transfers, err)
+		}
+		if err := repos.transfers.DeleteByID(ctx, transfer.ID); err != nil {
+			t.Fatalf("Failed to delete transfer: %v", err)
+		}
+		if err := repos.transfers.DeleteByID(ctx, transfer.ID); err == nil {
+			t.Error("Expected an error when deleting a missing transfer")
+		}
+	})
+}
+
+func TestRepositoryContract_LoansAndInvestments(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+		fixture := newContractFixture(t, repos)
+
+		mortgage := models.NewAccount(fixture.project.ID, "Mortgage", "PLN")
+		mortgage.Type = models.AccountLoan
+		if err := repos.accounts.Create(ctx, mortgage); err != nil {
+			t.Fatalf("Failed to create account: %v", err)
+		}
+
+		loan := models.NewLoan(mortgage, 300000, 6.5, 360, 10, contractTime(2026, time.January, 1))
+		if err := repos.loans.Create(ctx, loan); err != nil {
+			t.Fatalf("Failed to create loan: %v", err)
+		}
+		stored, err := repos.loans.GetByAccountID(ctx, mortgage.ID)
+		if err != nil {
+			t.Fatalf("Failed to get loan: %v", err)
+		}
+		if stored.ProjectID != fixture.project.ID || stored.Principal != 300000 || stored.TermMonths != 360 || !stored.StartDate.Equal(loan.StartDate) {
+			t.Errorf("Expected the loan to round-trip, got %+v", stored)
+		}
+
+		later := models.NewLoanRatePeriod(mortgage.ID, contractTime(2027, time.January, 1), 5.5)
+		earlier := models.NewLoanRatePeriod(mortgage.ID, contractTime(2026, time.July, 1), 6)
+		for _, period := range []*models.LoanRatePeriod{later, earlier} {
+			if err := repos.loans.AddRatePeriod(ctx, period); err != nil {
+				t.Fatalf("Failed to add rate period: %v", err)
+			}
+		}
+		periods, err := repos.loans.GetRatePeriods(ctx, mortgage.ID)
+		if err != nil || len(periods) != 2 || periods[0].ID != earlier.ID || periods[1].AnnualRate != 5.5 {
+			t.Errorf("Expected the rate periods by start, got %+v, %v", periods, err)
+		}
+		if err := repos.loans.DeleteRatePeriod(ctx, later.ID); err != nil {
+			t.Fatalf("Failed to delete rate period: %v", err)
+		}
+
+		security := models.NewSecurity(fixture.project.ID, "vwce", "Vanguard FTSE All-World", "EUR")
+		if err := repos.securities.Create(ctx, security); err != nil {
+			t.Fatalf("Failed to create security: %v", err)
+		}
+		if err := repos.securities.Create(ctx, models.NewSecurity(fixture.project.ID, "VWCE", "", "EUR")); err == nil {
+			t.Error("Expected a duplicate ticker within a project to be rejected")
+		}
+		if byTicker, err := repos.securities.GetByTicker(ctx, fixture.project.ID, " vwce "); err != nil || byTicker.ID != security.ID {
+			t.Errorf("Expected the security by normalized ticker, got %+v, %v", byTicker, err)
+		}
+
+		price := models.NewSecurityPrice(security.ID, contractTime(2026, time.March, 2), 120.5)
+		if err := repos.securities.SetPrice(ctx, price); err != nil {
+			t.Fatalf("Failed to set price: %v", err)
+		}
+		if err := repos.securities.SetPrice(ctx, models.NewSecurityPrice(security.ID, contractTime(2026, time.March, 2), 121)); err != nil {
+			t.Fatalf("Failed to replace price: %v", err)
+		}
+		prices, err := repos.securities.GetPrices(ctx, security.ID)
+		if err != nil || len(prices) != 1 || prices[0].Price != 121 || !prices[0].Date.Equal(price.Date) {
+			t.Errorf("Expected the price of the day replaced, got %+v, %v", prices, err)
+		}
+
+		buy := models.NewInvestmentOperation(fixture.account.ID, security.ID, models.OperationBuy, 2, 120.5, 1, 0, contractTime(2026, time.March, 2))
+		buy.TransactionID = fixture.transaction.ID
+		if err := repos.operations.Create(ctx, buy); err != nil {
+			t.Fatalf("Failed to create investment operation: %v", err)
+		}
+		operations, err := repos.operations.GetByAccountID(ctx, fixture.account.ID)
+		if err != nil || len(operations) != 1 || operations[0].Type != models.OperationBuy || operations[0].Amount != 242 || operations[0].TransactionID != fixture.transaction.ID {
+			t.Errorf("Expected the operation to round-trip, got %+v, %v", operations, err)
+		}
+		if err := repos.operations.Delete(ctx, buy.ID); err != nil {
+			t.Fatalf("Failed to delete investment operation: %v", err)
+		}
+		if err := repos.operations.Delete(ctx, buy.ID); err == nil {
+			t.Error("Expected an error when deleting a missing operation")
+		}
+	})
+}
+
+func TestRepositoryContract_ReconciliationsAndPeriodLocks(t *testing.T) {
+	runContract(t, func(t *testing.T, repos contractRepositories) {
+		ctx := context.Background()
+		fixture := newContractFixture(t, repos)
+
+		reconciliation := models.NewReconciliation(fixture.account.ID, contractTime(2026, time.January, 31), 4879.75)
+		if err := repos.reconciliations.Create(ctx, reconciliation); err != nil {
+			t.Fatalf("Failed to create reconciliation: %v", err)
+		}
+		open, err := repos.reconciliations.GetOpenByAccountID(ctx, fixture.account.ID)
+		if err != nil || open.ID != reconciliation.ID || open.StatementBalance != 4879.75 || open.FinishedAt != nil {
+			t.Fatalf("Expected the open reconciliation, got %+v, %v", open, err)
+		}
+
+		finishedAt := contractTime(2026, time.February, 2)
+		if err := repos.reconciliations.Finish(ctx, reconciliation.ID, finishedAt); err != nil {
+			t.Fatalf("Failed to finish reconciliation: %v", err)
+		}
+		if err := repos.reconciliations.Finish(ctx, reconciliation.ID, finishedAt); err == nil {
+			t.Error("Expected a finished reconciliation not to be finished again")
+		}
+		if _, err := repos.reconciliations.GetOpenByAccountID(ctx, fixture.account.ID); err == nil {
+			t.Error("Expected no open reconciliation after finishing")
+		}
+		finished, err := repos.reconciliations.GetByID(ctx, reconciliation.ID)
+		if err != nil || finished.FinishedAt == nil || !finished.FinishedAt.Equal(finishedAt) {
+			t.Errorf("Expected the reconciliation finished at %s, got %+v, %v", finishedAt, finished, err)
+		}
+		if err := repos.reconciliations.Delete(ctx, reconciliation.ID); err != nil {
+			t.Fatalf("Failed to delete reconciliation: %v", err)
+		}
+		if all, _ := repos.reconciliations.GetByAccountID(ctx, fixture.account.ID); len(all) != 0 {
+			t.Errorf("Expected no reconciliations after deleting, got %d", len(all))
+		}
+
+		lockedUntil := contractTime(2026, time.January, 31)
+		fixture.project.LockedUntil = &lockedUntil
+		closed := models.NewPeriodLockEvent(fixture.project, models.PeriodClosed, nil, "alice", "books closed")
+		closed.CreatedAt = contractTime(2026, time.February, 1)
+		reopened := models.NewPeriodLockEvent(&models.Project{ID: fixture.project.ID}, models.PeriodReopened, &lockedUntil, "alice", "")
+		reopened.CreatedAt = contractTime(2026, time.February, 3)
+		for _, event := range []*models.PeriodLockEvent{closed, reopened} {
+			if err := repos.periodLocks.Create(ctx, event); err != nil {
+				t.Fatalf("Failed to create period lock event: %v", err)
+			}
+		}
+
+		events, err := repos.periodLocks.GetByProjectID(ctx, fixture.project.ID)
+		if err != nil || len(events) != 2 || events[0].ID != reopened.ID || events[1].ID != closed.ID {
+			t.Fatalf("Expected the events newest first, got %+v, %v", events, err)
+		}
+		if events[0].LockedUntil != nil || events[0].PreviousLockedUntil == nil || !events[0].PreviousLockedUntil.Equal(lockedUntil) {
+			t.Errorf("Expected the reopening to round-trip, got %+v", events[0])
+		}
+		if events[1].Action != models.PeriodClosed || events[1].Reason != "books closed" || events[1].LockedUntil == nil || !events[1].LockedUntil.Equal(lockedUntil) {
+			t.Errorf("Expected the closing to round-trip, got %+v", events[1])
+		}
+	})
+}
+
+func assertTransactionOrder(t *testing.T, what string, got []*models.Transaction, expect ...*models.Transaction) {
+	t.Helper()
+
+	if len(got) != len(expect) {
+		t.Errorf("%s: expected %d transactions, got %d", what, len(expect), len(got))
+		return
+	}
+
+	for i := range expect {
+		if got[i].ID != expect[i].ID {
+			t.Errorf("%s: expected %s at %d, got %s", what, expect[i].Name, i, got[i].Name)
+		}
+	}
+}
@@ -1,14 +1,23 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// SchemaVersion is stored in PRAGMA user_version once migrate has run. Bump it
+// whenever a migration is added so readiness checks catch a stale database.
+const SchemaVersion = 19
+
 type Database interface {
 	Close() error
+	Ping(ctx context.Context) error
+	CheckSchema(ctx context.Context) error
+	SnapshotInto(ctx context.Context, path string) error
 }
 
 type DB struct {
@@ -84,6 +93,233 @@ func (db *DB) migrate() error {
 			FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
 		);
 		`,
+		`
+		CREATE TABLE IF NOT EXISTS attachments (
+			id TEXT PRIMARY KEY,
+			transaction_id TEXT NOT NULL,
+			file_name TEXT NOT NULL,
+			content_type TEXT NOT NULL,
+			size INTEGER NOT NULL,
+			checksum TEXT NOT NULL,
+			has_thumbnail BOOLEAN NOT NULL DEFAULT 0,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_attachments_transaction_id ON attachments (transaction_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_attachments_checksum ON attachments (checksum);`,
+		`
+		CREATE TABLE IF NOT EXISTS categories (
+			id TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			name TEXT NOT NULL COLLATE NOCASE,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+			UNIQUE (project_id, name)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS transaction_splits (
+			id TEXT PRIMARY KEY,
+			transaction_id TEXT NOT NULL,
+			category_id TEXT NOT NULL,
+			amount REAL NOT NULL,
+			memo TEXT NOT NULL DEFAULT '',
+			position INTEGER NOT NULL,
+			FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+			FOREIGN KEY (category_id) REFERENCES categories (id)
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_transaction_splits_transaction_id ON transaction_splits (transaction_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS shared_expenses (
+			id TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			transaction_id TEXT NOT NULL UNIQUE,
+			payer_id TEXT NOT NULL,
+			method TEXT NOT NULL CHECK (method IN ('equal', 'percentage', 'exact')),
+			amount REAL NOT NULL,
+			currency TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+			FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+			FOREIGN KEY (payer_id) REFERENCES access (id)
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_shared_expenses_project_id ON shared_expenses (project_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS expense_shares (
+			id TEXT PRIMARY KEY,
+			expense_id TEXT NOT NULL,
+			access_id TEXT NOT NULL,
+			amount REAL NOT NULL,
+			percentage REAL NOT NULL DEFAULT 0,
+			FOREIGN KEY (expense_id) REFERENCES shared_expenses (id) ON DELETE CASCADE,
+			FOREIGN KEY (access_id) REFERENCES access (id)
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_expense_shares_expense_id ON expense_shares (expense_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS settlements (
+			id TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			from_access_id TEXT NOT NULL,
+			to_access_id TEXT NOT NULL,
+			amount REAL NOT NULL,
+			currency TEXT NOT NULL,
+			group_id TEXT,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+			FOREIGN KEY (from_access_id) REFERENCES access (id),
+			FOREIGN KEY (to_access_id) REFERENCES access (id)
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_settlements_project_id ON settlements (project_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS account_transfers (
+			id TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			from_transaction_id TEXT NOT NULL,
+			to_transaction_id TEXT NOT NULL,
+			amount REAL NOT NULL,
+			group_id TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+			FOREIGN KEY (from_transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+			FOREIGN KEY (to_transaction_id) REFERENCES transactions (id) ON DELETE CASCADE
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_account_transfers_project_id ON account_transfers (project_id);`,
+		`CREATE INDEX IF NOT EXISTS idx_account_transfers_group_id ON account_transfers (group_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS payees (
+			id TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			name TEXT NOT NULL COLLATE NOCASE,
+			default_category_id TEXT,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+			FOREIGN KEY (default_category_id) REFERENCES categories (id),
+			UNIQUE (project_id, name)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS payee_aliases (
+			id TEXT PRIMARY KEY,
+			payee_id TEXT NOT NULL,
+			project_id TEXT NOT NULL,
+			alias TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (payee_id) REFERENCES payees (id) ON DELETE CASCADE,
+			UNIQUE (project_id, alias)
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_payee_aliases_payee_id ON payee_aliases (payee_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS loans (
+			account_id TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			principal REAL NOT NULL,
+			annual_rate REAL NOT NULL,
+			term_months INTEGER NOT NULL,
+			payment_day INTEGER NOT NULL,
+			start_date DATETIME NOT NULL,
+			created_at DATETIME NOT NULL,
+			updated_at DATETIME NOT NULL,
+			FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_loans_project_id ON loans (project_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS loan_rate_periods (
+			id TEXT PRIMARY KEY,
+			account_id TEXT NOT NULL,
+			starts_on DATETIME NOT NULL,
+			annual_rate REAL NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (account_id) REFERENCES loans (account_id) ON DELETE CASCADE
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_loan_rate_periods_account_id ON loan_rate_periods (account_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS securities (
+			id TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			ticker TEXT NOT NULL,
+			name TEXT NOT NULL DEFAULT '',
+			currency TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+			UNIQUE (project_id, ticker)
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS security_prices (
+			security_id TEXT NOT NULL,
+			date DATETIME NOT NULL,
+			price REAL NOT NULL,
+			updated_at DATETIME NOT NULL,
+			PRIMARY KEY (security_id, date),
+			FOREIGN KEY (security_id) REFERENCES securities (id) ON DELETE CASCADE
+		);
+		`,
+		`
+		CREATE TABLE IF NOT EXISTS investment_operations (
+			id TEXT PRIMARY KEY,
+			account_id TEXT NOT NULL,
+			security_id TEXT NOT NULL,
+			type TEXT NOT NULL CHECK (type IN ('buy', 'sell', 'dividend')),
+			quantity REAL NOT NULL,
+			price REAL NOT NULL,
+			fees REAL NOT NULL,
+			amount REAL NOT NULL,
+			date DATETIME NOT NULL,
+			transaction_id TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE,
+			FOREIGN KEY (security_id) REFERENCES securities (id)
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_investment_operations_account_id ON investment_operations (account_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS reconciliations (
+			id TEXT PRIMARY KEY,
+			account_id TEXT NOT NULL,
+			statement_date DATETIME NOT NULL,
+			statement_balance REAL NOT NULL,
+			created_at DATETIME NOT NULL,
+			finished_at DATETIME,
+			FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_reconciliations_account_id ON reconciliations (account_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS period_lock_events (
+			id TEXT PRIMARY KEY,
+			project_id TEXT NOT NULL,
+			action TEXT NOT NULL,
+			locked_until DATETIME,
+			previous_locked_until DATETIME,
+			actor TEXT NOT NULL,
+			reason TEXT NOT NULL DEFAULT '',
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+		);
+		`,
+		`CREATE INDEX IF NOT EXISTS idx_period_lock_events_project_id ON period_lock_events (project_id);`,
+		`
+		CREATE TABLE IF NOT EXISTS recovery_codes (
+			id TEXT PRIMARY KEY,
+			access_id TEXT NOT NULL,
+			code_hash TEXT NOT NULL,
+			used_at DATETIME,
+			created_at DATETIME NOT NULL,
+			FOREIGN KEY (access_id) REFERENCES access (id) ON DELETE CASCADE
+		);
+		`,
+		`DROP TABLE IF EXISTS postgres_pairing;`,
 	}
 
 	for _, query := range queries {
@@ -92,6 +328,172 @@ func (db *DB) migrate() error {
 		}
 	}
 
+	columns := []struct {
+		table      string
+		column     string
+		definition string
+	}{
+		{"projects", "require_two_factor", "BOOLEAN NOT NULL DEFAULT 0"},
+		{"projects", "locked_until", "DATETIME"},
+		{"access", "totp_secret", "TEXT NOT NULL DEFAULT ''"},
+		{"access", "totp_enabled", "BOOLEAN NOT NULL DEFAULT 0"},
+		{"access", "totp_last_counter", "INTEGER NOT NULL DEFAULT 0"},
+		{"access", "two_factor_failures", "INTEGER NOT NULL DEFAULT 0"},
+		{"access", "two_factor_failed_at", "DATETIME"},
+		{"transactions", "notes", "TEXT NOT NULL DEFAULT ''"},
+		{"transactions", "category_id", "TEXT"},
+		{"transactions", "payee_id", "TEXT"},
+		{"transactions", "status", "TEXT NOT NULL DEFAULT 'uncleared'"},
+		{"transactions", "external_id", "TEXT NOT NULL DEFAULT ''"},
+		{"accounts", "type", "TEXT NOT NULL DEFAULT 'checking'"},
+		{"accounts", "description", "TEXT NOT NULL DEFAULT ''"},
+		{"accounts", "position", "INTEGER NOT NULL DEFAULT 0"},
+		{"accounts", "archived_at", "DATETIME"},
+		{"accounts", "credit_limit", "REAL NOT NULL DEFAULT 0"},
+		{"accounts", "statement_day", "INTEGER NOT NULL DEFAULT 0"},
+		{"accounts", "due_day", "INTEGER NOT NULL DEFAULT 0"},
+	}
+
+	for _, c := range columns {
+		if err := db.addColumnIfNotExists(c.table, c.column, c.definition); err != nil {
+			return fmt.Errorf("failed to execute migration: %w", err)
+		}
+	}
+
+	// Imported transactions carry the bank's ID, which must not repeat within
+	// an account; hand-entered ones have none.
+	if _, err := db.conn.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_external_id ON transactions (account_id, external_id) WHERE external_id != ''`); err != nil {
+		return fmt.Errorf("failed to execute migration: %w", err)
+	}
+
+	if err := db.createTransactionSearchIndex(); err != nil {
+		return fmt.Errorf("failed to execute migration: %w", err)
+	}
+
+	if _, err := db.conn.Exec(fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion)); err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return nil
+}
+
+func (db *DB) addColumnIfNotExists(table, column, definition string) error {
+	rows, err := db.conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, columnType string
+		var defaultValue sql.NullString
+
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &pk); err != nil {
+			return fmt.Errorf("failed to scan column of %s: %w", table, err)
+		}
+
+		if name == column {
+			return nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating columns of %s: %w", table, err)
+	}
+
+	if _, err := db.conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
+
+	return nil
+}
+
+// searchIndexTriggers keep transactions_fts in sync with transactions.
+var searchIndexTriggers = map[string]string{
+	"transactions_fts_insert": `
+		CREATE TRIGGER transactions_fts_insert AFTER INSERT ON transactions BEGIN
+			INSERT INTO transactions_fts (rowid, name, notes) VALUES (new.rowid, new.name, new.notes);
+		END;
+	`,
+	"transactions_fts_delete": `
+		CREATE TRIGGER transactions_fts_delete AFTER DELETE ON transactions BEGIN
+			INSERT INTO transactions_fts (transactions_fts, rowid, name, notes) VALUES ('delete', old.rowid, old.name, old.notes);
+		END;
+	`,
+	"transactions_fts_update": `
+		CREATE TRIGGER transactions_fts_update AFTER UPDATE ON transactions BEGIN
+			INSERT INTO transactions_fts (transactions_fts, rowid, name, notes) VALUES ('delete', old.rowid, old.name, old.notes);
+			INSERT INTO transactions_fts (rowid, name, notes) VALUES (new.rowid, new.name, new.notes);
+		END;
+	`,
+}
+
+// createTransactionSearchIndex adds an FTS5 index over transaction names and notes,
+// kept in sync by triggers. The sqlite3 driver only ships FTS5 when built with the
+// sqlite_fts5 tag. Without it the triggers are dropped, since every write to
+// transactions would fail on them, and search falls back to LIKE; the next build
+// with FTS5 to open the database restores them and rebuilds the stale index.
+func (db *DB) createTransactionSearchIndex() error {
+	var fts5 bool
+	if err := db.conn.QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&fts5); err != nil {
+		return fmt.Errorf("failed to check for fts5: %w", err)
+	}
+
+	if !fts5 {
+		for name := range searchIndexTriggers {
+			if _, err := db.conn.Exec("DROP TRIGGER IF EXISTS " + name); err != nil {
+				return fmt.Errorf("failed to drop search index trigger: %w", err)
+			}
+		}
+		slog.Info("sqlite built without fts5, transaction search will use LIKE")
+		return nil
+	}
+
+	if _, err := db.conn.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS transactions_fts USING fts5(name, notes, content='transactions', content_rowid='rowid')`); err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+
+	rebuild := false
+	for name, query := range searchIndexTriggers {
+		var exists int
+		if err := db.conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name = ?`, name).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to look up search index trigger: %w", err)
+		}
+		if exists > 0 {
+			continue
+		}
+
+		if _, err := db.conn.Exec(query); err != nil {
+			return fmt.Errorf("failed to create search index: %w", err)
+		}
+		rebuild = true
+	}
+
+	if rebuild {
+		if _, err := db.conn.Exec(`INSERT INTO transactions_fts (transactions_fts) VALUES ('rebuild')`); err != nil {
+			return fmt.Errorf("failed to rebuild search index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) Ping(ctx context.Context) error {
+	return db.conn.PingContext(ctx)
+}
+
+// CheckSchema reports an error when the database was not migrated to SchemaVersion.
+func (db *DB) CheckSchema(ctx context.Context) error {
+	var version int
+	if err := db.conn.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if version != SchemaVersion {
+		return fmt.Errorf("schema version %d, expected %d", version, SchemaVersion)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+	"gofin/pkg/money"
+)
+
+func TestDB_CheckSchema(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	if err := db.Ping(ctx); err != nil {
+		t.Fatalf("Expected ping to succeed, got %v", err)
+	}
+
+	if err := db.CheckSchema(ctx); err != nil {
+		t.Fatalf("Expected migrated database to pass the schema check, got %v", err)
+	}
+
+	if _, err := db.GetConnection().Exec("PRAGMA user_version = 1"); err != nil {
+		t.Fatalf("Failed to downgrade schema version: %v", err)
+	}
+
+	if err := db.CheckSchema(ctx); err == nil {
+		t.Error("Expected schema check to fail for an outdated schema version")
+	}
+}
+
+// TestDB_SearchIndexAcrossBuilds opens a database the way a build with the other
+// FTS5 setting left it: with index triggers this build cannot run, or without the
+// triggers a build lacking FTS5 dropped.
+func TestDB_SearchIndexAcrossBuilds(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := NewDB(path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	var fts5 bool
+	db.GetConnection().QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&fts5)
+	db.Close()
+
+	conn, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	recorder := metrics.NewNoop()
+	project := models.NewProject("Search", "search")
+	account := models.NewAccount(project.ID, "Main", money.PLN)
+	NewProjectSqliteRepository(conn, recorder).Create(ctx, project)
+	NewAccountSqliteRepository(conn, recorder).Create(ctx, account)
+
+	newTransaction := func(name string) *models.Transaction {
+		date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		return models.NewTransaction(models.TransactionData{AccountID: account.ID, Value: -10, Name: name, Type: models.Debit, TransactionDate: &date})
+	}
+
+	if fts5 {
+		for name := range searchIndexTriggers {
+			if _, err := conn.Exec("DROP TRIGGER " + name); err != nil {
+				t.Fatalf("Failed to drop trigger: %v", err)
+			}
+		}
+		if err := NewTransactionSqliteRepository(conn, recorder).Create(ctx, newTransaction("Grocery store")); err != nil {
+			t.Fatalf("Failed to create transaction: %v", err)
+		}
+	} else {
+		if _, err := conn.Exec(searchIndexTriggers["transactions_fts_insert"]); err != nil {
+			t.Fatalf("Failed to create trigger: %v", err)
+		}
+		if err := NewTransactionSqliteRepository(conn, recorder).Create(ctx, newTransaction("Grocery store")); err == nil {
+			t.Fatal("Expected the left over trigger to break inserts")
+		}
+	}
+	conn.Close()
+
+	db, err = NewDB(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewTransactionSqliteRepository(db.GetConnection(), recorder)
+	if err := repo.Create(ctx, newTransaction("Grocery market")); err != nil {
+		t.Fatalf("Expected inserts to work after reopening, got %v", err)
+	}
+
+	page, err := repo.SearchTransactions(ctx, models.TransactionQuery{AccountID: &account.ID, Search: "groc"})
+	if err != nil {
+		t.Fatalf("Expected search to work after reopening, got %v", err)
+	}
+	expected := 1
+	if fts5 {
+		expected = 2
+	}
+	if len(page.Transactions) != expected {
+		t.Errorf("Expected %d matches, got %d", expected, len(page.Transactions))
+	}
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type SecurityInMemoryRepository struct {
+	securities map[string]*models.Security
+	prices     map[string]map[int64]*models.SecurityPrice
+	mu         sync.RWMutex
+}
+
+func NewSecurityInMemoryRepository() *SecurityInMemoryRepository {
+	return &SecurityInMemoryRepository{
+		securities: make(map[string]*models.Security),
+		prices:     make(map[string]map[int64]*models.SecurityPrice),
+	}
+}
+
+func (r *SecurityInMemoryRepository) Create(ctx context.Context, security *models.Security) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.securities {
+		if existing.ProjectID == security.ProjectID && existing.Ticker == security.Ticker {
+			return fmt.Errorf("security '%s' already exists", security.Ticker)
+		}
+	}
+
+	r.securities[security.ID.String()] = security
+	return nil
+}
+
+func (r *SecurityInMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Security, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	security, exists := r.securities[id.String()]
+	if !exists {
+		return nil, fmt.Errorf("security not found")
+	}
+
+	return security, nil
+}
+
+func (r *SecurityInMemoryRepository) GetByTicker(ctx context.Context, projectID uuid.UUID, ticker string) (*models.Security, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ticker = models.NormalizeTicker(ticker)
+	for _, security := range r.securities {
+		if security.ProjectID == projectID && security.Ticker == ticker {
+			return security, nil
+		}
+	}
+
+	return nil, fmt.Errorf("security not found")
+}
+
+func (r *SecurityInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Security, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var securities []*models.Security
+	for _, security := range r.securities {
+		if security.ProjectID == projectID {
+			securities = append(securities, security)
+		}
+	}
+
+	sort.Slice(securities, func(i, j int) bool {
+		return securities[i].Ticker < securities[j].Ticker
+	})
+
+	return securities, nil
+}
+
+func (r *SecurityInMemoryRepository) SetPrice(ctx context.Context, price *models.SecurityPrice) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := price.SecurityID.String()
+	if _, exists := r.securities[key]; !exists {
+		return fmt.Errorf("security not found")
+	}
+
+	if r.prices[key] == nil {
+		r.prices[key] = make(map[int64]*models.SecurityPrice)
+	}
+
+	r.prices[key][price.Date.Unix()] = price
+	return nil
+}
+
+func (r *SecurityInMemoryRepository) GetPrices(ctx context.Context, securityID uuid.UUID) ([]*models.SecurityPrice, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var prices []*models.SecurityPrice
+	for _, price := range r.prices[securityID.String()] {
+		prices = append(prices, price)
+	}
+
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Date.Before(prices[j].Date)
+	})
+
+	return prices, nil
+}
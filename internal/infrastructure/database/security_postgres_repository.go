@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type SecurityPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewSecurityPostgresRepository(db *sql.DB, observer QueryObserver) *SecurityPostgresRepository {
+	return &SecurityPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *SecurityPostgresRepository) Create(ctx context.Context, security *models.Security) error {
+	query := `
+		INSERT INTO securities (` + securityColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		security.ID.String(),
+		security.ProjectID.String(),
+		security.Ticker,
+		security.Name,
+		security.Currency.String(),
+		security.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create security: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SecurityPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Security, error) {
+	query := `SELECT ` + securityColumns + ` FROM securities WHERE id = $1`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanSecurity(row)
+}
+
+func (r *SecurityPostgresRepository) GetByTicker(ctx context.Context, projectID uuid.UUID, ticker string) (*models.Security, error) {
+	query := `SELECT ` + securityColumns + ` FROM securities WHERE project_id = $1 AND ticker = $2`
+
+	row := r.db.QueryRowContext(ctx, query, projectID.String(), models.NormalizeTicker(ticker))
+	return scanSecurity(row)
+}
+
+func (r *SecurityPostgresRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Security, error) {
+	query := `
+		SELECT ` + securityColumns + `
+		FROM securities
+		WHERE project_id = $1
+		ORDER BY ticker ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query securities by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var securities []*models.Security
+	for rows.Next() {
+		security, err := scanSecurity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan security: %w", err)
+		}
+		securities = append(securities, security)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating security rows: %w", err)
+	}
+
+	return securities, nil
+}
+
+func (r *SecurityPostgresRepository) SetPrice(ctx context.Context, price *models.SecurityPrice) error {
+	query := `
+		INSERT INTO security_prices (security_id, date, price, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (security_id, date) DO UPDATE SET price = excluded.price, updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, price.SecurityID.String(), price.Date, price.Price, price.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set security price: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SecurityPostgresRepository) GetPrices(ctx context.Context, securityID uuid.UUID) ([]*models.SecurityPrice, error) {
+	query := `
+		SELECT security_id, date, price, updated_at
+		FROM security_prices
+		WHERE security_id = $1
+		ORDER BY date ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, securityID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query security prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*models.SecurityPrice
+	for rows.Next() {
+		price, err := scanSecurityPrice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan security price: %w", err)
+		}
+		prices = append(prices, price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating security price rows: %w", err)
+	}
+
+	return prices, nil
+}
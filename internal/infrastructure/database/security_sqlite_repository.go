@@ -0,0 +1,178 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+const securityColumns = "id, project_id, ticker, name, currency, created_at"
+
+type SecuritySqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewSecuritySqliteRepository(db *sql.DB, observer QueryObserver) *SecuritySqliteRepository {
+	return &SecuritySqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *SecuritySqliteRepository) Create(ctx context.Context, security *models.Security) error {
+	query := `
+		INSERT INTO securities (` + securityColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		security.ID.String(),
+		security.ProjectID.String(),
+		security.Ticker,
+		security.Name,
+		security.Currency.String(),
+		security.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create security: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SecuritySqliteRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Security, error) {
+	query := `SELECT ` + securityColumns + ` FROM securities WHERE id = ?`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanSecurity(row)
+}
+
+func (r *SecuritySqliteRepository) GetByTicker(ctx context.Context, projectID uuid.UUID, ticker string) (*models.Security, error) {
+	query := `SELECT ` + securityColumns + ` FROM securities WHERE project_id = ? AND ticker = ?`
+
+	row := r.db.QueryRowContext(ctx, query, projectID.String(), models.NormalizeTicker(ticker))
+	return scanSecurity(row)
+}
+
+func (r *SecuritySqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Security, error) {
+	query := `
+		SELECT ` + securityColumns + `
+		FROM securities
+		WHERE project_id = ?
+		ORDER BY ticker ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query securities by project_id: %w", err)
+	}
+	defer rows.Close()
+
+	var securities []*models.Security
+	for rows.Next() {
+		security, err := scanSecurity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan security: %w", err)
+		}
+		securities = append(securities, security)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating security rows: %w", err)
+	}
+
+	return securities, nil
+}
+
+func (r *SecuritySqliteRepository) SetPrice(ctx context.Context, price *models.SecurityPrice) error {
+	query := `
+		INSERT INTO security_prices (security_id, date, price, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (security_id, date) DO UPDATE SET price = excluded.price, updated_at = excluded.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, price.SecurityID.String(), price.Date, price.Price, price.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set security price: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SecuritySqliteRepository) GetPrices(ctx context.Context, securityID uuid.UUID) ([]*models.SecurityPrice, error) {
+	query := `
+		SELECT security_id, date, price, updated_at
+		FROM security_prices
+		WHERE security_id = ?
+		ORDER BY date ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, securityID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query security prices: %w", err)
+	}
+	defer rows.Close()
+
+	var prices []*models.SecurityPrice
+	for rows.Next() {
+		price, err := scanSecurityPrice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan security price: %w", err)
+		}
+		prices = append(prices, price)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating security price rows: %w", err)
+	}
+
+	return prices, nil
+}
+
+func scanSecurity(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Security, error) {
+	var id, projectID, currency string
+	var security models.Security
+
+	err := scanner.Scan(&id, &projectID, &security.Ticker, &security.Name, &currency, &security.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("security not found")
+		}
+		return nil, fmt.Errorf("failed to scan security row: %w", err)
+	}
+
+	if security.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid security ID: %w", err)
+	}
+
+	if security.ProjectID, err = uuid.Parse(projectID); err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+
+	if security.Currency, err = models.ParseCurrency(currency); err != nil {
+		return nil, fmt.Errorf("invalid currency: %w", err)
+	}
+
+	return &security, nil
+}
+
+func scanSecurityPrice(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.SecurityPrice, error) {
+	var id string
+	var price models.SecurityPrice
+
+	err := scanner.Scan(&id, &price.Date, &price.Price, &price.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan security price row: %w", err)
+	}
+
+	if price.SecurityID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid security ID: %w", err)
+	}
+
+	return &price, nil
+}
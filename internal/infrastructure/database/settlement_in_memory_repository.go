@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type SettlementInMemoryRepository struct {
+	settlements map[uuid.UUID]*models.Settlement
+	mu          sync.RWMutex
+}
+
+func NewSettlementInMemoryRepository() *SettlementInMemoryRepository {
+	return &SettlementInMemoryRepository{
+		settlements: make(map[uuid.UUID]*models.Settlement),
+	}
+}
+
+func (r *SettlementInMemoryRepository) Create(ctx context.Context, settlement *models.Settlement) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.settlements[settlement.ID]; exists {
+		return fmt.Errorf("settlement with ID '%s' already exists", settlement.ID)
+	}
+
+	r.settlements[settlement.ID] = settlement
+	return nil
+}
+
+func (r *SettlementInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Settlement, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var settlements []*models.Settlement
+	for _, settlement := range r.settlements {
+		if settlement.ProjectID == projectID {
+			settlements = append(settlements, settlement)
+		}
+	}
+
+	sort.Slice(settlements, func(i, j int) bool {
+		return settlements[i].CreatedAt.After(settlements[j].CreatedAt)
+	})
+
+	return settlements, nil
+}
+
+func (r *SettlementInMemoryRepository) GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*models.Settlement, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var settlements []*models.Settlement
+	for _, settlement := range r.settlements {
+		if settlement.GroupID != nil && *settlement.GroupID == groupID {
+			settlements = append(settlements, settlement)
+		}
+	}
+
+	return settlements, nil
+}
+
+func (r *SettlementInMemoryRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.settlements[id]; !exists {
+		return fmt.Errorf("settlement with ID '%s' not found", id)
+	}
+
+	delete(r.settlements, id)
+	return nil
+}
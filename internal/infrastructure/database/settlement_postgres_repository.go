@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type SettlementPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewSettlementPostgresRepository(db *sql.DB, observer QueryObserver) *SettlementPostgresRepository {
+	return &SettlementPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *SettlementPostgresRepository) Create(ctx context.Context, settlement *models.Settlement) error {
+	query := `
+		INSERT INTO settlements (id, project_id, from_access_id, to_access_id, amount, currency, group_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		settlement.ID.String(),
+		settlement.ProjectID.String(),
+		settlement.FromID.String(),
+		settlement.ToID.String(),
+		settlement.Amount,
+		settlement.Currency.String(),
+		nullableUUID(settlement.GroupID),
+		settlement.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create settlement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SettlementPostgresRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Settlement, error) {
+	query := `
+		SELECT id, project_id, from_access_id, to_access_id, amount, currency, group_id, created_at
+		FROM settlements
+		WHERE project_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settlements: %w", err)
+	}
+	defer rows.Close()
+
+	var settlements []*models.Settlement
+	for rows.Next() {
+		settlement, err := scanSettlement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan settlement: %w", err)
+		}
+		settlements = append(settlements, settlement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating settlement rows: %w", err)
+	}
+
+	return settlements, nil
+}
+
+func (r *SettlementPostgresRepository) GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*models.Settlement, error) {
+	query := `
+		SELECT id, project_id, from_access_id, to_access_id, amount, currency, group_id, created_at
+		FROM settlements
+		WHERE group_id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, groupID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settlements: %w", err)
+	}
+	defer rows.Close()
+
+	var settlements []*models.Settlement
+	for rows.Next() {
+		settlement, err := scanSettlement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan settlement: %w", err)
+		}
+		settlements = append(settlements, settlement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating settlement rows: %w", err)
+	}
+
+	return settlements, nil
+}
+
+func (r *SettlementPostgresRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM settlements WHERE id = $1`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete settlement: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("settlement with ID '%s' not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type SettlementSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewSettlementSqliteRepository(db *sql.DB, observer QueryObserver) *SettlementSqliteRepository {
+	return &SettlementSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *SettlementSqliteRepository) Create(ctx context.Context, settlement *models.Settlement) error {
+	query := `
+		INSERT INTO settlements (id, project_id, from_access_id, to_access_id, amount, currency, group_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		settlement.ID.String(),
+		settlement.ProjectID.String(),
+		settlement.FromID.String(),
+		settlement.ToID.String(),
+		settlement.Amount,
+		settlement.Currency.String(),
+		nullableUUID(settlement.GroupID),
+		settlement.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create settlement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SettlementSqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.Settlement, error) {
+	query := `
+		SELECT id, project_id, from_access_id, to_access_id, amount, currency, group_id, created_at
+		FROM settlements
+		WHERE project_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settlements: %w", err)
+	}
+	defer rows.Close()
+
+	var settlements []*models.Settlement
+	for rows.Next() {
+		settlement, err := scanSettlement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan settlement: %w", err)
+		}
+		settlements = append(settlements, settlement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating settlement rows: %w", err)
+	}
+
+	return settlements, nil
+}
+
+func (r *SettlementSqliteRepository) GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*models.Settlement, error) {
+	query := `
+		SELECT id, project_id, from_access_id, to_access_id, amount, currency, group_id, created_at
+		FROM settlements
+		WHERE group_id = ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, groupID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settlements: %w", err)
+	}
+	defer rows.Close()
+
+	var settlements []*models.Settlement
+	for rows.Next() {
+		settlement, err := scanSettlement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan settlement: %w", err)
+		}
+		settlements = append(settlements, settlement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating settlement rows: %w", err)
+	}
+
+	return settlements, nil
+}
+
+func (r *SettlementSqliteRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM settlements WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete settlement: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("settlement with ID '%s' not found", id)
+	}
+
+	return nil
+}
+
+func scanSettlement(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.Settlement, error) {
+	var id, projectID, fromID, toID, currency string
+	var groupID sql.NullString
+	var amount float64
+	var createdAt time.Time
+
+	if err := scanner.Scan(&id, &projectID, &fromID, &toID, &amount, &currency, &groupID, &createdAt); err != nil {
+		return nil, fmt.Errorf("failed to scan settlement row: %w", err)
+	}
+
+	settlement := &models.Settlement{Amount: amount, CreatedAt: createdAt}
+	var err error
+	if settlement.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid settlement ID: %w", err)
+	}
+	if settlement.ProjectID, err = uuid.Parse(projectID); err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	if settlement.FromID, err = uuid.Parse(fromID); err != nil {
+		return nil, fmt.Errorf("invalid access ID: %w", err)
+	}
+	if settlement.ToID, err = uuid.Parse(toID); err != nil {
+		return nil, fmt.Errorf("invalid access ID: %w", err)
+	}
+	if settlement.Currency, err = models.ParseCurrency(currency); err != nil {
+		return nil, fmt.Errorf("invalid currency: %w", err)
+	}
+	if groupID.Valid {
+		parsed, err := uuid.Parse(groupID.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid group ID: %w", err)
+		}
+		settlement.GroupID = &parsed
+	}
+
+	return settlement, nil
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type SharedExpenseInMemoryRepository struct {
+	expenses map[uuid.UUID]*models.SharedExpense
+	shares   map[uuid.UUID][]*models.ExpenseShare
+	mu       sync.RWMutex
+}
+
+func NewSharedExpenseInMemoryRepository() *SharedExpenseInMemoryRepository {
+	return &SharedExpenseInMemoryRepository{
+		expenses: make(map[uuid.UUID]*models.SharedExpense),
+		shares:   make(map[uuid.UUID][]*models.ExpenseShare),
+	}
+}
+
+func (r *SharedExpenseInMemoryRepository) Create(ctx context.Context, expense *models.SharedExpense, shares []*models.ExpenseShare) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.expenses {
+		if existing.TransactionID == expense.TransactionID {
+			return fmt.Errorf("transaction is already shared")
+		}
+	}
+
+	r.expenses[expense.ID] = expense
+	r.shares[expense.ID] = shares
+	return nil
+}
+
+func (r *SharedExpenseInMemoryRepository) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) (*models.SharedExpense, []*models.ExpenseShare, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, expense := range r.expenses {
+		if expense.TransactionID == transactionID {
+			return expense, r.shares[expense.ID], nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("shared expense not found")
+}
+
+func (r *SharedExpenseInMemoryRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.SharedExpense, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var expenses []*models.SharedExpense
+	for _, expense := range r.expenses {
+		if expense.ProjectID == projectID {
+			expenses = append(expenses, expense)
+		}
+	}
+
+	sort.Slice(expenses, func(i, j int) bool {
+		return expenses[i].CreatedAt.Before(expenses[j].CreatedAt)
+	})
+
+	return expenses, nil
+}
+
+func (r *SharedExpenseInMemoryRepository) GetSharesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.ExpenseShare, error) {
+	expenses, err := r.GetByProjectID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var shares []*models.ExpenseShare
+	for _, expense := range expenses {
+		shares = append(shares, r.shares[expense.ID]...)
+	}
+
+	return shares, nil
+}
+
+func (r *SharedExpenseInMemoryRepository) DeleteByTransactionID(ctx context.Context, transactionID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, expense := range r.expenses {
+		if expense.TransactionID == transactionID {
+			delete(r.expenses, id)
+			delete(r.shares, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type SharedExpensePostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewSharedExpensePostgresRepository(db *sql.DB, observer QueryObserver) *SharedExpensePostgresRepository {
+	return &SharedExpensePostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *SharedExpensePostgresRepository) Create(ctx context.Context, expense *models.SharedExpense, shares []*models.ExpenseShare) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO shared_expenses (id, project_id, transaction_id, payer_id, method, amount, currency, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		expense.ID.String(),
+		expense.ProjectID.String(),
+		expense.TransactionID.String(),
+		expense.PayerID.String(),
+		expense.Method.String(),
+		expense.Amount,
+		expense.Currency.String(),
+		expense.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create shared expense: %w", err)
+	}
+
+	for position, share := range shares {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO expense_shares (id, expense_id, access_id, amount, percentage, position)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, share.ID.String(), expense.ID.String(), share.AccessID.String(), share.Amount, share.Percentage, position)
+		if err != nil {
+			return fmt.Errorf("failed to create expense share: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit shared expense: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SharedExpensePostgresRepository) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) (*models.SharedExpense, []*models.ExpenseShare, error) {
+	query := `
+		SELECT id, project_id, transaction_id, payer_id, method, amount, currency, created_at
+		FROM shared_expenses
+		WHERE transaction_id = $1
+	`
+
+	expense, err := scanSharedExpense(r.db.QueryRowContext(ctx, query, transactionID.String()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shares, err := r.queryShares(ctx, `
+		SELECT id, expense_id, access_id, amount, percentage
+		FROM expense_shares
+		WHERE expense_id = $1
+		ORDER BY position
+	`, expense.ID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return expense, shares, nil
+}
+
+func (r *SharedExpensePostgresRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.SharedExpense, error) {
+	query := `
+		SELECT id, project_id, transaction_id, payer_id, method, amount, currency, created_at
+		FROM shared_expenses
+		WHERE project_id = $1
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shared expenses: %w", err)
+	}
+	defer rows.Close()
+
+	var expenses []*models.SharedExpense
+	for rows.Next() {
+		expense, err := scanSharedExpense(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shared expense: %w", err)
+		}
+		expenses = append(expenses, expense)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shared expense rows: %w", err)
+	}
+
+	return expenses, nil
+}
+
+func (r *SharedExpensePostgresRepository) GetSharesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.ExpenseShare, error) {
+	return r.queryShares(ctx, `
+		SELECT s.id, s.expense_id, s.access_id, s.amount, s.percentage
+		FROM expense_shares s
+		JOIN shared_expenses e ON e.id = s.expense_id
+		WHERE e.project_id = $1
+		ORDER BY e.created_at, e.id, s.position
+	`, projectID.String())
+}
+
+func (r *SharedExpensePostgresRepository) DeleteByTransactionID(ctx context.Context, transactionID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM expense_shares
+		WHERE expense_id IN (SELECT id FROM shared_expenses WHERE transaction_id = $1)
+	`, transactionID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete expense shares: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shared_expenses WHERE transaction_id = $1`, transactionID.String()); err != nil {
+		return fmt.Errorf("failed to delete shared expense: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit shared expense deletion: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SharedExpensePostgresRepository) queryShares(ctx context.Context, query string, args ...interface{}) ([]*models.ExpenseShare, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expense shares: %w", err)
+	}
+	defer rows.Close()
+
+	var shares []*models.ExpenseShare
+	for rows.Next() {
+		share, err := scanExpenseShare(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expense share: %w", err)
+		}
+		shares = append(shares, share)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expense share rows: %w", err)
+	}
+
+	return shares, nil
+}
@@ -0,0 +1,237 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type SharedExpenseSqliteRepository struct {
+	db instrumentedDB
+}
+
+func NewSharedExpenseSqliteRepository(db *sql.DB, observer QueryObserver) *SharedExpenseSqliteRepository {
+	return &SharedExpenseSqliteRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *SharedExpenseSqliteRepository) Create(ctx context.Context, expense *models.SharedExpense, shares []*models.ExpenseShare) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO shared_expenses (id, project_id, transaction_id, payer_id, method, amount, currency, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		expense.ID.String(),
+		expense.ProjectID.String(),
+		expense.TransactionID.String(),
+		expense.PayerID.String(),
+		expense.Method.String(),
+		expense.Amount,
+		expense.Currency.String(),
+		expense.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create shared expense: %w", err)
+	}
+
+	for _, share := range shares {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO expense_shares (id, expense_id, access_id, amount, percentage)
+			VALUES (?, ?, ?, ?, ?)
+		`, share.ID.String(), expense.ID.String(), share.AccessID.String(), share.Amount, share.Percentage)
+		if err != nil {
+			return fmt.Errorf("failed to create expense share: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit shared expense: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SharedExpenseSqliteRepository) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) (*models.SharedExpense, []*models.ExpenseShare, error) {
+	query := `
+		SELECT id, project_id, transaction_id, payer_id, method, amount, currency, created_at
+		FROM shared_expenses
+		WHERE transaction_id = ?
+	`
+
+	expense, err := scanSharedExpense(r.db.QueryRowContext(ctx, query, transactionID.String()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shares, err := r.queryShares(ctx, `
+		SELECT id, expense_id, access_id, amount, percentage
+		FROM expense_shares
+		WHERE expense_id = ?
+		ORDER BY rowid
+	`, expense.ID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return expense, shares, nil
+}
+
+func (r *SharedExpenseSqliteRepository) GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.SharedExpense, error) {
+	query := `
+		SELECT id, project_id, transaction_id, payer_id, method, amount, currency, created_at
+		FROM shared_expenses
+		WHERE project_id = ?
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shared expenses: %w", err)
+	}
+	defer rows.Close()
+
+	var expenses []*models.SharedExpense
+	for rows.Next() {
+		expense, err := scanSharedExpense(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shared expense: %w", err)
+		}
+		expenses = append(expenses, expense)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating shared expense rows: %w", err)
+	}
+
+	return expenses, nil
+}
+
+func (r *SharedExpenseSqliteRepository) GetSharesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*models.ExpenseShare, error) {
+	return r.queryShares(ctx, `
+		SELECT s.id, s.expense_id, s.access_id, s.amount, s.percentage
+		FROM expense_shares s
+		JOIN shared_expenses e ON e.id = s.expense_id
+		WHERE e.project_id = ?
+		ORDER BY s.rowid
+	`, projectID.String())
+}
+
+func (r *SharedExpenseSqliteRepository) DeleteByTransactionID(ctx context.Context, transactionID uuid.UUID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM expense_shares
+		WHERE expense_id IN (SELECT id FROM shared_expenses WHERE transaction_id = ?)
+	`, transactionID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete expense shares: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM shared_expenses WHERE transaction_id = ?`, transactionID.String()); err != nil {
+		return fmt.Errorf("failed to delete shared expense: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit shared expense deletion: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SharedExpenseSqliteRepository) queryShares(ctx context.Context, query string, args ...interface{}) ([]*models.ExpenseShare, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expense shares: %w", err)
+	}
+	defer rows.Close()
+
+	var shares []*models.ExpenseShare
+	for rows.Next() {
+		share, err := scanExpenseShare(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expense share: %w", err)
+		}
+		shares = append(shares, share)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expense share rows: %w", err)
+	}
+
+	return shares, nil
+}
+
+func scanSharedExpense(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.SharedExpense, error) {
+	var id, projectID, transactionID, payerID, method, currency string
+	var amount float64
+	var createdAt time.Time
+
+	err := scanner.Scan(&id, &projectID, &transactionID, &payerID, &method, &amount, &currency, &createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("shared expense not found")
+		}
+		return nil, fmt.Errorf("failed to scan shared expense row: %w", err)
+	}
+
+	expense := &models.SharedExpense{Amount: amount, CreatedAt: createdAt}
+	if expense.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid shared expense ID: %w", err)
+	}
+	if expense.ProjectID, err = uuid.Parse(projectID); err != nil {
+		return nil, fmt.Errorf("invalid project ID: %w", err)
+	}
+	if expense.TransactionID, err = uuid.Parse(transactionID); err != nil {
+		return nil, fmt.Errorf("invalid transaction ID: %w", err)
+	}
+	if expense.PayerID, err = uuid.Parse(payerID); err != nil {
+		return nil, fmt.Errorf("invalid payer ID: %w", err)
+	}
+	if expense.Method, err = models.ParseShareMethod(method); err != nil {
+		return nil, err
+	}
+	if expense.Currency, err = models.ParseCurrency(currency); err != nil {
+		return nil, fmt.Errorf("invalid currency: %w", err)
+	}
+
+	return expense, nil
+}
+
+func scanExpenseShare(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.ExpenseShare, error) {
+	var id, expenseID, accessID string
+	var amount, percentage float64
+
+	if err := scanner.Scan(&id, &expenseID, &accessID, &amount, &percentage); err != nil {
+		return nil, fmt.Errorf("failed to scan expense share row: %w", err)
+	}
+
+	share := &models.ExpenseShare{Amount: amount, Percentage: percentage}
+	var err error
+	if share.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid expense share ID: %w", err)
+	}
+	if share.ExpenseID, err = uuid.Parse(expenseID); err != nil {
+		return nil, fmt.Errorf("invalid expense ID: %w", err)
+	}
+	if share.AccessID, err = uuid.Parse(accessID); err != nil {
+		return nil, fmt.Errorf("invalid access ID: %w", err)
+	}
+
+	return share, nil
+}
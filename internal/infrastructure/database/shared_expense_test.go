@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+	"gofin/pkg/money"
+)
+
+func TestSharedExpenseSqliteRepository(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	recorder := metrics.NewNoop()
+	repo := NewSharedExpenseSqliteRepository(db.GetConnection(), recorder)
+	settlementRepo := NewSettlementSqliteRepository(db.GetConnection(), recorder)
+
+	projectID := uuid.New()
+	payerID, otherID := uuid.New(), uuid.New()
+	transactionID := uuid.New()
+
+	expense := models.NewSharedExpense(projectID, transactionID, payerID, models.SharePercentage, 10, money.PLN)
+	shares, err := models.ComputeShares(expense.ID, models.SharePercentage, 10, []models.ShareData{
+		{AccessID: payerID, Percentage: 33.3},
+		{AccessID: otherID, Percentage: 66.7},
+	})
+	if err != nil {
+		t.Fatalf("Failed to compute shares: %v", err)
+	}
+
+	if err := repo.Create(ctx, expense, shares); err != nil {
+		t.Fatalf("Failed to create shared expense: %v", err)
+	}
+
+	if err := repo.Create(ctx, models.NewSharedExpense(projectID, transactionID, payerID, models.ShareEqual, 10, money.PLN), nil); err == nil {
+		t.Error("Expected a second shared expense for the same transaction to be rejected")
+	}
+
+	stored, storedShares, err := repo.GetByTransactionID(ctx, transactionID)
+	if err != nil {
+		t.Fatalf("Failed to get shared expense: %v", err)
+	}
+	if stored.PayerID != payerID || stored.Method != models.SharePercentage || stored.Currency != money.PLN {
+		t.Errorf("Unexpected shared expense %+v", stored)
+	}
+	if len(storedShares) != 2 || storedShares[0].Amount+storedShares[1].Amount != 10 || storedShares[1].Percentage != 66.7 {
+		t.Errorf("Unexpected shares %+v, %+v", storedShares[0], storedShares[1])
+	}
+
+	projectShares, err := repo.GetSharesByProjectID(ctx, projectID)
+	if err != nil || len(projectShares) != 2 {
+		t.Errorf("Expected 2 project shares, got %d (%v)", len(projectShares), err)
+	}
+
+	if err := repo.DeleteByTransactionID(ctx, transactionID); err != nil {
+		t.Fatalf("Failed to delete shared expense: %v", err)
+	}
+	if projectShares, _ := repo.GetSharesByProjectID(ctx, projectID); len(projectShares) != 0 {
+		t.Errorf("Expected shares to be deleted with the expense, got %d", len(projectShares))
+	}
+
+	groupID := uuid.New()
+	if err := settlementRepo.Create(ctx, models.NewSettlement(projectID, otherID, payerID, 6.67, money.PLN, &groupID)); err != nil {
+		t.Fatalf("Failed to create settlement: %v", err)
+	}
+	settlements, err := settlementRepo.GetByProjectID(ctx, projectID)
+	if err != nil || len(settlements) != 1 {
+		t.Fatalf("Expected 1 settlement, got %d (%v)", len(settlements), err)
+	}
+	if settlements[0].GroupID == nil || *settlements[0].GroupID != groupID || settlements[0].Amount != 6.67 {
+		t.Errorf("Unexpected settlement %+v", settlements[0])
+	}
+
+	if grouped, err := settlementRepo.GetByGroupID(ctx, groupID); err != nil || len(grouped) != 1 || grouped[0].ID != settlements[0].ID {
+		t.Errorf("Expected the settlement by its group, got %v (%v)", grouped, err)
+	}
+	if err := settlementRepo.DeleteByID(ctx, settlements[0].ID); err != nil {
+		t.Fatalf("Failed to delete settlement: %v", err)
+	}
+	if remaining, _ := settlementRepo.GetByProjectID(ctx, projectID); len(remaining) != 0 {
+		t.Errorf("Expected the settlement to be deleted, got %d", len(remaining))
+	}
+	if err := settlementRepo.DeleteByID(ctx, settlements[0].ID); err == nil {
+		t.Error("Expected an error deleting a missing settlement")
+	}
+}
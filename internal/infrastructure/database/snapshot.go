@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SnapshotInto writes a consistent copy of the database to path with VACUUM
+// INTO. It runs inside a read transaction, so the web server can keep writing
+// while the copy is taken. path must not exist yet.
+func (db *DB) SnapshotInto(ctx context.Context, path string) error {
+	if _, err := db.conn.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+	return nil
+}
+
+// TableCount is the number of rows in one table of a database file.
+type TableCount struct {
+	Table string `json:"table"`
+	Rows  int64  `json:"rows"`
+}
+
+// Inspection describes a database file without migrating it.
+type Inspection struct {
+	SchemaVersion int
+	// Integrity is "ok" or the problems PRAGMA integrity_check found.
+	Integrity string
+	Tables    []TableCount
+}
+
+// Inspect opens the existing database file at path and reads its schema
+// version, checks its integrity and counts the rows of every table. Unlike
+// NewDB it never migrates, so a snapshot stays as it was taken. The file is
+// not opened read-only, as checking the search index needs to write, so it
+// should be a copy nothing else uses.
+func Inspect(ctx context.Context, path string) (*Inspection, error) {
+	conn, err := sql.Open("sqlite3", "file:"+(&url.URL{Path: path}).EscapedPath()+"?mode=rw")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer conn.Close()
+
+	inspection := &Inspection{}
+	if err := conn.QueryRowContext(ctx, "PRAGMA user_version").Scan(&inspection.SchemaVersion); err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check integrity: %w", err)
+	}
+	var problems []string
+	for rows.Next() {
+		var problem string
+		if err := rows.Scan(&problem); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan integrity check: %w", err)
+		}
+		problems = append(problems, problem)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating integrity check: %w", err)
+	}
+	inspection.Integrity = strings.Join(problems, "; ")
+
+	tables, err := inspectedTables(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range tables {
+		count := TableCount{Table: table}
+		if err := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT COUNT(*) FROM "%s"`, table)).Scan(&count.Rows); err != nil {
+			return nil, fmt.Errorf("failed to count rows of %s: %w", table, err)
+		}
+		inspection.Tables = append(inspection.Tables, count)
+	}
+
+	return inspection, nil
+}
+
+// inspectedTables lists the tables gofin creates, leaving out SQLite's own and
+// the search index, whose shadow tables need FTS5 to be read.
+func inspectedTables(ctx context.Context, conn *sql.DB) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, `
+		SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name NOT LIKE 'transactions_fts%'
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tables: %w", err)
+	}
+
+	return tables, nil
+}
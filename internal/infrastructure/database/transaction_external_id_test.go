@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+)
+
+func TestTransactionSqliteRepository_ExternalIDs(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	repo := NewTransactionSqliteRepository(db.GetConnection(), metrics.NewNoop())
+
+	accountID := uuid.New()
+	otherAccountID := uuid.New()
+	for _, data := range []models.TransactionData{
+		{AccountID: accountID, Value: 10, Name: "Imported", Type: models.Debit, ExternalID: "FIT-1"},
+		{AccountID: accountID, Value: 20, Name: "Manual", Type: models.Debit},
+		{AccountID: accountID, Value: 30, Name: "Another manual", Type: models.Debit},
+		{AccountID: otherAccountID, Value: 10, Name: "Same ID elsewhere", Type: models.Debit, ExternalID: "FIT-1"},
+	} {
+		if err := repo.Create(ctx, models.NewTransaction(data)); err != nil {
+			t.Fatalf("Failed to create transaction %q: %v", data.Name, err)
+		}
+	}
+
+	duplicate := models.NewTransaction(models.TransactionData{AccountID: accountID, Value: 10, Name: "Imported again", Type: models.Debit, ExternalID: "FIT-1"})
+	if err := repo.Create(ctx, duplicate); err == nil {
+		t.Error("Expected an error importing the same external ID into an account twice")
+	}
+
+	externalIDs, err := repo.GetExternalIDs(ctx, accountID)
+	if err != nil {
+		t.Fatalf("Failed to get external IDs: %v", err)
+	}
+	if len(externalIDs) != 1 || externalIDs[0] != "FIT-1" {
+		t.Errorf("Expected only FIT-1, got %v", externalIDs)
+	}
+
+	transactions, err := repo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		t.Fatalf("Failed to get transactions: %v", err)
+	}
+	for _, transaction := range transactions {
+		if transaction.Name == "Imported" && transaction.ExternalID != "FIT-1" {
+			t.Errorf("Expected the external ID to be read back, got %q", transaction.ExternalID)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -44,7 +45,7 @@ func TestTransactionInMemoryRepository_FutureTransactionFiltering(t *testing.T)
 	}
 
 	for _, tx := range transactions {
-		if err := repo.Create(tx); err != nil {
+		if err := repo.Create(context.Background(), tx); err != nil {
 			t.Fatalf("Failed to create transaction: %v", err)
 		}
 	}
@@ -97,7 +98,7 @@ func TestTransactionInMemoryRepository_FutureTransactionFiltering(t *testing.T)
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			transactions, err := repo.GetTransactionsWithFilters(tt.query)
+			transactions, err := repo.GetTransactionsWithFilters(context.Background(), tt.query)
 			if err != nil {
 				t.Fatalf("Failed to get transactions: %v", err)
 			}
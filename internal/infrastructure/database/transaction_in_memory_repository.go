@@ -1,8 +1,11 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,7 +24,11 @@ func NewTransactionInMemoryRepository() *TransactionInMemoryRepository {
 	}
 }
 
-func (r *TransactionInMemoryRepository) Create(transaction *models.Transaction) error {
+func (r *TransactionInMemoryRepository) Create(ctx context.Context, transaction *models.Transaction) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -30,11 +37,23 @@ func (r *TransactionInMemoryRepository) Create(transaction *models.Transaction)
 		return fmt.Errorf("transaction with ID '%s' already exists", transaction.ID.String())
 	}
 
+	if transaction.ExternalID != "" {
+		for _, existing := range r.transactions {
+			if existing.AccountID == transaction.AccountID && existing.ExternalID == transaction.ExternalID {
+				return fmt.Errorf("transaction with external ID '%s' already exists", transaction.ExternalID)
+			}
+		}
+	}
+
 	r.transactions[key] = transaction
 	return nil
 }
 
-func (r *TransactionInMemoryRepository) GetByAccountID(accountID uuid.UUID) ([]*models.Transaction, error) {
+func (r *TransactionInMemoryRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -45,10 +64,21 @@ func (r *TransactionInMemoryRepository) GetByAccountID(accountID uuid.UUID) ([]*
 		}
 	}
 
+	slices.SortFunc(transactions, func(a, b *models.Transaction) int {
+		if byDate := b.TransactionDate.Compare(a.TransactionDate); byDate != 0 {
+			return byDate
+		}
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
 	return transactions, nil
 }
 
-func (r *TransactionInMemoryRepository) GetByGroupID(groupID uuid.UUID) ([]*models.Transaction, error) {
+func (r *TransactionInMemoryRepository) GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*models.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -59,10 +89,18 @@ func (r *TransactionInMemoryRepository) GetByGroupID(groupID uuid.UUID) ([]*mode
 		}
 	}
 
+	slices.SortFunc(transactions, func(a, b *models.Transaction) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
 	return transactions, nil
 }
 
-func (r *TransactionInMemoryRepository) GetByID(id uuid.UUID) (*models.Transaction, error) {
+func (r *TransactionInMemoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -74,7 +112,11 @@ func (r *TransactionInMemoryRepository) GetByID(id uuid.UUID) (*models.Transacti
 	return transaction, nil
 }
 
-func (r *TransactionInMemoryRepository) GetByAccountIDWithDateRange(accountID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
+func (r *TransactionInMemoryRepository) GetByAccountIDWithDateRange(ctx context.Context, accountID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -87,10 +129,18 @@ func (r *TransactionInMemoryRepository) GetByAccountIDWithDateRange(accountID uu
 		}
 	}
 
+	slices.SortFunc(transactions, func(a, b *models.Transaction) int {
+		return a.TransactionDate.Compare(b.TransactionDate)
+	})
+
 	return transactions, nil
 }
 
-func (r *TransactionInMemoryRepository) GetByProjectIDWithDateRange(projectID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
+func (r *TransactionInMemoryRepository) GetByProjectIDWithDateRange(ctx context.Context, projectID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -101,6 +151,10 @@ func (r *TransactionInMemoryRepository) GetByProjectIDWithDateRange(projectID uu
 		}
 	}
 
+	slices.SortFunc(transactions, func(a, b *models.Transaction) int {
+		return a.TransactionDate.Compare(b.TransactionDate)
+	})
+
 	return transactions, nil
 }
 
@@ -127,37 +181,236 @@ func (r *TransactionInMemoryRepository) isTransactionInDateRangeWithFutureFilter
 	return true
 }
 
-func (r *TransactionInMemoryRepository) GetTransactionsWithFilters(query models.TransactionQuery) ([]*models.Transaction, error) {
+func (r *TransactionInMemoryRepository) GetTransactionsWithFilters(ctx context.Context, query models.TransactionQuery) ([]*models.Transaction, error) {
+	page, err := r.SearchTransactions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Transactions, nil
+}
+
+func (r *TransactionInMemoryRepository) SearchTransactions(ctx context.Context, query models.TransactionQuery) (*models.TransactionPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var cursor *models.TransactionCursor
+	if query.Cursor != "" {
+		decoded, err := models.DecodeTransactionCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = decoded
+	}
+
+	sortBy, direction := query.SortOrDefault()
+	compare := func(a, b *models.Transaction) int {
+		result := models.CompareTransactions(
+			models.NewTransactionCursor(a, sortBy, direction),
+			models.NewTransactionCursor(b, sortBy, direction),
+			sortBy,
+		)
+		if direction == models.SortDescending {
+			return -result
+		}
+		return result
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var transactions []*models.Transaction
 	for _, transaction := range r.transactions {
-		if r.matchesFilters(transaction, query) {
-			transactions = append(transactions, transaction)
+		if !r.matchesFilters(transaction, query) {
+			continue
 		}
+		if cursor != nil {
+			result := models.CompareTransactions(models.NewTransactionCursor(transaction, sortBy, direction), *cursor, sortBy)
+			if direction == models.SortDescending {
+				result = -result
+			}
+			if result <= 0 {
+				continue
+			}
+		}
+		transactions = append(transactions, transaction)
 	}
 
 	sort.Slice(transactions, func(i, j int) bool {
-		return transactions[i].TransactionDate.After(transactions[j].TransactionDate)
+		return compare(transactions[i], transactions[j]) < 0
 	})
 
-	return transactions, nil
+	page := &models.TransactionPage{Transactions: transactions}
+	if query.Limit > 0 && len(transactions) > query.Limit {
+		page.Transactions = transactions[:query.Limit]
+		page.NextCursor = models.NewTransactionCursor(page.Transactions[query.Limit-1], sortBy, direction).Encode()
+	}
+
+	return page, nil
 }
 
 func (r *TransactionInMemoryRepository) matchesFilters(transaction *models.Transaction, query models.TransactionQuery) bool {
-	if query.ProjectID != nil {
-		return r.isTransactionInDateRangeWithFutureFilter(transaction, query.StartDate, query.EndDate, query.ExcludeFutureTransactions)
+	if query.ProjectID == nil && query.AccountID != nil && transaction.AccountID != *query.AccountID {
+		return false
 	}
 
-	if query.AccountID != nil && transaction.AccountID != *query.AccountID {
+	if len(query.AccountIDs) > 0 && !slices.Contains(query.AccountIDs, transaction.AccountID) {
+		return false
+	}
+
+	if query.PayeeID != nil && (transaction.PayeeID == nil || *transaction.PayeeID != *query.PayeeID) {
+		return false
+	}
+
+	if query.MinValue != nil && transaction.Value < *query.MinValue {
+		return false
+	}
+
+	if query.MaxValue != nil && transaction.Value > *query.MaxValue {
+		return false
+	}
+
+	if query.Type != "" && transaction.Type != query.Type {
+		return false
+	}
+
+	if !matchesSearch(transaction, query.Search) {
 		return false
 	}
 
 	return r.isTransactionInDateRangeWithFutureFilter(transaction, query.StartDate, query.EndDate, query.ExcludeFutureTransactions)
 }
 
-func (r *TransactionInMemoryRepository) DeleteByID(id uuid.UUID) error {
+// matchesSearch mirrors the SQLite search: every term must appear in the name or notes.
+func matchesSearch(transaction *models.Transaction, search string) bool {
+	name := strings.ToLower(transaction.Name)
+	notes := strings.ToLower(transaction.Notes)
+
+	for _, term := range searchTerms(search) {
+		term = strings.ToLower(term)
+		if !strings.Contains(name, term) && !strings.Contains(notes, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *TransactionInMemoryRepository) UpdateNotes(ctx context.Context, id uuid.UUID, notes string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transaction, exists := r.transactions[id.String()]
+	if !exists {
+		return fmt.Errorf("transaction not found")
+	}
+
+	transaction.Notes = notes
+	transaction.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *TransactionInMemoryRepository) UpdateCategory(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transaction, exists := r.transactions[id.String()]
+	if !exists {
+		return fmt.Errorf("transaction not found")
+	}
+
+	transaction.CategoryID = categoryID
+	transaction.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *TransactionInMemoryRepository) UpdatePayee(ctx context.Context, id uuid.UUID, payeeID *uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transaction, exists := r.transactions[id.String()]
+	if !exists {
+		return fmt.Errorf("transaction not found")
+	}
+
+	transaction.PayeeID = payeeID
+	transaction.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *TransactionInMemoryRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.TransactionStatus) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transaction, exists := r.transactions[id.String()]
+	if !exists {
+		return fmt.Errorf("transaction not found")
+	}
+
+	transaction.Status = status
+	transaction.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *TransactionInMemoryRepository) GetExternalIDs(ctx context.Context, accountID uuid.UUID) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var externalIDs []string
+	for _, transaction := range r.transactions {
+		if transaction.AccountID == accountID && transaction.ExternalID != "" {
+			externalIDs = append(externalIDs, transaction.ExternalID)
+		}
+	}
+
+	return externalIDs, nil
+}
+
+func (r *TransactionInMemoryRepository) ReassignPayee(ctx context.Context, fromPayeeID, toPayeeID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, transaction := range r.transactions {
+		if transaction.PayeeID != nil && *transaction.PayeeID == fromPayeeID {
+			payeeID := toPayeeID
+			transaction.PayeeID = &payeeID
+			transaction.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+func (r *TransactionInMemoryRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -0,0 +1,375 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type TransactionPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewTransactionPostgresRepository(db *sql.DB, observer QueryObserver) *TransactionPostgresRepository {
+	return &TransactionPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *TransactionPostgresRepository) Create(ctx context.Context, transaction *models.Transaction) error {
+	query := `
+		INSERT INTO transactions (id, account_id, value, name, transaction_date, type, notes, category_id, payee_id, group_id, status, external_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		transaction.ID.String(),
+		transaction.AccountID.String(),
+		transaction.Value,
+		transaction.Name,
+		transaction.TransactionDate,
+		transaction.Type.String(),
+		transaction.Notes,
+		nullableUUID(transaction.CategoryID),
+		nullableUUID(transaction.PayeeID),
+		nullableUUID(transaction.GroupID),
+		transaction.Status.String(),
+		transaction.ExternalID,
+		transaction.CreatedAt,
+		transaction.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TransactionPostgresRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions t
+		WHERE account_id = $1
+		ORDER BY transaction_date DESC, created_at DESC
+	`
+
+	return r.queryTransactions(ctx, "account_id", query, accountID.String())
+}
+
+func (r *TransactionPostgresRepository) GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*models.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions t
+		WHERE group_id = $1
+		ORDER BY created_at ASC
+	`
+
+	return r.queryTransactions(ctx, "group_id", query, groupID.String())
+}
+
+func (r *TransactionPostgresRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions t
+		WHERE id = $1
+	`
+
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanTransaction(row)
+}
+
+func (r *TransactionPostgresRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM transactions WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete transaction: %w", err)
+	}
+
+	return expectTransactionRow(result)
+}
+
+func (r *TransactionPostgresRepository) UpdateNotes(ctx context.Context, id uuid.UUID, notes string) error {
+	query := `UPDATE transactions SET notes = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, notes, time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to update transaction notes: %w", err)
+	}
+
+	return expectTransactionRow(result)
+}
+
+func (r *TransactionPostgresRepository) UpdateCategory(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID) error {
+	query := `UPDATE transactions SET category_id = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, nullableUUID(categoryID), time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to update transaction category: %w", err)
+	}
+
+	return expectTransactionRow(result)
+}
+
+func (r *TransactionPostgresRepository) UpdatePayee(ctx context.Context, id uuid.UUID, payeeID *uuid.UUID) error {
+	query := `UPDATE transactions SET payee_id = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, nullableUUID(payeeID), time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to update transaction payee: %w", err)
+	}
+
+	return expectTransactionRow(result)
+}
+
+func (r *TransactionPostgresRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.TransactionStatus) error {
+	query := `UPDATE transactions SET status = $1, updated_at = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, status.String(), time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	return expectTransactionRow(result)
+}
+
+func (r *TransactionPostgresRepository) GetExternalIDs(ctx context.Context, accountID uuid.UUID) ([]string, error) {
+	query := `SELECT external_id FROM transactions WHERE account_id = $1 AND external_id != ''`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transaction external IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var externalIDs []string
+	for rows.Next() {
+		var externalID string
+		if err := rows.Scan(&externalID); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction external ID: %w", err)
+		}
+		externalIDs = append(externalIDs, externalID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transaction external IDs: %w", err)
+	}
+
+	return externalIDs, nil
+}
+
+func (r *TransactionPostgresRepository) ReassignPayee(ctx context.Context, fromPayeeID, toPayeeID uuid.UUID) error {
+	query := `UPDATE transactions SET payee_id = $1, updated_at = $2 WHERE payee_id = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, toPayeeID.String(), time.Now(), fromPayeeID.String()); err != nil {
+		return fmt.Errorf("failed to reassign transaction payee: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TransactionPostgresRepository) GetByAccountIDWithDateRange(ctx context.Context, accountID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
+	var args postgresArgs
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions t
+		WHERE t.account_id = ` + args.add(accountID.String())
+
+	query += dateRangeCondition(&args, startDate, endDate)
+	query += " ORDER BY t.transaction_date ASC"
+
+	return r.queryTransactions(ctx, "account_id with date range", query, args...)
+}
+
+func (r *TransactionPostgresRepository) GetByProjectIDWithDateRange(ctx context.Context, projectID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
+	var args postgresArgs
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions t
+		JOIN accounts a ON t.account_id = a.id
+		WHERE a.project_id = ` + args.add(projectID.String())
+
+	query += dateRangeCondition(&args, startDate, endDate)
+	query += " ORDER BY t.transaction_date ASC"
+
+	return r.queryTransactions(ctx, "project_id with date range", query, args...)
+}
+
+func (r *TransactionPostgresRepository) GetTransactionsWithFilters(ctx context.Context, query models.TransactionQuery) ([]*models.Transaction, error) {
+	page, err := r.SearchTransactions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Transactions, nil
+}
+
+// SearchTransactions mirrors the SQLite search without its full-text index:
+// every search term must appear in the name or notes, ignoring case. Names sort
+// case-insensitively in byte order, as the cursors compare them.
+func (r *TransactionPostgresRepository) SearchTransactions(ctx context.Context, query models.TransactionQuery) (*models.TransactionPage, error) {
+	var baseQuery string
+	var args postgresArgs
+
+	if query.ProjectID != nil {
+		baseQuery = `
+			SELECT ` + transactionColumns + `
+			FROM transactions t
+			JOIN accounts a ON t.account_id = a.id
+			WHERE a.project_id = ` + args.add(query.ProjectID.String())
+	} else {
+		baseQuery = `
+			SELECT ` + transactionColumns + `
+			FROM transactions t
+			WHERE t.account_id = ` + args.add(query.AccountID.String())
+	}
+
+	if len(query.AccountIDs) > 0 {
+		placeholders := make([]string, len(query.AccountIDs))
+		for i, accountID := range query.AccountIDs {
+			placeholders[i] = args.add(accountID.String())
+		}
+		baseQuery += " AND t.account_id IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	if query.PayeeID != nil {
+		baseQuery += " AND t.payee_id = " + args.add(query.PayeeID.String())
+	}
+
+	baseQuery += dateRangeCondition(&args, query.StartDate, query.EndDate)
+
+	if query.ExcludeFutureTransactions && query.EndDate == nil {
+		baseQuery += " AND t.transaction_date <= " + args.add(time.Now())
+	}
+
+	for _, term := range searchTerms(query.Search) {
+		pattern := args.add("%" + escapeLike(term) + "%")
+		baseQuery += fmt.Sprintf(` AND (t.name ILIKE %[1]s ESCAPE '\' OR t.notes ILIKE %[1]s ESCAPE '\')`, pattern)
+	}
+
+	if query.MinValue != nil {
+		baseQuery += " AND t.value >= " + args.add(*query.MinValue)
+	}
+
+	if query.MaxValue != nil {
+		baseQuery += " AND t.value <= " + args.add(*query.MaxValue)
+	}
+
+	if query.Type != "" {
+		baseQuery += " AND t.type = " + args.add(query.Type.String())
+	}
+
+	sortBy, direction := query.SortOrDefault()
+	sortColumn, comparator, order := "t.transaction_date", "<", "DESC"
+	switch sortBy {
+	case models.SortByValue:
+		sortColumn = "t.value"
+	case models.SortByName:
+		sortColumn = `LOWER(t.name) COLLATE "C"`
+	}
+	if direction == models.SortAscending {
+		comparator, order = ">", "ASC"
+	}
+
+	if query.Cursor != "" {
+		cursor, err := models.DecodeTransactionCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		var cursorValue string
+		switch sortBy {
+		case models.SortByValue:
+			cursorValue = args.add(cursor.Value)
+		case models.SortByName:
+			cursorValue = "LOWER(" + args.add(cursor.Name) + `) COLLATE "C"`
+		default:
+			cursorValue = args.add(cursor.Date)
+		}
+
+		baseQuery += fmt.Sprintf(" AND (%[1]s %[2]s %[3]s OR (%[1]s = %[3]s AND t.id %[2]s %[4]s))", sortColumn, comparator, cursorValue, args.add(cursor.ID.String()))
+	}
+
+	baseQuery += fmt.Sprintf(" ORDER BY %s %s, t.id %s", sortColumn, order, order)
+
+	if query.Limit > 0 {
+		baseQuery += " LIMIT " + args.add(query.Limit+1)
+	}
+
+	transactions, err := r.queryTransactions(ctx, "filters", baseQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.TransactionPage{Transactions: transactions}
+	if query.Limit > 0 && len(transactions) > query.Limit {
+		page.Transactions = transactions[:query.Limit]
+		page.NextCursor = models.NewTransactionCursor(page.Transactions[query.Limit-1], sortBy, direction).Encode()
+	}
+
+	return page, nil
+}
+
+func (r *TransactionPostgresRepository) queryTransactions(ctx context.Context, by, query string, args ...any) ([]*models.Transaction, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transactions by %s: %w", by, err)
+	}
+	defer rows.Close()
+
+	var transactions []*models.Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transaction rows: %w", err)
+	}
+
+	return transactions, nil
+}
+
+func expectTransactionRow(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction not found")
+	}
+
+	return nil
+}
+
+func dateRangeCondition(args *postgresArgs, startDate, endDate *time.Time) string {
+	var condition string
+
+	if startDate != nil {
+		condition += " AND t.transaction_date >= " + args.add(*startDate)
+	}
+
+	if endDate != nil {
+		condition += " AND t.transaction_date <= " + args.add(*endDate)
+	}
+
+	return condition
+}
+
+// postgresArgs collects the arguments of a query built up piece by piece; add
+// returns the numbered placeholder of the value it appends.
+type postgresArgs []any
+
+func (a *postgresArgs) add(value any) string {
+	*a = append(*a, value)
+	return fmt.Sprintf("$%d", len(*a))
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+	"gofin/pkg/money"
+)
+
+func TestTransactionSqliteRepository_SearchTransactions(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	recorder := metrics.NewNoop()
+	projectRepo := NewProjectSqliteRepository(db.GetConnection(), recorder)
+	accountRepo := NewAccountSqliteRepository(db.GetConnection(), recorder)
+	repo := NewTransactionSqliteRepository(db.GetConnection(), recorder)
+
+	project := models.NewProject("Search", "search")
+	if err := projectRepo.Create(ctx, project); err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	account := models.NewAccount(project.ID, "Main", money.PLN)
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatalf("Failed to create account: %v", err)
+	}
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, name := range []string{"Grocery store", "100% refund", "Grocery market", "Rent", "Coffee"} {
+		date := base.AddDate(0, 0, i)
+		transaction := models.NewTransaction(models.TransactionData{
+			AccountID:       account.ID,
+			Value:           float64(10 * (i + 1)),
+			Name:            name,
+			Type:            models.Debit,
+			TransactionDate: &date,
+		}, uuid.New())
+		if i == 3 {
+			transaction.Notes = "paid to the landlord"
+		}
+		if err := repo.Create(ctx, transaction); err != nil {
+			t.Fatalf("Failed to create transaction: %v", err)
+		}
+	}
+
+	search := func(query models.TransactionQuery) *models.TransactionPage {
+		t.Helper()
+		query.ProjectID = &project.ID
+		page, err := repo.SearchTransactions(ctx, query)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return page
+	}
+
+	if page := search(models.TransactionQuery{Search: "grocery"}); len(page.Transactions) != 2 {
+		t.Errorf("Expected 2 grocery transactions, got %d", len(page.Transactions))
+	}
+
+	if page := search(models.TransactionQuery{Search: "landlord"}); len(page.Transactions) != 1 || page.Transactions[0].Name != "Rent" {
+		t.Errorf("Expected notes to be searched, got %d transactions", len(page.Transactions))
+	}
+
+	if page := search(models.TransactionQuery{Search: `"unbalanced`}); len(page.Transactions) != 0 {
+		t.Errorf("Expected no match for a stray quote, got %d transactions", len(page.Transactions))
+	}
+
+	var names []string
+	query := models.TransactionQuery{SortBy: models.SortByName, SortDirection: models.SortAscending, Limit: 2}
+	for {
+		page := search(query)
+		for _, transaction := range page.Transactions {
+			names = append(names, transaction.Name)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	expected := []string{"100% refund", "Coffee", "Grocery market", "Grocery store", "Rent"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, names)
+		}
+	}
+
+	var dates []time.Time
+	query = models.TransactionQuery{Limit: 2}
+	for {
+		page := search(query)
+		for _, transaction := range page.Transactions {
+			dates = append(dates, transaction.TransactionDate)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	if len(dates) != 5 || !dates[0].After(dates[4]) {
+		t.Errorf("Expected 5 transactions newest first across pages, got %v", dates)
+	}
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type TransactionSplitInMemoryRepository struct {
+	splits map[uuid.UUID][]*models.TransactionSplit
+	mu     sync.RWMutex
+}
+
+func NewTransactionSplitInMemoryRepository() *TransactionSplitInMemoryRepository {
+	return &TransactionSplitInMemoryRepository{
+		splits: make(map[uuid.UUID][]*models.TransactionSplit),
+	}
+}
+
+func (r *TransactionSplitInMemoryRepository) ReplaceForTransaction(ctx context.Context, transactionID uuid.UUID, splits []*models.TransactionSplit) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(splits) == 0 {
+		delete(r.splits, transactionID)
+		return nil
+	}
+
+	stored := make([]*models.TransactionSplit, len(splits))
+	for i, split := range splits {
+		split.TransactionID = transactionID
+		stored[i] = split
+	}
+	sort.Slice(stored, func(i, j int) bool {
+		return stored[i].Position < stored[j].Position
+	})
+
+	r.splits[transactionID] = stored
+	return nil
+}
+
+func (r *TransactionSplitInMemoryRepository) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*models.TransactionSplit, error) {
+	return r.GetByTransactionIDs(ctx, []uuid.UUID{transactionID})
+}
+
+func (r *TransactionSplitInMemoryRepository) GetByTransactionIDs(ctx context.Context, transactionIDs []uuid.UUID) ([]*models.TransactionSplit, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var splits []*models.TransactionSplit
+	for _, id := range transactionIDs {
+		splits = append(splits, r.splits[id]...)
+	}
+
+	return splits, nil
+}
+
+func (r *TransactionSplitInMemoryRepository) DeleteByTransactionID(ctx context.Context, transactionID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.splits, transactionID)
+	return nil
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+)
+
+type TransactionSplitPostgresRepository struct {
+	db instrumentedDB
+}
+
+func NewTransactionSplitPostgresRepository(db *sql.DB, observer QueryObserver) *TransactionSplitPostgresRepository {
+	return &TransactionSplitPostgresRepository{db: newInstrumentedDB(db, observer)}
+}
+
+func (r *TransactionSplitPostgresRepository) ReplaceForTransaction(ctx context.Context, transactionID uuid.UUID, splits []*models.TransactionSplit) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM transaction_splits WHERE transaction_id = $1`, transactionID.String()); err != nil {
+		return fmt.Errorf("failed to delete transaction splits: %w", err)
+	}
+
+	query := `
+		INSERT INTO transaction_splits (id, transaction_id, category_id, amount, memo, position)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	for _, split := range splits {
+		_, err := tx.ExecContext(ctx, query, split.ID.String(), transactionID.String(), split.CategoryID.String(), split.Amount, split.Memo, split.Position)
+		if err != nil {
+			return fmt.Errorf("failed to create transaction split: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction splits: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TransactionSplitPostgresRepository) GetByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*models.TransactionSplit, error) {
+	return r.GetByTransactionIDs(ctx, []uuid.UUID{transactionID})
+}
+
+func (r *TransactionSplitPostgresRepository) GetByTransactionIDs(ctx context.Context, transactionIDs []uuid.UUID) ([]*models.TransactionSplit, error) {
+	if len(transactionIDs) == 0 {
+		return nil, nil
+	}
+
+	var args postgresArgs
+	placeholders := make([]string, len(transactionIDs))
+	for i, id := range transactionIDs {
+		placeholders[i] = args.add(id.String())
+	}
+
+	query := `
+		SELECT id, transaction_id, category_id, amount, memo, position
+		FROM transaction_splits
+		WHERE transaction_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY transaction_id, position ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transaction splits: %w", err)
+	}
+	defer rows.Close()
+
+	var splits []*models.TransactionSplit
+	for rows.Next() {
+		split, err := scanTransactionSplit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction split: %w", err)
+		}
+		splits = append(splits, split)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transaction split rows: %w", err)
+	}
+
+	return splits, nil
+}
+
+func (r *TransactionSplitPostgresRepository) DeleteByTransactionID(ctx context.Context, transactionID uuid.UUID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM transaction_splits WHERE transaction_id = $1`, transactionID.String()); err != nil {
+		return fmt.Errorf("failed to delete transaction splits: %w", err)
+	}
+
+	return nil
+}
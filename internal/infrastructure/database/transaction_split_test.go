@@ -0,0 +1,102 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"gofin/internal/models"
+	"gofin/pkg/metrics"
+	"gofin/pkg/money"
+)
+
+func TestTransactionSplitSqliteRepository_ReplaceForTransaction(t *testing.T) {
+	db, err := NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	recorder := metrics.NewNoop()
+	categoryRepo := NewCategorySqliteRepository(db.GetConnection(), recorder)
+	transactionRepo := NewTransactionSqliteRepository(db.GetConnection(), recorder)
+	repo := NewTransactionSplitSqliteRepository(db.GetConnection(), recorder)
+
+	projectID := uuid.New()
+	groceries := models.NewCategory(projectID, "Groceries")
+	pharmacy := models.NewCategory(projectID, "Pharmacy")
+	for _, category := range []*models.Category{groceries, pharmacy} {
+		if err := categoryRepo.Create(ctx, category); err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+	}
+
+	if exists, _ := categoryRepo.ExistsByName(ctx, projectID, "GROCERIES"); !exists {
+		t.Error("Expected category names to match ignoring case")
+	}
+
+	account := models.NewAccount(projectID, "Main", money.PLN)
+	transaction := models.NewTransaction(models.TransactionData{
+		AccountID:  account.ID,
+		Value:      50,
+		Name:       "Receipt",
+		Type:       models.Debit,
+		CategoryID: &groceries.ID,
+	}, uuid.New())
+	if err := transactionRepo.Create(ctx, transaction); err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	stored, err := transactionRepo.GetByID(ctx, transaction.ID)
+	if err != nil {
+		t.Fatalf("Failed to get transaction: %v", err)
+	}
+	if stored.CategoryID == nil || *stored.CategoryID != groceries.ID {
+		t.Fatalf("Expected category %s, got %v", groceries.ID, stored.CategoryID)
+	}
+
+	if err := transactionRepo.UpdateCategory(ctx, transaction.ID, nil); err != nil {
+		t.Fatalf("Failed to clear category: %v", err)
+	}
+	stored, _ = transactionRepo.GetByID(ctx, transaction.ID)
+	if stored.CategoryID != nil {
+		t.Errorf("Expected category to be cleared, got %v", stored.CategoryID)
+	}
+
+	first := models.NewTransactionSplits(transaction.ID, []models.SplitData{
+		{CategoryID: groceries.ID, Amount: 20},
+		{CategoryID: pharmacy.ID, Amount: 30, Memo: "vitamins"},
+	})
+	if err := repo.ReplaceForTransaction(ctx, transaction.ID, first); err != nil {
+		t.Fatalf("Failed to save splits: %v", err)
+	}
+
+	second := models.NewTransactionSplits(transaction.ID, []models.SplitData{
+		{CategoryID: pharmacy.ID, Amount: 10},
+		{CategoryID: groceries.ID, Amount: 40},
+	})
+	if err := repo.ReplaceForTransaction(ctx, transaction.ID, second); err != nil {
+		t.Fatalf("Failed to replace splits: %v", err)
+	}
+
+	splits, err := repo.GetByTransactionIDs(ctx, []uuid.UUID{transaction.ID, uuid.New()})
+	if err != nil {
+		t.Fatalf("Failed to get splits: %v", err)
+	}
+	if len(splits) != 2 {
+		t.Fatalf("Expected 2 splits after replacing, got %d", len(splits))
+	}
+	if splits[0].CategoryID != pharmacy.ID || splits[0].Amount != 10 || splits[1].CategoryID != groceries.ID {
+		t.Errorf("Expected splits in position order, got %+v, %+v", splits[0], splits[1])
+	}
+
+	if err := repo.DeleteByTransactionID(ctx, transaction.ID); err != nil {
+		t.Fatalf("Failed to delete splits: %v", err)
+	}
+	splits, _ = repo.GetByTransactionID(ctx, transaction.ID)
+	if len(splits) != 0 {
+		t.Errorf("Expected no splits after delete, got %d", len(splits))
+	}
+}
@@ -1,26 +1,34 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"gofin/internal/models"
 )
 
+const transactionColumns = "t.id, t.account_id, t.value, t.name, t.transaction_date, t.type, t.notes, t.category_id, t.payee_id, t.group_id, t.status, t.external_id, t.created_at, t.updated_at"
+
 type TransactionSqliteRepository struct {
-	db *sql.DB
+	db instrumentedDB
+
+	searchIndexOnce sync.Once
+	searchIndex     bool
 }
 
-func NewTransactionSqliteRepository(db *sql.DB) *TransactionSqliteRepository {
-	return &TransactionSqliteRepository{db: db}
+func NewTransactionSqliteRepository(db *sql.DB, observer QueryObserver) *TransactionSqliteRepository {
+	return &TransactionSqliteRepository{db: newInstrumentedDB(db, observer)}
 }
 
-func (r *TransactionSqliteRepository) Create(transaction *models.Transaction) error {
+func (r *TransactionSqliteRepository) Create(ctx context.Context, transaction *models.Transaction) error {
 	query := `
-		INSERT INTO transactions (id, account_id, value, name, transaction_date, type, group_id, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO transactions (id, account_id, value, name, transaction_date, type, notes, category_id, payee_id, group_id, status, external_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	var groupID *string
@@ -29,7 +37,7 @@ func (r *TransactionSqliteRepository) Create(transaction *models.Transaction) er
 		groupID = &groupIDStr
 	}
 
-	_, err := r.db.Exec(
+	_, err := r.db.ExecContext(ctx,
 		query,
 		transaction.ID.String(),
 		transaction.AccountID.String(),
@@ -37,7 +45,12 @@ func (r *TransactionSqliteRepository) Create(transaction *models.Transaction) er
 		transaction.Name,
 		transaction.TransactionDate,
 		transaction.Type.String(),
+		transaction.Notes,
+		nullableUUID(transaction.CategoryID),
+		nullableUUID(transaction.PayeeID),
 		groupID,
+		transaction.Status.String(),
+		transaction.ExternalID,
 		transaction.CreatedAt,
 		transaction.UpdatedAt,
 	)
@@ -49,15 +62,15 @@ func (r *TransactionSqliteRepository) Create(transaction *models.Transaction) er
 	return nil
 }
 
-func (r *TransactionSqliteRepository) GetByAccountID(accountID uuid.UUID) ([]*models.Transaction, error) {
+func (r *TransactionSqliteRepository) GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*models.Transaction, error) {
 	query := `
-		SELECT id, account_id, value, name, transaction_date, type, group_id, created_at, updated_at
-		FROM transactions
+		SELECT ` + transactionColumns + `
+		FROM transactions t
 		WHERE account_id = ?
 		ORDER BY transaction_date DESC, created_at DESC
 	`
 
-	rows, err := r.db.Query(query, accountID.String())
+	rows, err := r.db.QueryContext(ctx, query, accountID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query transactions by account_id: %w", err)
 	}
@@ -65,7 +78,7 @@ func (r *TransactionSqliteRepository) GetByAccountID(accountID uuid.UUID) ([]*mo
 
 	var transactions []*models.Transaction
 	for rows.Next() {
-		transaction, err := r.scanTransaction(rows)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
 		}
@@ -79,15 +92,15 @@ func (r *TransactionSqliteRepository) GetByAccountID(accountID uuid.UUID) ([]*mo
 	return transactions, nil
 }
 
-func (r *TransactionSqliteRepository) GetByGroupID(groupID uuid.UUID) ([]*models.Transaction, error) {
+func (r *TransactionSqliteRepository) GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*models.Transaction, error) {
 	query := `
-		SELECT id, account_id, value, name, transaction_date, type, group_id, created_at, updated_at
-		FROM transactions
+		SELECT ` + transactionColumns + `
+		FROM transactions t
 		WHERE group_id = ?
 		ORDER BY created_at ASC
 	`
 
-	rows, err := r.db.Query(query, groupID.String())
+	rows, err := r.db.QueryContext(ctx, query, groupID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query transactions by group_id: %w", err)
 	}
@@ -95,7 +108,7 @@ func (r *TransactionSqliteRepository) GetByGroupID(groupID uuid.UUID) ([]*models
 
 	var transactions []*models.Transaction
 	for rows.Next() {
-		transaction, err := r.scanTransaction(rows)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
 		}
@@ -109,26 +122,26 @@ func (r *TransactionSqliteRepository) GetByGroupID(groupID uuid.UUID) ([]*models
 	return transactions, nil
 }
 
-func (r *TransactionSqliteRepository) GetByID(id uuid.UUID) (*models.Transaction, error) {
+func (r *TransactionSqliteRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
 	query := `
-		SELECT id, account_id, value, name, transaction_date, type, group_id, created_at, updated_at
-		FROM transactions
+		SELECT ` + transactionColumns + `
+		FROM transactions t
 		WHERE id = ?
 	`
 
-	row := r.db.QueryRow(query, id.String())
-	return r.scanTransaction(row)
+	row := r.db.QueryRowContext(ctx, query, id.String())
+	return scanTransaction(row)
 }
 
-func (r *TransactionSqliteRepository) scanTransaction(scanner interface {
+func scanTransaction(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*models.Transaction, error) {
-	var id, accountID, name, transactionType string
+	var id, accountID, name, transactionType, notes, status, externalID string
 	var value float64
 	var transactionDate, createdAt, updatedAt time.Time
-	var groupIDStr sql.NullString
+	var groupIDStr, categoryIDStr, payeeIDStr sql.NullString
 
-	err := scanner.Scan(&id, &accountID, &value, &name, &transactionDate, &transactionType, &groupIDStr, &createdAt, &updatedAt)
+	err := scanner.Scan(&id, &accountID, &value, &name, &transactionDate, &transactionType, &notes, &categoryIDStr, &payeeIDStr, &groupIDStr, &status, &externalID, &createdAt, &updatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("transaction not found")
@@ -151,6 +164,11 @@ func (r *TransactionSqliteRepository) scanTransaction(scanner interface {
 		return nil, fmt.Errorf("invalid transaction type: %w", err)
 	}
 
+	parsedStatus, err := models.ParseTransactionStatus(status)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction status: %w", err)
+	}
+
 	var groupID *uuid.UUID
 	if groupIDStr.Valid && groupIDStr.String != "" {
 		groupUUID, err := uuid.Parse(groupIDStr.String)
@@ -160,6 +178,24 @@ func (r *TransactionSqliteRepository) scanTransaction(scanner interface {
 		groupID = &groupUUID
 	}
 
+	var categoryID *uuid.UUID
+	if categoryIDStr.Valid && categoryIDStr.String != "" {
+		categoryUUID, err := uuid.Parse(categoryIDStr.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category ID: %w", err)
+		}
+		categoryID = &categoryUUID
+	}
+
+	var payeeID *uuid.UUID
+	if payeeIDStr.Valid && payeeIDStr.String != "" {
+		payeeUUID, err := uuid.Parse(payeeIDStr.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payee ID: %w", err)
+		}
+		payeeID = &payeeUUID
+	}
+
 	return &models.Transaction{
 		ID:              transactionID,
 		AccountID:       accountUUID,
@@ -167,16 +203,21 @@ func (r *TransactionSqliteRepository) scanTransaction(scanner interface {
 		Name:            name,
 		TransactionDate: transactionDate,
 		Type:            parsedType,
+		Notes:           notes,
+		CategoryID:      categoryID,
+		PayeeID:         payeeID,
 		GroupID:         groupID,
+		Status:          parsedStatus,
+		ExternalID:      externalID,
 		CreatedAt:       createdAt,
 		UpdatedAt:       updatedAt,
 	}, nil
 }
 
-func (r *TransactionSqliteRepository) DeleteByID(id uuid.UUID) error {
+func (r *TransactionSqliteRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM transactions WHERE id = ?`
 
-	result, err := r.db.Exec(query, id.String())
+	result, err := r.db.ExecContext(ctx, query, id.String())
 	if err != nil {
 		return fmt.Errorf("failed to delete transaction: %w", err)
 	}
@@ -193,9 +234,124 @@ func (r *TransactionSqliteRepository) DeleteByID(id uuid.UUID) error {
 	return nil
 }
 
-func (r *TransactionSqliteRepository) GetByAccountIDWithDateRange(accountID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
+func (r *TransactionSqliteRepository) UpdateNotes(ctx context.Context, id uuid.UUID, notes string) error {
+	query := `UPDATE transactions SET notes = ?, updated_at = ? WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, notes, time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to update transaction notes: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction not found")
+	}
+
+	return nil
+}
+
+func (r *TransactionSqliteRepository) UpdateCategory(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID) error {
+	query := `UPDATE transactions SET category_id = ?, updated_at = ? WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, nullableUUID(categoryID), time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to update transaction category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction not found")
+	}
+
+	return nil
+}
+
+func (r *TransactionSqliteRepository) UpdatePayee(ctx context.Context, id uuid.UUID, payeeID *uuid.UUID) error {
+	query := `UPDATE transactions SET payee_id = ?, updated_at = ? WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, nullableUUID(payeeID), time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to update transaction payee: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction not found")
+	}
+
+	return nil
+}
+
+func (r *TransactionSqliteRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.TransactionStatus) error {
+	query := `UPDATE transactions SET status = ?, updated_at = ? WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, status.String(), time.Now(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction not found")
+	}
+
+	return nil
+}
+
+func (r *TransactionSqliteRepository) GetExternalIDs(ctx context.Context, accountID uuid.UUID) ([]string, error) {
+	query := `SELECT external_id FROM transactions WHERE account_id = ? AND external_id != ''`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transaction external IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var externalIDs []string
+	for rows.Next() {
+		var externalID string
+		if err := rows.Scan(&externalID); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction external ID: %w", err)
+		}
+		externalIDs = append(externalIDs, externalID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating transaction external IDs: %w", err)
+	}
+
+	return externalIDs, nil
+}
+
+func (r *TransactionSqliteRepository) ReassignPayee(ctx context.Context, fromPayeeID, toPayeeID uuid.UUID) error {
+	query := `UPDATE transactions SET payee_id = ?, updated_at = ? WHERE payee_id = ?`
+
+	if _, err := r.db.ExecContext(ctx, query, toPayeeID.String(), time.Now(), fromPayeeID.String()); err != nil {
+		return fmt.Errorf("failed to reassign transaction payee: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TransactionSqliteRepository) GetByAccountIDWithDateRange(ctx context.Context, accountID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
 	query := `
-		SELECT t.id, t.account_id, t.value, t.name, t.transaction_date, t.type, t.group_id, t.created_at, t.updated_at
+		SELECT ` + transactionColumns + `
 		FROM transactions t
 		WHERE t.account_id = ?
 	`
@@ -213,7 +369,7 @@ func (r *TransactionSqliteRepository) GetByAccountIDWithDateRange(accountID uuid
 
 	query += " ORDER BY t.transaction_date ASC"
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query transactions by account_id with date range: %w", err)
 	}
@@ -221,7 +377,7 @@ func (r *TransactionSqliteRepository) GetByAccountIDWithDateRange(accountID uuid
 
 	var transactions []*models.Transaction
 	for rows.Next() {
-		transaction, err := r.scanTransaction(rows)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
 		}
@@ -235,9 +391,9 @@ func (r *TransactionSqliteRepository) GetByAccountIDWithDateRange(accountID uuid
 	return transactions, nil
 }
 
-func (r *TransactionSqliteRepository) GetByProjectIDWithDateRange(projectID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
+func (r *TransactionSqliteRepository) GetByProjectIDWithDateRange(ctx context.Context, projectID uuid.UUID, startDate, endDate *time.Time) ([]*models.Transaction, error) {
 	query := `
-		SELECT t.id, t.account_id, t.value, t.name, t.transaction_date, t.type, t.group_id, t.created_at, t.updated_at
+		SELECT ` + transactionColumns + `
 		FROM transactions t
 		JOIN accounts a ON t.account_id = a.id
 		WHERE a.project_id = ?
@@ -256,7 +412,7 @@ func (r *TransactionSqliteRepository) GetByProjectIDWithDateRange(projectID uuid
 
 	query += " ORDER BY t.transaction_date ASC"
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query transactions by project_id with date range: %w", err)
 	}
@@ -264,7 +420,7 @@ func (r *TransactionSqliteRepository) GetByProjectIDWithDateRange(projectID uuid
 
 	var transactions []*models.Transaction
 	for rows.Next() {
-		transaction, err := r.scanTransaction(rows)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
 		}
@@ -278,13 +434,25 @@ func (r *TransactionSqliteRepository) GetByProjectIDWithDateRange(projectID uuid
 	return transactions, nil
 }
 
-func (r *TransactionSqliteRepository) GetTransactionsWithFilters(query models.TransactionQuery) ([]*models.Transaction, error) {
+func (r *TransactionSqliteRepository) GetTransactionsWithFilters(ctx context.Context, query models.TransactionQuery) ([]*models.Transaction, error) {
+	page, err := r.SearchTransactions(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Transactions, nil
+}
+
+// SearchTransactions returns the transactions matching every filter in query, one
+// page at a time when query.Limit is set. Pages are keyed on the sort value and ID
+// of the last row rather than an offset.
+func (r *TransactionSqliteRepository) SearchTransactions(ctx context.Context, query models.TransactionQuery) (*models.TransactionPage, error) {
 	var baseQuery string
 	var args []interface{}
 
 	if query.ProjectID != nil {
 		baseQuery = `
-			SELECT t.id, t.account_id, t.value, t.name, t.transaction_date, t.type, t.group_id, t.created_at, t.updated_at
+			SELECT ` + transactionColumns + `
 			FROM transactions t
 			JOIN accounts a ON t.account_id = a.id
 			WHERE a.project_id = ?
@@ -292,13 +460,27 @@ func (r *TransactionSqliteRepository) GetTransactionsWithFilters(query models.Tr
 		args = append(args, query.ProjectID.String())
 	} else {
 		baseQuery = `
-			SELECT t.id, t.account_id, t.value, t.name, t.transaction_date, t.type, t.group_id, t.created_at, t.updated_at
+			SELECT ` + transactionColumns + `
 			FROM transactions t
 			WHERE t.account_id = ?
 		`
 		args = append(args, query.AccountID.String())
 	}
 
+	if len(query.AccountIDs) > 0 {
+		placeholders := make([]string, len(query.AccountIDs))
+		for i, accountID := range query.AccountIDs {
+			placeholders[i] = "?"
+			args = append(args, accountID.String())
+		}
+		baseQuery += " AND t.account_id IN (" + strings.Join(placeholders, ", ") + ")"
+	}
+
+	if query.PayeeID != nil {
+		baseQuery += " AND t.payee_id = ?"
+		args = append(args, query.PayeeID.String())
+	}
+
 	if query.StartDate != nil {
 		baseQuery += " AND t.transaction_date >= ?"
 		args = append(args, *query.StartDate)
@@ -314,9 +496,74 @@ func (r *TransactionSqliteRepository) GetTransactionsWithFilters(query models.Tr
 		args = append(args, time.Now())
 	}
 
-	baseQuery += " ORDER BY t.transaction_date DESC"
+	if terms := searchTerms(query.Search); len(terms) > 0 {
+		if r.hasSearchIndex(ctx) {
+			baseQuery += " AND t.rowid IN (SELECT rowid FROM transactions_fts WHERE transactions_fts MATCH ?)"
+			args = append(args, ftsMatchExpression(terms))
+		} else {
+			for _, term := range terms {
+				pattern := "%" + escapeLike(term) + "%"
+				baseQuery += ` AND (t.name LIKE ? ESCAPE '\' OR t.notes LIKE ? ESCAPE '\')`
+				args = append(args, pattern, pattern)
+			}
+		}
+	}
+
+	if query.MinValue != nil {
+		baseQuery += " AND t.value >= ?"
+		args = append(args, *query.MinValue)
+	}
+
+	if query.MaxValue != nil {
+		baseQuery += " AND t.value <= ?"
+		args = append(args, *query.MaxValue)
+	}
+
+	if query.Type != "" {
+		baseQuery += " AND t.type = ?"
+		args = append(args, query.Type.String())
+	}
+
+	sortBy, direction := query.SortOrDefault()
+	sortColumn, comparator, order := "t.transaction_date", "<", "DESC"
+	switch sortBy {
+	case models.SortByValue:
+		sortColumn = "t.value"
+	case models.SortByName:
+		sortColumn = "t.name COLLATE NOCASE"
+	}
+	if direction == models.SortAscending {
+		comparator, order = ">", "ASC"
+	}
+
+	if query.Cursor != "" {
+		cursor, err := models.DecodeTransactionCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		var cursorValue interface{}
+		switch sortBy {
+		case models.SortByValue:
+			cursorValue = cursor.Value
+		case models.SortByName:
+			cursorValue = cursor.Name
+		default:
+			cursorValue = cursor.Date
+		}
+
+		baseQuery += fmt.Sprintf(" AND (%[1]s %[2]s ? OR (%[1]s = ? AND t.id %[2]s ?))", sortColumn, comparator)
+		args = append(args, cursorValue, cursorValue, cursor.ID.String())
+	}
+
+	baseQuery += fmt.Sprintf(" ORDER BY %s %s, t.id %s", sortColumn, order, order)
+
+	if query.Limit > 0 {
+		baseQuery += " LIMIT ?"
+		args = append(args, query.Limit+1)
+	}
 
-	rows, err := r.db.Query(baseQuery, args...)
+	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query transactions with filters: %w", err)
 	}
@@ -324,7 +571,7 @@ func (r *TransactionSqliteRepository) GetTransactionsWithFilters(query models.Tr
 
 	var transactions []*models.Transaction
 	for rows.Next() {
-		transaction, err := r.scanTransaction(rows)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
 		}
@@ -335,5 +582,51 @@ func (r *TransactionSqliteRepository) GetTransactionsWithFilters(query models.Tr
 		return nil, fmt.Errorf("error iterating transaction rows: %w", err)
 	}
 
-	return transactions, nil
+	page := &models.TransactionPage{Transactions: transactions}
+	if query.Limit > 0 && len(transactions) > query.Limit {
+		page.Transactions = transactions[:query.Limit]
+		page.NextCursor = models.NewTransactionCursor(page.Transactions[query.Limit-1], sortBy, direction).Encode()
+	}
+
+	return page, nil
+}
+
+// hasSearchIndex reports whether transactions_fts is kept up to date, which it is
+// only while its triggers exist.
+func (r *TransactionSqliteRepository) hasSearchIndex(ctx context.Context) bool {
+	r.searchIndexOnce.Do(func() {
+		var count int
+		err := r.db.QueryRowContext(context.WithoutCancel(ctx), `SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name = 'transactions_fts_insert'`).Scan(&count)
+		r.searchIndex = err == nil && count > 0
+	})
+
+	return r.searchIndex
+}
+
+func searchTerms(search string) []string {
+	return strings.Fields(search)
+}
+
+// ftsMatchExpression quotes every term so user input is never parsed as FTS5 syntax,
+// and matches each one as a prefix.
+func ftsMatchExpression(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+func escapeLike(term string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(term)
+}
+
+func nullableUUID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	value := id.String()
+	return &value
 }
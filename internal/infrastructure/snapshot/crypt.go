@@ -0,0 +1,221 @@
+package snapshot
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// An encrypted snapshot starts with encryptionMagic, the salt the key is
+// derived from and a random nonce prefix. The data follows in AES-256-GCM
+// sealed chunks; each nonce ends with the chunk number, and the last chunk is
+// sealed with different additional data, so reordered, dropped or truncated
+// chunks all fail to open.
+const (
+	encryptionMagic = "GOFINENC1\n"
+	saltSize        = 16
+	noncePrefixSize = 8
+	chunkSize       = 64 << 10
+)
+
+var (
+	chunkData  = []byte{0}
+	finalChunk = []byte{1}
+
+	errWrongPassphrase = errors.New("wrong passphrase or damaged snapshot")
+)
+
+func isEncrypted(header []byte) bool {
+	return bytes.HasPrefix(header, []byte(encryptionMagic))
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(passphrase), salt, 3, 64*1024, 2, 32)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+type chunkNonce struct {
+	nonce   []byte
+	counter uint32
+}
+
+func (n *chunkNonce) next() []byte {
+	binary.BigEndian.PutUint32(n.nonce[noncePrefixSize:], n.counter)
+	n.counter++
+	return n.nonce
+}
+
+type encryptWriter struct {
+	w     io.Writer
+	aead  cipher.AEAD
+	nonce chunkNonce
+	buf   []byte
+}
+
+func newEncryptWriter(w io.Writer, passphrase string) (*encryptWriter, error) {
+	header := make([]byte, len(encryptionMagic)+saltSize+noncePrefixSize)
+	copy(header, encryptionMagic)
+	if _, err := rand.Read(header[len(encryptionMagic):]); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	salt := header[len(encryptionMagic) : len(encryptionMagic)+saltSize]
+	prefix := header[len(encryptionMagic)+saltSize:]
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write encryption header: %w", err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	copy(nonce, prefix)
+
+	return &encryptWriter{
+		w:     w,
+		aead:  aead,
+		nonce: chunkNonce{nonce: nonce},
+		buf:   make([]byte, 0, chunkSize),
+	}, nil
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is only sealed once more data arrives, so the last
+		// one is always sealed by Close.
+		if len(e.buf) == chunkSize {
+			if err := e.seal(chunkData); err != nil {
+				return written, err
+			}
+		}
+
+		n := min(chunkSize-len(e.buf), len(p))
+		e.buf = append(e.buf, p[:n]...)
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close seals the last chunk. It does not close the underlying writer.
+func (e *encryptWriter) Close() error {
+	return e.seal(finalChunk)
+}
+
+func (e *encryptWriter) seal(additionalData []byte) error {
+	sealed := e.aead.Seal(nil, e.nonce.next(), e.buf, additionalData)
+	e.buf = e.buf[:0]
+
+	if _, err := e.w.Write(sealed); err != nil {
+		return fmt.Errorf("failed to write encrypted snapshot: %w", err)
+	}
+	return nil
+}
+
+type decryptReader struct {
+	r      io.Reader
+	aead   cipher.AEAD
+	nonce  chunkNonce
+	record []byte
+	plain  []byte
+	done   bool
+	err    error
+}
+
+func newDecryptReader(r io.Reader, passphrase string) (*decryptReader, error) {
+	header := make([]byte, len(encryptionMagic)+saltSize+noncePrefixSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read encryption header: %w", err)
+	}
+	if !isEncrypted(header) {
+		return nil, fmt.Errorf("snapshot is not encrypted")
+	}
+	salt := header[len(encryptionMagic) : len(encryptionMagic)+saltSize]
+	prefix := header[len(encryptionMagic)+saltSize:]
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	copy(nonce, prefix)
+
+	return &decryptReader{
+		r:      r,
+		aead:   aead,
+		nonce:  chunkNonce{nonce: nonce},
+		record: make([]byte, chunkSize+aead.Overhead()),
+	}, nil
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		// A chunk that failed to open fails every later read too, rather
+		// than the next one reading past it.
+		if d.err == nil {
+			d.err = d.open()
+		}
+		if d.err != nil {
+			return 0, d.err
+		}
+	}
+
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+func (d *decryptReader) open() error {
+	n, err := io.ReadFull(d.r, d.record)
+	if err == io.EOF {
+		return fmt.Errorf("encrypted snapshot is truncated")
+	}
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return fmt.Errorf("failed to read encrypted snapshot: %w", err)
+	}
+
+	record := d.record[:n]
+	nonce := d.nonce.next()
+
+	// Only a full record can be followed by more chunks.
+	if n == len(d.record) {
+		if plain, err := d.aead.Open(nil, nonce, record, chunkData); err == nil {
+			d.plain = plain
+			return nil
+		}
+	}
+
+	plain, err := d.aead.Open(nil, nonce, record, finalChunk)
+	if err != nil {
+		return errWrongPassphrase
+	}
+
+	var extra [1]byte
+	if _, err := io.ReadFull(d.r, extra[:]); err == nil {
+		return fmt.Errorf("encrypted snapshot has data after its last chunk")
+	}
+
+	d.plain = plain
+	d.done = true
+	return nil
+}
@@ -0,0 +1,54 @@
+package snapshot
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"gofin/internal/infrastructure/database"
+	"gofin/pkg/config"
+	"gofin/pkg/logging"
+)
+
+// Schedule takes a snapshot every cfg.Interval and prunes the old ones until
+// ctx is done. A failed snapshot is logged and retried at the next tick.
+func Schedule(ctx context.Context, db database.Database, cfg config.SnapshotsConfig) {
+	slog.Info("database snapshots scheduled",
+		slog.Duration("interval", cfg.Interval),
+		slog.String("dir", cfg.Dir),
+		slog.Int("keep", cfg.Keep))
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			takeScheduled(ctx, db, cfg)
+		}
+	}
+}
+
+func takeScheduled(ctx context.Context, db database.Database, cfg config.SnapshotsConfig) {
+	start := time.Now()
+	manifest, err := Create(ctx, db, cfg)
+	if err != nil {
+		slog.Error("failed to snapshot database", logging.Err(err))
+		return
+	}
+
+	slog.Info("database snapshot taken",
+		slog.String("file", manifest.File),
+		slog.Int64("size", manifest.Size),
+		slog.Duration("duration", time.Since(start)))
+
+	removed, err := Prune(cfg.Dir, cfg.Keep)
+	if err != nil {
+		slog.Error("failed to prune database snapshots", logging.Err(err))
+	}
+	for _, old := range removed {
+		slog.Info("database snapshot pruned", slog.String("file", old.File))
+	}
+}
@@ -0,0 +1,446 @@
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gofin/internal/infrastructure/database"
+	"gofin/pkg/config"
+)
+
+// A snapshot is a data file named after the time it was taken, e.g.
+// gofin-20261019T084500Z.db.gz.enc, next to a manifest with the same stem and
+// a .json extension. The manifest is written last, so a snapshot without one
+// was never finished and is not listed.
+const (
+	namePrefix     = "gofin-"
+	nameTimeFormat = "20060102T150405Z"
+	dataExt        = ".db"
+	gzipExt        = ".gz"
+	encryptedExt   = ".enc"
+	manifestExt    = ".json"
+	partialExt     = ".partial"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Manifest describes a snapshot as it was taken, so verify can tell whether
+// the data file still matches.
+type Manifest struct {
+	File          string                `json:"file"`
+	CreatedAt     time.Time             `json:"created_at"`
+	SchemaVersion int                   `json:"schema_version"`
+	Compressed    bool                  `json:"compressed"`
+	Encrypted     bool                  `json:"encrypted"`
+	Size          int64                 `json:"size"`
+	SHA256        string                `json:"sha256"`
+	Tables        []database.TableCount `json:"tables"`
+}
+
+func (m *Manifest) stem() string {
+	return namePrefix + m.CreatedAt.UTC().Format(nameTimeFormat)
+}
+
+// Create takes a snapshot of db into cfg.Dir while it stays in use, then
+// compresses and encrypts it as cfg asks.
+func Create(ctx context.Context, db database.Database, cfg config.SnapshotsConfig) (*Manifest, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create snapshots directory: %w", err)
+	}
+
+	manifest := &Manifest{CreatedAt: time.Now().UTC()}
+	stem := manifest.stem()
+	manifestPath := filepath.Join(cfg.Dir, stem+manifestExt)
+	if _, err := os.Stat(manifestPath); err == nil {
+		return nil, fmt.Errorf("snapshot %s already exists", stem)
+	}
+
+	raw := filepath.Join(cfg.Dir, stem+dataExt+partialExt)
+	os.Remove(raw)
+	defer os.Remove(raw)
+
+	if err := db.SnapshotInto(ctx, raw); err != nil {
+		return nil, err
+	}
+
+	inspection, err := database.Inspect(ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect snapshot: %w", err)
+	}
+	if inspection.Integrity != "ok" {
+		return nil, fmt.Errorf("snapshot failed the integrity check: %s", inspection.Integrity)
+	}
+
+	manifest.File = stem + dataExt
+	manifest.SchemaVersion = inspection.SchemaVersion
+	manifest.Compressed = cfg.Compress
+	manifest.Encrypted = cfg.Passphrase != ""
+	manifest.Tables = inspection.Tables
+	if manifest.Compressed {
+		manifest.File += gzipExt
+	}
+	if manifest.Encrypted {
+		manifest.File += encryptedExt
+	}
+
+	if err := encode(raw, filepath.Join(cfg.Dir, manifest.File), cfg, manifest); err != nil {
+		return nil, err
+	}
+
+	if err := writeManifest(manifestPath, manifest); err != nil {
+		os.Remove(filepath.Join(cfg.Dir, manifest.File))
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// encode copies the raw snapshot to path, gzipping and then encrypting it,
+// and records the size and checksum of what was written in manifest.
+func encode(raw, path string, cfg config.SnapshotsConfig, manifest *Manifest) error {
+	in, err := os.Open(raw)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+
+	hash := sha256.New()
+	var w io.Writer = io.MultiWriter(out, hash)
+	var closers []io.Closer
+
+	if cfg.Passphrase != "" {
+		encrypter, err := newEncryptWriter(w, cfg.Passphrase)
+		if err != nil {
+			out.Close()
+			os.Remove(path)
+			return err
+		}
+		w = encrypter
+		closers = append(closers, encrypter)
+	}
+
+	if cfg.Compress {
+		compressor := gzip.NewWriter(w)
+		w = compressor
+		closers = append(closers, compressor)
+	}
+
+	_, err = io.Copy(w, in)
+	for i := len(closers) - 1; i >= 0 && err == nil; i-- {
+		err = closers[i].Close()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	manifest.Size = info.Size()
+	manifest.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return nil
+}
+
+func writeManifest(path string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot manifest: %w", err)
+	}
+
+	partial := path + partialExt
+	if err := os.WriteFile(partial, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+	if err := os.Rename(partial, path); err != nil {
+		os.Remove(partial)
+		return fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+	return nil
+}
+
+func readManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot manifest %s: %w", filepath.Base(path), err)
+	}
+	if manifest.File == "" || filepath.Base(manifest.File) != manifest.File {
+		return nil, fmt.Errorf("snapshot manifest %s names no data file", filepath.Base(path))
+	}
+
+	return &manifest, nil
+}
+
+// List returns the finished snapshots in dir, newest first.
+func List(dir string) ([]*Manifest, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, namePrefix+"*"+manifestExt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	manifests := make([]*Manifest, 0, len(paths))
+	for _, path := range paths {
+		manifest, err := readManifest(path)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].CreatedAt.After(manifests[j].CreatedAt)
+	})
+
+	return manifests, nil
+}
+
+// Prune deletes all but the newest keep snapshots in dir and returns the
+// deleted ones. keep zero keeps every snapshot.
+func Prune(dir string, keep int) ([]*Manifest, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+
+	manifests, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifests) <= keep {
+		return nil, nil
+	}
+
+	var removed []*Manifest
+	for _, manifest := range manifests[keep:] {
+		// The manifest goes first, so a failure part way leaves an unlisted
+		// data file rather than a listed snapshot without one.
+		if err := os.Remove(filepath.Join(dir, manifest.stem()+manifestExt)); err != nil {
+			return removed, fmt.Errorf("failed to delete snapshot: %w", err)
+		}
+		if err := os.Remove(filepath.Join(dir, manifest.File)); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to delete snapshot: %w", err)
+		}
+		removed = append(removed, manifest)
+	}
+
+	return removed, nil
+}
+
+// Verification is what verify found in a snapshot. Manifest is nil when the
+// snapshot has none, in which case only its integrity and schema version are
+// checked.
+type Verification struct {
+	Manifest   *Manifest
+	Inspection *database.Inspection
+	Problems   []string
+}
+
+func (v *Verification) OK() bool {
+	return len(v.Problems) == 0
+}
+
+// Verify decrypts and decompresses the snapshot at path, which may name the
+// data file or its manifest, into a temporary file and opens it. The snapshot
+// must pass SQLite's integrity check, must not be newer than this build's
+// schema and must match its manifest's checksum, schema version and row
+// counts.
+func Verify(ctx context.Context, path, passphrase string) (*Verification, error) {
+	dataPath, manifest, err := resolve(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(dataPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer file.Close()
+
+	tmp, err := os.CreateTemp("", "gofin-verify-*"+dataExt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	raw := io.TeeReader(file, hash)
+	if err := decode(tmp, raw, passphrase); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if _, err := io.Copy(io.Discard, raw); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	inspection, err := database.Inspect(ctx, tmp.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &Verification{Manifest: manifest, Inspection: inspection}
+	problem := func(format string, args ...any) {
+		verification.Problems = append(verification.Problems, fmt.Sprintf(format, args...))
+	}
+
+	if inspection.Integrity != "ok" {
+		problem("integrity check failed: %s", inspection.Integrity)
+	}
+	if inspection.SchemaVersion > database.SchemaVersion {
+		problem("schema version %d is newer than %d this build knows", inspection.SchemaVersion, database.SchemaVersion)
+	}
+
+	if manifest != nil {
+		if sum := hex.EncodeToString(hash.Sum(nil)); sum != manifest.SHA256 {
+			problem("checksum %s does not match the manifest's %s", sum, manifest.SHA256)
+		}
+		if inspection.SchemaVersion != manifest.SchemaVersion {
+			problem("schema version %d, the manifest says %d", inspection.SchemaVersion, manifest.SchemaVersion)
+		}
+
+		expected := make(map[string]int64, len(manifest.Tables))
+		for _, table := range manifest.Tables {
+			expected[table.Table] = table.Rows
+		}
+		for _, table := range inspection.Tables {
+			rows, ok := expected[table.Table]
+			if !ok {
+				problem("table %s is not in the manifest", table.Table)
+				continue
+			}
+			if rows != table.Rows {
+				problem("table %s has %d rows, the manifest says %d", table.Table, table.Rows, rows)
+			}
+			delete(expected, table.Table)
+		}
+		for table := range expected {
+			problem("table %s is missing", table)
+		}
+	}
+
+	return verification, nil
+}
+
+// Extract writes the plain database held by the snapshot at path to output,
+// which must not exist yet, e.g. to run the web server on it.
+func Extract(path, output, passphrase string) error {
+	dataPath, _, err := resolve(path)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(dataPath)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer file.Close()
+
+	out, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create database file: %w", err)
+	}
+
+	err = decode(out, file, passphrase)
+	if closeErr := out.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write database file: %w", closeErr)
+	}
+	if err != nil {
+		os.Remove(output)
+		return err
+	}
+	return nil
+}
+
+// resolve finds the data file and the manifest, if any, of the snapshot at path.
+func resolve(path string) (string, *Manifest, error) {
+	if strings.HasSuffix(path, manifestExt) {
+		manifest, err := readManifest(path)
+		if err != nil {
+			return "", nil, err
+		}
+		return filepath.Join(filepath.Dir(path), manifest.File), manifest, nil
+	}
+
+	stem := filepath.Base(path)
+	for _, ext := range []string{encryptedExt, gzipExt, dataExt} {
+		stem = strings.TrimSuffix(stem, ext)
+	}
+
+	manifestPath := filepath.Join(filepath.Dir(path), stem+manifestExt)
+	if _, err := os.Stat(manifestPath); err != nil {
+		return path, nil, nil
+	}
+
+	manifest, err := readManifest(manifestPath)
+	if err != nil {
+		return "", nil, err
+	}
+	if manifest.File != filepath.Base(path) {
+		return path, nil, nil
+	}
+	return path, manifest, nil
+}
+
+// decode writes the plain database held by r to w, telling encrypted and
+// gzipped snapshots apart by their first bytes.
+func decode(w io.Writer, r io.Reader, passphrase string) error {
+	buffered := bufio.NewReader(r)
+	header, _ := buffered.Peek(len(encryptionMagic))
+
+	var plain io.Reader = buffered
+	if isEncrypted(header) {
+		if passphrase == "" {
+			return fmt.Errorf("snapshot is encrypted, a passphrase is required")
+		}
+		decrypter, err := newDecryptReader(buffered, passphrase)
+		if err != nil {
+			return err
+		}
+		plain = decrypter
+	}
+
+	plainBuffered := bufio.NewReader(plain)
+	header, _ = plainBuffered.Peek(len(gzipMagic))
+	plain = plainBuffered
+
+	if len(header) == len(gzipMagic) && header[0] == gzipMagic[0] && header[1] == gzipMagic[1] {
+		decompressor, err := gzip.NewReader(plainBuffered)
+		if err != nil {
+			return fmt.Errorf("failed to decompress snapshot: %w", err)
+		}
+		defer decompressor.Close()
+		plain = decompressor
+	}
+
+	if _, err := io.Copy(w, plain); err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return nil
+}
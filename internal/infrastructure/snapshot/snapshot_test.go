@@ -0,0 +1,191 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gofin/internal/infrastructure/database"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	"gofin/pkg/metrics"
+)
+
+func TestEncryption_RoundTrip(t *testing.T) {
+	for _, size := range []int{0, 10, chunkSize, 2*chunkSize + 7} {
+		plain := make([]byte, size)
+		rand.Read(plain)
+
+		var sealed bytes.Buffer
+		encrypter, err := newEncryptWriter(&sealed, "secret")
+		if err != nil {
+			t.Fatalf("Failed to create encrypter: %v", err)
+		}
+		encrypter.Write(plain)
+		if err := encrypter.Close(); err != nil {
+			t.Fatalf("Failed to close encrypter: %v", err)
+		}
+
+		decrypter, err := newDecryptReader(bytes.NewReader(sealed.Bytes()), "secret")
+		if err != nil {
+			t.Fatalf("Failed to create decrypter: %v", err)
+		}
+		opened, err := io.ReadAll(decrypter)
+		if err != nil {
+			t.Fatalf("Size %d: expected no error, got %v", size, err)
+		}
+		if !bytes.Equal(opened, plain) {
+			t.Errorf("Size %d: decrypted data differs", size)
+		}
+
+		wrong, _ := newDecryptReader(bytes.NewReader(sealed.Bytes()), "wrong")
+		if _, err := io.ReadAll(wrong); err == nil {
+			t.Errorf("Size %d: expected the wrong passphrase to fail", size)
+		}
+
+		if size > chunkSize {
+			truncated, _ := newDecryptReader(bytes.NewReader(sealed.Bytes()[:sealed.Len()-1]), "secret")
+			if _, err := io.ReadAll(truncated); err == nil {
+				t.Errorf("Size %d: expected a truncated snapshot to fail", size)
+			}
+		}
+	}
+}
+
+func TestCreateAndVerify(t *testing.T) {
+	ctx := context.Background()
+	db, err := database.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	projects := database.NewProjectSqliteRepository(db.GetConnection(), metrics.NewNoop())
+	projects.Create(ctx, models.NewProject("Home", "home"))
+
+	tests := []struct {
+		name        string
+		compress    bool
+		passphrase  string
+		verifyWith  string
+		tamper      func(t *testing.T, dir string, manifest *Manifest)
+		expectError string
+		expectIssue string
+	}{
+		{name: "plain"},
+		{name: "compressed", compress: true},
+		{name: "compressed and encrypted", compress: true, passphrase: "secret", verifyWith: "secret"},
+		{name: "encrypted without passphrase", passphrase: "secret", expectError: "passphrase is required"},
+		{name: "wrong passphrase", passphrase: "secret", verifyWith: "wrong", expectError: "wrong passphrase"},
+		{
+			name: "row counts edited",
+			tamper: func(t *testing.T, dir string, manifest *Manifest) {
+				for i := range manifest.Tables {
+					if manifest.Tables[i].Table == "projects" {
+						manifest.Tables[i].Rows = 2
+					}
+				}
+				writeManifest(filepath.Join(dir, manifest.stem()+manifestExt), manifest)
+			},
+			expectIssue: "table projects has 1 rows, the manifest says 2",
+		},
+		{
+			name: "data file changed",
+			tamper: func(t *testing.T, dir string, manifest *Manifest) {
+				file, err := os.OpenFile(filepath.Join(dir, manifest.File), os.O_WRONLY|os.O_APPEND, 0)
+				if err != nil {
+					t.Fatalf("Failed to open snapshot: %v", err)
+				}
+				file.Write(make([]byte, 4096))
+				file.Close()
+			},
+			expectIssue: "checksum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.SnapshotsConfig{Dir: t.TempDir(), Compress: tt.compress, Passphrase: tt.passphrase}
+			manifest, err := Create(ctx, db, cfg)
+			if err != nil {
+				t.Fatalf("Failed to create snapshot: %v", err)
+			}
+
+			if tt.tamper != nil {
+				tt.tamper(t, cfg.Dir, manifest)
+			}
+
+			verification, err := Verify(ctx, filepath.Join(cfg.Dir, manifest.File), tt.verifyWith)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if tt.expectIssue != "" {
+				if verification.OK() || !strings.Contains(strings.Join(verification.Problems, "; "), tt.expectIssue) {
+					t.Fatalf("Expected a problem containing %q, got %v", tt.expectIssue, verification.Problems)
+				}
+				return
+			}
+			if !verification.OK() {
+				t.Fatalf("Expected the snapshot to verify, got %v", verification.Problems)
+			}
+			if verification.Manifest == nil || verification.Inspection.SchemaVersion != database.SchemaVersion {
+				t.Errorf("Expected the manifest and schema version %d, got %+v", database.SchemaVersion, verification.Inspection)
+			}
+
+			output := filepath.Join(t.TempDir(), "restored.db")
+			if err := Extract(filepath.Join(cfg.Dir, manifest.stem()+manifestExt), output, tt.verifyWith); err != nil {
+				t.Fatalf("Failed to extract snapshot: %v", err)
+			}
+			restored, err := database.NewDB(output)
+			if err != nil {
+				t.Fatalf("Failed to open extracted snapshot: %v", err)
+			}
+			defer restored.Close()
+			if exists, _ := database.NewProjectSqliteRepository(restored.GetConnection(), metrics.NewNoop()).ExistsBySlug(ctx, "home"); !exists {
+				t.Error("Expected the extracted snapshot to hold the project")
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	for day := 0; day < 5; day++ {
+		manifest := &Manifest{CreatedAt: start.AddDate(0, 0, day)}
+		manifest.File = manifest.stem() + dataExt
+		os.WriteFile(filepath.Join(dir, manifest.File), []byte("data"), 0o600)
+		writeManifest(filepath.Join(dir, manifest.stem()+manifestExt), manifest)
+	}
+
+	removed, err := Prune(dir, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(removed) != 3 {
+		t.Errorf("Expected 3 snapshots deleted, got %d", len(removed))
+	}
+
+	kept, _ := List(dir)
+	if len(kept) != 2 || !kept[0].CreatedAt.Equal(start.AddDate(0, 0, 4)) || !kept[1].CreatedAt.Equal(start.AddDate(0, 0, 3)) {
+		t.Fatalf("Expected the two newest snapshots kept, got %+v", kept)
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 4 {
+		t.Errorf("Expected the data files of pruned snapshots deleted too, %d files left", len(files))
+	}
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+type InMemoryBlobStore struct {
+	blobs map[string][]byte
+	mu    sync.RWMutex
+}
+
+func NewInMemoryBlobStore() *InMemoryBlobStore {
+	return &InMemoryBlobStore{
+		blobs: make(map[string][]byte),
+	}
+}
+
+func (s *InMemoryBlobStore) Put(ctx context.Context, key string, content io.Reader) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *InMemoryBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, exists := s.blobs[key]
+	if !exists {
+		return nil, fmt.Errorf("blob %s not found", key)
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *InMemoryBlobStore) Exists(ctx context.Context, key string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.blobs[key]
+	return exists, nil
+}
+
+func (s *InMemoryBlobStore) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.blobs, key)
+	return nil
+}
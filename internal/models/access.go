@@ -1,28 +1,46 @@
 package models
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Access struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	ProjectID uuid.UUID `json:"project_id" db:"project_id"`
-	UID       string    `json:"uid" db:"uid"`
-	PinHash   string    `json:"-" db:"pin_hash"`
-	Name      string    `json:"name" db:"name"`
-	ReadOnly  bool      `json:"readonly" db:"readonly"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID          uuid.UUID `json:"id" db:"id"`
+	ProjectID   uuid.UUID `json:"project_id" db:"project_id"`
+	UID         string    `json:"uid" db:"uid"`
+	PinHash     string    `json:"-" db:"pin_hash"`
+	Name        string    `json:"name" db:"name"`
+	ReadOnly    bool      `json:"readonly" db:"readonly"`
+	TOTPSecret  string    `json:"-" db:"totp_secret"`
+	TOTPEnabled bool      `json:"totp_enabled" db:"totp_enabled"`
+	// TOTPLastCounter is the time step of the last TOTP code accepted, so the
+	// same code cannot be used twice.
+	TOTPLastCounter int64 `json:"-" db:"totp_last_counter"`
+	// TwoFactorFailures counts wrong codes since the second factor last
+	// succeeded, and TwoFactorFailedAt is when the last one was entered.
+	TwoFactorFailures int        `json:"-" db:"two_factor_failures"`
+	TwoFactorFailedAt *time.Time `json:"-" db:"two_factor_failed_at"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 type AccessRepository interface {
-	Create(access *Access) error
-	GetByID(id uuid.UUID) (*Access, error)
-	GetByProjectID(projectID uuid.UUID) ([]*Access, error)
-	GetByUID(projectID uuid.UUID, uid string) (*Access, error)
-	ExistsByUID(projectID uuid.UUID, uid string) (bool, error)
+	Create(ctx context.Context, access *Access) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Access, error)
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Access, error)
+	GetByUID(ctx context.Context, projectID uuid.UUID, uid string) (*Access, error)
+	ExistsByUID(ctx context.Context, projectID uuid.UUID, uid string) (bool, error)
+	Update(ctx context.Context, access *Access) error
+	// SetTwoFactorFailures stores failures and failedAt only while the stored
+	// count is still expected, and reports whether it was.
+	SetTwoFactorFailures(ctx context.Context, id uuid.UUID, expected, failures int, failedAt *time.Time) (bool, error)
+	// UseTOTPCounter records counter as the last TOTP time step used and clears
+	// the failures, unless the same or a later step was already used, and
+	// reports whether it did.
+	UseTOTPCounter(ctx context.Context, id uuid.UUID, counter int64) (bool, error)
 }
 
 func NewAccess(projectID uuid.UUID, uid, pinHash, name string, readonly bool) *Access {
@@ -38,3 +56,7 @@ func NewAccess(projectID uuid.UUID, uid, pinHash, name string, readonly bool) *A
 		UpdatedAt: now,
 	}
 }
+
+func (a *Access) RequiresTwoFactor(project *Project) bool {
+	return project.RequireTwoFactor && !a.ReadOnly
+}
@@ -1,26 +1,111 @@
 package models
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gofin/pkg/money"
 )
 
+type AccountType string
+
+const (
+	AccountCash       AccountType = "cash"
+	AccountChecking   AccountType = "checking"
+	AccountSavings    AccountType = "savings"
+	AccountCreditCard AccountType = "credit_card"
+	AccountInvestment AccountType = "investment"
+	AccountLoan       AccountType = "loan"
+)
+
+// AccountTypes lists every account type in the order forms offer them.
+var AccountTypes = []AccountType{AccountChecking, AccountSavings, AccountCash, AccountCreditCard, AccountInvestment, AccountLoan}
+
+func (t AccountType) String() string {
+	return string(t)
+}
+
+func (t AccountType) IsValid() bool {
+	switch t {
+	case AccountCash, AccountChecking, AccountSavings, AccountCreditCard, AccountInvestment, AccountLoan:
+		return true
+	default:
+		return false
+	}
+}
+
+// Label is the human-readable name of the type.
+func (t AccountType) Label() string {
+	switch t {
+	case AccountCash:
+		return "Cash"
+	case AccountChecking:
+		return "Checking"
+	case AccountSavings:
+		return "Savings"
+	case AccountCreditCard:
+		return "Credit card"
+	case AccountInvestment:
+		return "Investment"
+	case AccountLoan:
+		return "Loan"
+	default:
+		return string(t)
+	}
+}
+
+func ParseAccountType(s string) (AccountType, error) {
+	switch s {
+	case "credit-card", "creditcard":
+		return AccountCreditCard, nil
+	}
+
+	t := AccountType(s)
+	if !t.IsValid() {
+		return "", fmt.Errorf("invalid account type: %s", s)
+	}
+	return t, nil
+}
+
+// MaxAccountDescriptionLength caps the free-form description of an account.
+const MaxAccountDescriptionLength = 200
+
 type Account struct {
-	ID        uuid.UUID      `json:"id" db:"id"`
-	ProjectID uuid.UUID      `json:"project_id" db:"project_id"`
-	Name      string         `json:"name" db:"name"`
-	Currency  money.Currency `json:"currency" db:"currency"`
-	CreatedAt time.Time      `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at" db:"updated_at"`
+	ID          uuid.UUID      `json:"id" db:"id"`
+	ProjectID   uuid.UUID      `json:"project_id" db:"project_id"`
+	Name        string         `json:"name" db:"name"`
+	Currency    money.Currency `json:"currency" db:"currency"`
+	Type        AccountType    `json:"type" db:"type"`
+	Description string         `json:"description" db:"description"`
+	Position    int            `json:"position" db:"position"`
+	ArchivedAt  *time.Time     `json:"archived_at,omitempty" db:"archived_at"`
+	// CreditLimit, StatementDay and DueDay only apply to credit cards; zero
+	// means not set.
+	CreditLimit  float64   `json:"credit_limit,omitempty" db:"credit_limit"`
+	StatementDay int       `json:"statement_day,omitempty" db:"statement_day"`
+	DueDay       int       `json:"due_day,omitempty" db:"due_day"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// IsArchived reports whether the account is hidden from forms. Its transactions
+// stay in history and balances either way.
+func (a *Account) IsArchived() bool {
+	return a.ArchivedAt != nil
+}
+
+// AccountRepository returns accounts in display order: by Position, then by
+// creation time.
 type AccountRepository interface {
-	Create(account *Account) error
-	GetByProjectID(projectID uuid.UUID) ([]*Account, error)
-	GetByID(id uuid.UUID) (*Account, error)
-	ExistsByName(projectID uuid.UUID, name string) (bool, error)
+	Create(ctx context.Context, account *Account) error
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Account, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Account, error)
+	ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error)
+	// Update saves the name, type, description, position, archive state and
+	// credit card terms.
+	Update(ctx context.Context, account *Account) error
 }
 
 func NewAccount(projectID uuid.UUID, name string, currency money.Currency) *Account {
@@ -30,11 +115,23 @@ func NewAccount(projectID uuid.UUID, name string, currency money.Currency) *Acco
 		ProjectID: projectID,
 		Name:      name,
 		Currency:  currency,
+		Type:      AccountChecking,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
 
+// ActiveAccounts drops archived accounts, keeping the order.
+func ActiveAccounts(accounts []*Account) []*Account {
+	active := make([]*Account, 0, len(accounts))
+	for _, account := range accounts {
+		if !account.IsArchived() {
+			active = append(active, account)
+		}
+	}
+	return active
+}
+
 func ParseCurrency(s string) (money.Currency, error) {
 	return money.ParseCurrency(s)
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AccountTransfer is money moved between two accounts of a project: the debit
+// FromTransactionID and the top-up ToTransactionID, both in group GroupID. Reports
+// leave the two legs out of income and expense.
+type AccountTransfer struct {
+	ID                uuid.UUID `json:"id" db:"id"`
+	ProjectID         uuid.UUID `json:"project_id" db:"project_id"`
+	FromTransactionID uuid.UUID `json:"from_transaction_id" db:"from_transaction_id"`
+	ToTransactionID   uuid.UUID `json:"to_transaction_id" db:"to_transaction_id"`
+	Amount            float64   `json:"amount" db:"amount"`
+	GroupID           uuid.UUID `json:"group_id" db:"group_id"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+type AccountTransferRepository interface {
+	Create(ctx context.Context, transfer *AccountTransfer) error
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*AccountTransfer, error)
+	GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*AccountTransfer, error)
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+}
+
+// NewAccountTransfer records the debit from and the top-up to as one transfer.
+func NewAccountTransfer(projectID uuid.UUID, from, to *Transaction) *AccountTransfer {
+	transfer := &AccountTransfer{
+		ID:                uuid.New(),
+		ProjectID:         projectID,
+		FromTransactionID: from.ID,
+		ToTransactionID:   to.ID,
+		Amount:            from.Value,
+		CreatedAt:         time.Now(),
+	}
+	if from.GroupID != nil {
+		transfer.GroupID = *from.GroupID
+	}
+	return transfer
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a file kept with a transaction, such as a receipt or an invoice.
+// The content lives in a BlobStore under its SHA-256 checksum, so identical files
+// uploaded more than once share one blob.
+type Attachment struct {
+	ID            uuid.UUID `json:"id" db:"id"`
+	TransactionID uuid.UUID `json:"transaction_id" db:"transaction_id"`
+	FileName      string    `json:"file_name" db:"file_name"`
+	ContentType   string    `json:"content_type" db:"content_type"`
+	Size          int64     `json:"size" db:"size"`
+	Checksum      string    `json:"checksum" db:"checksum"`
+	HasThumbnail  bool      `json:"has_thumbnail" db:"has_thumbnail"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *Attachment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Attachment, error)
+	GetByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*Attachment, error)
+	CountByChecksum(ctx context.Context, checksum string) (int, error)
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+}
+
+// BlobStore keeps opaque file contents by key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func NewAttachment(transactionID uuid.UUID, fileName, contentType string, size int64, checksum string) *Attachment {
+	return &Attachment{
+		ID:            uuid.New(),
+		TransactionID: transactionID,
+		FileName:      fileName,
+		ContentType:   contentType,
+		Size:          size,
+		Checksum:      checksum,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// BlobKey is where the attachment content is stored.
+func (a *Attachment) BlobKey() string {
+	return a.Checksum
+}
+
+// ThumbnailKey is where the preview of an image attachment is stored.
+func (a *Attachment) ThumbnailKey() string {
+	return a.Checksum + ".thumb.jpg"
+}
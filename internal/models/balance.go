@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -49,3 +50,8 @@ func (q *BalanceQuery) Validate() error {
 
 	return nil
 }
+
+// ToCents rounds an amount to whole cents, so sums can be compared exactly.
+func ToCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
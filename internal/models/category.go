@@ -0,0 +1,44 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Category struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	ProjectID uuid.UUID `json:"project_id" db:"project_id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type CategoryRepository interface {
+	Create(ctx context.Context, category *Category) error
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Category, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Category, error)
+	ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error)
+}
+
+func NewCategory(projectID uuid.UUID, name string) *Category {
+	now := time.Now()
+	return &Category{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// CategoryTotal is the money that moved through one category in one currency.
+// A nil CategoryID collects uncategorized amounts.
+type CategoryTotal struct {
+	CategoryID *uuid.UUID `json:"category_id,omitempty"`
+	Name       string     `json:"name"`
+	Currency   string     `json:"currency"`
+	Spent      float64    `json:"spent"`
+	Received   float64    `json:"received"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidateCreditCardTerms checks the limit and the statement closing and payment
+// due days of a credit card. A zero day means not set; a due day needs a
+// closing day to be counted from.
+func ValidateCreditCardTerms(creditLimit float64, statementDay, dueDay int) error {
+	if creditLimit < 0 {
+		return fmt.Errorf("credit limit cannot be negative")
+	}
+
+	if statementDay < 0 || statementDay > 31 {
+		return fmt.Errorf("statement closing day must be between 1 and 31")
+	}
+
+	if dueDay < 0 || dueDay > 31 {
+		return fmt.Errorf("payment due day must be between 1 and 31")
+	}
+
+	if dueDay > 0 && statementDay == 0 {
+		return fmt.Errorf("payment due day requires a statement closing day")
+	}
+
+	return nil
+}
+
+// HasStatements reports whether the account is a credit card with a statement
+// closing day, so its transactions can be grouped into billing cycles.
+func (a *Account) HasStatements() bool {
+	return a.Type == AccountCreditCard && a.StatementDay > 0
+}
+
+// StatementCycle is one billing cycle of a credit card. Start and End are whole
+// days, both included; DueDate is zero when the card has no due day.
+type StatementCycle struct {
+	Start   time.Time
+	End     time.Time
+	DueDate time.Time
+}
+
+// Contains reports whether date falls on one of the cycle's days.
+func (c StatementCycle) Contains(date time.Time) bool {
+	return !date.Before(c.Start) && date.Before(c.End.AddDate(0, 0, 1))
+}
+
+// StatementCycleAt returns the cycle that date belongs to. Closing and due days
+// past the end of a short month move to its last day, so a card closing on the
+// 31st closes on February 28th.
+func (a *Account) StatementCycleAt(date time.Time) StatementCycle {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+
+	closing := dayInMonth(day, 0, a.StatementDay)
+	if closing.Before(day) {
+		closing = dayInMonth(day, 1, a.StatementDay)
+	}
+
+	cycle := StatementCycle{
+		Start: dayInMonth(closing, -1, a.StatementDay).AddDate(0, 0, 1),
+		End:   closing,
+	}
+
+	if a.DueDay > 0 {
+		cycle.DueDate = dayInMonth(closing, 0, a.DueDay)
+		if !cycle.DueDate.After(closing) {
+			cycle.DueDate = dayInMonth(closing, 1, a.DueDay)
+		}
+	}
+
+	return cycle
+}
+
+// PreviousCycle returns the cycle that closed right before c started.
+func (a *Account) PreviousCycle(c StatementCycle) StatementCycle {
+	return a.StatementCycleAt(c.Start.AddDate(0, 0, -1))
+}
+
+// dayInMonth returns the given day of the month offset months away from date,
+// clamped to the length of that month.
+func dayInMonth(date time.Time, offset, day int) time.Time {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location()).AddDate(0, offset, 0)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
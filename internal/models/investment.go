@@ -0,0 +1,322 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/pkg/money"
+)
+
+// MaxTickerLength caps security tickers such as "VWCE.DE" or "NASDAQ:AAPL".
+const MaxTickerLength = 20
+
+// quantityEpsilon absorbs float rounding when lots are sold down to nothing.
+const quantityEpsilon = 1e-9
+
+type InvestmentOperationType string
+
+const (
+	OperationBuy      InvestmentOperationType = "buy"
+	OperationSell     InvestmentOperationType = "sell"
+	OperationDividend InvestmentOperationType = "dividend"
+)
+
+func (t InvestmentOperationType) String() string {
+	return string(t)
+}
+
+func ParseInvestmentOperationType(s string) (InvestmentOperationType, error) {
+	switch InvestmentOperationType(strings.ToLower(strings.TrimSpace(s))) {
+	case OperationBuy:
+		return OperationBuy, nil
+	case OperationSell:
+		return OperationSell, nil
+	case OperationDividend:
+		return OperationDividend, nil
+	default:
+		return "", fmt.Errorf("invalid operation type: %s", s)
+	}
+}
+
+// CostMethod decides which cost a sale is matched against when computing the
+// realised gain.
+type CostMethod string
+
+const (
+	// CostFIFO sells the oldest lots first.
+	CostFIFO CostMethod = "fifo"
+	// CostAverage sells at the average cost of everything held.
+	CostAverage CostMethod = "average"
+)
+
+func (m CostMethod) String() string {
+	return string(m)
+}
+
+func ParseCostMethod(s string) (CostMethod, error) {
+	switch CostMethod(strings.ToLower(strings.TrimSpace(s))) {
+	case CostFIFO, "":
+		return CostFIFO, nil
+	case CostAverage:
+		return CostAverage, nil
+	default:
+		return "", fmt.Errorf("invalid cost method: %s", s)
+	}
+}
+
+// Security is a stock, fund or bond traded in a project, identified by its
+// ticker. Its prices are shared by every investment account holding it.
+type Security struct {
+	ID        uuid.UUID      `json:"id" db:"id"`
+	ProjectID uuid.UUID      `json:"project_id" db:"project_id"`
+	Ticker    string         `json:"ticker" db:"ticker"`
+	Name      string         `json:"name" db:"name"`
+	Currency  money.Currency `json:"currency" db:"currency"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// SecurityPrice is the closing price of a security on a day.
+type SecurityPrice struct {
+	SecurityID uuid.UUID `json:"security_id" db:"security_id"`
+	Date       time.Time `json:"date" db:"date"`
+	Price      float64   `json:"price" db:"price"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// SecurityRepository returns securities ordered by ticker and prices ordered by
+// date. SetPrice replaces the price already stored for the same day.
+type SecurityRepository interface {
+	Create(ctx context.Context, security *Security) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Security, error)
+	GetByTicker(ctx context.Context, projectID uuid.UUID, ticker string) (*Security, error)
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Security, error)
+	SetPrice(ctx context.Context, price *SecurityPrice) error
+	GetPrices(ctx context.Context, securityID uuid.UUID) ([]*SecurityPrice, error)
+}
+
+// InvestmentOperation is a buy, sell or dividend on an investment account.
+// Amount is the cash it moved: paid for a buy including fees, received for a
+// sale after fees, or the dividend itself. TransactionID points at the cash
+// transaction booked on the account.
+type InvestmentOperation struct {
+	ID            uuid.UUID               `json:"id" db:"id"`
+	AccountID     uuid.UUID               `json:"account_id" db:"account_id"`
+	SecurityID    uuid.UUID               `json:"security_id" db:"security_id"`
+	Type          InvestmentOperationType `json:"type" db:"type"`
+	Quantity      float64                 `json:"quantity" db:"quantity"`
+	Price         float64                 `json:"price" db:"price"`
+	Fees          float64                 `json:"fees" db:"fees"`
+	Amount        float64                 `json:"amount" db:"amount"`
+	Date          time.Time               `json:"date" db:"date"`
+	TransactionID uuid.UUID               `json:"transaction_id" db:"transaction_id"`
+	CreatedAt     time.Time               `json:"created_at" db:"created_at"`
+}
+
+// InvestmentOperationRepository returns operations oldest first.
+type InvestmentOperationRepository interface {
+	Create(ctx context.Context, operation *InvestmentOperation) error
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*InvestmentOperation, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+func NewSecurity(projectID uuid.UUID, ticker, name string, currency money.Currency) *Security {
+	return &Security{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		Ticker:    NormalizeTicker(ticker),
+		Name:      strings.TrimSpace(name),
+		Currency:  currency,
+		CreatedAt: time.Now(),
+	}
+}
+
+func NewSecurityPrice(securityID uuid.UUID, date time.Time, price float64) *SecurityPrice {
+	return &SecurityPrice{
+		SecurityID: securityID,
+		Date:       time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		Price:      price,
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// NewInvestmentOperation works out the cash amount of the operation. amount is
+// only used for dividends.
+func NewInvestmentOperation(accountID, securityID uuid.UUID, operationType InvestmentOperationType, quantity, price, fees, amount float64, date time.Time) *InvestmentOperation {
+	switch operationType {
+	case OperationBuy:
+		amount = roundCents(quantity*price + fees)
+	case OperationSell:
+		amount = roundCents(quantity*price - fees)
+	default:
+		quantity, price = 0, 0
+	}
+
+	return &InvestmentOperation{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		SecurityID: securityID,
+		Type:       operationType,
+		Quantity:   quantity,
+		Price:      price,
+		Fees:       fees,
+		Amount:     amount,
+		Date:       date,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// NormalizeTicker trims and upper-cases a ticker so "vwce.de" and "VWCE.DE"
+// name the same security.
+func NormalizeTicker(ticker string) string {
+	return strings.ToUpper(strings.TrimSpace(ticker))
+}
+
+func ValidateTicker(ticker string) error {
+	ticker = NormalizeTicker(ticker)
+	if ticker == "" {
+		return fmt.Errorf("ticker is required")
+	}
+
+	if len(ticker) > MaxTickerLength {
+		return fmt.Errorf("ticker cannot exceed %d characters", MaxTickerLength)
+	}
+
+	if strings.ContainsAny(ticker, " \t,;") {
+		return fmt.Errorf("ticker cannot contain spaces, commas or semicolons")
+	}
+
+	return nil
+}
+
+// PriceAt returns the latest of the date-ordered prices set on or before date.
+func PriceAt(prices []*SecurityPrice, date time.Time) (*SecurityPrice, bool) {
+	var found *SecurityPrice
+	for _, price := range prices {
+		if price.Date.After(date) {
+			break
+		}
+		found = price
+	}
+	return found, found != nil
+}
+
+// Lot is a purchase still held, at its unit cost including fees.
+type Lot struct {
+	Date     time.Time `json:"date"`
+	Quantity float64   `json:"quantity"`
+	UnitCost float64   `json:"unit_cost"`
+}
+
+// Realisation is the gain or loss of one sale.
+type Realisation struct {
+	Date     time.Time `json:"date"`
+	Quantity float64   `json:"quantity"`
+	Proceeds float64   `json:"proceeds"`
+	Cost     float64   `json:"cost"`
+	Gain     float64   `json:"gain"`
+}
+
+// Holding is what an account holds of one security. CostBasis is what the held
+// quantity cost under the chosen method; with average cost it differs from the
+// sum of the lots, which are always sold oldest first.
+type Holding struct {
+	SecurityID   uuid.UUID     `json:"security_id"`
+	Quantity     float64       `json:"quantity"`
+	CostBasis    float64       `json:"cost_basis"`
+	RealisedGain float64       `json:"realised_gain"`
+	Dividends    float64       `json:"dividends"`
+	Lots         []Lot         `json:"lots"`
+	Realisations []Realisation `json:"realisations"`
+}
+
+// BuildHoldings replays the operations of an account in date order into
+// holdings, in the order the securities were first traded. It fails when a
+// sale exceeds what was held on its date.
+func BuildHoldings(operations []*InvestmentOperation, method CostMethod) ([]*Holding, error) {
+	ordered := make([]*InvestmentOperation, len(operations))
+	copy(ordered, operations)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Date.Before(ordered[j].Date)
+	})
+
+	var holdings []*Holding
+	bySecurity := make(map[uuid.UUID]*Holding)
+
+	for _, operation := range ordered {
+		holding, exists := bySecurity[operation.SecurityID]
+		if !exists {
+			holding = &Holding{SecurityID: operation.SecurityID}
+			bySecurity[operation.SecurityID] = holding
+			holdings = append(holdings, holding)
+		}
+
+		switch operation.Type {
+		case OperationBuy:
+			holding.Lots = append(holding.Lots, Lot{
+				Date:     operation.Date,
+				Quantity: operation.Quantity,
+				UnitCost: operation.Amount / operation.Quantity,
+			})
+			holding.Quantity += operation.Quantity
+			holding.CostBasis += operation.Amount
+
+		case OperationSell:
+			if operation.Quantity > holding.Quantity+quantityEpsilon {
+				return nil, fmt.Errorf("cannot sell %g on %s, only %g held", operation.Quantity, operation.Date.Format("2006-01-02"), holding.Quantity)
+			}
+
+			fifoCost := holding.sellLots(operation.Quantity)
+			cost := fifoCost
+			if method == CostAverage {
+				cost = holding.CostBasis * operation.Quantity / holding.Quantity
+			}
+
+			holding.Quantity -= operation.Quantity
+			holding.CostBasis -= cost
+			if holding.Quantity < quantityEpsilon {
+				holding.Quantity, holding.CostBasis = 0, 0
+			}
+
+			gain := roundCents(operation.Amount - cost)
+			holding.RealisedGain += gain
+			holding.Realisations = append(holding.Realisations, Realisation{
+				Date:     operation.Date,
+				Quantity: operation.Quantity,
+				Proceeds: operation.Amount,
+				Cost:     roundCents(cost),
+				Gain:     gain,
+			})
+
+		case OperationDividend:
+			holding.Dividends += operation.Amount
+		}
+	}
+
+	for _, holding := range holdings {
+		holding.CostBasis = roundCents(holding.CostBasis)
+		holding.RealisedGain = roundCents(holding.RealisedGain)
+		holding.Dividends = roundCents(holding.Dividends)
+	}
+
+	return holdings, nil
+}
+
+// sellLots takes quantity off the oldest lots and returns what it cost.
+func (h *Holding) sellLots(quantity float64) float64 {
+	var cost float64
+	for quantity > quantityEpsilon && len(h.Lots) > 0 {
+		lot := &h.Lots[0]
+		sold := min(lot.Quantity, quantity)
+		cost += sold * lot.UnitCost
+		lot.Quantity -= sold
+		quantity -= sold
+		if lot.Quantity < quantityEpsilon {
+			h.Lots = h.Lots[1:]
+		}
+	}
+	return cost
+}
@@ -0,0 +1,263 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxLoanTermMonths caps loan terms at fifty years.
+const MaxLoanTermMonths = 600
+
+// Loan holds the terms of a loan account. The account's balance is the debt:
+// the principal is booked as a debit on the start date and the principal part
+// of every payment as a top-up.
+type Loan struct {
+	AccountID  uuid.UUID `json:"account_id" db:"account_id"`
+	ProjectID  uuid.UUID `json:"project_id" db:"project_id"`
+	Principal  float64   `json:"principal" db:"principal"`
+	AnnualRate float64   `json:"annual_rate" db:"annual_rate"`
+	TermMonths int       `json:"term_months" db:"term_months"`
+	PaymentDay int       `json:"payment_day" db:"payment_day"`
+	StartDate  time.Time `json:"start_date" db:"start_date"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// LoanRatePeriod changes the annual rate of a variable-rate loan for the
+// installments due on or after StartsOn.
+type LoanRatePeriod struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	AccountID  uuid.UUID `json:"account_id" db:"account_id"`
+	StartsOn   time.Time `json:"starts_on" db:"starts_on"`
+	AnnualRate float64   `json:"annual_rate" db:"annual_rate"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// LoanRepository returns rate periods ordered by StartsOn.
+type LoanRepository interface {
+	Create(ctx context.Context, loan *Loan) error
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) (*Loan, error)
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Loan, error)
+	AddRatePeriod(ctx context.Context, period *LoanRatePeriod) error
+	GetRatePeriods(ctx context.Context, accountID uuid.UUID) ([]*LoanRatePeriod, error)
+	DeleteRatePeriod(ctx context.Context, id uuid.UUID) error
+}
+
+func NewLoan(account *Account, principal, annualRate float64, termMonths, paymentDay int, startDate time.Time) *Loan {
+	now := time.Now()
+	return &Loan{
+		AccountID:  account.ID,
+		ProjectID:  account.ProjectID,
+		Principal:  principal,
+		AnnualRate: annualRate,
+		TermMonths: termMonths,
+		PaymentDay: paymentDay,
+		StartDate:  startDate,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func NewLoanRatePeriod(accountID uuid.UUID, startsOn time.Time, annualRate float64) *LoanRatePeriod {
+	return &LoanRatePeriod{
+		ID:         uuid.New(),
+		AccountID:  accountID,
+		StartsOn:   startsOn,
+		AnnualRate: annualRate,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// ValidateAnnualRate checks an annual interest rate given in percent.
+func ValidateAnnualRate(annualRate float64) error {
+	if annualRate < 0 || annualRate > 100 {
+		return fmt.Errorf("interest rate must be between 0 and 100 percent")
+	}
+	return nil
+}
+
+func ValidateLoanTerms(principal, annualRate float64, termMonths, paymentDay int) error {
+	if principal <= 0 {
+		return fmt.Errorf("principal must be positive")
+	}
+
+	if err := ValidateAnnualRate(annualRate); err != nil {
+		return err
+	}
+
+	if termMonths < 1 || termMonths > MaxLoanTermMonths {
+		return fmt.Errorf("term must be between 1 and %d months", MaxLoanTermMonths)
+	}
+
+	if paymentDay < 1 || paymentDay > 31 {
+		return fmt.Errorf("payment day must be between 1 and 31")
+	}
+
+	return nil
+}
+
+// InstallmentDate returns the due date of installment n, counted from one. The
+// first installment falls in the month after the start date.
+func (l *Loan) InstallmentDate(n int) time.Time {
+	return dayInMonth(l.StartDate, n, l.PaymentDay)
+}
+
+// RateAt returns the annual rate in force on date: the latest rate period that
+// started by then, or the loan's own rate before the first one.
+func (l *Loan) RateAt(date time.Time, periods []*LoanRatePeriod) float64 {
+	rate := l.AnnualRate
+	for _, period := range periods {
+		if !period.StartsOn.After(date) {
+			rate = period.AnnualRate
+		}
+	}
+	return rate
+}
+
+// Installment is one row of an amortization schedule. Prepayment is extra
+// principal paid on top of the installment; Balance is what is left after both.
+type Installment struct {
+	Number     int       `json:"number"`
+	Date       time.Time `json:"date"`
+	Rate       float64   `json:"rate"`
+	Payment    float64   `json:"payment"`
+	Principal  float64   `json:"principal"`
+	Interest   float64   `json:"interest"`
+	Prepayment float64   `json:"prepayment,omitempty"`
+	Balance    float64   `json:"balance"`
+}
+
+// LoanPrepayment is an early repayment applied with the first installment due on
+// or after Date. ReducePayment keeps the term and lowers the installments;
+// otherwise the installments stay and the loan ends sooner.
+type LoanPrepayment struct {
+	Date          time.Time
+	Amount        float64
+	ReducePayment bool
+}
+
+// BuildAmortizationSchedule lays out equal monthly installments (an annuity)
+// over the loan term. The installment is recalculated over the remaining term
+// whenever the rate changes or a prepayment asks for a lower payment; any other
+// prepayment brings the last installment forward instead.
+func BuildAmortizationSchedule(loan *Loan, periods []*LoanRatePeriod, prepayments []LoanPrepayment) []Installment {
+	var schedule []Installment
+
+	balance := loan.Principal
+	previous := time.Time{}
+	rate, payment := 0.0, 0.0
+	recalculate := true
+	last := loan.TermMonths
+
+	for n := 1; n <= last && balance >= 0.005; n++ {
+		date := loan.InstallmentDate(n)
+
+		if current := loan.RateAt(date, periods); current != rate {
+			rate = current
+			recalculate = true
+		}
+
+		if recalculate {
+			payment = annuityPayment(balance, rate, last-n+1)
+			recalculate = false
+		}
+
+		interest := roundCents(balance * rate / 1200)
+		principal := roundCents(payment - interest)
+		if principal > balance || n == last {
+			principal = balance
+		}
+		balance = roundCents(balance - principal)
+
+		installment := Installment{
+			Number:    n,
+			Date:      date,
+			Rate:      rate,
+			Payment:   roundCents(principal + interest),
+			Principal: principal,
+			Interest:  interest,
+		}
+
+		for _, prepayment := range prepayments {
+			if prepayment.Date.After(date) || !prepayment.Date.After(previous) {
+				continue
+			}
+
+			extra := roundCents(min(prepayment.Amount, balance))
+			installment.Prepayment += extra
+			balance = roundCents(balance - extra)
+			if prepayment.ReducePayment {
+				recalculate = true
+			} else {
+				last = n + installmentsLeft(balance, rate, payment)
+			}
+		}
+
+		installment.Balance = balance
+		schedule = append(schedule, installment)
+		previous = date
+	}
+
+	return schedule
+}
+
+// TotalInterest sums the interest of a schedule.
+func TotalInterest(schedule []Installment) float64 {
+	var total float64
+	for _, installment := range schedule {
+		total += installment.Interest
+	}
+	return roundCents(total)
+}
+
+func annuityPayment(balance, annualRate float64, installments int) float64 {
+	if installments <= 0 {
+		return balance
+	}
+
+	monthly := annualRate / 1200
+	if monthly == 0 {
+		return roundCents(balance / float64(installments))
+	}
+
+	return roundCents(balance * monthly / (1 - math.Pow(1+monthly, -float64(installments))))
+}
+
+// installmentsLeft counts the installments of payment it takes to repay balance.
+func installmentsLeft(balance, annualRate, payment float64) int {
+	if balance < 0.005 {
+		return 0
+	}
+
+	monthly := annualRate / 1200
+	if monthly == 0 {
+		return int(math.Ceil(balance/payment - 1e-9))
+	}
+
+	return int(math.Ceil(-math.Log(1-balance*monthly/payment)/math.Log(1+monthly) - 1e-9))
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// LoanOutstanding is the debt on a loan account as of asOf: the principal
+// booked as debits minus the repayments booked as top-ups.
+func LoanOutstanding(transactions []*Transaction, asOf time.Time) float64 {
+	var outstanding float64
+	for _, transaction := range transactions {
+		if transaction.TransactionDate.After(asOf) {
+			continue
+		}
+		if transaction.Type == Debit {
+			outstanding += transaction.Value
+		} else {
+			outstanding -= transaction.Value
+		}
+	}
+	return roundCents(outstanding)
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Payee is a counterparty that transactions are paid to or received from. The
+// names banks print vary ("BIEDRONKA 123", "Biedronka"), so a payee is found
+// through its aliases rather than its display name.
+type Payee struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	ProjectID         uuid.UUID  `json:"project_id" db:"project_id"`
+	Name              string     `json:"name" db:"name"`
+	DefaultCategoryID *uuid.UUID `json:"default_category_id,omitempty" db:"default_category_id"`
+	CreatedAt         time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// PayeeAlias is a normalized transaction name that resolves to a payee. An alias
+// is unique within a project, so every name matches at most one payee.
+type PayeeAlias struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	PayeeID   uuid.UUID `json:"payee_id" db:"payee_id"`
+	ProjectID uuid.UUID `json:"project_id" db:"project_id"`
+	Alias     string    `json:"alias" db:"alias"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type PayeeRepository interface {
+	Create(ctx context.Context, payee *Payee) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Payee, error)
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Payee, error)
+	ExistsByName(ctx context.Context, projectID uuid.UUID, name string) (bool, error)
+	UpdateDefaultCategory(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID) error
+	AddAlias(ctx context.Context, alias *PayeeAlias) error
+	GetAliasesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*PayeeAlias, error)
+	DeleteAlias(ctx context.Context, id uuid.UUID) error
+	// Merge moves every alias of source to target and deletes source.
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID) error
+}
+
+func NewPayee(projectID uuid.UUID, name string, defaultCategoryID *uuid.UUID) *Payee {
+	now := time.Now()
+	return &Payee{
+		ID:                uuid.New(),
+		ProjectID:         projectID,
+		Name:              name,
+		DefaultCategoryID: defaultCategoryID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+}
+
+func NewPayeeAlias(payee *Payee, alias string) *PayeeAlias {
+	return &PayeeAlias{
+		ID:        uuid.New(),
+		PayeeID:   payee.ID,
+		ProjectID: payee.ProjectID,
+		Alias:     alias,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NormalizePayeeName reduces a transaction name to lower-case words, dropping
+// digits and punctuation so store numbers and card suffixes don't matter.
+func NormalizePayeeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// MatchPayee returns the payee whose alias fits the transaction name, or nil.
+// An alias matches the whole normalized name or its leading words, and the
+// longest matching alias wins so "biedronka express" beats "biedronka".
+func MatchPayee(name string, aliases []*PayeeAlias) *uuid.UUID {
+	normalized := NormalizePayeeName(name)
+	if normalized == "" {
+		return nil
+	}
+
+	var best *PayeeAlias
+	for _, alias := range aliases {
+		if alias.Alias == "" {
+			continue
+		}
+		if normalized != alias.Alias && !strings.HasPrefix(normalized, alias.Alias+" ") {
+			continue
+		}
+		if best == nil || len(alias.Alias) > len(best.Alias) {
+			best = alias
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	payeeID := best.PayeeID
+	return &payeeID
+}
+
+// PayeeTotal is the money that went to or came from one payee in one currency.
+type PayeeTotal struct {
+	Currency string  `json:"currency"`
+	Spent    float64 `json:"spent"`
+	Received float64 `json:"received"`
+	Count    int     `json:"count"`
+}
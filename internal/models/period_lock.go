@@ -0,0 +1,77 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PeriodLockAction string
+
+const (
+	PeriodClosed   PeriodLockAction = "close"
+	PeriodReopened PeriodLockAction = "reopen"
+)
+
+// PeriodLockEvent is an entry of the audit trail of a project's closed
+// periods. LockedUntil is the last closed day once the change was made and
+// PreviousLockedUntil the one before it; either is nil when nothing was closed.
+type PeriodLockEvent struct {
+	ID                  uuid.UUID        `json:"id" db:"id"`
+	ProjectID           uuid.UUID        `json:"project_id" db:"project_id"`
+	Action              PeriodLockAction `json:"action" db:"action"`
+	LockedUntil         *time.Time       `json:"locked_until,omitempty" db:"locked_until"`
+	PreviousLockedUntil *time.Time       `json:"previous_locked_until,omitempty" db:"previous_locked_until"`
+	Actor               string           `json:"actor" db:"actor"`
+	Reason              string           `json:"reason" db:"reason"`
+	CreatedAt           time.Time        `json:"created_at" db:"created_at"`
+}
+
+// PeriodLockEventRepository returns the audit trail of a project newest first.
+type PeriodLockEventRepository interface {
+	Create(ctx context.Context, event *PeriodLockEvent) error
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*PeriodLockEvent, error)
+}
+
+func NewPeriodLockEvent(project *Project, action PeriodLockAction, previous *time.Time, actor, reason string) *PeriodLockEvent {
+	return &PeriodLockEvent{
+		ID:                  uuid.New(),
+		ProjectID:           project.ID,
+		Action:              action,
+		LockedUntil:         project.LockedUntil,
+		PreviousLockedUntil: previous,
+		Actor:               actor,
+		Reason:              reason,
+		CreatedAt:           time.Now(),
+	}
+}
+
+func (a PeriodLockAction) String() string {
+	return string(a)
+}
+
+// IsPeriodLocked reports whether a transaction dated date falls in a closed
+// period of the project.
+func (p *Project) IsPeriodLocked(date time.Time) bool {
+	return p.LockedUntil != nil && date.Before(p.LockedUntil.AddDate(0, 0, 1))
+}
+
+// ErrPeriodClosed is wrapped by the errors of EnsurePeriodOpen.
+var ErrPeriodClosed = errors.New("closed period")
+
+// EnsurePeriodOpen refuses dates in a closed period, so no transaction can be
+// created, changed or deleted there.
+func (p *Project) EnsurePeriodOpen(date time.Time) error {
+	if p.IsPeriodLocked(date) {
+		return fmt.Errorf("%s falls in a %w, the books are closed up to %s", date.Format("2006-01-02"), ErrPeriodClosed, p.LockedUntil.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// MonthEnd returns the last day of a month at midnight UTC.
+func MonthEnd(year int, month time.Month) time.Time {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+}
@@ -1,24 +1,30 @@
 package models
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Project is a shared ledger. LockedUntil is the last day of its closed
+// periods, nil while every period is open.
 type Project struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	Slug      string    `json:"slug" db:"slug"`
-	Name      string    `json:"name" db:"name"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID               uuid.UUID  `json:"id" db:"id"`
+	Slug             string     `json:"slug" db:"slug"`
+	Name             string     `json:"name" db:"name"`
+	RequireTwoFactor bool       `json:"require_two_factor" db:"require_two_factor"`
+	LockedUntil      *time.Time `json:"locked_until,omitempty" db:"locked_until"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 type ProjectRepository interface {
-	Create(project *Project) error
-	GetByID(id uuid.UUID) (*Project, error)
-	GetBySlug(slug string) (*Project, error)
-	ExistsBySlug(slug string) (bool, error)
+	Create(ctx context.Context, project *Project) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Project, error)
+	GetBySlug(ctx context.Context, slug string) (*Project, error)
+	ExistsBySlug(ctx context.Context, slug string) (bool, error)
+	Update(ctx context.Context, project *Project) error
 }
 
 func NewProject(name, slug string) *Project {
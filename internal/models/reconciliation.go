@@ -0,0 +1,57 @@
+package models
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reconciliation compares an account with a bank statement: the transactions
+// ticked off as cleared, together with those already reconciled, have to add up
+// to the statement balance before the reconciliation can be finished. An
+// account has at most one open reconciliation.
+type Reconciliation struct {
+	ID               uuid.UUID  `json:"id" db:"id"`
+	AccountID        uuid.UUID  `json:"account_id" db:"account_id"`
+	StatementDate    time.Time  `json:"statement_date" db:"statement_date"`
+	StatementBalance float64    `json:"statement_balance" db:"statement_balance"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// ReconciliationRepository returns the reconciliations of an account newest
+// statement first. GetOpenByAccountID fails when the account has none open.
+type ReconciliationRepository interface {
+	Create(ctx context.Context, reconciliation *Reconciliation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Reconciliation, error)
+	GetOpenByAccountID(ctx context.Context, accountID uuid.UUID) (*Reconciliation, error)
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Reconciliation, error)
+	Finish(ctx context.Context, id uuid.UUID, finishedAt time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+func NewReconciliation(accountID uuid.UUID, statementDate time.Time, statementBalance float64) *Reconciliation {
+	return &Reconciliation{
+		ID:               uuid.New(),
+		AccountID:        accountID,
+		StatementDate:    time.Date(statementDate.Year(), statementDate.Month(), statementDate.Day(), 0, 0, 0, 0, time.UTC),
+		StatementBalance: statementBalance,
+		CreatedAt:        time.Now(),
+	}
+}
+
+func (r *Reconciliation) IsFinished() bool {
+	return r.FinishedAt != nil
+}
+
+// Covers reports whether a transaction dated date belongs on the statement.
+func (r *Reconciliation) Covers(date time.Time) bool {
+	return date.Before(r.StatementDate.AddDate(0, 0, 1))
+}
+
+// IsBalanced reports whether a difference to the statement rounds to zero cents.
+func IsBalanced(difference float64) bool {
+	return math.Abs(difference) < 0.005
+}
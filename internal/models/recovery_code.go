@@ -0,0 +1,32 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecoveryCode struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	AccessID  uuid.UUID  `json:"access_id" db:"access_id"`
+	CodeHash  string     `json:"-" db:"code_hash"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type RecoveryCodeRepository interface {
+	ReplaceForAccess(ctx context.Context, accessID uuid.UUID, codes []*RecoveryCode) error
+	GetUnusedByAccessID(ctx context.Context, accessID uuid.UUID) ([]*RecoveryCode, error)
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	DeleteByAccessID(ctx context.Context, accessID uuid.UUID) error
+}
+
+func NewRecoveryCode(accessID uuid.UUID, codeHash string) *RecoveryCode {
+	return &RecoveryCode{
+		ID:        uuid.New(),
+		AccessID:  accessID,
+		CodeHash:  codeHash,
+		CreatedAt: time.Now(),
+	}
+}
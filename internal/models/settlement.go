@@ -0,0 +1,63 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/pkg/money"
+)
+
+// Settlement is money one member paid another to even out shared expenses. GroupID
+// links the transactions recorded for it, if any.
+type Settlement struct {
+	ID        uuid.UUID      `json:"id" db:"id"`
+	ProjectID uuid.UUID      `json:"project_id" db:"project_id"`
+	FromID    uuid.UUID      `json:"from_access_id" db:"from_access_id"`
+	ToID      uuid.UUID      `json:"to_access_id" db:"to_access_id"`
+	Amount    float64        `json:"amount" db:"amount"`
+	Currency  money.Currency `json:"currency" db:"currency"`
+	GroupID   *uuid.UUID     `json:"group_id,omitempty" db:"group_id"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+type SettlementRepository interface {
+	Create(ctx context.Context, settlement *Settlement) error
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*Settlement, error)
+	GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*Settlement, error)
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+}
+
+func NewSettlement(projectID, fromID, toID uuid.UUID, amount float64, currency money.Currency, groupID *uuid.UUID) *Settlement {
+	return &Settlement{
+		ID:        uuid.New(),
+		ProjectID: projectID,
+		FromID:    fromID,
+		ToID:      toID,
+		Amount:    amount,
+		Currency:  currency,
+		GroupID:   groupID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// MemberBalance is where one member stands in one currency: positive when the others
+// owe them money, negative when they owe the others.
+type MemberBalance struct {
+	AccessID uuid.UUID      `json:"access_id"`
+	Name     string         `json:"name"`
+	Currency money.Currency `json:"currency"`
+	Paid     float64        `json:"paid"`
+	Owed     float64        `json:"owed"`
+	Balance  float64        `json:"balance"`
+}
+
+// Transfer is a payment that settles balances between two members.
+type Transfer struct {
+	FromID   uuid.UUID      `json:"from_access_id"`
+	FromName string         `json:"from_name"`
+	ToID     uuid.UUID      `json:"to_access_id"`
+	ToName   string         `json:"to_name"`
+	Amount   float64        `json:"amount"`
+	Currency money.Currency `json:"currency"`
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/pkg/money"
+)
+
+type ShareMethod string
+
+const (
+	ShareEqual      ShareMethod = "equal"
+	SharePercentage ShareMethod = "percentage"
+	ShareExact      ShareMethod = "exact"
+)
+
+var AllShareMethods = []ShareMethod{ShareEqual, SharePercentage, ShareExact}
+
+func (m ShareMethod) String() string {
+	return string(m)
+}
+
+func (m ShareMethod) IsValid() bool {
+	for _, method := range AllShareMethods {
+		if method == m {
+			return true
+		}
+	}
+	return false
+}
+
+func ParseShareMethod(s string) (ShareMethod, error) {
+	method := ShareMethod(s)
+	if !method.IsValid() {
+		return "", fmt.Errorf("invalid share method: %s", s)
+	}
+	return method, nil
+}
+
+// SharedExpense records that a member of the project (an Access) paid for a debit
+// transaction on behalf of several members.
+type SharedExpense struct {
+	ID            uuid.UUID      `json:"id" db:"id"`
+	ProjectID     uuid.UUID      `json:"project_id" db:"project_id"`
+	TransactionID uuid.UUID      `json:"transaction_id" db:"transaction_id"`
+	PayerID       uuid.UUID      `json:"payer_id" db:"payer_id"`
+	Method        ShareMethod    `json:"method" db:"method"`
+	Amount        float64        `json:"amount" db:"amount"`
+	Currency      money.Currency `json:"currency" db:"currency"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+}
+
+// ExpenseShare is the part of a shared expense one member owes. Percentage is only
+// kept for expenses shared by percentage, so the form can be shown again as entered.
+type ExpenseShare struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	ExpenseID  uuid.UUID `json:"expense_id" db:"expense_id"`
+	AccessID   uuid.UUID `json:"access_id" db:"access_id"`
+	Amount     float64   `json:"amount" db:"amount"`
+	Percentage float64   `json:"percentage" db:"percentage"`
+}
+
+// ShareData describes one participant as entered: Percentage is read for percentage
+// shares, Amount for exact shares and neither for equal shares.
+type ShareData struct {
+	AccessID   uuid.UUID
+	Percentage float64
+	Amount     float64
+}
+
+type SharedExpenseRepository interface {
+	Create(ctx context.Context, expense *SharedExpense, shares []*ExpenseShare) error
+	GetByTransactionID(ctx context.Context, transactionID uuid.UUID) (*SharedExpense, []*ExpenseShare, error)
+	GetByProjectID(ctx context.Context, projectID uuid.UUID) ([]*SharedExpense, error)
+	GetSharesByProjectID(ctx context.Context, projectID uuid.UUID) ([]*ExpenseShare, error)
+	DeleteByTransactionID(ctx context.Context, transactionID uuid.UUID) error
+}
+
+func NewSharedExpense(projectID, transactionID, payerID uuid.UUID, method ShareMethod, amount float64, currency money.Currency) *SharedExpense {
+	return &SharedExpense{
+		ID:            uuid.New(),
+		ProjectID:     projectID,
+		TransactionID: transactionID,
+		PayerID:       payerID,
+		Method:        method,
+		Amount:        amount,
+		Currency:      currency,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ComputeShares turns the entered participants into shares of amount that add up to
+// it to the cent. Cents left over by equal or percentage rounding go to the first
+// participants, one each, and cents over the amount, from percentages a little
+// above 100, are taken back from them the same way.
+func ComputeShares(expenseID uuid.UUID, method ShareMethod, amount float64, participants []ShareData) ([]*ExpenseShare, error) {
+	if len(participants) == 0 {
+		return nil, fmt.Errorf("at least one participant is required")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(participants))
+	for _, participant := range participants {
+		if seen[participant.AccessID] {
+			return nil, fmt.Errorf("each member can only take part once")
+		}
+		seen[participant.AccessID] = true
+	}
+
+	total := ToCents(amount)
+	cents := make([]int64, len(participants))
+
+	switch method {
+	case ShareEqual:
+		for i := range participants {
+			cents[i] = total / int64(len(participants))
+		}
+	case SharePercentage:
+		var percentTotal float64
+		for i, participant := range participants {
+			if participant.Percentage <= 0 {
+				return nil, fmt.Errorf("share %d: percentage must be positive", i+1)
+			}
+			percentTotal += participant.Percentage
+			cents[i] = int64(math.Floor(float64(total) * participant.Percentage / 100))
+		}
+		if math.Abs(percentTotal-100) > 0.001 {
+			return nil, fmt.Errorf("percentages add up to %.2f%% instead of 100%%", percentTotal)
+		}
+	case ShareExact:
+		var exactTotal int64
+		for i, participant := range participants {
+			if participant.Amount <= 0 {
+				return nil, fmt.Errorf("share %d: amount must be positive", i+1)
+			}
+			cents[i] = ToCents(participant.Amount)
+			exactTotal += cents[i]
+		}
+		if exactTotal != total {
+			return nil, fmt.Errorf("shares add up to %.2f but the expense is %.2f", float64(exactTotal)/100, amount)
+		}
+	default:
+		return nil, fmt.Errorf("invalid share method: %s", method)
+	}
+
+	remainder := total
+	for _, c := range cents {
+		remainder -= c
+	}
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(cents) {
+		if step < 0 && cents[i] == 0 {
+			continue
+		}
+		cents[i] += step
+		remainder -= step
+	}
+
+	shares := make([]*ExpenseShare, 0, len(participants))
+	for i, participant := range participants {
+		share := &ExpenseShare{
+			ID:        uuid.New(),
+			ExpenseID: expenseID,
+			AccessID:  participant.AccessID,
+			Amount:    float64(cents[i]) / 100,
+		}
+		if method == SharePercentage {
+			share.Percentage = participant.Percentage
+		}
+		shares = append(shares, share)
+	}
+
+	return shares, nil
+}
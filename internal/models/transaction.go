@@ -1,6 +1,8 @@
 package models
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -33,43 +35,142 @@ func ParseTransactionType(s string) (TransactionType, error) {
 	}
 }
 
+// TransactionStatus tracks a transaction against the bank: uncleared until it
+// shows up on the bank side, cleared once ticked off, and reconciled once a
+// reconciliation agreeing with a bank statement includes it.
+type TransactionStatus string
+
+const (
+	StatusUncleared  TransactionStatus = "uncleared"
+	StatusCleared    TransactionStatus = "cleared"
+	StatusReconciled TransactionStatus = "reconciled"
+)
+
+func (s TransactionStatus) String() string {
+	return string(s)
+}
+
+func ParseTransactionStatus(s string) (TransactionStatus, error) {
+	switch TransactionStatus(s) {
+	case StatusUncleared, "":
+		return StatusUncleared, nil
+	case StatusCleared:
+		return StatusCleared, nil
+	case StatusReconciled:
+		return StatusReconciled, nil
+	default:
+		return "", fmt.Errorf("invalid transaction status: %s", s)
+	}
+}
+
 type TransactionData struct {
 	AccountID       uuid.UUID
 	Value           float64
 	Name            string
 	Type            TransactionType
 	TransactionDate *time.Time
+	Notes           string
+	CategoryID      *uuid.UUID
+	PayeeID         *uuid.UUID
+	Splits          []SplitData
+	// ExternalID is the bank's own ID of an imported transaction.
+	ExternalID string
 }
 
+// MaxNotesLength caps the free-form notes kept with a transaction.
+const MaxNotesLength = 4000
+
 type Transaction struct {
-	ID              uuid.UUID       `json:"id" db:"id"`
-	AccountID       uuid.UUID       `json:"account_id" db:"account_id"`
-	Value           float64         `json:"value" db:"value"`
-	Name            string          `json:"name" db:"name"`
-	TransactionDate time.Time       `json:"transaction_date" db:"transaction_date"`
-	Type            TransactionType `json:"type" db:"type"`
-	GroupID         *uuid.UUID      `json:"group_id,omitempty" db:"group_id"`
-	CreatedAt       time.Time       `json:"created_at" db:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at" db:"updated_at"`
+	ID              uuid.UUID         `json:"id" db:"id"`
+	AccountID       uuid.UUID         `json:"account_id" db:"account_id"`
+	Value           float64           `json:"value" db:"value"`
+	Name            string            `json:"name" db:"name"`
+	TransactionDate time.Time         `json:"transaction_date" db:"transaction_date"`
+	Type            TransactionType   `json:"type" db:"type"`
+	Notes           string            `json:"notes" db:"notes"`
+	CategoryID      *uuid.UUID        `json:"category_id,omitempty" db:"category_id"`
+	PayeeID         *uuid.UUID        `json:"payee_id,omitempty" db:"payee_id"`
+	GroupID         *uuid.UUID        `json:"group_id,omitempty" db:"group_id"`
+	Status          TransactionStatus `json:"status" db:"status"`
+	// ExternalID is the bank's ID of an imported transaction, unique within
+	// its account, so importing the same statement twice adds nothing.
+	ExternalID string    `json:"external_id,omitempty" db:"external_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
 }
 
 type TransactionRepository interface {
-	Create(transaction *Transaction) error
-	GetByAccountID(accountID uuid.UUID) ([]*Transaction, error)
-	GetByGroupID(groupID uuid.UUID) ([]*Transaction, error)
-	GetByID(id uuid.UUID) (*Transaction, error)
-	GetByAccountIDWithDateRange(accountID uuid.UUID, startDate, endDate *time.Time) ([]*Transaction, error)
-	GetByProjectIDWithDateRange(projectID uuid.UUID, startDate, endDate *time.Time) ([]*Transaction, error)
-	GetTransactionsWithFilters(query TransactionQuery) ([]*Transaction, error)
-	DeleteByID(id uuid.UUID) error
+	Create(ctx context.Context, transaction *Transaction) error
+	GetByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Transaction, error)
+	GetByGroupID(ctx context.Context, groupID uuid.UUID) ([]*Transaction, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Transaction, error)
+	GetByAccountIDWithDateRange(ctx context.Context, accountID uuid.UUID, startDate, endDate *time.Time) ([]*Transaction, error)
+	GetByProjectIDWithDateRange(ctx context.Context, projectID uuid.UUID, startDate, endDate *time.Time) ([]*Transaction, error)
+	GetTransactionsWithFilters(ctx context.Context, query TransactionQuery) ([]*Transaction, error)
+	SearchTransactions(ctx context.Context, query TransactionQuery) (*TransactionPage, error)
+	UpdateNotes(ctx context.Context, id uuid.UUID, notes string) error
+	UpdateCategory(ctx context.Context, id uuid.UUID, categoryID *uuid.UUID) error
+	UpdatePayee(ctx context.Context, id uuid.UUID, payeeID *uuid.UUID) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status TransactionStatus) error
+	// GetExternalIDs lists the external IDs of the imported transactions of an
+	// account.
+	GetExternalIDs(ctx context.Context, accountID uuid.UUID) ([]string, error)
+	ReassignPayee(ctx context.Context, fromPayeeID, toPayeeID uuid.UUID) error
+	DeleteByID(ctx context.Context, id uuid.UUID) error
 }
 
+type TransactionSortField string
+
+const (
+	SortByDate  TransactionSortField = "date"
+	SortByValue TransactionSortField = "value"
+	SortByName  TransactionSortField = "name"
+)
+
+type SortDirection string
+
+const (
+	SortDescending SortDirection = "desc"
+	SortAscending  SortDirection = "asc"
+)
+
 type TransactionQuery struct {
 	ProjectID                 *uuid.UUID
 	AccountID                 *uuid.UUID
+	AccountIDs                []uuid.UUID
+	PayeeID                   *uuid.UUID
 	StartDate                 *time.Time
 	EndDate                   *time.Time
 	ExcludeFutureTransactions bool
+	Search                    string
+	MinValue                  *float64
+	MaxValue                  *float64
+	Type                      TransactionType
+	SortBy                    TransactionSortField
+	SortDirection             SortDirection
+	Limit                     int
+	Cursor                    string
+}
+
+// TransactionPage is one page of a search. NextCursor is empty on the last page.
+type TransactionPage struct {
+	Transactions []*Transaction
+	NextCursor   string
+}
+
+// SortOrDefault returns the sort field and direction, defaulting to newest first.
+func (q *TransactionQuery) SortOrDefault() (TransactionSortField, SortDirection) {
+	sortBy := q.SortBy
+	if sortBy == "" {
+		sortBy = SortByDate
+	}
+
+	direction := q.SortDirection
+	if direction == "" {
+		direction = SortDescending
+	}
+
+	return sortBy, direction
 }
 
 func (q *TransactionQuery) Validate() error {
@@ -87,6 +188,42 @@ func (q *TransactionQuery) Validate() error {
 		}
 	}
 
+	if q.MinValue != nil && q.MaxValue != nil && *q.MaxValue < *q.MinValue {
+		return fmt.Errorf("max value cannot be below min value")
+	}
+
+	if q.Type != "" && !q.Type.IsValid() {
+		return fmt.Errorf("invalid transaction type: %s", q.Type)
+	}
+
+	switch q.SortBy {
+	case "", SortByDate, SortByValue, SortByName:
+	default:
+		return fmt.Errorf("invalid sort field: %s", q.SortBy)
+	}
+
+	switch q.SortDirection {
+	case "", SortAscending, SortDescending:
+	default:
+		return fmt.Errorf("invalid sort direction: %s", q.SortDirection)
+	}
+
+	if q.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+
+	if q.Cursor != "" {
+		cursor, err := DecodeTransactionCursor(q.Cursor)
+		if err != nil {
+			return err
+		}
+
+		sortBy, direction := q.SortOrDefault()
+		if cursor.SortBy != sortBy || cursor.Direction != direction {
+			return fmt.Errorf("cursor does not match the requested sort order")
+		}
+	}
+
 	return nil
 }
 
@@ -109,8 +246,39 @@ func NewTransaction(data TransactionData, groupID ...uuid.UUID) *Transaction {
 		Name:            data.Name,
 		TransactionDate: transactionDate,
 		Type:            data.Type,
+		Notes:           data.Notes,
+		CategoryID:      data.CategoryID,
+		PayeeID:         data.PayeeID,
 		GroupID:         groupIDPtr,
+		Status:          StatusUncleared,
+		ExternalID:      data.ExternalID,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
 }
+
+// IsReconciled reports whether the transaction is locked by a finished
+// reconciliation.
+func (t *Transaction) IsReconciled() bool {
+	return t.Status == StatusReconciled
+}
+
+// ErrTransactionReconciled is wrapped by the errors of EnsureEditable.
+var ErrTransactionReconciled = errors.New("reconciled")
+
+// EnsureEditable refuses changes to a reconciled transaction, which has to
+// keep agreeing with the bank statement it was reconciled against.
+func (t *Transaction) EnsureEditable() error {
+	if t.IsReconciled() {
+		return fmt.Errorf("transaction '%s' is %w and cannot be changed", t.Name, ErrTransactionReconciled)
+	}
+	return nil
+}
+
+// SignedValue is the effect of the transaction on its account balance.
+func (t *Transaction) SignedValue() float64 {
+	if t.Type == TopUp {
+		return t.Value
+	}
+	return -t.Value
+}
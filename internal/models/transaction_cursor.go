@@ -0,0 +1,78 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransactionCursor marks the last transaction of a page. The next page starts right
+// after it in the (sort field, id) order, so inserts between requests do not shift pages.
+type TransactionCursor struct {
+	SortBy    TransactionSortField `json:"s"`
+	Direction SortDirection        `json:"o"`
+	Date      time.Time            `json:"d,omitempty"`
+	Value     float64              `json:"v,omitempty"`
+	Name      string               `json:"n,omitempty"`
+	ID        uuid.UUID            `json:"id"`
+}
+
+func NewTransactionCursor(transaction *Transaction, sortBy TransactionSortField, direction SortDirection) TransactionCursor {
+	return TransactionCursor{
+		SortBy:    sortBy,
+		Direction: direction,
+		Date:      transaction.TransactionDate,
+		Value:     transaction.Value,
+		Name:      transaction.Name,
+		ID:        transaction.ID,
+	}
+}
+
+func (c TransactionCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeTransactionCursor(encoded string) (*TransactionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var cursor TransactionCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return &cursor, nil
+}
+
+// CompareTransactions orders a and b by sortBy with the ID as tie-breaker, returning
+// a negative number when a sorts first in ascending order.
+func CompareTransactions(a, b TransactionCursor, sortBy TransactionSortField) int {
+	var result int
+
+	switch sortBy {
+	case SortByValue:
+		switch {
+		case a.Value < b.Value:
+			result = -1
+		case a.Value > b.Value:
+			result = 1
+		}
+	case SortByName:
+		result = strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	default:
+		result = a.Date.Compare(b.Date)
+	}
+
+	if result != 0 {
+		return result
+	}
+
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MaxSplitMemoLength caps the memo of a single split line.
+const MaxSplitMemoLength = 200
+
+// TransactionSplit assigns part of a transaction's value to a category, e.g. the
+// pharmacy items on a supermarket receipt. The splits of a transaction always add
+// up to its Value.
+type TransactionSplit struct {
+	ID            uuid.UUID `json:"id" db:"id"`
+	TransactionID uuid.UUID `json:"transaction_id" db:"transaction_id"`
+	CategoryID    uuid.UUID `json:"category_id" db:"category_id"`
+	Amount        float64   `json:"amount" db:"amount"`
+	Memo          string    `json:"memo" db:"memo"`
+	Position      int       `json:"position" db:"position"`
+}
+
+type SplitData struct {
+	CategoryID uuid.UUID
+	Amount     float64
+	Memo       string
+}
+
+type TransactionSplitRepository interface {
+	ReplaceForTransaction(ctx context.Context, transactionID uuid.UUID, splits []*TransactionSplit) error
+	GetByTransactionID(ctx context.Context, transactionID uuid.UUID) ([]*TransactionSplit, error)
+	GetByTransactionIDs(ctx context.Context, transactionIDs []uuid.UUID) ([]*TransactionSplit, error)
+	DeleteByTransactionID(ctx context.Context, transactionID uuid.UUID) error
+}
+
+func NewTransactionSplits(transactionID uuid.UUID, data []SplitData) []*TransactionSplit {
+	splits := make([]*TransactionSplit, 0, len(data))
+	for i, d := range data {
+		splits = append(splits, &TransactionSplit{
+			ID:            uuid.New(),
+			TransactionID: transactionID,
+			CategoryID:    d.CategoryID,
+			Amount:        d.Amount,
+			Memo:          d.Memo,
+			Position:      i,
+		})
+	}
+	return splits
+}
+
+// ValidateSplits checks that every line is positive and that the lines add up to
+// value to the cent. An empty list means the transaction is not split.
+func ValidateSplits(value float64, splits []SplitData) error {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	var totalCents int64
+	for i, split := range splits {
+		if split.Amount <= 0 {
+			return fmt.Errorf("split %d: amount must be positive", i+1)
+		}
+
+		if split.CategoryID == uuid.Nil {
+			return fmt.Errorf("split %d: category is required", i+1)
+		}
+
+		if len(split.Memo) > MaxSplitMemoLength {
+			return fmt.Errorf("split %d: memo cannot be longer than %d characters", i+1, MaxSplitMemoLength)
+		}
+
+		totalCents += ToCents(split.Amount)
+	}
+
+	if totalCents != ToCents(value) {
+		return fmt.Errorf("splits add up to %.2f but the transaction value is %.2f", float64(totalCents)/100, value)
+	}
+
+	return nil
+}
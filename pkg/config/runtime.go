@@ -0,0 +1,289 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultConfigFile     = "gofin.yaml"
+	DefaultListenAddress  = ":8080"
+	DefaultDatabasePath   = "database.db"
+	DefaultDatabaseDriver = DatabaseDriverSQLite
+	DefaultAssetsDir      = "web"
+	DefaultSessionTTL     = 24 * time.Hour
+
+	DefaultReadTimeout     = 15 * time.Second
+	DefaultWriteTimeout    = 30 * time.Second
+	DefaultIdleTimeout     = 120 * time.Second
+	DefaultShutdownTimeout = 15 * time.Second
+
+	DefaultAttachmentsDir     = "attachments"
+	DefaultAttachmentsMaxSize = 10 << 20
+
+	DefaultSnapshotsDir  = "snapshots"
+	DefaultSnapshotsKeep = 7
+
+	DefaultLogLevel  = "info"
+	DefaultLogFormat = "text"
+
+	ConfigFileEnv = "GOFIN_CONFIG"
+)
+
+const (
+	DatabaseDriverSQLite   = "sqlite"
+	DatabaseDriverPostgres = "postgres"
+)
+
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Session     SessionConfig     `yaml:"session"`
+	Log         LogConfig         `yaml:"log"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
+	Attachments AttachmentsConfig `yaml:"attachments"`
+	Snapshots   SnapshotsConfig   `yaml:"snapshots"`
+}
+
+type ServerConfig struct {
+	ListenAddress string `yaml:"listen_address"`
+	BasePath      string `yaml:"base_path"`
+	DevMode       bool   `yaml:"dev_mode"`
+	AssetsDir     string `yaml:"assets_dir"`
+
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+// DatabaseConfig selects the repository backend: the SQLite file at Path or,
+// with the postgres driver, the database at DSN, which several instances can
+// share.
+type DatabaseConfig struct {
+	Driver string `yaml:"driver"`
+	Path   string `yaml:"path"`
+	DSN    string `yaml:"dsn"`
+}
+
+type SessionConfig struct {
+	Secret        string        `yaml:"secret"`
+	TTL           time.Duration `yaml:"ttl"`
+	SecureCookies bool          `yaml:"secure_cookies"`
+}
+
+// MetricsConfig controls /metrics. It is off by default: the endpoint needs no
+// login and its counters are labelled by project slug, so it is only meant to be
+// turned on where the port is reachable by the monitoring system alone.
+type MetricsConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// AttachmentsConfig limits uploaded files. AllowedTypes are matched against the type
+// sniffed from the file content, not the one the browser claims.
+type AttachmentsConfig struct {
+	Dir          string   `yaml:"dir"`
+	MaxSize      int64    `yaml:"max_size"`
+	AllowedTypes []string `yaml:"allowed_types"`
+}
+
+// DefaultAttachmentTypes covers scanned receipts and invoices.
+var DefaultAttachmentTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf"}
+
+// SnapshotsConfig controls online copies of the database. The web server takes
+// one every Interval, zero turning that off, and keeps the newest Keep, zero
+// keeping all. Snapshots are gzipped when Compress is set and encrypted when
+// Passphrase is not empty.
+type SnapshotsConfig struct {
+	Dir        string        `yaml:"dir"`
+	Interval   time.Duration `yaml:"interval"`
+	Keep       int           `yaml:"keep"`
+	Compress   bool          `yaml:"compress"`
+	Passphrase string        `yaml:"passphrase"`
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			ListenAddress: DefaultListenAddress,
+			AssetsDir:     DefaultAssetsDir,
+
+			ReadTimeout:     DefaultReadTimeout,
+			WriteTimeout:    DefaultWriteTimeout,
+			IdleTimeout:     DefaultIdleTimeout,
+			ShutdownTimeout: DefaultShutdownTimeout,
+		},
+		Database: DatabaseConfig{
+			Driver: DefaultDatabaseDriver,
+			Path:   DefaultDatabasePath,
+		},
+		Session: SessionConfig{
+			TTL: DefaultSessionTTL,
+		},
+		Log: LogConfig{
+			Level:  DefaultLogLevel,
+			Format: DefaultLogFormat,
+		},
+		Attachments: AttachmentsConfig{
+			Dir:          DefaultAttachmentsDir,
+			MaxSize:      DefaultAttachmentsMaxSize,
+			AllowedTypes: DefaultAttachmentTypes,
+		},
+		Snapshots: SnapshotsConfig{
+			Dir:  DefaultSnapshotsDir,
+			Keep: DefaultSnapshotsKeep,
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the config file, GOFIN_* environment
+// variables and flag overrides, each source taking precedence over the previous one.
+// An empty path falls back to GOFIN_CONFIG and then to gofin.yaml if it exists.
+func Load(path string, overrides Overrides) (*Config, error) {
+	cfg := Default()
+
+	path, required := resolveConfigFile(path)
+	if path != "" {
+		if err := cfg.loadFile(path, required); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.applyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.applyOverrides(overrides); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) Validate() error {
+	if c.Server.ListenAddress == "" {
+		return fmt.Errorf("listen address cannot be empty")
+	}
+
+	if c.Server.DevMode && c.Server.AssetsDir == "" {
+		return fmt.Errorf("assets directory cannot be empty in dev mode")
+	}
+
+	if c.Database.Path == "" {
+		return fmt.Errorf("database path cannot be empty")
+	}
+
+	switch c.Database.Driver {
+	case DatabaseDriverSQLite:
+	case DatabaseDriverPostgres:
+		if c.Database.DSN == "" {
+			return fmt.Errorf("database dsn cannot be empty with the postgres driver")
+		}
+		if c.Snapshots.Interval > 0 {
+			return fmt.Errorf("scheduled snapshots are not supported with the postgres driver")
+		}
+	default:
+		return fmt.Errorf("database driver must be sqlite or postgres")
+	}
+
+	if c.Session.TTL <= 0 {
+		return fmt.Errorf("session ttl must be positive")
+	}
+
+	if c.Server.ReadTimeout <= 0 || c.Server.WriteTimeout <= 0 || c.Server.IdleTimeout <= 0 || c.Server.ShutdownTimeout <= 0 {
+		return fmt.Errorf("server timeouts must be positive")
+	}
+
+	if c.Server.BasePath != "" && (!strings.HasPrefix(c.Server.BasePath, "/") || strings.HasSuffix(c.Server.BasePath, "/")) {
+		return fmt.Errorf("base path must start with '/' and must not end with '/'")
+	}
+
+	if c.Attachments.Dir == "" {
+		return fmt.Errorf("attachments directory cannot be empty")
+	}
+
+	if c.Attachments.MaxSize <= 0 {
+		return fmt.Errorf("attachments max size must be positive")
+	}
+
+	if len(c.Attachments.AllowedTypes) == 0 {
+		return fmt.Errorf("at least one attachment type must be allowed")
+	}
+
+	if c.Snapshots.Dir == "" {
+		return fmt.Errorf("snapshots directory cannot be empty")
+	}
+
+	if c.Snapshots.Interval < 0 || c.Snapshots.Keep < 0 {
+		return fmt.Errorf("snapshots interval and keep cannot be negative")
+	}
+
+	if _, err := ParseLogLevel(c.Log.Level); err != nil {
+		return err
+	}
+
+	if c.Log.Format != "text" && c.Log.Format != "json" {
+		return fmt.Errorf("log format must be text or json")
+	}
+
+	return nil
+}
+
+func (c *Config) CookiePath() string {
+	if c.Server.BasePath == "" {
+		return "/"
+	}
+
+	return c.Server.BasePath
+}
+
+func ParseLogLevel(level string) (slog.Level, error) {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("invalid log level: %s", level)
+	}
+
+	return parsed, nil
+}
+
+func resolveConfigFile(path string) (string, bool) {
+	if path != "" {
+		return path, true
+	}
+
+	if envPath, ok := os.LookupEnv(ConfigFileEnv); ok && envPath != "" {
+		return envPath, true
+	}
+
+	return DefaultConfigFile, false
+}
+
+func (c *Config) loadFile(path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !required {
+			return nil
+		}
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
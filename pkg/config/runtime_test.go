@@ -0,0 +1,306 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv unsets every GOFIN_* variable for the test, so the environment the
+// tests run in cannot leak into the configuration.
+func clearEnv(t *testing.T) {
+	t.Helper()
+
+	t.Setenv(ConfigFileEnv, "")
+	for _, s := range settings {
+		t.Setenv(envName(s.flag), "")
+	}
+}
+
+func writeConfigFile(t *testing.T, dir, content string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	fileContent := `
+server:
+  listen_address: ":9000"
+  read_timeout: 5s
+database:
+  path: file.db
+session:
+  ttl: 1h
+log:
+  level: debug
+`
+
+	tests := []struct {
+		name      string
+		env       map[string]string
+		overrides Overrides
+		check     func(t *testing.T, cfg *Config)
+	}{
+		{
+			name: "file overrides defaults",
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.ListenAddress != ":9000" || cfg.Database.Path != "file.db" || cfg.Session.TTL != time.Hour || cfg.Server.ReadTimeout != 5*time.Second {
+					t.Errorf("Expected the file values, got %+v", cfg)
+				}
+				if cfg.Server.WriteTimeout != DefaultWriteTimeout || cfg.Log.Format != DefaultLogFormat {
+					t.Errorf("Expected defaults for settings missing from the file, got %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "env overrides file",
+			env:  map[string]string{"GOFIN_LISTEN_ADDRESS": ":9100", "GOFIN_SESSION_TTL": "2h", "GOFIN_METRICS": "true"},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.ListenAddress != ":9100" || cfg.Session.TTL != 2*time.Hour || !cfg.Metrics.Enabled {
+					t.Errorf("Expected the env values, got %+v", cfg)
+				}
+				if cfg.Database.Path != "file.db" {
+					t.Errorf("Expected the file value where env is unset, got %s", cfg.Database.Path)
+				}
+			},
+		},
+		{
+			name: "empty env is ignored",
+			env:  map[string]string{"GOFIN_LISTEN_ADDRESS": ""},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.ListenAddress != ":9000" {
+					t.Errorf("Expected the file value, got %s", cfg.Server.ListenAddress)
+				}
+			},
+		},
+		{
+			name:      "flags override env",
+			env:       map[string]string{"GOFIN_LISTEN_ADDRESS": ":9100", "GOFIN_LOG_LEVEL": "warn"},
+			overrides: Overrides{"listen-address": ":9200", "db-path": "flag.db", "attachments-types": " image/png, ,application/pdf "},
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.Server.ListenAddress != ":9200" || cfg.Database.Path != "flag.db" {
+					t.Errorf("Expected the flag values, got %+v", cfg)
+				}
+				if cfg.Log.Level != "warn" || cfg.Session.TTL != time.Hour {
+					t.Errorf("Expected env and file values where no flag is set, got %+v", cfg)
+				}
+				if want := []string{"image/png", "application/pdf"}; !reflect.DeepEqual(cfg.Attachments.AllowedTypes, want) {
+					t.Errorf("Expected attachment types %v, got %v", want, cfg.Attachments.AllowedTypes)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			path := writeConfigFile(t, t.TempDir(), fileContent)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := Load(path, tt.overrides)
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	clearEnv(t)
+	t.Chdir(t.TempDir())
+
+	cfg, err := Load("", nil)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("Expected the defaults without a config file, got %+v", cfg)
+	}
+}
+
+func TestLoad_ConfigFileLookup(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        func(dir string) string
+		env         func(dir string) string
+		defaultFile bool
+		wantAddress string
+		wantErr     string
+	}{
+		{
+			name:        "explicit path",
+			path:        func(dir string) string { return writeConfigFile(t, dir, `server: {listen_address: ":7001"}`) },
+			wantAddress: ":7001",
+		},
+		{
+			name:    "explicit path is required",
+			path:    func(dir string) string { return filepath.Join(dir, "missing.yaml") },
+			wantErr: "failed to read config file",
+		},
+		{
+			name:        "path from GOFIN_CONFIG",
+			env:         func(dir string) string { return writeConfigFile(t, dir, `server: {listen_address: ":7002"}`) },
+			wantAddress: ":7002",
+		},
+		{
+			name:    "path from GOFIN_CONFIG is required",
+			env:     func(dir string) string { return filepath.Join(dir, "missing.yaml") },
+			wantErr: "failed to read config file",
+		},
+		{
+			name:        "explicit path wins over GOFIN_CONFIG",
+			path:        func(dir string) string { return writeConfigFile(t, dir, `server: {listen_address: ":7003"}`) },
+			env:         func(dir string) string { return filepath.Join(dir, "missing.yaml") },
+			wantAddress: ":7003",
+		},
+		{
+			name:        "default file in the working directory",
+			defaultFile: true,
+			wantAddress: ":7004",
+		},
+		{
+			name:        "missing default file is optional",
+			wantAddress: DefaultListenAddress,
+		},
+		{
+			name:    "invalid yaml",
+			path:    func(dir string) string { return writeConfigFile(t, dir, "server: [") },
+			wantErr: "failed to parse config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			dir := t.TempDir()
+			t.Chdir(dir)
+
+			if tt.defaultFile {
+				if err := os.WriteFile(DefaultConfigFile, []byte(`server: {listen_address: ":7004"}`), 0o600); err != nil {
+					t.Fatalf("Failed to write config file: %v", err)
+				}
+			}
+
+			var path string
+			if tt.path != nil {
+				path = tt.path(dir)
+			}
+			if tt.env != nil {
+				t.Setenv(ConfigFileEnv, tt.env(t.TempDir()))
+			}
+
+			cfg, err := Load(path, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if cfg.Server.ListenAddress != tt.wantAddress {
+				t.Errorf("Expected listen address %s, got %s", tt.wantAddress, cfg.Server.ListenAddress)
+			}
+		})
+	}
+}
+
+func TestLoad_RejectsInvalidConfig(t *testing.T) {
+	clearEnv(t)
+	t.Chdir(t.TempDir())
+
+	if _, err := Load("", Overrides{"log-format": "xml"}); err == nil || err.Error() != "log format must be text or json" {
+		t.Errorf("Load() error = %v, expected the validation error", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{name: "defaults are valid", modify: func(c *Config) {}},
+		{name: "empty listen address", modify: func(c *Config) { c.Server.ListenAddress = "" }, wantErr: "listen address cannot be empty"},
+		{name: "dev mode without assets", modify: func(c *Config) { c.Server.DevMode = true; c.Server.AssetsDir = "" }, wantErr: "assets directory cannot be empty in dev mode"},
+		{name: "assets only needed in dev mode", modify: func(c *Config) { c.Server.AssetsDir = "" }},
+		{name: "empty database path", modify: func(c *Config) { c.Database.Path = "" }, wantErr: "database path cannot be empty"},
+		{name: "postgres without dsn", modify: func(c *Config) { c.Database.Driver = DatabaseDriverPostgres }, wantErr: "database dsn cannot be empty with the postgres driver"},
+		{
+			name: "postgres with scheduled snapshots",
+			modify: func(c *Config) {
+				c.Database.Driver = DatabaseDriverPostgres
+				c.Database.DSN = "postgres://localhost/gofin"
+				c.Snapshots.Interval = time.Hour
+			},
+			wantErr: "scheduled snapshots are not supported with the postgres driver",
+		},
+		{
+			name: "postgres with dsn",
+			modify: func(c *Config) {
+				c.Database.Driver = DatabaseDriverPostgres
+				c.Database.DSN = "postgres://localhost/gofin"
+			},
+		},
+		{name: "unknown driver", modify: func(c *Config) { c.Database.Driver = "mysql" }, wantErr: "database driver must be sqlite or postgres"},
+		{name: "zero session ttl", modify: func(c *Config) { c.Session.TTL = 0 }, wantErr: "session ttl must be positive"},
+		{name: "zero read timeout", modify: func(c *Config) { c.Server.ReadTimeout = 0 }, wantErr: "server timeouts must be positive"},
+		{name: "negative write timeout", modify: func(c *Config) { c.Server.WriteTimeout = -time.Second }, wantErr: "server timeouts must be positive"},
+		{name: "zero idle timeout", modify: func(c *Config) { c.Server.IdleTimeout = 0 }, wantErr: "server timeouts must be positive"},
+		{name: "zero shutdown timeout", modify: func(c *Config) { c.Server.ShutdownTimeout = 0 }, wantErr: "server timeouts must be positive"},
+		{name: "base path without leading slash", modify: func(c *Config) { c.Server.BasePath = "gofin" }, wantErr: "base path must start with '/' and must not end with '/'"},
+		{name: "base path with trailing slash", modify: func(c *Config) { c.Server.BasePath = "/gofin/" }, wantErr: "base path must start with '/' and must not end with '/'"},
+		{name: "valid base path", modify: func(c *Config) { c.Server.BasePath = "/gofin" }},
+		{name: "empty attachments dir", modify: func(c *Config) { c.Attachments.Dir = "" }, wantErr: "attachments directory cannot be empty"},
+		{name: "zero attachments max size", modify: func(c *Config) { c.Attachments.MaxSize = 0 }, wantErr: "attachments max size must be positive"},
+		{name: "no attachment types", modify: func(c *Config) { c.Attachments.AllowedTypes = nil }, wantErr: "at least one attachment type must be allowed"},
+		{name: "empty snapshots dir", modify: func(c *Config) { c.Snapshots.Dir = "" }, wantErr: "snapshots directory cannot be empty"},
+		{name: "negative snapshots interval", modify: func(c *Config) { c.Snapshots.Interval = -time.Hour }, wantErr: "snapshots interval and keep cannot be negative"},
+		{name: "negative snapshots keep", modify: func(c *Config) { c.Snapshots.Keep = -1 }, wantErr: "snapshots interval and keep cannot be negative"},
+		{name: "invalid log level", modify: func(c *Config) { c.Log.Level = "verbose" }, wantErr: "invalid log level: verbose"},
+		{name: "invalid log format", modify: func(c *Config) { c.Log.Format = "xml" }, wantErr: "log format must be text or json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_CookiePath(t *testing.T) {
+	cfg := Default()
+	if got := cfg.CookiePath(); got != "/" {
+		t.Errorf("CookiePath() = %s, want /", got)
+	}
+
+	cfg.Server.BasePath = "/gofin"
+	if got := cfg.CookiePath(); got != "/gofin" {
+		t.Errorf("CookiePath() = %s, want /gofin", got)
+	}
+}
@@ -0,0 +1,330 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const envPrefix = "GOFIN_"
+
+// Overrides holds settings explicitly passed on the command line, keyed by flag name.
+type Overrides map[string]string
+
+type setting struct {
+	flag   string
+	usage  string
+	isBool bool
+	apply  func(c *Config, value string) error
+}
+
+var settings = []setting{
+	{
+		flag:  "listen-address",
+		usage: "address the web server listens on",
+		apply: func(c *Config, value string) error {
+			c.Server.ListenAddress = value
+			return nil
+		},
+	},
+	{
+		flag:  "base-path",
+		usage: "URL path prefix the web interface is served under",
+		apply: func(c *Config, value string) error {
+			c.Server.BasePath = value
+			return nil
+		},
+	},
+	{
+		flag:   "dev",
+		usage:  "serve templates and static files from disk, reloading them on every request",
+		isBool: true,
+		apply: func(c *Config, value string) error {
+			devMode, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid dev mode value: %w", err)
+			}
+			c.Server.DevMode = devMode
+			return nil
+		},
+	},
+	{
+		flag:  "assets-dir",
+		usage: "directory holding templates/ and static/ in dev mode",
+		apply: func(c *Config, value string) error {
+			c.Server.AssetsDir = value
+			return nil
+		},
+	},
+	{
+		flag:  "db-driver",
+		usage: "repository backend: sqlite or postgres",
+		apply: func(c *Config, value string) error {
+			c.Database.Driver = value
+			return nil
+		},
+	},
+	{
+		flag:  "db-path",
+		usage: "path to the SQLite database file",
+		apply: func(c *Config, value string) error {
+			c.Database.Path = value
+			return nil
+		},
+	},
+	{
+		flag:  "db-dsn",
+		usage: "PostgreSQL connection string, used with --db-driver postgres",
+		apply: func(c *Config, value string) error {
+			c.Database.DSN = value
+			return nil
+		},
+	},
+	{
+		flag:  "session-secret",
+		usage: "secret used to sign session tokens (random per process when empty)",
+		apply: func(c *Config, value string) error {
+			c.Session.Secret = value
+			return nil
+		},
+	},
+	durationSetting("session-ttl", "session lifetime, e.g. 24h", func(c *Config) *time.Duration { return &c.Session.TTL }),
+	durationSetting("read-timeout", "maximum duration for reading a request", func(c *Config) *time.Duration { return &c.Server.ReadTimeout }),
+	durationSetting("write-timeout", "maximum duration for writing a response", func(c *Config) *time.Duration { return &c.Server.WriteTimeout }),
+	durationSetting("idle-timeout", "how long keep-alive connections stay open", func(c *Config) *time.Duration { return &c.Server.IdleTimeout }),
+	durationSetting("shutdown-timeout", "how long to wait for in-flight requests on shutdown", func(c *Config) *time.Duration { return &c.Server.ShutdownTimeout }),
+	{
+		flag:   "secure-cookies",
+		usage:  "mark cookies as Secure (requires HTTPS)",
+		isBool: true,
+		apply: func(c *Config, value string) error {
+			secure, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid secure cookies value: %w", err)
+			}
+			c.Session.SecureCookies = secure
+			return nil
+		},
+	},
+	{
+		flag:  "log-level",
+		usage: "log level: debug, info, warn or error",
+		apply: func(c *Config, value string) error {
+			c.Log.Level = value
+			return nil
+		},
+	},
+	{
+		flag:   "metrics",
+		usage:  "expose Prometheus metrics on /metrics, without authentication",
+		isBool: true,
+		apply: func(c *Config, value string) error {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid metrics value: %w", err)
+			}
+			c.Metrics.Enabled = enabled
+			return nil
+		},
+	},
+	{
+		flag:  "attachments-dir",
+		usage: "directory where transaction attachments are stored",
+		apply: func(c *Config, value string) error {
+			c.Attachments.Dir = value
+			return nil
+		},
+	},
+	{
+		flag:  "attachments-max-size",
+		usage: "largest accepted attachment in bytes",
+		apply: func(c *Config, value string) error {
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid attachments max size: %w", err)
+			}
+			c.Attachments.MaxSize = size
+			return nil
+		},
+	},
+	{
+		flag:  "attachments-types",
+		usage: "comma-separated MIME types accepted as attachments",
+		apply: func(c *Config, value string) error {
+			var types []string
+			for _, t := range strings.Split(value, ",") {
+				if t = strings.TrimSpace(t); t != "" {
+					types = append(types, t)
+				}
+			}
+			c.Attachments.AllowedTypes = types
+			return nil
+		},
+	},
+	{
+		flag:  "snapshots-dir",
+		usage: "directory where database snapshots are written",
+		apply: func(c *Config, value string) error {
+			c.Snapshots.Dir = value
+			return nil
+		},
+	},
+	durationSetting("snapshots-interval", "how often the web server snapshots the database, 0 to never", func(c *Config) *time.Duration { return &c.Snapshots.Interval }),
+	{
+		flag:  "snapshots-keep",
+		usage: "number of snapshots to keep, 0 to keep all",
+		apply: func(c *Config, value string) error {
+			keep, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid snapshots keep: %w", err)
+			}
+			c.Snapshots.Keep = keep
+			return nil
+		},
+	},
+	{
+		flag:   "snapshots-compress",
+		usage:  "gzip database snapshots",
+		isBool: true,
+		apply: func(c *Config, value string) error {
+			compress, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid snapshots compress value: %w", err)
+			}
+			c.Snapshots.Compress = compress
+			return nil
+		},
+	},
+	{
+		flag:  "snapshots-passphrase",
+		usage: "passphrase database snapshots are encrypted with, none when empty",
+		apply: func(c *Config, value string) error {
+			c.Snapshots.Passphrase = value
+			return nil
+		},
+	},
+	{
+		flag:  "log-format",
+		usage: "log output format: text or json",
+		apply: func(c *Config, value string) error {
+			c.Log.Format = value
+			return nil
+		},
+	},
+}
+
+func durationSetting(name, usage string, target func(c *Config) *time.Duration) setting {
+	return setting{
+		flag:  name,
+		usage: usage,
+		apply: func(c *Config, value string) error {
+			duration, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid duration: %w", err)
+			}
+			*target(c) = duration
+			return nil
+		},
+	}
+}
+
+// RegisterFlags adds a --config flag and one flag per setting to fs. Only flags
+// that are explicitly set end up in the returned overrides.
+func RegisterFlags(fs *flag.FlagSet) (*string, Overrides) {
+	overrides := Overrides{}
+	configFile := fs.String("config", "", "path to the YAML config file (env "+ConfigFileEnv+")")
+
+	for _, s := range settings {
+		fs.Var(newOverrideValue(s.flag, s.isBool, overrides), s.flag, fmt.Sprintf("%s (env %s)", s.usage, envName(s.flag)))
+	}
+
+	return configFile, overrides
+}
+
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	for _, s := range settings {
+		value, ok := lookup(envName(s.flag))
+		if !ok || value == "" {
+			continue
+		}
+
+		if err := s.apply(c, value); err != nil {
+			return fmt.Errorf("%s: %w", envName(s.flag), err)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) applyOverrides(overrides Overrides) error {
+	for _, s := range settings {
+		value, ok := overrides[s.flag]
+		if !ok {
+			continue
+		}
+
+		if err := s.apply(c, value); err != nil {
+			return fmt.Errorf("--%s: %w", s.flag, err)
+		}
+	}
+
+	return nil
+}
+
+func envName(flagName string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+type overrideValue struct {
+	name      string
+	overrides Overrides
+}
+
+func newOverrideValue(name string, isBool bool, overrides Overrides) flag.Value {
+	value := &overrideValue{name: name, overrides: overrides}
+	if isBool {
+		return &boolOverrideValue{value}
+	}
+
+	return value
+}
+
+func (v *overrideValue) String() string {
+	if v == nil || v.overrides == nil {
+		return ""
+	}
+
+	return v.overrides[v.name]
+}
+
+func (v *overrideValue) Set(value string) error {
+	v.overrides[v.name] = value
+	return nil
+}
+
+func (v *overrideValue) Type() string {
+	return "string"
+}
+
+// boolOverrideValue lets boolean settings be passed as a bare --flag.
+type boolOverrideValue struct {
+	*overrideValue
+}
+
+func (v *boolOverrideValue) String() string {
+	if v == nil || v.overrideValue == nil || v.overrides[v.name] == "" {
+		return "false"
+	}
+
+	return v.overrides[v.name]
+}
+
+func (v *boolOverrideValue) IsBoolFlag() bool {
+	return true
+}
+
+func (v *boolOverrideValue) Type() string {
+	return "bool"
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig_ApplyEnv_InvalidValues(t *testing.T) {
+	tests := []struct {
+		env     string
+		value   string
+		wantErr string
+	}{
+		{env: "GOFIN_DEV", value: "maybe", wantErr: "GOFIN_DEV: invalid dev mode value"},
+		{env: "GOFIN_SECURE_COOKIES", value: "yes please", wantErr: "GOFIN_SECURE_COOKIES: invalid secure cookies value"},
+		{env: "GOFIN_METRICS", value: "on", wantErr: "GOFIN_METRICS: invalid metrics value"},
+		{env: "GOFIN_SNAPSHOTS_COMPRESS", value: "gzip", wantErr: "GOFIN_SNAPSHOTS_COMPRESS: invalid snapshots compress value"},
+		{env: "GOFIN_SESSION_TTL", value: "24", wantErr: "GOFIN_SESSION_TTL: invalid duration"},
+		{env: "GOFIN_READ_TIMEOUT", value: "soon", wantErr: "GOFIN_READ_TIMEOUT: invalid duration"},
+		{env: "GOFIN_SNAPSHOTS_INTERVAL", value: "daily", wantErr: "GOFIN_SNAPSHOTS_INTERVAL: invalid duration"},
+		{env: "GOFIN_ATTACHMENTS_MAX_SIZE", value: "10MB", wantErr: "GOFIN_ATTACHMENTS_MAX_SIZE: invalid attachments max size"},
+		{env: "GOFIN_SNAPSHOTS_KEEP", value: "all", wantErr: "GOFIN_SNAPSHOTS_KEEP: invalid snapshots keep"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			lookup := func(name string) (string, bool) {
+				if name == tt.env {
+					return tt.value, true
+				}
+				return "", false
+			}
+
+			err := Default().applyEnv(lookup)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("applyEnv() error = %v, want prefix %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"GOFIN_LISTEN_ADDRESS":       ":9000",
+		"GOFIN_BASE_PATH":            "/gofin",
+		"GOFIN_DEV":                  "true",
+		"GOFIN_DB_DRIVER":            "postgres",
+		"GOFIN_DB_DSN":               "postgres://localhost/gofin",
+		"GOFIN_SESSION_TTL":          "90m",
+		"GOFIN_ATTACHMENTS_MAX_SIZE": "1024",
+		"GOFIN_SNAPSHOTS_KEEP":       "3",
+		"GOFIN_LOG_FORMAT":           "json",
+	}
+
+	cfg := Default()
+	if err := cfg.applyEnv(func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}); err != nil {
+		t.Fatalf("applyEnv() unexpected error: %v", err)
+	}
+
+	if cfg.Server.ListenAddress != ":9000" || cfg.Server.BasePath != "/gofin" || !cfg.Server.DevMode {
+		t.Errorf("Unexpected server config %+v", cfg.Server)
+	}
+	if cfg.Database.Driver != DatabaseDriverPostgres || cfg.Database.DSN != "postgres://localhost/gofin" {
+		t.Errorf("Unexpected database config %+v", cfg.Database)
+	}
+	if cfg.Session.TTL != 90*time.Minute || cfg.Attachments.MaxSize != 1024 || cfg.Snapshots.Keep != 3 || cfg.Log.Format != "json" {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+}
+
+func TestConfig_ApplyOverrides_InvalidValue(t *testing.T) {
+	err := Default().applyOverrides(Overrides{"session-ttl": "forever"})
+	if err == nil || !strings.HasPrefix(err.Error(), "--session-ttl: invalid duration") {
+		t.Errorf("applyOverrides() error = %v, want the flag name in the error", err)
+	}
+}
+
+func TestRegisterFlags(t *testing.T) {
+	fs := flag.NewFlagSet("gofin", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFile, overrides := RegisterFlags(fs)
+
+	if err := fs.Parse([]string{"--config", "custom.yaml", "--listen-address", ":9000", "--dev", "--metrics"}); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	if *configFile != "custom.yaml" {
+		t.Errorf("Expected config file custom.yaml, got %s", *configFile)
+	}
+
+	want := Overrides{"listen-address": ":9000", "dev": "true", "metrics": "true"}
+	if len(overrides) != len(want) {
+		t.Errorf("Expected only the flags passed to be overrides, got %v", overrides)
+	}
+	for name, value := range want {
+		if overrides[name] != value {
+			t.Errorf("Expected override %s=%s, got %q", name, value, overrides[name])
+		}
+	}
+
+	for _, s := range settings {
+		usage := fs.Lookup(s.flag)
+		if usage == nil {
+			t.Errorf("Expected flag --%s to be registered", s.flag)
+			continue
+		}
+		if !strings.Contains(usage.Usage, envName(s.flag)) {
+			t.Errorf("Expected the usage of --%s to name %s, got %q", s.flag, envName(s.flag), usage.Usage)
+		}
+	}
+}
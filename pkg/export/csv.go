@@ -0,0 +1,71 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// formulaPrefixes start a cell that a spreadsheet would evaluate as a formula.
+const formulaPrefixes = "=+-@\t\r"
+
+// writeCSV writes a lone table as a plain header and rows, ready for import
+// elsewhere. Several tables are written one after another, each led by its title
+// and separated by an empty line.
+func writeCSV(w io.Writer, document Document) error {
+	writer := csv.NewWriter(w)
+	sections := len(document.Tables) > 1
+
+	for i, table := range document.Tables {
+		if sections {
+			if i > 0 {
+				if err := writer.Write(nil); err != nil {
+					return err
+				}
+			}
+			if err := writer.Write([]string{escapeCSVCell(table.Title, false)}); err != nil {
+				return err
+			}
+		}
+
+		header := make([]string, len(table.Columns))
+		for j, column := range table.Columns {
+			header[j] = escapeCSVCell(column.Title, false)
+		}
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+
+		for _, row := range table.Rows {
+			record := make([]string, len(row))
+			for j, value := range row {
+				numeric := j < len(table.Columns) && table.Columns[j].Numeric
+				record[j] = escapeCSVCell(value, numeric)
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// escapeCSVCell keeps a spreadsheet from running text such as a bank-supplied
+// payee name as a formula, by prefixing it with a quote. Numbers in numeric
+// columns, negative ones included, are left as they are.
+func escapeCSVCell(value string, numeric bool) string {
+	if value == "" || !strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return value
+	}
+
+	if numeric {
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+	}
+
+	return "'" + value
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	transactions := Table{
+		Title:   "Transactions",
+		Columns: []Column{{Title: "Date"}, {Title: "Name"}, {Title: "Amount", Numeric: true}},
+		Rows: [][]string{
+			{"2024-01-05", "Salary, January", "1000.00"},
+			{"2024-01-20", `Shop "Centrum"`, "-12.50"},
+		},
+	}
+	balances := Table{
+		Title:   "Balances",
+		Columns: []Column{{Title: "Account"}, {Title: "Balance", Numeric: true}},
+		Rows:    [][]string{{"Checking", "987.50"}},
+	}
+
+	tests := []struct {
+		name     string
+		document Document
+		want     [][]string
+	}{
+		{
+			name:     "single table without title",
+			document: Document{Title: "Export", Tables: []Table{transactions}},
+			want: [][]string{
+				{"Date", "Name", "Amount"},
+				{"2024-01-05", "Salary, January", "1000.00"},
+				{"2024-01-20", `Shop "Centrum"`, "-12.50"},
+			},
+		},
+		{
+			name:     "several tables as titled sections",
+			document: Document{Title: "Export", Tables: []Table{transactions, balances}},
+			want: [][]string{
+				{"Transactions"},
+				{"Date", "Name", "Amount"},
+				{"2024-01-05", "Salary, January", "1000.00"},
+				{"2024-01-20", `Shop "Centrum"`, "-12.50"},
+				{"Balances"},
+				{"Account", "Balance"},
+				{"Checking", "987.50"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, CSV, tt.document); err != nil {
+				t.Fatalf("Write() unexpected error: %v", err)
+			}
+
+			reader := csv.NewReader(&buf)
+			reader.FieldsPerRecord = -1
+			got, err := reader.ReadAll()
+			if err != nil {
+				t.Fatalf("Failed to read CSV: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Write() rows = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteCSV_EscapesFormulas(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		numeric bool
+		want    string
+	}{
+		{name: "equals sign", value: `=HYPERLINK("http://evil","x")`, want: `'=HYPERLINK("http://evil","x")`},
+		{name: "plus sign", value: "+48 600 100 200", want: "'+48 600 100 200"},
+		{name: "minus sign", value: "-2+3", want: "'-2+3"},
+		{name: "at sign", value: "@SUM(A1)", want: "'@SUM(A1)"},
+		{name: "tab", value: "\t=1", want: "'\t=1"},
+		{name: "carriage return", value: "\r=1", want: "'\r=1"},
+		{name: "plain text", value: "Biedronka", want: "Biedronka"},
+		{name: "formula character later in the text", value: "A=B", want: "A=B"},
+		{name: "negative number in a numeric column", value: "-12.50", numeric: true, want: "-12.50"},
+		{name: "formula in a numeric column", value: "-1+cmd", numeric: true, want: "'-1+cmd"},
+		{name: "negative number in a text column", value: "-12.50", want: "'-12.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document := Document{Tables: []Table{{
+				Columns: []Column{{Title: "Value", Numeric: tt.numeric}},
+				Rows:    [][]string{{tt.value}},
+			}}}
+
+			var buf bytes.Buffer
+			if err := Write(&buf, CSV, document); err != nil {
+				t.Fatalf("Write() unexpected error: %v", err)
+			}
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("Failed to read CSV: %v", err)
+			}
+			if len(records) != 2 || records[1][0] != tt.want {
+				t.Errorf("Write() cell = %q, want %q", records, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+	PDF  Format = "pdf"
+)
+
+var AllFormats = []Format{CSV, XLSX, PDF}
+
+func (f Format) String() string {
+	return string(f)
+}
+
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(s))) {
+	case CSV:
+		return CSV, nil
+	case XLSX:
+		return XLSX, nil
+	case PDF:
+		return PDF, nil
+	default:
+		return "", fmt.Errorf("invalid export format: %s", s)
+	}
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case PDF:
+		return "application/pdf"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// FileName returns name with the extension of the format.
+func (f Format) FileName(name string) string {
+	return name + "." + f.String()
+}
+
+// Column is a table heading. Numeric columns hold plain decimal numbers, which
+// spreadsheets store as numbers and PDF aligns to the right.
+type Column struct {
+	Title   string
+	Numeric bool
+}
+
+type Table struct {
+	Title   string
+	Columns []Column
+	Rows    [][]string
+}
+
+// Document is what gets exported: a title and one or more tables, each of which
+// becomes a section of a CSV file or a PDF and a sheet of a workbook.
+type Document struct {
+	Title  string
+	Tables []Table
+}
+
+func Write(w io.Writer, format Format, document Document) error {
+	switch format {
+	case CSV:
+		return writeCSV(w, document)
+	case XLSX:
+		return writeXLSX(w, document)
+	case PDF:
+		return writePDF(w, document)
+	default:
+		return fmt.Errorf("invalid export format: %s", format)
+	}
+}
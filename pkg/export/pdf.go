@@ -0,0 +1,316 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 landscape leaves room for the columns of a transaction list.
+const (
+	pdfPageWidth   = 842.0
+	pdfPageHeight  = 595.0
+	pdfMargin      = 40.0
+	pdfFooterSpace = 24.0
+	pdfTitleSize   = 14.0
+	pdfHeadingSize = 11.0
+	pdfFontSize    = 9.0
+	pdfLineHeight  = 14.0
+	pdfCellPadding = 4.0
+	pdfMinColumn   = 40.0
+	pdfEllipsis    = "..."
+	pdfRegularFont = "F1"
+	pdfBoldFont    = "F2"
+	pdfFirstPageID = 6
+	pdfEmptyTable  = "Nothing to show."
+)
+
+// helveticaWidths are the widths of the printable ASCII characters of Helvetica
+// in thousandths of the font size, from the space up to the tilde.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// winAnsiFallbacks spell the letters the standard PDF fonts cannot show, such as
+// the Polish ones outside Latin-1, without their diacritics.
+var winAnsiFallbacks = map[rune]byte{
+	'ą': 'a', 'ć': 'c', 'ę': 'e', 'ł': 'l', 'ń': 'n', 'ś': 's', 'ź': 'z', 'ż': 'z',
+	'Ą': 'A', 'Ć': 'C', 'Ę': 'E', 'Ł': 'L', 'Ń': 'N', 'Ś': 'S', 'Ź': 'Z', 'Ż': 'Z',
+	'–': '-', '—': '-', '‘': '\'', '’': '\'', '“': '"', '”': '"', '…': '.',
+}
+
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	y     float64
+}
+
+// writePDF lays the tables out one below the other on as many pages as they
+// need, repeating the column headings on every page a table continues on. Like
+// in CSV, tables are only titled when there are several of them. It
+// only uses the standard Helvetica fonts, so text is limited to Latin-1.
+func writePDF(w io.Writer, document Document) error {
+	pdf := &pdfDocument{}
+	pdf.newPage()
+
+	if document.Title != "" {
+		pdf.text(pdfMargin, pdf.y-pdfTitleSize, pdfTitleSize, true, document.Title)
+		pdf.y -= pdfTitleSize + pdfLineHeight
+	}
+
+	headings := len(document.Tables) > 1
+	for _, table := range document.Tables {
+		pdf.table(table, headings)
+	}
+
+	return pdf.write(w, document.Title)
+}
+
+func (d *pdfDocument) newPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// fits starts a new page unless height still fits above the footer.
+func (d *pdfDocument) fits(height float64) bool {
+	if d.y-height >= pdfMargin+pdfFooterSpace {
+		return true
+	}
+	d.newPage()
+	return false
+}
+
+func (d *pdfDocument) table(table Table, heading bool) {
+	widths := columnWidths(table)
+
+	d.fits(pdfHeadingSize + 3*pdfLineHeight)
+	if heading && table.Title != "" {
+		d.text(pdfMargin, d.y-pdfHeadingSize, pdfHeadingSize, true, table.Title)
+		d.y -= pdfHeadingSize + pdfLineHeight/2
+	}
+
+	header := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		header[i] = column.Title
+	}
+	d.row(table, widths, header, true)
+
+	for _, row := range table.Rows {
+		if !d.fits(pdfLineHeight) {
+			d.row(table, widths, header, true)
+		}
+		d.row(table, widths, row, false)
+	}
+
+	if len(table.Rows) == 0 {
+		d.text(pdfMargin+pdfCellPadding, d.y-pdfFontSize-2, pdfFontSize, false, pdfEmptyTable)
+		d.y -= pdfLineHeight
+	}
+
+	d.y -= pdfLineHeight
+}
+
+func (d *pdfDocument) row(table Table, widths []float64, cells []string, heading bool) {
+	baseline := d.y - pdfFontSize - 2
+	x := pdfMargin
+	for i, width := range widths {
+		if i < len(cells) {
+			value := truncateText(cells[i], width-2*pdfCellPadding, heading)
+			left := x + pdfCellPadding
+			if table.Columns[i].Numeric {
+				left = x + width - pdfCellPadding - textWidth(value, heading)
+			}
+			d.text(left, baseline, pdfFontSize, heading, value)
+		}
+		x += width
+	}
+	d.y -= pdfLineHeight
+
+	if heading {
+		fmt.Fprintf(d.page, "0.6 G 0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, d.y+2, x, d.y+2)
+	}
+}
+
+func (d *pdfDocument) text(x, y, size float64, bold bool, value string) {
+	font := pdfRegularFont
+	if bold {
+		font = pdfBoldFont
+	}
+	fmt.Fprintf(d.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escapePDF(value))
+}
+
+func (d *pdfDocument) write(w io.Writer, title string) error {
+	out := &countingWriter{w: w}
+	var offsets []int64
+	object := func(body string) {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	fmt.Fprint(out, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", pdfFirstPageID+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) >>", escapePDF(title)))
+
+	for i, page := range d.pages {
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(d.pages))
+		fmt.Fprintf(page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", pdfRegularFont, pdfFontSize-1, pdfPageWidth-pdfMargin-textWidth(footer, false)*(pdfFontSize-1)/pdfFontSize, pdfMargin, footer)
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return fmt.Errorf("failed to compress page: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to compress page: %w", err)
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, pdfRegularFont, pdfBoldFont, len(offsets)+2))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.Bytes()))
+	}
+
+	xref := out.n
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.err
+}
+
+// columnWidths gives every column the width of its widest cell. When that does
+// not fit the page, numeric columns keep their width and the text columns share
+// what is left in proportion, so long names are the ones cut short.
+func columnWidths(table Table) []float64 {
+	available := pdfPageWidth - 2*pdfMargin
+	widths := make([]float64, len(table.Columns))
+	var total, text float64
+	for i, column := range table.Columns {
+		widths[i] = textWidth(column.Title, true)
+		for _, row := range table.Rows {
+			if i < len(row) {
+				widths[i] = max(widths[i], textWidth(row[i], false))
+			}
+		}
+		widths[i] += 2 * pdfCellPadding
+		total += widths[i]
+		if !column.Numeric {
+			text += widths[i]
+		}
+	}
+
+	if total <= available {
+		return widths
+	}
+
+	textColumns := 0
+	for _, column := range table.Columns {
+		if !column.Numeric {
+			textColumns++
+		}
+	}
+
+	if left := available - (total - text); textColumns > 0 && left >= float64(textColumns)*pdfMinColumn {
+		for i, column := range table.Columns {
+			if !column.Numeric {
+				widths[i] = max(widths[i]*left/text, pdfMinColumn)
+			}
+		}
+		return widths
+	}
+
+	for i := range widths {
+		widths[i] *= available / total
+	}
+
+	return widths
+}
+
+// textWidth measures value in points at the body font size.
+func textWidth(value string, bold bool) float64 {
+	var width int
+	for _, r := range value {
+		if r >= ' ' && r <= '~' {
+			width += helveticaWidths[r-' ']
+		} else {
+			width += 556
+		}
+	}
+
+	points := float64(width) * pdfFontSize / 1000
+	if bold {
+		points *= 1.05
+	}
+	return points
+}
+
+func truncateText(value string, width float64, bold bool) string {
+	if textWidth(value, bold) <= width {
+		return value
+	}
+
+	runes := []rune(value)
+	for len(runes) > 0 && textWidth(string(runes)+pdfEllipsis, bold) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + pdfEllipsis
+}
+
+// escapePDF encodes value as WinAnsi for a PDF literal string.
+func escapePDF(value string) string {
+	var escaped strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			escaped.WriteByte('\\')
+			escaped.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			escaped.WriteByte(' ')
+		case r >= ' ' && r <= '~':
+			escaped.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			escaped.WriteByte(byte(r))
+		default:
+			if fallback, ok := winAnsiFallbacks[r]; ok {
+				escaped.WriteByte(fallback)
+			} else {
+				escaped.WriteByte('?')
+			}
+		}
+	}
+	return escaped.String()
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
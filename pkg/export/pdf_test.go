@@ -0,0 +1,144 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	pdfStreamPattern = regexp.MustCompile(`(?s)<< /Length (\d+) /Filter /FlateDecode >>\nstream\n`)
+	pdfXrefPattern   = regexp.MustCompile(`(?m)^(\d{10}) 00000 n $`)
+)
+
+// pdfPages returns the decompressed content stream of every page.
+func pdfPages(t *testing.T, content []byte) []string {
+	t.Helper()
+
+	var pages []string
+	for _, match := range pdfStreamPattern.FindAllSubmatchIndex(content, -1) {
+		length, _ := strconv.Atoi(string(content[match[2]:match[3]]))
+		stream := content[match[1] : match[1]+length]
+
+		reader, err := zlib.NewReader(bytes.NewReader(stream))
+		if err != nil {
+			t.Fatalf("Failed to open page stream: %v", err)
+		}
+		page, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Failed to read page stream: %v", err)
+		}
+		pages = append(pages, string(page))
+	}
+
+	return pages
+}
+
+func TestWritePDF(t *testing.T) {
+	document := Document{Title: "Report (Q1)", Tables: []Table{
+		{
+			Title:   "Transactions",
+			Columns: []Column{{Title: "Name"}, {Title: "Amount", Numeric: true}},
+			Rows: [][]string{
+				{"Żabka – Łódź", "-12.50"},
+				{`C:\path (copy)`, "3.00"},
+			},
+		},
+		{Title: "Balances", Columns: []Column{{Title: "Account"}}},
+	}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, PDF, document); err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+	content := buf.Bytes()
+
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(content, []byte("%%EOF\n")) {
+		t.Fatalf("Expected a PDF header and trailer")
+	}
+
+	offsets := pdfXrefPattern.FindAllSubmatch(content, -1)
+	if len(offsets) != 7 {
+		t.Fatalf("Expected 7 objects in the cross-reference table, got %d", len(offsets))
+	}
+	for i, offset := range offsets {
+		position, _ := strconv.Atoi(string(offset[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(content[position:], []byte(want)) {
+			t.Errorf("Cross-reference entry %d does not point at its object", i+1)
+		}
+	}
+
+	if !bytes.Contains(content, []byte(`<< /Title (Report \(Q1\)) >>`)) {
+		t.Error("Expected the document title in the info dictionary")
+	}
+
+	pages := pdfPages(t, content)
+	if len(pages) != 1 {
+		t.Fatalf("Expected 1 page, got %d", len(pages))
+	}
+
+	for _, want := range []string{
+		"/F2 14.0 Tf 40.00 541.00 Td (Report \\(Q1\\)) Tj",
+		"/F2 11.0 Tf",
+		"(Transactions) Tj",
+		"(Balances) Tj",
+		"(Zabka - L\xf3dz) Tj",
+		`(C:\\path \(copy\)) Tj`,
+		"(-12.50) Tj",
+		"(" + pdfEmptyTable + ") Tj",
+		"(Page 1 of 1) Tj",
+	} {
+		if !strings.Contains(pages[0], want) {
+			t.Errorf("Expected the page to contain %q", want)
+		}
+	}
+}
+
+func TestWritePDF_Pages(t *testing.T) {
+	table := Table{Columns: []Column{{Title: "Name"}, {Title: "Amount", Numeric: true}}}
+	for i := 0; i < 100; i++ {
+		table.Rows = append(table.Rows, []string{fmt.Sprintf("Row %d", i), "1.00"})
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, PDF, Document{Tables: []Table{table}}); err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+
+	pages := pdfPages(t, buf.Bytes())
+	if len(pages) < 2 {
+		t.Fatalf("Expected 100 rows to need several pages, got %d", len(pages))
+	}
+
+	rows := 0
+	for i, page := range pages {
+		if !strings.Contains(page, "(Name) Tj") {
+			t.Errorf("Expected page %d to repeat the column headings", i+1)
+		}
+		if footer := fmt.Sprintf("(Page %d of %d) Tj", i+1, len(pages)); !strings.Contains(page, footer) {
+			t.Errorf("Expected page %d to have footer %q", i+1, footer)
+		}
+		rows += strings.Count(page, "(Row ")
+	}
+	if rows != 100 {
+		t.Errorf("Expected every row once, got %d", rows)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	long := strings.Repeat("Transaction ", 20)
+
+	got := truncateText(long, 100, false)
+	if !strings.HasSuffix(got, pdfEllipsis) || textWidth(got, false) > 100 {
+		t.Errorf("truncateText() = %q, expected it to fit 100 points with an ellipsis", got)
+	}
+
+	if got := truncateText("Short", 100, false); got != "Short" {
+		t.Errorf("truncateText() = %q, want the text unchanged", got)
+	}
+}
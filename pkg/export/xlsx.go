@@ -0,0 +1,169 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	maxSheetNameLength = 31
+	invalidSheetChars  = `[]:*?/\`
+
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+%s</Types>`
+	xlsxSheetContentType = `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+%s</sheets>
+</workbook>`
+	xlsxWorkbookSheet = `<sheet name="%s" sheetId="%d" r:id="rId%d"/>
+`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+%s<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+	xlsxWorkbookSheetRel = `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>
+`
+	// The styles are indexed by cell s attributes: 0 is the default, 1 a bold
+	// heading and 2 a number with two decimals.
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/><xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>
+</styleSheet>`
+
+	xlsxStyleHeading = 1
+	xlsxStyleNumber  = 2
+)
+
+type workbookPart struct {
+	name    string
+	content string
+}
+
+// writeXLSX writes an Office Open XML workbook with a sheet per table. Cells are
+// inline strings or numbers, which every spreadsheet reads without a shared
+// string table.
+func writeXLSX(w io.Writer, document Document) error {
+	archive := zip.NewWriter(w)
+
+	var contentTypes, sheets, rels strings.Builder
+	names := make(map[string]bool)
+	for i, table := range document.Tables {
+		number := i + 1
+		fmt.Fprintf(&contentTypes, xlsxSheetContentType, number)
+		fmt.Fprintf(&sheets, xlsxWorkbookSheet, escapeXML(sheetName(table.Title, number, names)), number, number)
+		fmt.Fprintf(&rels, xlsxWorkbookSheetRel, number, number)
+	}
+
+	files := []workbookPart{
+		{"[Content_Types].xml", fmt.Sprintf(xlsxContentTypes, contentTypes.String())},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, sheets.String())},
+		{"xl/_rels/workbook.xml.rels", fmt.Sprintf(xlsxWorkbookRels, rels.String(), len(document.Tables)+1)},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, table := range document.Tables {
+		files = append(files, workbookPart{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), worksheet(table)})
+	}
+
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s to workbook: %w", file.name, err)
+		}
+		if _, err := io.WriteString(writer, file.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.name, err)
+		}
+	}
+
+	return archive.Close()
+}
+
+func worksheet(table Table) string {
+	var sheet strings.Builder
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+
+	sheet.WriteString(`<row r="1">`)
+	for i, column := range table.Columns {
+		fmt.Fprintf(&sheet, `<c r="%s1" t="inlineStr" s="%d"><is><t>%s</t></is></c>`, cellColumn(i), xlsxStyleHeading, escapeXML(column.Title))
+	}
+	sheet.WriteString(`</row>`)
+
+	for i, row := range table.Rows {
+		rowNumber := i + 2
+		fmt.Fprintf(&sheet, `<row r="%d">`, rowNumber)
+		for j, value := range row {
+			if value == "" {
+				continue
+			}
+			reference := cellColumn(j) + strconv.Itoa(rowNumber)
+			if j < len(table.Columns) && table.Columns[j].Numeric {
+				if _, err := strconv.ParseFloat(value, 64); err == nil {
+					fmt.Fprintf(&sheet, `<c r="%s" s="%d"><v>%s</v></c>`, reference, xlsxStyleNumber, value)
+					continue
+				}
+			}
+			fmt.Fprintf(&sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, reference, escapeXML(value))
+		}
+		sheet.WriteString(`</row>`)
+	}
+
+	sheet.WriteString(`</sheetData></worksheet>`)
+	return sheet.String()
+}
+
+// cellColumn turns a zero-based column index into its letters: A to Z, then AA.
+func cellColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// sheetName makes a title usable as a sheet name, which must be unique, short
+// and free of a few characters.
+func sheetName(title string, number int, taken map[string]bool) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(invalidSheetChars, r) {
+			return ' '
+		}
+		return r
+	}, strings.TrimSpace(title))
+
+	if runes := []rune(name); len(runes) > maxSheetNameLength {
+		name = string(runes[:maxSheetNameLength])
+	}
+	if name == "" || taken[strings.ToLower(name)] {
+		name = fmt.Sprintf("Sheet%d", number)
+	}
+
+	taken[strings.ToLower(name)] = true
+	return name
+}
+
+func escapeXML(s string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(s))
+	return escaped.String()
+}
@@ -0,0 +1,151 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type testWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type testWorksheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Reference string `xml:"r,attr"`
+			Type      string `xml:"t,attr"`
+			Style     int    `xml:"s,attr"`
+			Value     string `xml:"v"`
+			Text      string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readWorkbookPart(t *testing.T, files map[string]*zip.File, name string, v any) {
+	t.Helper()
+
+	file, ok := files[name]
+	if !ok {
+		t.Fatalf("Workbook has no %s", name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", name, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	if err := xml.Unmarshal(content, v); err != nil {
+		t.Fatalf("Failed to parse %s: %v", name, err)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	document := Document{Title: "Export", Tables: []Table{
+		{
+			Title:   "Transactions",
+			Columns: []Column{{Title: "Date"}, {Title: "Name"}, {Title: "Amount", Numeric: true}},
+			Rows: [][]string{
+				{"2024-01-05", "Salary & bonus", "1000.00"},
+				{"2024-01-20", "=SUM(A1)", "-12.50"},
+				{"2024-01-21", "", "n/a"},
+			},
+		},
+		{Title: "Q1: in/out [PLN]", Columns: []Column{{Title: "Account"}}},
+		{Title: "Transactions", Columns: []Column{{Title: "Account"}}},
+	}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, XLSX, document); err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to open workbook: %v", err)
+	}
+	files := make(map[string]*zip.File)
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var workbook testWorkbook
+	readWorkbookPart(t, files, "xl/workbook.xml", &workbook)
+	var names []string
+	for _, sheet := range workbook.Sheets {
+		names = append(names, sheet.Name)
+	}
+	if want := []string{"Transactions", "Q1  in out  PLN ", "Sheet3"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Sheet names = %q, want %q", names, want)
+	}
+
+	for _, part := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet2.xml", "xl/worksheets/sheet3.xml"} {
+		if _, ok := files[part]; !ok {
+			t.Errorf("Workbook has no %s", part)
+		}
+	}
+
+	var sheet testWorksheet
+	readWorkbookPart(t, files, "xl/worksheets/sheet1.xml", &sheet)
+	if len(sheet.Rows) != 4 {
+		t.Fatalf("Expected a heading and 3 rows, got %d rows", len(sheet.Rows))
+	}
+
+	heading := sheet.Rows[0].Cells
+	if len(heading) != 3 || heading[2].Reference != "C1" || heading[2].Text != "Amount" || heading[2].Style != xlsxStyleHeading {
+		t.Errorf("Unexpected heading %+v", heading)
+	}
+
+	salary := sheet.Rows[1].Cells
+	if salary[1].Type != "inlineStr" || salary[1].Text != "Salary & bonus" {
+		t.Errorf("Expected an escaped inline string, got %+v", salary[1])
+	}
+	if salary[2].Type != "" || salary[2].Value != "1000.00" || salary[2].Style != xlsxStyleNumber {
+		t.Errorf("Expected a number cell, got %+v", salary[2])
+	}
+
+	formula := sheet.Rows[2].Cells
+	if formula[1].Type != "inlineStr" || formula[1].Text != "=SUM(A1)" {
+		t.Errorf("Expected a formula-like name to stay an inline string, got %+v", formula[1])
+	}
+	if formula[2].Value != "-12.50" {
+		t.Errorf("Expected a negative number cell, got %+v", formula[2])
+	}
+
+	unparsable := sheet.Rows[3].Cells
+	if len(unparsable) != 2 || unparsable[0].Reference != "A4" || unparsable[1].Reference != "C4" {
+		t.Fatalf("Expected the empty cell to be skipped, got %+v", unparsable)
+	}
+	if unparsable[1].Type != "inlineStr" || unparsable[1].Text != "n/a" {
+		t.Errorf("Expected text in a numeric column to be an inline string, got %+v", unparsable[1])
+	}
+}
+
+func TestCellColumn(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{index: 0, want: "A"},
+		{index: 25, want: "Z"},
+		{index: 26, want: "AA"},
+		{index: 51, want: "AZ"},
+		{index: 702, want: "AAA"},
+	}
+
+	for _, tt := range tests {
+		if got := cellColumn(tt.index); got != tt.want {
+			t.Errorf("cellColumn(%d) = %s, want %s", tt.index, got, tt.want)
+		}
+	}
+}
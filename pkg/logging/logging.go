@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+type contextKey string
+
+const (
+	loggerKey       contextKey = "logger"
+	requestAttrsKey contextKey = "requestAttrs"
+)
+
+// New builds a logger writing to w in the given format ("text" or "json").
+func New(w io.Writer, level slog.Level, format string) (*slog.Logger, error) {
+	options := &slog.HandlerOptions{Level: level}
+
+	switch format {
+	case FormatText, "":
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", format)
+	}
+}
+
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// FromContext returns the request-scoped logger, or the default logger outside a request.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+
+	return slog.Default()
+}
+
+type requestAttrs struct {
+	mu    sync.Mutex
+	attrs []slog.Attr
+}
+
+// WithRequestAttrs prepares ctx to collect attributes from inner middleware and
+// handlers, so an outer access log can report values resolved further down the chain.
+func WithRequestAttrs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestAttrsKey, &requestAttrs{})
+}
+
+func AddRequestAttrs(ctx context.Context, attrs ...slog.Attr) {
+	collected, ok := ctx.Value(requestAttrsKey).(*requestAttrs)
+	if !ok {
+		return
+	}
+
+	collected.mu.Lock()
+	defer collected.mu.Unlock()
+	collected.attrs = append(collected.attrs, attrs...)
+}
+
+func RequestAttrs(ctx context.Context) []slog.Attr {
+	collected, ok := ctx.Value(requestAttrsKey).(*requestAttrs)
+	if !ok {
+		return nil
+	}
+
+	collected.mu.Lock()
+	defer collected.mu.Unlock()
+	return append([]slog.Attr(nil), collected.attrs...)
+}
+
+// Err describes err for a log record. The message keeps the whole wrapped chain and,
+// when the error wraps others, the innermost cause is reported separately.
+func Err(err error) slog.Attr {
+	if err == nil {
+		return slog.String("error", "")
+	}
+
+	cause := err
+	for {
+		next := errors.Unwrap(cause)
+		if next == nil {
+			break
+		}
+		cause = next
+	}
+
+	if cause == err {
+		return slog.Group("error", slog.String("message", err.Error()))
+	}
+
+	return slog.Group("error",
+		slog.String("message", err.Error()),
+		slog.String("cause", cause.Error()),
+		slog.String("cause_type", fmt.Sprintf("%T", cause)),
+	)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+)
+
+// Recorder collects application metrics. Code records through this interface so it
+// does not depend on a particular backend; tests and tools can use Noop.
+type Recorder interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+	ObserveQuery(statement string, duration time.Duration, err error)
+	IncLogin(result string)
+	AddTransactionsCreated(projectSlug string, count int)
+	IncTransactionsDeleted(projectSlug string)
+	Handler() http.Handler
+}
+
+type Noop struct{}
+
+func NewNoop() *Noop {
+	return &Noop{}
+}
+
+func (Noop) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {}
+
+func (Noop) ObserveQuery(statement string, duration time.Duration, err error) {}
+
+func (Noop) IncLogin(result string) {}
+
+func (Noop) AddTransactionsCreated(projectSlug string, count int) {}
+
+func (Noop) IncTransactionsDeleted(projectSlug string) {}
+
+func (Noop) Handler() http.Handler {
+	return http.NotFoundHandler()
+}
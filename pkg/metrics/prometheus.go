@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "gofin"
+
+type Prometheus struct {
+	registry            *prometheus.Registry
+	httpRequestDuration *prometheus.HistogramVec
+	queryDuration       *prometheus.HistogramVec
+	queryErrors         *prometheus.CounterVec
+	logins              *prometheus.CounterVec
+	transactionsCreated *prometheus.CounterVec
+	transactionsDeleted *prometheus.CounterVec
+}
+
+// NewPrometheus registers the application metrics together with Go runtime, process
+// and connection pool statistics of db on a dedicated registry.
+func NewPrometheus(db *sql.DB) *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "sqlite_query_duration_seconds",
+			Help:      "Duration of SQLite statements issued by the repositories.",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1},
+		}, []string{"statement"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "sqlite_query_errors_total",
+			Help:      "SQLite statements that returned an error.",
+		}, []string{"statement"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "logins_total",
+			Help:      "Login attempts by result.",
+		}, []string{"result"}),
+		transactionsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_created_total",
+			Help:      "Transactions created per project.",
+		}, []string{"project"}),
+		transactionsDeleted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_deleted_total",
+			Help:      "Transactions deleted per project.",
+		}, []string{"project"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		collectors.NewDBStatsCollector(db, "sqlite"),
+		p.httpRequestDuration,
+		p.queryDuration,
+		p.queryErrors,
+		p.logins,
+		p.transactionsCreated,
+		p.transactionsDeleted,
+	)
+
+	return p
+}
+
+func (p *Prometheus) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	p.httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func (p *Prometheus) ObserveQuery(statement string, duration time.Duration, err error) {
+	p.queryDuration.WithLabelValues(statement).Observe(duration.Seconds())
+	if err != nil && err != sql.ErrNoRows {
+		p.queryErrors.WithLabelValues(statement).Inc()
+	}
+}
+
+func (p *Prometheus) IncLogin(result string) {
+	p.logins.WithLabelValues(result).Inc()
+}
+
+func (p *Prometheus) AddTransactionsCreated(projectSlug string, count int) {
+	p.transactionsCreated.WithLabelValues(projectSlug).Add(float64(count))
+}
+
+func (p *Prometheus) IncTransactionsDeleted(projectSlug string) {
+	p.transactionsDeleted.WithLabelValues(projectSlug).Inc()
+}
+
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
@@ -6,10 +6,12 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
-	"gofin/web"
 	"net/http"
 	"strings"
 	"time"
+
+	"gofin/pkg/config"
+	"gofin/web"
 )
 
 type SessionToken struct {
@@ -20,41 +22,78 @@ type SessionToken struct {
 }
 
 type SessionManager struct {
-	secretKey []byte
+	secretKey     []byte
+	sessionTTL    time.Duration
+	secureCookies bool
+	cookiePath    string
 }
 
-func NewSessionManager() *SessionManager {
-	secretKey := make([]byte, 32)
-	rand.Read(secretKey)
+// NewSessionManager signs tokens with the configured session secret. Without one
+// a random key is used, so sessions do not survive a restart.
+func NewSessionManager(cfg *config.Config) *SessionManager {
+	var secretKey []byte
+	if cfg.Session.Secret != "" {
+		sum := sha256.Sum256([]byte(cfg.Session.Secret))
+		secretKey = sum[:]
+	} else {
+		secretKey = make([]byte, 32)
+		rand.Read(secretKey)
+	}
 
 	return &SessionManager{
-		secretKey: secretKey,
+		secretKey:     secretKey,
+		sessionTTL:    cfg.Session.TTL,
+		secureCookies: cfg.Session.SecureCookies,
+		cookiePath:    cfg.CookiePath(),
 	}
 }
 
+const (
+	sessionTokenPurpose   = "session"
+	twoFactorTokenPurpose = "2fa"
+
+	twoFactorTokenTTL = 5 * time.Minute
+)
+
 func (sm *SessionManager) GenerateSessionToken(accessID, projectID string) (string, error) {
+	return sm.generateToken(sessionTokenPurpose, accessID, projectID, sm.sessionTTL)
+}
+
+func (sm *SessionManager) ValidateSessionToken(token string) (*SessionToken, bool) {
+	return sm.validateToken(sessionTokenPurpose, token)
+}
+
+// GenerateTwoFactorToken issues a short-lived token proving the PIN step of the
+// login succeeded. It cannot be used as a session token.
+func (sm *SessionManager) GenerateTwoFactorToken(accessID, projectID string) (string, error) {
+	return sm.generateToken(twoFactorTokenPurpose, accessID, projectID, twoFactorTokenTTL)
+}
+
+func (sm *SessionManager) ValidateTwoFactorToken(token string) (*SessionToken, bool) {
+	return sm.validateToken(twoFactorTokenPurpose, token)
+}
+
+func (sm *SessionManager) generateToken(purpose, accessID, projectID string, ttl time.Duration) (string, error) {
 	nonce := make([]byte, 16)
 	rand.Read(nonce)
 
 	token := SessionToken{
 		AccessID:  accessID,
 		ProjectID: projectID,
-		ExpiresAt: time.Now().Add(24 * time.Hour).Unix(),
+		ExpiresAt: time.Now().Add(ttl).Unix(),
 		Nonce:     base64.URLEncoding.EncodeToString(nonce),
 	}
 
 	tokenData := fmt.Sprintf("%s:%s:%d:%s", token.AccessID, token.ProjectID, token.ExpiresAt, token.Nonce)
 
-	h := hmac.New(sha256.New, sm.secretKey)
-	h.Write([]byte(tokenData))
-	signature := base64.URLEncoding.EncodeToString(h.Sum(nil))
+	signature := sm.sign(purpose, tokenData)
 
 	signedToken := fmt.Sprintf("%s.%s", tokenData, signature)
 
 	return base64.URLEncoding.EncodeToString([]byte(signedToken)), nil
 }
 
-func (sm *SessionManager) ValidateSessionToken(token string) (*SessionToken, bool) {
+func (sm *SessionManager) validateToken(purpose, token string) (*SessionToken, bool) {
 	tokenBytes, err := base64.URLEncoding.DecodeString(token)
 	if err != nil {
 		return nil, false
@@ -69,9 +108,7 @@ func (sm *SessionManager) ValidateSessionToken(token string) (*SessionToken, boo
 
 	tokenData, signature := parts[0], parts[1]
 
-	h := hmac.New(sha256.New, sm.secretKey)
-	h.Write([]byte(tokenData))
-	expectedSignature := base64.URLEncoding.EncodeToString(h.Sum(nil))
+	expectedSignature := sm.sign(purpose, tokenData)
 
 	if !hmac.Equal([]byte(signature), []byte(expectedSignature)) {
 		return nil, false
@@ -100,26 +137,56 @@ func (sm *SessionManager) ValidateSessionToken(token string) (*SessionToken, boo
 	return sessionToken, true
 }
 
-func SetSessionCookie(w http.ResponseWriter, value string) {
+func (sm *SessionManager) sign(purpose, tokenData string) string {
+	h := hmac.New(sha256.New, sm.secretKey)
+	h.Write([]byte(purpose + "|" + tokenData))
+	return base64.URLEncoding.EncodeToString(h.Sum(nil))
+}
+
+func (sm *SessionManager) SetSessionCookie(w http.ResponseWriter, value string) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     web.SessionTokenCookie,
 		Value:    value,
-		Path:     web.CookiePath,
-		MaxAge:   web.CookieMaxAge,
+		Path:     sm.cookiePath,
+		MaxAge:   int(sm.sessionTTL.Seconds()),
 		HttpOnly: true,
-		Secure:   false,
+		Secure:   sm.secureCookies,
 		SameSite: http.SameSiteStrictMode,
 	})
 }
 
-func ClearSessionCookie(w http.ResponseWriter) {
+func (sm *SessionManager) ClearSessionCookie(w http.ResponseWriter) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     web.SessionTokenCookie,
 		Value:    "",
-		Path:     web.CookiePath,
+		Path:     sm.cookiePath,
+		MaxAge:   web.CookieMaxAgeClear,
+		HttpOnly: true,
+		Secure:   sm.secureCookies,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func (sm *SessionManager) SetTwoFactorCookie(w http.ResponseWriter, value string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     web.TwoFactorCookie,
+		Value:    value,
+		Path:     sm.cookiePath,
+		MaxAge:   web.TwoFactorCookieMaxAge,
+		HttpOnly: true,
+		Secure:   sm.secureCookies,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func (sm *SessionManager) ClearTwoFactorCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     web.TwoFactorCookie,
+		Value:    "",
+		Path:     sm.cookiePath,
 		MaxAge:   web.CookieMaxAgeClear,
 		HttpOnly: true,
-		Secure:   false,
+		Secure:   sm.secureCookies,
 		SameSite: http.SameSiteStrictMode,
 	})
 }
@@ -0,0 +1,165 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	Digits     = 6
+	Period     = 30
+	SecretSize = 20
+	Skew       = 1
+
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeLength   = 10
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func GenerateSecret() (string, error) {
+	secret := make([]byte, SecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return generateCodeForCounter(key, uint64(t.Unix()/Period)), nil
+}
+
+func Validate(secret, code string, t time.Time) bool {
+	_, ok := Match(secret, code, t)
+	return ok
+}
+
+// Match reports the time step a code was generated for, allowing Skew steps of
+// clock drift either way. Callers that must not accept a code twice store the
+// step and refuse codes for the same or an earlier one.
+func Match(secret, code string, t time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	key, err := decodeSecret(secret)
+	if err != nil {
+		return 0, false
+	}
+
+	counter := t.Unix() / Period
+	for offset := int64(-Skew); offset <= Skew; offset++ {
+		expected := generateCodeForCounter(key, uint64(counter+offset))
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter + offset, true
+		}
+	}
+
+	return 0, false
+}
+
+func ProvisioningURI(secret, issuer, accountName string) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprintf("%d", Digits))
+	q.Set("period", fmt.Sprintf("%d", Period))
+
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, 0, count)
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+
+	for i := 0; i < count; i++ {
+		var sb strings.Builder
+		for j := 0; j < recoveryCodeLength; j++ {
+			if j == recoveryCodeLength/2 {
+				sb.WriteByte('-')
+			}
+
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+			}
+			sb.WriteByte(recoveryCodeAlphabet[n.Int64()])
+		}
+		codes = append(codes, sb.String())
+	}
+
+	return codes, nil
+}
+
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// IsRecoveryCode reports whether a normalized code has the shape of a recovery
+// code, so anything else can be refused without hashing it.
+func IsRecoveryCode(code string) bool {
+	if len(code) != recoveryCodeLength+1 {
+		return false
+	}
+
+	for i := 0; i < len(code); i++ {
+		if i == recoveryCodeLength/2 {
+			if code[i] != '-' {
+				return false
+			}
+			continue
+		}
+		if !strings.ContainsRune(recoveryCodeAlphabet, rune(code[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret: %w", err)
+	}
+
+	return key, nil
+}
+
+func generateCodeForCounter(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	h := hmac.New(sha1.New, key)
+	h.Write(msg[:])
+	sum := h.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
@@ -0,0 +1,137 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 seed of RFC 6238 appendix B, "12345678901234567890",
+// in base32.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCode_RFC6238(t *testing.T) {
+	// The RFC lists eight-digit codes; six-digit codes are their last six digits.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := GenerateCode(rfcSecret, time.Unix(tt.unix, 0))
+			if err != nil {
+				t.Fatalf("GenerateCode() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GenerateCode(%d) = %s, want %s", tt.unix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := now.Unix() / Period
+
+	codeAt := func(t *testing.T, at time.Time) string {
+		code, err := GenerateCode(rfcSecret, at)
+		if err != nil {
+			t.Fatalf("GenerateCode() unexpected error: %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name        string
+		code        string
+		secret      string
+		wantCounter int64
+		wantOK      bool
+	}{
+		{name: "current step", code: codeAt(t, now), secret: rfcSecret, wantCounter: step, wantOK: true},
+		{name: "previous step within skew", code: codeAt(t, now.Add(-Period*time.Second)), secret: rfcSecret, wantCounter: step - 1, wantOK: true},
+		{name: "next step within skew", code: codeAt(t, now.Add(Period*time.Second)), secret: rfcSecret, wantCounter: step + 1, wantOK: true},
+		{name: "surrounding whitespace", code: " " + codeAt(t, now) + "\n", secret: rfcSecret, wantCounter: step, wantOK: true},
+		{name: "outside skew", code: codeAt(t, now.Add(-2*Period*time.Second)), secret: rfcSecret},
+		{name: "wrong length", code: "05047", secret: rfcSecret},
+		{name: "invalid secret", code: codeAt(t, now), secret: "not base32!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter, ok := Match(tt.secret, tt.code, now)
+			if ok != tt.wantOK || counter != tt.wantCounter {
+				t.Errorf("Match() = (%d, %v), want (%d, %v)", counter, ok, tt.wantCounter, tt.wantOK)
+			}
+			if got := Validate(tt.secret, tt.code, now); got != tt.wantOK {
+				t.Errorf("Validate() = %v, want %v", got, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() unexpected error: %v", err)
+	}
+
+	key, err := decodeSecret(secret)
+	if err != nil {
+		t.Fatalf("decodeSecret() unexpected error: %v", err)
+	}
+	if len(key) != SecretSize {
+		t.Errorf("Expected a %d byte secret, got %d", SecretSize, len(key))
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes() unexpected error: %v", err)
+	}
+
+	if len(codes) != 10 {
+		t.Fatalf("Expected 10 codes, got %d", len(codes))
+	}
+	for _, code := range codes {
+		if !IsRecoveryCode(code) {
+			t.Errorf("IsRecoveryCode(%q) = false for a generated code", code)
+		}
+	}
+
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: NormalizeRecoveryCode(" ABCDE-FGHJK "), want: true},
+		{code: "abcdefghjk", want: false},
+		{code: "abcde_fghjk", want: false},
+		{code: "abcde-fghj1", want: false},
+		{code: "123456", want: false},
+		{code: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := IsRecoveryCode(tt.code); got != tt.want {
+				t.Errorf("IsRecoveryCode(%q) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	got := ProvisioningURI(rfcSecret, "gofin", "Home Budget")
+	want := "otpauth://totp/gofin:Home%20Budget?algorithm=SHA1&digits=6&issuer=gofin&period=30&secret=" + rfcSecret
+	if got != want {
+		t.Errorf("ProvisioningURI() = %s, want %s", got, want)
+	}
+}
@@ -9,8 +9,11 @@ import (
 type contextKey string
 
 const (
-	projectKey contextKey = "project"
-	accessKey  contextKey = "access"
+	projectKey   contextKey = "project"
+	accessKey    contextKey = "access"
+	csrfKey      contextKey = "csrf"
+	basePathKey  contextKey = "basePath"
+	requestIDKey contextKey = "requestID"
 )
 
 func SetProject(ctx context.Context, project *models.Project) context.Context {
@@ -30,3 +33,30 @@ func GetAccess(ctx context.Context) (*models.Access, bool) {
 	access, ok := ctx.Value(accessKey).(*models.Access)
 	return access, ok
 }
+
+func SetCSRFToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, csrfKey, token)
+}
+
+func GetCSRFToken(ctx context.Context) string {
+	token, _ := ctx.Value(csrfKey).(string)
+	return token
+}
+
+func SetBasePath(ctx context.Context, basePath string) context.Context {
+	return context.WithValue(ctx, basePathKey, basePath)
+}
+
+func GetBasePath(ctx context.Context) string {
+	basePath, _ := ctx.Value(basePathKey).(string)
+	return basePath
+}
+
+func SetRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
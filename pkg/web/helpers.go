@@ -4,10 +4,11 @@ import (
 	"net/http"
 	"net/url"
 
+	"gofin/pkg/logging"
 	"gofin/web"
 )
 
-const TemplatesDir = "web/templates"
+const TemplatesDir = web.TemplatesDir
 
 func RedirectWithSuccess(w http.ResponseWriter, r *http.Request, redirectPath, successMessage string) {
 	u, _ := url.Parse(redirectPath)
@@ -17,18 +18,30 @@ func RedirectWithSuccess(w http.ResponseWriter, r *http.Request, redirectPath, s
 	http.Redirect(w, r, u.String(), http.StatusSeeOther)
 }
 
+// ServerError logs err with the request logger and answers with a 500 carrying only
+// the generic message, so internal details never reach the client.
+func ServerError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	logging.FromContext(r.Context()).ErrorContext(r.Context(), message, logging.Err(err))
+	http.Error(w, message, http.StatusInternalServerError)
+}
+
 func GetTemplatePath(filename string) string {
 	return TemplatesDir + "/" + filename
 }
 
+// ProjectURL builds an absolute path to a project route, honouring the configured base path.
+func ProjectURL(r *http.Request, projectSlug, route string) string {
+	return GetBasePath(r.Context()) + "/" + projectSlug + route
+}
+
 func RedirectToProjectLogin(w http.ResponseWriter, r *http.Request, projectSlug string) {
-	http.Redirect(w, r, "/"+projectSlug+web.RouteLogin, http.StatusSeeOther)
+	http.Redirect(w, r, ProjectURL(r, projectSlug, web.RouteLogin), http.StatusSeeOther)
 }
 
 func RedirectToProjectDashboard(w http.ResponseWriter, r *http.Request, projectSlug string) {
-	http.Redirect(w, r, "/"+projectSlug+web.RouteDashboard, http.StatusSeeOther)
+	http.Redirect(w, r, ProjectURL(r, projectSlug, web.RouteDashboard), http.StatusSeeOther)
 }
 
 func RedirectToProjectHomeWithSuccess(w http.ResponseWriter, r *http.Request, projectSlug, successMessage string) {
-	RedirectWithSuccess(w, r, "/"+projectSlug+web.RouteDashboard, successMessage)
+	RedirectWithSuccess(w, r, ProjectURL(r, projectSlug, web.RouteDashboard), successMessage)
 }
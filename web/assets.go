@@ -0,0 +1,56 @@
+package web
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+//go:embed templates static
+var embeddedFiles embed.FS
+
+// Assets gives access to templates and static files. By default they are read from
+// the copy embedded in the binary; in dev mode they are read from disk on every
+// request so edits show up without a restart.
+type Assets struct {
+	files   fs.FS
+	devMode bool
+}
+
+func NewEmbeddedAssets() *Assets {
+	return &Assets{files: embeddedFiles}
+}
+
+func NewDiskAssets(dir string) (*Assets, error) {
+	if _, err := os.Stat(dir + "/" + TemplatesDir); err != nil {
+		return nil, fmt.Errorf("assets directory %s has no %s: %w", dir, TemplatesDir, err)
+	}
+
+	return &Assets{files: os.DirFS(dir), devMode: true}, nil
+}
+
+func NewAssets(devMode bool, dir string) (*Assets, error) {
+	if devMode {
+		return NewDiskAssets(dir)
+	}
+
+	return NewEmbeddedAssets(), nil
+}
+
+func (a *Assets) DevMode() bool {
+	return a.devMode
+}
+
+func (a *Assets) Templates() fs.FS {
+	return a.files
+}
+
+func (a *Assets) Static() (fs.FS, error) {
+	static, err := fs.Sub(a.files, StaticDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open static assets: %w", err)
+	}
+
+	return static, nil
+}
@@ -0,0 +1,236 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/create_account"
+	"gofin/internal/cases/credit_card_statements"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/money"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	accountsTemplateFile   = "accounts.html"
+	statementsTemplateFile = "statements.html"
+	accountsBodyClass      = "dashboard-page"
+	accountsTitle          = "Accounts"
+	statementCycles        = 12
+)
+
+type AccountTypeOption struct {
+	Value string
+	Label string
+}
+
+type AccountDisplay struct {
+	ID          string
+	Name        string
+	Type        string
+	TypeLabel   string
+	Currency    string
+	Description string
+	Archived    bool
+	ArchivedAt  string
+	First       bool
+	Last        bool
+
+	IsLoan        bool
+	IsInvestment  bool
+	IsCreditCard  bool
+	HasStatements bool
+	CreditLimit   string
+	StatementDay  int
+	DueDay        int
+}
+
+type StatementDisplay struct {
+	Period   string
+	DueDate  string
+	Charges  string
+	Payments string
+	Balance  string
+	Count    int
+}
+
+type AccountsComponent struct {
+	container          *container.Container
+	template           *pageTemplate
+	statementsTemplate *pageTemplate
+}
+
+func NewAccountsComponent(container *container.Container, assets *web.Assets) (*AccountsComponent, error) {
+	tmpl, err := parsePageTemplate(assets, accountsTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse accounts template: %w", err)
+	}
+
+	statementsTmpl, err := parsePageTemplate(assets, statementsTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse statements template: %w", err)
+	}
+
+	return &AccountsComponent{
+		container:          container,
+		template:           tmpl,
+		statementsTemplate: statementsTmpl,
+	}, nil
+}
+
+// RenderAccounts lists every account of the project in display order, archived
+// ones included so they can be restored.
+func (c *AccountsComponent) RenderAccounts(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, successKey, errorMsg string) {
+	accounts, err := c.container.AccountRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project accounts", err)
+		return
+	}
+
+	var displayAccounts []AccountDisplay
+	for i, account := range accounts {
+		display := AccountDisplay{
+			ID:          account.ID.String(),
+			Name:        account.Name,
+			Type:        account.Type.String(),
+			TypeLabel:   account.Type.Label(),
+			Currency:    account.Currency.String(),
+			Description: account.Description,
+			Archived:    account.IsArchived(),
+			First:       i == 0,
+			Last:        i == len(accounts)-1,
+
+			IsLoan:        account.Type == models.AccountLoan,
+			IsInvestment:  account.Type == models.AccountInvestment,
+			IsCreditCard:  account.Type == models.AccountCreditCard,
+			HasStatements: account.HasStatements(),
+			StatementDay:  account.StatementDay,
+			DueDay:        account.DueDay,
+		}
+		if account.CreditLimit > 0 {
+			display.CreditLimit = strconv.FormatFloat(account.CreditLimit, 'f', 2, 64)
+		}
+		if account.ArchivedAt != nil {
+			display.ArchivedAt = account.ArchivedAt.Format("2006-01-02")
+		}
+		displayAccounts = append(displayAccounts, display)
+	}
+
+	types := make([]AccountTypeOption, 0, len(models.AccountTypes))
+	for _, accountType := range models.AccountTypes {
+		types = append(types, AccountTypeOption{Value: accountType.String(), Label: accountType.Label()})
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug          string
+		ReadOnly             bool
+		SuccessMsg           string
+		ErrorMsg             string
+		Accounts             []AccountDisplay
+		NewAccount           AccountDisplay
+		Types                []AccountTypeOption
+		Currencies           []money.Currency
+		MaxNameLength        int
+		MaxDescriptionLength int
+	}{
+		PageData:             newPageData(r, accountsTitle, accountsBodyClass),
+		ProjectSlug:          project.Slug,
+		ReadOnly:             access.ReadOnly,
+		ErrorMsg:             errorMsg,
+		Accounts:             displayAccounts,
+		Types:                types,
+		Currencies:           money.AllCurrencies,
+		MaxNameLength:        create_account.MaxNameLength,
+		MaxDescriptionLength: models.MaxAccountDescriptionLength,
+	}
+
+	switch successKey {
+	case web.SuccessKeyAccountCreated:
+		data.SuccessMsg = web.SuccessAccountCreated
+	case web.SuccessKeyAccountUpdated:
+		data.SuccessMsg = web.SuccessAccountUpdated
+	case web.SuccessKeyAccountArchived:
+		data.SuccessMsg = web.SuccessAccountArchived
+	case web.SuccessKeyAccountUnarchived:
+		data.SuccessMsg = web.SuccessAccountUnarchived
+	case web.SuccessKeyTransferRecorded:
+		data.SuccessMsg = web.SuccessTransferRecorded
+	}
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render accounts", err)
+	}
+}
+
+// RenderStatements shows the balance, available credit and billing cycles of a
+// credit card, answering 404 for other accounts.
+func (c *AccountsComponent) RenderStatements(w http.ResponseWriter, r *http.Request, project *models.Project, accountID uuid.UUID) {
+	summary, err := c.container.CreditCardStatementsService.GetStatements(r.Context(), project.ID, accountID, statementCycles, time.Now())
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	currency := summary.Account.Currency.String()
+	format := func(amount float64) string {
+		return fmt.Sprintf("%.2f %s", amount, currency)
+	}
+
+	newStatement := func(statement credit_card_statements.Statement) StatementDisplay {
+		display := StatementDisplay{
+			Period:   statement.Start.Format("2006-01-02") + " – " + statement.End.Format("2006-01-02"),
+			Charges:  format(statement.Charges),
+			Payments: format(statement.Payments),
+			Balance:  format(statement.Balance),
+			Count:    statement.Count,
+		}
+		if !statement.DueDate.IsZero() {
+			display.DueDate = statement.DueDate.Format("2006-01-02")
+		}
+		return display
+	}
+
+	var statements []StatementDisplay
+	for _, statement := range summary.Statements {
+		statements = append(statements, newStatement(statement))
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug     string
+		Name            string
+		Owed            string
+		Overpaid        bool
+		CreditLimit     string
+		AvailableCredit string
+		Unpaid          string
+		HasUnpaid       bool
+		Current         StatementDisplay
+		Statements      []StatementDisplay
+	}{
+		PageData:    newPageData(r, summary.Account.Name, accountsBodyClass),
+		ProjectSlug: project.Slug,
+		Name:        summary.Account.Name,
+		Owed:        format(summary.Owed),
+		Overpaid:    summary.Owed < 0,
+		Unpaid:      format(summary.Unpaid),
+		HasUnpaid:   summary.Unpaid > 0,
+		Current:     newStatement(summary.Current),
+		Statements:  statements,
+	}
+
+	if summary.Account.CreditLimit > 0 {
+		data.CreditLimit = format(summary.Account.CreditLimit)
+		data.AvailableCredit = format(summary.AvailableCredit)
+	}
+
+	if err := c.statementsTemplate.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render statements", err)
+	}
+}
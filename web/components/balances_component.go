@@ -0,0 +1,170 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/shared_balances"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	balancesTemplateFile = "balances.html"
+	balancesBodyClass    = "dashboard-page"
+	balancesTitle        = "Balances"
+)
+
+type MemberBalanceDisplay struct {
+	Name       string
+	Paid       string
+	Owed       string
+	Balance    string
+	IsPositive bool
+}
+
+type TransferDisplay struct {
+	FromID   string
+	FromName string
+	ToID     string
+	ToName   string
+	Amount   string
+	Currency string
+	Accounts []SearchAccountOption
+}
+
+type SettlementDisplay struct {
+	FromName  string
+	ToName    string
+	Amount    string
+	CreatedAt string
+}
+
+type BalancesComponent struct {
+	container *container.Container
+	template  *pageTemplate
+}
+
+func NewBalancesComponent(container *container.Container, assets *web.Assets) (*BalancesComponent, error) {
+	tmpl, err := parsePageTemplate(assets, balancesTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse balances template: %w", err)
+	}
+
+	return &BalancesComponent{
+		container: container,
+		template:  tmpl,
+	}, nil
+}
+
+// RenderBalances shows the member ledger and the suggested transfers. Each transfer
+// offers only the accounts in its currency for recording the settlement.
+func (c *BalancesComponent) RenderBalances(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, balances *shared_balances.SharedBalancesData, successKey, errorMsg string) {
+	accounts, err := c.container.AccountRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project accounts", err)
+		return
+	}
+
+	members, err := c.container.AccessRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project members", err)
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(members))
+	for _, member := range members {
+		names[member.ID] = member.Name
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug string
+		ReadOnly    bool
+		SuccessMsg  string
+		ErrorMsg    string
+		Balances    []MemberBalanceDisplay
+		Transfers   []TransferDisplay
+		Settlements []SettlementDisplay
+	}{
+		PageData:    newPageData(r, balancesTitle, balancesBodyClass),
+		ProjectSlug: project.Slug,
+		ReadOnly:    access.ReadOnly,
+		ErrorMsg:    errorMsg,
+		Balances:    c.formatBalances(balances.Balances),
+		Transfers:   c.formatTransfers(balances.Transfers, models.ActiveAccounts(accounts)),
+		Settlements: c.formatSettlements(balances.Settlements, names),
+	}
+
+	if successKey == web.SuccessKeySettlementRecorded {
+		data.SuccessMsg = web.SuccessSettlementRecorded
+	}
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render balances", err)
+	}
+}
+
+func (c *BalancesComponent) formatBalances(balances []models.MemberBalance) []MemberBalanceDisplay {
+	var displayBalances []MemberBalanceDisplay
+
+	for _, balance := range balances {
+		currency := balance.Currency.String()
+		displayBalances = append(displayBalances, MemberBalanceDisplay{
+			Name:       balance.Name,
+			Paid:       fmt.Sprintf("%.2f %s", balance.Paid, currency),
+			Owed:       fmt.Sprintf("%.2f %s", balance.Owed, currency),
+			Balance:    fmt.Sprintf("%+.2f %s", balance.Balance, currency),
+			IsPositive: balance.Balance >= 0,
+		})
+	}
+
+	return displayBalances
+}
+
+func (c *BalancesComponent) formatTransfers(transfers []models.Transfer, accounts []*models.Account) []TransferDisplay {
+	var displayTransfers []TransferDisplay
+
+	for _, transfer := range transfers {
+		var options []SearchAccountOption
+		for _, account := range accounts {
+			if account.Currency == transfer.Currency {
+				options = append(options, SearchAccountOption{
+					ID:       account.ID.String(),
+					Name:     account.Name,
+					Currency: account.Currency.String(),
+				})
+			}
+		}
+
+		displayTransfers = append(displayTransfers, TransferDisplay{
+			FromID:   transfer.FromID.String(),
+			FromName: transfer.FromName,
+			ToID:     transfer.ToID.String(),
+			ToName:   transfer.ToName,
+			Amount:   fmt.Sprintf("%.2f", transfer.Amount),
+			Currency: transfer.Currency.String(),
+			Accounts: options,
+		})
+	}
+
+	return displayTransfers
+}
+
+func (c *BalancesComponent) formatSettlements(settlements []*models.Settlement, names map[uuid.UUID]string) []SettlementDisplay {
+	var displaySettlements []SettlementDisplay
+
+	for _, settlement := range settlements {
+		displaySettlements = append(displaySettlements, SettlementDisplay{
+			FromName:  names[settlement.FromID],
+			ToName:    names[settlement.ToID],
+			Amount:    fmt.Sprintf("%.2f %s", settlement.Amount, settlement.Currency),
+			CreatedAt: settlement.CreatedAt.Format("2006-01-02"),
+		})
+	}
+
+	return displaySettlements
+}
@@ -0,0 +1,88 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+
+	"gofin/internal/cases/create_category"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	categoriesTemplateFile = "categories.html"
+	categoriesBodyClass    = "dashboard-page"
+	categoriesTitle        = "Categories"
+)
+
+type CategoryOption struct {
+	ID       string
+	Name     string
+	Selected bool
+}
+
+type CategoriesComponent struct {
+	container *container.Container
+	template  *pageTemplate
+}
+
+func NewCategoriesComponent(container *container.Container, assets *web.Assets) (*CategoriesComponent, error) {
+	tmpl, err := parsePageTemplate(assets, categoriesTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse categories template: %w", err)
+	}
+
+	return &CategoriesComponent{
+		container: container,
+		template:  tmpl,
+	}, nil
+}
+
+func (c *CategoriesComponent) RenderCategories(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, successKey, errorMsg string) {
+	categories, err := c.container.CategoryRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project categories", err)
+		return
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug   string
+		ReadOnly      bool
+		SuccessMsg    string
+		ErrorMsg      string
+		Categories    []CategoryOption
+		MaxNameLength int
+	}{
+		PageData:      newPageData(r, categoriesTitle, categoriesBodyClass),
+		ProjectSlug:   project.Slug,
+		ReadOnly:      access.ReadOnly,
+		ErrorMsg:      errorMsg,
+		Categories:    newCategoryOptions(categories, nil),
+		MaxNameLength: create_category.MaxNameLength,
+	}
+
+	if successKey == web.SuccessKeyCategoryCreated {
+		data.SuccessMsg = web.SuccessCategoryCreated
+	}
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render categories", err)
+	}
+}
+
+// newCategoryOptions lists categories for a select, marking selected as chosen.
+func newCategoryOptions(categories []*models.Category, selected *models.Category) []CategoryOption {
+	options := make([]CategoryOption, 0, len(categories))
+	for _, category := range categories {
+		options = append(options, CategoryOption{
+			ID:       category.ID.String(),
+			Name:     category.Name,
+			Selected: selected != nil && selected.ID == category.ID,
+		})
+	}
+
+	return options
+}
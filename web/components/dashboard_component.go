@@ -1,14 +1,17 @@
 package components
 
 import (
+	"context"
 	"fmt"
-	"html/template"
 	"net/http"
+	"net/url"
 	"time"
 
+	"gofin/internal/cases/credit_card_statements"
 	"gofin/internal/cases/get_project_balance"
 	"gofin/internal/container"
 	"gofin/internal/models"
+	"gofin/pkg/config"
 	webhelpers "gofin/pkg/web"
 	"gofin/web"
 )
@@ -31,6 +34,24 @@ type CurrencyTotalDisplay struct {
 	IsPositive bool
 }
 
+type CategoryTotalDisplay struct {
+	Name          string
+	Spent         string
+	Received      string
+	HasSpent      bool
+	HasReceived   bool
+	Uncategorized bool
+}
+
+type PaymentReminderDisplay struct {
+	AccountID   string
+	AccountName string
+	Amount      string
+	DueDate     string
+	DaysLeft    int
+	Overdue     bool
+}
+
 type TransactionDisplay struct {
 	ID              string
 	AccountName     string
@@ -42,18 +63,19 @@ type TransactionDisplay struct {
 	Type            string
 	IsDebit         bool
 	IsTopUp         bool
+	Status          string
+	Cleared         bool
+	Reconciled      bool
+	ClosedPeriod    bool
 }
 
 type DashboardComponent struct {
 	container *container.Container
-	template  *template.Template
+	template  *pageTemplate
 }
 
-func NewDashboardComponent(container *container.Container) (*DashboardComponent, error) {
-	tmpl, err := template.ParseFiles(
-		web.BaseTemplate,
-		webhelpers.GetTemplatePath(dashboardTemplateFile),
-	)
+func NewDashboardComponent(container *container.Container, assets *web.Assets) (*DashboardComponent, error) {
+	tmpl, err := parsePageTemplate(assets, dashboardTemplateFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse dashboard template: %w", err)
 	}
@@ -64,12 +86,11 @@ func NewDashboardComponent(container *container.Container) (*DashboardComponent,
 	}, nil
 }
 
-func (c *DashboardComponent) RenderDashboard(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, projectSlug, successKey string, year, month int, transactions []*models.Transaction, balanceData *get_project_balance.ProjectBalanceData) {
+func (c *DashboardComponent) RenderDashboard(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, projectSlug, successKey string, year, month int, transactions []*models.Transaction, balanceData *get_project_balance.ProjectBalanceData, categoryTotals []models.CategoryTotal, reminders []credit_card_statements.PaymentReminder) {
 	successMessage := c.getSuccessMessage(successKey)
 
 	data := struct {
-		Title                  string
-		BodyClass              string
+		PageData
 		ProjectID              string
 		ProjectSlug            string
 		ProjectName            string
@@ -78,15 +99,18 @@ func (c *DashboardComponent) RenderDashboard(w http.ResponseWriter, r *http.Requ
 		SuccessMsg             string
 		AccountBalances        []AccountBalanceDisplay
 		CurrencyTotals         []CurrencyTotalDisplay
+		CategoryTotals         []CategoryTotalDisplay
+		PaymentReminders       []PaymentReminderDisplay
 		Transactions           []TransactionDisplay
 		SelectedYear           int
 		SelectedMonth          int
 		Years                  []int
 		Months                 []int
 		RouteDeleteTransaction string
+		TransactionExports     []ExportLink
+		BalanceExports         []ExportLink
 	}{
-		Title:                  project.Name,
-		BodyClass:              dashboardBodyClass,
+		PageData:               newPageData(r, project.Name, dashboardBodyClass),
 		ProjectID:              project.ID.String(),
 		ProjectSlug:            projectSlug,
 		ProjectName:            project.Name,
@@ -95,7 +119,9 @@ func (c *DashboardComponent) RenderDashboard(w http.ResponseWriter, r *http.Requ
 		SuccessMsg:             successMessage,
 		AccountBalances:        c.formatAccountBalances(balanceData.AccountBalances),
 		CurrencyTotals:         c.formatCurrencyTotals(balanceData.CurrencyTotals),
-		Transactions:           c.formatTransactions(transactions),
+		CategoryTotals:         c.formatCategoryTotals(categoryTotals),
+		PaymentReminders:       c.formatPaymentReminders(reminders),
+		Transactions:           c.formatTransactions(r.Context(), project, transactions),
 		SelectedYear:           year,
 		SelectedMonth:          month,
 		Years:                  c.getYears(),
@@ -103,8 +129,22 @@ func (c *DashboardComponent) RenderDashboard(w http.ResponseWriter, r *http.Requ
 		RouteDeleteTransaction: web.RouteDeleteTransaction,
 	}
 
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, -1)
+	if month != 0 {
+		from = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(0, 1, -1)
+	}
+	data.TransactionExports = exportLinks(r, projectSlug, web.RouteExportTransactions, url.Values{
+		web.SearchParamFrom: {from.Format(config.DateFormat)},
+		web.SearchParamTo:   {to.Format(config.DateFormat)},
+	})
+	data.BalanceExports = exportLinks(r, projectSlug, web.RouteExportBalances, url.Values{
+		web.ExportAsOfParam: {to.Format(config.DateFormat)},
+	})
+
 	if err := c.template.Execute(w, data); err != nil {
-		http.Error(w, "Failed to render dashboard", http.StatusInternalServerError)
+		webhelpers.ServerError(w, r, "Failed to render dashboard", err)
 	}
 }
 
@@ -113,6 +153,8 @@ func (c *DashboardComponent) getSuccessMessage(successKey string) string {
 		web.SuccessKeyTransactionsCreated: web.SuccessTransactionsCreated,
 		web.SuccessKeyLoginSuccessful:     web.SuccessLoginSuccessful,
 		web.SuccessKeyTransactionDeleted:  web.SuccessTransactionDeleted,
+		web.SuccessKeyTwoFactorDisabled:   web.SuccessTwoFactorDisabled,
+		web.SuccessKeyStatementImported:   web.SuccessStatementImported,
 	}
 
 	if message, exists := successMessages[successKey]; exists {
@@ -157,6 +199,40 @@ func (c *DashboardComponent) formatCurrencyTotals(currencyTotals []models.Curren
 	return displayTotals
 }
 
+func (c *DashboardComponent) formatCategoryTotals(categoryTotals []models.CategoryTotal) []CategoryTotalDisplay {
+	var displayTotals []CategoryTotalDisplay
+
+	for _, total := range categoryTotals {
+		displayTotals = append(displayTotals, CategoryTotalDisplay{
+			Name:          total.Name,
+			Spent:         c.formatBalance(total.Spent, total.Currency),
+			Received:      c.formatBalance(total.Received, total.Currency),
+			HasSpent:      total.Spent != 0,
+			HasReceived:   total.Received != 0,
+			Uncategorized: total.CategoryID == nil,
+		})
+	}
+
+	return displayTotals
+}
+
+func (c *DashboardComponent) formatPaymentReminders(reminders []credit_card_statements.PaymentReminder) []PaymentReminderDisplay {
+	var displayReminders []PaymentReminderDisplay
+
+	for _, reminder := range reminders {
+		displayReminders = append(displayReminders, PaymentReminderDisplay{
+			AccountID:   reminder.AccountID.String(),
+			AccountName: reminder.AccountName,
+			Amount:      c.formatBalance(reminder.Amount, reminder.Currency),
+			DueDate:     reminder.DueDate.Format("2006-01-02"),
+			DaysLeft:    reminder.DaysLeft,
+			Overdue:     reminder.DaysLeft < 0,
+		})
+	}
+
+	return displayReminders
+}
+
 func (c *DashboardComponent) getYears() []int {
 	currentYear := time.Now().Year()
 	years := make([]int, 0, 11)
@@ -170,36 +246,42 @@ func (c *DashboardComponent) getMonths() []int {
 	return []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
 }
 
-func (c *DashboardComponent) formatTransactions(transactions []*models.Transaction) []TransactionDisplay {
+func (c *DashboardComponent) formatTransactions(ctx context.Context, project *models.Project, transactions []*models.Transaction) []TransactionDisplay {
 	var displayTransactions []TransactionDisplay
 
 	for _, transaction := range transactions {
-		account, err := c.container.AccountRepository.GetByID(transaction.AccountID)
+		account, err := c.container.AccountRepository.GetByID(ctx, transaction.AccountID)
 		if err != nil {
 			continue
 		}
 
-		formattedValue := c.formatTransactionValue(transaction.Value, account.Currency.String(), transaction.Type)
-		formattedDate := transaction.TransactionDate.Format("2006-01-02")
-
-		displayTransactions = append(displayTransactions, TransactionDisplay{
-			ID:              transaction.ID.String(),
-			AccountName:     account.Name,
-			AccountCurrency: account.Currency.String(),
-			Value:           fmt.Sprintf("%.2f", transaction.Value),
-			FormattedValue:  formattedValue,
-			Name:            transaction.Name,
-			TransactionDate: formattedDate,
-			Type:            transaction.Type.String(),
-			IsDebit:         transaction.Type == models.Debit,
-			IsTopUp:         transaction.Type == models.TopUp,
-		})
+		display := newTransactionDisplay(transaction, account)
+		display.ClosedPeriod = project.IsPeriodLocked(transaction.TransactionDate)
+		displayTransactions = append(displayTransactions, display)
 	}
 
 	return displayTransactions
 }
 
-func (c *DashboardComponent) formatTransactionValue(value float64, currency string, transactionType models.TransactionType) string {
+func newTransactionDisplay(transaction *models.Transaction, account *models.Account) TransactionDisplay {
+	return TransactionDisplay{
+		ID:              transaction.ID.String(),
+		AccountName:     account.Name,
+		AccountCurrency: account.Currency.String(),
+		Value:           fmt.Sprintf("%.2f", transaction.Value),
+		FormattedValue:  formatTransactionValue(transaction.Value, account.Currency.String(), transaction.Type),
+		Name:            transaction.Name,
+		TransactionDate: transaction.TransactionDate.Format("2006-01-02"),
+		Type:            transaction.Type.String(),
+		IsDebit:         transaction.Type == models.Debit,
+		IsTopUp:         transaction.Type == models.TopUp,
+		Status:          transaction.Status.String(),
+		Cleared:         transaction.Status == models.StatusCleared,
+		Reconciled:      transaction.IsReconciled(),
+	}
+}
+
+func formatTransactionValue(value float64, currency string, transactionType models.TransactionType) string {
 	if transactionType == models.Debit {
 		return fmt.Sprintf("-%.2f %s", value, currency)
 	}
@@ -0,0 +1,36 @@
+package components
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gofin/pkg/export"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+// ExportLink downloads an export in one format.
+type ExportLink struct {
+	Label string
+	URL   string
+}
+
+// exportLinks points at route once per export format, passing params on so the
+// download covers the same filters as the page it is offered on.
+func exportLinks(r *http.Request, projectSlug, route string, params url.Values) []ExportLink {
+	links := make([]ExportLink, 0, len(export.AllFormats))
+	for _, format := range export.AllFormats {
+		query := url.Values{}
+		for key, values := range params {
+			query[key] = values
+		}
+		query.Set(web.ExportFormatParam, format.String())
+
+		links = append(links, ExportLink{
+			Label: strings.ToUpper(format.String()),
+			URL:   webhelpers.ProjectURL(r, projectSlug, route) + "?" + query.Encode(),
+		})
+	}
+	return links
+}
@@ -0,0 +1,151 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+
+	"gofin/internal/cases/import_statement"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	importStatementTemplateFile = "import_statement.html"
+	importStatementBodyClass    = "dashboard-page"
+	importStatementTitle        = "Import Statement"
+)
+
+// ImportStatementDisplay is one statement of the previewed file and the
+// account it goes to.
+type ImportStatementDisplay struct {
+	AccountName   string
+	AccountNumber string
+	Currency      string
+	Rows          []ImportRowDisplay
+}
+
+// ImportRowDisplay is a previewed entry. Rows without a SkipReason are posted
+// back as the groups[Index].* fields of the create transaction form.
+type ImportRowDisplay struct {
+	Index      int
+	Date       string
+	Day        string
+	Name       string
+	Notes      string
+	Value      string
+	Type       string
+	Amount     string
+	Negative   bool
+	AccountID  string
+	ExternalID string
+	PayeeName  string
+	Categories []CategoryOption
+	SkipReason string
+}
+
+type ImportStatementComponent struct {
+	container *container.Container
+	template  *pageTemplate
+}
+
+func NewImportStatementComponent(container *container.Container, assets *web.Assets) (*ImportStatementComponent, error) {
+	tmpl, err := parsePageTemplate(assets, importStatementTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse import statement template: %w", err)
+	}
+
+	return &ImportStatementComponent{
+		container: container,
+		template:  tmpl,
+	}, nil
+}
+
+// RenderImportStatement shows the upload form, and below it the preview of
+// the uploaded file when there is one.
+func (c *ImportStatementComponent) RenderImportStatement(w http.ResponseWriter, r *http.Request, project *models.Project, preview *import_statement.ImportPreview, errorMsg string) {
+	accounts, err := c.container.AccountRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project accounts", err)
+		return
+	}
+
+	categories, err := c.container.CategoryRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project categories", err)
+		return
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug    string
+		ErrorMsg       string
+		Accounts       []*models.Account
+		Statements     []ImportStatementDisplay
+		NewRows        int
+		SkippedRows    int
+		MaxNotesLength int
+	}{
+		PageData:       newPageData(r, importStatementTitle, importStatementBodyClass),
+		ProjectSlug:    project.Slug,
+		ErrorMsg:       errorMsg,
+		Accounts:       models.ActiveAccounts(accounts),
+		MaxNotesLength: models.MaxNotesLength,
+	}
+
+	if preview != nil {
+		index := 0
+		for _, statement := range preview.Statements {
+			display := ImportStatementDisplay{
+				AccountName:   statement.Account.Name,
+				AccountNumber: statement.AccountNumber,
+				Currency:      statement.Account.Currency.String(),
+			}
+
+			for _, row := range statement.Rows {
+				display.Rows = append(display.Rows, newImportRowDisplay(index, row, display.Currency, categories))
+				index++
+
+				if row.SkipReason == "" {
+					data.NewRows++
+				} else {
+					data.SkippedRows++
+				}
+			}
+
+			data.Statements = append(data.Statements, display)
+		}
+	}
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render import statement page", err)
+	}
+}
+
+func newImportRowDisplay(index int, row import_statement.PreviewRow, currency string, categories []*models.Category) ImportRowDisplay {
+	var selected *models.Category
+	for _, category := range categories {
+		if row.Data.CategoryID != nil && category.ID == *row.Data.CategoryID {
+			selected = category
+		}
+	}
+
+	return ImportRowDisplay{
+		Index:      index,
+		Date:       row.Data.TransactionDate.Format(config.DateTimeFormat),
+		Day:        row.Data.TransactionDate.Format(config.DateFormat),
+		Name:       row.Data.Name,
+		Notes:      row.Data.Notes,
+		Value:      fmt.Sprintf("%.2f", row.Data.Value),
+		Type:       row.Data.Type.String(),
+		Amount:     fmt.Sprintf("%.2f %s", row.Entry.Amount, currency),
+		Negative:   row.Entry.Amount < 0,
+		AccountID:  row.Data.AccountID.String(),
+		ExternalID: row.Data.ExternalID,
+		PayeeName:  row.PayeeName,
+		Categories: newCategoryOptions(categories, selected),
+		SkipReason: row.SkipReason,
+	}
+}
@@ -0,0 +1,298 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/get_portfolio"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	investmentsTemplateFile = "investments.html"
+	investmentTemplateFile  = "investment.html"
+	investmentsBodyClass    = "dashboard-page"
+	investmentsTitle        = "Investments"
+)
+
+type PortfolioDisplay struct {
+	ID             string
+	Name           string
+	Currency       string
+	Cash           string
+	MarketValue    string
+	CostBasis      string
+	UnrealisedGain string
+	RealisedGain   string
+	Dividends      string
+	TotalValue     string
+	Gaining        bool
+}
+
+type PositionDisplay struct {
+	Ticker         string
+	Name           string
+	Quantity       string
+	CostBasis      string
+	Price          string
+	PriceDate      string
+	MarketValue    string
+	UnrealisedGain string
+	Gaining        bool
+	Lots           []LotDisplay
+}
+
+type LotDisplay struct {
+	Date     string
+	Quantity string
+	UnitCost string
+}
+
+type RealisationDisplay struct {
+	Date     string
+	Ticker   string
+	Quantity string
+	Proceeds string
+	Cost     string
+	Gain     string
+	Gaining  bool
+}
+
+type OperationDisplay struct {
+	Date     string
+	Type     string
+	Ticker   string
+	Quantity string
+	Price    string
+	Fees     string
+	Amount   string
+}
+
+type InvestmentsComponent struct {
+	container          *container.Container
+	listTemplate       *pageTemplate
+	investmentTemplate *pageTemplate
+}
+
+func NewInvestmentsComponent(container *container.Container, assets *web.Assets) (*InvestmentsComponent, error) {
+	listTmpl, err := parsePageTemplate(assets, investmentsTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse investments template: %w", err)
+	}
+
+	investmentTmpl, err := parsePageTemplate(assets, investmentTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse investment template: %w", err)
+	}
+
+	return &InvestmentsComponent{
+		container:          container,
+		listTemplate:       listTmpl,
+		investmentTemplate: investmentTmpl,
+	}, nil
+}
+
+// RenderInvestments lists the investment accounts of the project valued at the
+// latest known prices, with gains worked out using the requested cost method.
+func (c *InvestmentsComponent) RenderInvestments(w http.ResponseWriter, r *http.Request, project *models.Project) {
+	method := costMethod(r)
+
+	portfolios, err := c.container.GetPortfolioService.GetPortfolios(r.Context(), project.ID, method, time.Now())
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project portfolios", err)
+		return
+	}
+
+	var displays []PortfolioDisplay
+	for _, portfolio := range portfolios {
+		displays = append(displays, newPortfolioDisplay(portfolio))
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug string
+		Method      string
+		Portfolios  []PortfolioDisplay
+	}{
+		PageData:    newPageData(r, investmentsTitle, investmentsBodyClass),
+		ProjectSlug: project.Slug,
+		Method:      method.String(),
+		Portfolios:  displays,
+	}
+
+	if err := c.listTemplate.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render investments", err)
+	}
+}
+
+// RenderInvestment shows the holdings, realised gains and operations of an
+// investment account with the forms that trade and price its securities,
+// answering 404 for accounts outside the current project.
+func (c *InvestmentsComponent) RenderInvestment(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, accountID uuid.UUID, successKey, errorMsg string) {
+	method := costMethod(r)
+	now := time.Now()
+
+	portfolio, err := c.container.GetPortfolioService.GetPortfolio(r.Context(), project.ID, accountID, method, now)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	securities, err := c.container.SecurityRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project securities", err)
+		return
+	}
+
+	tickers := make(map[uuid.UUID]string, len(securities))
+	for _, security := range securities {
+		tickers[security.ID] = security.Ticker
+	}
+
+	currency := portfolio.Account.Currency.String()
+
+	var positions []PositionDisplay
+	var realisations []RealisationDisplay
+	for _, position := range portfolio.Positions {
+		for _, realisation := range position.Realisations {
+			realisations = append(realisations, RealisationDisplay{
+				Date:     realisation.Date.Format(config.DateFormat),
+				Ticker:   position.Security.Ticker,
+				Quantity: formatQuantity(realisation.Quantity),
+				Proceeds: formatAmount(realisation.Proceeds, currency),
+				Cost:     formatAmount(realisation.Cost, currency),
+				Gain:     formatAmount(realisation.Gain, currency),
+				Gaining:  realisation.Gain >= 0,
+			})
+		}
+
+		if position.Quantity == 0 {
+			continue
+		}
+
+		display := PositionDisplay{
+			Ticker:         position.Security.Ticker,
+			Name:           position.Security.Name,
+			Quantity:       formatQuantity(position.Quantity),
+			CostBasis:      formatAmount(position.CostBasis, currency),
+			MarketValue:    formatAmount(position.MarketValue, currency),
+			UnrealisedGain: formatAmount(position.UnrealisedGain, currency),
+			Gaining:        position.UnrealisedGain >= 0,
+		}
+		if position.Priced {
+			display.Price = formatAmount(position.Price, currency)
+			display.PriceDate = position.PriceDate.Format(config.DateFormat)
+		}
+		for _, lot := range position.Lots {
+			display.Lots = append(display.Lots, LotDisplay{
+				Date:     lot.Date.Format(config.DateFormat),
+				Quantity: formatQuantity(lot.Quantity),
+				UnitCost: formatAmount(lot.UnitCost, currency),
+			})
+		}
+		positions = append(positions, display)
+	}
+
+	operations := make([]OperationDisplay, 0, len(portfolio.Operations))
+	for i := len(portfolio.Operations) - 1; i >= 0; i-- {
+		operation := portfolio.Operations[i]
+		display := OperationDisplay{
+			Date:   operation.Date.Format(config.DateFormat),
+			Type:   operation.Type.String(),
+			Ticker: tickers[operation.SecurityID],
+			Amount: formatAmount(operation.Amount, currency),
+		}
+		if operation.Type != models.OperationDividend {
+			display.Quantity = formatQuantity(operation.Quantity)
+			display.Price = formatAmount(operation.Price, currency)
+			display.Fees = formatAmount(operation.Fees, currency)
+		}
+		operations = append(operations, display)
+	}
+
+	var priced []string
+	for _, security := range securities {
+		if security.Currency == portfolio.Account.Currency {
+			priced = append(priced, security.Ticker)
+		}
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug     string
+		ReadOnly        bool
+		SuccessMsg      string
+		ErrorMsg        string
+		Method          string
+		Portfolio       PortfolioDisplay
+		Positions       []PositionDisplay
+		Realisations    []RealisationDisplay
+		Operations      []OperationDisplay
+		Tickers         []string
+		MaxTickerLength int
+		Today           string
+	}{
+		PageData:        newPageData(r, portfolio.Account.Name, investmentsBodyClass),
+		ProjectSlug:     project.Slug,
+		ReadOnly:        access.ReadOnly || portfolio.Account.IsArchived(),
+		ErrorMsg:        errorMsg,
+		Method:          method.String(),
+		Portfolio:       newPortfolioDisplay(portfolio),
+		Positions:       positions,
+		Realisations:    realisations,
+		Operations:      operations,
+		Tickers:         priced,
+		MaxTickerLength: models.MaxTickerLength,
+		Today:           now.Format(config.DateFormat),
+	}
+
+	switch successKey {
+	case web.SuccessKeyOperationRecorded:
+		data.SuccessMsg = web.SuccessOperationRecorded
+	case web.SuccessKeyPriceSaved:
+		data.SuccessMsg = web.SuccessPriceSaved
+	case web.SuccessKeyPricesImported:
+		data.SuccessMsg = web.SuccessPricesImported
+	}
+
+	if err := c.investmentTemplate.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render investment", err)
+	}
+}
+
+func newPortfolioDisplay(portfolio *get_portfolio.Portfolio) PortfolioDisplay {
+	currency := portfolio.Account.Currency.String()
+	return PortfolioDisplay{
+		ID:             portfolio.Account.ID.String(),
+		Name:           portfolio.Account.Name,
+		Currency:       currency,
+		Cash:           formatAmount(portfolio.Cash, currency),
+		MarketValue:    formatAmount(portfolio.MarketValue, currency),
+		CostBasis:      formatAmount(portfolio.CostBasis, currency),
+		UnrealisedGain: formatAmount(portfolio.UnrealisedGain, currency),
+		RealisedGain:   formatAmount(portfolio.RealisedGain, currency),
+		Dividends:      formatAmount(portfolio.Dividends, currency),
+		TotalValue:     formatAmount(portfolio.TotalValue, currency),
+		Gaining:        portfolio.UnrealisedGain >= 0,
+	}
+}
+
+// costMethod reads the cost method from the query, falling back to FIFO.
+func costMethod(r *http.Request) models.CostMethod {
+	method, err := models.ParseCostMethod(r.URL.Query().Get(web.CostMethodParam))
+	if err != nil {
+		return models.CostFIFO
+	}
+	return method
+}
+
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
@@ -0,0 +1,332 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/create_account"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	"gofin/pkg/money"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	loansTemplateFile = "loans.html"
+	loanTemplateFile  = "loan.html"
+	loansBodyClass    = "dashboard-page"
+	loansTitle        = "Loans"
+)
+
+type LoanDisplay struct {
+	ID          string
+	Name        string
+	Currency    string
+	Principal   string
+	Rate        string
+	Term        int
+	PaymentDay  int
+	StartDate   string
+	Outstanding string
+	NextDue     string
+	NextPayment string
+	Archived    bool
+}
+
+type InstallmentDisplay struct {
+	Number     int
+	Date       string
+	Rate       string
+	Payment    string
+	Principal  string
+	Interest   string
+	Prepayment string
+	Balance    string
+	Paid       bool
+}
+
+// AccountOption is an account a loan installment can be paid from.
+type AccountOption struct {
+	ID   string
+	Name string
+}
+
+type RatePeriodDisplay struct {
+	ID       string
+	StartsOn string
+	Rate     string
+}
+
+type SimulationDisplay struct {
+	Amount            string
+	Date              string
+	ReducePayment     bool
+	InterestSaved     string
+	InstallmentsSaved int
+	NewPayment        string
+	LastDate          string
+	Schedule          []InstallmentDisplay
+}
+
+type LoansComponent struct {
+	container    *container.Container
+	listTemplate *pageTemplate
+	loanTemplate *pageTemplate
+}
+
+func NewLoansComponent(container *container.Container, assets *web.Assets) (*LoansComponent, error) {
+	listTmpl, err := parsePageTemplate(assets, loansTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse loans template: %w", err)
+	}
+
+	loanTmpl, err := parsePageTemplate(assets, loanTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse loan template: %w", err)
+	}
+
+	return &LoansComponent{
+		container:    container,
+		listTemplate: listTmpl,
+		loanTemplate: loanTmpl,
+	}, nil
+}
+
+// RenderLoans lists the loans of the project with their outstanding debt and
+// next installment, next to the form that opens a new one.
+func (c *LoansComponent) RenderLoans(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, errorMsg string) {
+	summaries, err := c.container.GetLoanScheduleService.GetLoans(r.Context(), project.ID, time.Now())
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project loans", err)
+		return
+	}
+
+	var loans []LoanDisplay
+	for _, summary := range summaries {
+		currency := summary.Account.Currency.String()
+		display := LoanDisplay{
+			ID:          summary.Account.ID.String(),
+			Name:        summary.Account.Name,
+			Currency:    currency,
+			Principal:   formatAmount(summary.Loan.Principal, currency),
+			Rate:        formatRate(summary.Loan.RateAt(time.Now(), summary.Periods)),
+			Term:        summary.Loan.TermMonths,
+			PaymentDay:  summary.Loan.PaymentDay,
+			StartDate:   summary.Loan.StartDate.Format(config.DateFormat),
+			Outstanding: formatAmount(summary.Outstanding, currency),
+			Archived:    summary.Account.IsArchived(),
+		}
+		if next := summary.NextInstallment; next != nil {
+			display.NextDue = next.Date.Format(config.DateFormat)
+			display.NextPayment = formatAmount(next.Payment, currency)
+		}
+		loans = append(loans, display)
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug   string
+		ReadOnly      bool
+		ErrorMsg      string
+		Loans         []LoanDisplay
+		Currencies    []money.Currency
+		MaxNameLength int
+		MaxTermMonths int
+		Today         string
+	}{
+		PageData:      newPageData(r, loansTitle, loansBodyClass),
+		ProjectSlug:   project.Slug,
+		ReadOnly:      access.ReadOnly,
+		ErrorMsg:      errorMsg,
+		Loans:         loans,
+		Currencies:    money.AllCurrencies,
+		MaxNameLength: create_account.MaxNameLength,
+		MaxTermMonths: models.MaxLoanTermMonths,
+		Today:         time.Now().Format(config.DateFormat),
+	}
+
+	if err := c.listTemplate.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render loans", err)
+	}
+}
+
+// RenderLoan shows the amortization schedule of a loan with its rate periods and
+// payment form, answering 404 for loans outside the current project. When the
+// query asks for an early repayment, the simulated schedule is shown as well.
+func (c *LoansComponent) RenderLoan(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, accountID uuid.UUID, successKey, errorMsg string) {
+	now := time.Now()
+	summary, err := c.container.GetLoanScheduleService.GetSchedule(r.Context(), project.ID, accountID, now)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	accounts, err := c.container.AccountRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project accounts", err)
+		return
+	}
+
+	currency := summary.Account.Currency.String()
+
+	var payFrom []AccountOption
+	for _, account := range models.ActiveAccounts(accounts) {
+		if account.ID != accountID && account.Currency == summary.Account.Currency && account.Type != models.AccountLoan {
+			payFrom = append(payFrom, AccountOption{ID: account.ID.String(), Name: account.Name})
+		}
+	}
+
+	var periods []RatePeriodDisplay
+	for _, period := range summary.Periods {
+		periods = append(periods, RatePeriodDisplay{
+			ID:       period.ID.String(),
+			StartsOn: period.StartsOn.Format(config.DateFormat),
+			Rate:     formatRate(period.AnnualRate),
+		})
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug     string
+		ReadOnly        bool
+		SuccessMsg      string
+		ErrorMsg        string
+		Loan            LoanDisplay
+		TotalInterest   string
+		Schedule        []InstallmentDisplay
+		Periods         []RatePeriodDisplay
+		PayFrom         []AccountOption
+		SuggestedAmount string
+		Today           string
+		Simulation      *SimulationDisplay
+		SimulationError string
+		SimulateAmount  string
+		SimulateDate    string
+		SimulateReduce  bool
+	}{
+		PageData:      newPageData(r, summary.Account.Name, loansBodyClass),
+		ProjectSlug:   project.Slug,
+		ReadOnly:      access.ReadOnly,
+		ErrorMsg:      errorMsg,
+		TotalInterest: formatAmount(summary.TotalInterest, currency),
+		Schedule:      newInstallmentDisplays(summary.Schedule, currency, now),
+		Periods:       periods,
+		PayFrom:       payFrom,
+		Today:         now.Format(config.DateFormat),
+		SimulateDate:  now.Format(config.DateFormat),
+		Loan: LoanDisplay{
+			ID:          summary.Account.ID.String(),
+			Name:        summary.Account.Name,
+			Currency:    currency,
+			Principal:   formatAmount(summary.Loan.Principal, currency),
+			Rate:        formatRate(summary.Loan.AnnualRate),
+			Term:        summary.Loan.TermMonths,
+			PaymentDay:  summary.Loan.PaymentDay,
+			StartDate:   summary.Loan.StartDate.Format(config.DateFormat),
+			Outstanding: formatAmount(summary.Outstanding, currency),
+			Archived:    summary.Account.IsArchived(),
+		},
+	}
+
+	if next := summary.NextInstallment; next != nil {
+		data.Loan.NextDue = next.Date.Format(config.DateFormat)
+		data.Loan.NextPayment = formatAmount(next.Payment, currency)
+		data.SuggestedAmount = strconv.FormatFloat(next.Payment, 'f', 2, 64)
+	}
+
+	query := r.URL.Query()
+	if amount := strings.TrimSpace(query.Get(web.SimulateParamAmount)); amount != "" {
+		data.SimulateAmount = amount
+		data.SimulateReduce = query.Get(web.SimulateParamMode) == web.SimulateModePayment
+		if date := query.Get(web.SimulateParamDate); date != "" {
+			data.SimulateDate = date
+		}
+		data.Simulation, data.SimulationError = c.simulate(r, project, accountID, data.SimulateAmount, data.SimulateDate, data.SimulateReduce, currency, now)
+	}
+
+	switch successKey {
+	case web.SuccessKeyLoanCreated:
+		data.SuccessMsg = web.SuccessLoanCreated
+	case web.SuccessKeyLoanUpdated:
+		data.SuccessMsg = web.SuccessLoanUpdated
+	case web.SuccessKeyLoanPaymentRecorded:
+		data.SuccessMsg = web.SuccessLoanPaymentRecorded
+	}
+
+	if err := c.loanTemplate.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render loan", err)
+	}
+}
+
+func (c *LoansComponent) simulate(r *http.Request, project *models.Project, accountID uuid.UUID, amountValue, dateValue string, reducePayment bool, currency string, now time.Time) (*SimulationDisplay, string) {
+	amount, err := strconv.ParseFloat(amountValue, 64)
+	if err != nil {
+		return nil, "Invalid repayment amount"
+	}
+
+	date, err := time.Parse(config.DateFormat, dateValue)
+	if err != nil {
+		return nil, "Invalid repayment date"
+	}
+
+	simulation, err := c.container.GetLoanScheduleService.SimulateEarlyRepayment(r.Context(), project.ID, accountID, models.LoanPrepayment{
+		Date:          date,
+		Amount:        amount,
+		ReducePayment: reducePayment,
+	})
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	display := &SimulationDisplay{
+		Amount:            formatAmount(amount, currency),
+		Date:              date.Format(config.DateFormat),
+		ReducePayment:     reducePayment,
+		InterestSaved:     formatAmount(simulation.InterestSaved, currency),
+		InstallmentsSaved: simulation.InstallmentsSaved,
+		Schedule:          newInstallmentDisplays(simulation.Schedule, currency, now),
+	}
+	if simulation.NewPayment > 0 {
+		display.NewPayment = formatAmount(simulation.NewPayment, currency)
+	}
+	if len(simulation.Schedule) > 0 {
+		display.LastDate = simulation.Schedule[len(simulation.Schedule)-1].Date.Format(config.DateFormat)
+	}
+
+	return display, ""
+}
+
+func newInstallmentDisplays(schedule []models.Installment, currency string, now time.Time) []InstallmentDisplay {
+	displays := make([]InstallmentDisplay, 0, len(schedule))
+	for _, installment := range schedule {
+		display := InstallmentDisplay{
+			Number:    installment.Number,
+			Date:      installment.Date.Format(config.DateFormat),
+			Rate:      formatRate(installment.Rate),
+			Payment:   formatAmount(installment.Payment, currency),
+			Principal: formatAmount(installment.Principal, currency),
+			Interest:  formatAmount(installment.Interest, currency),
+			Balance:   formatAmount(installment.Balance, currency),
+			Paid:      !installment.Date.After(now),
+		}
+		if installment.Prepayment > 0 {
+			display.Prepayment = formatAmount(installment.Prepayment, currency)
+		}
+		displays = append(displays, display)
+	}
+	return displays
+}
+
+func formatAmount(amount float64, currency string) string {
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
+
+func formatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64) + "%"
+}
@@ -2,7 +2,6 @@ package components
 
 import (
 	"fmt"
-	"html/template"
 	"net/http"
 
 	"gofin/internal/container"
@@ -11,45 +10,65 @@ import (
 )
 
 const (
-	loginTemplateFile = "login.html"
-	loginBodyClass    = "login-page"
-	loginTitle        = "Login"
+	loginTemplateFile          = "login.html"
+	loginTwoFactorTemplateFile = "login_two_factor.html"
+	loginBodyClass             = "login-page"
+	loginTitle                 = "Login"
+	loginTwoFactorTitle        = "Two-Factor Authentication"
 )
 
 type LoginComponent struct {
-	container *container.Container
-	template  *template.Template
+	container         *container.Container
+	template          *pageTemplate
+	twoFactorTemplate *pageTemplate
 }
 
-func NewLoginComponent(container *container.Container) (*LoginComponent, error) {
-	tmpl, err := template.ParseFiles(
-		web.BaseTemplate,
-		webhelpers.GetTemplatePath(loginTemplateFile),
-	)
+func NewLoginComponent(container *container.Container, assets *web.Assets) (*LoginComponent, error) {
+	tmpl, err := parsePageTemplate(assets, loginTemplateFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse login template: %w", err)
 	}
 
+	twoFactorTmpl, err := parsePageTemplate(assets, loginTwoFactorTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse login two-factor template: %w", err)
+	}
+
 	return &LoginComponent{
-		container: container,
-		template:  tmpl,
+		container:         container,
+		template:          tmpl,
+		twoFactorTemplate: twoFactorTmpl,
 	}, nil
 }
 
 func (c *LoginComponent) RenderLoginPage(w http.ResponseWriter, r *http.Request, projectSlug string, errorMsg string) {
 	data := struct {
-		Title       string
-		BodyClass   string
+		PageData
 		ProjectSlug string
 		ErrorMsg    string
 	}{
-		Title:       loginTitle,
-		BodyClass:   loginBodyClass,
+		PageData:    newPageData(r, loginTitle, loginBodyClass),
 		ProjectSlug: projectSlug,
 		ErrorMsg:    errorMsg,
 	}
 
 	if err := c.template.Execute(w, data); err != nil {
-		http.Error(w, "Failed to render login page", http.StatusInternalServerError)
+		webhelpers.ServerError(w, r, "Failed to render login page", err)
+	}
+}
+
+func (c *LoginComponent) RenderTwoFactorPage(w http.ResponseWriter, r *http.Request, projectSlug string, errorMsg string) {
+	data := struct {
+		PageData
+		ProjectSlug string
+		ErrorMsg    string
+	}{
+		PageData:    newPageData(r, loginTwoFactorTitle, loginBodyClass),
+		ProjectSlug: projectSlug,
+		ErrorMsg:    errorMsg,
+	}
+
+	if err := c.twoFactorTemplate.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render two-factor page", err)
 	}
 }
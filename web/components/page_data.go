@@ -0,0 +1,26 @@
+package components
+
+import (
+	"net/http"
+
+	webhelpers "gofin/pkg/web"
+)
+
+// PageData holds the fields shared by every page rendered with the base template.
+// Components embed it in their view data so templates can reach {{.CSRFToken}}
+// and prefix links with {{.BasePath}}.
+type PageData struct {
+	Title     string
+	BodyClass string
+	CSRFToken string
+	BasePath  string
+}
+
+func newPageData(r *http.Request, title, bodyClass string) PageData {
+	return PageData{
+		Title:     title,
+		BodyClass: bodyClass,
+		CSRFToken: webhelpers.GetCSRFToken(r.Context()),
+		BasePath:  webhelpers.GetBasePath(r.Context()),
+	}
+}
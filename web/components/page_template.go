@@ -0,0 +1,53 @@
+package components
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+// pageTemplate is a page template combined with the base layout. In dev mode it is
+// parsed again on every Execute so template edits are picked up without a restart.
+type pageTemplate struct {
+	assets   *web.Assets
+	pageFile string
+	template *template.Template
+}
+
+func parsePageTemplate(assets *web.Assets, pageFile string) (*pageTemplate, error) {
+	tmpl, err := parseTemplateFiles(assets, pageFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pageTemplate{
+		assets:   assets,
+		pageFile: pageFile,
+		template: tmpl,
+	}, nil
+}
+
+func (t *pageTemplate) Execute(w io.Writer, data any) error {
+	tmpl := t.template
+	if t.assets.DevMode() {
+		reloaded, err := parseTemplateFiles(t.assets, t.pageFile)
+		if err != nil {
+			return err
+		}
+		tmpl = reloaded
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+func parseTemplateFiles(assets *web.Assets, pageFile string) (*template.Template, error) {
+	tmpl, err := template.ParseFS(assets.Templates(), web.BaseTemplate, webhelpers.GetTemplatePath(pageFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", pageFile, err)
+	}
+
+	return tmpl, nil
+}
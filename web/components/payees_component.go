@@ -0,0 +1,275 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"gofin/internal/cases/create_payee"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	payeesTemplateFile = "payees.html"
+	payeeTemplateFile  = "payee.html"
+	payeesBodyClass    = "dashboard-page"
+	payeesTitle        = "Payees"
+)
+
+type PayeeOption struct {
+	ID       string
+	Name     string
+	Selected bool
+}
+
+type PayeeDisplay struct {
+	ID       string
+	Name     string
+	Category string
+	Aliases  string
+}
+
+type AliasDisplay struct {
+	ID    string
+	Alias string
+}
+
+type PayeeTotalDisplay struct {
+	Currency    string
+	Spent       string
+	Received    string
+	Count       int
+	HasSpent    bool
+	HasReceived bool
+}
+
+type PayeesComponent struct {
+	container     *container.Container
+	listTemplate  *pageTemplate
+	payeeTemplate *pageTemplate
+}
+
+func NewPayeesComponent(container *container.Container, assets *web.Assets) (*PayeesComponent, error) {
+	listTmpl, err := parsePageTemplate(assets, payeesTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse payees template: %w", err)
+	}
+
+	payeeTmpl, err := parsePageTemplate(assets, payeeTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse payee template: %w", err)
+	}
+
+	return &PayeesComponent{
+		container:     container,
+		listTemplate:  listTmpl,
+		payeeTemplate: payeeTmpl,
+	}, nil
+}
+
+func (c *PayeesComponent) RenderPayees(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, successKey, errorMsg string) {
+	payees, err := c.container.PayeeRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project payees", err)
+		return
+	}
+
+	aliases, err := c.container.PayeeRepository.GetAliasesByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get payee aliases", err)
+		return
+	}
+
+	categories, err := c.container.CategoryRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project categories", err)
+		return
+	}
+
+	aliasesByPayee := make(map[uuid.UUID][]string)
+	for _, alias := range aliases {
+		aliasesByPayee[alias.PayeeID] = append(aliasesByPayee[alias.PayeeID], alias.Alias)
+	}
+
+	var displayPayees []PayeeDisplay
+	for _, payee := range payees {
+		displayPayees = append(displayPayees, PayeeDisplay{
+			ID:       payee.ID.String(),
+			Name:     payee.Name,
+			Category: categoryName(categories, payee.DefaultCategoryID),
+			Aliases:  strings.Join(aliasesByPayee[payee.ID], ", "),
+		})
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug   string
+		ReadOnly      bool
+		SuccessMsg    string
+		ErrorMsg      string
+		Payees        []PayeeDisplay
+		Categories    []CategoryOption
+		MaxNameLength int
+	}{
+		PageData:      newPageData(r, payeesTitle, payeesBodyClass),
+		ProjectSlug:   project.Slug,
+		ReadOnly:      access.ReadOnly,
+		ErrorMsg:      errorMsg,
+		Payees:        displayPayees,
+		Categories:    newCategoryOptions(categories, nil),
+		MaxNameLength: create_payee.MaxNameLength,
+	}
+
+	if successKey == web.SuccessKeyPayeesMerged {
+		data.SuccessMsg = web.SuccessPayeesMerged
+	}
+
+	if err := c.listTemplate.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render payees", err)
+	}
+}
+
+// RenderPayee shows one payee with its aliases and spending history, answering 404
+// for payees outside the current project.
+func (c *PayeesComponent) RenderPayee(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, payeeID uuid.UUID, successKey, errorMsg string) {
+	history, err := c.container.GetPayeeHistoryService.GetHistory(r.Context(), project.ID, payeeID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	payees, err := c.container.PayeeRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project payees", err)
+		return
+	}
+
+	categories, err := c.container.CategoryRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project categories", err)
+		return
+	}
+
+	accounts, err := c.container.AccountRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project accounts", err)
+		return
+	}
+
+	accountsByID := make(map[uuid.UUID]*models.Account, len(accounts))
+	for _, account := range accounts {
+		accountsByID[account.ID] = account
+	}
+
+	var transactions []TransactionDisplay
+	for _, transaction := range history.Transactions {
+		if account, ok := accountsByID[transaction.AccountID]; ok {
+			transactions = append(transactions, newTransactionDisplay(transaction, account))
+		}
+	}
+
+	var aliases []AliasDisplay
+	for _, alias := range history.Aliases {
+		aliases = append(aliases, AliasDisplay{ID: alias.ID.String(), Alias: alias.Alias})
+	}
+
+	var totals []PayeeTotalDisplay
+	for _, total := range history.Totals {
+		totals = append(totals, PayeeTotalDisplay{
+			Currency:    total.Currency,
+			Spent:       fmt.Sprintf("%.2f %s", total.Spent, total.Currency),
+			Received:    fmt.Sprintf("%.2f %s", total.Received, total.Currency),
+			Count:       total.Count,
+			HasSpent:    total.Spent > 0,
+			HasReceived: total.Received > 0,
+		})
+	}
+
+	var mergeTargets []PayeeOption
+	for _, payee := range payees {
+		if payee.ID != payeeID {
+			mergeTargets = append(mergeTargets, PayeeOption{ID: payee.ID.String(), Name: payee.Name})
+		}
+	}
+
+	var defaultCategory *models.Category
+	for _, category := range categories {
+		if history.Payee.DefaultCategoryID != nil && category.ID == *history.Payee.DefaultCategoryID {
+			defaultCategory = category
+		}
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug  string
+		ReadOnly     bool
+		SuccessMsg   string
+		ErrorMsg     string
+		PayeeID      string
+		Name         string
+		Category     string
+		Categories   []CategoryOption
+		Aliases      []AliasDisplay
+		Totals       []PayeeTotalDisplay
+		Transactions []TransactionDisplay
+		MergeTargets []PayeeOption
+	}{
+		PageData:     newPageData(r, history.Payee.Name, payeesBodyClass),
+		ProjectSlug:  project.Slug,
+		ReadOnly:     access.ReadOnly,
+		ErrorMsg:     errorMsg,
+		PayeeID:      payeeID.String(),
+		Name:         history.Payee.Name,
+		Category:     categoryName(categories, history.Payee.DefaultCategoryID),
+		Categories:   newCategoryOptions(categories, defaultCategory),
+		Aliases:      aliases,
+		Totals:       totals,
+		Transactions: transactions,
+		MergeTargets: mergeTargets,
+	}
+
+	switch successKey {
+	case web.SuccessKeyPayeeCreated:
+		data.SuccessMsg = web.SuccessPayeeCreated
+	case web.SuccessKeyPayeeUpdated:
+		data.SuccessMsg = web.SuccessPayeeUpdated
+	case web.SuccessKeyPayeesMerged:
+		data.SuccessMsg = web.SuccessPayeesMerged
+	}
+
+	if err := c.payeeTemplate.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render payee", err)
+	}
+}
+
+// newPayeeOptions lists payees for a select, marking the one with selectedID.
+func newPayeeOptions(payees []*models.Payee, selectedID *uuid.UUID) []PayeeOption {
+	options := make([]PayeeOption, 0, len(payees))
+	for _, payee := range payees {
+		options = append(options, PayeeOption{
+			ID:       payee.ID.String(),
+			Name:     payee.Name,
+			Selected: selectedID != nil && *selectedID == payee.ID,
+		})
+	}
+
+	return options
+}
+
+func categoryName(categories []*models.Category, categoryID *uuid.UUID) string {
+	if categoryID == nil {
+		return ""
+	}
+
+	for _, category := range categories {
+		if category.ID == *categoryID {
+			return category.Name
+		}
+	}
+
+	return ""
+}
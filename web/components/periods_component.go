@@ -0,0 +1,113 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	periodsTemplateFile = "periods.html"
+	periodsBodyClass    = "dashboard-page"
+	periodsTitle        = "Closed Periods"
+	monthInputFormat    = "2006-01"
+)
+
+type PeriodLockEventDisplay struct {
+	CreatedAt   string
+	Action      string
+	LockedUntil string
+	Previous    string
+	Actor       string
+	Reason      string
+}
+
+type PeriodsComponent struct {
+	container *container.Container
+	template  *pageTemplate
+}
+
+func NewPeriodsComponent(container *container.Container, assets *web.Assets) (*PeriodsComponent, error) {
+	tmpl, err := parsePageTemplate(assets, periodsTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse periods template: %w", err)
+	}
+
+	return &PeriodsComponent{
+		container: container,
+		template:  tmpl,
+	}, nil
+}
+
+// RenderPeriods shows up to when the books of the project are closed, the
+// forms closing a further month or year and the audit trail of every change.
+func (c *PeriodsComponent) RenderPeriods(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, successKey, errorMsg string) {
+	events, err := c.container.ClosePeriodService.GetAuditTrail(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get period lock events", err)
+		return
+	}
+
+	var history []PeriodLockEventDisplay
+	for _, event := range events {
+		display := PeriodLockEventDisplay{
+			CreatedAt:   event.CreatedAt.Format("2006-01-02 15:04"),
+			Action:      "Closed",
+			LockedUntil: "every period open",
+			Previous:    "every period open",
+			Actor:       event.Actor,
+			Reason:      event.Reason,
+		}
+		if event.Action == models.PeriodReopened {
+			display.Action = "Reopened"
+		}
+		if event.LockedUntil != nil {
+			display.LockedUntil = "closed up to " + event.LockedUntil.Format(config.DateFormat)
+		}
+		if event.PreviousLockedUntil != nil {
+			display.Previous = "closed up to " + event.PreviousLockedUntil.Format(config.DateFormat)
+		}
+		history = append(history, display)
+	}
+
+	lastMonth := time.Now().AddDate(0, 0, -time.Now().Day())
+
+	data := struct {
+		PageData
+		ProjectSlug string
+		ReadOnly    bool
+		SuccessMsg  string
+		ErrorMsg    string
+		LockedUntil string
+		LastMonth   string
+		LastYear    string
+		History     []PeriodLockEventDisplay
+	}{
+		PageData:    newPageData(r, periodsTitle, periodsBodyClass),
+		ProjectSlug: project.Slug,
+		ReadOnly:    access.ReadOnly,
+		ErrorMsg:    errorMsg,
+		LastMonth:   lastMonth.Format(monthInputFormat),
+		LastYear:    strconv.Itoa(time.Now().Year() - 1),
+		History:     history,
+	}
+
+	if project.LockedUntil != nil {
+		data.LockedUntil = project.LockedUntil.Format(config.DateFormat)
+	}
+
+	if successKey == web.SuccessKeyPeriodClosed {
+		data.SuccessMsg = web.SuccessPeriodClosed
+	}
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render closed periods", err)
+	}
+}
@@ -0,0 +1,149 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	reconcileTemplateFile = "reconcile.html"
+	reconcileBodyClass    = "dashboard-page"
+)
+
+type ReconcileTransactionDisplay struct {
+	ID             string
+	Date           string
+	Name           string
+	FormattedValue string
+	IsDebit        bool
+	SignedValue    string
+	Cleared        bool
+}
+
+type ReconciliationDisplay struct {
+	ID               string
+	StatementDate    string
+	StatementBalance string
+	FinishedAt       string
+}
+
+type ReconcileComponent struct {
+	container *container.Container
+	template  *pageTemplate
+}
+
+func NewReconcileComponent(container *container.Container, assets *web.Assets) (*ReconcileComponent, error) {
+	tmpl, err := parsePageTemplate(assets, reconcileTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reconcile template: %w", err)
+	}
+
+	return &ReconcileComponent{
+		container: container,
+		template:  tmpl,
+	}, nil
+}
+
+// RenderReconcile shows the open reconciliation of an account with the
+// transactions on its statement, or the form that starts one, answering 404 for
+// accounts outside the current project.
+func (c *ReconcileComponent) RenderReconcile(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, accountID uuid.UUID, successKey, errorMsg string) {
+	session, err := c.container.ReconcileAccountService.GetSession(r.Context(), project.ID, accountID)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	currency := session.Account.Currency.String()
+
+	transactions := make([]ReconcileTransactionDisplay, 0, len(session.Transactions))
+	for _, transaction := range session.Transactions {
+		transactions = append(transactions, ReconcileTransactionDisplay{
+			ID:             transaction.ID.String(),
+			Date:           transaction.TransactionDate.Format(config.DateFormat),
+			Name:           transaction.Name,
+			FormattedValue: formatTransactionValue(transaction.Value, currency, transaction.Type),
+			IsDebit:        transaction.Type == models.Debit,
+			SignedValue:    strconv.FormatFloat(transaction.SignedValue(), 'f', 2, 64),
+			Cleared:        transaction.Status == models.StatusCleared,
+		})
+	}
+
+	var history []ReconciliationDisplay
+	for _, past := range session.History {
+		history = append(history, ReconciliationDisplay{
+			ID:               past.ID.String(),
+			StatementDate:    past.StatementDate.Format(config.DateFormat),
+			StatementBalance: formatAmount(past.StatementBalance, currency),
+			FinishedAt:       past.FinishedAt.Format(config.DateFormat),
+		})
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug       string
+		ReadOnly          bool
+		SuccessMsg        string
+		ErrorMsg          string
+		AccountID         string
+		AccountName       string
+		Currency          string
+		Open              *ReconciliationDisplay
+		StatementValue    string
+		ReconciledValue   string
+		ReconciledBalance string
+		ClearedBalance    string
+		Difference        string
+		Transactions      []ReconcileTransactionDisplay
+		History           []ReconciliationDisplay
+		Today             string
+	}{
+		PageData:          newPageData(r, "Reconcile "+session.Account.Name, reconcileBodyClass),
+		ProjectSlug:       project.Slug,
+		ReadOnly:          access.ReadOnly,
+		ErrorMsg:          errorMsg,
+		AccountID:         session.Account.ID.String(),
+		AccountName:       session.Account.Name,
+		Currency:          currency,
+		ReconciledValue:   strconv.FormatFloat(session.ReconciledBalance, 'f', 2, 64),
+		ReconciledBalance: formatAmount(session.ReconciledBalance, currency),
+		ClearedBalance:    formatAmount(session.ClearedBalance, currency),
+		Difference:        formatAmount(session.Difference, currency),
+		Transactions:      transactions,
+		History:           history,
+		Today:             time.Now().Format(config.DateFormat),
+	}
+
+	if open := session.Reconciliation; open != nil {
+		data.Open = &ReconciliationDisplay{
+			ID:               open.ID.String(),
+			StatementDate:    open.StatementDate.Format(config.DateFormat),
+			StatementBalance: formatAmount(open.StatementBalance, currency),
+		}
+		data.StatementValue = strconv.FormatFloat(open.StatementBalance, 'f', 2, 64)
+	}
+
+	switch successKey {
+	case web.SuccessKeyReconcileStarted:
+		data.SuccessMsg = web.SuccessReconcileStarted
+	case web.SuccessKeyReconcileSaved:
+		data.SuccessMsg = web.SuccessReconcileSaved
+	case web.SuccessKeyReconcileFinished:
+		data.SuccessMsg = web.SuccessReconcileFinished
+	case web.SuccessKeyReconcileCancelled:
+		data.SuccessMsg = web.SuccessReconcileCancelled
+	}
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render reconciliation", err)
+	}
+}
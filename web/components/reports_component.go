@@ -0,0 +1,275 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"gofin/internal/cases/get_reports"
+	"gofin/internal/models"
+	"gofin/pkg/config"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	reportsTemplateFile = "reports.html"
+	reportsBodyClass    = "dashboard-page"
+	reportsTitle        = "Reports"
+	reportMonthLabel    = "Jan 2006"
+)
+
+// MonthChartBar is one month of the income vs expense chart. The heights are
+// percentages of the largest amount in the chart.
+type MonthChartBar struct {
+	Label         string
+	Income        string
+	Expense       string
+	Net           string
+	IncomeHeight  float64
+	ExpenseHeight float64
+	Negative      bool
+}
+
+// ShareRow is a category or payee with a bar as wide as its share of the
+// largest one listed.
+type ShareRow struct {
+	Name     string
+	Spent    string
+	Received string
+	Share    string
+	Count    int
+	Width    float64
+}
+
+type YearOverYearRow struct {
+	Label           string
+	PreviousLabel   string
+	Income          string
+	Expense         string
+	PreviousIncome  string
+	PreviousExpense string
+	Change          string
+	Increased       bool
+}
+
+type CashFlowRow struct {
+	Label    string
+	Opening  string
+	Inflows  string
+	Outflows string
+	Closing  string
+	Negative bool
+}
+
+type ReportsComponent struct {
+	template *pageTemplate
+}
+
+func NewReportsComponent(assets *web.Assets) (*ReportsComponent, error) {
+	tmpl, err := parsePageTemplate(assets, reportsTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse reports template: %w", err)
+	}
+
+	return &ReportsComponent{
+		template: tmpl,
+	}, nil
+}
+
+// RenderReports shows the report for the range picked in the filter form, or
+// only the form and the error when the report could not be built.
+func (c *ReportsComponent) RenderReports(w http.ResponseWriter, r *http.Request, project *models.Project, query get_reports.ReportQuery, report *get_reports.Report, errorMsg string) {
+	data := struct {
+		PageData
+		ProjectSlug     string
+		ErrorMsg        string
+		From            string
+		To              string
+		Currency        string
+		Currencies      []string
+		Report          *get_reports.Report
+		Income          string
+		Expense         string
+		Net             string
+		Months          []MonthChartBar
+		Categories      []ShareRow
+		Payees          []ShareRow
+		YearOverYear    []YearOverYearRow
+		YearOverYearSum YearOverYearRow
+		CashFlow        []CashFlowRow
+		CashFlowSum     CashFlowRow
+		ExportLinks     []ExportLink
+	}{
+		PageData:    newPageData(r, reportsTitle, reportsBodyClass),
+		ProjectSlug: project.Slug,
+		ErrorMsg:    errorMsg,
+		From:        query.From.Format(config.DateFormat),
+		To:          query.To.Format(config.DateFormat),
+		Currency:    query.Currency,
+		Report:      report,
+	}
+
+	if report != nil {
+		currency := report.Currency
+		data.Currency = currency
+		data.Currencies = report.Currencies
+		data.Income = formatReportAmount(report.Income, currency)
+		data.Expense = formatReportAmount(report.Expense, currency)
+		data.Net = formatReportAmount(report.Net(), currency)
+		data.Months = monthChartBars(report.IncomeExpense, currency)
+		data.Categories = categoryRows(report.Categories, currency)
+		data.Payees = payeeRows(report.TopPayees, currency)
+		data.YearOverYear, data.YearOverYearSum = yearOverYearRows(report.YearOverYear, currency)
+		data.CashFlow, data.CashFlowSum = cashFlowRows(report.CashFlow, currency)
+	}
+
+	data.ExportLinks = exportLinks(r, project.Slug, web.RouteExportReport, url.Values{
+		web.ReportFromParam:     {data.From},
+		web.ReportToParam:       {data.To},
+		web.ReportCurrencyParam: {data.Currency},
+	})
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render reports", err)
+	}
+}
+
+func monthChartBars(months []get_reports.MonthlyTotal, currency string) []MonthChartBar {
+	var largest float64
+	for _, month := range months {
+		largest = max(largest, month.Income, month.Expense)
+	}
+
+	bars := make([]MonthChartBar, 0, len(months))
+	for _, month := range months {
+		bars = append(bars, MonthChartBar{
+			Label:         reportMonth(month.Month),
+			Income:        formatReportAmount(month.Income, currency),
+			Expense:       formatReportAmount(month.Expense, currency),
+			Net:           formatReportAmount(month.Net, currency),
+			IncomeHeight:  percentOf(month.Income, largest),
+			ExpenseHeight: percentOf(month.Expense, largest),
+			Negative:      month.Net < 0,
+		})
+	}
+	return bars
+}
+
+func categoryRows(categories []get_reports.CategoryShare, currency string) []ShareRow {
+	var largest float64
+	for _, category := range categories {
+		largest = max(largest, category.Spent)
+	}
+
+	rows := make([]ShareRow, 0, len(categories))
+	for _, category := range categories {
+		rows = append(rows, ShareRow{
+			Name:     category.Name,
+			Spent:    formatReportAmount(category.Spent, currency),
+			Received: formatReportAmount(category.Received, currency),
+			Share:    fmt.Sprintf("%.1f%%", category.Share),
+			Width:    percentOf(category.Spent, largest),
+		})
+	}
+	return rows
+}
+
+func payeeRows(payees []get_reports.PayeeSpending, currency string) []ShareRow {
+	var largest float64
+	for _, payee := range payees {
+		largest = max(largest, payee.Spent)
+	}
+
+	rows := make([]ShareRow, 0, len(payees))
+	for _, payee := range payees {
+		rows = append(rows, ShareRow{
+			Name:     payee.Name,
+			Spent:    formatReportAmount(payee.Spent, currency),
+			Received: formatReportAmount(payee.Received, currency),
+			Count:    payee.Count,
+			Width:    percentOf(payee.Spent, largest),
+		})
+	}
+	return rows
+}
+
+func yearOverYearRows(comparison get_reports.YearOverYear, currency string) ([]YearOverYearRow, YearOverYearRow) {
+	rows := make([]YearOverYearRow, 0, len(comparison.Months))
+	for _, month := range comparison.Months {
+		rows = append(rows, YearOverYearRow{
+			Label:           reportMonth(month.Month),
+			PreviousLabel:   reportMonth(month.PreviousMonth),
+			Income:          formatReportAmount(month.Income, currency),
+			Expense:         formatReportAmount(month.Expense, currency),
+			PreviousIncome:  formatReportAmount(month.PreviousIncome, currency),
+			PreviousExpense: formatReportAmount(month.PreviousExpense, currency),
+			Change:          formatChange(month.Change),
+			Increased:       month.Change != nil && *month.Change > 0,
+		})
+	}
+
+	total := YearOverYearRow{
+		Label:           "Total",
+		Income:          formatReportAmount(comparison.Income, currency),
+		Expense:         formatReportAmount(comparison.Expense, currency),
+		PreviousIncome:  formatReportAmount(comparison.PreviousIncome, currency),
+		PreviousExpense: formatReportAmount(comparison.PreviousExpense, currency),
+		Change:          formatChange(comparison.Change),
+		Increased:       comparison.Change != nil && *comparison.Change > 0,
+	}
+
+	return rows, total
+}
+
+func cashFlowRows(statement get_reports.CashFlowStatement, currency string) ([]CashFlowRow, CashFlowRow) {
+	rows := make([]CashFlowRow, 0, len(statement.Months))
+	for _, month := range statement.Months {
+		rows = append(rows, CashFlowRow{
+			Label:    reportMonth(month.Month),
+			Opening:  formatReportAmount(month.Opening, currency),
+			Inflows:  formatReportAmount(month.Inflows, currency),
+			Outflows: formatReportAmount(month.Outflows, currency),
+			Closing:  formatReportAmount(month.Closing, currency),
+			Negative: month.Closing < 0,
+		})
+	}
+
+	total := CashFlowRow{
+		Label:    "Total",
+		Opening:  formatReportAmount(statement.Opening, currency),
+		Inflows:  formatReportAmount(statement.Inflows, currency),
+		Outflows: formatReportAmount(statement.Outflows, currency),
+		Closing:  formatReportAmount(statement.Closing, currency),
+		Negative: statement.Closing < 0,
+	}
+
+	return rows, total
+}
+
+func reportMonth(month string) string {
+	parsed, err := time.Parse(get_reports.MonthFormat, month)
+	if err != nil {
+		return month
+	}
+	return parsed.Format(reportMonthLabel)
+}
+
+func formatChange(change *float64) string {
+	if change == nil {
+		return "–"
+	}
+	return fmt.Sprintf("%+.1f%%", *change)
+}
+
+func formatReportAmount(amount float64, currency string) string {
+	return fmt.Sprintf("%.2f %s", amount, currency)
+}
+
+func percentOf(amount, largest float64) float64 {
+	if largest <= 0 {
+		return 0
+	}
+	return amount / largest * 100
+}
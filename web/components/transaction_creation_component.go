@@ -2,7 +2,6 @@ package components
 
 import (
 	"fmt"
-	"html/template"
 	"net/http"
 	"time"
 
@@ -34,14 +33,11 @@ type CurrencyOption struct {
 
 type TransactionCreationComponent struct {
 	container *container.Container
-	template  *template.Template
+	template  *pageTemplate
 }
 
-func NewTransactionCreationComponent(container *container.Container) (*TransactionCreationComponent, error) {
-	tmpl, err := template.ParseFiles(
-		web.BaseTemplate,
-		webhelpers.GetTemplatePath(transactionTemplateFile),
-	)
+func NewTransactionCreationComponent(container *container.Container, assets *web.Assets) (*TransactionCreationComponent, error) {
+	tmpl, err := parsePageTemplate(assets, transactionTemplateFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse transaction template: %w", err)
 	}
@@ -53,28 +49,38 @@ func NewTransactionCreationComponent(container *container.Container) (*Transacti
 }
 
 func (c *TransactionCreationComponent) RenderCreateTransactionPage(w http.ResponseWriter, r *http.Request, projectSlug string, accounts []*models.Account, errorMsg string) {
+	project, _ := webhelpers.GetProject(r.Context())
+
+	categories, err := c.container.CategoryRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project categories", err)
+		return
+	}
+
 	data := struct {
-		Title            string
-		BodyClass        string
+		PageData
 		ProjectSlug      string
 		Accounts         []*models.Account
+		Categories       []CategoryOption
 		TransactionTypes []TransactionTypeOption
 		CurrencyOptions  []CurrencyOption
 		DefaultDate      string
+		MaxNotesLength   int
 		ErrorMsg         string
 	}{
-		Title:            pageTitle,
-		BodyClass:        bodyClass,
+		PageData:         newPageData(r, pageTitle, bodyClass),
 		ProjectSlug:      projectSlug,
 		Accounts:         accounts,
+		Categories:       newCategoryOptions(categories, nil),
 		TransactionTypes: c.getTransactionTypeOptions(),
 		CurrencyOptions:  c.getCurrencyOptions(),
 		DefaultDate:      time.Now().Format(config.DateTimeFormat),
+		MaxNotesLength:   models.MaxNotesLength,
 		ErrorMsg:         errorMsg,
 	}
 
 	if err := c.template.Execute(w, data); err != nil {
-		http.Error(w, templateError, http.StatusInternalServerError)
+		webhelpers.ServerError(w, r, templateError, err)
 	}
 }
 
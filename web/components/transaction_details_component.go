@@ -0,0 +1,297 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	transactionDetailsTemplateFile = "transaction_details.html"
+	transactionDetailsBodyClass    = "dashboard-page"
+	transactionDetailsTitle        = "Transaction"
+)
+
+type AttachmentDisplay struct {
+	ID           string
+	FileName     string
+	ContentType  string
+	Size         string
+	HasThumbnail bool
+	CreatedAt    string
+}
+
+type SplitDisplay struct {
+	CategoryID   string
+	CategoryName string
+	Amount       string
+	Memo         string
+}
+
+type ShareMemberDisplay struct {
+	ID       string
+	Name     string
+	Included bool
+	Value    string
+	Amount   string
+}
+
+// SharingDisplay describes who paid for the transaction and how it is shared. Members
+// lists every project member so the form can add or drop participants.
+type SharingDisplay struct {
+	Shared    bool
+	Shareable bool
+	PayerID   string
+	PayerName string
+	Method    string
+	Methods   []SearchOption
+	Members   []ShareMemberDisplay
+}
+
+type TransactionDetailsComponent struct {
+	container *container.Container
+	template  *pageTemplate
+}
+
+func NewTransactionDetailsComponent(container *container.Container, assets *web.Assets) (*TransactionDetailsComponent, error) {
+	tmpl, err := parsePageTemplate(assets, transactionDetailsTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction details template: %w", err)
+	}
+
+	return &TransactionDetailsComponent{
+		container: container,
+		template:  tmpl,
+	}, nil
+}
+
+func (c *TransactionDetailsComponent) RenderDetails(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, transaction *models.Transaction, attachments []*models.Attachment, successKey, errorMsg string) {
+	account, err := c.container.AccountRepository.GetByID(r.Context(), transaction.AccountID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get transaction account", err)
+		return
+	}
+
+	categories, err := c.container.CategoryRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project categories", err)
+		return
+	}
+
+	splits, err := c.container.TransactionSplitRepository.GetByTransactionID(r.Context(), transaction.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get transaction splits", err)
+		return
+	}
+
+	payees, err := c.container.PayeeRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project payees", err)
+		return
+	}
+
+	var category *models.Category
+	for _, candidate := range categories {
+		if transaction.CategoryID != nil && candidate.ID == *transaction.CategoryID {
+			category = candidate
+		}
+	}
+
+	sharing, err := c.formatSharing(r.Context(), project, transaction, account.Currency.String())
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get shared expense", err)
+		return
+	}
+
+	limits := c.container.Config.Attachments
+
+	data := struct {
+		PageData
+		ProjectSlug    string
+		ReadOnly       bool
+		Locked         bool
+		SuccessMsg     string
+		ErrorMsg       string
+		Transaction    TransactionDisplay
+		Notes          string
+		MaxNotesLength int
+		Categories     []CategoryOption
+		Category       string
+		Payees         []PayeeOption
+		Payee          PayeeOption
+		Splits         []SplitDisplay
+		MaxMemoLength  int
+		Sharing        SharingDisplay
+		Attachments    []AttachmentDisplay
+		AcceptTypes    string
+		MaxUploadSize  string
+	}{
+		PageData:       newPageData(r, transactionDetailsTitle, transactionDetailsBodyClass),
+		ProjectSlug:    project.Slug,
+		ReadOnly:       access.ReadOnly,
+		Locked:         transaction.IsReconciled() || project.IsPeriodLocked(transaction.TransactionDate),
+		SuccessMsg:     c.getSuccessMessage(successKey),
+		ErrorMsg:       errorMsg,
+		Transaction:    newTransactionDisplay(transaction, account),
+		Notes:          transaction.Notes,
+		MaxNotesLength: models.MaxNotesLength,
+		Categories:     newCategoryOptions(categories, category),
+		Payees:         newPayeeOptions(payees, transaction.PayeeID),
+		Splits:         c.formatSplits(splits, categories, access.ReadOnly || transaction.IsReconciled() || project.IsPeriodLocked(transaction.TransactionDate)),
+		MaxMemoLength:  models.MaxSplitMemoLength,
+		Sharing:        sharing,
+		Attachments:    c.formatAttachments(attachments),
+		AcceptTypes:    strings.Join(limits.AllowedTypes, ","),
+		MaxUploadSize:  formatFileSize(limits.MaxSize),
+	}
+
+	data.Transaction.ClosedPeriod = project.IsPeriodLocked(transaction.TransactionDate)
+
+	if category != nil {
+		data.Category = category.Name
+	}
+
+	for _, payee := range data.Payees {
+		if payee.Selected {
+			data.Payee = payee
+		}
+	}
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render transaction details", err)
+	}
+}
+
+func (c *TransactionDetailsComponent) getSuccessMessage(successKey string) string {
+	successMessages := map[string]string{
+		web.SuccessKeyNotesUpdated:       web.SuccessNotesUpdated,
+		web.SuccessKeyAttachmentUploaded: web.SuccessAttachmentUploaded,
+		web.SuccessKeyAttachmentDeleted:  web.SuccessAttachmentDeleted,
+		web.SuccessKeyCategoriesUpdated:  web.SuccessCategoriesUpdated,
+		web.SuccessKeyExpenseShared:      web.SuccessExpenseShared,
+		web.SuccessKeyExpenseUnshared:    web.SuccessExpenseUnshared,
+		web.SuccessKeyPayeeUpdated:       web.SuccessPayeeUpdated,
+		web.SuccessKeyStatusUpdated:      web.SuccessStatusUpdated,
+	}
+
+	return successMessages[successKey]
+}
+
+func (c *TransactionDetailsComponent) formatAttachments(attachments []*models.Attachment) []AttachmentDisplay {
+	var displayAttachments []AttachmentDisplay
+
+	for _, attachment := range attachments {
+		displayAttachments = append(displayAttachments, AttachmentDisplay{
+			ID:           attachment.ID.String(),
+			FileName:     attachment.FileName,
+			ContentType:  attachment.ContentType,
+			Size:         formatFileSize(attachment.Size),
+			HasThumbnail: attachment.HasThumbnail,
+			CreatedAt:    attachment.CreatedAt.Format("2006-01-02 15:04"),
+		})
+	}
+
+	return displayAttachments
+}
+
+// formatSplits lists the saved split lines followed, when the page is editable, by a
+// few blank ones so a transaction can be split without any client-side script.
+func (c *TransactionDetailsComponent) formatSplits(splits []*models.TransactionSplit, categories []*models.Category, readOnly bool) []SplitDisplay {
+	names := make(map[string]string, len(categories))
+	for _, category := range categories {
+		names[category.ID.String()] = category.Name
+	}
+
+	var displaySplits []SplitDisplay
+	for _, split := range splits {
+		displaySplits = append(displaySplits, SplitDisplay{
+			CategoryID:   split.CategoryID.String(),
+			CategoryName: names[split.CategoryID.String()],
+			Amount:       fmt.Sprintf("%.2f", split.Amount),
+			Memo:         split.Memo,
+		})
+	}
+
+	if !readOnly {
+		for i := 0; i < web.BlankSplitRows; i++ {
+			displaySplits = append(displaySplits, SplitDisplay{})
+		}
+	}
+
+	return displaySplits
+}
+
+func (c *TransactionDetailsComponent) formatSharing(ctx context.Context, project *models.Project, transaction *models.Transaction, currency string) (SharingDisplay, error) {
+	members, err := c.container.AccessRepository.GetByProjectID(ctx, project.ID)
+	if err != nil {
+		return SharingDisplay{}, fmt.Errorf("failed to get project members: %w", err)
+	}
+
+	sharing := SharingDisplay{Shareable: transaction.Type == models.Debit, Method: models.ShareEqual.String()}
+
+	shares := make(map[uuid.UUID]*models.ExpenseShare)
+	if expense, expenseShares, err := c.container.SharedExpenseRepository.GetByTransactionID(ctx, transaction.ID); err == nil {
+		sharing.Shared = true
+		sharing.PayerID = expense.PayerID.String()
+		sharing.Method = expense.Method.String()
+		for _, share := range expenseShares {
+			shares[share.AccessID] = share
+		}
+	}
+
+	for _, member := range members {
+		display := ShareMemberDisplay{ID: member.ID.String(), Name: member.Name, Included: !sharing.Shared}
+		if member.ID.String() == sharing.PayerID {
+			sharing.PayerName = member.Name
+		}
+
+		if share, ok := shares[member.ID]; ok {
+			display.Included = true
+			display.Amount = fmt.Sprintf("%.2f %s", share.Amount, currency)
+			switch sharing.Method {
+			case models.SharePercentage.String():
+				display.Value = strconv.FormatFloat(share.Percentage, 'f', -1, 64)
+			case models.ShareExact.String():
+				display.Value = fmt.Sprintf("%.2f", share.Amount)
+			}
+		}
+
+		sharing.Members = append(sharing.Members, display)
+	}
+
+	for _, method := range []struct {
+		method models.ShareMethod
+		label  string
+	}{
+		{models.ShareEqual, "Equally"},
+		{models.SharePercentage, "By percentage"},
+		{models.ShareExact, "Exact amounts"},
+	} {
+		sharing.Methods = append(sharing.Methods, SearchOption{
+			Value:    method.method.String(),
+			Label:    method.label,
+			Selected: method.method.String() == sharing.Method,
+		})
+	}
+
+	return sharing, nil
+}
+
+func formatFileSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
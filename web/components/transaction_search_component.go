@@ -0,0 +1,140 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"gofin/internal/container"
+	"gofin/internal/models"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	transactionSearchTemplateFile = "search_transactions.html"
+	transactionSearchBodyClass    = "dashboard-page"
+	transactionSearchTitle        = "Search Transactions"
+)
+
+type SearchAccountOption struct {
+	ID       string
+	Name     string
+	Currency string
+	Selected bool
+}
+
+type SearchOption struct {
+	Value    string
+	Label    string
+	Selected bool
+}
+
+type TransactionSearchComponent struct {
+	container *container.Container
+	template  *pageTemplate
+}
+
+func NewTransactionSearchComponent(container *container.Container, assets *web.Assets) (*TransactionSearchComponent, error) {
+	tmpl, err := parsePageTemplate(assets, transactionSearchTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transaction search template: %w", err)
+	}
+
+	return &TransactionSearchComponent{
+		container: container,
+		template:  tmpl,
+	}, nil
+}
+
+// RenderSearch renders the search form filled from the request's query string
+// together with one page of results. The next page link keeps every filter and
+// only swaps the cursor.
+func (c *TransactionSearchComponent) RenderSearch(w http.ResponseWriter, r *http.Request, project *models.Project, page *models.TransactionPage, errorMsg string) {
+	accounts, err := c.container.AccountRepository.GetByProjectID(r.Context(), project.ID)
+	if err != nil {
+		webhelpers.ServerError(w, r, "Failed to get project accounts", err)
+		return
+	}
+
+	params := r.URL.Query()
+	accountsByID := make(map[string]*models.Account, len(accounts))
+	accountOptions := make([]SearchAccountOption, 0, len(accounts))
+	for _, account := range accounts {
+		accountsByID[account.ID.String()] = account
+		accountOptions = append(accountOptions, SearchAccountOption{
+			ID:       account.ID.String(),
+			Name:     account.Name,
+			Currency: account.Currency.String(),
+			Selected: slices.Contains(params[web.SearchParamAccount], account.ID.String()),
+		})
+	}
+
+	var transactions []TransactionDisplay
+	var nextURL string
+	if page != nil {
+		for _, transaction := range page.Transactions {
+			if account, ok := accountsByID[transaction.AccountID.String()]; ok {
+				transactions = append(transactions, newTransactionDisplay(transaction, account))
+			}
+		}
+
+		if page.NextCursor != "" {
+			next := r.URL.Query()
+			next.Set(web.SearchParamCursor, page.NextCursor)
+			nextURL = "?" + next.Encode()
+		}
+	}
+
+	data := struct {
+		PageData
+		ProjectSlug  string
+		ErrorMsg     string
+		Search       string
+		MinValue     string
+		MaxValue     string
+		From         string
+		To           string
+		Types        []SearchOption
+		SortFields   []SearchOption
+		Directions   []SearchOption
+		Accounts     []SearchAccountOption
+		Transactions []TransactionDisplay
+		Searched     bool
+		NextURL      string
+		ExportLinks  []ExportLink
+	}{
+		PageData:     newPageData(r, transactionSearchTitle, transactionSearchBodyClass),
+		ProjectSlug:  project.Slug,
+		ErrorMsg:     errorMsg,
+		Search:       params.Get(web.SearchParamQuery),
+		MinValue:     params.Get(web.SearchParamMinValue),
+		MaxValue:     params.Get(web.SearchParamMaxValue),
+		From:         params.Get(web.SearchParamFrom),
+		To:           params.Get(web.SearchParamTo),
+		Types:        c.options(params.Get(web.SearchParamType), []SearchOption{{Value: "", Label: "Any type"}, {Value: models.Debit.String(), Label: "Debit"}, {Value: models.TopUp.String(), Label: "Top Up"}}),
+		SortFields:   c.options(params.Get(web.SearchParamSort), []SearchOption{{Value: string(models.SortByDate), Label: "Date"}, {Value: string(models.SortByValue), Label: "Amount"}, {Value: string(models.SortByName), Label: "Name"}}),
+		Directions:   c.options(params.Get(web.SearchParamDirection), []SearchOption{{Value: string(models.SortDescending), Label: "Descending"}, {Value: string(models.SortAscending), Label: "Ascending"}}),
+		Accounts:     accountOptions,
+		Transactions: transactions,
+		Searched:     page != nil,
+		NextURL:      nextURL,
+	}
+
+	if page != nil {
+		filters := r.URL.Query()
+		filters.Del(web.SearchParamCursor)
+		data.ExportLinks = exportLinks(r, project.Slug, web.RouteExportTransactions, filters)
+	}
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render transaction search", err)
+	}
+}
+
+func (c *TransactionSearchComponent) options(selected string, options []SearchOption) []SearchOption {
+	for i := range options {
+		options[i].Selected = options[i].Value == selected
+	}
+	return options
+}
@@ -0,0 +1,64 @@
+package components
+
+import (
+	"fmt"
+	"net/http"
+
+	"gofin/internal/cases/enroll_two_factor"
+	"gofin/internal/container"
+	"gofin/internal/models"
+	webhelpers "gofin/pkg/web"
+	"gofin/web"
+)
+
+const (
+	twoFactorTemplateFile = "two_factor.html"
+	twoFactorBodyClass    = "dashboard-page"
+	twoFactorTitle        = "Two-Factor Authentication"
+)
+
+type TwoFactorComponent struct {
+	container *container.Container
+	template  *pageTemplate
+}
+
+func NewTwoFactorComponent(container *container.Container, assets *web.Assets) (*TwoFactorComponent, error) {
+	tmpl, err := parsePageTemplate(assets, twoFactorTemplateFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse two-factor template: %w", err)
+	}
+
+	return &TwoFactorComponent{
+		container: container,
+		template:  tmpl,
+	}, nil
+}
+
+func (c *TwoFactorComponent) RenderTwoFactorPage(w http.ResponseWriter, r *http.Request, project *models.Project, access *models.Access, enrollment *enroll_two_factor.EnrollmentData, recoveryCodes []string, errorMsg string) {
+	data := struct {
+		PageData
+		ProjectSlug     string
+		Enabled         bool
+		Required        bool
+		Secret          string
+		ProvisioningURI string
+		RecoveryCodes   []string
+		ErrorMsg        string
+	}{
+		PageData:      newPageData(r, twoFactorTitle, twoFactorBodyClass),
+		ProjectSlug:   project.Slug,
+		Enabled:       access.TOTPEnabled,
+		Required:      access.RequiresTwoFactor(project),
+		RecoveryCodes: recoveryCodes,
+		ErrorMsg:      errorMsg,
+	}
+
+	if enrollment != nil {
+		data.Secret = enrollment.Secret
+		data.ProvisioningURI = enrollment.ProvisioningURI
+	}
+
+	if err := c.template.Execute(w, data); err != nil {
+		webhelpers.ServerError(w, r, "Failed to render two-factor page", err)
+	}
+}
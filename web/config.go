@@ -1,24 +1,169 @@
 package web
 
 const (
-	RouteLogin             = "/login"
-	RouteLogout            = "/logout"
-	RouteDashboard         = "/dashboard"
-	RouteCreateTransaction = "/transactions/create"
-	RouteDeleteTransaction = "/transactions/delete"
-	RouteCreateAccount     = "/accounts/create"
-	RouteStatic            = "/static/*"
+	RouteLogin              = "/login"
+	RouteLoginTwoFactor     = "/login/2fa"
+	RouteLogout             = "/logout"
+	RouteDashboard          = "/dashboard"
+	RouteCreateTransaction  = "/transactions/create"
+	RouteDeleteTransaction  = "/transactions/delete"
+	RouteSearchTransaction  = "/transactions/search"
+	RouteImportStatement    = "/transactions/import"
+	RouteConfirmImport      = "/transactions/import/confirm"
+	RouteTransaction        = "/transactions/{transactionID}"
+	RouteTransactionNotes   = "/transactions/{transactionID}/notes"
+	RouteTransactionSplits  = "/transactions/{transactionID}/splits"
+	RouteShareTransaction   = "/transactions/{transactionID}/share"
+	RouteUnshareTransaction = "/transactions/{transactionID}/share/delete"
+	RouteTransactionPayee   = "/transactions/{transactionID}/payee"
+	RouteTransactionStatus  = "/transactions/{transactionID}/status"
+	RouteUploadAttachment   = "/transactions/{transactionID}/attachments"
+	RouteAttachment         = "/attachments/{attachmentID}"
+	RouteAttachmentThumb    = "/attachments/{attachmentID}/thumbnail"
+	RouteDeleteAttachment   = "/attachments/{attachmentID}/delete"
+	RouteCreateAccount      = "/accounts/create"
+	RouteTransfer           = "/accounts/transfer"
+	RouteAccounts           = "/accounts"
+	RouteAccount            = "/accounts/{accountID}"
+	RouteArchiveAccount     = "/accounts/{accountID}/archive"
+	RouteUnarchiveAccount   = "/accounts/{accountID}/unarchive"
+	RouteMoveAccount        = "/accounts/{accountID}/move"
+	RouteAccountStatements  = "/accounts/{accountID}/statements"
+	RouteReconcile          = "/accounts/{accountID}/reconcile"
+	RouteReconciliation     = "/accounts/{accountID}/reconcile/{reconciliationID}"
+	RouteCancelReconcile    = "/accounts/{accountID}/reconcile/{reconciliationID}/cancel"
+	RouteLoans              = "/loans"
+	RouteLoan               = "/loans/{accountID}"
+	RouteLoanRates          = "/loans/{accountID}/rates"
+	RouteDeleteLoanRate     = "/loans/{accountID}/rates/{periodID}/delete"
+	RouteLoanPayments       = "/loans/{accountID}/payments"
+	RouteInvestments        = "/investments"
+	RouteInvestment         = "/investments/{accountID}"
+	RouteInvestmentOps      = "/investments/{accountID}/operations"
+	RouteSecurityPrices     = "/investments/{accountID}/prices"
+	RouteImportPrices       = "/investments/{accountID}/prices/import"
+	RouteCategories         = "/categories"
+	RoutePayees             = "/payees"
+	RoutePayee              = "/payees/{payeeID}"
+	RoutePayeeAliases       = "/payees/{payeeID}/aliases"
+	RouteDeletePayeeAlias   = "/payees/{payeeID}/aliases/{aliasID}/delete"
+	RoutePayeeCategory      = "/payees/{payeeID}/category"
+	RouteMergePayee         = "/payees/{payeeID}/merge"
+	RouteBalances           = "/balances"
+	RouteSettle             = "/balances/settle"
+	RoutePeriods            = "/periods"
+	RouteReports            = "/reports"
+	RouteReportsData        = "/reports/data"
+	RouteExportTransactions = "/export/transactions"
+	RouteExportBalances     = "/export/balances"
+	RouteExportReport       = "/export/report"
+	RouteTwoFactor          = "/security/2fa"
+	RouteDisableTwoFactor   = "/security/2fa/disable"
+	RouteStatic             = "/static/*"
+	RouteHealthz            = "/healthz"
+	RouteReadyz             = "/readyz"
+	RouteMetrics            = "/metrics"
 
-	TemplatesDir = "web/templates"
-	BaseTemplate = "web/templates/base.html"
+	TemplatesDir = "templates"
+	BaseTemplate = "templates/base.html"
 
 	SessionTokenCookie = "session_token"
-	DatabaseFile       = "database.db"
+	CSRFTokenCookie    = "csrf_token"
+	TwoFactorCookie    = "pending_2fa"
+	CSRFFormField      = "csrf_token"
+	CSRFHeader         = "X-CSRF-Token"
+	RequestIDHeader    = "X-Request-ID"
+
+	TransactionIDParam  = "transactionID"
+	AttachmentIDParam   = "attachmentID"
+	PayeeIDParam        = "payeeID"
+	AliasIDParam        = "aliasID"
+	AccountIDParam      = "accountID"
+	PeriodIDParam       = "periodID"
+	ReconcileIDParam    = "reconciliationID"
+	AttachmentFormField = "file"
+
+	CategoryFormField      = "category_id"
+	SplitCategoryFormField = "split_category_id"
+	SplitAmountFormField   = "split_amount"
+	SplitMemoFormField     = "split_memo"
+
+	PayerFormField       = "payer_id"
+	ShareMethodFormField = "method"
+	ShareMemberFormField = "share_member"
+	ShareValueFormField  = "share_value_"
+
+	PayeeFormField       = "payee_id"
+	AliasFormField       = "alias"
+	AliasesFormField     = "aliases"
+	MergeTargetFormField = "target_payee_id"
+
+	AccountTypeFormField        = "type"
+	AccountCurrencyFormField    = "currency"
+	AccountDescriptionFormField = "description"
+	CreditLimitFormField        = "credit_limit"
+	StatementDayFormField       = "statement_day"
+	DueDayFormField             = "due_day"
+	MoveDirectionFormField      = "direction"
+	MoveDirectionUp             = "up"
+	MoveDirectionDown           = "down"
+
+	PrincipalFormField   = "principal"
+	AnnualRateFormField  = "annual_rate"
+	TermMonthsFormField  = "term_months"
+	PaymentDayFormField  = "payment_day"
+	StartDateFormField   = "start_date"
+	StartsOnFormField    = "starts_on"
+	AmountFormField      = "amount"
+	FromAccountFormField = "from_account_id"
+	ToAccountFormField   = "to_account_id"
+	PaymentDateFormField = "date"
+
+	TickerFormField        = "ticker"
+	SecurityNameFormField  = "security_name"
+	OperationTypeFormField = "operation_type"
+	QuantityFormField      = "quantity"
+	PriceFormField         = "price"
+	FeesFormField          = "fees"
+	PriceFileFormField     = "file"
+
+	StatusFormField           = "status"
+	StatementDateFormField    = "statement_date"
+	StatementBalanceFormField = "statement_balance"
+	ClearedFormField          = "cleared"
+	ReconcileActionFormField  = "action"
+	ReconcileActionFinish     = "finish"
+
+	CloseMonthFormField = "month"
+	CloseYearFormField  = "year"
+
+	ReportFromParam     = "from"
+	ReportToParam       = "to"
+	ReportCurrencyParam = "currency"
+
+	ExportFormatParam = "format"
+	ExportAsOfParam   = "as_of"
+
+	StatementFileFormField = "file"
+	ImportAccountFormField = "account_id"
+	ImportIncludeFormField = "groups[%d].include"
+
+	// BlankSplitRows is how many empty split lines the transaction page offers on top
+	// of the ones already saved.
+	BlankSplitRows = 3
+
+	// MultipartMemory is how much of a multipart body is kept in memory before the
+	// rest spills to temporary files.
+	MultipartMemory = 8 << 20
+
+	// RequestBodyOverhead leaves room for form fields and multipart framing on top
+	// of the largest accepted attachment.
+	RequestBodyOverhead = 1 << 20
 
-	CookiePath        = "/"
-	CookieMaxAge      = 86400
 	CookieMaxAgeClear = -1
 
+	TwoFactorCookieMaxAge = 300
+
 	ContextAccessID    = "accessID"
 	ContextProjectID   = "projectID"
 	ContextProjectSlug = "projectSlug"
@@ -30,16 +175,97 @@ const (
 	AccessIDNotFoundError    = "Access ID not found"
 	ProjectNotFoundError     = "Project not found"
 	AccessNotFoundError      = "Access not found"
+	CSRFTokenInvalidError    = "Invalid or missing CSRF token"
+	RequestTooLargeError     = "Request body too large"
 
 	SuccessTransactionsCreated = "Transactions created successfully!"
 	SuccessLoginSuccessful     = "Login successful!"
 	SuccessTransactionDeleted  = "Transaction deleted successfully!"
+	SuccessTwoFactorDisabled   = "Two-factor authentication disabled."
+	SuccessNotesUpdated        = "Notes saved."
+	SuccessAttachmentUploaded  = "Attachment uploaded."
+	SuccessAttachmentDeleted   = "Attachment deleted."
+	SuccessCategoriesUpdated   = "Categories saved."
+	SuccessCategoryCreated     = "Category created."
+	SuccessExpenseShared       = "Shared expense saved."
+	SuccessExpenseUnshared     = "Transaction is no longer shared."
+	SuccessSettlementRecorded  = "Settlement recorded."
+	SuccessTransferRecorded    = "Transfer recorded."
+	SuccessPayeeCreated        = "Payee created."
+	SuccessPayeeUpdated        = "Payee saved."
+	SuccessPayeesMerged        = "Payees merged."
+	SuccessAccountCreated      = "Account created."
+	SuccessAccountUpdated      = "Account saved."
+	SuccessAccountArchived     = "Account archived."
+	SuccessAccountUnarchived   = "Account restored."
+	SuccessLoanCreated         = "Loan created."
+	SuccessLoanUpdated         = "Loan rates saved."
+	SuccessLoanPaymentRecorded = "Loan payment recorded."
+	SuccessOperationRecorded   = "Investment operation recorded."
+	SuccessPriceSaved          = "Price saved."
+	SuccessPricesImported      = "Prices imported."
+	SuccessStatusUpdated       = "Status saved."
+	SuccessReconcileStarted    = "Reconciliation started."
+	SuccessReconcileSaved      = "Reconciliation saved."
+	SuccessReconcileFinished   = "Reconciliation finished. Its transactions are now locked."
+	SuccessReconcileCancelled  = "Reconciliation cancelled."
+	SuccessPeriodClosed        = "Period closed."
+	SuccessStatementImported   = "Statement imported."
 
 	SuccessKeyTransactionsCreated = "transactions_created"
 	SuccessKeyLoginSuccessful     = "login_successful"
 	SuccessKeyTransactionDeleted  = "transaction_deleted"
+	SuccessKeyTwoFactorDisabled   = "two_factor_disabled"
+	SuccessKeyNotesUpdated        = "notes_updated"
+	SuccessKeyAttachmentUploaded  = "attachment_uploaded"
+	SuccessKeyAttachmentDeleted   = "attachment_deleted"
+	SuccessKeyCategoriesUpdated   = "categories_updated"
+	SuccessKeyCategoryCreated     = "category_created"
+	SuccessKeyExpenseShared       = "expense_shared"
+	SuccessKeyExpenseUnshared     = "expense_unshared"
+	SuccessKeySettlementRecorded  = "settlement_recorded"
+	SuccessKeyTransferRecorded    = "transfer_recorded"
+	SuccessKeyPayeeCreated        = "payee_created"
+	SuccessKeyPayeeUpdated        = "payee_updated"
+	SuccessKeyPayeesMerged        = "payees_merged"
+	SuccessKeyAccountCreated      = "account_created"
+	SuccessKeyAccountUpdated      = "account_updated"
+	SuccessKeyAccountArchived     = "account_archived"
+	SuccessKeyAccountUnarchived   = "account_unarchived"
+	SuccessKeyLoanCreated         = "loan_created"
+	SuccessKeyLoanUpdated         = "loan_updated"
+	SuccessKeyLoanPaymentRecorded = "loan_payment_recorded"
+	SuccessKeyOperationRecorded   = "operation_recorded"
+	SuccessKeyPriceSaved          = "price_saved"
+	SuccessKeyPricesImported      = "prices_imported"
+	SuccessKeyStatusUpdated       = "status_updated"
+	SuccessKeyReconcileStarted    = "reconcile_started"
+	SuccessKeyReconcileSaved      = "reconcile_saved"
+	SuccessKeyReconcileFinished   = "reconcile_finished"
+	SuccessKeyReconcileCancelled  = "reconcile_cancelled"
+	SuccessKeyPeriodClosed        = "period_closed"
+	SuccessKeyStatementImported   = "statement_imported"
 
 	SuccessQueryParam = "success"
 
-	StaticDir = "web/static"
+	SearchParamQuery     = "q"
+	SearchParamMinValue  = "min"
+	SearchParamMaxValue  = "max"
+	SearchParamType      = "type"
+	SearchParamAccount   = "account"
+	SearchParamSort      = "sort"
+	SearchParamDirection = "dir"
+	SearchParamCursor    = "cursor"
+	SearchParamFrom      = "from"
+	SearchParamTo        = "to"
+
+	SimulateParamAmount = "prepay"
+	SimulateParamDate   = "prepay_date"
+	SimulateParamMode   = "prepay_mode"
+	SimulateModePayment = "payment"
+	SimulateModeTerm    = "term"
+
+	CostMethodParam = "method"
+
+	StaticDir = "static"
 )
@@ -7,7 +7,13 @@ document.addEventListener('alpine:init', () => {
             if (confirm('Are you sure you want to delete this transaction?')) {
                 const form = document.createElement('form');
                 form.method = 'POST';
-                form.action = '/' + this.projectSlug + this.deleteRoute + '?id=' + transactionId;
+                form.action = getBasePath() + '/' + this.projectSlug + this.deleteRoute + '?id=' + transactionId;
+
+                const csrfInput = document.createElement('input');
+                csrfInput.type = 'hidden';
+                csrfInput.name = 'csrf_token';
+                csrfInput.value = getCSRFToken();
+                form.appendChild(csrfInput);
 
                 document.body.appendChild(form);
                 form.submit();
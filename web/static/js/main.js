@@ -1,3 +1,13 @@
+function getBasePath() {
+    const meta = document.querySelector('meta[name="base-path"]');
+    return meta ? meta.getAttribute('content') : '';
+}
+
+function getCSRFToken() {
+    const meta = document.querySelector('meta[name="csrf-token"]');
+    return meta ? meta.getAttribute('content') : '';
+}
+
 document.addEventListener('DOMContentLoaded', function () {
     const digitInputs = document.querySelectorAll('input[type="text"]');
 
@@ -0,0 +1,23 @@
+document.addEventListener('alpine:init', () => {
+    Alpine.data('reconcile', () => ({
+        statement: 0,
+        reconciled: 0,
+        difference: 0,
+
+        recount() {
+            let cleared = 0;
+            this.$root.querySelectorAll('input[name="cleared"]:checked').forEach((input) => {
+                cleared += parseFloat(input.dataset.amount);
+            });
+            this.difference = Math.round((this.statement - this.reconciled - cleared) * 100) / 100;
+        },
+
+        formatted() {
+            return this.difference.toFixed(2);
+        },
+
+        balanced() {
+            return Math.abs(this.difference) < 0.005;
+        }
+    }));
+});
@@ -67,7 +67,9 @@ document.addEventListener('alpine:init', () => {
                 { selector: 'input[name*="value"]', name: `groups[${index}].value`, id: `value_${index}` },
                 { selector: 'select[name*="type"]', name: `groups[${index}].type`, id: `type_${index}` },
                 { selector: 'select[name*="account_id"]', name: `groups[${index}].account_id`, id: `account_${index}` },
-                { selector: 'input[name*="date"]', name: `groups[${index}].date`, id: `date_${index}` }
+                { selector: 'select[name*="category_id"]', name: `groups[${index}].category_id`, id: `category_${index}`, optional: true },
+                { selector: 'input[name*="date"]', name: `groups[${index}].date`, id: `date_${index}` },
+                { selector: 'textarea[name*="notes"]', name: `groups[${index}].notes`, id: `notes_${index}`, optional: true }
             ];
 
             fields.forEach(field => {
@@ -77,8 +79,8 @@ document.addEventListener('alpine:init', () => {
                     element.id = field.id;
                     element.value = '';
 
-                    if (field.selector.includes('name') || field.selector.includes('value') ||
-                        field.selector.includes('type') || field.selector.includes('account_id')) {
+                    if (!field.optional && (field.selector.includes('name') || field.selector.includes('value') ||
+                        field.selector.includes('type') || field.selector.includes('account_id'))) {
                         element.required = true;
                     }
 
@@ -210,11 +212,13 @@ document.addEventListener('alpine:init', () => {
             }
 
             try {
-                const projectSlug = window.location.pathname.split('/')[1];
-                const response = await fetch(`/${projectSlug}/accounts/create`, {
+                const basePath = getBasePath();
+                const projectSlug = window.location.pathname.substring(basePath.length).split('/')[1];
+                const response = await fetch(`${basePath}/${projectSlug}/accounts/create`, {
                     method: 'POST',
                     headers: {
                         'Content-Type': 'application/json',
+                        'X-CSRF-Token': getCSRFToken(),
                     },
                     body: JSON.stringify({
                         name: name,
@@ -0,0 +1,10 @@
+function renderQRCode(element, uri) {
+    if (!uri || typeof qrcode === 'undefined') {
+        return;
+    }
+
+    const qr = qrcode(0, 'M');
+    qr.addData(uri);
+    qr.make();
+    element.innerHTML = qr.createSvgTag({ cellSize: 4, margin: 2 });
+}